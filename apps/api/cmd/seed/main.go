@@ -88,6 +88,9 @@ func main() {
 		"imports.read":      "Read import run reports and downloads",
 		"imports.write":     "Run import dry-runs and apply imports",
 		"exports.read":      "Download tenant data exports",
+		"users.manage":      "Create, deactivate and reset passwords for tenant users",
+		"roles.manage":      "Manage roles, role permissions and user role assignments",
+		"settings.manage":   "Manage tenant settings such as the MFA policy",
 	}
 
 	for perm, description := range permissionDescriptions {
@@ -106,7 +109,7 @@ func main() {
 	}{
 		"admin": {
 			description: "Tenant administrator",
-			permissions: []string{"customers.read", "customers.write", "estimates.read", "estimates.write", "estimates.convert", "calendar.read", "calendar.write", "jobs.read", "jobs.write", "storage.read", "storage.write", "imports.read", "imports.write", "exports.read"},
+			permissions: []string{"customers.read", "customers.write", "estimates.read", "estimates.write", "estimates.convert", "calendar.read", "calendar.write", "jobs.read", "jobs.write", "storage.read", "storage.write", "imports.read", "imports.write", "exports.read", "users.manage", "roles.manage", "settings.manage"},
 		},
 		"sales": {
 			description: "Sales role",
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
@@ -10,9 +11,12 @@ import (
 	"time"
 
 	"github.com/moveops-platform/apps/api/internal/app"
+	"github.com/moveops-platform/apps/api/internal/audit"
 	"github.com/moveops-platform/apps/api/internal/config"
 	"github.com/moveops-platform/apps/api/internal/db"
 	gen "github.com/moveops-platform/apps/api/internal/gen/db"
+	"github.com/moveops-platform/apps/api/internal/mail"
+	"github.com/moveops-platform/apps/api/internal/outbox"
 )
 
 func main() {
@@ -23,6 +27,12 @@ func main() {
 		logger.Error("load config", "error", err)
 		os.Exit(1)
 	}
+	if cfg.MailFromAddress != "" {
+		if _, err := mail.NormalizeAddress(cfg.MailFromAddress); err != nil {
+			logger.Error("load config", "error", fmt.Errorf("MAIL_FROM_ADDRESS: %w", err))
+			os.Exit(1)
+		}
+	}
 
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
@@ -50,6 +60,26 @@ func main() {
 		IdleTimeout:       cfg.IdleTimeout,
 	}
 
+	if cfg.SMTPHost != "" {
+		tlsMode, err := mail.ParseTLSMode(cfg.SMTPTLS)
+		if err != nil {
+			logger.Error("load config", "error", fmt.Errorf("SMTP_TLS: %w", err))
+			os.Exit(1)
+		}
+		sender := &mail.SMTPSender{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			TLS:      tlsMode,
+		}
+		worker := outbox.NewWorker(queries, sender, audit.NewLogger(queries), logger, cfg.MailMaxAttempts)
+		worker.Interval = cfg.MailWorkerInterval
+		go worker.Run(ctx)
+	} else {
+		logger.Warn("SMTP_HOST is not set; queued email will not be delivered")
+	}
+
 	go func() {
 		logger.Info("api_started", "addr", cfg.Addr)
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -0,0 +1,85 @@
+package acceptance
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestLinkTokenRoundTripAndTampering(t *testing.T) {
+	signer := NewSigner([]byte("test-key-0123456789abcdef0123456789"))
+	linkID := uuid.New()
+	expiresAt := time.Date(2026, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	token := signer.LinkToken(linkID, expiresAt)
+	if got, ok := LinkID(token); !ok || got != linkID {
+		t.Fatalf("expected link id %s from %q, got %s", linkID, token, got)
+	}
+	if !signer.VerifyLinkToken(token, linkID, expiresAt) {
+		t.Fatalf("expected the token to verify")
+	}
+	if signer.VerifyLinkToken(token, linkID, expiresAt.Add(24*time.Hour)) {
+		t.Fatalf("expected an extended expiry to invalidate the token")
+	}
+	if signer.VerifyLinkToken(token, uuid.New(), expiresAt) {
+		t.Fatalf("expected another link id to fail")
+	}
+	if NewSigner([]byte("another-key-0123456789abcdef01234")).VerifyLinkToken(token, linkID, expiresAt) {
+		t.Fatalf("expected another key to fail")
+	}
+	forged := signer.LinkToken(uuid.New(), expiresAt)
+	_, mac, _ := strings.Cut(forged, ".")
+	encodedID, _, _ := strings.Cut(token, ".")
+	if signer.VerifyLinkToken(encodedID+"."+mac, linkID, expiresAt) {
+		t.Fatalf("expected a MAC from another link to fail")
+	}
+
+	for _, bad := range []string{"", "abc", "abc.def", "!!!.abc"} {
+		if _, ok := LinkID(bad); ok {
+			t.Fatalf("expected %q to be rejected", bad)
+		}
+	}
+}
+
+func TestSignatureVerifiesOnlyUnchangedRecords(t *testing.T) {
+	signer := NewSigner([]byte("test-key-0123456789abcdef0123456789"))
+	sig := Signature{
+		ID:             uuid.New(),
+		TenantID:       uuid.New(),
+		EstimateID:     uuid.New(),
+		RevisionNumber: 4,
+		DocumentSHA256: strings.Repeat("ab", 32),
+		SignerName:     "Ada Lovelace",
+		SignerIP:       "203.0.113.7",
+		UserAgent:      "Mozilla/5.0",
+		SignedAt:       time.Date(2026, 3, 2, 15, 4, 5, 123456789, time.UTC),
+	}
+	mac := signer.Sign(sig)
+
+	// Postgres keeps microseconds; the record read back must still verify.
+	stored := sig
+	stored.SignedAt = sig.SignedAt.Truncate(time.Microsecond).In(time.FixedZone("EST", -5*3600))
+	if !signer.Verify(stored, mac) {
+		t.Fatalf("expected the stored record to verify")
+	}
+
+	changes := map[string]func(*Signature){
+		"name":     func(s *Signature) { s.SignerName = "Ada Byron" },
+		"ip":       func(s *Signature) { s.SignerIP = "198.51.100.1" },
+		"revision": func(s *Signature) { s.RevisionNumber = 5 },
+		"document": func(s *Signature) { s.DocumentSHA256 = strings.Repeat("cd", 32) },
+		"time":     func(s *Signature) { s.SignedAt = s.SignedAt.Add(time.Second) },
+	}
+	for name, change := range changes {
+		tampered := sig
+		change(&tampered)
+		if signer.Verify(tampered, mac) {
+			t.Fatalf("expected a changed %s to fail verification", name)
+		}
+	}
+	if signer.Verify(sig, "not-hex") {
+		t.Fatalf("expected a malformed MAC to fail")
+	}
+}
@@ -3,6 +3,7 @@ package app
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -11,6 +12,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/textproto"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -19,9 +21,16 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/moveops-platform/apps/api/internal/acceptance"
+	"github.com/moveops-platform/apps/api/internal/audit"
 	"github.com/moveops-platform/apps/api/internal/auth"
 	"github.com/moveops-platform/apps/api/internal/config"
 	gen "github.com/moveops-platform/apps/api/internal/gen/db"
+	"github.com/moveops-platform/apps/api/internal/mail"
+	"github.com/moveops-platform/apps/api/internal/mail/mailtest"
+	"github.com/moveops-platform/apps/api/internal/oidc/oidctest"
+	"github.com/moveops-platform/apps/api/internal/outbox"
+	"golang.org/x/crypto/argon2"
 )
 
 func TestTenantIsolation(t *testing.T) {
@@ -42,6 +51,139 @@ func TestTenantIsolation(t *testing.T) {
 	}
 }
 
+func TestCustomerListAndUpdate(t *testing.T) {
+	env := setupTestEnv(t)
+	ctx := context.Background()
+
+	_, _ = seedTenantUser(t, ctx, env.pool, "tenant-customer-list-a", "Tenant Customer List A", "customer-list-a@example.com", "Password123!", []string{"customers.read", "customers.write"})
+	_, _ = seedTenantUser(t, ctx, env.pool, "tenant-customer-list-b", "Tenant Customer List B", "customer-list-b@example.com", "Password123!", []string{"customers.read", "customers.write"})
+
+	cookieA := login(t, env.router, "customer-list-a@example.com", "Password123!")
+	csrfA := csrfToken(t, env.router, cookieA)
+	adaID := createCustomer(t, env.router, cookieA, csrfA, "Ada", "Lovelace")
+	_ = createCustomer(t, env.router, cookieA, csrfA, "Grace", "Hopper")
+
+	status, body := request(t, env.router, http.MethodPatch, "/api/customers/"+adaID, []byte(`{"phone":"(512) 555-0199","email":"ada@example.com"}`), cookieA, csrfA)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for customer update, got %d (%s)", status, string(body))
+	}
+
+	status, body = request(t, env.router, http.MethodGet, "/api/customers?q=5125550199", nil, cookieA, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for customer search, got %d (%s)", status, string(body))
+	}
+	items := parseCustomerListIDs(t, body)
+	if len(items) != 1 || items[0] != adaID {
+		t.Fatalf("expected phone search to match Ada, got %v", items)
+	}
+
+	status, body = request(t, env.router, http.MethodGet, "/api/customers?limit=1", nil, cookieA, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for customer list, got %d (%s)", status, string(body))
+	}
+	if items := parseCustomerListIDs(t, body); len(items) != 1 {
+		t.Fatalf("expected a single customer with limit=1, got %d", len(items))
+	}
+
+	cookieB := login(t, env.router, "customer-list-b@example.com", "Password123!")
+	csrfB := csrfToken(t, env.router, cookieB)
+	status, body = request(t, env.router, http.MethodGet, "/api/customers?q=ada", nil, cookieB, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for cross-tenant customer list, got %d (%s)", status, string(body))
+	}
+	if items := parseCustomerListIDs(t, body); len(items) != 0 {
+		t.Fatalf("expected cross-tenant customer list to be empty, got %d", len(items))
+	}
+	status, _ = request(t, env.router, http.MethodPatch, "/api/customers/"+adaID, []byte(`{"firstName":"Mallory"}`), cookieB, csrfB)
+	if status != http.StatusNotFound {
+		t.Fatalf("expected 404 for cross-tenant customer update, got %d", status)
+	}
+}
+
+func TestCustomerMergeMovesEstimatesAndJobs(t *testing.T) {
+	env := setupTestEnv(t)
+	ctx := context.Background()
+
+	tenantID, _ := seedTenantUser(t, ctx, env.pool, "tenant-customer-merge", "Tenant Customer Merge", "customer-merge@example.com", "Password123!", []string{"customers.read", "customers.write", "estimates.read", "estimates.write", "estimates.convert", "jobs.read"})
+
+	cookie := login(t, env.router, "customer-merge@example.com", "Password123!")
+	csrf := csrfToken(t, env.router, cookie)
+	survivorID := createCustomer(t, env.router, cookie, csrf, "Integration", "Customer")
+
+	status, body := request(t, env.router, http.MethodPost, "/api/estimates", estimatePayload("Integration Customer"), cookie, csrf, withIdempotency("customer-merge-estimate"))
+	if status != http.StatusCreated {
+		t.Fatalf("create estimate expected 201, got %d (%s)", status, string(body))
+	}
+	estimateID := parseEstimateID(t, body)
+	duplicateID := parseEstimateCustomerID(t, body)
+	if duplicateID == survivorID {
+		t.Fatalf("expected estimate to create a separate customer")
+	}
+	jobID := convertEstimateToJob(t, env.router, cookie, csrf, estimateID, "customer-merge-convert")
+
+	payload := []byte(`{"sourceCustomerId":"` + duplicateID + `"}`)
+	status, body = request(t, env.router, http.MethodPost, "/api/customers/"+survivorID+"/merge", payload, cookie, csrf)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for customer merge, got %d (%s)", status, string(body))
+	}
+	var merge struct {
+		Customer struct {
+			Email string `json:"email"`
+		} `json:"customer"`
+		EstimatesMoved int64 `json:"estimatesMoved"`
+		JobsMoved      int64 `json:"jobsMoved"`
+	}
+	if err := json.Unmarshal(body, &merge); err != nil {
+		t.Fatalf("parse merge body: %v", err)
+	}
+	if merge.EstimatesMoved != 1 || merge.JobsMoved != 1 {
+		t.Fatalf("expected one estimate and one job moved, got %d/%d", merge.EstimatesMoved, merge.JobsMoved)
+	}
+	if merge.Customer.Email != "customer@example.com" {
+		t.Fatalf("expected survivor to inherit duplicate email, got %q", merge.Customer.Email)
+	}
+
+	status, body = request(t, env.router, http.MethodGet, "/api/estimates/"+estimateID, nil, cookie, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for estimate read, got %d (%s)", status, string(body))
+	}
+	if got := parseEstimateCustomerID(t, body); got != survivorID {
+		t.Fatalf("expected estimate to point at survivor %s, got %s", survivorID, got)
+	}
+
+	var jobCustomerID uuid.UUID
+	if err := env.pool.QueryRow(ctx, `SELECT customer_id FROM jobs WHERE id = $1`, jobID).Scan(&jobCustomerID); err != nil {
+		t.Fatalf("load job customer: %v", err)
+	}
+	if jobCustomerID.String() != survivorID {
+		t.Fatalf("expected job to point at survivor %s, got %s", survivorID, jobCustomerID)
+	}
+
+	status, _ = request(t, env.router, http.MethodGet, "/api/customers/"+duplicateID, nil, cookie, "")
+	if status != http.StatusNotFound {
+		t.Fatalf("expected merged customer to be gone, got %d", status)
+	}
+
+	var count int
+	if err := env.pool.QueryRow(ctx, `
+		SELECT COUNT(*)
+		FROM audit_log
+		WHERE tenant_id = $1
+		  AND action = 'customers.merge'
+		  AND entity_id = $2
+	`, tenantID, uuid.MustParse(survivorID)).Scan(&count); err != nil {
+		t.Fatalf("count customers.merge audit rows: %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("expected exactly 1 customers.merge audit row, got %d", count)
+	}
+
+	status, _ = request(t, env.router, http.MethodPost, "/api/customers/"+survivorID+"/merge", []byte(`{"sourceCustomerId":"`+survivorID+`"}`), cookie, csrf)
+	if status != http.StatusBadRequest {
+		t.Fatalf("expected 400 for self-merge, got %d", status)
+	}
+}
+
 func TestRBACDeniesRead(t *testing.T) {
 	env := setupTestEnv(t)
 	ctx := context.Background()
@@ -63,6 +205,46 @@ func TestRBACDeniesRead(t *testing.T) {
 	}
 }
 
+func TestAuthMeExposesPermissionSet(t *testing.T) {
+	env := setupTestEnv(t)
+	ctx := context.Background()
+
+	tenantID, userID := seedTenantUser(t, ctx, env.pool, "tenant-auth-perms", "Tenant Auth Perms", "auth-perms@example.com", "Password123!", []string{"jobs.read", "customers.read"})
+	_, otherRoleID := seedUserInTenant(t, ctx, env.pool, tenantID, "auth-perms-other@example.com", "Password123!", []string{"customers.read", "storage.read"})
+
+	status, body := request(t, env.router, http.MethodPost, "/api/auth/login", []byte(`{"email":"auth-perms@example.com","password":"Password123!"}`), nil, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for login, got %d (%s)", status, string(body))
+	}
+	if got := parseSessionPermissions(t, body); strings.Join(got, ",") != "customers.read,jobs.read" {
+		t.Fatalf("unexpected login permissions: %v", got)
+	}
+
+	cookie := login(t, env.router, "auth-perms@example.com", "Password123!")
+	status, _ = request(t, env.router, http.MethodGet, "/api/storage", nil, cookie, "")
+	if status != http.StatusForbidden {
+		t.Fatalf("expected 403 without storage.read, got %d", status)
+	}
+
+	// Permissions are loaded per request, so a role granted mid-session applies
+	// to the next request.
+	if _, err := env.pool.Exec(ctx, `INSERT INTO user_roles (user_id, role_id, tenant_id) VALUES ($1, $2, $3)`, userID, otherRoleID, tenantID); err != nil {
+		t.Fatalf("grant second role: %v", err)
+	}
+
+	status, body = request(t, env.router, http.MethodGet, "/api/auth/me", nil, cookie, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for auth/me, got %d (%s)", status, string(body))
+	}
+	if got := parseSessionPermissions(t, body); strings.Join(got, ",") != "customers.read,jobs.read,storage.read" {
+		t.Fatalf("unexpected auth/me permissions: %v", got)
+	}
+	status, _ = request(t, env.router, http.MethodGet, "/api/storage", nil, cookie, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 after storage.read grant, got %d", status)
+	}
+}
+
 func TestCSRFRequiredForCustomersCreate(t *testing.T) {
 	env := setupTestEnv(t)
 	ctx := context.Background()
@@ -113,7 +295,8 @@ func TestCSRFRequiredForLogout(t *testing.T) {
 }
 
 func TestLoginRateLimit(t *testing.T) {
-	env := setupTestEnv(t)
+	// Lockout is disabled so only the per-IP limiter reacts to the failures.
+	env := setupTestEnvWithConfig(t, func(cfg *config.Config) { cfg.LoginLockoutThreshold = 0 })
 	ctx := context.Background()
 
 	_, _ = seedTenantUser(t, ctx, env.pool, "tenant-rate-limit", "Tenant Rate Limit", "rate-limit@example.com", "Password123!", []string{"customers.read"})
@@ -134,168 +317,2722 @@ func TestLoginRateLimit(t *testing.T) {
 	}
 }
 
-func TestLogoutRevokesSession(t *testing.T) {
+func TestLoginLockoutAndAdminUnlock(t *testing.T) {
 	env := setupTestEnv(t)
 	ctx := context.Background()
 
-	_, _ = seedTenantUser(t, ctx, env.pool, "tenant-session", "Tenant Session", "session@example.com", "Password123!", []string{"customers.read", "customers.write"})
+	tenantID, _ := seedTenantUser(t, ctx, env.pool, "tenant-lockout", "Tenant Lockout", "lockout-admin@example.com", "Password123!", []string{"users.manage"})
+	victimID, _ := seedUserInTenant(t, ctx, env.pool, tenantID, "lockout-victim@example.com", "Password123!", []string{"customers.read"})
 
-	cookie := login(t, env.router, "session@example.com", "Password123!")
-	status, _ := request(t, env.router, http.MethodGet, "/api/auth/me", nil, cookie, "")
+	for i := 0; i < 3; i++ {
+		status, body := request(t, env.router, http.MethodPost, "/api/auth/login", []byte(`{"email":"lockout-victim@example.com","password":"wrong-password"}`), nil, "")
+		if status != http.StatusUnauthorized {
+			t.Fatalf("expected 401 for failed login %d, got %d (%s)", i+1, status, string(body))
+		}
+	}
+
+	// The correct password is refused while the account is locked.
+	status, body := request(t, env.router, http.MethodPost, "/api/auth/login", []byte(`{"email":"lockout-victim@example.com","password":"Password123!"}`), nil, "")
+	if status != http.StatusLocked || parseErrorCode(t, body) != "account_locked" {
+		t.Fatalf("expected 423 account_locked, got %d (%s)", status, string(body))
+	}
+
+	adminCookie := login(t, env.router, "lockout-admin@example.com", "Password123!")
+	adminCsrf := csrfToken(t, env.router, adminCookie)
+
+	status, body = request(t, env.router, http.MethodGet, "/api/users/"+victimID.String(), nil, adminCookie, "")
 	if status != http.StatusOK {
-		t.Fatalf("expected 200 before logout, got %d", status)
+		t.Fatalf("expected 200 for user read, got %d (%s)", status, string(body))
+	}
+	var locked struct {
+		User struct {
+			LockedUntil *time.Time `json:"lockedUntil"`
+		} `json:"user"`
+	}
+	if err := json.Unmarshal(body, &locked); err != nil {
+		t.Fatalf("parse user body: %v", err)
+	}
+	if locked.User.LockedUntil == nil || time.Until(*locked.User.LockedUntil) <= 0 || time.Until(*locked.User.LockedUntil) > time.Minute {
+		t.Fatalf("expected a lock of at most the base back-off, got %v", locked.User.LockedUntil)
 	}
 
-	csrf := csrfToken(t, env.router, cookie)
-	status, _ = request(t, env.router, http.MethodPost, "/api/auth/logout", nil, cookie, csrf)
-	if status != http.StatusNoContent {
-		t.Fatalf("expected 204 logout response, got %d", status)
+	status, body = request(t, env.router, http.MethodPost, "/api/users/"+victimID.String()+"/unlock", nil, adminCookie, adminCsrf)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for unlock, got %d (%s)", status, string(body))
 	}
+	_ = login(t, env.router, "lockout-victim@example.com", "Password123!")
 
-	status, _ = request(t, env.router, http.MethodGet, "/api/auth/me", nil, cookie, "")
-	if status != http.StatusUnauthorized {
-		t.Fatalf("expected 401 after logout, got %d", status)
+	var failedCount, lockedCount, unlockCount int
+	if err := env.pool.QueryRow(ctx, `
+		SELECT
+		  COUNT(*) FILTER (WHERE action = 'auth.login_failed'),
+		  COUNT(*) FILTER (WHERE action = 'auth.locked'),
+		  COUNT(*) FILTER (WHERE action = 'users.unlock')
+		FROM audit_log
+		WHERE tenant_id = $1
+		  AND entity_id = $2
+	`, tenantID, victimID).Scan(&failedCount, &lockedCount, &unlockCount); err != nil {
+		t.Fatalf("count lockout audit rows: %v", err)
+	}
+	if failedCount != 3 || lockedCount != 1 || unlockCount != 1 {
+		t.Fatalf("expected 3 failed, 1 locked and 1 unlock audit rows, got %d/%d/%d", failedCount, lockedCount, unlockCount)
 	}
 }
 
-func TestEstimateTenantIsolation(t *testing.T) {
+func TestTOTPEnrollmentLoginAndTenantPolicy(t *testing.T) {
 	env := setupTestEnv(t)
 	ctx := context.Background()
 
-	_, _ = seedTenantUser(t, ctx, env.pool, "tenant-estimate-a", "Tenant Estimate A", "estimate-a@example.com", "Password123!", []string{"estimates.read", "estimates.write"})
-	_, _ = seedTenantUser(t, ctx, env.pool, "tenant-estimate-b", "Tenant Estimate B", "estimate-b@example.com", "Password123!", []string{"estimates.read"})
+	tenantID, adminID := seedTenantUser(t, ctx, env.pool, "tenant-mfa", "Tenant MFA", "mfa-admin@example.com", "Password123!", []string{"settings.manage", "storage.write"})
+	_, _ = seedUserInTenant(t, ctx, env.pool, tenantID, "mfa-viewer@example.com", "Password123!", []string{"customers.read"})
 
-	cookieA := login(t, env.router, "estimate-a@example.com", "Password123!")
-	csrfA := csrfToken(t, env.router, cookieA)
-	estimateID := createEstimate(t, env.router, cookieA, csrfA, "idem-estimate-a")
+	adminCookie := login(t, env.router, "mfa-admin@example.com", "Password123!")
+	adminCsrf := csrfToken(t, env.router, adminCookie)
 
-	cookieB := login(t, env.router, "estimate-b@example.com", "Password123!")
-	status, _ := request(t, env.router, http.MethodGet, "/api/estimates/"+estimateID, nil, cookieB, "")
-	if status != http.StatusNotFound {
-		t.Fatalf("expected 404 for cross-tenant estimate read, got %d", status)
+	status, body := request(t, env.router, http.MethodPatch, "/api/settings", []byte(`{"requireMfaForSensitiveRoles":true}`), adminCookie, adminCsrf)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for settings update, got %d (%s)", status, string(body))
 	}
-}
 
-func TestEstimateTenantIsolationOnUpdate(t *testing.T) {
-	env := setupTestEnv(t)
-	ctx := context.Background()
+	// Holding storage.write now requires enrollment before anything but /auth.
+	status, body = request(t, env.router, http.MethodGet, "/api/settings", nil, adminCookie, "")
+	if status != http.StatusForbidden || parseErrorCode(t, body) != "mfa_enrollment_required" {
+		t.Fatalf("expected 403 mfa_enrollment_required, got %d (%s)", status, string(body))
+	}
+	status, body = request(t, env.router, http.MethodGet, "/api/auth/me", nil, adminCookie, "")
+	if status != http.StatusOK || !strings.Contains(string(body), `"mfaEnrollmentRequired":true`) {
+		t.Fatalf("expected /auth/me to report pending enrollment, got %d (%s)", status, string(body))
+	}
 
-	_, _ = seedTenantUser(t, ctx, env.pool, "tenant-estimate-update-a", "Tenant Estimate Update A", "estimate-update-a@example.com", "Password123!", []string{"estimates.read", "estimates.write"})
-	_, _ = seedTenantUser(t, ctx, env.pool, "tenant-estimate-update-b", "Tenant Estimate Update B", "estimate-update-b@example.com", "Password123!", []string{"estimates.read", "estimates.write"})
+	// Users without sensitive permissions are unaffected by the policy.
+	viewerCookie := login(t, env.router, "mfa-viewer@example.com", "Password123!")
+	status, body = request(t, env.router, http.MethodGet, "/api/customers", nil, viewerCookie, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for viewer without MFA, got %d (%s)", status, string(body))
+	}
 
-	cookieA := login(t, env.router, "estimate-update-a@example.com", "Password123!")
-	csrfA := csrfToken(t, env.router, cookieA)
-	estimateID := createEstimate(t, env.router, cookieA, csrfA, "idem-estimate-update-a")
+	status, body = request(t, env.router, http.MethodPost, "/api/auth/mfa/totp/enroll", nil, adminCookie, adminCsrf)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for enroll, got %d (%s)", status, string(body))
+	}
+	var enrollment struct {
+		Secret          string `json:"secret"`
+		ProvisioningURI string `json:"provisioningUri"`
+	}
+	if err := json.Unmarshal(body, &enrollment); err != nil {
+		t.Fatalf("parse enrollment body: %v", err)
+	}
+	if enrollment.Secret == "" || !strings.HasPrefix(enrollment.ProvisioningURI, "otpauth://totp/") {
+		t.Fatalf("unexpected enrollment payload: %s", string(body))
+	}
 
-	cookieB := login(t, env.router, "estimate-update-b@example.com", "Password123!")
-	csrfB := csrfToken(t, env.router, cookieB)
-	status, body := request(t, env.router, http.MethodPatch, "/api/estimates/"+estimateID, estimateUpdatePayload("Cross Tenant Attempt"), cookieB, csrfB)
-	if status != http.StatusNotFound {
-		t.Fatalf("expected 404 for cross-tenant estimate update, got %d (%s)", status, string(body))
+	status, body = request(t, env.router, http.MethodPost, "/api/auth/mfa/totp/confirm", []byte(`{"code":"000000"}`), adminCookie, adminCsrf)
+	if status != http.StatusBadRequest || parseErrorCode(t, body) != "invalid_mfa_code" {
+		t.Fatalf("expected 400 invalid_mfa_code, got %d (%s)", status, string(body))
 	}
-}
 
-func TestEstimateRBACForCreateAndConvert(t *testing.T) {
-	env := setupTestEnv(t)
-	ctx := context.Background()
+	step := auth.TOTPStep(time.Now())
+	status, body = request(t, env.router, http.MethodPost, "/api/auth/mfa/totp/confirm", totpPayload(t, "code", enrollment.Secret, step), adminCookie, adminCsrf)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for confirm, got %d (%s)", status, string(body))
+	}
+	var recovery struct {
+		RecoveryCodes []string `json:"recoveryCodes"`
+	}
+	if err := json.Unmarshal(body, &recovery); err != nil {
+		t.Fatalf("parse recovery codes: %v", err)
+	}
+	if len(recovery.RecoveryCodes) != 10 {
+		t.Fatalf("expected 10 recovery codes, got %d", len(recovery.RecoveryCodes))
+	}
 
-	tenantID, _ := seedTenantUser(t, ctx, env.pool, "tenant-rbac-est", "Tenant RBAC Est", "est-admin@example.com", "Password123!", []string{"estimates.read", "estimates.write", "estimates.convert"})
-	_, _ = seedUserInTenant(t, ctx, env.pool, tenantID, "est-reader@example.com", "Password123!", []string{"estimates.read"})
-	_, _ = seedUserInTenant(t, ctx, env.pool, tenantID, "est-writer@example.com", "Password123!", []string{"estimates.read", "estimates.write"})
+	status, body = request(t, env.router, http.MethodGet, "/api/settings", nil, adminCookie, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for settings after enrollment, got %d (%s)", status, string(body))
+	}
 
-	readerCookie := login(t, env.router, "est-reader@example.com", "Password123!")
-	readerCsrf := csrfToken(t, env.router, readerCookie)
-	status, _ := request(t, env.router, http.MethodPost, "/api/estimates", estimatePayload("Reader Blocked"), readerCookie, readerCsrf, withIdempotency("idem-rbac-reader"))
-	if status != http.StatusForbidden {
-		t.Fatalf("expected 403 for missing estimates.write, got %d", status)
+	pending := loginMFAChallenge(t, env.router, "mfa-admin@example.com", "Password123!")
+	status, body = request(t, env.router, http.MethodGet, "/api/auth/me", nil, pending, "")
+	if status != http.StatusUnauthorized || parseErrorCode(t, body) != "mfa_required" {
+		t.Fatalf("expected 401 mfa_required for pending session, got %d (%s)", status, string(body))
 	}
 
-	writerCookie := login(t, env.router, "est-writer@example.com", "Password123!")
-	writerCsrf := csrfToken(t, env.router, writerCookie)
-	estimateID := createEstimate(t, env.router, writerCookie, writerCsrf, "idem-rbac-writer")
+	// The step used to confirm enrollment cannot be replayed.
+	status, body, _ = requestWithSession(t, env.router, http.MethodPost, "/api/auth/mfa/verify", totpPayload(t, "code", enrollment.Secret, step), pending, "")
+	if status != http.StatusUnauthorized || parseErrorCode(t, body) != "invalid_mfa_code" {
+		t.Fatalf("expected 401 for replayed code, got %d (%s)", status, string(body))
+	}
+	status, body, session := requestWithSession(t, env.router, http.MethodPost, "/api/auth/mfa/verify", totpPayload(t, "code", enrollment.Secret, step+1), pending, "")
+	if status != http.StatusOK || session == nil {
+		t.Fatalf("expected 200 and a session for verify, got %d (%s)", status, string(body))
+	}
+	if !strings.Contains(string(body), `"mfaEnabled":true`) {
+		t.Fatalf("expected mfaEnabled in session response, got %s", string(body))
+	}
+	status, body = request(t, env.router, http.MethodGet, "/api/settings", nil, session, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 with verified session, got %d (%s)", status, string(body))
+	}
 
-	status, _ = request(t, env.router, http.MethodPost, "/api/estimates/"+estimateID+"/convert", nil, writerCookie, writerCsrf, withIdempotency("idem-rbac-convert"))
-	if status != http.StatusForbidden {
-		t.Fatalf("expected 403 for missing estimates.convert, got %d", status)
+	recoveryPayload, _ := json.Marshal(map[string]string{"recoveryCode": recovery.RecoveryCodes[0]})
+	pending = loginMFAChallenge(t, env.router, "mfa-admin@example.com", "Password123!")
+	status, body, _ = requestWithSession(t, env.router, http.MethodPost, "/api/auth/mfa/verify", recoveryPayload, pending, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for recovery code, got %d (%s)", status, string(body))
+	}
+	pending = loginMFAChallenge(t, env.router, "mfa-admin@example.com", "Password123!")
+	status, body, _ = requestWithSession(t, env.router, http.MethodPost, "/api/auth/mfa/verify", recoveryPayload, pending, "")
+	if status != http.StatusUnauthorized || parseErrorCode(t, body) != "invalid_mfa_code" {
+		t.Fatalf("expected 401 for reused recovery code, got %d (%s)", status, string(body))
+	}
+
+	var enabledCount, mfaLoginCount int
+	if err := env.pool.QueryRow(ctx, `
+		SELECT
+		  COUNT(*) FILTER (WHERE action = 'auth.mfa_enabled'),
+		  COUNT(*) FILTER (WHERE action = 'auth.login' AND metadata->>'method' LIKE 'mfa_%')
+		FROM audit_log
+		WHERE tenant_id = $1
+		  AND user_id = $2
+	`, tenantID, adminID).Scan(&enabledCount, &mfaLoginCount); err != nil {
+		t.Fatalf("count MFA audit rows: %v", err)
+	}
+	if enabledCount != 1 || mfaLoginCount != 2 {
+		t.Fatalf("expected 1 mfa_enabled and 2 MFA login audit rows, got %d/%d", enabledCount, mfaLoginCount)
 	}
 }
 
-func TestEstimateCreateIdempotency(t *testing.T) {
+func TestLoginTenantPickerAndSwitch(t *testing.T) {
 	env := setupTestEnv(t)
 	ctx := context.Background()
 
-	_, _ = seedTenantUser(t, ctx, env.pool, "tenant-idem-create", "Tenant Idem Create", "idem-create@example.com", "Password123!", []string{"estimates.read", "estimates.write"})
-
-	cookie := login(t, env.router, "idem-create@example.com", "Password123!")
-	csrf := csrfToken(t, env.router, cookie)
+	_, _ = seedTenantUser(t, ctx, env.pool, "tenant-multi-a", "Alpha Movers", "consultant@example.com", "Password123!", []string{"customers.read"})
+	tenantB, _ := seedTenantUser(t, ctx, env.pool, "tenant-multi-b", "Bravo Movers", "consultant@example.com", "Password123!", []string{"jobs.read"})
+	_, _ = seedTenantUser(t, ctx, env.pool, "tenant-multi-c", "Charlie Movers", "consultant@example.com", "DifferentPass1!", []string{"jobs.read"})
 
-	key := "idem-create-same"
-	status1, body1 := request(t, env.router, http.MethodPost, "/api/estimates", estimatePayload("Alice Create"), cookie, csrf, withIdempotency(key))
-	if status1 != http.StatusCreated {
-		t.Fatalf("expected 201 create, got %d (%s)", status1, string(body1))
+	status, body := request(t, env.router, http.MethodPost, "/api/auth/login", []byte(`{"email":"consultant@example.com","password":"Password123!"}`), nil, "")
+	if status != http.StatusConflict || parseErrorCode(t, body) != "tenant_selection_required" {
+		t.Fatalf("expected tenant_selection_required, got %d (%s)", status, string(body))
 	}
-	estimateID1 := parseEstimateID(t, body1)
-
-	status2, body2 := request(t, env.router, http.MethodPost, "/api/estimates", estimatePayload("Alice Create"), cookie, csrf, withIdempotency(key))
-	if status2 != http.StatusOK {
-		t.Fatalf("expected 200 idempotent replay, got %d (%s)", status2, string(body2))
+	var picker struct {
+		Error struct {
+			Details struct {
+				Tenants []struct {
+					Slug string `json:"slug"`
+				} `json:"tenants"`
+			} `json:"details"`
+		} `json:"error"`
 	}
-	estimateID2 := parseEstimateID(t, body2)
-	if estimateID1 != estimateID2 {
-		t.Fatalf("expected same estimate id for idempotent replay, got %s and %s", estimateID1, estimateID2)
+	if err := json.Unmarshal(body, &picker); err != nil {
+		t.Fatalf("parse picker body: %v", err)
+	}
+	if len(picker.Error.Details.Tenants) != 2 || picker.Error.Details.Tenants[0].Slug != "tenant-multi-a" || picker.Error.Details.Tenants[1].Slug != "tenant-multi-b" {
+		t.Fatalf("expected picker to list only tenants where the password matched, got %+v", picker.Error.Details.Tenants)
 	}
 
-	status3, body3 := request(t, env.router, http.MethodPost, "/api/estimates", estimatePayload("Different Payload"), cookie, csrf, withIdempotency(key))
-	if status3 != http.StatusConflict {
-		t.Fatalf("expected 409 for key reuse with different payload, got %d (%s)", status3, string(body3))
+	status, _ = request(t, env.router, http.MethodPost, "/api/auth/login", []byte(`{"email":"consultant@example.com","password":"Password123!","tenantSlug":"tenant-multi-c"}`), nil, "")
+	if status != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for wrong password in selected tenant, got %d", status)
 	}
-}
 
-func TestEstimateConvertIdempotencyAndSingleJob(t *testing.T) {
-	env := setupTestEnv(t)
-	ctx := context.Background()
+	cookieA := loginTenant(t, env.router, "consultant@example.com", "Password123!", "tenant-multi-a")
+	csrfA := csrfToken(t, env.router, cookieA)
 
-	tenantID, _ := seedTenantUser(t, ctx, env.pool, "tenant-idem-convert", "Tenant Idem Convert", "idem-convert@example.com", "Password123!", []string{"estimates.read", "estimates.write", "estimates.convert"})
+	status, body = request(t, env.router, http.MethodGet, "/api/auth/tenants", nil, cookieA, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for tenant list, got %d (%s)", status, string(body))
+	}
+	var tenants struct {
+		Items []struct {
+			Slug string `json:"slug"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(body, &tenants); err != nil {
+		t.Fatalf("parse tenant list: %v", err)
+	}
+	// Tenant C shares the email but not the password, so this session has not
+	// proven that account and must not even learn about it.
+	if len(tenants.Items) != 2 || tenants.Items[0].Slug != "tenant-multi-a" || tenants.Items[1].Slug != "tenant-multi-b" {
+		t.Fatalf("expected only the proven tenants A and B, got %+v", tenants.Items)
+	}
 
-	cookie := login(t, env.router, "idem-convert@example.com", "Password123!")
-	csrf := csrfToken(t, env.router, cookie)
-	estimateID := createEstimate(t, env.router, cookie, csrf, "idem-convert-estimate")
+	status, body = request(t, env.router, http.MethodPost, "/api/auth/switch-tenant", []byte(`{"tenantSlug":"tenant-multi-c"}`), cookieA, csrfA)
+	if status != http.StatusForbidden || parseErrorCode(t, body) != "password_required" {
+		t.Fatalf("expected 403 password_required for an unproven account, got %d (%s)", status, string(body))
+	}
+	status, body = request(t, env.router, http.MethodPost, "/api/auth/switch-tenant", []byte(`{"tenantSlug":"tenant-multi-c","password":"Password123!"}`), cookieA, csrfA)
+	if status != http.StatusUnauthorized || parseErrorCode(t, body) != "invalid_credentials" {
+		t.Fatalf("expected 401 for the wrong target password, got %d (%s)", status, string(body))
+	}
+	var failedCount int
+	if err := env.pool.QueryRow(ctx, `
+		SELECT f.failed_count
+		FROM user_login_failures f
+		JOIN tenants t ON t.id = f.tenant_id
+		WHERE t.slug = 'tenant-multi-c'
+	`).Scan(&failedCount); err != nil || failedCount != 1 {
+		t.Fatalf("expected the wrong switch password to count as a failure, got %d (%v)", failedCount, err)
+	}
 
-	key := "idem-convert-key"
-	status1, body1 := request(t, env.router, http.MethodPost, "/api/estimates/"+estimateID+"/convert", nil, cookie, csrf, withIdempotency(key))
-	if status1 != http.StatusCreated {
-		t.Fatalf("expected 201 convert, got %d (%s)", status1, string(body1))
+	status, _ = request(t, env.router, http.MethodPost, "/api/auth/switch-tenant", []byte(`{"tenantSlug":"tenant-unknown"}`), cookieA, csrfA)
+	if status != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown tenant switch, got %d", status)
 	}
-	jobID1 := parseJobID(t, body1)
 
-	status2, body2 := request(t, env.router, http.MethodPost, "/api/estimates/"+estimateID+"/convert", nil, cookie, csrf, withIdempotency(key))
-	if status2 != http.StatusOK {
-		t.Fatalf("expected 200 idempotent convert replay, got %d (%s)", status2, string(body2))
+	cookieB := switchTenant(t, env.router, cookieA, csrfA, "tenant-multi-b")
+	status, _ = request(t, env.router, http.MethodGet, "/api/auth/me", nil, cookieA, "")
+	if status != http.StatusUnauthorized {
+		t.Fatalf("expected switch to revoke the previous session, got %d", status)
 	}
-	jobID2 := parseJobID(t, body2)
-	if jobID1 != jobID2 {
-		t.Fatalf("expected same job id for idempotent convert replay, got %s and %s", jobID1, jobID2)
+	status, body = request(t, env.router, http.MethodGet, "/api/auth/me", nil, cookieB, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for auth/me after switch, got %d (%s)", status, string(body))
+	}
+	var me struct {
+		Tenant struct {
+			ID string `json:"id"`
+		} `json:"tenant"`
+		Permissions []string `json:"permissions"`
+	}
+	if err := json.Unmarshal(body, &me); err != nil {
+		t.Fatalf("parse auth/me body: %v", err)
+	}
+	if me.Tenant.ID != tenantB.String() || len(me.Permissions) != 1 || me.Permissions[0] != "jobs.read" {
+		t.Fatalf("expected session in tenant B with its permissions, got %+v", me)
 	}
 
-	status3, body3 := request(t, env.router, http.MethodPost, "/api/estimates/"+estimateID+"/convert", nil, cookie, csrf, withIdempotency("idem-convert-key-2"))
-	if status3 != http.StatusOK {
-		t.Fatalf("expected 200 for repeated convert with different key, got %d (%s)", status3, string(body3))
+	csrfB := csrfToken(t, env.router, cookieB)
+	status, body, cookieC := requestWithSession(t, env.router, http.MethodPost, "/api/auth/switch-tenant", []byte(`{"tenantSlug":"tenant-multi-c","password":"DifferentPass1!"}`), cookieB, csrfB)
+	if status != http.StatusOK || cookieC == nil {
+		t.Fatalf("expected 200 when switching with the target password, got %d (%s)", status, string(body))
+	}
+	status, body = request(t, env.router, http.MethodGet, "/api/auth/tenants", nil, cookieC, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for tenant list, got %d (%s)", status, string(body))
+	}
+	if err := json.Unmarshal(body, &tenants); err != nil {
+		t.Fatalf("parse tenant list: %v", err)
+	}
+	if len(tenants.Items) != 3 {
+		t.Fatalf("expected all three proven tenants after the password switch, got %+v", tenants.Items)
+	}
+}
+
+func TestLogoutRevokesSession(t *testing.T) {
+	env := setupTestEnv(t)
+	ctx := context.Background()
+
+	_, _ = seedTenantUser(t, ctx, env.pool, "tenant-session", "Tenant Session", "session@example.com", "Password123!", []string{"customers.read", "customers.write"})
+
+	cookie := login(t, env.router, "session@example.com", "Password123!")
+	status, _ := request(t, env.router, http.MethodGet, "/api/auth/me", nil, cookie, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 before logout, got %d", status)
+	}
+
+	csrf := csrfToken(t, env.router, cookie)
+	status, _ = request(t, env.router, http.MethodPost, "/api/auth/logout", nil, cookie, csrf)
+	if status != http.StatusNoContent {
+		t.Fatalf("expected 204 logout response, got %d", status)
+	}
+
+	status, _ = request(t, env.router, http.MethodGet, "/api/auth/me", nil, cookie, "")
+	if status != http.StatusUnauthorized {
+		t.Fatalf("expected 401 after logout, got %d", status)
+	}
+}
+
+func TestUserAdminLifecycle(t *testing.T) {
+	env := setupTestEnv(t)
+	ctx := context.Background()
+
+	tenantID, adminID := seedTenantUser(t, ctx, env.pool, "tenant-user-admin", "Tenant User Admin", "user-admin@example.com", "Password123!", []string{"users.manage", "customers.read"})
+	_, _ = seedUserInTenant(t, ctx, env.pool, tenantID, "user-admin-limited@example.com", "Password123!", []string{"customers.read"})
+	ownerID, ownerRoleID := seedUserInTenant(t, ctx, env.pool, tenantID, "user-admin-owner@example.com", "Password123!", []string{"roles.manage"})
+
+	adminCookie := login(t, env.router, "user-admin@example.com", "Password123!")
+	adminCsrf := csrfToken(t, env.router, adminCookie)
+
+	// users.manage alone must not hand out roles or take over a roles.manage
+	// account through a password reset.
+	status, body := request(t, env.router, http.MethodPost, "/api/users", []byte(fmt.Sprintf(`{"email":"escalate@example.com","fullName":"Escalate","roleIds":[%q]}`, ownerRoleID)), adminCookie, adminCsrf)
+	if status != http.StatusForbidden {
+		t.Fatalf("expected 403 for roleIds without roles.manage, got %d (%s)", status, string(body))
+	}
+	status, body = request(t, env.router, http.MethodPost, "/api/users/"+ownerID.String()+"/password-reset", []byte(`{"password":"TakeoverPass1!"}`), adminCookie, adminCsrf)
+	if status != http.StatusForbidden {
+		t.Fatalf("expected 403 resetting a roles.manage user's password, got %d (%s)", status, string(body))
+	}
+	status, body = request(t, env.router, http.MethodPost, "/api/users/"+ownerID.String()+"/password-reset-token", nil, adminCookie, adminCsrf)
+	if status != http.StatusForbidden {
+		t.Fatalf("expected 403 issuing a reset token for a roles.manage user, got %d (%s)", status, string(body))
+	}
+
+	status, body = request(t, env.router, http.MethodPost, "/api/users", []byte(`{"email":"Dispatcher@Example.com","fullName":"New Dispatcher"}`), adminCookie, adminCsrf)
+	if status != http.StatusCreated {
+		t.Fatalf("expected 201 for user create, got %d (%s)", status, string(body))
+	}
+	created := parseTenantUser(t, body)
+	if created.TemporaryPassword == "" || created.User.Email != "dispatcher@example.com" || !created.User.IsActive {
+		t.Fatalf("unexpected created user payload: %+v", created)
+	}
+
+	status, _ = request(t, env.router, http.MethodPost, "/api/users", []byte(`{"email":"dispatcher@example.com","fullName":"Duplicate"}`), adminCookie, adminCsrf)
+	if status != http.StatusConflict {
+		t.Fatalf("expected 409 for duplicate user email, got %d", status)
+	}
+
+	dispatcherCookie := login(t, env.router, "dispatcher@example.com", created.TemporaryPassword)
+
+	status, body = request(t, env.router, http.MethodPost, "/api/users/"+created.User.ID+"/password-reset", []byte(`{"password":"ResetPassword1!"}`), adminCookie, adminCsrf)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for password reset, got %d (%s)", status, string(body))
+	}
+	status, _ = request(t, env.router, http.MethodGet, "/api/auth/me", nil, dispatcherCookie, "")
+	if status != http.StatusUnauthorized {
+		t.Fatalf("expected password reset to revoke sessions, got %d", status)
+	}
+	dispatcherCookie = login(t, env.router, "dispatcher@example.com", "ResetPassword1!")
+
+	status, body = request(t, env.router, http.MethodPatch, "/api/users/"+created.User.ID, []byte(`{"isActive":false}`), adminCookie, adminCsrf)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for deactivate, got %d (%s)", status, string(body))
+	}
+	if parseTenantUser(t, body).User.IsActive {
+		t.Fatalf("expected user to be inactive")
+	}
+	status, _ = request(t, env.router, http.MethodGet, "/api/auth/me", nil, dispatcherCookie, "")
+	if status != http.StatusUnauthorized {
+		t.Fatalf("expected deactivation to revoke sessions, got %d", status)
+	}
+	status, _ = request(t, env.router, http.MethodPost, "/api/auth/login", []byte(`{"email":"dispatcher@example.com","password":"ResetPassword1!"}`), nil, "")
+	if status != http.StatusUnauthorized {
+		t.Fatalf("expected deactivated user login to fail, got %d", status)
+	}
+
+	status, _ = request(t, env.router, http.MethodPatch, "/api/users/"+adminID.String(), []byte(`{"isActive":false}`), adminCookie, adminCsrf)
+	if status != http.StatusConflict {
+		t.Fatalf("expected 409 for self-deactivation, got %d", status)
+	}
+
+	status, body = request(t, env.router, http.MethodGet, "/api/users", nil, adminCookie, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for user list, got %d (%s)", status, string(body))
+	}
+	var list struct {
+		Items []tenantUserPayload `json:"items"`
+	}
+	if err := json.Unmarshal(body, &list); err != nil {
+		t.Fatalf("parse user list: %v", err)
+	}
+	if len(list.Items) != 4 {
+		t.Fatalf("expected 4 users in tenant, got %d", len(list.Items))
+	}
+
+	limitedCookie := login(t, env.router, "user-admin-limited@example.com", "Password123!")
+	status, _ = request(t, env.router, http.MethodGet, "/api/users", nil, limitedCookie, "")
+	if status != http.StatusForbidden {
+		t.Fatalf("expected 403 without users.manage, got %d", status)
+	}
+
+	var count int
+	if err := env.pool.QueryRow(ctx, `
+		SELECT COUNT(*)
+		FROM audit_log
+		WHERE tenant_id = $1
+		  AND entity_id = $2
+		  AND action IN ('users.create', 'users.password_reset', 'users.deactivate')
+	`, tenantID, uuid.MustParse(created.User.ID)).Scan(&count); err != nil {
+		t.Fatalf("count user audit rows: %v", err)
+	}
+	if count != 3 {
+		t.Fatalf("expected 3 user admin audit rows, got %d", count)
+	}
+}
+
+func TestRoleManagementAndLastAdminGuard(t *testing.T) {
+	env := setupTestEnv(t)
+	ctx := context.Background()
+
+	tenantID, memberID := seedTenantUser(t, ctx, env.pool, "tenant-role-admin", "Tenant Role Admin", "role-member@example.com", "Password123!", []string{"customers.read"})
+	_, adminRoleID := seedUserInTenant(t, ctx, env.pool, tenantID, "role-admin@example.com", "Password123!", []string{"roles.manage", "customers.read"})
+
+	adminCookie := login(t, env.router, "role-admin@example.com", "Password123!")
+	adminCsrf := csrfToken(t, env.router, adminCookie)
+	memberCookie := login(t, env.router, "role-member@example.com", "Password123!")
+
+	status, _ := request(t, env.router, http.MethodGet, "/api/roles", nil, memberCookie, "")
+	if status != http.StatusForbidden {
+		t.Fatalf("expected 403 without roles.manage, got %d", status)
+	}
+
+	status, body := request(t, env.router, http.MethodPost, "/api/roles", []byte(`{"name":"Dispatch","permissions":["customers.read","bogus.permission"]}`), adminCookie, adminCsrf)
+	if status != http.StatusBadRequest {
+		t.Fatalf("expected 400 for unknown permission, got %d (%s)", status, string(body))
+	}
+
+	status, body = request(t, env.router, http.MethodPost, "/api/roles", []byte(`{"name":"Dispatch","description":"Dispatch desk","permissions":["roles.manage","customers.read"]}`), adminCookie, adminCsrf)
+	if status != http.StatusCreated {
+		t.Fatalf("expected 201 for role create, got %d (%s)", status, string(body))
+	}
+	dispatch := parseRole(t, body)
+	if len(dispatch.Permissions) != 2 || dispatch.UserCount != 0 {
+		t.Fatalf("unexpected created role payload: %+v", dispatch)
+	}
+
+	status, _ = request(t, env.router, http.MethodPost, "/api/roles", []byte(`{"name":"Dispatch","permissions":[]}`), adminCookie, adminCsrf)
+	if status != http.StatusConflict {
+		t.Fatalf("expected 409 for duplicate role name, got %d", status)
+	}
+
+	status, body = request(t, env.router, http.MethodPut, "/api/users/"+memberID.String()+"/roles", []byte(fmt.Sprintf(`{"roleIds":[%q]}`, dispatch.ID)), adminCookie, adminCsrf)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for role assignment, got %d (%s)", status, string(body))
+	}
+	if roles := parseTenantUser(t, body).User.Roles; len(roles) != 1 || roles[0] != "Dispatch" {
+		t.Fatalf("expected member to hold only Dispatch, got %v", roles)
+	}
+
+	status, _ = request(t, env.router, http.MethodGet, "/api/roles", nil, memberCookie, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected assigned role to grant roles.manage, got %d", status)
+	}
+
+	// The member still holds roles.manage through Dispatch, so the admin's
+	// original role can give it up.
+	status, body = request(t, env.router, http.MethodPatch, "/api/roles/"+adminRoleID.String(), []byte(`{"permissions":["customers.read"]}`), adminCookie, adminCsrf)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for role permission update, got %d (%s)", status, string(body))
+	}
+
+	status, body = request(t, env.router, http.MethodPatch, "/api/roles/"+dispatch.ID, []byte(`{"permissions":["customers.read"]}`), adminCookie, adminCsrf)
+	if status != http.StatusConflict || parseErrorCode(t, body) != "last_admin_role" {
+		t.Fatalf("expected last_admin_role on permission removal, got %d (%s)", status, string(body))
+	}
+	status, body = request(t, env.router, http.MethodDelete, "/api/roles/"+dispatch.ID, nil, memberCookie, csrfToken(t, env.router, memberCookie))
+	if status != http.StatusConflict || parseErrorCode(t, body) != "last_admin_role" {
+		t.Fatalf("expected last_admin_role on role delete, got %d (%s)", status, string(body))
+	}
+	status, body = request(t, env.router, http.MethodPut, "/api/users/"+memberID.String()+"/roles", []byte(`{"roleIds":[]}`), memberCookie, csrfToken(t, env.router, memberCookie))
+	if status != http.StatusConflict || parseErrorCode(t, body) != "last_admin_role" {
+		t.Fatalf("expected last_admin_role on role unassignment, got %d (%s)", status, string(body))
+	}
+
+	status, _ = request(t, env.router, http.MethodGet, "/api/roles", nil, adminCookie, "")
+	if status != http.StatusForbidden {
+		t.Fatalf("expected admin to lose roles.manage after update, got %d", status)
+	}
+
+	status, body = request(t, env.router, http.MethodPost, "/api/roles", []byte(`{"name":"Temporary","permissions":[]}`), memberCookie, csrfToken(t, env.router, memberCookie))
+	if status != http.StatusCreated {
+		t.Fatalf("expected 201 for role create, got %d (%s)", status, string(body))
+	}
+	temporary := parseRole(t, body)
+	status, _ = request(t, env.router, http.MethodDelete, "/api/roles/"+temporary.ID, nil, memberCookie, csrfToken(t, env.router, memberCookie))
+	if status != http.StatusNoContent {
+		t.Fatalf("expected 204 for role delete, got %d", status)
+	}
+	status, _ = request(t, env.router, http.MethodGet, "/api/roles/"+temporary.ID, nil, memberCookie, "")
+	if status != http.StatusNotFound {
+		t.Fatalf("expected 404 for deleted role, got %d", status)
+	}
+
+	var count int
+	if err := env.pool.QueryRow(ctx, `
+		SELECT COUNT(*)
+		FROM audit_log
+		WHERE tenant_id = $1
+		  AND action IN ('roles.create', 'roles.update', 'roles.delete', 'users.roles_update')
+	`, tenantID).Scan(&count); err != nil {
+		t.Fatalf("count role audit rows: %v", err)
+	}
+	if count != 5 {
+		t.Fatalf("expected 5 role audit rows, got %d", count)
+	}
+}
+
+func TestSessionListAndRevoke(t *testing.T) {
+	env := setupTestEnv(t)
+	ctx := context.Background()
+
+	tenantID, _ := seedTenantUser(t, ctx, env.pool, "tenant-sessions", "Tenant Sessions", "sessions-admin@example.com", "Password123!", []string{"users.manage"})
+	workerID, _ := seedUserInTenant(t, ctx, env.pool, tenantID, "sessions-worker@example.com", "Password123!", []string{"customers.read"})
+
+	credentials := []byte(`{"email":"sessions-worker@example.com","password":"Password123!"}`)
+	status, body, laptop := requestWithSession(t, env.router, http.MethodPost, "/api/auth/login", credentials, nil, "", map[string]string{"User-Agent": "Laptop Browser"})
+	if status != http.StatusOK || laptop == nil {
+		t.Fatalf("expected laptop login, got %d (%s)", status, string(body))
+	}
+	status, body, phone := requestWithSession(t, env.router, http.MethodPost, "/api/auth/login", credentials, nil, "", map[string]string{"User-Agent": "Phone Browser"})
+	if status != http.StatusOK || phone == nil {
+		t.Fatalf("expected phone login, got %d (%s)", status, string(body))
+	}
+	phoneCsrf := csrfToken(t, env.router, phone)
+
+	status, body = request(t, env.router, http.MethodGet, "/api/auth/sessions", nil, phone, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for session list, got %d (%s)", status, string(body))
+	}
+	var sessions struct {
+		Items []struct {
+			ID        string `json:"id"`
+			UserAgent string `json:"userAgent"`
+			IPAddress string `json:"ipAddress"`
+			Current   bool   `json:"current"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(body, &sessions); err != nil {
+		t.Fatalf("parse session list: %v", err)
+	}
+	if len(sessions.Items) != 2 {
+		t.Fatalf("expected 2 sessions, got %d (%s)", len(sessions.Items), string(body))
+	}
+	var laptopSessionID, phoneSessionID string
+	for _, item := range sessions.Items {
+		if item.IPAddress != "127.0.0.1" {
+			t.Fatalf("expected client IP to be captured, got %q", item.IPAddress)
+		}
+		switch item.UserAgent {
+		case "Laptop Browser":
+			laptopSessionID = item.ID
+			if item.Current {
+				t.Fatal("laptop session must not be marked current")
+			}
+		case "Phone Browser":
+			phoneSessionID = item.ID
+			if !item.Current {
+				t.Fatal("phone session must be marked current")
+			}
+		}
+	}
+	if laptopSessionID == "" || phoneSessionID == "" {
+		t.Fatalf("expected both user agents in session list, got %s", string(body))
+	}
+
+	status, body = request(t, env.router, http.MethodDelete, "/api/auth/sessions/"+laptopSessionID, nil, phone, phoneCsrf)
+	if status != http.StatusNoContent {
+		t.Fatalf("expected 204 for session revoke, got %d (%s)", status, string(body))
+	}
+	status, _ = request(t, env.router, http.MethodGet, "/api/auth/me", nil, laptop, "")
+	if status != http.StatusUnauthorized {
+		t.Fatalf("expected revoked laptop session to be rejected, got %d", status)
+	}
+	status, body = request(t, env.router, http.MethodDelete, "/api/auth/sessions/"+laptopSessionID, nil, phone, phoneCsrf)
+	if status != http.StatusNotFound || parseErrorCode(t, body) != "session_not_found" {
+		t.Fatalf("expected 404 for already revoked session, got %d (%s)", status, string(body))
+	}
+
+	adminCookie := login(t, env.router, "sessions-admin@example.com", "Password123!")
+	adminCsrf := csrfToken(t, env.router, adminCookie)
+
+	// Another user's session id is not revocable through the self-service route.
+	status, body = request(t, env.router, http.MethodDelete, "/api/auth/sessions/"+phoneSessionID, nil, adminCookie, adminCsrf)
+	if status != http.StatusNotFound {
+		t.Fatalf("expected 404 for another user's session, got %d (%s)", status, string(body))
+	}
+
+	status, body = request(t, env.router, http.MethodDelete, "/api/users/"+workerID.String()+"/sessions", nil, adminCookie, adminCsrf)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for admin revoke, got %d (%s)", status, string(body))
+	}
+	var revoked struct {
+		RevokedCount int64 `json:"revokedCount"`
+	}
+	if err := json.Unmarshal(body, &revoked); err != nil {
+		t.Fatalf("parse revoke body: %v", err)
+	}
+	if revoked.RevokedCount != 1 {
+		t.Fatalf("expected 1 revoked session, got %d", revoked.RevokedCount)
+	}
+	status, _ = request(t, env.router, http.MethodGet, "/api/auth/me", nil, phone, "")
+	if status != http.StatusUnauthorized {
+		t.Fatalf("expected phone session to be revoked by admin, got %d", status)
+	}
+
+	var auditCount int
+	if err := env.pool.QueryRow(ctx, `
+		SELECT COUNT(*)
+		FROM audit_log
+		WHERE tenant_id = $1
+		  AND action IN ('auth.session_revoked', 'users.revoke_sessions')
+	`, tenantID).Scan(&auditCount); err != nil {
+		t.Fatalf("count session audit rows: %v", err)
+	}
+	if auditCount != 2 {
+		t.Fatalf("expected 2 session revoke audit rows, got %d", auditCount)
+	}
+}
+
+func TestSessionSlidingExpiryAndTokenRotation(t *testing.T) {
+	env := setupTestEnvWithConfig(t, func(cfg *config.Config) {
+		cfg.SessionIdleTimeout = time.Hour
+		cfg.SessionTouchInterval = time.Hour
+		// Rotate on every request that carries the current token.
+		cfg.SessionRotateInterval = time.Nanosecond
+	})
+	ctx := context.Background()
+
+	_, userID := seedTenantUser(t, ctx, env.pool, "tenant-sliding", "Tenant Sliding", "sliding@example.com", "Password123!", []string{"customers.read"})
+	original := login(t, env.router, "sliding@example.com", "Password123!")
+
+	var expiresAt, absoluteExpiresAt time.Time
+	if err := env.pool.QueryRow(ctx, `
+		SELECT expires_at, absolute_expires_at FROM sessions WHERE user_id = $1
+	`, userID).Scan(&expiresAt, &absoluteExpiresAt); err != nil {
+		t.Fatalf("load session expiry: %v", err)
+	}
+	if time.Until(expiresAt) > time.Hour || time.Until(absoluteExpiresAt) < 11*time.Hour {
+		t.Fatalf("expected idle expiry within an hour and a 12h absolute cap, got %s / %s", expiresAt, absoluteExpiresAt)
+	}
+
+	status, body, rotated := requestWithSession(t, env.router, http.MethodGet, "/api/auth/me", nil, original, "")
+	if status != http.StatusOK || rotated == nil || rotated.Value == original.Value {
+		t.Fatalf("expected 200 with a rotated cookie, got %d (%s)", status, string(body))
+	}
+
+	// The previous token keeps working briefly for requests already in flight,
+	// and does not trigger another rotation.
+	status, body, again := requestWithSession(t, env.router, http.MethodGet, "/api/auth/me", nil, original, "")
+	if status != http.StatusOK || again != nil {
+		t.Fatalf("expected previous token to be accepted without rotation, got %d (%s)", status, string(body))
+	}
+
+	var lastSeen time.Time
+	if err := env.pool.QueryRow(ctx, `SELECT last_seen_at FROM sessions WHERE user_id = $1`, userID).Scan(&lastSeen); err != nil {
+		t.Fatalf("load last seen: %v", err)
+	}
+	status, _, _ = requestWithSession(t, env.router, http.MethodGet, "/api/auth/me", nil, rotated, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected rotated token to be accepted, got %d", status)
+	}
+	var lastSeenAfter time.Time
+	if err := env.pool.QueryRow(ctx, `SELECT last_seen_at FROM sessions WHERE user_id = $1`, userID).Scan(&lastSeenAfter); err != nil {
+		t.Fatalf("load last seen: %v", err)
+	}
+	if !lastSeenAfter.Equal(lastSeen) {
+		t.Fatalf("expected touch to be throttled, last seen moved from %s to %s", lastSeen, lastSeenAfter)
+	}
+
+	// An idle session is rejected even though the absolute cap is far away.
+	if _, err := env.pool.Exec(ctx, `UPDATE sessions SET expires_at = NOW() - INTERVAL '1 second' WHERE user_id = $1`, userID); err != nil {
+		t.Fatalf("expire session: %v", err)
+	}
+	status, _ = request(t, env.router, http.MethodGet, "/api/auth/me", nil, rotated, "")
+	if status != http.StatusUnauthorized {
+		t.Fatalf("expected idle session to be rejected, got %d", status)
+	}
+
+	// An active session is rejected once the absolute cap passes.
+	fresh := login(t, env.router, "sliding@example.com", "Password123!")
+	if _, err := env.pool.Exec(ctx, `
+		UPDATE sessions SET absolute_expires_at = NOW() - INTERVAL '1 second'
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > NOW()
+	`, userID); err != nil {
+		t.Fatalf("cap session: %v", err)
+	}
+	status, _ = request(t, env.router, http.MethodGet, "/api/auth/me", nil, fresh, "")
+	if status != http.StatusUnauthorized {
+		t.Fatalf("expected session past its absolute lifetime to be rejected, got %d", status)
+	}
+}
+
+func TestAPITokenAuthenticationAndScopes(t *testing.T) {
+	env := setupTestEnv(t)
+	ctx := context.Background()
+
+	tenantID, userID := seedTenantUser(t, ctx, env.pool, "tenant-tokens", "Tenant Tokens", "integration@example.com", "Password123!", []string{"customers.read", "customers.write"})
+	cookie := login(t, env.router, "integration@example.com", "Password123!")
+	csrf := csrfToken(t, env.router, cookie)
+
+	status, body := request(t, env.router, http.MethodPost, "/api/auth/api-tokens", []byte(`{"name":"Accounting","scopes":["jobs.read"]}`), cookie, csrf)
+	if status != http.StatusBadRequest || parseErrorCode(t, body) != "invalid_scopes" {
+		t.Fatalf("expected 400 invalid_scopes for unheld scope, got %d (%s)", status, string(body))
+	}
+
+	readToken, readTokenID := createAPIToken(t, env.router, cookie, csrf, `{"name":"Accounting","scopes":["customers.read"]}`)
+	writeToken, _ := createAPIToken(t, env.router, cookie, csrf, `{"name":"Sync","scopes":["customers.read","customers.write"]}`)
+	if !strings.HasPrefix(readToken, "mo_pat_") {
+		t.Fatalf("expected prefixed token, got %q", readToken)
+	}
+
+	status, body = request(t, env.router, http.MethodGet, "/api/customers", nil, nil, "", bearer(readToken))
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for token read, got %d (%s)", status, string(body))
+	}
+
+	// Scopes cap the token below the user's own permissions.
+	customerPayload := []byte(`{"firstName":"Token","lastName":"Customer"}`)
+	status, body = request(t, env.router, http.MethodPost, "/api/customers", customerPayload, nil, "", bearer(readToken))
+	if status != http.StatusForbidden || parseErrorCode(t, body) != "forbidden" {
+		t.Fatalf("expected 403 for write outside token scopes, got %d (%s)", status, string(body))
+	}
+	// Token requests skip CSRF; cookie requests still need it.
+	status, body = request(t, env.router, http.MethodPost, "/api/customers", customerPayload, nil, "", bearer(writeToken))
+	if status != http.StatusCreated {
+		t.Fatalf("expected 201 for token write without CSRF, got %d (%s)", status, string(body))
+	}
+	status, _ = request(t, env.router, http.MethodPost, "/api/customers", customerPayload, cookie, "")
+	if status != http.StatusForbidden {
+		t.Fatalf("expected cookie write without CSRF to be rejected, got %d", status)
+	}
+
+	status, body = request(t, env.router, http.MethodGet, "/api/auth/me", nil, nil, "", bearer(readToken))
+	if status != http.StatusUnauthorized || parseErrorCode(t, body) != "api_token_not_allowed" {
+		t.Fatalf("expected 401 api_token_not_allowed on /auth, got %d (%s)", status, string(body))
+	}
+
+	status, body = request(t, env.router, http.MethodGet, "/api/auth/api-tokens", nil, cookie, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for token list, got %d (%s)", status, string(body))
+	}
+	var tokens struct {
+		Items []struct {
+			ID         string     `json:"id"`
+			LastUsedAt *time.Time `json:"lastUsedAt"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(body, &tokens); err != nil {
+		t.Fatalf("parse token list: %v", err)
+	}
+	if len(tokens.Items) != 2 {
+		t.Fatalf("expected 2 tokens, got %d", len(tokens.Items))
+	}
+	for _, item := range tokens.Items {
+		if item.LastUsedAt == nil {
+			t.Fatalf("expected last use to be tracked for token %s", item.ID)
+		}
+	}
+	if strings.Contains(string(body), readToken) || strings.Contains(string(body), writeToken) {
+		t.Fatal("token list must not expose token secrets")
+	}
+
+	status, body = request(t, env.router, http.MethodDelete, "/api/auth/api-tokens/"+readTokenID, nil, cookie, csrf)
+	if status != http.StatusNoContent {
+		t.Fatalf("expected 204 for token revoke, got %d (%s)", status, string(body))
+	}
+	status, _ = request(t, env.router, http.MethodGet, "/api/customers", nil, nil, "", bearer(readToken))
+	if status != http.StatusUnauthorized {
+		t.Fatalf("expected revoked token to be rejected, got %d", status)
+	}
+
+	if _, err := env.pool.Exec(ctx, `UPDATE api_tokens SET expires_at = NOW() - INTERVAL '1 second' WHERE tenant_id = $1 AND user_id = $2`, tenantID, userID); err != nil {
+		t.Fatalf("expire tokens: %v", err)
+	}
+	status, _ = request(t, env.router, http.MethodGet, "/api/customers", nil, nil, "", bearer(writeToken))
+	if status != http.StatusUnauthorized {
+		t.Fatalf("expected expired token to be rejected, got %d", status)
+	}
+}
+
+func TestOIDCSingleSignOnProvisioningAndRoleSync(t *testing.T) {
+	idp := oidctest.NewServer("moveops-web", "client-secret")
+	defer idp.Close()
+	env := setupTestEnvWithConfig(t, func(cfg *config.Config) {
+		cfg.OIDCRedirectURL = "http://api.test/api/auth/oidc/callback"
+		cfg.WebBaseURL = "http://web.test"
+		cfg.SSOAllowPrivateIssuers = true
+	})
+	ctx := context.Background()
+
+	tenantID, _ := seedTenantUser(t, ctx, env.pool, "tenant-sso", "Tenant SSO", "admin@sso.example.com", "Password123!", []string{"settings.manage", "users.manage"})
+	existingID, _ := seedUserInTenant(t, ctx, env.pool, tenantID, "ops@sso.example.com", "Password123!", nil)
+	_, dispatcherRoleID := seedUserInTenant(t, ctx, env.pool, tenantID, "dispatcher@sso.example.com", "Password123!", []string{"jobs.read"})
+
+	status, body := request(t, env.router, http.MethodGet, "/api/auth/oidc/tenant-sso/start", nil, nil, "")
+	if status != http.StatusNotFound || parseErrorCode(t, body) != "sso_not_configured" {
+		t.Fatalf("expected 404 sso_not_configured before setup, got %d (%s)", status, string(body))
+	}
+
+	adminCookie := login(t, env.router, "admin@sso.example.com", "Password123!")
+	adminCSRF := csrfToken(t, env.router, adminCookie)
+	settingsPayload := fmt.Sprintf(`{"enabled":true,"issuer":%q,"clientId":"moveops-web","clientSecret":"client-secret","groupRoleMappings":[{"group":"dispatch","roleId":%q}]}`, idp.Issuer(), dispatcherRoleID)
+	status, body = request(t, env.router, http.MethodPut, "/api/settings/sso", []byte(settingsPayload), adminCookie, adminCSRF)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for SSO settings, got %d (%s)", status, string(body))
+	}
+	if strings.Contains(string(body), "client-secret") || !strings.Contains(string(body), `"clientSecretSet":true`) {
+		t.Fatalf("expected write-only client secret, got %s", string(body))
+	}
+
+	// First login provisions the user and grants the mapped role.
+	idp.SetIdentity(oidctest.Identity{Subject: "sub-new", Email: "New.Hire@sso.example.com", EmailVerified: true, Name: "New Hire", Groups: []string{"dispatch"}})
+	location, session := ssoLogin(t, env.router, "tenant-sso")
+	if location != "http://web.test/" || session == nil {
+		t.Fatalf("expected redirect to web app with session, got %q", location)
+	}
+	status, body = request(t, env.router, http.MethodGet, "/api/auth/me", nil, session, "")
+	if status != http.StatusOK || !strings.Contains(string(body), "new.hire@sso.example.com") || !strings.Contains(string(body), "jobs.read") {
+		t.Fatalf("expected provisioned user with mapped role, got %d (%s)", status, string(body))
+	}
+
+	var provisionedID uuid.UUID
+	if err := env.pool.QueryRow(ctx, `SELECT user_id FROM user_identities WHERE tenant_id = $1 AND subject = 'sub-new'`, tenantID).Scan(&provisionedID); err != nil {
+		t.Fatalf("load identity: %v", err)
+	}
+	var auditCount int
+	if err := env.pool.QueryRow(ctx, `
+		SELECT COUNT(*) FROM audit_log
+		WHERE tenant_id = $1 AND user_id = $2
+		  AND (action = 'users.provision' OR (action = 'auth.login' AND metadata->>'method' = 'oidc'))
+	`, tenantID, provisionedID).Scan(&auditCount); err != nil {
+		t.Fatalf("count audit: %v", err)
+	}
+	if auditCount != 2 {
+		t.Fatalf("expected provision and login audit entries, got %d", auditCount)
+	}
+
+	// Leaving the group removes the mapped role on the next login without
+	// creating a second user.
+	idp.SetIdentity(oidctest.Identity{Subject: "sub-new", Email: "new.hire@sso.example.com", EmailVerified: true, Name: "New Hire"})
+	_, session = ssoLogin(t, env.router, "tenant-sso")
+	status, body = request(t, env.router, http.MethodGet, "/api/auth/me", nil, session, "")
+	if status != http.StatusOK || strings.Contains(string(body), "jobs.read") {
+		t.Fatalf("expected mapped role removed, got %d (%s)", status, string(body))
+	}
+	var userCount int
+	if err := env.pool.QueryRow(ctx, `SELECT COUNT(*) FROM users WHERE tenant_id = $1 AND lower(email) = 'new.hire@sso.example.com'`, tenantID).Scan(&userCount); err != nil {
+		t.Fatalf("count users: %v", err)
+	}
+	if userCount != 1 {
+		t.Fatalf("expected one provisioned user, got %d", userCount)
+	}
+
+	// A verified email links to the existing account.
+	idp.SetIdentity(oidctest.Identity{Subject: "sub-ops", Email: "ops@sso.example.com", EmailVerified: true})
+	ssoLogin(t, env.router, "tenant-sso")
+	var linkedID uuid.UUID
+	if err := env.pool.QueryRow(ctx, `SELECT user_id FROM user_identities WHERE tenant_id = $1 AND subject = 'sub-ops'`, tenantID).Scan(&linkedID); err != nil {
+		t.Fatalf("load linked identity: %v", err)
+	}
+	if linkedID != existingID {
+		t.Fatalf("expected identity linked to existing user %s, got %s", existingID, linkedID)
+	}
+
+	// A login lockout applies to SSO as well.
+	if _, err := env.pool.Exec(ctx, `
+		INSERT INTO user_login_failures (user_id, tenant_id, failed_count, locked_until)
+		VALUES ($1, $2, 3, NOW() + INTERVAL '5 minutes')
+	`, existingID, tenantID); err != nil {
+		t.Fatalf("lock user: %v", err)
+	}
+	if location, session := ssoLogin(t, env.router, "tenant-sso"); location != "http://web.test/login?ssoError=account_locked" || session != nil {
+		t.Fatalf("expected account_locked without a session, got %q", location)
+	}
+
+	// Unverified emails are neither linked nor provisioned.
+	idp.SetIdentity(oidctest.Identity{Subject: "sub-unverified", Email: "admin@sso.example.com"})
+	if location, _ := ssoLogin(t, env.router, "tenant-sso"); location != "http://web.test/login?ssoError=email_not_verified" {
+		t.Fatalf("expected email_not_verified, got %q", location)
+	}
+
+	// The state is single-use and bound to the browser that started the login.
+	idp.SetIdentity(oidctest.Identity{Subject: "sub-new", Email: "new.hire@sso.example.com", EmailVerified: true})
+	callbackPath, stateCookie := ssoAuthorize(t, env.router, "tenant-sso")
+	if location := ssoCallback(t, env.router, callbackPath, nil); !strings.HasSuffix(location, "ssoError=invalid_state") {
+		t.Fatalf("expected invalid_state without the state cookie, got %q", location)
+	}
+	if location := ssoCallback(t, env.router, callbackPath, stateCookie); location != "http://web.test/" {
+		t.Fatalf("expected callback to succeed, got %q", location)
+	}
+	if location := ssoCallback(t, env.router, callbackPath, stateCookie); !strings.HasSuffix(location, "ssoError=invalid_state") {
+		t.Fatalf("expected replayed state to be rejected, got %q", location)
+	}
+
+	status, body = request(t, env.router, http.MethodGet, "/api/settings/sso", nil, adminCookie, "")
+	if status != http.StatusOK || !strings.Contains(string(body), `"group":"dispatch"`) {
+		t.Fatalf("expected SSO settings with mapping, got %d (%s)", status, string(body))
+	}
+}
+
+func TestSSOSettingsRejectPrivateIssuers(t *testing.T) {
+	idp := oidctest.NewServer("moveops-web", "client-secret")
+	defer idp.Close()
+	env := setupTestEnv(t)
+	ctx := context.Background()
+
+	seedTenantUser(t, ctx, env.pool, "tenant-sso-ssrf", "Tenant SSO SSRF", "admin@ssrf.example.com", "Password123!", []string{"settings.manage"})
+	cookie := login(t, env.router, "admin@ssrf.example.com", "Password123!")
+	csrf := csrfToken(t, env.router, cookie)
+
+	for _, issuer := range []string{idp.Issuer(), "https://127.0.0.1:8443", "https://169.254.169.254/latest", "https://10.0.0.5", "https://localhost"} {
+		payload, _ := json.Marshal(map[string]any{"enabled": true, "issuer": issuer, "clientId": "moveops-web"})
+		status, body := request(t, env.router, http.MethodPut, "/api/settings/sso", payload, cookie, csrf)
+		if status != http.StatusBadRequest || parseErrorCode(t, body) != "validation_error" {
+			t.Fatalf("expected %s to be rejected, got %d (%s)", issuer, status, string(body))
+		}
+	}
+}
+
+func TestPasswordChangeResetAndRehash(t *testing.T) {
+	env := setupTestEnv(t)
+	ctx := context.Background()
+
+	tenantID, _ := seedTenantUser(t, ctx, env.pool, "tenant-passwords", "Tenant Passwords", "passwords-admin@example.com", "Password123!", []string{"users.manage", "settings.manage"})
+	workerID, _ := seedUserInTenant(t, ctx, env.pool, tenantID, "pw-worker@example.com", "Password123!", []string{"customers.read"})
+
+	workerCookie := login(t, env.router, "pw-worker@example.com", "Password123!")
+	workerCsrf := csrfToken(t, env.router, workerCookie)
+	otherCookie := login(t, env.router, "pw-worker@example.com", "Password123!")
+
+	status, body := request(t, env.router, http.MethodPost, "/api/auth/password", []byte(`{"currentPassword":"wrong-password","newPassword":"Correct-Horse-42"}`), workerCookie, workerCsrf)
+	if status != http.StatusBadRequest || parseErrorCode(t, body) != "invalid_current_password" {
+		t.Fatalf("expected 400 invalid_current_password, got %d (%s)", status, string(body))
+	}
+	for _, weak := range []string{"password1234", "pw-worker", "short"} {
+		payload, _ := json.Marshal(map[string]string{"currentPassword": "Password123!", "newPassword": weak})
+		status, body = request(t, env.router, http.MethodPost, "/api/auth/password", payload, workerCookie, workerCsrf)
+		if status != http.StatusBadRequest || parseErrorCode(t, body) != "weak_password" {
+			t.Fatalf("expected 400 weak_password for %q, got %d (%s)", weak, status, string(body))
+		}
+	}
+
+	status, body = request(t, env.router, http.MethodPost, "/api/auth/password", []byte(`{"currentPassword":"Password123!","newPassword":"Correct-Horse-42"}`), workerCookie, workerCsrf)
+	if status != http.StatusNoContent {
+		t.Fatalf("expected 204 for password change, got %d (%s)", status, string(body))
+	}
+	if status, _ = request(t, env.router, http.MethodGet, "/api/auth/me", nil, workerCookie, ""); status != http.StatusOK {
+		t.Fatalf("expected the changing session to survive, got %d", status)
+	}
+	if status, _ = request(t, env.router, http.MethodGet, "/api/auth/me", nil, otherCookie, ""); status != http.StatusUnauthorized {
+		t.Fatalf("expected other sessions to be revoked, got %d", status)
+	}
+
+	adminCookie := login(t, env.router, "passwords-admin@example.com", "Password123!")
+	adminCsrf := csrfToken(t, env.router, adminCookie)
+	status, body = request(t, env.router, http.MethodPatch, "/api/settings", []byte(`{"passwordMinLength":16}`), adminCookie, adminCsrf)
+	if status != http.StatusOK || !strings.Contains(string(body), `"passwordMinLength":16`) {
+		t.Fatalf("expected 200 with raised minimum length, got %d (%s)", status, string(body))
+	}
+
+	status, body = request(t, env.router, http.MethodPost, "/api/users/"+workerID.String()+"/password-reset-token", nil, adminCookie, adminCsrf)
+	if status != http.StatusCreated {
+		t.Fatalf("expected 201 for reset token, got %d (%s)", status, string(body))
+	}
+	var issued struct {
+		Token string `json:"token"`
+	}
+	if err := json.Unmarshal(body, &issued); err != nil || issued.Token == "" {
+		t.Fatalf("parse reset token: %v (%s)", err, string(body))
+	}
+
+	resetPayload := func(password string) []byte {
+		payload, _ := json.Marshal(map[string]string{"token": issued.Token, "newPassword": password})
+		return payload
+	}
+	status, body = request(t, env.router, http.MethodPost, "/api/auth/password-reset", resetPayload("Short-Pass-123"), nil, "")
+	if status != http.StatusBadRequest || parseErrorCode(t, body) != "weak_password" {
+		t.Fatalf("expected the tenant minimum length to apply, got %d (%s)", status, string(body))
+	}
+	status, body = request(t, env.router, http.MethodPost, "/api/auth/password-reset", resetPayload("A-much-longer-passphrase"), nil, "")
+	if status != http.StatusNoContent {
+		t.Fatalf("expected 204 for password reset, got %d (%s)", status, string(body))
+	}
+	status, body = request(t, env.router, http.MethodPost, "/api/auth/password-reset", resetPayload("Another-long-passphrase"), nil, "")
+	if status != http.StatusBadRequest || parseErrorCode(t, body) != "invalid_reset_token" {
+		t.Fatalf("expected reset tokens to be single-use, got %d (%s)", status, string(body))
+	}
+	if status, _ = request(t, env.router, http.MethodGet, "/api/auth/me", nil, workerCookie, ""); status != http.StatusUnauthorized {
+		t.Fatalf("expected reset to revoke all sessions, got %d", status)
+	}
+
+	// A hash made with older, cheaper parameters is upgraded on the next login.
+	salt := []byte("0123456789abcdef")
+	legacyHash := fmt.Sprintf("$argon2id$v=19$m=%d,t=%d,p=%d$%s$%s", 19*1024, 2, 1,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(argon2.IDKey([]byte("A-much-longer-passphrase"), salt, 2, 19*1024, 1, 32)))
+	if _, err := env.pool.Exec(ctx, `UPDATE users SET password_hash = $1 WHERE id = $2`, legacyHash, workerID); err != nil {
+		t.Fatalf("seed legacy hash: %v", err)
+	}
+	_ = login(t, env.router, "pw-worker@example.com", "A-much-longer-passphrase")
+	var storedHash string
+	if err := env.pool.QueryRow(ctx, `SELECT password_hash FROM users WHERE id = $1`, workerID).Scan(&storedHash); err != nil {
+		t.Fatalf("load password hash: %v", err)
+	}
+	if storedHash == legacyHash || auth.PasswordNeedsRehash(storedHash) {
+		t.Fatalf("expected the legacy hash to be upgraded on login, got %q", storedHash)
+	}
+
+	var auditCount int
+	if err := env.pool.QueryRow(ctx, `
+		SELECT COUNT(*) FROM audit_log
+		WHERE tenant_id = $1 AND entity_id = $2
+		  AND action IN ('auth.password_changed', 'users.password_reset_token', 'auth.password_reset_completed')
+	`, tenantID, workerID).Scan(&auditCount); err != nil {
+		t.Fatalf("count password audit rows: %v", err)
+	}
+	if auditCount != 3 {
+		t.Fatalf("expected 3 password audit rows, got %d", auditCount)
+	}
+}
+
+func TestEstimateTenantIsolation(t *testing.T) {
+	env := setupTestEnv(t)
+	ctx := context.Background()
+
+	_, _ = seedTenantUser(t, ctx, env.pool, "tenant-estimate-a", "Tenant Estimate A", "estimate-a@example.com", "Password123!", []string{"estimates.read", "estimates.write"})
+	_, _ = seedTenantUser(t, ctx, env.pool, "tenant-estimate-b", "Tenant Estimate B", "estimate-b@example.com", "Password123!", []string{"estimates.read"})
+
+	cookieA := login(t, env.router, "estimate-a@example.com", "Password123!")
+	csrfA := csrfToken(t, env.router, cookieA)
+	estimateID := createEstimate(t, env.router, cookieA, csrfA, "idem-estimate-a")
+
+	cookieB := login(t, env.router, "estimate-b@example.com", "Password123!")
+	status, _ := request(t, env.router, http.MethodGet, "/api/estimates/"+estimateID, nil, cookieB, "")
+	if status != http.StatusNotFound {
+		t.Fatalf("expected 404 for cross-tenant estimate read, got %d", status)
+	}
+}
+
+func TestEstimateTenantIsolationOnUpdate(t *testing.T) {
+	env := setupTestEnv(t)
+	ctx := context.Background()
+
+	_, _ = seedTenantUser(t, ctx, env.pool, "tenant-estimate-update-a", "Tenant Estimate Update A", "estimate-update-a@example.com", "Password123!", []string{"estimates.read", "estimates.write"})
+	_, _ = seedTenantUser(t, ctx, env.pool, "tenant-estimate-update-b", "Tenant Estimate Update B", "estimate-update-b@example.com", "Password123!", []string{"estimates.read", "estimates.write"})
+
+	cookieA := login(t, env.router, "estimate-update-a@example.com", "Password123!")
+	csrfA := csrfToken(t, env.router, cookieA)
+	estimateID := createEstimate(t, env.router, cookieA, csrfA, "idem-estimate-update-a")
+
+	cookieB := login(t, env.router, "estimate-update-b@example.com", "Password123!")
+	csrfB := csrfToken(t, env.router, cookieB)
+	status, body := request(t, env.router, http.MethodPatch, "/api/estimates/"+estimateID, estimateUpdatePayload("Cross Tenant Attempt"), cookieB, csrfB)
+	if status != http.StatusNotFound {
+		t.Fatalf("expected 404 for cross-tenant estimate update, got %d (%s)", status, string(body))
+	}
+}
+
+func TestEstimateListFiltersAndPagination(t *testing.T) {
+	env := setupTestEnv(t)
+	ctx := context.Background()
+
+	_, _ = seedTenantUser(t, ctx, env.pool, "tenant-estimate-list-a", "Tenant Estimate List A", "estimate-list-a@example.com", "Password123!", []string{"estimates.read", "estimates.write", "estimates.convert"})
+	_, _ = seedTenantUser(t, ctx, env.pool, "tenant-estimate-list-b", "Tenant Estimate List B", "estimate-list-b@example.com", "Password123!", []string{"estimates.read"})
+
+	cookieA := login(t, env.router, "estimate-list-a@example.com", "Password123!")
+	csrfA := csrfToken(t, env.router, cookieA)
+	for i, name := range []string{"Alice Walker", "Bob Stone", "Carol Price"} {
+		status, body := request(t, env.router, http.MethodPost, "/api/estimates", estimatePayload(name), cookieA, csrfA, withIdempotency(fmt.Sprintf("estimate-list-%d", i)))
+		if status != http.StatusCreated {
+			t.Fatalf("create estimate expected 201, got %d (%s)", status, string(body))
+		}
+		if i == 0 {
+			convertEstimateToJob(t, env.router, cookieA, csrfA, parseEstimateID(t, body), "estimate-list-convert")
+		}
+	}
+
+	status, body := request(t, env.router, http.MethodGet, "/api/estimates?limit=2", nil, cookieA, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for estimate list, got %d (%s)", status, string(body))
+	}
+	firstPage := parseEstimateList(t, body)
+	if len(firstPage.Items) != 2 || firstPage.NextCursor == nil {
+		t.Fatalf("expected first page of 2 with a cursor, got %d items", len(firstPage.Items))
+	}
+
+	status, body = request(t, env.router, http.MethodGet, "/api/estimates?limit=2&cursor="+*firstPage.NextCursor, nil, cookieA, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for second estimate page, got %d (%s)", status, string(body))
+	}
+	secondPage := parseEstimateList(t, body)
+	if len(secondPage.Items) != 1 || secondPage.NextCursor != nil {
+		t.Fatalf("expected final page of 1 without a cursor, got %d items", len(secondPage.Items))
+	}
+	for _, item := range firstPage.Items {
+		if item.ID == secondPage.Items[0].ID {
+			t.Fatalf("estimate %s returned on both pages", item.ID)
+		}
+	}
+
+	status, body = request(t, env.router, http.MethodGet, "/api/estimates?q=carol", nil, cookieA, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for estimate search, got %d (%s)", status, string(body))
+	}
+	searched := parseEstimateList(t, body)
+	if len(searched.Items) != 1 || searched.Items[0].CustomerName != "Carol Price" {
+		t.Fatalf("expected search to match Carol Price, got %+v", searched.Items)
+	}
+
+	status, body = request(t, env.router, http.MethodGet, "/api/estimates?status=converted", nil, cookieA, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for status filter, got %d (%s)", status, string(body))
+	}
+	converted := parseEstimateList(t, body)
+	if len(converted.Items) != 1 || converted.Items[0].ConvertedJobID == "" {
+		t.Fatalf("expected one converted estimate with job id, got %+v", converted.Items)
+	}
+
+	status, body = request(t, env.router, http.MethodGet, "/api/estimates?moveDateFrom=2026-04-01", nil, cookieA, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for move date filter, got %d (%s)", status, string(body))
+	}
+	if items := parseEstimateList(t, body).Items; len(items) != 0 {
+		t.Fatalf("expected no estimates after move date, got %d", len(items))
+	}
+
+	status, _ = request(t, env.router, http.MethodGet, "/api/estimates?cursor=not-a-cursor", nil, cookieA, "")
+	if status != http.StatusBadRequest {
+		t.Fatalf("expected 400 for invalid cursor, got %d", status)
+	}
+
+	cookieB := login(t, env.router, "estimate-list-b@example.com", "Password123!")
+	status, body = request(t, env.router, http.MethodGet, "/api/estimates", nil, cookieB, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for cross-tenant estimate list, got %d (%s)", status, string(body))
+	}
+	if items := parseEstimateList(t, body).Items; len(items) != 0 {
+		t.Fatalf("expected cross-tenant estimate list to be empty, got %d", len(items))
+	}
+}
+
+func TestEstimateRBACForCreateAndConvert(t *testing.T) {
+	env := setupTestEnv(t)
+	ctx := context.Background()
+
+	tenantID, _ := seedTenantUser(t, ctx, env.pool, "tenant-rbac-est", "Tenant RBAC Est", "est-admin@example.com", "Password123!", []string{"estimates.read", "estimates.write", "estimates.convert"})
+	_, _ = seedUserInTenant(t, ctx, env.pool, tenantID, "est-reader@example.com", "Password123!", []string{"estimates.read"})
+	_, _ = seedUserInTenant(t, ctx, env.pool, tenantID, "est-writer@example.com", "Password123!", []string{"estimates.read", "estimates.write"})
+
+	readerCookie := login(t, env.router, "est-reader@example.com", "Password123!")
+	readerCsrf := csrfToken(t, env.router, readerCookie)
+	status, _ := request(t, env.router, http.MethodPost, "/api/estimates", estimatePayload("Reader Blocked"), readerCookie, readerCsrf, withIdempotency("idem-rbac-reader"))
+	if status != http.StatusForbidden {
+		t.Fatalf("expected 403 for missing estimates.write, got %d", status)
+	}
+
+	writerCookie := login(t, env.router, "est-writer@example.com", "Password123!")
+	writerCsrf := csrfToken(t, env.router, writerCookie)
+	estimateID := createEstimate(t, env.router, writerCookie, writerCsrf, "idem-rbac-writer")
+
+	status, _ = request(t, env.router, http.MethodPost, "/api/estimates/"+estimateID+"/convert", nil, writerCookie, writerCsrf, withIdempotency("idem-rbac-convert"))
+	if status != http.StatusForbidden {
+		t.Fatalf("expected 403 for missing estimates.convert, got %d", status)
+	}
+}
+
+func TestEstimateCreateIdempotency(t *testing.T) {
+	env := setupTestEnv(t)
+	ctx := context.Background()
+
+	_, _ = seedTenantUser(t, ctx, env.pool, "tenant-idem-create", "Tenant Idem Create", "idem-create@example.com", "Password123!", []string{"estimates.read", "estimates.write"})
+
+	cookie := login(t, env.router, "idem-create@example.com", "Password123!")
+	csrf := csrfToken(t, env.router, cookie)
+
+	key := "idem-create-same"
+	status1, body1 := request(t, env.router, http.MethodPost, "/api/estimates", estimatePayload("Alice Create"), cookie, csrf, withIdempotency(key))
+	if status1 != http.StatusCreated {
+		t.Fatalf("expected 201 create, got %d (%s)", status1, string(body1))
+	}
+	estimateID1 := parseEstimateID(t, body1)
+
+	status2, body2 := request(t, env.router, http.MethodPost, "/api/estimates", estimatePayload("Alice Create"), cookie, csrf, withIdempotency(key))
+	if status2 != http.StatusOK {
+		t.Fatalf("expected 200 idempotent replay, got %d (%s)", status2, string(body2))
+	}
+	estimateID2 := parseEstimateID(t, body2)
+	if estimateID1 != estimateID2 {
+		t.Fatalf("expected same estimate id for idempotent replay, got %s and %s", estimateID1, estimateID2)
+	}
+
+	status3, body3 := request(t, env.router, http.MethodPost, "/api/estimates", estimatePayload("Different Payload"), cookie, csrf, withIdempotency(key))
+	if status3 != http.StatusConflict {
+		t.Fatalf("expected 409 for key reuse with different payload, got %d (%s)", status3, string(body3))
+	}
+}
+
+func TestEstimateConvertIdempotencyAndSingleJob(t *testing.T) {
+	env := setupTestEnv(t)
+	ctx := context.Background()
+
+	tenantID, _ := seedTenantUser(t, ctx, env.pool, "tenant-idem-convert", "Tenant Idem Convert", "idem-convert@example.com", "Password123!", []string{"estimates.read", "estimates.write", "estimates.convert"})
+
+	cookie := login(t, env.router, "idem-convert@example.com", "Password123!")
+	csrf := csrfToken(t, env.router, cookie)
+	estimateID := createEstimate(t, env.router, cookie, csrf, "idem-convert-estimate")
+
+	key := "idem-convert-key"
+	status1, body1 := request(t, env.router, http.MethodPost, "/api/estimates/"+estimateID+"/convert", nil, cookie, csrf, withIdempotency(key))
+	if status1 != http.StatusCreated {
+		t.Fatalf("expected 201 convert, got %d (%s)", status1, string(body1))
+	}
+	jobID1 := parseJobID(t, body1)
+
+	status2, body2 := request(t, env.router, http.MethodPost, "/api/estimates/"+estimateID+"/convert", nil, cookie, csrf, withIdempotency(key))
+	if status2 != http.StatusOK {
+		t.Fatalf("expected 200 idempotent convert replay, got %d (%s)", status2, string(body2))
+	}
+	jobID2 := parseJobID(t, body2)
+	if jobID1 != jobID2 {
+		t.Fatalf("expected same job id for idempotent convert replay, got %s and %s", jobID1, jobID2)
+	}
+
+	status3, body3 := request(t, env.router, http.MethodPost, "/api/estimates/"+estimateID+"/convert", nil, cookie, csrf, withIdempotency("idem-convert-key-2"))
+	if status3 != http.StatusOK {
+		t.Fatalf("expected 200 for repeated convert with different key, got %d (%s)", status3, string(body3))
+	}
+	jobID3 := parseJobID(t, body3)
+	if jobID1 != jobID3 {
+		t.Fatalf("expected same job id for repeated convert, got %s and %s", jobID1, jobID3)
+	}
+
+	var count int
+	estimateUUID, err := uuid.Parse(estimateID)
+	if err != nil {
+		t.Fatalf("parse estimate id: %v", err)
+	}
+	if err := env.pool.QueryRow(ctx, `SELECT COUNT(*) FROM jobs WHERE tenant_id = $1 AND estimate_id = $2`, tenantID, estimateUUID).Scan(&count); err != nil {
+		t.Fatalf("count jobs by estimate: %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("expected exactly 1 job for estimate, got %d", count)
+	}
+}
+
+func TestEstimateStatusLifecycleAndExpiry(t *testing.T) {
+	env := setupTestEnv(t)
+	ctx := context.Background()
+
+	tenantID, _ := seedTenantUser(t, ctx, env.pool, "tenant-estimate-status", "Tenant Estimate Status", "estimate-status@example.com", "Password123!", []string{"estimates.read", "estimates.write", "estimates.convert", "settings.manage"})
+
+	cookie := login(t, env.router, "estimate-status@example.com", "Password123!")
+	csrf := csrfToken(t, env.router, cookie)
+
+	status, body := request(t, env.router, http.MethodPatch, "/api/settings", []byte(`{"estimateValidityDays":14}`), cookie, csrf)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for validity setting, got %d (%s)", status, string(body))
+	}
+
+	type estimateStatusPayload struct {
+		Estimate struct {
+			Status     string     `json:"status"`
+			LostReason *string    `json:"lostReason"`
+			SentAt     *time.Time `json:"sentAt"`
+			ExpiresAt  *time.Time `json:"expiresAt"`
+		} `json:"estimate"`
+	}
+	patchStatus := func(estimateID, payload string) (int, estimateStatusPayload, []byte) {
+		status, body := request(t, env.router, http.MethodPatch, "/api/estimates/"+estimateID, []byte(payload), cookie, csrf)
+		var parsed estimateStatusPayload
+		if status == http.StatusOK {
+			if err := json.Unmarshal(body, &parsed); err != nil {
+				t.Fatalf("parse estimate: %v", err)
+			}
+		}
+		return status, parsed, body
+	}
+
+	estimateID := createEstimate(t, env.router, cookie, csrf, "status-lifecycle-1")
+
+	status, _, body = patchStatus(estimateID, `{"status":"accepted"}`)
+	if status != http.StatusConflict || parseErrorCode(t, body) != "invalid_status_transition" {
+		t.Fatalf("expected 409 for draft to accepted, got %d (%s)", status, string(body))
+	}
+
+	status, sent, body := patchStatus(estimateID, `{"status":"sent"}`)
+	if status != http.StatusOK || sent.Estimate.Status != "sent" || sent.Estimate.SentAt == nil || sent.Estimate.ExpiresAt == nil {
+		t.Fatalf("expected sent estimate with expiry, got %d (%s)", status, string(body))
+	}
+	if validity := sent.Estimate.ExpiresAt.Sub(*sent.Estimate.SentAt); validity < 13*24*time.Hour || validity > 15*24*time.Hour {
+		t.Fatalf("expected a 14 day validity, got %s", validity)
+	}
+
+	status, _, body = patchStatus(estimateID, `{"status":"declined"}`)
+	if status != http.StatusBadRequest || parseErrorCode(t, body) != "lost_reason_required" {
+		t.Fatalf("expected 400 lost_reason_required, got %d (%s)", status, string(body))
+	}
+	status, declined, body := patchStatus(estimateID, `{"status":"declined","lostReason":"Went with a competitor"}`)
+	if status != http.StatusOK || declined.Estimate.Status != "declined" || declined.Estimate.LostReason == nil || *declined.Estimate.LostReason != "Went with a competitor" {
+		t.Fatalf("expected declined estimate with lost reason, got %d (%s)", status, string(body))
+	}
+
+	status, body = request(t, env.router, http.MethodPost, "/api/estimates/"+estimateID+"/convert", nil, cookie, csrf, withIdempotency("status-lifecycle-convert-declined"))
+	if status != http.StatusConflict || parseErrorCode(t, body) != "estimate_not_convertible" {
+		t.Fatalf("expected 409 converting a declined estimate, got %d (%s)", status, string(body))
+	}
+
+	status, reopened, body := patchStatus(estimateID, `{"status":"draft"}`)
+	if status != http.StatusOK || reopened.Estimate.Status != "draft" || reopened.Estimate.LostReason != nil || reopened.Estimate.SentAt != nil {
+		t.Fatalf("expected reopened draft without lost reason, got %d (%s)", status, string(body))
+	}
+	if status, _, body = patchStatus(estimateID, `{"status":"sent"}`); status != http.StatusOK {
+		t.Fatalf("expected 200 re-sending, got %d (%s)", status, string(body))
+	}
+	if status, _, body = patchStatus(estimateID, `{"status":"accepted"}`); status != http.StatusOK {
+		t.Fatalf("expected 200 accepting, got %d (%s)", status, string(body))
+	}
+	status, body = request(t, env.router, http.MethodPost, "/api/estimates/"+estimateID+"/convert", nil, cookie, csrf, withIdempotency("status-lifecycle-convert"))
+	if status != http.StatusCreated {
+		t.Fatalf("expected 201 converting an accepted estimate, got %d (%s)", status, string(body))
+	}
+	status, _, body = patchStatus(estimateID, `{"status":"draft"}`)
+	if status != http.StatusConflict || parseErrorCode(t, body) != "invalid_status_transition" {
+		t.Fatalf("expected converted estimates to be final, got %d (%s)", status, string(body))
+	}
+
+	// A sent estimate past its validity expires on the next read.
+	staleID := createEstimate(t, env.router, cookie, csrf, "status-lifecycle-2")
+	if status, _, body = patchStatus(staleID, `{"status":"sent"}`); status != http.StatusOK {
+		t.Fatalf("expected 200 sending, got %d (%s)", status, string(body))
+	}
+	if _, err := env.pool.Exec(ctx, `UPDATE estimates SET expires_at = NOW() - INTERVAL '1 minute' WHERE id = $1`, staleID); err != nil {
+		t.Fatalf("backdate expiry: %v", err)
+	}
+	status, body = request(t, env.router, http.MethodGet, "/api/estimates?status=expired", nil, cookie, "")
+	if status != http.StatusOK || !strings.Contains(string(body), staleID) {
+		t.Fatalf("expected stale estimate in expired list, got %d (%s)", status, string(body))
+	}
+
+	var manualTransitions, automaticTransitions int
+	if err := env.pool.QueryRow(ctx, `
+		SELECT
+		  COUNT(*) FILTER (WHERE user_id IS NOT NULL),
+		  COUNT(*) FILTER (WHERE user_id IS NULL AND metadata->>'to' = 'expired')
+		FROM audit_log
+		WHERE tenant_id = $1 AND action = 'estimate.status_change'
+	`, tenantID).Scan(&manualTransitions, &automaticTransitions); err != nil {
+		t.Fatalf("count status audit rows: %v", err)
+	}
+	// sent, declined, draft, sent, accepted, converted and the second sent.
+	if manualTransitions != 7 || automaticTransitions != 1 {
+		t.Fatalf("expected 7 manual and 1 automatic transition audit rows, got %d/%d", manualTransitions, automaticTransitions)
+	}
+}
+
+func TestEstimateExpiryIsThrottledOnReads(t *testing.T) {
+	env := setupTestEnvWithConfig(t, func(cfg *config.Config) {
+		cfg.EstimateExpiryInterval = time.Hour
+	})
+	ctx := context.Background()
+
+	seedTenantUser(t, ctx, env.pool, "tenant-estimate-expiry", "Tenant Estimate Expiry", "estimate-expiry@example.com", "Password123!", []string{"estimates.read", "estimates.write", "estimates.convert"})
+	cookie := login(t, env.router, "estimate-expiry@example.com", "Password123!")
+	csrf := csrfToken(t, env.router, cookie)
+
+	estimateID := createEstimate(t, env.router, cookie, csrf, "estimate-expiry-1")
+	status, body := request(t, env.router, http.MethodPatch, "/api/estimates/"+estimateID, []byte(`{"status":"sent"}`), cookie, csrf)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 sending, got %d (%s)", status, string(body))
+	}
+	if _, err := env.pool.Exec(ctx, `UPDATE estimates SET expires_at = NOW() - INTERVAL '1 minute' WHERE id = $1`, estimateID); err != nil {
+		t.Fatalf("backdate expiry: %v", err)
+	}
+
+	// The send just expired the tenant's estimates, so reads within the
+	// interval skip the write.
+	status, body = request(t, env.router, http.MethodGet, "/api/estimates/"+estimateID, nil, cookie, "")
+	if status != http.StatusOK || !strings.Contains(string(body), `"status":"sent"`) {
+		t.Fatalf("expected the read to skip expiry within the interval, got %d (%s)", status, string(body))
+	}
+
+	// Writes are not throttled: a lapsed estimate cannot be converted.
+	status, body = request(t, env.router, http.MethodPost, "/api/estimates/"+estimateID+"/convert", nil, cookie, csrf, withIdempotency("estimate-expiry-convert"))
+	if status != http.StatusConflict || parseErrorCode(t, body) != "estimate_not_convertible" {
+		t.Fatalf("expected 409 converting a lapsed estimate, got %d (%s)", status, string(body))
+	}
+	status, body = request(t, env.router, http.MethodGet, "/api/estimates/"+estimateID, nil, cookie, "")
+	if status != http.StatusOK || !strings.Contains(string(body), `"status":"expired"`) {
+		t.Fatalf("expected the estimate expired by the write, got %d (%s)", status, string(body))
+	}
+}
+
+func TestEstimateInventoryLineItemsRecomputeTotals(t *testing.T) {
+	env := setupTestEnv(t)
+	ctx := context.Background()
+
+	tenantID, _ := seedTenantUser(t, ctx, env.pool, "tenant-estimate-inventory", "Tenant Estimate Inventory", "estimate-inventory@example.com", "Password123!", []string{"estimates.read", "estimates.write", "settings.manage"})
+	seedUserInTenant(t, ctx, env.pool, tenantID, "estimate-inventory-sales@example.com", "Password123!", []string{"estimates.read", "estimates.write"})
+
+	cookie := login(t, env.router, "estimate-inventory@example.com", "Password123!")
+	csrf := csrfToken(t, env.router, cookie)
+
+	status, body := request(t, env.router, http.MethodPost, "/api/inventory-items", []byte(`{"name":"Sofa","defaultRoom":"Living Room","cubicFeet":35}`), cookie, csrf)
+	if status != http.StatusCreated {
+		t.Fatalf("expected 201 creating catalog item, got %d (%s)", status, string(body))
+	}
+	var catalog struct {
+		Item struct {
+			ID        string `json:"id"`
+			WeightLbs int    `json:"weightLbs"`
+		} `json:"item"`
+	}
+	if err := json.Unmarshal(body, &catalog); err != nil {
+		t.Fatalf("parse catalog item: %v", err)
+	}
+	if catalog.Item.WeightLbs != 245 {
+		t.Fatalf("expected weight to default to 7 lbs per cubic foot, got %d", catalog.Item.WeightLbs)
+	}
+	status, body = request(t, env.router, http.MethodPost, "/api/inventory-items", []byte(`{"name":"sofa","cubicFeet":30}`), cookie, csrf)
+	if status != http.StatusConflict || parseErrorCode(t, body) != "inventory_item_name_conflict" {
+		t.Fatalf("expected 409 for duplicate catalog name, got %d (%s)", status, string(body))
+	}
+
+	salesCookie := login(t, env.router, "estimate-inventory-sales@example.com", "Password123!")
+	salesCSRF := csrfToken(t, env.router, salesCookie)
+	status, body = request(t, env.router, http.MethodPost, "/api/inventory-items", []byte(`{"name":"Desk","cubicFeet":20}`), salesCookie, salesCSRF)
+	if status != http.StatusForbidden {
+		t.Fatalf("expected 403 managing the catalog without settings.manage, got %d (%s)", status, string(body))
+	}
+
+	estimateID := createEstimate(t, env.router, salesCookie, salesCSRF, "estimate-inventory-1")
+	type lineItemPayload struct {
+		LineItem struct {
+			ID        string `json:"id"`
+			Room      string `json:"room"`
+			ItemName  string `json:"itemName"`
+			CubicFeet int    `json:"cubicFeet"`
+		} `json:"lineItem"`
+		TotalCubicFeet int `json:"totalCubicFeet"`
+		TotalWeightLbs int `json:"totalWeightLbs"`
+	}
+	addLineItem := func(payload string) (int, lineItemPayload, []byte) {
+		status, body := request(t, env.router, http.MethodPost, "/api/estimates/"+estimateID+"/line-items", []byte(payload), salesCookie, salesCSRF)
+		var parsed lineItemPayload
+		if status == http.StatusCreated {
+			if err := json.Unmarshal(body, &parsed); err != nil {
+				t.Fatalf("parse line item: %v", err)
+			}
+		}
+		return status, parsed, body
+	}
+
+	status, sofa, body := addLineItem(`{"inventoryItemId":"` + catalog.Item.ID + `","quantity":2}`)
+	if status != http.StatusCreated || sofa.LineItem.ItemName != "Sofa" || sofa.LineItem.Room != "Living Room" {
+		t.Fatalf("expected catalog defaults on line item, got %d (%s)", status, string(body))
+	}
+	if sofa.TotalCubicFeet != 70 || sofa.TotalWeightLbs != 490 {
+		t.Fatalf("expected totals 70/490, got %d/%d", sofa.TotalCubicFeet, sofa.TotalWeightLbs)
+	}
+	status, _, body = addLineItem(`{"itemName":"Boxes"}`)
+	if status != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a free-form item without room and size, got %d (%s)", status, string(body))
+	}
+	status, boxes, body := addLineItem(`{"room":"Kitchen","itemName":"Boxes","quantity":10,"cubicFeet":3,"weightLbs":30}`)
+	if status != http.StatusCreated || boxes.TotalCubicFeet != 100 || boxes.TotalWeightLbs != 790 {
+		t.Fatalf("expected totals 100/790, got %d (%s)", status, string(body))
+	}
+
+	status, body = request(t, env.router, http.MethodPatch, "/api/estimates/"+estimateID+"/line-items/"+boxes.LineItem.ID, []byte(`{"quantity":5}`), salesCookie, salesCSRF)
+	if status != http.StatusOK || !strings.Contains(string(body), `"totalCubicFeet":85`) {
+		t.Fatalf("expected recomputed totals after update, got %d (%s)", status, string(body))
+	}
+	status, body = request(t, env.router, http.MethodDelete, "/api/estimates/"+estimateID+"/line-items/"+sofa.LineItem.ID, nil, salesCookie, salesCSRF)
+	if status != http.StatusNoContent {
+		t.Fatalf("expected 204 removing line item, got %d (%s)", status, string(body))
+	}
+
+	status, body = request(t, env.router, http.MethodGet, "/api/estimates/"+estimateID, nil, salesCookie, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 loading estimate, got %d (%s)", status, string(body))
+	}
+	var estimate struct {
+		Estimate struct {
+			TotalCubicFeet int `json:"totalCubicFeet"`
+			TotalWeightLbs int `json:"totalWeightLbs"`
+		} `json:"estimate"`
+	}
+	if err := json.Unmarshal(body, &estimate); err != nil {
+		t.Fatalf("parse estimate: %v", err)
+	}
+	if estimate.Estimate.TotalCubicFeet != 15 || estimate.Estimate.TotalWeightLbs != 150 {
+		t.Fatalf("expected estimate totals 15/150, got %d/%d", estimate.Estimate.TotalCubicFeet, estimate.Estimate.TotalWeightLbs)
+	}
+
+	// Deleting a catalog item leaves existing line items untouched.
+	status, body = request(t, env.router, http.MethodDelete, "/api/inventory-items/"+catalog.Item.ID, nil, cookie, csrf)
+	if status != http.StatusNoContent {
+		t.Fatalf("expected 204 deleting catalog item, got %d (%s)", status, string(body))
+	}
+	status, body = request(t, env.router, http.MethodGet, "/api/estimates/"+estimateID+"/line-items", nil, salesCookie, "")
+	if status != http.StatusOK || !strings.Contains(string(body), `"itemName":"Boxes"`) || !strings.Contains(string(body), `"totalCubicFeet":15`) {
+		t.Fatalf("expected remaining line item and totals, got %d (%s)", status, string(body))
+	}
+
+	seedTenantUser(t, ctx, env.pool, "tenant-estimate-inventory-other", "Tenant Estimate Inventory Other", "estimate-inventory-other@example.com", "Password123!", []string{"estimates.read", "estimates.write"})
+	otherCookie := login(t, env.router, "estimate-inventory-other@example.com", "Password123!")
+	status, body = request(t, env.router, http.MethodGet, "/api/estimates/"+estimateID+"/line-items", nil, otherCookie, "")
+	if status != http.StatusNotFound {
+		t.Fatalf("expected 404 for another tenant's line items, got %d (%s)", status, string(body))
+	}
+
+	// Items at the limits sum past a 32-bit total.
+	var heavy lineItemPayload
+	for i := 0; i < 22; i++ {
+		status, heavy, body = addLineItem(`{"room":"Warehouse","itemName":"Crate","quantity":1000,"cubicFeet":10000,"weightLbs":100000}`)
+		if status != http.StatusCreated {
+			t.Fatalf("expected 201 for an item at the limits, got %d (%s)", status, string(body))
+		}
+	}
+	if heavy.TotalCubicFeet != 15+22*10_000_000 || heavy.TotalWeightLbs != 150+22*100_000_000 {
+		t.Fatalf("expected totals past 2^31, got %d/%d", heavy.TotalCubicFeet, heavy.TotalWeightLbs)
+	}
+}
+
+func TestEstimatePricingFromTariffAndManualOverride(t *testing.T) {
+	env := setupTestEnv(t)
+	ctx := context.Background()
+
+	seedTenantUser(t, ctx, env.pool, "tenant-estimate-pricing", "Tenant Estimate Pricing", "estimate-pricing@example.com", "Password123!", []string{"estimates.read", "estimates.write", "settings.manage"})
+
+	cookie := login(t, env.router, "estimate-pricing@example.com", "Password123!")
+	csrf := csrfToken(t, env.router, cookie)
+
+	estimateID := createEstimate(t, env.router, cookie, csrf, "estimate-pricing-1")
+	status, body := request(t, env.router, http.MethodPost, "/api/estimates/"+estimateID+"/price", []byte(`{"miles":10,"crewSize":2,"laborHours":4}`), cookie, csrf)
+	if status != http.StatusConflict || parseErrorCode(t, body) != "tariff_not_configured" {
+		t.Fatalf("expected 409 without a tariff, got %d (%s)", status, string(body))
+	}
+
+	tariff := `{
+		"minimumHours": 3,
+		"minimumChargeCents": 50000,
+		"longDistanceThresholdMiles": 100,
+		"perMileCents": 150,
+		"perCwtCents": 4500,
+		"fuelSurchargeBps": 1000,
+		"crewRates": [{"crewSize": 2, "hourlyRateCents": 14000}, {"crewSize": 3, "hourlyRateCents": 18500}],
+		"accessorials": [{"code": "stairs", "name": "Stairs", "unit": "flight", "rateCents": 7500}, {"code": "piano", "name": "Piano", "unit": "item", "rateCents": 25000}],
+		"valuationOptions": [{"code": "full_value", "name": "Full value protection", "centsPerThousand": 1000, "minimumCentsPerLb": 600}]
+	}`
+	status, body = request(t, env.router, http.MethodPut, "/api/settings/tariff", []byte(tariff), cookie, csrf)
+	if status != http.StatusOK || !strings.Contains(string(body), `"configured":true`) {
+		t.Fatalf("expected 200 saving tariff, got %d (%s)", status, string(body))
+	}
+	status, body = request(t, env.router, http.MethodPut, "/api/settings/tariff", []byte(strings.Replace(tariff, `"code": "piano"`, `"code": "stairs"`, 1)), cookie, csrf)
+	if status != http.StatusBadRequest {
+		t.Fatalf("expected 400 for duplicate accessorial codes, got %d (%s)", status, string(body))
+	}
+
+	type pricedEstimate struct {
+		Estimate struct {
+			EstimatedTotalCents *int64  `json:"estimatedTotalCents"`
+			PriceSource         *string `json:"priceSource"`
+			PriceBreakdown      *struct {
+				Mode         string `json:"mode"`
+				LaborMinutes int    `json:"laborMinutes"`
+				TotalCents   int64  `json:"totalCents"`
+				Lines        []struct {
+					Kind        string `json:"kind"`
+					AmountCents int64  `json:"amountCents"`
+				} `json:"lines"`
+			} `json:"priceBreakdown"`
+		} `json:"estimate"`
+	}
+	price := func(payload string) (int, pricedEstimate, []byte) {
+		status, body := request(t, env.router, http.MethodPost, "/api/estimates/"+estimateID+"/price", []byte(payload), cookie, csrf)
+		var parsed pricedEstimate
+		if status == http.StatusOK {
+			if err := json.Unmarshal(body, &parsed); err != nil {
+				t.Fatalf("parse priced estimate: %v", err)
+			}
+		}
+		return status, parsed, body
+	}
+
+	// 2 h billed at the 3 h minimum: $555, fuel 10% $55.50, one flight of stairs $75.
+	status, local, body := price(`{"miles":12,"crewSize":3,"laborHours":2,"accessorials":[{"code":"stairs","quantity":1}]}`)
+	if status != http.StatusOK || local.Estimate.PriceBreakdown == nil {
+		t.Fatalf("expected 200 pricing a local move, got %d (%s)", status, string(body))
+	}
+	if local.Estimate.PriceBreakdown.Mode != "local" || local.Estimate.PriceBreakdown.LaborMinutes != 180 || len(local.Estimate.PriceBreakdown.Lines) != 3 {
+		t.Fatalf("unexpected local breakdown %s", string(body))
+	}
+	if local.Estimate.EstimatedTotalCents == nil || *local.Estimate.EstimatedTotalCents != 68550 || local.Estimate.PriceSource == nil || *local.Estimate.PriceSource != "tariff" {
+		t.Fatalf("expected tariff total 68550, got %s", string(body))
+	}
+
+	status, _, body = price(`{"miles":12,"crewSize":2,"laborHours":2,"accessorials":[{"code":"hot_tub","quantity":1}]}`)
+	if status != http.StatusBadRequest || parseErrorCode(t, body) != "unknown_accessorial" {
+		t.Fatalf("expected 400 unknown_accessorial, got %d (%s)", status, string(body))
+	}
+	status, _, body = price(`{"miles":400}`)
+	if status != http.StatusBadRequest || parseErrorCode(t, body) != "weight_required" {
+		t.Fatalf("expected 400 weight_required for a long-distance move without inventory, got %d (%s)", status, string(body))
+	}
+
+	status, body = request(t, env.router, http.MethodPost, "/api/estimates/"+estimateID+"/line-items", []byte(`{"room":"Garage","itemName":"Boxes","quantity":100,"cubicFeet":3,"weightLbs":25}`), cookie, csrf)
+	if status != http.StatusCreated {
+		t.Fatalf("expected 201 adding inventory, got %d (%s)", status, string(body))
+	}
+	// 2,500 lbs: 25 cwt x $45 + 400 mi x $1.50 = $1,725, fuel $172.50,
+	// valuation on the $15,000 weight minimum = 15 x $10.
+	status, longDistance, body := price(`{"miles":400,"valuationCode":"full_value"}`)
+	if status != http.StatusOK || longDistance.Estimate.PriceBreakdown == nil || longDistance.Estimate.PriceBreakdown.Mode != "long_distance" {
+		t.Fatalf("expected long-distance price, got %d (%s)", status, string(body))
+	}
+	if *longDistance.Estimate.EstimatedTotalCents != 172500+17250+15000 {
+		t.Fatalf("unexpected long-distance total %d", *longDistance.Estimate.EstimatedTotalCents)
+	}
+
+	status, body = request(t, env.router, http.MethodPatch, "/api/estimates/"+estimateID, []byte(`{"estimatedTotalCents":180000}`), cookie, csrf)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 overriding the price, got %d (%s)", status, string(body))
+	}
+	var overridden pricedEstimate
+	if err := json.Unmarshal(body, &overridden); err != nil {
+		t.Fatalf("parse overridden estimate: %v", err)
+	}
+	if *overridden.Estimate.EstimatedTotalCents != 180000 || *overridden.Estimate.PriceSource != "manual" || overridden.Estimate.PriceBreakdown == nil {
+		t.Fatalf("expected manual override keeping the last breakdown, got %s", string(body))
+	}
+}
+
+func TestEstimateRevisionsDiffAndRestore(t *testing.T) {
+	env := setupTestEnv(t)
+	ctx := context.Background()
+
+	seedTenantUser(t, ctx, env.pool, "tenant-estimate-revisions", "Tenant Estimate Revisions", "estimate-revisions@example.com", "Password123!", []string{"estimates.read", "estimates.write"})
+
+	cookie := login(t, env.router, "estimate-revisions@example.com", "Password123!")
+	csrf := csrfToken(t, env.router, cookie)
+
+	estimateID := createEstimate(t, env.router, cookie, csrf, "estimate-revisions-1")
+	status, body := request(t, env.router, http.MethodPatch, "/api/estimates/"+estimateID, []byte(`{"notes":"Third floor walk-up","estimatedTotalCents":120000}`), cookie, csrf)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 updating estimate, got %d (%s)", status, string(body))
+	}
+	status, body = request(t, env.router, http.MethodPost, "/api/estimates/"+estimateID+"/line-items", []byte(`{"room":"Living room","itemName":"Sofa","quantity":2,"cubicFeet":35,"weightLbs":245}`), cookie, csrf)
+	if status != http.StatusCreated {
+		t.Fatalf("expected 201 adding line item, got %d (%s)", status, string(body))
+	}
+	status, body = request(t, env.router, http.MethodPatch, "/api/estimates/"+estimateID, []byte(`{"status":"sent"}`), cookie, csrf)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 sending estimate, got %d (%s)", status, string(body))
+	}
+	status, body = request(t, env.router, http.MethodPatch, "/api/estimates/"+estimateID, []byte(`{"notes":"Third floor walk-up"}`), cookie, csrf)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for a no-op update, got %d (%s)", status, string(body))
+	}
+
+	type revisionSummary struct {
+		RevisionNumber int     `json:"revisionNumber"`
+		Change         string  `json:"change"`
+		RestoredFrom   *int    `json:"restoredFrom"`
+		CreatedByName  *string `json:"createdByName"`
+	}
+	listRevisions := func() []revisionSummary {
+		status, body := request(t, env.router, http.MethodGet, "/api/estimates/"+estimateID+"/revisions", nil, cookie, "")
+		if status != http.StatusOK {
+			t.Fatalf("expected 200 listing revisions, got %d (%s)", status, string(body))
+		}
+		var parsed struct {
+			Items []revisionSummary `json:"items"`
+		}
+		if err := json.Unmarshal(body, &parsed); err != nil {
+			t.Fatalf("parse revisions: %v", err)
+		}
+		return parsed.Items
+	}
+
+	revisions := listRevisions()
+	wantChanges := []string{"status_changed", "line_items", "updated", "created"}
+	if len(revisions) != len(wantChanges) {
+		t.Fatalf("expected %d revisions without one for the no-op update, got %+v", len(wantChanges), revisions)
+	}
+	for i, want := range wantChanges {
+		if revisions[i].Change != want || revisions[i].RevisionNumber != len(wantChanges)-i || revisions[i].CreatedByName == nil {
+			t.Fatalf("revision %d: expected %s with an author, got %+v", i, want, revisions[i])
+		}
+	}
+
+	type revisionDiff struct {
+		Diff struct {
+			FromRevision int `json:"fromRevision"`
+			ToRevision   int `json:"toRevision"`
+			Fields       []struct {
+				Field string `json:"field"`
+				From  any    `json:"from"`
+				To    any    `json:"to"`
+			} `json:"fields"`
+			LineItemsAdded   []map[string]any `json:"lineItemsAdded"`
+			LineItemsRemoved []map[string]any `json:"lineItemsRemoved"`
+		} `json:"diff"`
+	}
+	diff := func(path string) revisionDiff {
+		status, body := request(t, env.router, http.MethodGet, "/api/estimates/"+estimateID+"/revisions/"+path, nil, cookie, "")
+		if status != http.StatusOK {
+			t.Fatalf("expected 200 diffing %s, got %d (%s)", path, status, string(body))
+		}
+		var parsed revisionDiff
+		if err := json.Unmarshal(body, &parsed); err != nil {
+			t.Fatalf("parse diff: %v", err)
+		}
+		return parsed
+	}
+
+	updateDiff := diff("2/diff")
+	changedFields := map[string]bool{}
+	for _, field := range updateDiff.Diff.Fields {
+		changedFields[field.Field] = true
+	}
+	if updateDiff.Diff.FromRevision != 1 || !changedFields["notes"] || !changedFields["estimatedTotalCents"] || changedFields["updatedAt"] || changedFields["customerName"] {
+		t.Fatalf("unexpected diff for the update: %+v", updateDiff.Diff)
+	}
+	lineItemDiff := diff("4/diff?against=2")
+	if len(lineItemDiff.Diff.LineItemsAdded) != 1 || len(lineItemDiff.Diff.LineItemsRemoved) != 0 {
+		t.Fatalf("expected one added line item between revisions 2 and 4, got %+v", lineItemDiff.Diff)
+	}
+
+	status, body = request(t, env.router, http.MethodGet, "/api/estimates/"+estimateID+"/revisions/99", nil, cookie, "")
+	if status != http.StatusNotFound || parseErrorCode(t, body) != "estimate_revision_not_found" {
+		t.Fatalf("expected 404 for a missing revision, got %d (%s)", status, string(body))
+	}
+
+	status, body = request(t, env.router, http.MethodPost, "/api/estimates/"+estimateID+"/revisions/1/restore", nil, cookie, csrf)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 restoring revision 1, got %d (%s)", status, string(body))
+	}
+	var restored struct {
+		Estimate struct {
+			Status              string  `json:"status"`
+			Notes               *string `json:"notes"`
+			EstimatedTotalCents *int64  `json:"estimatedTotalCents"`
+			TotalWeightLbs      int     `json:"totalWeightLbs"`
+		} `json:"estimate"`
+	}
+	if err := json.Unmarshal(body, &restored); err != nil {
+		t.Fatalf("parse restored estimate: %v", err)
+	}
+	if restored.Estimate.Status != "sent" || restored.Estimate.Notes != nil || restored.Estimate.EstimatedTotalCents != nil || restored.Estimate.TotalWeightLbs != 0 {
+		t.Fatalf("expected revision 1 content with the current status, got %s", string(body))
+	}
+
+	revisions = listRevisions()
+	if len(revisions) != 5 || revisions[0].Change != "restored" || revisions[0].RestoredFrom == nil || *revisions[0].RestoredFrom != 1 {
+		t.Fatalf("expected restore recorded as revision 5, got %+v", revisions)
+	}
+	restoreDiff := diff("5/diff")
+	if len(restoreDiff.Diff.LineItemsRemoved) != 1 {
+		t.Fatalf("expected the restore to remove the line item, got %+v", restoreDiff.Diff)
+	}
+}
+
+func TestEstimateCreateMatchesExistingCustomer(t *testing.T) {
+	env := setupTestEnv(t)
+	ctx := context.Background()
+
+	seedTenantUser(t, ctx, env.pool, "tenant-estimate-customer-match", "Tenant Estimate Customer Match", "estimate-customer-match@example.com", "Password123!", []string{"estimates.read", "estimates.write"})
+
+	cookie := login(t, env.router, "estimate-customer-match@example.com", "Password123!")
+	csrf := csrfToken(t, env.router, cookie)
+
+	type matchResponse struct {
+		Estimate struct {
+			ID         string `json:"id"`
+			CustomerID string `json:"customerId"`
+		} `json:"estimate"`
+		CustomerMatch *struct {
+			CustomerID         string `json:"customerId"`
+			MatchedOn          string `json:"matchedOn"`
+			PossibleDuplicates []struct {
+				CustomerID string `json:"customerId"`
+			} `json:"possibleDuplicates"`
+		} `json:"customerMatch"`
+	}
+	create := func(key string, overrides map[string]any) (int, matchResponse, []byte) {
+		var payload map[string]any
+		if err := json.Unmarshal(estimatePayload("Integration Customer"), &payload); err != nil {
+			t.Fatalf("build payload: %v", err)
+		}
+		for field, value := range overrides {
+			payload[field] = value
+		}
+		raw, _ := json.Marshal(payload)
+		status, body := request(t, env.router, http.MethodPost, "/api/estimates", raw, cookie, csrf, withIdempotency(key))
+		var parsed matchResponse
+		if status == http.StatusCreated {
+			if err := json.Unmarshal(body, &parsed); err != nil {
+				t.Fatalf("parse estimate: %v", err)
+			}
+			if parsed.CustomerMatch == nil || parsed.CustomerMatch.CustomerID != parsed.Estimate.CustomerID {
+				t.Fatalf("expected customerMatch for the estimate's customer, got %s", string(body))
+			}
+		}
+		return status, parsed, body
+	}
+
+	status, first, body := create("customer-match-1", nil)
+	if status != http.StatusCreated || first.CustomerMatch.MatchedOn != "none" {
+		t.Fatalf("expected a new customer for the first estimate, got %d (%s)", status, string(body))
+	}
+	customerID := first.Estimate.CustomerID
+
+	status, byEmail, body := create("customer-match-2", map[string]any{"email": " Customer@Example.com", "primaryPhone": "512-555-0199"})
+	if status != http.StatusCreated || byEmail.CustomerMatch.MatchedOn != "email" || byEmail.Estimate.CustomerID != customerID {
+		t.Fatalf("expected an email match to the first customer, got %d (%s)", status, string(body))
+	}
+
+	status, byPhone, body := create("customer-match-3", map[string]any{"email": "other@example.com", "primaryPhone": "1 (555) 0100", "customerName": "integration  customer"})
+	if status != http.StatusCreated || byPhone.CustomerMatch.MatchedOn != "phone" || byPhone.Estimate.CustomerID != customerID {
+		t.Fatalf("expected a phone and name match to the first customer, got %d (%s)", status, string(body))
+	}
+
+	status, fuzzy, body := create("customer-match-4", map[string]any{"email": "jamie@example.com", "customerName": "Jamie Customer"})
+	if status != http.StatusCreated || fuzzy.CustomerMatch.MatchedOn != "none" || fuzzy.Estimate.CustomerID == customerID {
+		t.Fatalf("expected a new customer for a phone match with another name, got %d (%s)", status, string(body))
+	}
+	if len(fuzzy.CustomerMatch.PossibleDuplicates) != 1 || fuzzy.CustomerMatch.PossibleDuplicates[0].CustomerID != customerID {
+		t.Fatalf("expected the first customer as a possible duplicate, got %s", string(body))
+	}
+
+	status, explicit, body := create("customer-match-5", map[string]any{"customerId": fuzzy.Estimate.CustomerID})
+	if status != http.StatusCreated || explicit.CustomerMatch.MatchedOn != "customer_id" || explicit.Estimate.CustomerID != fuzzy.Estimate.CustomerID {
+		t.Fatalf("expected the requested customer, got %d (%s)", status, string(body))
+	}
+
+	status, _, body = create("customer-match-6", map[string]any{"customerId": "00000000-0000-0000-0000-000000000001"})
+	if status != http.StatusNotFound || parseErrorCode(t, body) != "customer_not_found" {
+		t.Fatalf("expected 404 for an unknown customer, got %d (%s)", status, string(body))
+	}
+
+	status, body = request(t, env.router, http.MethodPost, "/api/estimates", estimatePayload("Integration Customer"), cookie, csrf, withIdempotency("customer-match-1"))
+	if status != http.StatusOK || strings.Contains(string(body), "customerMatch") {
+		t.Fatalf("expected an idempotent replay without customerMatch, got %d (%s)", status, string(body))
+	}
+
+	customerEmail := func(id string) string {
+		var email string
+		if err := env.pool.QueryRow(ctx, `SELECT COALESCE(email, '') FROM customers WHERE id = $1`, id).Scan(&email); err != nil {
+			t.Fatalf("load customer email: %v", err)
+		}
+		return strings.ToLower(strings.TrimSpace(email))
+	}
+
+	// The shared customer keeps its details whatever happens to one of its
+	// estimates' copies.
+	status, body = request(t, env.router, http.MethodPatch, "/api/estimates/"+byPhone.Estimate.ID, []byte(`{"notes":"Call ahead"}`), cookie, csrf)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for a notes edit, got %d (%s)", status, string(body))
+	}
+	status, body = request(t, env.router, http.MethodPatch, "/api/estimates/"+byPhone.Estimate.ID, []byte(`{"email":"renamed@example.com"}`), cookie, csrf)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for an email edit, got %d (%s)", status, string(body))
+	}
+	if email := customerEmail(customerID); email != "customer@example.com" {
+		t.Fatalf("expected the shared customer to keep its email, got %q", email)
+	}
+
+	status, solo, body := create("customer-match-7", map[string]any{"email": "solo@example.com", "primaryPhone": "512-555-0777", "customerName": "Solo Customer"})
+	if status != http.StatusCreated || solo.CustomerMatch.MatchedOn != "none" {
+		t.Fatalf("expected a new customer for the solo estimate, got %d (%s)", status, string(body))
+	}
+	status, body = request(t, env.router, http.MethodPatch, "/api/estimates/"+solo.Estimate.ID, []byte(`{"email":"customer@example.com"}`), cookie, csrf)
+	if status != http.StatusConflict || parseErrorCode(t, body) != "customer_email_conflict" {
+		t.Fatalf("expected 409 customer_email_conflict, got %d (%s)", status, string(body))
+	}
+	status, body = request(t, env.router, http.MethodPatch, "/api/estimates/"+solo.Estimate.ID, []byte(`{"email":"solo-new@example.com"}`), cookie, csrf)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for a solo email edit, got %d (%s)", status, string(body))
+	}
+	if email := customerEmail(solo.Estimate.CustomerID); email != "solo-new@example.com" {
+		t.Fatalf("expected the sole estimate's customer to follow the edit, got %q", email)
+	}
+}
+
+func TestEstimateDocumentRendersWithTenantTemplate(t *testing.T) {
+	env := setupTestEnv(t)
+	ctx := context.Background()
+
+	tenantID, _ := seedTenantUser(t, ctx, env.pool, "tenant-estimate-document", "Tenant Estimate Document", "estimate-document@example.com", "Password123!", []string{"estimates.read", "estimates.write", "settings.manage"})
+
+	cookie := login(t, env.router, "estimate-document@example.com", "Password123!")
+	csrf := csrfToken(t, env.router, cookie)
+
+	const onePixelPNG = "iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAYAAAAfFcSJAAAADUlEQVR42mP8z8DwHwAFBQIAX8jx0gAAAABJRU5ErkJggg=="
+	status, body := request(t, env.router, http.MethodPut, "/api/settings/estimate-document", []byte(`{"accentColor":"#ABCDEF"}`), cookie, csrf)
+	if status != http.StatusOK {
+		t.Fatalf("update template expected 200, got %d (%s)", status, string(body))
+	}
+	template, _ := json.Marshal(map[string]any{
+		"companyName": "Acme Movers",
+		"accentColor": "#aa3300",
+		"introText":   "Thanks {{customerName}} for considering {{companyName}}.",
+		"termsText":   "Estimate {{estimateNumber}} is valid until {{validUntil}}.",
+		"footerText":  "Acme Movers, licensed and insured",
+		"logo":        onePixelPNG,
+	})
+	status, body = request(t, env.router, http.MethodPut, "/api/settings/estimate-document", template, cookie, csrf)
+	if status != http.StatusOK {
+		t.Fatalf("update template expected 200, got %d (%s)", status, string(body))
+	}
+	var saved struct {
+		Template struct {
+			AccentColor     string  `json:"accentColor"`
+			HasLogo         bool    `json:"hasLogo"`
+			LogoContentType *string `json:"logoContentType"`
+			ShowLineItems   bool    `json:"showLineItems"`
+		} `json:"template"`
+	}
+	if err := json.Unmarshal(body, &saved); err != nil {
+		t.Fatalf("parse template: %v", err)
+	}
+	if saved.Template.AccentColor != "#aa3300" || !saved.Template.HasLogo || saved.Template.LogoContentType == nil || *saved.Template.LogoContentType != "image/png" || !saved.Template.ShowLineItems {
+		t.Fatalf("unexpected template %s", string(body))
+	}
+
+	badLogo, _ := json.Marshal(map[string]any{"logo": base64.StdEncoding.EncodeToString([]byte("GIF89a not really"))})
+	status, body = request(t, env.router, http.MethodPut, "/api/settings/estimate-document", badLogo, cookie, csrf)
+	if status != http.StatusBadRequest || parseErrorCode(t, body) != "validation_error" {
+		t.Fatalf("expected a non-PNG logo to be rejected, got %d (%s)", status, string(body))
+	}
+
+	estimateID := createEstimate(t, env.router, cookie, csrf, "estimate-document-create")
+	status, body = request(t, env.router, http.MethodPost, "/api/estimates/"+estimateID+"/line-items", []byte(`{"room":"Living room","itemName":"Sofa","quantity":2,"cubicFeet":35,"weightLbs":245}`), cookie, csrf)
+	if status != http.StatusCreated {
+		t.Fatalf("add line item expected 201, got %d (%s)", status, string(body))
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/estimates/"+estimateID+"/document.pdf", nil)
+	req.RemoteAddr = "127.0.0.1:12345"
+	req.AddCookie(cookie)
+	rec := httptest.NewRecorder()
+	env.router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("render document expected 200, got %d (%s)", rec.Code, rec.Body.String())
+	}
+	if got := rec.Header().Get("Content-Type"); got != "application/pdf" {
+		t.Fatalf("expected application/pdf, got %q", got)
+	}
+	if !strings.Contains(rec.Header().Get("Content-Disposition"), ".pdf") {
+		t.Fatalf("expected a PDF filename, got %q", rec.Header().Get("Content-Disposition"))
+	}
+	if !bytes.HasPrefix(rec.Body.Bytes(), []byte("%PDF-")) || !bytes.Contains(rec.Body.Bytes(), []byte("/Subtype /Image")) {
+		t.Fatalf("expected a PDF with the logo image")
+	}
+
+	var renders int
+	if err := env.pool.QueryRow(ctx, `
+		SELECT COUNT(*)
+		FROM audit_log
+		WHERE tenant_id = $1
+		  AND action = 'estimate.document_render'
+		  AND entity_id = $2
+	`, tenantID, uuid.MustParse(estimateID)).Scan(&renders); err != nil {
+		t.Fatalf("count render audit rows: %v", err)
+	}
+	if renders != 1 {
+		t.Fatalf("expected 1 estimate.document_render audit row, got %d", renders)
+	}
+
+	status, body = request(t, env.router, http.MethodGet, "/api/estimates/"+uuid.NewString()+"/document.pdf", nil, cookie, "")
+	if status != http.StatusNotFound || parseErrorCode(t, body) != "estimate_not_found" {
+		t.Fatalf("expected 404 for a missing estimate, got %d (%s)", status, string(body))
+	}
+}
+
+func TestOutboundEmailQueuesAndRetriesThroughSMTP(t *testing.T) {
+	env := setupTestEnv(t)
+	ctx := context.Background()
+
+	tenantID, _ := seedTenantUser(t, ctx, env.pool, "tenant-email", "Tenant Email", "email@example.com", "Password123!", []string{
+		"estimates.read", "estimates.write", "estimates.convert", "jobs.read", "jobs.write", "storage.read", "storage.write", "settings.manage",
+	})
+
+	cookie := login(t, env.router, "email@example.com", "Password123!")
+	csrf := csrfToken(t, env.router, cookie)
+
+	server := mailtest.NewServer()
+	defer server.Close()
+	q := gen.New(env.pool)
+	worker := outbox.NewWorker(q, &mail.SMTPSender{Host: server.Host, Port: server.Port, TLS: mail.TLSNone, Timeout: 5 * time.Second}, audit.NewLogger(q), slog.New(slog.NewTextHandler(io.Discard, nil)), 3)
+	worker.Backoff = func(int) time.Duration { return 0 }
+	processOnce := func(want int) {
+		t.Helper()
+		claimed, err := worker.ProcessOnce(ctx)
+		if err != nil || claimed != want {
+			t.Fatalf("process outbox expected %d messages, got %d (%v)", want, claimed, err)
+		}
+	}
+
+	type emailMessage struct {
+		ID        string  `json:"id"`
+		Kind      string  `json:"kind"`
+		Status    string  `json:"status"`
+		ToAddress string  `json:"toAddress"`
+		Subject   string  `json:"subject"`
+		Attempts  int     `json:"attempts"`
+		LastError *string `json:"lastError"`
+	}
+
+	estimateID := createEstimate(t, env.router, cookie, csrf, "email-estimate-create")
+	status, body := request(t, env.router, http.MethodPost, "/api/estimates/"+estimateID+"/emails", []byte(`{}`), cookie, csrf)
+	if status != http.StatusConflict || parseErrorCode(t, body) != "email_disabled" {
+		t.Fatalf("expected 409 email_disabled, got %d (%s)", status, string(body))
+	}
+
+	status, body = request(t, env.router, http.MethodPut, "/api/settings/email", []byte(`{"enabled":true}`), cookie, csrf)
+	if status != http.StatusBadRequest || parseErrorCode(t, body) != "validation_error" {
+		t.Fatalf("expected enabling without a sender to be rejected, got %d (%s)", status, string(body))
+	}
+	status, body = request(t, env.router, http.MethodPut, "/api/settings/email", []byte(`{"enabled":true,"fromAddress":"dispatch@acme.test","templates":[{"kind":"storage_invoice","subject":"Invoice {{estimateNumber}}","body":"Hi"}]}`), cookie, csrf)
+	if status != http.StatusBadRequest || parseErrorCode(t, body) != "validation_error" {
+		t.Fatalf("expected an unknown placeholder to be rejected, got %d (%s)", status, string(body))
+	}
+	settings, _ := json.Marshal(map[string]any{
+		"enabled":     true,
+		"fromName":    "Acme Movers",
+		"fromAddress": "dispatch@acme.test",
+		"replyTo":     "office@acme.test",
+		"templates": []map[string]string{{
+			"kind":    "estimate_sent",
+			"subject": "Estimate {{estimateNumber}}\nfor {{customerName}}",
+			"body":    "Hello {{customerName}}, your total is {{estimatedTotal}}. {{companyName}}",
+		}},
+	})
+	status, body = request(t, env.router, http.MethodPut, "/api/settings/email", settings, cookie, csrf)
+	if status != http.StatusOK {
+		t.Fatalf("update email settings expected 200, got %d (%s)", status, string(body))
+	}
+	var saved struct {
+		Settings struct {
+			Enabled   bool `json:"enabled"`
+			Templates []struct {
+				Kind       string `json:"kind"`
+				Customized bool   `json:"customized"`
+			} `json:"templates"`
+		} `json:"settings"`
+	}
+	if err := json.Unmarshal(body, &saved); err != nil {
+		t.Fatalf("parse email settings: %v", err)
+	}
+	if !saved.Settings.Enabled || len(saved.Settings.Templates) != 3 || !saved.Settings.Templates[0].Customized || saved.Settings.Templates[1].Customized {
+		t.Fatalf("unexpected email settings %s", string(body))
+	}
+
+	// Sending the estimate queues it; the first delivery fails temporarily.
+	status, body = request(t, env.router, http.MethodPatch, "/api/estimates/"+estimateID, []byte(`{"status":"sent"}`), cookie, csrf)
+	if status != http.StatusOK {
+		t.Fatalf("send estimate expected 200, got %d (%s)", status, string(body))
+	}
+	var estimate struct {
+		Estimate struct {
+			EstimateNumber string        `json:"estimateNumber"`
+			LastEmail      *emailMessage `json:"lastEmail"`
+		} `json:"estimate"`
+	}
+	if err := json.Unmarshal(body, &estimate); err != nil {
+		t.Fatalf("parse estimate: %v", err)
+	}
+	if estimate.Estimate.LastEmail == nil || estimate.Estimate.LastEmail.Kind != "estimate_sent" || estimate.Estimate.LastEmail.Status != "queued" || estimate.Estimate.LastEmail.ToAddress != "customer@example.com" {
+		t.Fatalf("expected a queued estimate email, got %s", string(body))
+	}
+	wantSubject := "Estimate " + estimate.Estimate.EstimateNumber + " for Integration Customer"
+	if estimate.Estimate.LastEmail.Subject != wantSubject {
+		t.Fatalf("expected subject %q, got %q", wantSubject, estimate.Estimate.LastEmail.Subject)
+	}
+
+	server.FailNext(1, 451)
+	processOnce(1)
+	if got := server.Messages(); len(got) != 0 {
+		t.Fatalf("expected the first delivery to be rejected, got %d messages", len(got))
+	}
+	status, body = request(t, env.router, http.MethodGet, "/api/estimates/"+estimateID+"/emails", nil, cookie, "")
+	var list struct {
+		Items []emailMessage `json:"items"`
+	}
+	if status != http.StatusOK || json.Unmarshal(body, &list) != nil || len(list.Items) != 1 || list.Items[0].Status != "queued" || list.Items[0].Attempts != 1 || list.Items[0].LastError == nil {
+		t.Fatalf("expected the email to wait for a retry, got %d (%s)", status, string(body))
+	}
+
+	processOnce(1)
+	messages := server.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("expected 1 delivered message, got %d", len(messages))
+	}
+	delivered := messages[0]
+	if len(delivered.To) != 1 || delivered.To[0] != "customer@example.com" || delivered.From != "dispatch@acme.test" {
+		t.Fatalf("unexpected envelope %s -> %v", delivered.From, delivered.To)
+	}
+	if delivered.Subject() != wantSubject || !strings.Contains(delivered.Header().Get("From"), "Acme Movers") || delivered.Header().Get("Reply-To") != "<office@acme.test>" {
+		t.Fatalf("unexpected headers %q", string(delivered.Data))
+	}
+	if body := delivered.Body(); !strings.Contains(body, "Hello Integration Customer, your total is to be confirmed.") || !strings.Contains(body, "Acme Movers") {
+		t.Fatalf("unexpected body %q", body)
+	}
+	status, body = request(t, env.router, http.MethodGet, "/api/estimates/"+estimateID, nil, cookie, "")
+	if status != http.StatusOK || json.Unmarshal(body, &estimate) != nil || estimate.Estimate.LastEmail == nil || estimate.Estimate.LastEmail.Status != "sent" || estimate.Estimate.LastEmail.Attempts != 2 {
+		t.Fatalf("expected the estimate to show the sent email, got %d (%s)", status, string(body))
+	}
+
+	// Converting queues the booking confirmation with the default template.
+	status, body = request(t, env.router, http.MethodPost, "/api/estimates/"+estimateID+"/convert", nil, cookie, csrf, withIdempotency("email-convert"))
+	if status != http.StatusCreated {
+		t.Fatalf("convert expected 201, got %d (%s)", status, string(body))
+	}
+	var job struct {
+		Job struct {
+			ID        string        `json:"id"`
+			JobNumber string        `json:"jobNumber"`
+			LastEmail *emailMessage `json:"lastEmail"`
+		} `json:"job"`
+	}
+	if err := json.Unmarshal(body, &job); err != nil {
+		t.Fatalf("parse job: %v", err)
+	}
+	if job.Job.LastEmail == nil || job.Job.LastEmail.Kind != "booking_confirmation" || job.Job.LastEmail.Subject != "Your move is booked: "+job.Job.JobNumber {
+		t.Fatalf("expected a queued booking confirmation, got %s", string(body))
+	}
+	status, body = request(t, env.router, http.MethodPost, "/api/estimates/"+estimateID+"/convert", nil, cookie, csrf, withIdempotency("email-convert"))
+	if status != http.StatusOK {
+		t.Fatalf("convert replay expected 200, got %d (%s)", status, string(body))
+	}
+	processOnce(1)
+	if messages = server.Messages(); len(messages) != 2 || !strings.Contains(messages[1].Body(), job.Job.JobNumber) {
+		t.Fatalf("expected the booking confirmation to be delivered once, got %d messages", len(messages))
+	}
+
+	status, body = request(t, env.router, http.MethodPost, "/api/jobs/"+job.Job.ID+"/emails", []byte(`{"to":"not an address"}`), cookie, csrf)
+	if status != http.StatusBadRequest || parseErrorCode(t, body) != "validation_error" {
+		t.Fatalf("expected an invalid recipient to be rejected, got %d (%s)", status, string(body))
+	}
+	status, body = request(t, env.router, http.MethodPost, "/api/jobs/"+job.Job.ID+"/emails", []byte(`{"to":"ops@example.com"}`), cookie, csrf)
+	if status != http.StatusAccepted {
+		t.Fatalf("resend confirmation expected 202, got %d (%s)", status, string(body))
+	}
+	processOnce(1)
+	if messages = server.Messages(); len(messages) != 3 || messages[2].To[0] != "ops@example.com" {
+		t.Fatalf("expected the confirmation to go to the override address")
+	}
+
+	// A permanent rejection fails the message without retrying.
+	storageID := createStorageRecord(t, env.router, cookie, csrf, job.Job.ID, "Main Facility")
+	status, body = request(t, env.router, http.MethodPost, "/api/storage/"+storageID+"/emails", []byte(`{}`), cookie, csrf)
+	if status != http.StatusAccepted {
+		t.Fatalf("storage invoice expected 202, got %d (%s)", status, string(body))
+	}
+	var queued struct {
+		Email emailMessage `json:"email"`
+	}
+	if err := json.Unmarshal(body, &queued); err != nil {
+		t.Fatalf("parse queued email: %v", err)
+	}
+	if queued.Email.Kind != "storage_invoice" || queued.Email.Subject != "Storage statement for "+job.Job.JobNumber {
+		t.Fatalf("unexpected storage invoice %s", string(body))
+	}
+	server.FailNext(1, 550)
+	processOnce(1)
+	processOnce(0)
+	status, body = request(t, env.router, http.MethodGet, "/api/storage/"+storageID+"/emails", nil, cookie, "")
+	if status != http.StatusOK || json.Unmarshal(body, &list) != nil || len(list.Items) != 1 || list.Items[0].Status != "failed" || list.Items[0].Attempts != 1 {
+		t.Fatalf("expected the storage invoice to fail permanently, got %d (%s)", status, string(body))
+	}
+
+	// A worker whose lease ran out loses the message to the next claim and
+	// can neither renew nor mark it any more.
+	status, body = request(t, env.router, http.MethodPost, "/api/jobs/"+job.Job.ID+"/emails", []byte(`{}`), cookie, csrf)
+	if status != http.StatusAccepted {
+		t.Fatalf("resend confirmation expected 202, got %d (%s)", status, string(body))
+	}
+	stale, err := q.ClaimDueEmails(ctx, gen.ClaimDueEmailsParams{LeaseSeconds: 0, BatchSize: 1})
+	if err != nil || len(stale) != 1 {
+		t.Fatalf("claim with an expired lease: %d messages (%v)", len(stale), err)
+	}
+	processOnce(1)
+	if messages = server.Messages(); len(messages) != 4 {
+		t.Fatalf("expected the reclaimed message to be delivered once, got %d messages", len(messages))
+	}
+	if renewed, err := q.RenewEmailLease(ctx, gen.RenewEmailLeaseParams{ID: stale[0].ID, LeaseSeconds: 60, Attempts: stale[0].Attempts}); err != nil || renewed != 0 {
+		t.Fatalf("expected the stale claim not to renew, got %d (%v)", renewed, err)
+	}
+	if marked, err := q.MarkEmailSent(ctx, gen.MarkEmailSentParams{ID: stale[0].ID, Attempts: stale[0].Attempts}); err != nil || marked != 0 {
+		t.Fatalf("expected the stale claim not to mark the message, got %d (%v)", marked, err)
+	}
+
+	var sentAudits, failedAudits int
+	if err := env.pool.QueryRow(ctx, `
+		SELECT
+		  COUNT(*) FILTER (WHERE action = 'email.sent'),
+		  COUNT(*) FILTER (WHERE action = 'email.failed')
+		FROM audit_log
+		WHERE tenant_id = $1
+	`, tenantID).Scan(&sentAudits, &failedAudits); err != nil {
+		t.Fatalf("count email audit rows: %v", err)
+	}
+	if sentAudits != 4 || failedAudits != 1 {
+		t.Fatalf("expected 4 email.sent and 1 email.failed audit rows, got %d and %d", sentAudits, failedAudits)
+	}
+}
+
+func TestEstimateOnlineAcceptanceWithSignature(t *testing.T) {
+	env := setupTestEnv(t)
+	ctx := context.Background()
+
+	tenantID, _ := seedTenantUser(t, ctx, env.pool, "tenant-accept", "Tenant Accept", "accept@example.com", "Password123!", []string{
+		"estimates.read", "estimates.write", "estimates.convert", "jobs.read",
+	})
+	seedUserInTenant(t, ctx, env.pool, tenantID, "accept-writer@example.com", "Password123!", []string{"estimates.read", "estimates.write"})
+
+	cookie := login(t, env.router, "accept@example.com", "Password123!")
+	csrf := csrfToken(t, env.router, cookie)
+
+	type linkPayload struct {
+		Link struct {
+			ID          string `json:"id"`
+			Token       string `json:"token"`
+			URL         string `json:"url"`
+			AutoConvert bool   `json:"autoConvert"`
+		} `json:"link"`
+	}
+	type publicPayload struct {
+		Estimate struct {
+			EstimateNumber string `json:"estimateNumber"`
+			Status         string `json:"status"`
+			CustomerName   string `json:"customerName"`
+			CanAccept      bool   `json:"canAccept"`
+			Acceptance     *struct {
+				SignerName string `json:"signerName"`
+			} `json:"acceptance"`
+		} `json:"estimate"`
+	}
+	createLink := func(estimateID, payload string) linkPayload {
+		t.Helper()
+		status, body := request(t, env.router, http.MethodPost, "/api/estimates/"+estimateID+"/acceptance-links", []byte(payload), cookie, csrf)
+		if status != http.StatusCreated {
+			t.Fatalf("create acceptance link expected 201, got %d (%s)", status, string(body))
+		}
+		var link linkPayload
+		if err := json.Unmarshal(body, &link); err != nil {
+			t.Fatalf("parse acceptance link: %v", err)
+		}
+		return link
+	}
+
+	estimateID := createEstimate(t, env.router, cookie, csrf, "accept-estimate-create")
+	status, body := request(t, env.router, http.MethodPost, "/api/estimates/"+estimateID+"/acceptance-links", []byte(`{}`), cookie, csrf)
+	if status != http.StatusConflict || parseErrorCode(t, body) != "estimate_not_sent" {
+		t.Fatalf("expected a draft estimate to be refused, got %d (%s)", status, string(body))
+	}
+	status, body = request(t, env.router, http.MethodPatch, "/api/estimates/"+estimateID, []byte(`{"status":"sent"}`), cookie, csrf)
+	if status != http.StatusOK {
+		t.Fatalf("send estimate expected 200, got %d (%s)", status, string(body))
+	}
+
+	writer := login(t, env.router, "accept-writer@example.com", "Password123!")
+	status, body = request(t, env.router, http.MethodPost, "/api/estimates/"+estimateID+"/acceptance-links", []byte(`{"autoConvert":true}`), writer, csrfToken(t, env.router, writer))
+	if status != http.StatusForbidden || parseErrorCode(t, body) != "forbidden" {
+		t.Fatalf("expected auto-conversion to need estimates.convert, got %d (%s)", status, string(body))
+	}
+
+	first := createLink(estimateID, `{}`)
+	link := createLink(estimateID, `{"autoConvert":true,"expiresInDays":7}`)
+	if !link.Link.AutoConvert || !strings.HasSuffix(link.Link.URL, "/accept/"+link.Link.Token) {
+		t.Fatalf("unexpected link %+v", link.Link)
+	}
+
+	// The earlier link was revoked; a forged token is indistinguishable from
+	// an unknown one.
+	status, body = request(t, env.router, http.MethodGet, "/api/public/estimate-acceptance/"+first.Link.Token, nil, nil, "")
+	if status != http.StatusGone || parseErrorCode(t, body) != "acceptance_link_expired" {
+		t.Fatalf("expected the revoked link to be gone, got %d (%s)", status, string(body))
+	}
+	encodedID, _, _ := strings.Cut(link.Link.Token, ".")
+	_, forgedMAC, _ := strings.Cut(first.Link.Token, ".")
+	for _, token := range []string{"not-a-token", encodedID + "." + forgedMAC} {
+		status, body = request(t, env.router, http.MethodGet, "/api/public/estimate-acceptance/"+token, nil, nil, "")
+		if status != http.StatusNotFound || parseErrorCode(t, body) != "acceptance_link_not_found" {
+			t.Fatalf("expected token %q to be rejected, got %d (%s)", token, status, string(body))
+		}
+	}
+
+	status, body = request(t, env.router, http.MethodGet, "/api/public/estimate-acceptance/"+link.Link.Token, nil, nil, "")
+	if status != http.StatusOK {
+		t.Fatalf("public view expected 200, got %d (%s)", status, string(body))
+	}
+	var view publicPayload
+	if err := json.Unmarshal(body, &view); err != nil {
+		t.Fatalf("parse public estimate: %v", err)
+	}
+	if view.Estimate.Status != "sent" || !view.Estimate.CanAccept || view.Estimate.CustomerName != "Integration Customer" || view.Estimate.Acceptance != nil {
+		t.Fatalf("unexpected public estimate %s", string(body))
+	}
+	if strings.Contains(string(body), "customer@example.com") {
+		t.Fatalf("public estimate must not expose contact details: %s", string(body))
+	}
+	status, body = request(t, env.router, http.MethodGet, "/api/public/estimate-acceptance/"+link.Link.Token+"/document.pdf", nil, nil, "")
+	if status != http.StatusOK || !bytes.HasPrefix(body, []byte("%PDF-")) {
+		t.Fatalf("public document expected a PDF, got %d", status)
+	}
+
+	status, body = request(t, env.router, http.MethodPost, "/api/public/estimate-acceptance/"+link.Link.Token+"/accept", []byte(`{"signerName":"Ada Lovelace","agreeToTerms":false}`), nil, "")
+	if status != http.StatusBadRequest || parseErrorCode(t, body) != "validation_error" {
+		t.Fatalf("expected acceptance without agreeing to be rejected, got %d (%s)", status, string(body))
+	}
+	status, body = request(t, env.router, http.MethodPost, "/api/public/estimate-acceptance/"+link.Link.Token+"/accept", []byte(`{"signerName":"  Ada   Lovelace ","agreeToTerms":true}`), nil, "", map[string]string{"User-Agent": "IntegrationBrowser/1.0"})
+	if status != http.StatusOK {
+		t.Fatalf("accept expected 200, got %d (%s)", status, string(body))
+	}
+	if err := json.Unmarshal(body, &view); err != nil {
+		t.Fatalf("parse accepted estimate: %v", err)
+	}
+	if view.Estimate.Status != "converted" || view.Estimate.CanAccept || view.Estimate.Acceptance == nil || view.Estimate.Acceptance.SignerName != "Ada Lovelace" {
+		t.Fatalf("expected the estimate to be accepted and converted, got %s", string(body))
+	}
+	status, body = request(t, env.router, http.MethodPost, "/api/public/estimate-acceptance/"+link.Link.Token+"/accept", []byte(`{"signerName":"Ada Lovelace","agreeToTerms":true}`), nil, "")
+	if status != http.StatusConflict || parseErrorCode(t, body) != "acceptance_link_used" {
+		t.Fatalf("expected a second acceptance to be refused, got %d (%s)", status, string(body))
+	}
+
+	// Auto-conversion went through the convert path: one job, booked by the
+	// link's creator.
+	var jobs, revisions int
+	if err := env.pool.QueryRow(ctx, `SELECT COUNT(*) FROM jobs WHERE estimate_id = $1 AND created_by IS NOT NULL`, estimateID).Scan(&jobs); err != nil {
+		t.Fatalf("count jobs: %v", err)
+	}
+	if jobs != 1 {
+		t.Fatalf("expected one job from the acceptance, got %d", jobs)
+	}
+	if err := env.pool.QueryRow(ctx, `SELECT COUNT(*) FROM estimate_revisions WHERE estimate_id = $1 AND change = 'status_changed' AND created_by IS NULL`, estimateID).Scan(&revisions); err != nil {
+		t.Fatalf("count revisions: %v", err)
+	}
+	if revisions != 1 {
+		t.Fatalf("expected one revision for the customer's acceptance, got %d", revisions)
+	}
+	var audited int
+	if err := env.pool.QueryRow(ctx, `SELECT COUNT(*) FROM audit_log WHERE entity_id = $1 AND action IN ('estimate.accepted_online', 'estimate.acceptance_link_view', 'estimate.convert_to_job')`, estimateID).Scan(&audited); err != nil {
+		t.Fatalf("count audit logs: %v", err)
+	}
+	if audited != 3 {
+		t.Fatalf("expected view, acceptance and conversion to be audited, got %d", audited)
+	}
+
+	type signaturesPayload struct {
+		Items []struct {
+			SignerName     string `json:"signerName"`
+			SignerIP       string `json:"signerIp"`
+			UserAgent      string `json:"userAgent"`
+			RevisionNumber int    `json:"revisionNumber"`
+			DocumentSha256 string `json:"documentSha256"`
+			Verified       bool   `json:"verified"`
+		} `json:"items"`
+	}
+	listSignatures := func() signaturesPayload {
+		t.Helper()
+		status, body := request(t, env.router, http.MethodGet, "/api/estimates/"+estimateID+"/signatures", nil, cookie, "")
+		if status != http.StatusOK {
+			t.Fatalf("list signatures expected 200, got %d (%s)", status, string(body))
+		}
+		var signatures signaturesPayload
+		if err := json.Unmarshal(body, &signatures); err != nil {
+			t.Fatalf("parse signatures: %v", err)
+		}
+		return signatures
+	}
+	signatures := listSignatures()
+	if len(signatures.Items) != 1 {
+		t.Fatalf("expected one signature, got %+v", signatures)
+	}
+	signature := signatures.Items[0]
+	if !signature.Verified || signature.SignerName != "Ada Lovelace" || signature.SignerIP != "127.0.0.1" || signature.UserAgent != "IntegrationBrowser/1.0" || len(signature.DocumentSha256) != 64 {
+		t.Fatalf("unexpected signature %+v", signature)
+	}
+
+	// Editing the record in the database breaks its signature.
+	if _, err := env.pool.Exec(ctx, `UPDATE estimate_signatures SET signer_name = 'Someone Else' WHERE estimate_id = $1`, estimateID); err != nil {
+		t.Fatalf("tamper with signature: %v", err)
+	}
+	if signature := listSignatures().Items[0]; signature.Verified {
+		t.Fatalf("expected the edited signature to fail verification")
+	}
+
+	// Extending a link in the database invalidates its token, and an expired
+	// link is gone.
+	otherID := createEstimate(t, env.router, cookie, csrf, "accept-estimate-expired")
+	status, body = request(t, env.router, http.MethodPatch, "/api/estimates/"+otherID, []byte(`{"status":"sent"}`), cookie, csrf)
+	if status != http.StatusOK {
+		t.Fatalf("send estimate expected 200, got %d (%s)", status, string(body))
+	}
+	expiring := createLink(otherID, `{}`)
+	if _, err := env.pool.Exec(ctx, `UPDATE estimate_acceptance_links SET expires_at = expires_at + INTERVAL '1 day' WHERE id = $1`, expiring.Link.ID); err != nil {
+		t.Fatalf("extend link: %v", err)
+	}
+	status, body = request(t, env.router, http.MethodGet, "/api/public/estimate-acceptance/"+expiring.Link.Token, nil, nil, "")
+	if status != http.StatusNotFound {
+		t.Fatalf("expected an extended link to be rejected, got %d (%s)", status, string(body))
+	}
+	expired := createLink(otherID, `{}`)
+	if _, err := env.pool.Exec(ctx, `UPDATE estimates SET status = 'draft' WHERE id = $1`, otherID); err != nil {
+		t.Fatalf("reset estimate: %v", err)
+	}
+	status, body = request(t, env.router, http.MethodPost, "/api/public/estimate-acceptance/"+expired.Link.Token+"/accept", []byte(`{"signerName":"Ada Lovelace","agreeToTerms":true}`), nil, "")
+	if status != http.StatusConflict || parseErrorCode(t, body) != "estimate_not_acceptable" {
+		t.Fatalf("expected a withdrawn estimate to be refused, got %d (%s)", status, string(body))
+	}
+	// A link that runs out is gone, even with a token signed for its new
+	// expiry.
+	var expiredAt time.Time
+	if err := env.pool.QueryRow(ctx, `UPDATE estimate_acceptance_links SET expires_at = date_trunc('second', NOW()) - INTERVAL '1 minute' WHERE id = $1 RETURNING expires_at`, expired.Link.ID).Scan(&expiredAt); err != nil {
+		t.Fatalf("expire link: %v", err)
+	}
+	expiredToken := acceptance.NewSigner([]byte("test-acceptance-signing-key-0123456789")).LinkToken(uuid.MustParse(expired.Link.ID), expiredAt)
+	status, body = request(t, env.router, http.MethodGet, "/api/public/estimate-acceptance/"+expiredToken, nil, nil, "")
+	if status != http.StatusGone || parseErrorCode(t, body) != "acceptance_link_expired" {
+		t.Fatalf("expected an expired link to be gone, got %d (%s)", status, string(body))
+	}
+}
+
+func TestPaymentsLedgerDerivesBalances(t *testing.T) {
+	env := setupTestEnv(t)
+	ctx := context.Background()
+
+	tenantID, _ := seedTenantUser(t, ctx, env.pool, "tenant-ledger", "Tenant Ledger", "ledger@example.com", "Password123!", []string{"estimates.read", "estimates.write", "estimates.convert", "jobs.read", "jobs.write", "storage.read", "storage.write"})
+	_, _ = seedUserInTenant(t, ctx, env.pool, tenantID, "ledger-reader@example.com", "Password123!", []string{"jobs.read"})
+
+	cookie := login(t, env.router, "ledger@example.com", "Password123!")
+	csrf := csrfToken(t, env.router, cookie)
+	estimateID := createEstimate(t, env.router, cookie, csrf, "ledger-estimate")
+	if _, err := env.pool.Exec(ctx, `UPDATE estimates SET estimated_total_cents = 150000, deposit_cents = 30000 WHERE id = $1`, estimateID); err != nil {
+		t.Fatalf("set estimate total: %v", err)
+	}
+	jobID := convertEstimateToJob(t, env.router, cookie, csrf, estimateID, "ledger-convert")
+
+	ledger := getLedger(t, env.router, cookie, "/api/jobs/"+jobID+"/ledger")
+	if ledger.BalanceCents != 120000 || len(ledger.Entries) != 2 || ledger.Entries[0].Kind != "charge" || ledger.Entries[1].Kind != "payment" || ledger.LastPaymentAt == nil {
+		t.Fatalf("expected the estimate total charged and the deposit paid on conversion, got %+v", ledger)
+	}
+
+	status, body := request(t, env.router, http.MethodPatch, "/api/estimates/"+estimateID, []byte(`{"depositCents":45000}`), cookie, csrf)
+	if status != http.StatusConflict || parseErrorCode(t, body) != "deposit_posted" {
+		t.Fatalf("expected the posted deposit to be locked on the estimate, got %d (%s)", status, string(body))
+	}
+
+	postLedger := func(path, key string, body map[string]any) (int, []byte) {
+		payload, _ := json.Marshal(body)
+		return request(t, env.router, http.MethodPost, path, payload, cookie, csrf, withIdempotency(key))
+	}
+	jobLedgerPath := "/api/jobs/" + jobID + "/ledger"
+
+	status, body = postLedger(jobLedgerPath, "ledger-no-method", map[string]any{"kind": "payment", "amountCents": 50000})
+	if status != http.StatusBadRequest || parseErrorCode(t, body) != "validation_error" {
+		t.Fatalf("expected a payment without method to be rejected, got %d (%s)", status, string(body))
+	}
+	status, body = postLedger(jobLedgerPath, "ledger-negative-charge", map[string]any{"kind": "charge", "amountCents": -100})
+	if status != http.StatusBadRequest {
+		t.Fatalf("expected a negative charge to be rejected, got %d (%s)", status, string(body))
+	}
+
+	payment := map[string]any{"kind": "payment", "amountCents": 50000, "method": "card", "reference": "AUTH-1"}
+	status, body = postLedger(jobLedgerPath, "ledger-payment-1", payment)
+	if status != http.StatusCreated {
+		t.Fatalf("expected 201 for payment, got %d (%s)", status, string(body))
+	}
+	posted := parseLedgerEntryResponse(t, body)
+	if posted.BalanceCents != 70000 || posted.Entry.BalanceEffectCents != -50000 {
+		t.Fatalf("expected the payment to reduce the balance to 70000, got %+v", posted)
+	}
+	status, body = postLedger(jobLedgerPath, "ledger-payment-1", payment)
+	if status != http.StatusOK || parseLedgerEntryResponse(t, body).Entry.ID != posted.Entry.ID {
+		t.Fatalf("expected an idempotent replay of the payment, got %d (%s)", status, string(body))
+	}
+	status, body = postLedger(jobLedgerPath, "ledger-payment-1", map[string]any{"kind": "payment", "amountCents": 60000, "method": "card"})
+	if status != http.StatusConflict || parseErrorCode(t, body) != "IDEMPOTENCY_KEY_REUSE" {
+		t.Fatalf("expected IDEMPOTENCY_KEY_REUSE for a changed payload, got %d (%s)", status, string(body))
+	}
+
+	if status, body = postLedger(jobLedgerPath, "ledger-refund-1", map[string]any{"kind": "refund", "amountCents": 10000, "method": "card"}); status != http.StatusCreated {
+		t.Fatalf("expected 201 for refund, got %d (%s)", status, string(body))
+	}
+	if status, body = postLedger(jobLedgerPath, "ledger-adjust-1", map[string]any{"kind": "adjustment", "amountCents": -5000, "memo": "Goodwill"}); status != http.StatusCreated {
+		t.Fatalf("expected 201 for adjustment, got %d (%s)", status, string(body))
+	}
+
+	ledger = getLedger(t, env.router, cookie, jobLedgerPath)
+	if ledger.BalanceCents != 75000 || len(ledger.Entries) != 5 || ledger.LastPaymentAt == nil {
+		t.Fatalf("expected balance 75000 over 5 entries with a last payment, got %+v", ledger)
+	}
+	if last := ledger.Entries[len(ledger.Entries)-1]; last.BalanceAfterCents == nil || *last.BalanceAfterCents != 75000 {
+		t.Fatalf("expected the running balance to end at 75000, got %+v", last)
+	}
+
+	status, body = request(t, env.router, http.MethodGet, "/api/jobs?balanceDue=true", nil, cookie, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for balance filter, got %d (%s)", status, string(body))
+	}
+	if items := parseJobList(t, body).Items; len(items) != 1 || items[0].ID != jobID || items[0].BalanceDueCents != 75000 {
+		t.Fatalf("expected the job with 75000 due, got %+v", items)
+	}
+
+	storageID := createStorageRecord(t, env.router, cookie, csrf, jobID, "Ledger Facility")
+	storageLedgerPath := "/api/storage/" + storageID + "/ledger"
+	if status, body = postLedger(storageLedgerPath, "ledger-storage-charge", map[string]any{"kind": "charge", "amountCents": 32900, "memo": "April storage"}); status != http.StatusCreated {
+		t.Fatalf("expected 201 for storage charge, got %d (%s)", status, string(body))
+	}
+	if status, body = postLedger(storageLedgerPath, "ledger-storage-payment", map[string]any{"kind": "payment", "amountCents": 40000, "method": "check", "reference": "CHK-1042"}); status != http.StatusCreated {
+		t.Fatalf("expected 201 for storage payment, got %d (%s)", status, string(body))
+	}
+
+	status, body = request(t, env.router, http.MethodGet, "/api/storage/"+storageID, nil, cookie, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 storage read, got %d (%s)", status, string(body))
+	}
+	record := parseStorageRecord(t, body)
+	if record.StorageBalanceCents != -7100 || record.MoveBalanceCents != 75000 {
+		t.Fatalf("expected a 7100 storage credit and the job's move balance, got %+v", record)
+	}
+
+	if status, body = postLedger(jobLedgerPath, "ledger-payment-2", map[string]any{"kind": "payment", "amountCents": 75000, "method": "ach"}); status != http.StatusCreated {
+		t.Fatalf("expected 201 for final payment, got %d (%s)", status, string(body))
+	}
+	status, body = request(t, env.router, http.MethodGet, "/api/storage/"+storageID, nil, cookie, "")
+	if status != http.StatusOK || parseStorageRecord(t, body).MoveBalanceCents != 0 {
+		t.Fatalf("expected the storage record to mirror the settled move balance, got %d (%s)", status, string(body))
+	}
+	status, body = request(t, env.router, http.MethodGet, "/api/jobs?balanceDue=true", nil, cookie, "")
+	if status != http.StatusOK || len(parseJobList(t, body).Items) != 0 {
+		t.Fatalf("expected no jobs with a balance due after settlement, got %d (%s)", status, string(body))
+	}
+
+	storageLedger := getLedger(t, env.router, cookie, storageLedgerPath)
+	if storageLedger.Account != "storage" || len(storageLedger.Entries) != 2 || storageLedger.BalanceCents != -7100 {
+		t.Fatalf("expected the storage ledger to hold its own entries only, got %+v", storageLedger)
+	}
+
+	reader := login(t, env.router, "ledger-reader@example.com", "Password123!")
+	readerCsrf := csrfToken(t, env.router, reader)
+	status, _ = request(t, env.router, http.MethodPost, jobLedgerPath, []byte(`{"kind":"charge","amountCents":100}`), reader, readerCsrf, withIdempotency("ledger-reader"))
+	if status != http.StatusForbidden {
+		t.Fatalf("expected 403 without jobs.write, got %d", status)
+	}
+
+	var audited int
+	if err := env.pool.QueryRow(ctx, `
+		SELECT COUNT(*) FROM audit_log WHERE tenant_id = $1 AND action = 'ledger_entry.create'
+	`, tenantID).Scan(&audited); err != nil {
+		t.Fatalf("count ledger audit rows: %v", err)
+	}
+	if audited != 6 {
+		t.Fatalf("expected 6 ledger_entry.create audit rows, got %d", audited)
+	}
+}
+
+func TestJobListFiltersAndTenantIsolation(t *testing.T) {
+	env := setupTestEnv(t)
+	ctx := context.Background()
+
+	_, _ = seedTenantUser(t, ctx, env.pool, "tenant-job-list-a", "Tenant Job List A", "job-list-a@example.com", "Password123!", []string{"estimates.read", "estimates.write", "estimates.convert", "jobs.read", "imports.write", "imports.read"})
+	_, _ = seedTenantUser(t, ctx, env.pool, "tenant-job-list-b", "Tenant Job List B", "job-list-b@example.com", "Password123!", []string{"jobs.read"})
+
+	cookieA := login(t, env.router, "job-list-a@example.com", "Password123!")
+	csrfA := csrfToken(t, env.router, cookieA)
+	estimateID := createEstimate(t, env.router, cookieA, csrfA, "job-list-estimate")
+	convertedJobID := convertEstimateToJob(t, env.router, cookieA, csrfA, estimateID, "job-list-convert")
+	if _, err := env.pool.Exec(ctx, `UPDATE jobs SET scheduled_date = NULL WHERE id = $1`, convertedJobID); err != nil {
+		t.Fatalf("clear scheduled date: %v", err)
+	}
+
+	status, body := multipartImportRequest(t, env.router, "/api/imports/apply", cookieA, csrfA, "job-list.csv", validImportCSV("J-LIST-001", "E-LIST-001", "job-list-customer@example.com"), importMapping())
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for import apply, got %d (%s)", status, string(body))
+	}
+
+	status, body = request(t, env.router, http.MethodGet, "/api/jobs?limit=1", nil, cookieA, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for job list, got %d (%s)", status, string(body))
+	}
+	firstPage := parseJobList(t, body)
+	if len(firstPage.Items) != 1 || firstPage.NextCursor == nil {
+		t.Fatalf("expected first page of 1 with a cursor, got %d items", len(firstPage.Items))
+	}
+	status, body = request(t, env.router, http.MethodGet, "/api/jobs?limit=1&cursor="+*firstPage.NextCursor, nil, cookieA, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for second job page, got %d (%s)", status, string(body))
+	}
+	secondPage := parseJobList(t, body)
+	if len(secondPage.Items) != 1 || secondPage.Items[0].ID == firstPage.Items[0].ID {
+		t.Fatalf("expected a distinct job on the second page, got %+v", secondPage.Items)
+	}
+
+	status, body = request(t, env.router, http.MethodGet, "/api/jobs?scheduled=false", nil, cookieA, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for unscheduled filter, got %d (%s)", status, string(body))
+	}
+	unscheduled := parseJobList(t, body)
+	if len(unscheduled.Items) != 1 || unscheduled.Items[0].ID != convertedJobID || unscheduled.Items[0].Source != "estimate" {
+		t.Fatalf("expected only the converted job to be unscheduled, got %+v", unscheduled.Items)
+	}
+
+	status, body = request(t, env.router, http.MethodGet, "/api/jobs?source=import&balanceDue=true", nil, cookieA, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for import/balance filter, got %d (%s)", status, string(body))
+	}
+	imported := parseJobList(t, body)
+	if len(imported.Items) != 1 || imported.Items[0].JobNumber != "J-LIST-001" {
+		t.Fatalf("expected imported job with balance due, got %+v", imported.Items)
+	}
+
+	cookieB := login(t, env.router, "job-list-b@example.com", "Password123!")
+	status, body = request(t, env.router, http.MethodGet, "/api/jobs", nil, cookieB, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for cross-tenant job list, got %d (%s)", status, string(body))
+	}
+	if items := parseJobList(t, body).Items; len(items) != 0 {
+		t.Fatalf("expected cross-tenant job list to be empty, got %d", len(items))
+	}
+}
+
+func TestQuickSearchRanksAndRespectsScope(t *testing.T) {
+	env := setupTestEnv(t)
+	ctx := context.Background()
+
+	tenantID, _ := seedTenantUser(t, ctx, env.pool, "tenant-search-a", "Tenant Search A", "search-a@example.com", "Password123!", []string{"customers.read", "estimates.read", "estimates.write", "estimates.convert", "jobs.read"})
+	_, _ = seedUserInTenant(t, ctx, env.pool, tenantID, "search-customers@example.com", "Password123!", []string{"customers.read"})
+	_, _ = seedTenantUser(t, ctx, env.pool, "tenant-search-b", "Tenant Search B", "search-b@example.com", "Password123!", []string{"customers.read", "estimates.read", "jobs.read"})
+
+	cookieA := login(t, env.router, "search-a@example.com", "Password123!")
+	csrfA := csrfToken(t, env.router, cookieA)
+	estimateID := createEstimate(t, env.router, cookieA, csrfA, "search-estimate")
+	jobID := convertEstimateToJob(t, env.router, cookieA, csrfA, estimateID, "search-convert")
+
+	var jobNumber string
+	if err := env.pool.QueryRow(ctx, `SELECT job_number FROM jobs WHERE id = $1`, jobID).Scan(&jobNumber); err != nil {
+		t.Fatalf("load job number: %v", err)
+	}
+
+	status, body := request(t, env.router, http.MethodGet, "/api/search?q="+jobNumber, nil, cookieA, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for search, got %d (%s)", status, string(body))
+	}
+	hits := parseSearchHits(t, body)
+	if len(hits) == 0 || hits[0].Type != "job" || hits[0].ID != jobID {
+		t.Fatalf("expected exact job number match to rank first, got %+v", hits)
+	}
+
+	status, body = request(t, env.router, http.MethodGet, "/api/search?q=austin", nil, cookieA, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for city search, got %d (%s)", status, string(body))
+	}
+	foundEstimate := false
+	for _, hit := range parseSearchHits(t, body) {
+		if hit.Type == "estimate" && hit.ID == estimateID {
+			foundEstimate = true
+		}
+	}
+	if !foundEstimate {
+		t.Fatalf("expected origin city search to return the estimate")
 	}
-	jobID3 := parseJobID(t, body3)
-	if jobID1 != jobID3 {
-		t.Fatalf("expected same job id for repeated convert, got %s and %s", jobID1, jobID3)
+
+	customersOnly := login(t, env.router, "search-customers@example.com", "Password123!")
+	status, body = request(t, env.router, http.MethodGet, "/api/search?q=integration", nil, customersOnly, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for customers-only search, got %d (%s)", status, string(body))
+	}
+	for _, hit := range parseSearchHits(t, body) {
+		if hit.Type != "customer" {
+			t.Fatalf("expected only customer hits without estimates/jobs read, got %s", hit.Type)
+		}
 	}
 
-	var count int
-	estimateUUID, err := uuid.Parse(estimateID)
-	if err != nil {
-		t.Fatalf("parse estimate id: %v", err)
+	cookieB := login(t, env.router, "search-b@example.com", "Password123!")
+	status, body = request(t, env.router, http.MethodGet, "/api/search?q=integration", nil, cookieB, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for cross-tenant search, got %d (%s)", status, string(body))
 	}
-	if err := env.pool.QueryRow(ctx, `SELECT COUNT(*) FROM jobs WHERE tenant_id = $1 AND estimate_id = $2`, tenantID, estimateUUID).Scan(&count); err != nil {
-		t.Fatalf("count jobs by estimate: %v", err)
+	if hits := parseSearchHits(t, body); len(hits) != 0 {
+		t.Fatalf("expected cross-tenant search to be empty, got %d hits", len(hits))
 	}
-	if count != 1 {
-		t.Fatalf("expected exactly 1 job for estimate, got %d", count)
+
+	status, _ = request(t, env.router, http.MethodGet, "/api/search?q=a", nil, cookieA, "")
+	if status != http.StatusBadRequest {
+		t.Fatalf("expected 400 for single-character query, got %d", status)
 	}
 }
 
@@ -458,6 +3195,113 @@ func TestCalendarScheduleUpdateCreatesAuditLog(t *testing.T) {
 	}
 }
 
+func TestJobStatusTransitionsAndHistory(t *testing.T) {
+	env := setupTestEnv(t)
+	ctx := context.Background()
+
+	tenantID, _ := seedTenantUser(t, ctx, env.pool, "tenant-job-status", "Tenant Job Status", "job-status@example.com", "Password123!", []string{"estimates.read", "estimates.write", "estimates.convert", "calendar.read", "calendar.write", "jobs.read"})
+
+	cookie := login(t, env.router, "job-status@example.com", "Password123!")
+	csrf := csrfToken(t, env.router, cookie)
+	jobID := convertEstimateToJob(t, env.router, cookie, csrf, createEstimate(t, env.router, cookie, csrf, "job-status-estimate-1"), "job-status-convert-1")
+
+	type jobStatusPayload struct {
+		Job struct {
+			Status        string `json:"status"`
+			StatusHistory []struct {
+				FromStatus *string `json:"fromStatus"`
+				ToStatus   string  `json:"toStatus"`
+				Reason     *string `json:"reason"`
+			} `json:"statusHistory"`
+		} `json:"job"`
+	}
+	patchJob := func(id, payload string) (int, jobStatusPayload, []byte) {
+		status, body := request(t, env.router, http.MethodPatch, "/api/jobs/"+id, []byte(payload), cookie, csrf)
+		var parsed jobStatusPayload
+		if status == http.StatusOK {
+			if err := json.Unmarshal(body, &parsed); err != nil {
+				t.Fatalf("parse job: %v", err)
+			}
+		}
+		return status, parsed, body
+	}
+
+	status, _, body := patchJob(jobID, `{"status":"completed"}`)
+	if status != http.StatusConflict || parseErrorCode(t, body) != "invalid_status_transition" {
+		t.Fatalf("expected 409 for booked to completed, got %d (%s)", status, string(body))
+	}
+	status, _, body = patchJob(jobID, `{"pickupTime":"08:00","reason":"Customer asked"}`)
+	if status != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a reason without a status change, got %d (%s)", status, string(body))
+	}
+
+	if _, err := env.pool.Exec(ctx, `UPDATE jobs SET scheduled_date = NULL WHERE id = $1`, jobID); err != nil {
+		t.Fatalf("clear scheduled date: %v", err)
+	}
+	status, _, body = patchJob(jobID, `{"status":"scheduled"}`)
+	if status != http.StatusBadRequest || parseErrorCode(t, body) != "scheduled_date_required" {
+		t.Fatalf("expected 400 scheduled_date_required, got %d (%s)", status, string(body))
+	}
+	for _, payload := range []string{`{"status":"scheduled","scheduledDate":"2026-05-04"}`, `{"status":"in_progress"}`, `{"status":"completed"}`} {
+		if status, _, body = patchJob(jobID, payload); status != http.StatusOK {
+			t.Fatalf("expected 200 for %s, got %d (%s)", payload, status, string(body))
+		}
+	}
+	status, completed, body := patchJob(jobID, `{"status":"cancelled","reason":"Too late"}`)
+	if status != http.StatusConflict {
+		t.Fatalf("expected completed jobs to be final, got %d (%s)", status, string(body))
+	}
+	status, body = request(t, env.router, http.MethodGet, "/api/jobs/"+jobID, nil, cookie, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 job read, got %d (%s)", status, string(body))
+	}
+	if err := json.Unmarshal(body, &completed); err != nil {
+		t.Fatalf("parse job: %v", err)
+	}
+	history := completed.Job.StatusHistory
+	wantHistory := []string{"booked", "scheduled", "in_progress", "completed"}
+	if completed.Job.Status != "completed" || len(history) != len(wantHistory) {
+		t.Fatalf("expected %d history entries for a completed job, got %s", len(wantHistory), string(body))
+	}
+	for i, want := range wantHistory {
+		if history[i].ToStatus != want {
+			t.Fatalf("expected history entry %d to be %s, got %s", i, want, history[i].ToStatus)
+		}
+	}
+	if history[0].FromStatus != nil || history[1].FromStatus == nil || *history[1].FromStatus != "booked" {
+		t.Fatalf("unexpected history from statuses: %s", string(body))
+	}
+
+	otherJobID := convertEstimateToJob(t, env.router, cookie, csrf, createEstimate(t, env.router, cookie, csrf, "job-status-estimate-2"), "job-status-convert-2")
+	status, _, body = patchJob(otherJobID, `{"status":"cancelled"}`)
+	if status != http.StatusBadRequest || parseErrorCode(t, body) != "cancellation_reason_required" {
+		t.Fatalf("expected 400 cancellation_reason_required, got %d (%s)", status, string(body))
+	}
+	status, cancelled, body := patchJob(otherJobID, `{"status":"cancelled","reason":"Customer moved the date out"}`)
+	if status != http.StatusOK || cancelled.Job.Status != "cancelled" {
+		t.Fatalf("expected 200 for cancellation, got %d (%s)", status, string(body))
+	}
+	last := cancelled.Job.StatusHistory[len(cancelled.Job.StatusHistory)-1]
+	if last.Reason == nil || *last.Reason != "Customer moved the date out" {
+		t.Fatalf("expected cancellation reason in history, got %s", string(body))
+	}
+	status, _, body = patchJob(otherJobID, `{"status":"completed"}`)
+	if status != http.StatusConflict {
+		t.Fatalf("expected 409 for cancelled to completed, got %d (%s)", status, string(body))
+	}
+	if status, _, body = patchJob(otherJobID, `{"status":"booked"}`); status != http.StatusOK {
+		t.Fatalf("expected cancelled jobs to be rebookable, got %d (%s)", status, string(body))
+	}
+
+	var phaseAudits int
+	if err := env.pool.QueryRow(ctx, `SELECT COUNT(*) FROM audit_log WHERE tenant_id = $1 AND action = 'job.phase_update'`, tenantID).Scan(&phaseAudits); err != nil {
+		t.Fatalf("count phase audit rows: %v", err)
+	}
+	if phaseAudits != 5 {
+		t.Fatalf("expected 5 job.phase_update audit rows, got %d", phaseAudits)
+	}
+}
+
 func TestStorageTenantIsolation(t *testing.T) {
 	env := setupTestEnv(t)
 	ctx := context.Background()
@@ -573,8 +3417,8 @@ func TestStorageCreateAndUpdatePersists(t *testing.T) {
 	if record.Vaults != 5 || record.Pads != 3 || record.Items != 24 || record.OversizeItems != 4 {
 		t.Fatalf("unexpected counts after update: %+v", record)
 	}
-	if record.StorageBalanceCents != 52000 || record.MoveBalanceCents != 9000 {
-		t.Fatalf("unexpected balances after update: %+v", record)
+	if record.StorageBalanceCents != 0 || record.MoveBalanceCents != 0 {
+		t.Fatalf("expected balances sent with the update to be ignored, got %+v", record)
 	}
 
 	status, body = request(t, env.router, http.MethodGet, "/api/storage?facility=Main%20Facility&q="+record.JobNumber, nil, cookie, "")
@@ -778,6 +3622,11 @@ type testEnv struct {
 }
 
 func setupTestEnv(t *testing.T) testEnv {
+	t.Helper()
+	return setupTestEnvWithConfig(t, nil)
+}
+
+func setupTestEnvWithConfig(t *testing.T, configure func(*config.Config)) testEnv {
 	t.Helper()
 	databaseURL := os.Getenv("TEST_DATABASE_URL")
 	if databaseURL == "" {
@@ -812,6 +3661,15 @@ func setupTestEnv(t *testing.T) testEnv {
 		IdleTimeout:        60 * time.Second,
 		RateLimitMaxIPs:    10000,
 		Env:                "test",
+
+		LoginLockoutThreshold:   3,
+		LoginLockoutBaseBackoff: time.Minute,
+		LoginLockoutMaxBackoff:  10 * time.Minute,
+
+		AcceptanceSigningKey: "test-acceptance-signing-key-0123456789",
+	}
+	if configure != nil {
+		configure(&cfg)
 	}
 
 	router, err := NewRouter(cfg, gen.New(pool), pool, logger)
@@ -899,7 +3757,16 @@ func seedUserInTenant(t *testing.T, ctx context.Context, pool *pgxpool.Pool, ten
 
 func login(t *testing.T, router http.Handler, email, password string) *http.Cookie {
 	t.Helper()
-	payload, _ := json.Marshal(map[string]string{"email": email, "password": password})
+	return loginTenant(t, router, email, password, "")
+}
+
+func loginTenant(t *testing.T, router http.Handler, email, password, tenantSlug string) *http.Cookie {
+	t.Helper()
+	body := map[string]string{"email": email, "password": password}
+	if tenantSlug != "" {
+		body["tenantSlug"] = tenantSlug
+	}
+	payload, _ := json.Marshal(body)
 	rec := httptest.NewRecorder()
 	req := httptest.NewRequest(http.MethodPost, "/api/auth/login", bytes.NewReader(payload))
 	req.Header.Set("Content-Type", "application/json")
@@ -919,6 +3786,49 @@ func login(t *testing.T, router http.Handler, email, password string) *http.Cook
 	return nil
 }
 
+func loginMFAChallenge(t *testing.T, router http.Handler, email, password string) *http.Cookie {
+	t.Helper()
+	payload, _ := json.Marshal(map[string]string{"email": email, "password": password})
+	status, body, session := requestWithSession(t, router, http.MethodPost, "/api/auth/login", payload, nil, "")
+	if status != http.StatusAccepted || session == nil {
+		t.Fatalf("login expected 202 MFA challenge, got %d with body: %s", status, string(body))
+	}
+	return session
+}
+
+func totpPayload(t *testing.T, field, secret string, step int64) []byte {
+	t.Helper()
+	code, err := auth.TOTPCode(secret, step)
+	if err != nil {
+		t.Fatalf("compute TOTP code: %v", err)
+	}
+	payload, _ := json.Marshal(map[string]string{field: code})
+	return payload
+}
+
+func switchTenant(t *testing.T, router http.Handler, session *http.Cookie, csrf, tenantSlug string) *http.Cookie {
+	t.Helper()
+	payload, _ := json.Marshal(map[string]string{"tenantSlug": tenantSlug})
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/switch-tenant", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-CSRF-Token", csrf)
+	req.AddCookie(session)
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		body, _ := io.ReadAll(rec.Result().Body)
+		t.Fatalf("switch tenant expected 200, got %d with body: %s", rec.Code, string(body))
+	}
+
+	for _, c := range rec.Result().Cookies() {
+		if c.Name == "mo_sess" {
+			return c
+		}
+	}
+	t.Fatal("session cookie not set")
+	return nil
+}
+
 func csrfToken(t *testing.T, router http.Handler, session *http.Cookie) string {
 	t.Helper()
 	status, body := request(t, router, http.MethodGet, "/api/auth/csrf", nil, session, "")
@@ -932,6 +3842,28 @@ func csrfToken(t *testing.T, router http.Handler, session *http.Cookie) string {
 	return payload["csrfToken"]
 }
 
+func createAPIToken(t *testing.T, router http.Handler, session *http.Cookie, csrf, payload string) (string, string) {
+	t.Helper()
+	status, body := request(t, router, http.MethodPost, "/api/auth/api-tokens", []byte(payload), session, csrf)
+	if status != http.StatusCreated {
+		t.Fatalf("create API token expected 201, got %d (%s)", status, string(body))
+	}
+	var created struct {
+		Token struct {
+			ID string `json:"id"`
+		} `json:"token"`
+		Secret string `json:"secret"`
+	}
+	if err := json.Unmarshal(body, &created); err != nil {
+		t.Fatalf("parse API token body: %v", err)
+	}
+	return created.Secret, created.Token.ID
+}
+
+func bearer(token string) map[string]string {
+	return map[string]string{"Authorization": "Bearer " + token}
+}
+
 func createCustomer(t *testing.T, router http.Handler, session *http.Cookie, csrf, firstName, lastName string) string {
 	t.Helper()
 	payload, _ := json.Marshal(map[string]string{"firstName": firstName, "lastName": lastName})
@@ -1031,6 +3963,145 @@ func parseJobID(t *testing.T, body []byte) string {
 	return payload.Job.ID
 }
 
+func parseCustomerListIDs(t *testing.T, body []byte) []string {
+	t.Helper()
+	var payload struct {
+		Items []struct {
+			ID string `json:"id"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("parse customer list body: %v", err)
+	}
+	ids := make([]string, 0, len(payload.Items))
+	for _, item := range payload.Items {
+		ids = append(ids, item.ID)
+	}
+	return ids
+}
+
+func parseEstimateCustomerID(t *testing.T, body []byte) string {
+	t.Helper()
+	var payload struct {
+		Estimate struct {
+			CustomerID string `json:"customerId"`
+		} `json:"estimate"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("parse estimate body: %v", err)
+	}
+	return payload.Estimate.CustomerID
+}
+
+func parseSessionPermissions(t *testing.T, body []byte) []string {
+	t.Helper()
+	var payload struct {
+		Permissions []string `json:"permissions"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("parse session body: %v", err)
+	}
+	return payload.Permissions
+}
+
+type tenantUserPayload struct {
+	ID       string   `json:"id"`
+	Email    string   `json:"email"`
+	IsActive bool     `json:"isActive"`
+	Roles    []string `json:"roles"`
+}
+
+type tenantUserResponsePayload struct {
+	User              tenantUserPayload `json:"user"`
+	TemporaryPassword string            `json:"temporaryPassword"`
+}
+
+func parseTenantUser(t *testing.T, body []byte) tenantUserResponsePayload {
+	t.Helper()
+	var payload tenantUserResponsePayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("parse user body: %v", err)
+	}
+	return payload
+}
+
+type rolePayload struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+	UserCount   int      `json:"userCount"`
+}
+
+func parseRole(t *testing.T, body []byte) rolePayload {
+	t.Helper()
+	var payload struct {
+		Role rolePayload `json:"role"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("parse role body: %v", err)
+	}
+	return payload.Role
+}
+
+type searchHitPayload struct {
+	Type  string  `json:"type"`
+	ID    string  `json:"id"`
+	Score float64 `json:"score"`
+}
+
+func parseSearchHits(t *testing.T, body []byte) []searchHitPayload {
+	t.Helper()
+	var payload struct {
+		Items []searchHitPayload `json:"items"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("parse search body: %v", err)
+	}
+	return payload.Items
+}
+
+type estimateListItemPayload struct {
+	ID             string `json:"id"`
+	CustomerName   string `json:"customerName"`
+	Status         string `json:"status"`
+	ConvertedJobID string `json:"convertedJobId"`
+}
+
+type estimateListPayload struct {
+	Items      []estimateListItemPayload `json:"items"`
+	NextCursor *string                   `json:"nextCursor"`
+}
+
+func parseEstimateList(t *testing.T, body []byte) estimateListPayload {
+	t.Helper()
+	var payload estimateListPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("parse estimate list body: %v", err)
+	}
+	return payload
+}
+
+type jobListItemPayload struct {
+	ID              string `json:"id"`
+	JobNumber       string `json:"jobNumber"`
+	Source          string `json:"source"`
+	BalanceDueCents int64  `json:"balanceDueCents"`
+}
+
+type jobListPayload struct {
+	Items      []jobListItemPayload `json:"items"`
+	NextCursor *string              `json:"nextCursor"`
+}
+
+func parseJobList(t *testing.T, body []byte) jobListPayload {
+	t.Helper()
+	var payload jobListPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("parse job list body: %v", err)
+	}
+	return payload
+}
+
 type calendarJobPayload struct {
 	JobID         string `json:"jobId"`
 	ScheduledDate string `json:"scheduledDate"`
@@ -1047,6 +4118,47 @@ func parseCalendarJobs(t *testing.T, body []byte) []calendarJobPayload {
 	return payload.Jobs
 }
 
+type ledgerEntryPayload struct {
+	ID                 string `json:"id"`
+	Kind               string `json:"kind"`
+	BalanceEffectCents int64  `json:"balanceEffectCents"`
+	BalanceAfterCents  *int64 `json:"balanceAfterCents"`
+}
+
+type ledgerPayload struct {
+	Account       string               `json:"account"`
+	BalanceCents  int64                `json:"balanceCents"`
+	LastPaymentAt *string              `json:"lastPaymentAt"`
+	Entries       []ledgerEntryPayload `json:"entries"`
+}
+
+type ledgerEntryResponsePayload struct {
+	Entry        ledgerEntryPayload `json:"entry"`
+	BalanceCents int64              `json:"balanceCents"`
+}
+
+func getLedger(t *testing.T, router http.Handler, session *http.Cookie, path string) ledgerPayload {
+	t.Helper()
+	status, body := request(t, router, http.MethodGet, path, nil, session, "")
+	if status != http.StatusOK {
+		t.Fatalf("get ledger expected 200, got %d (%s)", status, string(body))
+	}
+	var payload ledgerPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("parse ledger body: %v", err)
+	}
+	return payload
+}
+
+func parseLedgerEntryResponse(t *testing.T, body []byte) ledgerEntryResponsePayload {
+	t.Helper()
+	var payload ledgerEntryResponsePayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("parse ledger entry body: %v", err)
+	}
+	return payload
+}
+
 type storageRecordPayload struct {
 	ID                  string `json:"id"`
 	JobNumber           string `json:"jobNumber"`
@@ -1360,3 +4472,104 @@ func request(t *testing.T, router http.Handler, method, path string, body []byte
 	resBody, _ := io.ReadAll(rec.Result().Body)
 	return rec.Code, resBody
 }
+
+// requestWithSession is request for endpoints that issue a new session
+// cookie; the cookie is nil when the response did not set one.
+func requestWithSession(t *testing.T, router http.Handler, method, path string, body []byte, session *http.Cookie, csrf string, extraHeaders ...map[string]string) (int, []byte, *http.Cookie) {
+	t.Helper()
+	req := httptest.NewRequest(method, path, bytes.NewReader(body))
+	req.RemoteAddr = "127.0.0.1:12345"
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if session != nil {
+		req.AddCookie(session)
+	}
+	if csrf != "" {
+		req.Header.Set("X-CSRF-Token", csrf)
+	}
+	for _, headers := range extraHeaders {
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	resBody, _ := io.ReadAll(rec.Result().Body)
+	for _, c := range rec.Result().Cookies() {
+		if c.Name == "mo_sess" && c.Value != "" {
+			return rec.Code, resBody, c
+		}
+	}
+	return rec.Code, resBody, nil
+}
+
+// ssoAuthorize starts an SSO login and follows the stand-in provider's
+// redirect, returning the API callback path and the state cookie.
+func ssoAuthorize(t *testing.T, router http.Handler, tenantSlug string) (string, *http.Cookie) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/api/auth/oidc/"+tenantSlug+"/start", nil)
+	req.RemoteAddr = "127.0.0.1:12345"
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusFound {
+		t.Fatalf("sso start expected 302, got %d (%s)", rec.Code, rec.Body.String())
+	}
+	var stateCookie *http.Cookie
+	for _, c := range rec.Result().Cookies() {
+		if c.Name == "mo_sess_oidc" {
+			stateCookie = c
+		}
+	}
+	if stateCookie == nil {
+		t.Fatal("sso state cookie not set")
+	}
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	res, err := client.Get(rec.Header().Get("Location"))
+	if err != nil {
+		t.Fatalf("authorize at provider: %v", err)
+	}
+	res.Body.Close()
+	callback, err := url.Parse(res.Header.Get("Location"))
+	if err != nil || res.StatusCode != http.StatusFound {
+		t.Fatalf("unexpected provider response %d %q", res.StatusCode, res.Header.Get("Location"))
+	}
+	return callback.RequestURI(), stateCookie
+}
+
+// ssoCallback delivers the provider's redirect to the API and returns where
+// the API sent the browser.
+func ssoCallback(t *testing.T, router http.Handler, callbackPath string, stateCookie *http.Cookie) string {
+	t.Helper()
+	location, _ := ssoCallbackWithSession(t, router, callbackPath, stateCookie)
+	return location
+}
+
+func ssoCallbackWithSession(t *testing.T, router http.Handler, callbackPath string, stateCookie *http.Cookie) (string, *http.Cookie) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, callbackPath, nil)
+	req.RemoteAddr = "127.0.0.1:12345"
+	if stateCookie != nil {
+		req.AddCookie(stateCookie)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusFound {
+		t.Fatalf("sso callback expected 302, got %d (%s)", rec.Code, rec.Body.String())
+	}
+	for _, c := range rec.Result().Cookies() {
+		if c.Name == "mo_sess" && c.Value != "" {
+			return rec.Header().Get("Location"), c
+		}
+	}
+	return rec.Header().Get("Location"), nil
+}
+
+// ssoLogin runs a complete SSO login and returns the final redirect and the
+// session cookie, if one was issued.
+func ssoLogin(t *testing.T, router http.Handler, tenantSlug string) (string, *http.Cookie) {
+	t.Helper()
+	callbackPath, stateCookie := ssoAuthorize(t, router, tenantSlug)
+	return ssoCallbackWithSession(t, router, callbackPath, stateCookie)
+}
@@ -68,25 +68,168 @@ func NewRouter(cfg config.Config, q *gen.Queries, pool *pgxpool.Pool, logger *sl
 	auditLogger := audit.NewLogger(q)
 	h := handlers.NewServer(cfg, q, auditLogger, logger, pool)
 
-	authMW := middleware.AuthMiddleware{Queries: q, CookieName: cfg.SessionCookieName}
+	authMW := middleware.AuthMiddleware{
+		Queries:        q,
+		CookieName:     cfg.SessionCookieName,
+		SecureCookies:  cfg.SecureCookies,
+		IdleTimeout:    cfg.SessionIdleTimeout,
+		RotateInterval: cfg.SessionRotateInterval,
+		TouchInterval:  cfg.SessionTouchInterval,
+	}
 	loginLimiter := middleware.NewLoginRateLimiterWithMaxEntries(10, time.Minute, cfg.RateLimitMaxIPs)
+	ssoRateLimiter := middleware.NewIPRateLimiterWithMaxEntries(30, time.Minute, cfg.RateLimitMaxIPs)
 	searchRateLimiter := middleware.NewIPRateLimiterWithMaxEntries(90, time.Minute, cfg.RateLimitMaxIPs)
 	importRateLimiter := middleware.NewIPRateLimiterWithMaxEntries(8, time.Minute, cfg.RateLimitMaxIPs)
 	exportRateLimiter := middleware.NewIPRateLimiterWithMaxEntries(30, time.Minute, cfg.RateLimitMaxIPs)
+	acceptanceViewLimiter := middleware.NewIPRateLimiterWithMaxEntries(60, time.Minute, cfg.RateLimitMaxIPs)
+	acceptanceSignLimiter := middleware.NewIPRateLimiterWithMaxEntries(10, time.Minute, cfg.RateLimitMaxIPs)
 
 	api.Group(func(public chi.Router) {
 		public.With(loginLimiter.Middleware).Post("/auth/login", h.PostAuthLogin)
+		public.With(loginLimiter.Middleware).Post("/auth/password-reset", h.PostAuthPasswordReset)
 		public.Get("/health", h.GetHealth)
+		public.With(ssoRateLimiter.Middleware("Too many sign-in attempts")).Get("/auth/oidc/{tenantSlug}/start", func(w http.ResponseWriter, r *http.Request) {
+			h.GetAuthOidcTenantSlugStart(w, r, chi.URLParam(r, "tenantSlug"))
+		})
+		public.With(ssoRateLimiter.Middleware("Too many sign-in attempts")).Get("/auth/oidc/callback", func(w http.ResponseWriter, r *http.Request) {
+			query := r.URL.Query()
+			params := oapi.GetAuthOidcCallbackParams{}
+			if code := query.Get("code"); code != "" {
+				params.Code = &code
+			}
+			if state := query.Get("state"); state != "" {
+				params.State = &state
+			}
+			if providerError := query.Get("error"); providerError != "" {
+				params.Error = &providerError
+			}
+			if description := query.Get("error_description"); description != "" {
+				params.ErrorDescription = &description
+			}
+			h.GetAuthOidcCallback(w, r, params)
+		})
+		public.With(acceptanceViewLimiter.Middleware("Too many requests")).Get("/public/estimate-acceptance/{token}", func(w http.ResponseWriter, r *http.Request) {
+			h.GetPublicEstimateAcceptanceToken(w, r, chi.URLParam(r, "token"))
+		})
+		public.With(acceptanceViewLimiter.Middleware("Too many requests")).Get("/public/estimate-acceptance/{token}/document.pdf", func(w http.ResponseWriter, r *http.Request) {
+			h.GetPublicEstimateAcceptanceTokenDocumentPdf(w, r, chi.URLParam(r, "token"))
+		})
+		public.With(acceptanceSignLimiter.Middleware("Too many acceptance attempts")).Post("/public/estimate-acceptance/{token}/accept", func(w http.ResponseWriter, r *http.Request) {
+			h.PostPublicEstimateAcceptanceTokenAccept(w, r, chi.URLParam(r, "token"))
+		})
+	})
+
+	api.Group(func(challenge chi.Router) {
+		// The password step sets the session cookie but not a CSRF token, so the
+		// second factor is submitted like the login itself.
+		challenge.Use(authMW.RequireMFAChallenge)
+		challenge.With(loginLimiter.Middleware).Post("/auth/mfa/verify", h.PostAuthMfaVerify)
+	})
+
+	api.Group(func(account chi.Router) {
+		account.Use(authMW.RequireSession)
+		account.Get("/auth/me", h.GetAuthMe)
+		account.Get("/auth/csrf", h.GetAuthCsrf)
+		account.With(middleware.EnforceCSRF(cfg.CSRFEnforce)).Post("/auth/logout", h.PostAuthLogout)
+		account.Get("/auth/tenants", h.GetAuthTenants)
+		account.With(middleware.EnforceCSRF(cfg.CSRFEnforce)).Post("/auth/switch-tenant", h.PostAuthSwitchTenant)
+		account.With(middleware.EnforceCSRF(cfg.CSRFEnforce)).Post("/auth/password", h.PostAuthPassword)
+		account.Get("/auth/sessions", h.GetAuthSessions)
+		account.Get("/auth/api-tokens", h.GetAuthApiTokens)
+		account.With(middleware.EnforceCSRF(cfg.CSRFEnforce)).Post("/auth/api-tokens", h.PostAuthApiTokens)
+		account.With(middleware.EnforceCSRF(cfg.CSRFEnforce)).Delete("/auth/api-tokens/{tokenId}", func(w http.ResponseWriter, r *http.Request) {
+			tokenID, ok := parseUUIDParam(w, r, chi.URLParam(r, "tokenId"), "invalid_token_id", "Token id must be a valid UUID")
+			if !ok {
+				return
+			}
+			h.DeleteAuthApiTokensTokenId(w, r, openapi_types.UUID(tokenID))
+		})
+		account.With(middleware.EnforceCSRF(cfg.CSRFEnforce)).Delete("/auth/sessions/{sessionId}", func(w http.ResponseWriter, r *http.Request) {
+			sessionID, ok := parseUUIDParam(w, r, chi.URLParam(r, "sessionId"), "invalid_session_id", "Session id must be a valid UUID")
+			if !ok {
+				return
+			}
+			h.DeleteAuthSessionsSessionId(w, r, openapi_types.UUID(sessionID))
+		})
+		account.With(middleware.EnforceCSRF(cfg.CSRFEnforce)).Post("/auth/mfa/totp/enroll", h.PostAuthMfaTotpEnroll)
+		account.With(middleware.EnforceCSRF(cfg.CSRFEnforce)).Post("/auth/mfa/totp/confirm", h.PostAuthMfaTotpConfirm)
 	})
 
 	api.Group(func(protected chi.Router) {
 		protected.Use(authMW.RequireAuth)
-		protected.Get("/auth/me", h.GetAuthMe)
-		protected.Get("/auth/csrf", h.GetAuthCsrf)
-		protected.With(middleware.EnforceCSRF(cfg.CSRFEnforce)).Post("/auth/logout", h.PostAuthLogout)
 
 		protected.With(
-			middleware.RequirePermission(q, "customers.read"),
+			middleware.RequirePermission("settings.manage"),
+		).Get("/settings", h.GetSettings)
+
+		protected.With(
+			middleware.RequirePermission("settings.manage"),
+			middleware.EnforceCSRF(cfg.CSRFEnforce),
+		).Patch("/settings", h.PatchSettings)
+
+		protected.With(
+			middleware.RequirePermission("settings.manage"),
+		).Get("/settings/sso", h.GetSettingsSso)
+
+		protected.With(
+			middleware.RequirePermission("settings.manage"),
+			middleware.EnforceCSRF(cfg.CSRFEnforce),
+		).Put("/settings/sso", h.PutSettingsSso)
+
+		protected.With(
+			middleware.RequireAnyPermission("settings.manage", "estimates.read"),
+		).Get("/settings/tariff", h.GetSettingsTariff)
+
+		protected.With(
+			middleware.RequirePermission("settings.manage"),
+			middleware.EnforceCSRF(cfg.CSRFEnforce),
+		).Put("/settings/tariff", h.PutSettingsTariff)
+
+		protected.With(
+			middleware.RequireAnyPermission("settings.manage", "estimates.read"),
+		).Get("/settings/estimate-document", h.GetSettingsEstimateDocument)
+
+		protected.With(
+			middleware.RequirePermission("settings.manage"),
+			middleware.EnforceCSRF(cfg.CSRFEnforce),
+		).Put("/settings/estimate-document", h.PutSettingsEstimateDocument)
+
+		protected.With(
+			middleware.RequirePermission("settings.manage"),
+		).Get("/settings/email", h.GetSettingsEmail)
+
+		protected.With(
+			middleware.RequirePermission("settings.manage"),
+			middleware.EnforceCSRF(cfg.CSRFEnforce),
+		).Put("/settings/email", h.PutSettingsEmail)
+
+		protected.With(
+			searchRateLimiter.Middleware("Too many search requests"),
+			middleware.RequirePermission("customers.read"),
+		).Get("/customers", func(w http.ResponseWriter, r *http.Request) {
+			query := r.URL.Query()
+			params := oapi.GetCustomersParams{}
+
+			if qRaw := strings.TrimSpace(query.Get("q")); qRaw != "" {
+				params.Q = &qRaw
+			}
+			if limitRaw := strings.TrimSpace(query.Get("limit")); limitRaw != "" {
+				parsed, err := strconv.Atoi(limitRaw)
+				if err != nil || parsed < 1 {
+					httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "limit must be a positive integer", nil)
+					return
+				}
+				params.Limit = &parsed
+			}
+			if cursorRaw := strings.TrimSpace(query.Get("cursor")); cursorRaw != "" {
+				params.Cursor = &cursorRaw
+			}
+
+			h.GetCustomers(w, r, params)
+		})
+
+		protected.With(
+			middleware.RequirePermission("customers.read"),
 		).Get("/customers/{customerId}", func(w http.ResponseWriter, r *http.Request) {
 			customerID, ok := parseUUIDParam(w, r, chi.URLParam(r, "customerId"), "invalid_customer_id", "Customer id must be a valid UUID")
 			if !ok {
@@ -96,19 +239,97 @@ func NewRouter(cfg config.Config, q *gen.Queries, pool *pgxpool.Pool, logger *sl
 		})
 
 		protected.With(
-			middleware.RequirePermission(q, "customers.write"),
+			middleware.RequirePermission("customers.write"),
 			middleware.EnforceCSRF(cfg.CSRFEnforce),
 		).Post("/customers", h.PostCustomers)
 
 		protected.With(
-			middleware.RequirePermission(q, "estimates.write"),
+			middleware.RequirePermission("customers.write"),
+			middleware.EnforceCSRF(cfg.CSRFEnforce),
+		).Patch("/customers/{customerId}", func(w http.ResponseWriter, r *http.Request) {
+			customerID, ok := parseUUIDParam(w, r, chi.URLParam(r, "customerId"), "invalid_customer_id", "Customer id must be a valid UUID")
+			if !ok {
+				return
+			}
+			h.PatchCustomersCustomerId(w, r, openapi_types.UUID(customerID))
+		})
+
+		protected.With(
+			middleware.RequirePermission("customers.write"),
+			middleware.EnforceCSRF(cfg.CSRFEnforce),
+		).Post("/customers/{customerId}/merge", func(w http.ResponseWriter, r *http.Request) {
+			customerID, ok := parseUUIDParam(w, r, chi.URLParam(r, "customerId"), "invalid_customer_id", "Customer id must be a valid UUID")
+			if !ok {
+				return
+			}
+			h.PostCustomersCustomerIdMerge(w, r, openapi_types.UUID(customerID))
+		})
+
+		protected.With(
+			searchRateLimiter.Middleware("Too many search requests"),
+			middleware.RequirePermission("estimates.read"),
+		).Get("/estimates", func(w http.ResponseWriter, r *http.Request) {
+			query := r.URL.Query()
+			params := oapi.GetEstimatesParams{}
+
+			if qRaw := strings.TrimSpace(query.Get("q")); qRaw != "" {
+				params.Q = &qRaw
+			}
+			if statusRaw := strings.TrimSpace(query.Get("status")); statusRaw != "" {
+				status := oapi.GetEstimatesParamsStatus(statusRaw)
+				params.Status = &status
+			}
+			if leadSourceRaw := strings.TrimSpace(query.Get("leadSource")); leadSourceRaw != "" {
+				params.LeadSource = &leadSourceRaw
+			}
+
+			var ok bool
+			if params.MoveDateFrom, ok = parseOptionalDateQueryParam(w, r, "moveDateFrom"); !ok {
+				return
+			}
+			if params.MoveDateTo, ok = parseOptionalDateQueryParam(w, r, "moveDateTo"); !ok {
+				return
+			}
+			if params.CreatedFrom, ok = parseOptionalTimeQueryParam(w, r, "createdFrom"); !ok {
+				return
+			}
+			if params.CreatedTo, ok = parseOptionalTimeQueryParam(w, r, "createdTo"); !ok {
+				return
+			}
+
+			if createdByRaw := strings.TrimSpace(query.Get("createdBy")); createdByRaw != "" {
+				createdBy, ok := parseUUIDParam(w, r, createdByRaw, "invalid_user_id", "createdBy must be a valid UUID")
+				if !ok {
+					return
+				}
+				typed := openapi_types.UUID(createdBy)
+				params.CreatedBy = &typed
+			}
+
+			if limitRaw := strings.TrimSpace(query.Get("limit")); limitRaw != "" {
+				parsed, err := strconv.Atoi(limitRaw)
+				if err != nil || parsed < 1 {
+					httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "limit must be a positive integer", nil)
+					return
+				}
+				params.Limit = &parsed
+			}
+			if cursorRaw := strings.TrimSpace(query.Get("cursor")); cursorRaw != "" {
+				params.Cursor = &cursorRaw
+			}
+
+			h.GetEstimates(w, r, params)
+		})
+
+		protected.With(
+			middleware.RequirePermission("estimates.write"),
 			middleware.EnforceCSRF(cfg.CSRFEnforce),
 		).Post("/estimates", func(w http.ResponseWriter, r *http.Request) {
 			h.PostEstimates(w, r, oapi.PostEstimatesParams{IdempotencyKey: r.Header.Get("Idempotency-Key")})
 		})
 
 		protected.With(
-			middleware.RequirePermission(q, "estimates.read"),
+			middleware.RequirePermission("estimates.read"),
 		).Get("/estimates/{estimateId}", func(w http.ResponseWriter, r *http.Request) {
 			estimateID, ok := parseUUIDParam(w, r, chi.URLParam(r, "estimateId"), "invalid_estimate_id", "Estimate id must be a valid UUID")
 			if !ok {
@@ -118,7 +339,7 @@ func NewRouter(cfg config.Config, q *gen.Queries, pool *pgxpool.Pool, logger *sl
 		})
 
 		protected.With(
-			middleware.RequirePermission(q, "estimates.write"),
+			middleware.RequirePermission("estimates.write"),
 			middleware.EnforceCSRF(cfg.CSRFEnforce),
 		).Patch("/estimates/{estimateId}", func(w http.ResponseWriter, r *http.Request) {
 			estimateID, ok := parseUUIDParam(w, r, chi.URLParam(r, "estimateId"), "invalid_estimate_id", "Estimate id must be a valid UUID")
@@ -129,7 +350,7 @@ func NewRouter(cfg config.Config, q *gen.Queries, pool *pgxpool.Pool, logger *sl
 		})
 
 		protected.With(
-			middleware.RequirePermission(q, "estimates.convert"),
+			middleware.RequirePermission("estimates.convert"),
 			middleware.EnforceCSRF(cfg.CSRFEnforce),
 		).Post("/estimates/{estimateId}/convert", func(w http.ResponseWriter, r *http.Request) {
 			estimateID, ok := parseUUIDParam(w, r, chi.URLParam(r, "estimateId"), "invalid_estimate_id", "Estimate id must be a valid UUID")
@@ -139,9 +360,227 @@ func NewRouter(cfg config.Config, q *gen.Queries, pool *pgxpool.Pool, logger *sl
 			h.PostEstimatesEstimateIdConvert(w, r, openapi_types.UUID(estimateID), oapi.PostEstimatesEstimateIdConvertParams{IdempotencyKey: r.Header.Get("Idempotency-Key")})
 		})
 
+		protected.With(
+			middleware.RequirePermission("estimates.write"),
+			middleware.EnforceCSRF(cfg.CSRFEnforce),
+		).Post("/estimates/{estimateId}/price", func(w http.ResponseWriter, r *http.Request) {
+			estimateID, ok := parseUUIDParam(w, r, chi.URLParam(r, "estimateId"), "invalid_estimate_id", "Estimate id must be a valid UUID")
+			if !ok {
+				return
+			}
+			h.PostEstimatesEstimateIdPrice(w, r, openapi_types.UUID(estimateID))
+		})
+
+		protected.With(
+			middleware.RequirePermission("estimates.read"),
+		).Get("/estimates/{estimateId}/revisions", func(w http.ResponseWriter, r *http.Request) {
+			estimateID, ok := parseUUIDParam(w, r, chi.URLParam(r, "estimateId"), "invalid_estimate_id", "Estimate id must be a valid UUID")
+			if !ok {
+				return
+			}
+			h.GetEstimatesEstimateIdRevisions(w, r, openapi_types.UUID(estimateID))
+		})
+
+		protected.With(
+			middleware.RequirePermission("estimates.read"),
+		).Get("/estimates/{estimateId}/revisions/{revisionNumber}", func(w http.ResponseWriter, r *http.Request) {
+			estimateID, ok := parseUUIDParam(w, r, chi.URLParam(r, "estimateId"), "invalid_estimate_id", "Estimate id must be a valid UUID")
+			if !ok {
+				return
+			}
+			revisionNumber, ok := parseRevisionNumberParam(w, r, chi.URLParam(r, "revisionNumber"))
+			if !ok {
+				return
+			}
+			h.GetEstimatesEstimateIdRevisionsRevisionNumber(w, r, openapi_types.UUID(estimateID), revisionNumber)
+		})
+
+		protected.With(
+			middleware.RequirePermission("estimates.read"),
+		).Get("/estimates/{estimateId}/revisions/{revisionNumber}/diff", func(w http.ResponseWriter, r *http.Request) {
+			estimateID, ok := parseUUIDParam(w, r, chi.URLParam(r, "estimateId"), "invalid_estimate_id", "Estimate id must be a valid UUID")
+			if !ok {
+				return
+			}
+			revisionNumber, ok := parseRevisionNumberParam(w, r, chi.URLParam(r, "revisionNumber"))
+			if !ok {
+				return
+			}
+			params := oapi.GetEstimatesEstimateIdRevisionsRevisionNumberDiffParams{}
+			if againstRaw := strings.TrimSpace(r.URL.Query().Get("against")); againstRaw != "" {
+				parsed, err := strconv.Atoi(againstRaw)
+				if err != nil || parsed < 1 {
+					httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "against must be a positive integer", nil)
+					return
+				}
+				params.Against = &parsed
+			}
+			h.GetEstimatesEstimateIdRevisionsRevisionNumberDiff(w, r, openapi_types.UUID(estimateID), revisionNumber, params)
+		})
+
+		protected.With(
+			middleware.RequirePermission("estimates.write"),
+			middleware.EnforceCSRF(cfg.CSRFEnforce),
+		).Post("/estimates/{estimateId}/revisions/{revisionNumber}/restore", func(w http.ResponseWriter, r *http.Request) {
+			estimateID, ok := parseUUIDParam(w, r, chi.URLParam(r, "estimateId"), "invalid_estimate_id", "Estimate id must be a valid UUID")
+			if !ok {
+				return
+			}
+			revisionNumber, ok := parseRevisionNumberParam(w, r, chi.URLParam(r, "revisionNumber"))
+			if !ok {
+				return
+			}
+			h.PostEstimatesEstimateIdRevisionsRevisionNumberRestore(w, r, openapi_types.UUID(estimateID), revisionNumber)
+		})
+
+		protected.With(
+			middleware.RequirePermission("estimates.read"),
+		).Get("/estimates/{estimateId}/document.pdf", func(w http.ResponseWriter, r *http.Request) {
+			estimateID, ok := parseUUIDParam(w, r, chi.URLParam(r, "estimateId"), "invalid_estimate_id", "Estimate id must be a valid UUID")
+			if !ok {
+				return
+			}
+			h.GetEstimatesEstimateIdDocumentPdf(w, r, openapi_types.UUID(estimateID))
+		})
+
+		protected.With(
+			middleware.RequirePermission("estimates.read"),
+		).Get("/estimates/{estimateId}/emails", func(w http.ResponseWriter, r *http.Request) {
+			estimateID, ok := parseUUIDParam(w, r, chi.URLParam(r, "estimateId"), "invalid_estimate_id", "Estimate id must be a valid UUID")
+			if !ok {
+				return
+			}
+			h.GetEstimatesEstimateIdEmails(w, r, openapi_types.UUID(estimateID))
+		})
+
+		protected.With(
+			middleware.RequirePermission("estimates.write"),
+			middleware.EnforceCSRF(cfg.CSRFEnforce),
+		).Post("/estimates/{estimateId}/emails", func(w http.ResponseWriter, r *http.Request) {
+			estimateID, ok := parseUUIDParam(w, r, chi.URLParam(r, "estimateId"), "invalid_estimate_id", "Estimate id must be a valid UUID")
+			if !ok {
+				return
+			}
+			h.PostEstimatesEstimateIdEmails(w, r, openapi_types.UUID(estimateID))
+		})
+
+		protected.With(
+			middleware.RequirePermission("estimates.write"),
+			middleware.EnforceCSRF(cfg.CSRFEnforce),
+		).Post("/estimates/{estimateId}/acceptance-links", func(w http.ResponseWriter, r *http.Request) {
+			estimateID, ok := parseUUIDParam(w, r, chi.URLParam(r, "estimateId"), "invalid_estimate_id", "Estimate id must be a valid UUID")
+			if !ok {
+				return
+			}
+			h.PostEstimatesEstimateIdAcceptanceLinks(w, r, openapi_types.UUID(estimateID))
+		})
+
+		protected.With(
+			middleware.RequirePermission("estimates.read"),
+		).Get("/estimates/{estimateId}/signatures", func(w http.ResponseWriter, r *http.Request) {
+			estimateID, ok := parseUUIDParam(w, r, chi.URLParam(r, "estimateId"), "invalid_estimate_id", "Estimate id must be a valid UUID")
+			if !ok {
+				return
+			}
+			h.GetEstimatesEstimateIdSignatures(w, r, openapi_types.UUID(estimateID))
+		})
+
+		protected.With(
+			middleware.RequirePermission("estimates.read"),
+		).Get("/estimates/{estimateId}/line-items", func(w http.ResponseWriter, r *http.Request) {
+			estimateID, ok := parseUUIDParam(w, r, chi.URLParam(r, "estimateId"), "invalid_estimate_id", "Estimate id must be a valid UUID")
+			if !ok {
+				return
+			}
+			h.GetEstimatesEstimateIdLineItems(w, r, openapi_types.UUID(estimateID))
+		})
+
+		protected.With(
+			middleware.RequirePermission("estimates.write"),
+			middleware.EnforceCSRF(cfg.CSRFEnforce),
+		).Post("/estimates/{estimateId}/line-items", func(w http.ResponseWriter, r *http.Request) {
+			estimateID, ok := parseUUIDParam(w, r, chi.URLParam(r, "estimateId"), "invalid_estimate_id", "Estimate id must be a valid UUID")
+			if !ok {
+				return
+			}
+			h.PostEstimatesEstimateIdLineItems(w, r, openapi_types.UUID(estimateID))
+		})
+
+		protected.With(
+			middleware.RequirePermission("estimates.write"),
+			middleware.EnforceCSRF(cfg.CSRFEnforce),
+		).Patch("/estimates/{estimateId}/line-items/{lineItemId}", func(w http.ResponseWriter, r *http.Request) {
+			estimateID, ok := parseUUIDParam(w, r, chi.URLParam(r, "estimateId"), "invalid_estimate_id", "Estimate id must be a valid UUID")
+			if !ok {
+				return
+			}
+			lineItemID, ok := parseUUIDParam(w, r, chi.URLParam(r, "lineItemId"), "invalid_line_item_id", "Line item id must be a valid UUID")
+			if !ok {
+				return
+			}
+			h.PatchEstimatesEstimateIdLineItemsLineItemId(w, r, openapi_types.UUID(estimateID), openapi_types.UUID(lineItemID))
+		})
+
+		protected.With(
+			middleware.RequirePermission("estimates.write"),
+			middleware.EnforceCSRF(cfg.CSRFEnforce),
+		).Delete("/estimates/{estimateId}/line-items/{lineItemId}", func(w http.ResponseWriter, r *http.Request) {
+			estimateID, ok := parseUUIDParam(w, r, chi.URLParam(r, "estimateId"), "invalid_estimate_id", "Estimate id must be a valid UUID")
+			if !ok {
+				return
+			}
+			lineItemID, ok := parseUUIDParam(w, r, chi.URLParam(r, "lineItemId"), "invalid_line_item_id", "Line item id must be a valid UUID")
+			if !ok {
+				return
+			}
+			h.DeleteEstimatesEstimateIdLineItemsLineItemId(w, r, openapi_types.UUID(estimateID), openapi_types.UUID(lineItemID))
+		})
+
+		protected.With(
+			middleware.RequirePermission("estimates.read"),
+		).Get("/inventory-items", func(w http.ResponseWriter, r *http.Request) {
+			params := oapi.GetInventoryItemsParams{}
+			if boolRaw := strings.TrimSpace(r.URL.Query().Get("includeInactive")); boolRaw != "" {
+				parsed, err := strconv.ParseBool(boolRaw)
+				if err != nil {
+					httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "includeInactive must be true or false", nil)
+					return
+				}
+				params.IncludeInactive = &parsed
+			}
+			h.GetInventoryItems(w, r, params)
+		})
+
+		protected.With(
+			middleware.RequirePermission("settings.manage"),
+			middleware.EnforceCSRF(cfg.CSRFEnforce),
+		).Post("/inventory-items", h.PostInventoryItems)
+
+		protected.With(
+			middleware.RequirePermission("settings.manage"),
+			middleware.EnforceCSRF(cfg.CSRFEnforce),
+		).Patch("/inventory-items/{itemId}", func(w http.ResponseWriter, r *http.Request) {
+			itemID, ok := parseUUIDParam(w, r, chi.URLParam(r, "itemId"), "invalid_inventory_item_id", "Inventory item id must be a valid UUID")
+			if !ok {
+				return
+			}
+			h.PatchInventoryItemsItemId(w, r, openapi_types.UUID(itemID))
+		})
+
+		protected.With(
+			middleware.RequirePermission("settings.manage"),
+			middleware.EnforceCSRF(cfg.CSRFEnforce),
+		).Delete("/inventory-items/{itemId}", func(w http.ResponseWriter, r *http.Request) {
+			itemID, ok := parseUUIDParam(w, r, chi.URLParam(r, "itemId"), "invalid_inventory_item_id", "Inventory item id must be a valid UUID")
+			if !ok {
+				return
+			}
+			h.DeleteInventoryItemsItemId(w, r, openapi_types.UUID(itemID))
+		})
+
 		protected.With(
 			searchRateLimiter.Middleware("Too many search requests"),
-			middleware.RequirePermission(q, "calendar.read"),
+			middleware.RequirePermission("calendar.read"),
 		).Get("/calendar", func(w http.ResponseWriter, r *http.Request) {
 			fromDate, ok := parseDateQueryParam(w, r, "from")
 			if !ok {
@@ -185,7 +624,62 @@ func NewRouter(cfg config.Config, q *gen.Queries, pool *pgxpool.Pool, logger *sl
 		})
 
 		protected.With(
-			middleware.RequirePermission(q, "jobs.read"),
+			searchRateLimiter.Middleware("Too many search requests"),
+			middleware.RequirePermission("jobs.read"),
+		).Get("/jobs", func(w http.ResponseWriter, r *http.Request) {
+			query := r.URL.Query()
+			params := oapi.GetJobsParams{}
+
+			if statusRaw := strings.TrimSpace(query.Get("status")); statusRaw != "" {
+				status := oapi.GetJobsParamsStatus(statusRaw)
+				params.Status = &status
+			}
+			if sourceRaw := strings.TrimSpace(query.Get("source")); sourceRaw != "" {
+				source := oapi.GetJobsParamsSource(sourceRaw)
+				params.Source = &source
+			}
+			if boolRaw := strings.TrimSpace(query.Get("scheduled")); boolRaw != "" {
+				parsed, err := strconv.ParseBool(boolRaw)
+				if err != nil {
+					httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "scheduled must be true or false", nil)
+					return
+				}
+				params.Scheduled = &parsed
+			}
+			if boolRaw := strings.TrimSpace(query.Get("balanceDue")); boolRaw != "" {
+				parsed, err := strconv.ParseBool(boolRaw)
+				if err != nil {
+					httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "balanceDue must be true or false", nil)
+					return
+				}
+				params.BalanceDue = &parsed
+			}
+			if customerIDRaw := strings.TrimSpace(query.Get("customerId")); customerIDRaw != "" {
+				customerID, ok := parseUUIDParam(w, r, customerIDRaw, "invalid_customer_id", "customerId must be a valid UUID")
+				if !ok {
+					return
+				}
+				typed := openapi_types.UUID(customerID)
+				params.CustomerId = &typed
+			}
+
+			if limitRaw := strings.TrimSpace(query.Get("limit")); limitRaw != "" {
+				parsed, err := strconv.Atoi(limitRaw)
+				if err != nil || parsed < 1 {
+					httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "limit must be a positive integer", nil)
+					return
+				}
+				params.Limit = &parsed
+			}
+			if cursorRaw := strings.TrimSpace(query.Get("cursor")); cursorRaw != "" {
+				params.Cursor = &cursorRaw
+			}
+
+			h.GetJobs(w, r, params)
+		})
+
+		protected.With(
+			middleware.RequirePermission("jobs.read"),
 		).Get("/jobs/{jobId}", func(w http.ResponseWriter, r *http.Request) {
 			jobID, ok := parseUUIDParam(w, r, chi.URLParam(r, "jobId"), "invalid_job_id", "Job id must be a valid UUID")
 			if !ok {
@@ -195,7 +689,7 @@ func NewRouter(cfg config.Config, q *gen.Queries, pool *pgxpool.Pool, logger *sl
 		})
 
 		protected.With(
-			middleware.RequirePermission(q, "calendar.write"),
+			middleware.RequirePermission("calendar.write"),
 			middleware.EnforceCSRF(cfg.CSRFEnforce),
 		).Patch("/jobs/{jobId}", func(w http.ResponseWriter, r *http.Request) {
 			jobID, ok := parseUUIDParam(w, r, chi.URLParam(r, "jobId"), "invalid_job_id", "Job id must be a valid UUID")
@@ -206,7 +700,7 @@ func NewRouter(cfg config.Config, q *gen.Queries, pool *pgxpool.Pool, logger *sl
 		})
 
 		protected.With(
-			middleware.RequirePermission(q, "storage.write"),
+			middleware.RequirePermission("storage.write"),
 			middleware.EnforceCSRF(cfg.CSRFEnforce),
 		).Post("/jobs/{jobId}/storage", func(w http.ResponseWriter, r *http.Request) {
 			jobID, ok := parseUUIDParam(w, r, chi.URLParam(r, "jobId"), "invalid_job_id", "Job id must be a valid UUID")
@@ -216,9 +710,73 @@ func NewRouter(cfg config.Config, q *gen.Queries, pool *pgxpool.Pool, logger *sl
 			h.PostJobsJobIdStorage(w, r, openapi_types.UUID(jobID))
 		})
 
+		protected.With(
+			middleware.RequirePermission("jobs.read"),
+		).Get("/jobs/{jobId}/emails", func(w http.ResponseWriter, r *http.Request) {
+			jobID, ok := parseUUIDParam(w, r, chi.URLParam(r, "jobId"), "invalid_job_id", "Job id must be a valid UUID")
+			if !ok {
+				return
+			}
+			h.GetJobsJobIdEmails(w, r, openapi_types.UUID(jobID))
+		})
+
+		protected.With(
+			middleware.RequirePermission("jobs.write"),
+			middleware.EnforceCSRF(cfg.CSRFEnforce),
+		).Post("/jobs/{jobId}/emails", func(w http.ResponseWriter, r *http.Request) {
+			jobID, ok := parseUUIDParam(w, r, chi.URLParam(r, "jobId"), "invalid_job_id", "Job id must be a valid UUID")
+			if !ok {
+				return
+			}
+			h.PostJobsJobIdEmails(w, r, openapi_types.UUID(jobID))
+		})
+
+		protected.With(
+			middleware.RequirePermission("jobs.read"),
+		).Get("/jobs/{jobId}/ledger", func(w http.ResponseWriter, r *http.Request) {
+			jobID, ok := parseUUIDParam(w, r, chi.URLParam(r, "jobId"), "invalid_job_id", "Job id must be a valid UUID")
+			if !ok {
+				return
+			}
+			h.GetJobsJobIdLedger(w, r, openapi_types.UUID(jobID))
+		})
+
+		protected.With(
+			middleware.RequirePermission("jobs.write"),
+			middleware.EnforceCSRF(cfg.CSRFEnforce),
+		).Post("/jobs/{jobId}/ledger", func(w http.ResponseWriter, r *http.Request) {
+			jobID, ok := parseUUIDParam(w, r, chi.URLParam(r, "jobId"), "invalid_job_id", "Job id must be a valid UUID")
+			if !ok {
+				return
+			}
+			h.PostJobsJobIdLedger(w, r, openapi_types.UUID(jobID), oapi.PostJobsJobIdLedgerParams{IdempotencyKey: r.Header.Get("Idempotency-Key")})
+		})
+
+		protected.With(
+			searchRateLimiter.Middleware("Too many search requests"),
+			middleware.RequireAnyPermission("estimates.read", "jobs.read", "customers.read"),
+		).Get("/search", func(w http.ResponseWriter, r *http.Request) {
+			query := r.URL.Query()
+			params := oapi.GetSearchParams{Q: strings.TrimSpace(query.Get("q"))}
+			if params.Q == "" {
+				httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "q query parameter is required", nil)
+				return
+			}
+			if limitRaw := strings.TrimSpace(query.Get("limit")); limitRaw != "" {
+				parsed, err := strconv.Atoi(limitRaw)
+				if err != nil || parsed < 1 {
+					httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "limit must be a positive integer", nil)
+					return
+				}
+				params.Limit = &parsed
+			}
+
+			h.GetSearch(w, r, params)
+		})
+
 		protected.With(
 			searchRateLimiter.Middleware("Too many search requests"),
-			middleware.RequirePermission(q, "storage.read"),
+			middleware.RequirePermission("storage.read"),
 		).Get("/storage", func(w http.ResponseWriter, r *http.Request) {
 			query := r.URL.Query()
 			facility := strings.TrimSpace(query.Get("facility"))
@@ -289,7 +847,7 @@ func NewRouter(cfg config.Config, q *gen.Queries, pool *pgxpool.Pool, logger *sl
 		})
 
 		protected.With(
-			middleware.RequirePermission(q, "storage.read"),
+			middleware.RequirePermission("storage.read"),
 		).Get("/storage/{storageRecordId}", func(w http.ResponseWriter, r *http.Request) {
 			storageRecordID, ok := parseUUIDParam(w, r, chi.URLParam(r, "storageRecordId"), "invalid_storage_record_id", "Storage record id must be a valid UUID")
 			if !ok {
@@ -299,7 +857,7 @@ func NewRouter(cfg config.Config, q *gen.Queries, pool *pgxpool.Pool, logger *sl
 		})
 
 		protected.With(
-			middleware.RequirePermission(q, "storage.write"),
+			middleware.RequirePermission("storage.write"),
 			middleware.EnforceCSRF(cfg.CSRFEnforce),
 		).Put("/storage/{storageRecordId}", func(w http.ResponseWriter, r *http.Request) {
 			storageRecordID, ok := parseUUIDParam(w, r, chi.URLParam(r, "storageRecordId"), "invalid_storage_record_id", "Storage record id must be a valid UUID")
@@ -309,21 +867,63 @@ func NewRouter(cfg config.Config, q *gen.Queries, pool *pgxpool.Pool, logger *sl
 			h.PutStorageStorageRecordId(w, r, openapi_types.UUID(storageRecordID))
 		})
 
+		protected.With(
+			middleware.RequirePermission("storage.read"),
+		).Get("/storage/{storageRecordId}/emails", func(w http.ResponseWriter, r *http.Request) {
+			storageRecordID, ok := parseUUIDParam(w, r, chi.URLParam(r, "storageRecordId"), "invalid_storage_record_id", "Storage record id must be a valid UUID")
+			if !ok {
+				return
+			}
+			h.GetStorageStorageRecordIdEmails(w, r, openapi_types.UUID(storageRecordID))
+		})
+
+		protected.With(
+			middleware.RequirePermission("storage.write"),
+			middleware.EnforceCSRF(cfg.CSRFEnforce),
+		).Post("/storage/{storageRecordId}/emails", func(w http.ResponseWriter, r *http.Request) {
+			storageRecordID, ok := parseUUIDParam(w, r, chi.URLParam(r, "storageRecordId"), "invalid_storage_record_id", "Storage record id must be a valid UUID")
+			if !ok {
+				return
+			}
+			h.PostStorageStorageRecordIdEmails(w, r, openapi_types.UUID(storageRecordID))
+		})
+
+		protected.With(
+			middleware.RequirePermission("storage.read"),
+		).Get("/storage/{storageRecordId}/ledger", func(w http.ResponseWriter, r *http.Request) {
+			storageRecordID, ok := parseUUIDParam(w, r, chi.URLParam(r, "storageRecordId"), "invalid_storage_record_id", "Storage record id must be a valid UUID")
+			if !ok {
+				return
+			}
+			h.GetStorageStorageRecordIdLedger(w, r, openapi_types.UUID(storageRecordID))
+		})
+
+		protected.With(
+			middleware.RequirePermission("storage.write"),
+			middleware.EnforceCSRF(cfg.CSRFEnforce),
+		).Post("/storage/{storageRecordId}/ledger", func(w http.ResponseWriter, r *http.Request) {
+			storageRecordID, ok := parseUUIDParam(w, r, chi.URLParam(r, "storageRecordId"), "invalid_storage_record_id", "Storage record id must be a valid UUID")
+			if !ok {
+				return
+			}
+			h.PostStorageStorageRecordIdLedger(w, r, openapi_types.UUID(storageRecordID), oapi.PostStorageStorageRecordIdLedgerParams{IdempotencyKey: r.Header.Get("Idempotency-Key")})
+		})
+
 		protected.With(
 			importRateLimiter.Middleware("Too many import requests"),
-			middleware.RequirePermission(q, "imports.write"),
+			middleware.RequirePermission("imports.write"),
 			middleware.EnforceCSRF(cfg.CSRFEnforce),
 		).Post("/imports/dry-run", h.PostImportsDryRun)
 
 		protected.With(
 			importRateLimiter.Middleware("Too many import requests"),
-			middleware.RequirePermission(q, "imports.write"),
+			middleware.RequirePermission("imports.write"),
 			middleware.EnforceCSRF(cfg.CSRFEnforce),
 		).Post("/imports/apply", h.PostImportsApply)
 
 		protected.With(
 			importRateLimiter.Middleware("Too many import requests"),
-			middleware.RequirePermission(q, "imports.read"),
+			middleware.RequirePermission("imports.read"),
 		).Get("/imports/{importRunId}", func(w http.ResponseWriter, r *http.Request) {
 			importRunID, ok := parseUUIDParam(w, r, chi.URLParam(r, "importRunId"), "invalid_import_run_id", "Import run id must be a valid UUID")
 			if !ok {
@@ -334,7 +934,7 @@ func NewRouter(cfg config.Config, q *gen.Queries, pool *pgxpool.Pool, logger *sl
 
 		protected.With(
 			importRateLimiter.Middleware("Too many import requests"),
-			middleware.RequirePermission(q, "imports.read"),
+			middleware.RequirePermission("imports.read"),
 		).Get("/imports/{importRunId}/errors.csv", func(w http.ResponseWriter, r *http.Request) {
 			importRunID, ok := parseUUIDParam(w, r, chi.URLParam(r, "importRunId"), "invalid_import_run_id", "Import run id must be a valid UUID")
 			if !ok {
@@ -345,7 +945,7 @@ func NewRouter(cfg config.Config, q *gen.Queries, pool *pgxpool.Pool, logger *sl
 
 		protected.With(
 			importRateLimiter.Middleware("Too many import requests"),
-			middleware.RequirePermission(q, "imports.read"),
+			middleware.RequirePermission("imports.read"),
 		).Get("/imports/{importRunId}/report.json", func(w http.ResponseWriter, r *http.Request) {
 			importRunID, ok := parseUUIDParam(w, r, chi.URLParam(r, "importRunId"), "invalid_import_run_id", "Import run id must be a valid UUID")
 			if !ok {
@@ -356,7 +956,7 @@ func NewRouter(cfg config.Config, q *gen.Queries, pool *pgxpool.Pool, logger *sl
 
 		protected.With(
 			importRateLimiter.Middleware("Too many import requests"),
-			middleware.RequireAnyPermission(q, "imports.read", "exports.read"),
+			middleware.RequireAnyPermission("imports.read", "exports.read"),
 		).Get("/imports/templates/{template}.csv", func(w http.ResponseWriter, r *http.Request) {
 			template := oapi.ImportTemplate(strings.TrimSpace(chi.URLParam(r, "template")))
 			h.GetImportsTemplatesTemplateCsv(w, r, template)
@@ -364,23 +964,153 @@ func NewRouter(cfg config.Config, q *gen.Queries, pool *pgxpool.Pool, logger *sl
 
 		protected.With(
 			exportRateLimiter.Middleware("Too many export requests"),
-			middleware.RequirePermission(q, "exports.read"),
+			middleware.RequirePermission("exports.read"),
 		).Get("/exports/customers.csv", h.GetExportsCustomersCsv)
 
 		protected.With(
 			exportRateLimiter.Middleware("Too many export requests"),
-			middleware.RequirePermission(q, "exports.read"),
+			middleware.RequirePermission("exports.read"),
 		).Get("/exports/estimates.csv", h.GetExportsEstimatesCsv)
 
 		protected.With(
 			exportRateLimiter.Middleware("Too many export requests"),
-			middleware.RequirePermission(q, "exports.read"),
+			middleware.RequirePermission("exports.read"),
 		).Get("/exports/jobs.csv", h.GetExportsJobsCsv)
 
 		protected.With(
 			exportRateLimiter.Middleware("Too many export requests"),
-			middleware.RequirePermission(q, "exports.read"),
+			middleware.RequirePermission("exports.read"),
 		).Get("/exports/storage.csv", h.GetExportsStorageCsv)
+
+		protected.With(
+			middleware.RequirePermission("users.manage"),
+		).Get("/users", h.GetUsers)
+
+		protected.With(
+			middleware.RequirePermission("users.manage"),
+			middleware.EnforceCSRF(cfg.CSRFEnforce),
+		).Post("/users", h.PostUsers)
+
+		protected.With(
+			middleware.RequirePermission("users.manage"),
+		).Get("/users/{userId}", func(w http.ResponseWriter, r *http.Request) {
+			userID, ok := parseUUIDParam(w, r, chi.URLParam(r, "userId"), "invalid_user_id", "User id must be a valid UUID")
+			if !ok {
+				return
+			}
+			h.GetUsersUserId(w, r, openapi_types.UUID(userID))
+		})
+
+		protected.With(
+			middleware.RequirePermission("users.manage"),
+			middleware.EnforceCSRF(cfg.CSRFEnforce),
+		).Patch("/users/{userId}", func(w http.ResponseWriter, r *http.Request) {
+			userID, ok := parseUUIDParam(w, r, chi.URLParam(r, "userId"), "invalid_user_id", "User id must be a valid UUID")
+			if !ok {
+				return
+			}
+			h.PatchUsersUserId(w, r, openapi_types.UUID(userID))
+		})
+
+		protected.With(
+			middleware.RequirePermission("users.manage"),
+			middleware.EnforceCSRF(cfg.CSRFEnforce),
+		).Post("/users/{userId}/password-reset", func(w http.ResponseWriter, r *http.Request) {
+			userID, ok := parseUUIDParam(w, r, chi.URLParam(r, "userId"), "invalid_user_id", "User id must be a valid UUID")
+			if !ok {
+				return
+			}
+			h.PostUsersUserIdPasswordReset(w, r, openapi_types.UUID(userID))
+		})
+
+		protected.With(
+			middleware.RequirePermission("users.manage"),
+			middleware.EnforceCSRF(cfg.CSRFEnforce),
+		).Post("/users/{userId}/password-reset-token", func(w http.ResponseWriter, r *http.Request) {
+			userID, ok := parseUUIDParam(w, r, chi.URLParam(r, "userId"), "invalid_user_id", "User id must be a valid UUID")
+			if !ok {
+				return
+			}
+			h.PostUsersUserIdPasswordResetToken(w, r, openapi_types.UUID(userID))
+		})
+
+		protected.With(
+			middleware.RequirePermission("users.manage"),
+			middleware.EnforceCSRF(cfg.CSRFEnforce),
+		).Post("/users/{userId}/unlock", func(w http.ResponseWriter, r *http.Request) {
+			userID, ok := parseUUIDParam(w, r, chi.URLParam(r, "userId"), "invalid_user_id", "User id must be a valid UUID")
+			if !ok {
+				return
+			}
+			h.PostUsersUserIdUnlock(w, r, openapi_types.UUID(userID))
+		})
+
+		protected.With(
+			middleware.RequirePermission("users.manage"),
+			middleware.EnforceCSRF(cfg.CSRFEnforce),
+		).Delete("/users/{userId}/sessions", func(w http.ResponseWriter, r *http.Request) {
+			userID, ok := parseUUIDParam(w, r, chi.URLParam(r, "userId"), "invalid_user_id", "User id must be a valid UUID")
+			if !ok {
+				return
+			}
+			h.DeleteUsersUserIdSessions(w, r, openapi_types.UUID(userID))
+		})
+
+		protected.With(
+			middleware.RequirePermission("roles.manage"),
+			middleware.EnforceCSRF(cfg.CSRFEnforce),
+		).Put("/users/{userId}/roles", func(w http.ResponseWriter, r *http.Request) {
+			userID, ok := parseUUIDParam(w, r, chi.URLParam(r, "userId"), "invalid_user_id", "User id must be a valid UUID")
+			if !ok {
+				return
+			}
+			h.PutUsersUserIdRoles(w, r, openapi_types.UUID(userID))
+		})
+
+		protected.With(
+			middleware.RequirePermission("roles.manage"),
+		).Get("/permissions", h.GetPermissions)
+
+		protected.With(
+			middleware.RequirePermission("roles.manage"),
+		).Get("/roles", h.GetRoles)
+
+		protected.With(
+			middleware.RequirePermission("roles.manage"),
+			middleware.EnforceCSRF(cfg.CSRFEnforce),
+		).Post("/roles", h.PostRoles)
+
+		protected.With(
+			middleware.RequirePermission("roles.manage"),
+		).Get("/roles/{roleId}", func(w http.ResponseWriter, r *http.Request) {
+			roleID, ok := parseUUIDParam(w, r, chi.URLParam(r, "roleId"), "invalid_role_id", "Role id must be a valid UUID")
+			if !ok {
+				return
+			}
+			h.GetRolesRoleId(w, r, openapi_types.UUID(roleID))
+		})
+
+		protected.With(
+			middleware.RequirePermission("roles.manage"),
+			middleware.EnforceCSRF(cfg.CSRFEnforce),
+		).Patch("/roles/{roleId}", func(w http.ResponseWriter, r *http.Request) {
+			roleID, ok := parseUUIDParam(w, r, chi.URLParam(r, "roleId"), "invalid_role_id", "Role id must be a valid UUID")
+			if !ok {
+				return
+			}
+			h.PatchRolesRoleId(w, r, openapi_types.UUID(roleID))
+		})
+
+		protected.With(
+			middleware.RequirePermission("roles.manage"),
+			middleware.EnforceCSRF(cfg.CSRFEnforce),
+		).Delete("/roles/{roleId}", func(w http.ResponseWriter, r *http.Request) {
+			roleID, ok := parseUUIDParam(w, r, chi.URLParam(r, "roleId"), "invalid_role_id", "Role id must be a valid UUID")
+			if !ok {
+				return
+			}
+			h.DeleteRolesRoleId(w, r, openapi_types.UUID(roleID))
+		})
 	})
 
 	r.Mount("/api", api)
@@ -396,6 +1126,15 @@ func parseUUIDParam(w http.ResponseWriter, r *http.Request, raw, code, message s
 	return id, true
 }
 
+func parseRevisionNumberParam(w http.ResponseWriter, r *http.Request, raw string) (int, bool) {
+	revisionNumber, err := strconv.Atoi(raw)
+	if err != nil || revisionNumber < 1 {
+		httpx.WriteError(w, r, http.StatusBadRequest, "invalid_revision_number", "Revision number must be a positive integer", nil)
+		return 0, false
+	}
+	return revisionNumber, true
+}
+
 func parseDateQueryParam(w http.ResponseWriter, r *http.Request, key string) (openapi_types.Date, bool) {
 	raw := r.URL.Query().Get(key)
 	if raw == "" {
@@ -409,3 +1148,29 @@ func parseDateQueryParam(w http.ResponseWriter, r *http.Request, key string) (op
 	}
 	return openapi_types.Date{Time: time.Date(parsed.Year(), parsed.Month(), parsed.Day(), 0, 0, 0, 0, time.UTC)}, true
 }
+
+func parseOptionalDateQueryParam(w http.ResponseWriter, r *http.Request, key string) (*openapi_types.Date, bool) {
+	raw := strings.TrimSpace(r.URL.Query().Get(key))
+	if raw == "" {
+		return nil, true
+	}
+	parsed, err := time.Parse("2006-01-02", raw)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", key+" must be in YYYY-MM-DD format", nil)
+		return nil, false
+	}
+	return &openapi_types.Date{Time: time.Date(parsed.Year(), parsed.Month(), parsed.Day(), 0, 0, 0, 0, time.UTC)}, true
+}
+
+func parseOptionalTimeQueryParam(w http.ResponseWriter, r *http.Request, key string) (*time.Time, bool) {
+	raw := strings.TrimSpace(r.URL.Query().Get(key))
+	if raw == "" {
+		return nil, true
+	}
+	parsed, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", key+" must be an RFC 3339 timestamp", nil)
+		return nil, false
+	}
+	return &parsed, true
+}
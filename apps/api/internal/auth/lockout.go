@@ -0,0 +1,22 @@
+package auth
+
+import "time"
+
+// LockoutDuration returns how long an account stays locked for its
+// (priorLocks+1)-th lockout: base doubled once per earlier lock, capped at max.
+func LockoutDuration(base, max time.Duration, priorLocks int) time.Duration {
+	if base <= 0 {
+		return 0
+	}
+	d := base
+	for i := 0; i < priorLocks; i++ {
+		if max > 0 && d >= max {
+			break
+		}
+		d *= 2
+	}
+	if max > 0 && d > max {
+		return max
+	}
+	return d
+}
@@ -0,0 +1,24 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLockoutDurationDoublesUntilCap(t *testing.T) {
+	cases := []struct {
+		priorLocks int
+		want       time.Duration
+	}{
+		{priorLocks: 0, want: 5 * time.Minute},
+		{priorLocks: 1, want: 10 * time.Minute},
+		{priorLocks: 3, want: 40 * time.Minute},
+		{priorLocks: 4, want: time.Hour},
+		{priorLocks: 100, want: time.Hour},
+	}
+	for _, tc := range cases {
+		if got := LockoutDuration(5*time.Minute, time.Hour, tc.priorLocks); got != tc.want {
+			t.Fatalf("priorLocks=%d: expected %s, got %s", tc.priorLocks, tc.want, got)
+		}
+	}
+}
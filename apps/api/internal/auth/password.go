@@ -33,33 +33,59 @@ func HashPassword(password string) (string, error) {
 }
 
 func VerifyPassword(password, encoded string) (bool, error) {
+	params, salt, expectedHash, err := decodePasswordHash(encoded)
+	if err != nil {
+		return false, err
+	}
+
+	hash := argon2.IDKey([]byte(password), salt, params.iterations, params.memory, params.parallelism, uint32(len(expectedHash)))
+	if subtle.ConstantTimeCompare(hash, expectedHash) == 1 {
+		return true, nil
+	}
+	return false, nil
+}
+
+// PasswordNeedsRehash reports whether encoded was made with different argon2
+// parameters than HashPassword uses now. Callers rehash after a successful
+// verification, while they still have the plaintext.
+func PasswordNeedsRehash(encoded string) bool {
+	params, _, hash, err := decodePasswordHash(encoded)
+	if err != nil {
+		return false
+	}
+	return params.memory != memory ||
+		params.iterations != iterations ||
+		params.parallelism != parallelism ||
+		len(hash) != keyLength
+}
+
+type passwordParams struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+}
+
+func decodePasswordHash(encoded string) (passwordParams, []byte, []byte, error) {
 	parts := strings.Split(encoded, "$")
 	if len(parts) != 6 {
-		return false, errors.New("invalid hash format")
+		return passwordParams{}, nil, nil, errors.New("invalid hash format")
 	}
 	if parts[1] != "argon2id" {
-		return false, errors.New("unexpected hash algorithm")
+		return passwordParams{}, nil, nil, errors.New("unexpected hash algorithm")
 	}
 
-	var mem uint32
-	var it uint32
-	var par uint8
-	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &mem, &it, &par); err != nil {
-		return false, fmt.Errorf("parse hash params: %w", err)
+	var params passwordParams
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.iterations, &params.parallelism); err != nil {
+		return passwordParams{}, nil, nil, fmt.Errorf("parse hash params: %w", err)
 	}
 
 	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
 	if err != nil {
-		return false, fmt.Errorf("decode salt: %w", err)
+		return passwordParams{}, nil, nil, fmt.Errorf("decode salt: %w", err)
 	}
-	expectedHash, err := base64.RawStdEncoding.DecodeString(parts[5])
+	hash, err := base64.RawStdEncoding.DecodeString(parts[5])
 	if err != nil {
-		return false, fmt.Errorf("decode hash: %w", err)
+		return passwordParams{}, nil, nil, fmt.Errorf("decode hash: %w", err)
 	}
-
-	hash := argon2.IDKey([]byte(password), salt, it, mem, par, uint32(len(expectedHash)))
-	if subtle.ConstantTimeCompare(hash, expectedHash) == 1 {
-		return true, nil
-	}
-	return false, nil
+	return params, salt, hash, nil
 }
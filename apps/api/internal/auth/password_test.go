@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"golang.org/x/crypto/argon2"
+)
+
+func TestPasswordNeedsRehash(t *testing.T) {
+	current, err := HashPassword("correct horse battery")
+	if err != nil {
+		t.Fatalf("hash: %v", err)
+	}
+	if PasswordNeedsRehash(current) {
+		t.Fatal("expected a hash with current parameters not to need a rehash")
+	}
+
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		t.Fatalf("salt: %v", err)
+	}
+	weak := argon2.IDKey([]byte("correct horse battery"), salt, 1, 32*1024, 1, keyLength)
+	legacy := fmt.Sprintf("$argon2id$v=19$m=%d,t=%d,p=%d$%s$%s", 32*1024, 1, 1,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(weak))
+
+	ok, err := VerifyPassword("correct horse battery", legacy)
+	if err != nil || !ok {
+		t.Fatalf("expected legacy hash to verify, got %v %v", ok, err)
+	}
+	if !PasswordNeedsRehash(legacy) {
+		t.Fatal("expected a hash with old parameters to need a rehash")
+	}
+	if PasswordNeedsRehash("not-a-hash") {
+		t.Fatal("expected malformed hashes to be left alone")
+	}
+}
+
+func TestPasswordPolicyCheck(t *testing.T) {
+	policy := PasswordPolicy{MinLength: 12, CheckBreached: true}
+	cases := []struct {
+		password string
+		inputs   []string
+		want     []string
+	}{
+		{password: "long enough phrase", want: nil},
+		{password: "short1!", want: []string{PasswordTooShort}},
+		{password: "Password123", want: []string{PasswordTooShort, PasswordBreached}},
+		{password: "QWERTYUIOP", want: []string{PasswordTooShort, PasswordBreached}},
+		{password: "dispatch.lead", inputs: []string{"Dispatch.Lead@example.com"}, want: []string{PasswordMatchesAccount}},
+		{password: string(make([]rune, MaxPasswordLength+1)), want: []string{PasswordTooLong}},
+	}
+	for _, tc := range cases {
+		err := policy.Check(tc.password, tc.inputs...)
+		if tc.want == nil {
+			if err != nil {
+				t.Fatalf("%q: expected no violations, got %v", tc.password, err)
+			}
+			continue
+		}
+		var policyErr *PasswordPolicyError
+		if !errors.As(err, &policyErr) || !reflect.DeepEqual(policyErr.Violations, tc.want) {
+			t.Fatalf("%q: expected %v, got %v", tc.password, tc.want, err)
+		}
+	}
+
+	// The breached list can be switched off, and the minimum never drops
+	// below the floor.
+	lax := PasswordPolicy{MinLength: 4}
+	if err := lax.Check("password"); err != nil {
+		t.Fatalf("expected breached check to be optional, got %v", err)
+	}
+	if err := lax.Check("abc1234"); err == nil {
+		t.Fatal("expected the minimum length floor to apply")
+	}
+}
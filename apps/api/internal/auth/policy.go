@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"bufio"
+	_ "embed"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// MaxPasswordLength caps what is fed to argon2 so a large body cannot be used
+// to burn CPU.
+const MaxPasswordLength = 128
+
+// MinPasswordLengthFloor is the lowest minimum a tenant may configure.
+const MinPasswordLengthFloor = 8
+
+// Password policy violations reported in PasswordPolicyError.
+const (
+	PasswordTooShort       = "too_short"
+	PasswordTooLong        = "too_long"
+	PasswordBreached       = "breached"
+	PasswordMatchesAccount = "matches_account"
+)
+
+//go:embed breached_passwords.txt
+var breachedPasswordList string
+
+var (
+	breachedOnce sync.Once
+	breachedSet  map[string]struct{}
+)
+
+// PasswordPolicy is the rule set a new password has to pass.
+type PasswordPolicy struct {
+	MinLength     int
+	CheckBreached bool
+}
+
+// DefaultPasswordPolicy applies to tenants that have not configured one.
+var DefaultPasswordPolicy = PasswordPolicy{MinLength: 10, CheckBreached: true}
+
+// PasswordPolicyError lists every rule a password breaks.
+type PasswordPolicyError struct {
+	Violations []string
+}
+
+func (e *PasswordPolicyError) Error() string {
+	return "password does not meet the policy: " + strings.Join(e.Violations, ", ")
+}
+
+// Check returns a *PasswordPolicyError when password breaks the policy.
+// accountInputs (email, name) may not be used as the password.
+func (p PasswordPolicy) Check(password string, accountInputs ...string) error {
+	var violations []string
+
+	length := utf8.RuneCountInString(password)
+	minLength := p.MinLength
+	if minLength < MinPasswordLengthFloor {
+		minLength = MinPasswordLengthFloor
+	}
+	if length < minLength {
+		violations = append(violations, PasswordTooShort)
+	}
+	if length > MaxPasswordLength {
+		violations = append(violations, PasswordTooLong)
+	}
+
+	normalized := strings.ToLower(strings.TrimSpace(password))
+	if p.CheckBreached && IsBreachedPassword(normalized) {
+		violations = append(violations, PasswordBreached)
+	}
+	for _, input := range accountInputs {
+		input = strings.ToLower(strings.TrimSpace(input))
+		if input == "" {
+			continue
+		}
+		local, _, _ := strings.Cut(input, "@")
+		if normalized == input || normalized == local {
+			violations = append(violations, PasswordMatchesAccount)
+			break
+		}
+	}
+
+	if len(violations) > 0 {
+		return &PasswordPolicyError{Violations: violations}
+	}
+	return nil
+}
+
+// IsBreachedPassword reports whether password is on the bundled list of
+// commonly breached passwords.
+func IsBreachedPassword(password string) bool {
+	breachedOnce.Do(func() {
+		breachedSet = map[string]struct{}{}
+		scanner := bufio.NewScanner(strings.NewReader(breachedPasswordList))
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			breachedSet[strings.ToLower(line)] = struct{}{}
+		}
+	})
+	_, found := breachedSet[strings.ToLower(strings.TrimSpace(password))]
+	return found
+}
@@ -0,0 +1,26 @@
+package auth
+
+import "time"
+
+// SessionIdleExpiry returns when a session used at now expires: idle after
+// now, but never past the absolute cap. An idle of zero disables the sliding
+// window.
+func SessionIdleExpiry(now time.Time, idle time.Duration, absolute time.Time) time.Time {
+	if idle <= 0 {
+		return absolute
+	}
+	if next := now.Add(idle); next.Before(absolute) {
+		return next
+	}
+	return absolute
+}
+
+// SessionRefreshDue reports whether more than interval has passed since last.
+// A nil last (never recorded) is always due; a non-positive interval makes
+// every call due.
+func SessionRefreshDue(last *time.Time, now time.Time, interval time.Duration) bool {
+	if last == nil || interval <= 0 {
+		return true
+	}
+	return now.Sub(*last) >= interval
+}
@@ -0,0 +1,40 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSessionIdleExpiryIsCappedByAbsoluteExpiry(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	absolute := now.Add(3 * time.Hour)
+
+	if got := SessionIdleExpiry(now, time.Hour, absolute); !got.Equal(now.Add(time.Hour)) {
+		t.Fatalf("expected idle expiry one hour out, got %s", got)
+	}
+	if got := SessionIdleExpiry(now.Add(150*time.Minute), time.Hour, absolute); !got.Equal(absolute) {
+		t.Fatalf("expected absolute cap near the end of the session, got %s", got)
+	}
+	if got := SessionIdleExpiry(now, 0, absolute); !got.Equal(absolute) {
+		t.Fatalf("expected absolute expiry when idle timeout is disabled, got %s", got)
+	}
+}
+
+func TestSessionRefreshDue(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	recent := now.Add(-30 * time.Second)
+	old := now.Add(-2 * time.Minute)
+
+	if !SessionRefreshDue(nil, now, time.Minute) {
+		t.Fatal("expected a session never refreshed to be due")
+	}
+	if SessionRefreshDue(&recent, now, time.Minute) {
+		t.Fatal("expected a recent refresh to be throttled")
+	}
+	if !SessionRefreshDue(&old, now, time.Minute) {
+		t.Fatal("expected an old refresh to be due")
+	}
+	if !SessionRefreshDue(&recent, now, 0) {
+		t.Fatal("expected every request to be due without an interval")
+	}
+}
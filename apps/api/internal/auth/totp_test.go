@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfc6238Secret is the SHA1 test key from RFC 6238 appendix B.
+var rfc6238Secret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestTOTPCodeMatchesRFC6238Vectors(t *testing.T) {
+	// The RFC lists 8-digit codes; the 6-digit code is the last six digits.
+	cases := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "287082"},
+		{unix: 1111111109, want: "081804"},
+		{unix: 1234567890, want: "005924"},
+		{unix: 2000000000, want: "279037"},
+	}
+	for _, tc := range cases {
+		got, err := TOTPCode(rfc6238Secret, TOTPStep(time.Unix(tc.unix, 0)))
+		if err != nil {
+			t.Fatalf("totp code: %v", err)
+		}
+		if got != tc.want {
+			t.Fatalf("unix=%d: expected %s, got %s", tc.unix, tc.want, got)
+		}
+	}
+}
+
+func TestVerifyTOTPAllowsSkewAndRejectsReplay(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+	previous, err := TOTPCode(rfc6238Secret, TOTPStep(now)-1)
+	if err != nil {
+		t.Fatalf("totp code: %v", err)
+	}
+
+	step, ok, err := VerifyTOTP(rfc6238Secret, previous, now, 0)
+	if err != nil || !ok || step != TOTPStep(now)-1 {
+		t.Fatalf("expected previous step to verify, got step=%d ok=%v err=%v", step, ok, err)
+	}
+	if _, ok, _ := VerifyTOTP(rfc6238Secret, previous, now, step); ok {
+		t.Fatal("expected replayed code to be rejected")
+	}
+	if _, ok, _ := VerifyTOTP(rfc6238Secret, "000000", now, 0); ok {
+		t.Fatal("expected wrong code to be rejected")
+	}
+}
+
+func TestRecoveryCodeNormalization(t *testing.T) {
+	code, err := GenerateRecoveryCode()
+	if err != nil {
+		t.Fatalf("generate recovery code: %v", err)
+	}
+	if len(code) != 11 || code[5] != '-' {
+		t.Fatalf("unexpected recovery code format: %q", code)
+	}
+	if NormalizeRecoveryCode(" "+strings.ToUpper(code)+" ") != strings.ReplaceAll(code, "-", "") {
+		t.Fatalf("expected normalization to ignore case, spaces and hyphen")
+	}
+}
@@ -11,34 +11,86 @@ import (
 )
 
 type Config struct {
-	Addr               string
-	DatabaseURL        string
-	SessionCookieName  string
-	SessionTTL         time.Duration
-	SecureCookies      bool
-	CSRFEnforce        bool
-	CORSAllowedOrigins []string
-	Env                string
-	APIMaxBodyBytes    int64
-	ImportMaxFileBytes int64
-	ImportMaxRows      int
-	ReadHeaderTimeout  time.Duration
-	ReadTimeout        time.Duration
-	WriteTimeout       time.Duration
-	IdleTimeout        time.Duration
-	RateLimitMaxIPs    int
+	Addr              string
+	DatabaseURL       string
+	SessionCookieName string
+	// SessionTTL is the absolute lifetime of a session, however active it is.
+	SessionTTL time.Duration
+	// SessionIdleTimeout signs out sessions that see no requests for this long.
+	// Zero disables the idle timeout.
+	SessionIdleTimeout time.Duration
+	// SessionRotateInterval is how often an active session gets a new token.
+	// Zero disables rotation.
+	SessionRotateInterval time.Duration
+	// SessionTouchInterval throttles last-seen and idle expiry writes.
+	SessionTouchInterval time.Duration
+	SecureCookies        bool
+	CSRFEnforce          bool
+	CORSAllowedOrigins   []string
+	Env                  string
+	APIMaxBodyBytes      int64
+	ImportMaxFileBytes   int64
+	ImportMaxRows        int
+	ReadHeaderTimeout    time.Duration
+	ReadTimeout          time.Duration
+	WriteTimeout         time.Duration
+	IdleTimeout          time.Duration
+	RateLimitMaxIPs      int
+
+	LoginLockoutThreshold   int
+	LoginLockoutBaseBackoff time.Duration
+	LoginLockoutMaxBackoff  time.Duration
+	// PasswordResetTokenTTL is how long an admin-issued reset token works.
+	PasswordResetTokenTTL time.Duration
+
+	// OIDCRedirectURL is the SSO callback registered with identity providers.
+	OIDCRedirectURL string
+	// WebBaseURL is where the SSO callback sends the browser afterwards.
+	WebBaseURL string
+	// SSOAllowPrivateIssuers accepts plain http issuers on loopback and
+	// private addresses, for identity providers run locally. Never in
+	// production.
+	SSOAllowPrivateIssuers bool
+
+	// SMTPHost is the relay outbound email goes through. When it is empty
+	// messages are queued but the outbox worker does not run.
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	// SMTPTLS is the raw SMTP_TLS mode, parsed where the sender is built.
+	SMTPTLS string
+	// MailFromAddress is the sender for tenants that have not set their own.
+	MailFromAddress    string
+	MailWorkerInterval time.Duration
+	// MailMaxAttempts is how many deliveries a message gets before it is
+	// marked failed.
+	MailMaxAttempts int
+
+	// EstimateExpiryInterval throttles how often estimate reads expire a
+	// tenant's lapsed estimates. Writes always expire first. Zero expires on
+	// every read.
+	EstimateExpiryInterval time.Duration
+
+	// AcceptanceSigningKey signs estimate acceptance links and customer
+	// signatures. Changing it invalidates open links, and signatures made
+	// under the old key no longer verify.
+	AcceptanceSigningKey string
 }
 
 func Load() (Config, error) {
 	_ = godotenv.Load()
 
 	cfg := Config{
-		Addr:              getEnv("API_ADDR", ":8080"),
-		DatabaseURL:       os.Getenv("DATABASE_URL"),
-		SessionCookieName: getEnv("SESSION_COOKIE_NAME", "mo_sess"),
-		SessionTTL:        time.Duration(getEnvInt("SESSION_TTL_HOURS", 12)) * time.Hour,
-		SecureCookies:     getEnvBool("COOKIE_SECURE", false),
-		CSRFEnforce:       getEnvBool("CSRF_ENFORCE", true),
+		Addr:                  getEnv("API_ADDR", ":8080"),
+		DatabaseURL:           os.Getenv("DATABASE_URL"),
+		SessionCookieName:     getEnv("SESSION_COOKIE_NAME", "mo_sess"),
+		SessionTTL:            time.Duration(getEnvInt("SESSION_TTL_HOURS", 12)) * time.Hour,
+		SessionIdleTimeout:    time.Duration(getEnvInt("SESSION_IDLE_TIMEOUT_MINUTES", 120)) * time.Minute,
+		SessionRotateInterval: time.Duration(getEnvInt("SESSION_ROTATE_MINUTES", 60)) * time.Minute,
+		SessionTouchInterval:  time.Duration(getEnvInt("SESSION_TOUCH_INTERVAL_SEC", 60)) * time.Second,
+		SecureCookies:         getEnvBool("COOKIE_SECURE", false),
+		CSRFEnforce:           getEnvBool("CSRF_ENFORCE", true),
 		CORSAllowedOrigins: getEnvCSV("CORS_ALLOWED_ORIGINS", []string{
 			"http://localhost:3000",
 			"http://127.0.0.1:3000",
@@ -52,6 +104,29 @@ func Load() (Config, error) {
 		WriteTimeout:       time.Duration(getEnvInt("API_WRITE_TIMEOUT_SEC", 30)) * time.Second,
 		IdleTimeout:        time.Duration(getEnvInt("API_IDLE_TIMEOUT_SEC", 60)) * time.Second,
 		RateLimitMaxIPs:    getEnvInt("RATE_LIMIT_MAX_IPS", 10000),
+
+		LoginLockoutThreshold:   getEnvInt("LOGIN_LOCKOUT_THRESHOLD", 5),
+		LoginLockoutBaseBackoff: time.Duration(getEnvInt("LOGIN_LOCKOUT_BASE_MINUTES", 5)) * time.Minute,
+		LoginLockoutMaxBackoff:  time.Duration(getEnvInt("LOGIN_LOCKOUT_MAX_MINUTES", 24*60)) * time.Minute,
+		PasswordResetTokenTTL:   time.Duration(getEnvInt("PASSWORD_RESET_TOKEN_TTL_MINUTES", 60)) * time.Minute,
+
+		OIDCRedirectURL: getEnv("OIDC_REDIRECT_URL", "http://localhost:8080/api/auth/oidc/callback"),
+		WebBaseURL:      strings.TrimSuffix(getEnv("WEB_BASE_URL", "http://localhost:3000"), "/"),
+
+		SSOAllowPrivateIssuers: getEnvBool("SSO_ALLOW_PRIVATE_ISSUERS", false),
+
+		SMTPHost:           strings.TrimSpace(os.Getenv("SMTP_HOST")),
+		SMTPPort:           getEnvInt("SMTP_PORT", 587),
+		SMTPUsername:       os.Getenv("SMTP_USERNAME"),
+		SMTPPassword:       os.Getenv("SMTP_PASSWORD"),
+		SMTPTLS:            getEnv("SMTP_TLS", "starttls"),
+		MailFromAddress:    strings.TrimSpace(os.Getenv("MAIL_FROM_ADDRESS")),
+		MailWorkerInterval: time.Duration(getEnvInt("MAIL_WORKER_INTERVAL_SEC", 15)) * time.Second,
+		MailMaxAttempts:    getEnvInt("MAIL_MAX_ATTEMPTS", 6),
+
+		EstimateExpiryInterval: time.Duration(getEnvInt("ESTIMATE_EXPIRY_INTERVAL_SEC", 60)) * time.Second,
+
+		AcceptanceSigningKey: os.Getenv("ACCEPTANCE_SIGNING_KEY"),
 	}
 
 	if cfg.DatabaseURL == "" {
@@ -60,6 +135,25 @@ func Load() (Config, error) {
 
 	if cfg.Env == "prod" || cfg.Env == "production" {
 		cfg.SecureCookies = true
+		if len(cfg.AcceptanceSigningKey) < 32 {
+			return Config{}, fmt.Errorf("ACCEPTANCE_SIGNING_KEY of at least 32 characters is required in production")
+		}
+		if cfg.SSOAllowPrivateIssuers {
+			return Config{}, fmt.Errorf("SSO_ALLOW_PRIVATE_ISSUERS must not be set in production")
+		}
+	}
+	if cfg.AcceptanceSigningKey == "" {
+		// The fixed key is public, so it is only used when the environment
+		// is explicitly a development or test one. This reads APP_ENV rather
+		// than cfg.Env on purpose: an unset APP_ENV defaults cfg.Env to dev,
+		// and a deployment that forgot to set it must not sign with a known
+		// key. Staging and anything misspelled must set a real key too.
+		switch os.Getenv("APP_ENV") {
+		case "dev", "test":
+			cfg.AcceptanceSigningKey = "moveops-dev-acceptance-signing-key"
+		default:
+			return Config{}, fmt.Errorf("ACCEPTANCE_SIGNING_KEY is required unless APP_ENV is dev or test")
+		}
 	}
 
 	return cfg, nil
@@ -10,6 +10,21 @@ import (
 	"github.com/google/uuid"
 )
 
+type ApiToken struct {
+	ID          uuid.UUID  `json:"id"`
+	TenantID    uuid.UUID  `json:"tenant_id"`
+	UserID      uuid.UUID  `json:"user_id"`
+	Name        string     `json:"name"`
+	TokenHash   string     `json:"token_hash"`
+	TokenPrefix string     `json:"token_prefix"`
+	Scopes      []string   `json:"scopes"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+	LastUsedAt  *time.Time `json:"last_used_at"`
+	LastUsedIp  *string    `json:"last_used_ip"`
+	CreatedAt   time.Time  `json:"created_at"`
+	RevokedAt   *time.Time `json:"revoked_at"`
+}
+
 type AuditLog struct {
 	ID         int64      `json:"id"`
 	TenantID   uuid.UUID  `json:"tenant_id"`
@@ -35,6 +50,38 @@ type Customer struct {
 	UpdatedAt time.Time  `json:"updated_at"`
 }
 
+type EmailOutbox struct {
+	ID              uuid.UUID  `json:"id"`
+	TenantID        uuid.UUID  `json:"tenant_id"`
+	Kind            string     `json:"kind"`
+	EstimateID      *uuid.UUID `json:"estimate_id"`
+	JobID           *uuid.UUID `json:"job_id"`
+	StorageRecordID *uuid.UUID `json:"storage_record_id"`
+	ToAddress       string     `json:"to_address"`
+	FromAddress     string     `json:"from_address"`
+	FromName        *string    `json:"from_name"`
+	ReplyTo         *string    `json:"reply_to"`
+	Subject         string     `json:"subject"`
+	Body            string     `json:"body"`
+	Status          string     `json:"status"`
+	Attempts        int32      `json:"attempts"`
+	NextAttemptAt   time.Time  `json:"next_attempt_at"`
+	LastError       *string    `json:"last_error"`
+	SentAt          *time.Time `json:"sent_at"`
+	CreatedBy       *uuid.UUID `json:"created_by"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+type EmailTemplate struct {
+	TenantID  uuid.UUID  `json:"tenant_id"`
+	Kind      string     `json:"kind"`
+	Subject   string     `json:"subject"`
+	Body      string     `json:"body"`
+	UpdatedBy *uuid.UUID `json:"updated_by"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
 type Estimate struct {
 	ID                      uuid.UUID  `json:"id"`
 	TenantID                uuid.UUID  `json:"tenant_id"`
@@ -60,7 +107,16 @@ type Estimate struct {
 	LocationType            *string    `json:"location_type"`
 	EstimatedTotalCents     *int64     `json:"estimated_total_cents"`
 	DepositCents            *int64     `json:"deposit_cents"`
+	TotalCubicFeet          int64      `json:"total_cubic_feet"`
+	TotalWeightLbs          int64      `json:"total_weight_lbs"`
+	PriceSource             *string    `json:"price_source"`
+	PriceBreakdown          []byte     `json:"price_breakdown"`
+	PricedAt                *time.Time `json:"priced_at"`
 	Notes                   *string    `json:"notes"`
+	LostReason              *string    `json:"lost_reason"`
+	SentAt                  *time.Time `json:"sent_at"`
+	ExpiresAt               *time.Time `json:"expires_at"`
+	StatusChangedAt         time.Time  `json:"status_changed_at"`
 	IdempotencyKey          *string    `json:"idempotency_key"`
 	IdempotencyPayloadHash  *string    `json:"idempotency_payload_hash"`
 	CreatedBy               *uuid.UUID `json:"created_by"`
@@ -69,6 +125,75 @@ type Estimate struct {
 	UpdatedAt               time.Time  `json:"updated_at"`
 }
 
+type EstimateAcceptanceLink struct {
+	ID            uuid.UUID  `json:"id"`
+	TenantID      uuid.UUID  `json:"tenant_id"`
+	EstimateID    uuid.UUID  `json:"estimate_id"`
+	AutoConvert   bool       `json:"auto_convert"`
+	ExpiresAt     time.Time  `json:"expires_at"`
+	RevokedAt     *time.Time `json:"revoked_at"`
+	AcceptedAt    *time.Time `json:"accepted_at"`
+	FirstViewedAt *time.Time `json:"first_viewed_at"`
+	CreatedBy     *uuid.UUID `json:"created_by"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+type EstimateDocumentTemplate struct {
+	TenantID        uuid.UUID  `json:"tenant_id"`
+	CompanyName     *string    `json:"company_name"`
+	AccentColor     string     `json:"accent_color"`
+	Logo            []byte     `json:"logo"`
+	LogoContentType *string    `json:"logo_content_type"`
+	IntroText       *string    `json:"intro_text"`
+	TermsText       *string    `json:"terms_text"`
+	FooterText      *string    `json:"footer_text"`
+	ShowLineItems   bool       `json:"show_line_items"`
+	UpdatedBy       *uuid.UUID `json:"updated_by"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+type EstimateLineItem struct {
+	ID              uuid.UUID  `json:"id"`
+	TenantID        uuid.UUID  `json:"tenant_id"`
+	EstimateID      uuid.UUID  `json:"estimate_id"`
+	InventoryItemID *uuid.UUID `json:"inventory_item_id"`
+	Room            string     `json:"room"`
+	ItemName        string     `json:"item_name"`
+	Quantity        int32      `json:"quantity"`
+	CubicFeet       int32      `json:"cubic_feet"`
+	WeightLbs       int32      `json:"weight_lbs"`
+	CreatedBy       *uuid.UUID `json:"created_by"`
+	UpdatedBy       *uuid.UUID `json:"updated_by"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+type EstimateRevision struct {
+	ID             uuid.UUID  `json:"id"`
+	TenantID       uuid.UUID  `json:"tenant_id"`
+	EstimateID     uuid.UUID  `json:"estimate_id"`
+	RevisionNumber int32      `json:"revision_number"`
+	Change         string     `json:"change"`
+	RestoredFrom   *int32     `json:"restored_from"`
+	Snapshot       []byte     `json:"snapshot"`
+	CreatedBy      *uuid.UUID `json:"created_by"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type EstimateSignature struct {
+	ID             uuid.UUID  `json:"id"`
+	TenantID       uuid.UUID  `json:"tenant_id"`
+	EstimateID     uuid.UUID  `json:"estimate_id"`
+	LinkID         *uuid.UUID `json:"link_id"`
+	RevisionNumber int32      `json:"revision_number"`
+	DocumentSha256 string     `json:"document_sha256"`
+	SignerName     string     `json:"signer_name"`
+	SignerIp       string     `json:"signer_ip"`
+	UserAgent      string     `json:"user_agent"`
+	SignedAt       time.Time  `json:"signed_at"`
+	Signature      string     `json:"signature"`
+}
+
 type ImportIdempotency struct {
 	TenantID       uuid.UUID `json:"tenant_id"`
 	EntityType     string    `json:"entity_type"`
@@ -109,6 +234,20 @@ type ImportRun struct {
 	CompletedAt     *time.Time `json:"completed_at"`
 }
 
+type InventoryItem struct {
+	ID          uuid.UUID  `json:"id"`
+	TenantID    uuid.UUID  `json:"tenant_id"`
+	Name        string     `json:"name"`
+	DefaultRoom *string    `json:"default_room"`
+	CubicFeet   int32      `json:"cubic_feet"`
+	WeightLbs   int32      `json:"weight_lbs"`
+	Active      bool       `json:"active"`
+	CreatedBy   *uuid.UUID `json:"created_by"`
+	UpdatedBy   *uuid.UUID `json:"updated_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
 type Job struct {
 	ID                    uuid.UUID  `json:"id"`
 	TenantID              uuid.UUID  `json:"tenant_id"`
@@ -119,12 +258,65 @@ type Job struct {
 	ScheduledDate         *time.Time `json:"scheduled_date"`
 	PickupTime            *string    `json:"pickup_time"`
 	ConvertIdempotencyKey *string    `json:"convert_idempotency_key"`
+	BalanceCents          int64      `json:"balance_cents"`
+	LastPaymentAt         *time.Time `json:"last_payment_at"`
 	CreatedBy             *uuid.UUID `json:"created_by"`
 	UpdatedBy             *uuid.UUID `json:"updated_by"`
 	CreatedAt             time.Time  `json:"created_at"`
 	UpdatedAt             time.Time  `json:"updated_at"`
 }
 
+type JobStatusHistory struct {
+	ID         uuid.UUID  `json:"id"`
+	TenantID   uuid.UUID  `json:"tenant_id"`
+	JobID      uuid.UUID  `json:"job_id"`
+	FromStatus *string    `json:"from_status"`
+	ToStatus   string     `json:"to_status"`
+	Reason     *string    `json:"reason"`
+	ChangedBy  *uuid.UUID `json:"changed_by"`
+	ChangedAt  time.Time  `json:"changed_at"`
+}
+
+type LedgerEntry struct {
+	ID                     uuid.UUID  `json:"id"`
+	TenantID               uuid.UUID  `json:"tenant_id"`
+	JobID                  uuid.UUID  `json:"job_id"`
+	StorageRecordID        *uuid.UUID `json:"storage_record_id"`
+	Account                string     `json:"account"`
+	Kind                   string     `json:"kind"`
+	AmountCents            int64      `json:"amount_cents"`
+	BalanceEffectCents     int64      `json:"balance_effect_cents"`
+	Method                 *string    `json:"method"`
+	Reference              *string    `json:"reference"`
+	Memo                   *string    `json:"memo"`
+	OccurredAt             time.Time  `json:"occurred_at"`
+	IdempotencyKey         *string    `json:"idempotency_key"`
+	IdempotencyPayloadHash *string    `json:"idempotency_payload_hash"`
+	CreatedBy              *uuid.UUID `json:"created_by"`
+	CreatedAt              time.Time  `json:"created_at"`
+}
+
+type OidcLoginState struct {
+	StateHash    string     `json:"state_hash"`
+	TenantID     uuid.UUID  `json:"tenant_id"`
+	CodeVerifier string     `json:"code_verifier"`
+	Nonce        string     `json:"nonce"`
+	CreatedAt    time.Time  `json:"created_at"`
+	ExpiresAt    time.Time  `json:"expires_at"`
+	ConsumedAt   *time.Time `json:"consumed_at"`
+}
+
+type PasswordResetToken struct {
+	ID        uuid.UUID  `json:"id"`
+	TenantID  uuid.UUID  `json:"tenant_id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	TokenHash string     `json:"token_hash"`
+	CreatedBy *uuid.UUID `json:"created_by"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
 type Permission struct {
 	ID          uuid.UUID `json:"id"`
 	Name        string    `json:"name"`
@@ -146,15 +338,28 @@ type RolePermission struct {
 }
 
 type Session struct {
-	ID         uuid.UUID  `json:"id"`
-	TenantID   uuid.UUID  `json:"tenant_id"`
-	UserID     uuid.UUID  `json:"user_id"`
-	TokenHash  string     `json:"token_hash"`
-	CsrfToken  string     `json:"csrf_token"`
-	CreatedAt  time.Time  `json:"created_at"`
-	ExpiresAt  time.Time  `json:"expires_at"`
-	LastSeenAt *time.Time `json:"last_seen_at"`
-	RevokedAt  *time.Time `json:"revoked_at"`
+	ID                uuid.UUID   `json:"id"`
+	TenantID          uuid.UUID   `json:"tenant_id"`
+	UserID            uuid.UUID   `json:"user_id"`
+	TokenHash         string      `json:"token_hash"`
+	CsrfToken         string      `json:"csrf_token"`
+	CreatedAt         time.Time   `json:"created_at"`
+	ExpiresAt         time.Time   `json:"expires_at"`
+	LastSeenAt        *time.Time  `json:"last_seen_at"`
+	RevokedAt         *time.Time  `json:"revoked_at"`
+	MfaPending        bool        `json:"mfa_pending"`
+	UserAgent         string      `json:"user_agent"`
+	IpAddress         string      `json:"ip_address"`
+	AbsoluteExpiresAt time.Time   `json:"absolute_expires_at"`
+	TokenRotatedAt    time.Time   `json:"token_rotated_at"`
+	PreviousTokenHash *string     `json:"previous_token_hash"`
+	LinkedUserIds     []uuid.UUID `json:"linked_user_ids"`
+}
+
+type SsoGroupRoleMapping struct {
+	TenantID  uuid.UUID `json:"tenant_id"`
+	GroupName string    `json:"group_name"`
+	RoleID    uuid.UUID `json:"role_id"`
 }
 
 type StorageRecord struct {
@@ -182,6 +387,40 @@ type StorageRecord struct {
 	UpdatedAt           time.Time  `json:"updated_at"`
 }
 
+type Tariff struct {
+	TenantID                   uuid.UUID  `json:"tenant_id"`
+	MinimumHours               int32      `json:"minimum_hours"`
+	MinimumChargeCents         int64      `json:"minimum_charge_cents"`
+	LongDistanceThresholdMiles int32      `json:"long_distance_threshold_miles"`
+	PerMileCents               int64      `json:"per_mile_cents"`
+	PerCwtCents                int64      `json:"per_cwt_cents"`
+	FuelSurchargeBps           int32      `json:"fuel_surcharge_bps"`
+	UpdatedBy                  *uuid.UUID `json:"updated_by"`
+	UpdatedAt                  time.Time  `json:"updated_at"`
+}
+
+type TariffAccessorial struct {
+	TenantID  uuid.UUID `json:"tenant_id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	Unit      string    `json:"unit"`
+	RateCents int64     `json:"rate_cents"`
+}
+
+type TariffCrewRate struct {
+	TenantID        uuid.UUID `json:"tenant_id"`
+	CrewSize        int32     `json:"crew_size"`
+	HourlyRateCents int64     `json:"hourly_rate_cents"`
+}
+
+type TariffValuationOption struct {
+	TenantID          uuid.UUID `json:"tenant_id"`
+	Code              string    `json:"code"`
+	Name              string    `json:"name"`
+	CentsPerThousand  int64     `json:"cents_per_thousand"`
+	MinimumCentsPerLb int64     `json:"minimum_cents_per_lb"`
+}
+
 type Tenant struct {
 	ID        uuid.UUID `json:"id"`
 	Slug      string    `json:"slug"`
@@ -196,6 +435,40 @@ type TenantCounter struct {
 	UpdatedAt   time.Time `json:"updated_at"`
 }
 
+type TenantEmailSetting struct {
+	TenantID    uuid.UUID  `json:"tenant_id"`
+	Enabled     bool       `json:"enabled"`
+	FromName    *string    `json:"from_name"`
+	FromAddress *string    `json:"from_address"`
+	ReplyTo     *string    `json:"reply_to"`
+	UpdatedBy   *uuid.UUID `json:"updated_by"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+type TenantSetting struct {
+	TenantID                    uuid.UUID  `json:"tenant_id"`
+	RequireMfaForSensitiveRoles bool       `json:"require_mfa_for_sensitive_roles"`
+	PasswordMinLength           int32      `json:"password_min_length"`
+	PasswordCheckBreached       bool       `json:"password_check_breached"`
+	EstimateValidityDays        int32      `json:"estimate_validity_days"`
+	UpdatedAt                   time.Time  `json:"updated_at"`
+	UpdatedBy                   *uuid.UUID `json:"updated_by"`
+}
+
+type TenantSsoProvider struct {
+	TenantID        uuid.UUID  `json:"tenant_id"`
+	Enabled         bool       `json:"enabled"`
+	Issuer          string     `json:"issuer"`
+	ClientID        string     `json:"client_id"`
+	ClientSecret    string     `json:"client_secret"`
+	JitProvisioning bool       `json:"jit_provisioning"`
+	DefaultRoleID   *uuid.UUID `json:"default_role_id"`
+	GroupsClaim     string     `json:"groups_claim"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	UpdatedBy       *uuid.UUID `json:"updated_by"`
+}
+
 type User struct {
 	ID           uuid.UUID `json:"id"`
 	TenantID     uuid.UUID `json:"tenant_id"`
@@ -207,6 +480,44 @@ type User struct {
 	UpdatedAt    time.Time `json:"updated_at"`
 }
 
+type UserIdentity struct {
+	ID          uuid.UUID `json:"id"`
+	TenantID    uuid.UUID `json:"tenant_id"`
+	UserID      uuid.UUID `json:"user_id"`
+	Issuer      string    `json:"issuer"`
+	Subject     string    `json:"subject"`
+	CreatedAt   time.Time `json:"created_at"`
+	LastLoginAt time.Time `json:"last_login_at"`
+}
+
+type UserLoginFailure struct {
+	UserID       uuid.UUID  `json:"user_id"`
+	TenantID     uuid.UUID  `json:"tenant_id"`
+	FailedCount  int32      `json:"failed_count"`
+	LockCount    int32      `json:"lock_count"`
+	LastFailedAt *time.Time `json:"last_failed_at"`
+	LockedUntil  *time.Time `json:"locked_until"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+type UserMfa struct {
+	UserID       uuid.UUID  `json:"user_id"`
+	TenantID     uuid.UUID  `json:"tenant_id"`
+	TotpSecret   string     `json:"totp_secret"`
+	ConfirmedAt  *time.Time `json:"confirmed_at"`
+	LastUsedStep int64      `json:"last_used_step"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+type UserMfaRecoveryCode struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	CodeHash  string     `json:"code_hash"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
 type UserRole struct {
 	UserID    uuid.UUID `json:"user_id"`
 	RoleID    uuid.UUID `json:"role_id"`
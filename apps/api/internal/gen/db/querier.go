@@ -11,56 +11,200 @@ import (
 )
 
 type Querier interface {
+	AddRolePermissionsByName(ctx context.Context, arg AddRolePermissionsByNameParams) error
+	AdvanceUserMFAStep(ctx context.Context, arg AdvanceUserMFAStepParams) (int64, error)
+	AssignUserRole(ctx context.Context, arg AssignUserRoleParams) (int64, error)
+	ClaimDueEmails(ctx context.Context, arg ClaimDueEmailsParams) ([]EmailOutbox, error)
+	ClearLoginFailures(ctx context.Context, arg ClearLoginFailuresParams) (int64, error)
+	ClearRolePermissions(ctx context.Context, roleID uuid.UUID) error
+	ClearUserRoles(ctx context.Context, arg ClearUserRolesParams) error
 	CompleteImportRun(ctx context.Context, arg CompleteImportRunParams) (ImportRun, error)
+	ConfirmUserMFA(ctx context.Context, arg ConfirmUserMFAParams) (int64, error)
+	ConsumeOIDCLoginState(ctx context.Context, stateHash string) (ConsumeOIDCLoginStateRow, error)
+	CountActiveUsersWithPermission(ctx context.Context, arg CountActiveUsersWithPermissionParams) (int64, error)
+	CountPermissionsByNames(ctx context.Context, names []string) (int64, error)
+	CountTenantRolesByIDs(ctx context.Context, arg CountTenantRolesByIDsParams) (int64, error)
+	CreateAPIToken(ctx context.Context, arg CreateAPITokenParams) (CreateAPITokenRow, error)
 	CreateCustomer(ctx context.Context, arg CreateCustomerParams) (Customer, error)
 	CreateCustomerForEstimate(ctx context.Context, arg CreateCustomerForEstimateParams) (Customer, error)
 	CreateEstimate(ctx context.Context, arg CreateEstimateParams) (Estimate, error)
+	CreateEstimateAcceptanceLink(ctx context.Context, arg CreateEstimateAcceptanceLinkParams) (EstimateAcceptanceLink, error)
+	CreateEstimateLineItem(ctx context.Context, arg CreateEstimateLineItemParams) (EstimateLineItem, error)
 	CreateImportRun(ctx context.Context, arg CreateImportRunParams) (ImportRun, error)
+	CreateInventoryItem(ctx context.Context, arg CreateInventoryItemParams) (InventoryItem, error)
 	CreateJob(ctx context.Context, arg CreateJobParams) (Job, error)
+	CreateLedgerEntry(ctx context.Context, arg CreateLedgerEntryParams) (LedgerEntry, error)
+	CreateOIDCLoginState(ctx context.Context, arg CreateOIDCLoginStateParams) error
+	CreatePasswordResetToken(ctx context.Context, arg CreatePasswordResetTokenParams) (CreatePasswordResetTokenRow, error)
+	CreateRole(ctx context.Context, arg CreateRoleParams) (Role, error)
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
 	CreateStorageRecord(ctx context.Context, arg CreateStorageRecordParams) (StorageRecord, error)
+	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	DeleteCustomer(ctx context.Context, arg DeleteCustomerParams) (int64, error)
+	DeleteEmailTemplates(ctx context.Context, tenantID uuid.UUID) error
+	DeleteEstimateLineItem(ctx context.Context, arg DeleteEstimateLineItemParams) (int64, error)
+	DeleteEstimateLineItems(ctx context.Context, arg DeleteEstimateLineItemsParams) error
+	DeleteExpiredOIDCLoginStates(ctx context.Context) error
+	DeleteInventoryItem(ctx context.Context, arg DeleteInventoryItemParams) (int64, error)
+	DeleteRecoveryCodes(ctx context.Context, userID uuid.UUID) error
+	DeleteRole(ctx context.Context, arg DeleteRoleParams) (int64, error)
+	DeleteSSOGroupRoleMappings(ctx context.Context, tenantID uuid.UUID) error
+	DeleteTariffAccessorials(ctx context.Context, tenantID uuid.UUID) error
+	DeleteTariffCrewRates(ctx context.Context, tenantID uuid.UUID) error
+	DeleteTariffValuationOptions(ctx context.Context, tenantID uuid.UUID) error
+	DeleteUnusedPasswordResetTokens(ctx context.Context, arg DeleteUnusedPasswordResetTokensParams) error
+	EnqueueEmail(ctx context.Context, arg EnqueueEmailParams) (EmailOutbox, error)
+	ExpireSentEstimates(ctx context.Context, tenantID uuid.UUID) ([]uuid.UUID, error)
 	ExportCustomersRows(ctx context.Context, tenantID uuid.UUID) ([]ExportCustomersRowsRow, error)
 	ExportEstimatesRows(ctx context.Context, tenantID uuid.UUID) ([]ExportEstimatesRowsRow, error)
 	ExportJobsRows(ctx context.Context, tenantID uuid.UUID) ([]ExportJobsRowsRow, error)
 	ExportStorageRows(ctx context.Context, tenantID uuid.UUID) ([]ExportStorageRowsRow, error)
 	FindCustomerByEmail(ctx context.Context, arg FindCustomerByEmailParams) (Customer, error)
 	FindCustomerByPhone(ctx context.Context, arg FindCustomerByPhoneParams) (Customer, error)
+	FindCustomersByPhoneDigits(ctx context.Context, arg FindCustomersByPhoneDigitsParams) ([]Customer, error)
+	GetAPITokenPrincipalByHash(ctx context.Context, tokenHash string) (GetAPITokenPrincipalByHashRow, error)
 	GetCustomerByID(ctx context.Context, arg GetCustomerByIDParams) (Customer, error)
+	GetCustomerByIDForUpdate(ctx context.Context, arg GetCustomerByIDForUpdateParams) (Customer, error)
+	GetEmailTemplate(ctx context.Context, arg GetEmailTemplateParams) (GetEmailTemplateRow, error)
+	GetEnabledSSOProviderBySlug(ctx context.Context, slug string) (GetEnabledSSOProviderBySlugRow, error)
+	GetEstimateAcceptanceLink(ctx context.Context, id uuid.UUID) (EstimateAcceptanceLink, error)
 	GetEstimateByID(ctx context.Context, arg GetEstimateByIDParams) (Estimate, error)
 	GetEstimateByIdempotencyKey(ctx context.Context, arg GetEstimateByIdempotencyKeyParams) (Estimate, error)
 	GetEstimateByNumber(ctx context.Context, arg GetEstimateByNumberParams) (Estimate, error)
 	GetEstimateDetailByID(ctx context.Context, arg GetEstimateDetailByIDParams) (GetEstimateDetailByIDRow, error)
+	GetEstimateDocumentTemplate(ctx context.Context, tenantID uuid.UUID) (GetEstimateDocumentTemplateRow, error)
+	GetEstimateLineItem(ctx context.Context, arg GetEstimateLineItemParams) (EstimateLineItem, error)
+	GetEstimateRevision(ctx context.Context, arg GetEstimateRevisionParams) (GetEstimateRevisionRow, error)
+	GetEstimateSignatureByLink(ctx context.Context, linkID *uuid.UUID) (EstimateSignature, error)
 	GetImportIdempotency(ctx context.Context, arg GetImportIdempotencyParams) (ImportIdempotency, error)
 	GetImportRunByID(ctx context.Context, arg GetImportRunByIDParams) (ImportRun, error)
+	GetInventoryItem(ctx context.Context, arg GetInventoryItemParams) (InventoryItem, error)
 	GetJobByConvertIdempotencyKey(ctx context.Context, arg GetJobByConvertIdempotencyKeyParams) (Job, error)
 	GetJobByEstimateID(ctx context.Context, arg GetJobByEstimateIDParams) (Job, error)
 	GetJobByID(ctx context.Context, arg GetJobByIDParams) (Job, error)
+	GetJobByIDForUpdate(ctx context.Context, arg GetJobByIDForUpdateParams) (Job, error)
 	GetJobByJobNumber(ctx context.Context, arg GetJobByJobNumberParams) (Job, error)
 	GetJobDetailByID(ctx context.Context, arg GetJobDetailByIDParams) (GetJobDetailByIDRow, error)
+	GetJobEmailContext(ctx context.Context, arg GetJobEmailContextParams) (GetJobEmailContextRow, error)
+	GetLatestEstimateRevisionDigest(ctx context.Context, arg GetLatestEstimateRevisionDigestParams) (GetLatestEstimateRevisionDigestRow, error)
+	GetLedgerEntryByIdempotencyKey(ctx context.Context, arg GetLedgerEntryByIdempotencyKeyParams) (LedgerEntry, error)
+	GetLoginLockState(ctx context.Context, userIds []uuid.UUID) ([]GetLoginLockStateRow, error)
+	GetLoginMFAState(ctx context.Context, arg GetLoginMFAStateParams) (GetLoginMFAStateRow, error)
+	GetPasswordResetToken(ctx context.Context, tokenHash string) (GetPasswordResetTokenRow, error)
+	GetPreviousEstimateRevisionNumber(ctx context.Context, arg GetPreviousEstimateRevisionNumberParams) (int32, error)
+	GetSSOLoginUser(ctx context.Context, arg GetSSOLoginUserParams) (GetSSOLoginUserRow, error)
+	GetSSOProvider(ctx context.Context, tenantID uuid.UUID) (GetSSOProviderRow, error)
+	GetSessionLinkedUserIDs(ctx context.Context, arg GetSessionLinkedUserIDsParams) ([]uuid.UUID, error)
 	GetSessionPrincipalByTokenHash(ctx context.Context, tokenHash string) (GetSessionPrincipalByTokenHashRow, error)
 	GetStorageRecordByID(ctx context.Context, arg GetStorageRecordByIDParams) (StorageRecord, error)
 	GetStorageRecordByJobID(ctx context.Context, arg GetStorageRecordByJobIDParams) (StorageRecord, error)
 	GetStorageRecordDetailByID(ctx context.Context, arg GetStorageRecordDetailByIDParams) (GetStorageRecordDetailByIDRow, error)
+	GetStorageRecordEmailContext(ctx context.Context, arg GetStorageRecordEmailContextParams) (GetStorageRecordEmailContextRow, error)
+	GetTariff(ctx context.Context, tenantID uuid.UUID) (Tariff, error)
+	GetTenantEmailSettings(ctx context.Context, tenantID uuid.UUID) (GetTenantEmailSettingsRow, error)
+	GetTenantRoleByID(ctx context.Context, arg GetTenantRoleByIDParams) (GetTenantRoleByIDRow, error)
+	GetTenantSettings(ctx context.Context, tenantID uuid.UUID) (GetTenantSettingsRow, error)
+	GetTenantUserByID(ctx context.Context, arg GetTenantUserByIDParams) (GetTenantUserByIDRow, error)
+	GetUserMFA(ctx context.Context, arg GetUserMFAParams) (UserMfa, error)
+	GetUserPasswordHash(ctx context.Context, arg GetUserPasswordHashParams) (GetUserPasswordHashRow, error)
 	IncrementTenantCounter(ctx context.Context, arg IncrementTenantCounterParams) (int64, error)
 	InsertAuditLog(ctx context.Context, arg InsertAuditLogParams) error
+	InsertEmailTemplates(ctx context.Context, arg InsertEmailTemplatesParams) error
+	InsertEstimateRevision(ctx context.Context, arg InsertEstimateRevisionParams) (int32, error)
+	InsertEstimateSignature(ctx context.Context, arg InsertEstimateSignatureParams) error
+	InsertJobStatusHistory(ctx context.Context, arg InsertJobStatusHistoryParams) error
+	InsertRecoveryCodes(ctx context.Context, arg InsertRecoveryCodesParams) error
+	InsertSSOGroupRoleMappings(ctx context.Context, arg InsertSSOGroupRoleMappingsParams) error
+	InsertTariffAccessorials(ctx context.Context, arg InsertTariffAccessorialsParams) error
+	InsertTariffCrewRates(ctx context.Context, arg InsertTariffCrewRatesParams) error
+	InsertTariffValuationOptions(ctx context.Context, arg InsertTariffValuationOptionsParams) error
+	ListAPITokens(ctx context.Context, arg ListAPITokensParams) ([]ListAPITokensRow, error)
 	ListCalendarJobs(ctx context.Context, arg ListCalendarJobsParams) ([]ListCalendarJobsRow, error)
+	ListCustomers(ctx context.Context, arg ListCustomersParams) ([]Customer, error)
+	ListEmailOutbox(ctx context.Context, arg ListEmailOutboxParams) ([]EmailOutbox, error)
+	ListEmailTemplates(ctx context.Context, tenantID uuid.UUID) ([]ListEmailTemplatesRow, error)
+	ListEstimateLineItems(ctx context.Context, arg ListEstimateLineItemsParams) ([]EstimateLineItem, error)
+	ListEstimateRevisions(ctx context.Context, arg ListEstimateRevisionsParams) ([]ListEstimateRevisionsRow, error)
+	ListEstimateSignatures(ctx context.Context, arg ListEstimateSignaturesParams) ([]ListEstimateSignaturesRow, error)
+	ListEstimates(ctx context.Context, arg ListEstimatesParams) ([]ListEstimatesRow, error)
 	ListImportRowResultsByRun(ctx context.Context, arg ListImportRowResultsByRunParams) ([]ImportRowResult, error)
 	ListImportRowResultsByRunAndSeverity(ctx context.Context, arg ListImportRowResultsByRunAndSeverityParams) ([]ImportRowResult, error)
+	ListInventoryItems(ctx context.Context, arg ListInventoryItemsParams) ([]InventoryItem, error)
+	ListJobStatusHistory(ctx context.Context, arg ListJobStatusHistoryParams) ([]ListJobStatusHistoryRow, error)
+	ListJobs(ctx context.Context, arg ListJobsParams) ([]ListJobsRow, error)
+	ListLedgerEntries(ctx context.Context, arg ListLedgerEntriesParams) ([]ListLedgerEntriesRow, error)
+	ListPermissions(ctx context.Context) ([]ListPermissionsRow, error)
+	ListSSOGroupRoleMappings(ctx context.Context, tenantID uuid.UUID) ([]ListSSOGroupRoleMappingsRow, error)
 	ListStorageRows(ctx context.Context, arg ListStorageRowsParams) ([]ListStorageRowsRow, error)
+	ListTariffAccessorials(ctx context.Context, tenantID uuid.UUID) ([]ListTariffAccessorialsRow, error)
+	ListTariffCrewRates(ctx context.Context, tenantID uuid.UUID) ([]ListTariffCrewRatesRow, error)
+	ListTariffValuationOptions(ctx context.Context, tenantID uuid.UUID) ([]ListTariffValuationOptionsRow, error)
+	ListTenantRoles(ctx context.Context, tenantID uuid.UUID) ([]ListTenantRolesRow, error)
+	ListTenantUsers(ctx context.Context, tenantID uuid.UUID) ([]ListTenantUsersRow, error)
+	ListUserPermissions(ctx context.Context, arg ListUserPermissionsParams) ([]string, error)
+	ListUserRoleIDs(ctx context.Context, arg ListUserRoleIDsParams) ([]uuid.UUID, error)
+	ListUserSessions(ctx context.Context, arg ListUserSessionsParams) ([]ListUserSessionsRow, error)
 	ListUsersByEmail(ctx context.Context, email string) ([]ListUsersByEmailRow, error)
+	LockEstimate(ctx context.Context, arg LockEstimateParams) (uuid.UUID, error)
+	LockEstimateAcceptanceLink(ctx context.Context, id uuid.UUID) (EstimateAcceptanceLink, error)
+	LockTenantRoles(ctx context.Context, tenantID uuid.UUID) error
+	LockUserLogin(ctx context.Context, arg LockUserLoginParams) error
+	MarkEmailFailed(ctx context.Context, arg MarkEmailFailedParams) (int64, error)
+	MarkEmailRetry(ctx context.Context, arg MarkEmailRetryParams) (int64, error)
+	MarkEmailSent(ctx context.Context, arg MarkEmailSentParams) (int64, error)
+	MarkEstimateAcceptanceLinkAccepted(ctx context.Context, arg MarkEstimateAcceptanceLinkAcceptedParams) error
+	MarkEstimateAcceptanceLinkViewed(ctx context.Context, id uuid.UUID) (int64, error)
 	MarkEstimateConverted(ctx context.Context, arg MarkEstimateConvertedParams) (int64, error)
+	ReassignCustomerImportIdempotency(ctx context.Context, arg ReassignCustomerImportIdempotencyParams) error
+	ReassignEstimatesCustomer(ctx context.Context, arg ReassignEstimatesCustomerParams) (int64, error)
+	ReassignJobsCustomer(ctx context.Context, arg ReassignJobsCustomerParams) (int64, error)
+	RecalculateEstimateTotals(ctx context.Context, arg RecalculateEstimateTotalsParams) (RecalculateEstimateTotalsRow, error)
+	RecordLoginFailure(ctx context.Context, arg RecordLoginFailureParams) (UserLoginFailure, error)
+	RefreshJobBalance(ctx context.Context, arg RefreshJobBalanceParams) (RefreshJobBalanceRow, error)
+	RefreshStorageBalance(ctx context.Context, arg RefreshStorageBalanceParams) (RefreshStorageBalanceRow, error)
+	RemoveUserRoles(ctx context.Context, arg RemoveUserRolesParams) (int64, error)
+	RenewEmailLease(ctx context.Context, arg RenewEmailLeaseParams) (int64, error)
+	RestoreEstimateFields(ctx context.Context, arg RestoreEstimateFieldsParams) (Estimate, error)
+	RestoreEstimateLineItem(ctx context.Context, arg RestoreEstimateLineItemParams) error
+	RevokeAPIToken(ctx context.Context, arg RevokeAPITokenParams) (int64, error)
+	RevokeEstimateAcceptanceLinks(ctx context.Context, arg RevokeEstimateAcceptanceLinksParams) error
+	RevokeOtherUserSessions(ctx context.Context, arg RevokeOtherUserSessionsParams) (int64, error)
 	RevokeSessionByID(ctx context.Context, arg RevokeSessionByIDParams) (int64, error)
 	RevokeSessionByTokenHash(ctx context.Context, tokenHash string) (int64, error)
-	TouchSession(ctx context.Context, id uuid.UUID) error
+	RevokeSessionsForUser(ctx context.Context, arg RevokeSessionsForUserParams) (int64, error)
+	RevokeUserSession(ctx context.Context, arg RevokeUserSessionParams) (int64, error)
+	RotateSessionToken(ctx context.Context, arg RotateSessionTokenParams) (int64, error)
+	SearchTenantRecords(ctx context.Context, arg SearchTenantRecordsParams) ([]SearchTenantRecordsRow, error)
+	SetEstimateDocumentLogo(ctx context.Context, arg SetEstimateDocumentLogoParams) error
+	SetEstimatePrice(ctx context.Context, arg SetEstimatePriceParams) (Estimate, error)
+	SetStorageMoveBalance(ctx context.Context, arg SetStorageMoveBalanceParams) error
+	SyncSoleEstimateCustomer(ctx context.Context, arg SyncSoleEstimateCustomerParams) (int64, error)
+	TouchAPIToken(ctx context.Context, arg TouchAPITokenParams) error
+	TouchSession(ctx context.Context, arg TouchSessionParams) error
+	TransitionEstimateStatus(ctx context.Context, arg TransitionEstimateStatusParams) (Estimate, error)
+	UpdateCustomer(ctx context.Context, arg UpdateCustomerParams) (Customer, error)
 	UpdateCustomerForEstimate(ctx context.Context, arg UpdateCustomerForEstimateParams) (Customer, error)
 	UpdateEstimate(ctx context.Context, arg UpdateEstimateParams) (Estimate, error)
 	UpdateEstimateByNumber(ctx context.Context, arg UpdateEstimateByNumberParams) (Estimate, error)
+	UpdateEstimateLineItem(ctx context.Context, arg UpdateEstimateLineItemParams) (EstimateLineItem, error)
+	UpdateInventoryItem(ctx context.Context, arg UpdateInventoryItemParams) (InventoryItem, error)
 	UpdateJobByJobNumber(ctx context.Context, arg UpdateJobByJobNumberParams) (Job, error)
 	UpdateJobScheduleStatus(ctx context.Context, arg UpdateJobScheduleStatusParams) (Job, error)
+	UpdateRole(ctx context.Context, arg UpdateRoleParams) (Role, error)
 	UpdateStorageRecordByID(ctx context.Context, arg UpdateStorageRecordByIDParams) (StorageRecord, error)
+	UpdateTenantUser(ctx context.Context, arg UpdateTenantUserParams) (User, error)
+	UpdateUserPasswordHash(ctx context.Context, arg UpdateUserPasswordHashParams) (int64, error)
+	UpsertEstimateDocumentTemplate(ctx context.Context, arg UpsertEstimateDocumentTemplateParams) error
 	UpsertImportIdempotency(ctx context.Context, arg UpsertImportIdempotencyParams) (ImportIdempotency, error)
 	UpsertImportRowResult(ctx context.Context, arg UpsertImportRowResultParams) (ImportRowResult, error)
-	UserHasPermission(ctx context.Context, arg UserHasPermissionParams) (bool, error)
+	UpsertPendingUserMFA(ctx context.Context, arg UpsertPendingUserMFAParams) (UserMfa, error)
+	UpsertSSOProvider(ctx context.Context, arg UpsertSSOProviderParams) error
+	UpsertTariff(ctx context.Context, arg UpsertTariffParams) (Tariff, error)
+	UpsertTenantEmailSettings(ctx context.Context, arg UpsertTenantEmailSettingsParams) error
+	UpsertTenantSettings(ctx context.Context, arg UpsertTenantSettingsParams) error
+	UpsertUserIdentity(ctx context.Context, arg UpsertUserIdentityParams) error
+	UsePasswordResetToken(ctx context.Context, id uuid.UUID) (int64, error)
+	UseRecoveryCode(ctx context.Context, arg UseRecoveryCodeParams) (int64, error)
 }
 
 var _ Querier = (*Queries)(nil)
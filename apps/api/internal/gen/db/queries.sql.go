@@ -12,6 +12,179 @@ import (
 	"github.com/google/uuid"
 )
 
+const addRolePermissionsByName = `-- name: AddRolePermissionsByName :exec
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT $1, p.id
+FROM permissions p
+WHERE p.name = ANY($2::text[])
+ON CONFLICT DO NOTHING
+`
+
+type AddRolePermissionsByNameParams struct {
+	RoleID uuid.UUID `json:"role_id"`
+	Names  []string  `json:"names"`
+}
+
+func (q *Queries) AddRolePermissionsByName(ctx context.Context, arg AddRolePermissionsByNameParams) error {
+	_, err := q.db.Exec(ctx, addRolePermissionsByName, arg.RoleID, arg.Names)
+	return err
+}
+
+const advanceUserMFAStep = `-- name: AdvanceUserMFAStep :execrows
+UPDATE user_mfa
+SET last_used_step = $1,
+    updated_at = NOW()
+WHERE user_id = $2
+  AND confirmed_at IS NOT NULL
+  AND last_used_step < $1
+`
+
+type AdvanceUserMFAStepParams struct {
+	Step   int64     `json:"step"`
+	UserID uuid.UUID `json:"user_id"`
+}
+
+func (q *Queries) AdvanceUserMFAStep(ctx context.Context, arg AdvanceUserMFAStepParams) (int64, error) {
+	result, err := q.db.Exec(ctx, advanceUserMFAStep, arg.Step, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const assignUserRole = `-- name: AssignUserRole :execrows
+INSERT INTO user_roles (user_id, role_id, tenant_id)
+SELECT $1, r.id, r.tenant_id
+FROM roles r
+WHERE r.id = $2
+  AND r.tenant_id = $3
+ON CONFLICT DO NOTHING
+`
+
+type AssignUserRoleParams struct {
+	UserID   uuid.UUID `json:"user_id"`
+	RoleID   uuid.UUID `json:"role_id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+}
+
+func (q *Queries) AssignUserRole(ctx context.Context, arg AssignUserRoleParams) (int64, error) {
+	result, err := q.db.Exec(ctx, assignUserRole, arg.UserID, arg.RoleID, arg.TenantID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const claimDueEmails = `-- name: ClaimDueEmails :many
+UPDATE email_outbox
+SET
+  status = 'sending',
+  attempts = attempts + 1,
+  next_attempt_at = NOW() + make_interval(secs => $1::int),
+  updated_at = NOW()
+WHERE id IN (
+  SELECT id
+  FROM email_outbox
+  WHERE status IN ('queued', 'sending')
+    AND next_attempt_at <= NOW()
+  ORDER BY next_attempt_at, id
+  LIMIT $2
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING id, tenant_id, kind, estimate_id, job_id, storage_record_id, to_address, from_address, from_name, reply_to, subject, body, status, attempts, next_attempt_at, last_error, sent_at, created_by, created_at, updated_at
+`
+
+type ClaimDueEmailsParams struct {
+	LeaseSeconds int32 `json:"lease_seconds"`
+	BatchSize    int32 `json:"batch_size"`
+}
+
+func (q *Queries) ClaimDueEmails(ctx context.Context, arg ClaimDueEmailsParams) ([]EmailOutbox, error) {
+	rows, err := q.db.Query(ctx, claimDueEmails, arg.LeaseSeconds, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []EmailOutbox{}
+	for rows.Next() {
+		var i EmailOutbox
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.Kind,
+			&i.EstimateID,
+			&i.JobID,
+			&i.StorageRecordID,
+			&i.ToAddress,
+			&i.FromAddress,
+			&i.FromName,
+			&i.ReplyTo,
+			&i.Subject,
+			&i.Body,
+			&i.Status,
+			&i.Attempts,
+			&i.NextAttemptAt,
+			&i.LastError,
+			&i.SentAt,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const clearLoginFailures = `-- name: ClearLoginFailures :execrows
+DELETE FROM user_login_failures
+WHERE user_id = $1
+  AND tenant_id = $2
+`
+
+type ClearLoginFailuresParams struct {
+	UserID   uuid.UUID `json:"user_id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+}
+
+func (q *Queries) ClearLoginFailures(ctx context.Context, arg ClearLoginFailuresParams) (int64, error) {
+	result, err := q.db.Exec(ctx, clearLoginFailures, arg.UserID, arg.TenantID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const clearRolePermissions = `-- name: ClearRolePermissions :exec
+DELETE FROM role_permissions
+WHERE role_id = $1
+`
+
+func (q *Queries) ClearRolePermissions(ctx context.Context, roleID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, clearRolePermissions, roleID)
+	return err
+}
+
+const clearUserRoles = `-- name: ClearUserRoles :exec
+DELETE FROM user_roles
+WHERE user_id = $1
+  AND tenant_id = $2
+`
+
+type ClearUserRolesParams struct {
+	UserID   uuid.UUID `json:"user_id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+}
+
+func (q *Queries) ClearUserRoles(ctx context.Context, arg ClearUserRolesParams) error {
+	_, err := q.db.Exec(ctx, clearUserRoles, arg.UserID, arg.TenantID)
+	return err
+}
+
 const completeImportRun = `-- name: CompleteImportRun :one
 UPDATE import_run
 SET
@@ -55,6 +228,173 @@ func (q *Queries) CompleteImportRun(ctx context.Context, arg CompleteImportRunPa
 	return i, err
 }
 
+const confirmUserMFA = `-- name: ConfirmUserMFA :execrows
+UPDATE user_mfa
+SET confirmed_at = NOW(),
+    last_used_step = $1,
+    updated_at = NOW()
+WHERE user_id = $2
+  AND confirmed_at IS NULL
+`
+
+type ConfirmUserMFAParams struct {
+	Step   int64     `json:"step"`
+	UserID uuid.UUID `json:"user_id"`
+}
+
+func (q *Queries) ConfirmUserMFA(ctx context.Context, arg ConfirmUserMFAParams) (int64, error) {
+	result, err := q.db.Exec(ctx, confirmUserMFA, arg.Step, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const consumeOIDCLoginState = `-- name: ConsumeOIDCLoginState :one
+UPDATE oidc_login_states
+SET consumed_at = NOW()
+WHERE state_hash = $1
+  AND consumed_at IS NULL
+  AND expires_at > NOW()
+RETURNING tenant_id, code_verifier, nonce
+`
+
+type ConsumeOIDCLoginStateRow struct {
+	TenantID     uuid.UUID `json:"tenant_id"`
+	CodeVerifier string    `json:"code_verifier"`
+	Nonce        string    `json:"nonce"`
+}
+
+func (q *Queries) ConsumeOIDCLoginState(ctx context.Context, stateHash string) (ConsumeOIDCLoginStateRow, error) {
+	row := q.db.QueryRow(ctx, consumeOIDCLoginState, stateHash)
+	var i ConsumeOIDCLoginStateRow
+	err := row.Scan(&i.TenantID, &i.CodeVerifier, &i.Nonce)
+	return i, err
+}
+
+const countActiveUsersWithPermission = `-- name: CountActiveUsersWithPermission :one
+SELECT COUNT(DISTINCT u.id)::bigint
+FROM users u
+JOIN user_roles ur
+  ON ur.user_id = u.id
+  AND ur.tenant_id = u.tenant_id
+JOIN role_permissions rp ON rp.role_id = ur.role_id
+JOIN permissions p ON p.id = rp.permission_id
+WHERE u.tenant_id = $1
+  AND u.is_active = TRUE
+  AND p.name = $2
+`
+
+type CountActiveUsersWithPermissionParams struct {
+	TenantID   uuid.UUID `json:"tenant_id"`
+	Permission string    `json:"permission"`
+}
+
+func (q *Queries) CountActiveUsersWithPermission(ctx context.Context, arg CountActiveUsersWithPermissionParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countActiveUsersWithPermission, arg.TenantID, arg.Permission)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const countPermissionsByNames = `-- name: CountPermissionsByNames :one
+SELECT COUNT(*)::bigint
+FROM permissions
+WHERE name = ANY($1::text[])
+`
+
+func (q *Queries) CountPermissionsByNames(ctx context.Context, names []string) (int64, error) {
+	row := q.db.QueryRow(ctx, countPermissionsByNames, names)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const countTenantRolesByIDs = `-- name: CountTenantRolesByIDs :one
+SELECT COUNT(*)::bigint
+FROM roles
+WHERE tenant_id = $1
+  AND id = ANY($2::uuid[])
+`
+
+type CountTenantRolesByIDsParams struct {
+	TenantID uuid.UUID   `json:"tenant_id"`
+	RoleIds  []uuid.UUID `json:"role_ids"`
+}
+
+func (q *Queries) CountTenantRolesByIDs(ctx context.Context, arg CountTenantRolesByIDsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countTenantRolesByIDs, arg.TenantID, arg.RoleIds)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const createAPIToken = `-- name: CreateAPIToken :one
+INSERT INTO api_tokens (
+  tenant_id,
+  user_id,
+  name,
+  token_hash,
+  token_prefix,
+  scopes,
+  expires_at
+) VALUES (
+  $1,
+  $2,
+  $3,
+  $4,
+  $5,
+  $6::text[],
+  $7
+)
+RETURNING id, name, token_prefix, scopes, expires_at, last_used_at, last_used_ip, created_at
+`
+
+type CreateAPITokenParams struct {
+	TenantID    uuid.UUID  `json:"tenant_id"`
+	UserID      uuid.UUID  `json:"user_id"`
+	Name        string     `json:"name"`
+	TokenHash   string     `json:"token_hash"`
+	TokenPrefix string     `json:"token_prefix"`
+	Scopes      []string   `json:"scopes"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+}
+
+type CreateAPITokenRow struct {
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	TokenPrefix string     `json:"token_prefix"`
+	Scopes      []string   `json:"scopes"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+	LastUsedAt  *time.Time `json:"last_used_at"`
+	LastUsedIp  *string    `json:"last_used_ip"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+func (q *Queries) CreateAPIToken(ctx context.Context, arg CreateAPITokenParams) (CreateAPITokenRow, error) {
+	row := q.db.QueryRow(ctx, createAPIToken,
+		arg.TenantID,
+		arg.UserID,
+		arg.Name,
+		arg.TokenHash,
+		arg.TokenPrefix,
+		arg.Scopes,
+		arg.ExpiresAt,
+	)
+	var i CreateAPITokenRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.TokenPrefix,
+		&i.Scopes,
+		&i.ExpiresAt,
+		&i.LastUsedAt,
+		&i.LastUsedIp,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const createCustomer = `-- name: CreateCustomer :one
 INSERT INTO customers (
   tenant_id,
@@ -193,6 +533,7 @@ INSERT INTO estimates (
   move_size,
   location_type,
   estimated_total_cents,
+  price_source,
   deposit_cents,
   notes,
   idempotency_key,
@@ -221,7 +562,8 @@ INSERT INTO estimates (
   $19,
   $20,
   $21,
-  $22,
+  $22::bigint,
+  CASE WHEN $22::bigint IS NULL THEN NULL ELSE 'manual' END,
   $23,
   $24,
   $25,
@@ -229,7 +571,7 @@ INSERT INTO estimates (
   $27,
   $28
 )
-RETURNING id, tenant_id, estimate_number, customer_id, status, customer_name, primary_phone, secondary_phone, email, origin_address_line1, origin_city, origin_state, origin_postal_code, destination_address_line1, destination_city, destination_state, destination_postal_code, move_date, pickup_time, lead_source, move_size, location_type, estimated_total_cents, deposit_cents, notes, idempotency_key, idempotency_payload_hash, created_by, updated_by, created_at, updated_at
+RETURNING id, tenant_id, estimate_number, customer_id, status, customer_name, primary_phone, secondary_phone, email, origin_address_line1, origin_city, origin_state, origin_postal_code, destination_address_line1, destination_city, destination_state, destination_postal_code, move_date, pickup_time, lead_source, move_size, location_type, estimated_total_cents, deposit_cents, total_cubic_feet, total_weight_lbs, price_source, price_breakdown, priced_at, notes, lost_reason, sent_at, expires_at, status_changed_at, idempotency_key, idempotency_payload_hash, created_by, updated_by, created_at, updated_at
 `
 
 type CreateEstimateParams struct {
@@ -320,7 +662,16 @@ func (q *Queries) CreateEstimate(ctx context.Context, arg CreateEstimateParams)
 		&i.LocationType,
 		&i.EstimatedTotalCents,
 		&i.DepositCents,
+		&i.TotalCubicFeet,
+		&i.TotalWeightLbs,
+		&i.PriceSource,
+		&i.PriceBreakdown,
+		&i.PricedAt,
 		&i.Notes,
+		&i.LostReason,
+		&i.SentAt,
+		&i.ExpiresAt,
+		&i.StatusChangedAt,
 		&i.IdempotencyKey,
 		&i.IdempotencyPayloadHash,
 		&i.CreatedBy,
@@ -331,6 +682,125 @@ func (q *Queries) CreateEstimate(ctx context.Context, arg CreateEstimateParams)
 	return i, err
 }
 
+const createEstimateAcceptanceLink = `-- name: CreateEstimateAcceptanceLink :one
+INSERT INTO estimate_acceptance_links (
+  tenant_id,
+  estimate_id,
+  auto_convert,
+  expires_at,
+  created_by
+) VALUES (
+  $1,
+  $2,
+  $3,
+  $4,
+  $5
+)
+RETURNING id, tenant_id, estimate_id, auto_convert, expires_at, revoked_at, accepted_at, first_viewed_at, created_by, created_at
+`
+
+type CreateEstimateAcceptanceLinkParams struct {
+	TenantID    uuid.UUID  `json:"tenant_id"`
+	EstimateID  uuid.UUID  `json:"estimate_id"`
+	AutoConvert bool       `json:"auto_convert"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	CreatedBy   *uuid.UUID `json:"created_by"`
+}
+
+func (q *Queries) CreateEstimateAcceptanceLink(ctx context.Context, arg CreateEstimateAcceptanceLinkParams) (EstimateAcceptanceLink, error) {
+	row := q.db.QueryRow(ctx, createEstimateAcceptanceLink,
+		arg.TenantID,
+		arg.EstimateID,
+		arg.AutoConvert,
+		arg.ExpiresAt,
+		arg.CreatedBy,
+	)
+	var i EstimateAcceptanceLink
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.EstimateID,
+		&i.AutoConvert,
+		&i.ExpiresAt,
+		&i.RevokedAt,
+		&i.AcceptedAt,
+		&i.FirstViewedAt,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createEstimateLineItem = `-- name: CreateEstimateLineItem :one
+INSERT INTO estimate_line_items (
+  tenant_id,
+  estimate_id,
+  inventory_item_id,
+  room,
+  item_name,
+  quantity,
+  cubic_feet,
+  weight_lbs,
+  created_by,
+  updated_by
+) VALUES (
+  $1,
+  $2,
+  $3,
+  $4,
+  $5,
+  $6,
+  $7,
+  $8,
+  $9,
+  $9
+)
+RETURNING id, tenant_id, estimate_id, inventory_item_id, room, item_name, quantity, cubic_feet, weight_lbs, created_by, updated_by, created_at, updated_at
+`
+
+type CreateEstimateLineItemParams struct {
+	TenantID        uuid.UUID  `json:"tenant_id"`
+	EstimateID      uuid.UUID  `json:"estimate_id"`
+	InventoryItemID *uuid.UUID `json:"inventory_item_id"`
+	Room            string     `json:"room"`
+	ItemName        string     `json:"item_name"`
+	Quantity        int32      `json:"quantity"`
+	CubicFeet       int32      `json:"cubic_feet"`
+	WeightLbs       int32      `json:"weight_lbs"`
+	CreatedBy       *uuid.UUID `json:"created_by"`
+}
+
+func (q *Queries) CreateEstimateLineItem(ctx context.Context, arg CreateEstimateLineItemParams) (EstimateLineItem, error) {
+	row := q.db.QueryRow(ctx, createEstimateLineItem,
+		arg.TenantID,
+		arg.EstimateID,
+		arg.InventoryItemID,
+		arg.Room,
+		arg.ItemName,
+		arg.Quantity,
+		arg.CubicFeet,
+		arg.WeightLbs,
+		arg.CreatedBy,
+	)
+	var i EstimateLineItem
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.EstimateID,
+		&i.InventoryItemID,
+		&i.Room,
+		&i.ItemName,
+		&i.Quantity,
+		&i.CubicFeet,
+		&i.WeightLbs,
+		&i.CreatedBy,
+		&i.UpdatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const createImportRun = `-- name: CreateImportRun :one
 INSERT INTO import_run (
   tenant_id,
@@ -398,6 +868,62 @@ func (q *Queries) CreateImportRun(ctx context.Context, arg CreateImportRunParams
 	return i, err
 }
 
+const createInventoryItem = `-- name: CreateInventoryItem :one
+INSERT INTO inventory_items (
+  tenant_id,
+  name,
+  default_room,
+  cubic_feet,
+  weight_lbs,
+  created_by,
+  updated_by
+) VALUES (
+  $1,
+  $2,
+  $3,
+  $4,
+  $5,
+  $6,
+  $6
+)
+RETURNING id, tenant_id, name, default_room, cubic_feet, weight_lbs, active, created_by, updated_by, created_at, updated_at
+`
+
+type CreateInventoryItemParams struct {
+	TenantID    uuid.UUID  `json:"tenant_id"`
+	Name        string     `json:"name"`
+	DefaultRoom *string    `json:"default_room"`
+	CubicFeet   int32      `json:"cubic_feet"`
+	WeightLbs   int32      `json:"weight_lbs"`
+	CreatedBy   *uuid.UUID `json:"created_by"`
+}
+
+func (q *Queries) CreateInventoryItem(ctx context.Context, arg CreateInventoryItemParams) (InventoryItem, error) {
+	row := q.db.QueryRow(ctx, createInventoryItem,
+		arg.TenantID,
+		arg.Name,
+		arg.DefaultRoom,
+		arg.CubicFeet,
+		arg.WeightLbs,
+		arg.CreatedBy,
+	)
+	var i InventoryItem
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.Name,
+		&i.DefaultRoom,
+		&i.CubicFeet,
+		&i.WeightLbs,
+		&i.Active,
+		&i.CreatedBy,
+		&i.UpdatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const createJob = `-- name: CreateJob :one
 INSERT INTO jobs (
   tenant_id,
@@ -422,7 +948,7 @@ INSERT INTO jobs (
   $9,
   $10
 )
-RETURNING id, tenant_id, job_number, estimate_id, customer_id, status, scheduled_date, pickup_time, convert_idempotency_key, created_by, updated_by, created_at, updated_at
+RETURNING id, tenant_id, job_number, estimate_id, customer_id, status, scheduled_date, pickup_time, convert_idempotency_key, balance_cents, last_payment_at, created_by, updated_by, created_at, updated_at
 `
 
 type CreateJobParams struct {
@@ -462,6 +988,8 @@ func (q *Queries) CreateJob(ctx context.Context, arg CreateJobParams) (Job, erro
 		&i.ScheduledDate,
 		&i.PickupTime,
 		&i.ConvertIdempotencyKey,
+		&i.BalanceCents,
+		&i.LastPaymentAt,
 		&i.CreatedBy,
 		&i.UpdatedBy,
 		&i.CreatedAt,
@@ -470,29 +998,223 @@ func (q *Queries) CreateJob(ctx context.Context, arg CreateJobParams) (Job, erro
 	return i, err
 }
 
-const createSession = `-- name: CreateSession :one
-INSERT INTO sessions (
+const createLedgerEntry = `-- name: CreateLedgerEntry :one
+INSERT INTO ledger_entries (
   tenant_id,
-  user_id,
+  job_id,
+  storage_record_id,
+  account,
+  kind,
+  amount_cents,
+  method,
+  reference,
+  memo,
+  occurred_at,
+  idempotency_key,
+  idempotency_payload_hash,
+  created_by
+) VALUES (
+  $1,
+  $2,
+  $3,
+  $4,
+  $5,
+  $6,
+  $7,
+  $8,
+  $9,
+  COALESCE($10::timestamptz, NOW()),
+  $11,
+  $12,
+  $13
+)
+RETURNING id, tenant_id, job_id, storage_record_id, account, kind, amount_cents, balance_effect_cents, method, reference, memo, occurred_at, idempotency_key, idempotency_payload_hash, created_by, created_at
+`
+
+type CreateLedgerEntryParams struct {
+	TenantID               uuid.UUID  `json:"tenant_id"`
+	JobID                  uuid.UUID  `json:"job_id"`
+	StorageRecordID        *uuid.UUID `json:"storage_record_id"`
+	Account                string     `json:"account"`
+	Kind                   string     `json:"kind"`
+	AmountCents            int64      `json:"amount_cents"`
+	Method                 *string    `json:"method"`
+	Reference              *string    `json:"reference"`
+	Memo                   *string    `json:"memo"`
+	OccurredAt             *time.Time `json:"occurred_at"`
+	IdempotencyKey         *string    `json:"idempotency_key"`
+	IdempotencyPayloadHash *string    `json:"idempotency_payload_hash"`
+	CreatedBy              *uuid.UUID `json:"created_by"`
+}
+
+func (q *Queries) CreateLedgerEntry(ctx context.Context, arg CreateLedgerEntryParams) (LedgerEntry, error) {
+	row := q.db.QueryRow(ctx, createLedgerEntry,
+		arg.TenantID,
+		arg.JobID,
+		arg.StorageRecordID,
+		arg.Account,
+		arg.Kind,
+		arg.AmountCents,
+		arg.Method,
+		arg.Reference,
+		arg.Memo,
+		arg.OccurredAt,
+		arg.IdempotencyKey,
+		arg.IdempotencyPayloadHash,
+		arg.CreatedBy,
+	)
+	var i LedgerEntry
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.JobID,
+		&i.StorageRecordID,
+		&i.Account,
+		&i.Kind,
+		&i.AmountCents,
+		&i.BalanceEffectCents,
+		&i.Method,
+		&i.Reference,
+		&i.Memo,
+		&i.OccurredAt,
+		&i.IdempotencyKey,
+		&i.IdempotencyPayloadHash,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createOIDCLoginState = `-- name: CreateOIDCLoginState :exec
+INSERT INTO oidc_login_states (state_hash, tenant_id, code_verifier, nonce, expires_at)
+VALUES ($1, $2, $3, $4, $5)
+`
+
+type CreateOIDCLoginStateParams struct {
+	StateHash    string    `json:"state_hash"`
+	TenantID     uuid.UUID `json:"tenant_id"`
+	CodeVerifier string    `json:"code_verifier"`
+	Nonce        string    `json:"nonce"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+func (q *Queries) CreateOIDCLoginState(ctx context.Context, arg CreateOIDCLoginStateParams) error {
+	_, err := q.db.Exec(ctx, createOIDCLoginState,
+		arg.StateHash,
+		arg.TenantID,
+		arg.CodeVerifier,
+		arg.Nonce,
+		arg.ExpiresAt,
+	)
+	return err
+}
+
+const createPasswordResetToken = `-- name: CreatePasswordResetToken :one
+INSERT INTO password_reset_tokens (tenant_id, user_id, token_hash, created_by, expires_at)
+SELECT u.tenant_id, u.id, $1, $2, $3
+FROM users u
+WHERE u.id = $4
+  AND u.tenant_id = $5
+  AND u.is_active = TRUE
+RETURNING id, expires_at
+`
+
+type CreatePasswordResetTokenParams struct {
+	TokenHash string     `json:"token_hash"`
+	CreatedBy *uuid.UUID `json:"created_by"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UserID    uuid.UUID  `json:"user_id"`
+	TenantID  uuid.UUID  `json:"tenant_id"`
+}
+
+type CreatePasswordResetTokenRow struct {
+	ID        uuid.UUID `json:"id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func (q *Queries) CreatePasswordResetToken(ctx context.Context, arg CreatePasswordResetTokenParams) (CreatePasswordResetTokenRow, error) {
+	row := q.db.QueryRow(ctx, createPasswordResetToken,
+		arg.TokenHash,
+		arg.CreatedBy,
+		arg.ExpiresAt,
+		arg.UserID,
+		arg.TenantID,
+	)
+	var i CreatePasswordResetTokenRow
+	err := row.Scan(&i.ID, &i.ExpiresAt)
+	return i, err
+}
+
+const createRole = `-- name: CreateRole :one
+INSERT INTO roles (
+  tenant_id,
+  name,
+  description
+) VALUES (
+  $1,
+  $2,
+  $3
+)
+RETURNING id, tenant_id, name, description, created_at
+`
+
+type CreateRoleParams struct {
+	TenantID    uuid.UUID `json:"tenant_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+}
+
+func (q *Queries) CreateRole(ctx context.Context, arg CreateRoleParams) (Role, error) {
+	row := q.db.QueryRow(ctx, createRole, arg.TenantID, arg.Name, arg.Description)
+	var i Role
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createSession = `-- name: CreateSession :one
+INSERT INTO sessions (
+  tenant_id,
+  user_id,
   token_hash,
   csrf_token,
-  expires_at
+  expires_at,
+  absolute_expires_at,
+  mfa_pending,
+  user_agent,
+  ip_address,
+  linked_user_ids
 ) VALUES (
   $1,
   $2,
   $3,
   $4,
-  $5
+  $5,
+  $6,
+  $7,
+  $8,
+  $9,
+  $10::uuid[]
 )
-RETURNING id, tenant_id, user_id, token_hash, csrf_token, created_at, expires_at, last_seen_at, revoked_at
+RETURNING id, tenant_id, user_id, token_hash, csrf_token, created_at, expires_at, last_seen_at, revoked_at, mfa_pending, user_agent, ip_address, absolute_expires_at, token_rotated_at, previous_token_hash, linked_user_ids
 `
 
 type CreateSessionParams struct {
-	TenantID  uuid.UUID `json:"tenant_id"`
-	UserID    uuid.UUID `json:"user_id"`
-	TokenHash string    `json:"token_hash"`
-	CsrfToken string    `json:"csrf_token"`
-	ExpiresAt time.Time `json:"expires_at"`
+	TenantID          uuid.UUID   `json:"tenant_id"`
+	UserID            uuid.UUID   `json:"user_id"`
+	TokenHash         string      `json:"token_hash"`
+	CsrfToken         string      `json:"csrf_token"`
+	ExpiresAt         time.Time   `json:"expires_at"`
+	AbsoluteExpiresAt time.Time   `json:"absolute_expires_at"`
+	MfaPending        bool        `json:"mfa_pending"`
+	UserAgent         string      `json:"user_agent"`
+	IpAddress         string      `json:"ip_address"`
+	LinkedUserIds     []uuid.UUID `json:"linked_user_ids"`
 }
 
 func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error) {
@@ -502,6 +1224,11 @@ func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (S
 		arg.TokenHash,
 		arg.CsrfToken,
 		arg.ExpiresAt,
+		arg.AbsoluteExpiresAt,
+		arg.MfaPending,
+		arg.UserAgent,
+		arg.IpAddress,
+		arg.LinkedUserIds,
 	)
 	var i Session
 	err := row.Scan(
@@ -514,6 +1241,13 @@ func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (S
 		&i.ExpiresAt,
 		&i.LastSeenAt,
 		&i.RevokedAt,
+		&i.MfaPending,
+		&i.UserAgent,
+		&i.IpAddress,
+		&i.AbsoluteExpiresAt,
+		&i.TokenRotatedAt,
+		&i.PreviousTokenHash,
+		&i.LinkedUserIds,
 	)
 	return i, err
 }
@@ -535,9 +1269,7 @@ INSERT INTO storage_record (
   oversize_items,
   volume,
   monthly_rate_cents,
-  storage_balance_cents,
   move_balance_cents,
-  last_payment_at,
   notes
 ) VALUES (
   $1,
@@ -555,34 +1287,29 @@ INSERT INTO storage_record (
   COALESCE($13::int, 0),
   COALESCE($14::int, 0),
   $15::bigint,
-  COALESCE($16::bigint, 0),
-  COALESCE($17::bigint, 0),
-  $18::timestamptz,
-  $19
+  (SELECT j.balance_cents FROM jobs j WHERE j.id = $2 AND j.tenant_id = $1),
+  $16
 )
 RETURNING id, tenant_id, job_id, facility, status, date_in, date_out, next_bill_date, lot_number, location_label, vaults, pads, items, oversize_items, volume, monthly_rate_cents, storage_balance_cents, move_balance_cents, last_payment_at, notes, created_at, updated_at
 `
 
 type CreateStorageRecordParams struct {
-	TenantID            uuid.UUID  `json:"tenant_id"`
-	JobID               uuid.UUID  `json:"job_id"`
-	Facility            string     `json:"facility"`
-	Status              *string    `json:"status"`
-	DateIn              *time.Time `json:"date_in"`
-	DateOut             *time.Time `json:"date_out"`
-	NextBillDate        *time.Time `json:"next_bill_date"`
-	LotNumber           *string    `json:"lot_number"`
-	LocationLabel       *string    `json:"location_label"`
-	Vaults              *int32     `json:"vaults"`
-	Pads                *int32     `json:"pads"`
-	Items               *int32     `json:"items"`
-	OversizeItems       *int32     `json:"oversize_items"`
-	Volume              *int32     `json:"volume"`
-	MonthlyRateCents    *int64     `json:"monthly_rate_cents"`
-	StorageBalanceCents *int64     `json:"storage_balance_cents"`
-	MoveBalanceCents    *int64     `json:"move_balance_cents"`
-	LastPaymentAt       *time.Time `json:"last_payment_at"`
-	Notes               *string    `json:"notes"`
+	TenantID         uuid.UUID  `json:"tenant_id"`
+	JobID            uuid.UUID  `json:"job_id"`
+	Facility         string     `json:"facility"`
+	Status           *string    `json:"status"`
+	DateIn           *time.Time `json:"date_in"`
+	DateOut          *time.Time `json:"date_out"`
+	NextBillDate     *time.Time `json:"next_bill_date"`
+	LotNumber        *string    `json:"lot_number"`
+	LocationLabel    *string    `json:"location_label"`
+	Vaults           *int32     `json:"vaults"`
+	Pads             *int32     `json:"pads"`
+	Items            *int32     `json:"items"`
+	OversizeItems    *int32     `json:"oversize_items"`
+	Volume           *int32     `json:"volume"`
+	MonthlyRateCents *int64     `json:"monthly_rate_cents"`
+	Notes            *string    `json:"notes"`
 }
 
 func (q *Queries) CreateStorageRecord(ctx context.Context, arg CreateStorageRecordParams) (StorageRecord, error) {
@@ -602,9 +1329,6 @@ func (q *Queries) CreateStorageRecord(ctx context.Context, arg CreateStorageReco
 		arg.OversizeItems,
 		arg.Volume,
 		arg.MonthlyRateCents,
-		arg.StorageBalanceCents,
-		arg.MoveBalanceCents,
-		arg.LastPaymentAt,
 		arg.Notes,
 	)
 	var i StorageRecord
@@ -635,118 +1359,463 @@ func (q *Queries) CreateStorageRecord(ctx context.Context, arg CreateStorageReco
 	return i, err
 }
 
-const exportCustomersRows = `-- name: ExportCustomersRows :many
-SELECT
-  id,
-  first_name,
-  last_name,
+const createUser = `-- name: CreateUser :one
+INSERT INTO users (
+  tenant_id,
   email,
-  phone,
-  created_at,
-  updated_at
-FROM customers
-WHERE tenant_id = $1
-ORDER BY created_at ASC
+  full_name,
+  password_hash,
+  is_active
+) VALUES (
+  $1,
+  $2,
+  $3,
+  $4,
+  TRUE
+)
+RETURNING id, tenant_id, email, full_name, password_hash, is_active, created_at, updated_at
 `
 
-type ExportCustomersRowsRow struct {
-	ID        uuid.UUID `json:"id"`
-	FirstName string    `json:"first_name"`
-	LastName  string    `json:"last_name"`
-	Email     *string   `json:"email"`
-	Phone     *string   `json:"phone"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+type CreateUserParams struct {
+	TenantID     uuid.UUID `json:"tenant_id"`
+	Email        string    `json:"email"`
+	FullName     string    `json:"full_name"`
+	PasswordHash string    `json:"password_hash"`
 }
 
-func (q *Queries) ExportCustomersRows(ctx context.Context, tenantID uuid.UUID) ([]ExportCustomersRowsRow, error) {
-	rows, err := q.db.Query(ctx, exportCustomersRows, tenantID)
+func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
+	row := q.db.QueryRow(ctx, createUser,
+		arg.TenantID,
+		arg.Email,
+		arg.FullName,
+		arg.PasswordHash,
+	)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.Email,
+		&i.FullName,
+		&i.PasswordHash,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deleteCustomer = `-- name: DeleteCustomer :execrows
+DELETE FROM customers
+WHERE id = $1
+  AND tenant_id = $2
+`
+
+type DeleteCustomerParams struct {
+	ID       uuid.UUID `json:"id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+}
+
+func (q *Queries) DeleteCustomer(ctx context.Context, arg DeleteCustomerParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteCustomer, arg.ID, arg.TenantID)
 	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	items := []ExportCustomersRowsRow{}
-	for rows.Next() {
-		var i ExportCustomersRowsRow
-		if err := rows.Scan(
-			&i.ID,
-			&i.FirstName,
-			&i.LastName,
-			&i.Email,
-			&i.Phone,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
+		return 0, err
 	}
-	return items, nil
+	return result.RowsAffected(), nil
 }
 
-const exportEstimatesRows = `-- name: ExportEstimatesRows :many
-SELECT
-  id,
-  estimate_number,
-  customer_name,
-  email,
-  primary_phone,
-  secondary_phone,
-  status,
-  origin_city,
-  origin_state,
-  origin_postal_code,
-  destination_city,
-  destination_state,
-  destination_postal_code,
-  move_date,
-  pickup_time,
-  lead_source,
-  estimated_total_cents,
-  deposit_cents,
-  notes,
-  created_at,
-  updated_at
-FROM estimates
+const deleteEmailTemplates = `-- name: DeleteEmailTemplates :exec
+DELETE FROM email_templates
 WHERE tenant_id = $1
-ORDER BY created_at ASC
 `
 
-type ExportEstimatesRowsRow struct {
-	ID                    uuid.UUID `json:"id"`
-	EstimateNumber        string    `json:"estimate_number"`
-	CustomerName          string    `json:"customer_name"`
-	Email                 string    `json:"email"`
-	PrimaryPhone          string    `json:"primary_phone"`
-	SecondaryPhone        *string   `json:"secondary_phone"`
-	Status                string    `json:"status"`
-	OriginCity            string    `json:"origin_city"`
-	OriginState           string    `json:"origin_state"`
-	OriginPostalCode      string    `json:"origin_postal_code"`
-	DestinationCity       string    `json:"destination_city"`
-	DestinationState      string    `json:"destination_state"`
-	DestinationPostalCode string    `json:"destination_postal_code"`
-	MoveDate              time.Time `json:"move_date"`
-	PickupTime            *string   `json:"pickup_time"`
-	LeadSource            string    `json:"lead_source"`
-	EstimatedTotalCents   *int64    `json:"estimated_total_cents"`
-	DepositCents          *int64    `json:"deposit_cents"`
-	Notes                 *string   `json:"notes"`
-	CreatedAt             time.Time `json:"created_at"`
-	UpdatedAt             time.Time `json:"updated_at"`
+func (q *Queries) DeleteEmailTemplates(ctx context.Context, tenantID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteEmailTemplates, tenantID)
+	return err
 }
 
-func (q *Queries) ExportEstimatesRows(ctx context.Context, tenantID uuid.UUID) ([]ExportEstimatesRowsRow, error) {
-	rows, err := q.db.Query(ctx, exportEstimatesRows, tenantID)
+const deleteEstimateLineItem = `-- name: DeleteEstimateLineItem :execrows
+DELETE FROM estimate_line_items
+WHERE id = $1
+  AND tenant_id = $2
+  AND estimate_id = $3
+`
+
+type DeleteEstimateLineItemParams struct {
+	ID         uuid.UUID `json:"id"`
+	TenantID   uuid.UUID `json:"tenant_id"`
+	EstimateID uuid.UUID `json:"estimate_id"`
+}
+
+func (q *Queries) DeleteEstimateLineItem(ctx context.Context, arg DeleteEstimateLineItemParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteEstimateLineItem, arg.ID, arg.TenantID, arg.EstimateID)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	defer rows.Close()
-	items := []ExportEstimatesRowsRow{}
-	for rows.Next() {
+	return result.RowsAffected(), nil
+}
+
+const deleteEstimateLineItems = `-- name: DeleteEstimateLineItems :exec
+DELETE FROM estimate_line_items
+WHERE tenant_id = $1
+  AND estimate_id = $2
+`
+
+type DeleteEstimateLineItemsParams struct {
+	TenantID   uuid.UUID `json:"tenant_id"`
+	EstimateID uuid.UUID `json:"estimate_id"`
+}
+
+func (q *Queries) DeleteEstimateLineItems(ctx context.Context, arg DeleteEstimateLineItemsParams) error {
+	_, err := q.db.Exec(ctx, deleteEstimateLineItems, arg.TenantID, arg.EstimateID)
+	return err
+}
+
+const deleteExpiredOIDCLoginStates = `-- name: DeleteExpiredOIDCLoginStates :exec
+DELETE FROM oidc_login_states
+WHERE expires_at < NOW() - INTERVAL '1 day'
+`
+
+func (q *Queries) DeleteExpiredOIDCLoginStates(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, deleteExpiredOIDCLoginStates)
+	return err
+}
+
+const deleteInventoryItem = `-- name: DeleteInventoryItem :execrows
+DELETE FROM inventory_items
+WHERE id = $1
+  AND tenant_id = $2
+`
+
+type DeleteInventoryItemParams struct {
+	ID       uuid.UUID `json:"id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+}
+
+func (q *Queries) DeleteInventoryItem(ctx context.Context, arg DeleteInventoryItemParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteInventoryItem, arg.ID, arg.TenantID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteRecoveryCodes = `-- name: DeleteRecoveryCodes :exec
+DELETE FROM user_mfa_recovery_codes
+WHERE user_id = $1
+`
+
+func (q *Queries) DeleteRecoveryCodes(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteRecoveryCodes, userID)
+	return err
+}
+
+const deleteRole = `-- name: DeleteRole :execrows
+DELETE FROM roles
+WHERE id = $1
+  AND tenant_id = $2
+`
+
+type DeleteRoleParams struct {
+	ID       uuid.UUID `json:"id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+}
+
+func (q *Queries) DeleteRole(ctx context.Context, arg DeleteRoleParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteRole, arg.ID, arg.TenantID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteSSOGroupRoleMappings = `-- name: DeleteSSOGroupRoleMappings :exec
+DELETE FROM sso_group_role_mappings
+WHERE tenant_id = $1
+`
+
+func (q *Queries) DeleteSSOGroupRoleMappings(ctx context.Context, tenantID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteSSOGroupRoleMappings, tenantID)
+	return err
+}
+
+const deleteTariffAccessorials = `-- name: DeleteTariffAccessorials :exec
+DELETE FROM tariff_accessorials
+WHERE tenant_id = $1
+`
+
+func (q *Queries) DeleteTariffAccessorials(ctx context.Context, tenantID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteTariffAccessorials, tenantID)
+	return err
+}
+
+const deleteTariffCrewRates = `-- name: DeleteTariffCrewRates :exec
+DELETE FROM tariff_crew_rates
+WHERE tenant_id = $1
+`
+
+func (q *Queries) DeleteTariffCrewRates(ctx context.Context, tenantID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteTariffCrewRates, tenantID)
+	return err
+}
+
+const deleteTariffValuationOptions = `-- name: DeleteTariffValuationOptions :exec
+DELETE FROM tariff_valuation_options
+WHERE tenant_id = $1
+`
+
+func (q *Queries) DeleteTariffValuationOptions(ctx context.Context, tenantID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteTariffValuationOptions, tenantID)
+	return err
+}
+
+const deleteUnusedPasswordResetTokens = `-- name: DeleteUnusedPasswordResetTokens :exec
+DELETE FROM password_reset_tokens
+WHERE user_id = $1
+  AND tenant_id = $2
+  AND used_at IS NULL
+`
+
+type DeleteUnusedPasswordResetTokensParams struct {
+	UserID   uuid.UUID `json:"user_id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+}
+
+func (q *Queries) DeleteUnusedPasswordResetTokens(ctx context.Context, arg DeleteUnusedPasswordResetTokensParams) error {
+	_, err := q.db.Exec(ctx, deleteUnusedPasswordResetTokens, arg.UserID, arg.TenantID)
+	return err
+}
+
+const enqueueEmail = `-- name: EnqueueEmail :one
+INSERT INTO email_outbox (
+  tenant_id,
+  kind,
+  estimate_id,
+  job_id,
+  storage_record_id,
+  to_address,
+  from_address,
+  from_name,
+  reply_to,
+  subject,
+  body,
+  created_by
+) VALUES (
+  $1,
+  $2,
+  $3,
+  $4,
+  $5,
+  $6,
+  $7,
+  $8,
+  $9,
+  $10,
+  $11,
+  $12
+)
+RETURNING id, tenant_id, kind, estimate_id, job_id, storage_record_id, to_address, from_address, from_name, reply_to, subject, body, status, attempts, next_attempt_at, last_error, sent_at, created_by, created_at, updated_at
+`
+
+type EnqueueEmailParams struct {
+	TenantID        uuid.UUID  `json:"tenant_id"`
+	Kind            string     `json:"kind"`
+	EstimateID      *uuid.UUID `json:"estimate_id"`
+	JobID           *uuid.UUID `json:"job_id"`
+	StorageRecordID *uuid.UUID `json:"storage_record_id"`
+	ToAddress       string     `json:"to_address"`
+	FromAddress     string     `json:"from_address"`
+	FromName        *string    `json:"from_name"`
+	ReplyTo         *string    `json:"reply_to"`
+	Subject         string     `json:"subject"`
+	Body            string     `json:"body"`
+	CreatedBy       *uuid.UUID `json:"created_by"`
+}
+
+func (q *Queries) EnqueueEmail(ctx context.Context, arg EnqueueEmailParams) (EmailOutbox, error) {
+	row := q.db.QueryRow(ctx, enqueueEmail,
+		arg.TenantID,
+		arg.Kind,
+		arg.EstimateID,
+		arg.JobID,
+		arg.StorageRecordID,
+		arg.ToAddress,
+		arg.FromAddress,
+		arg.FromName,
+		arg.ReplyTo,
+		arg.Subject,
+		arg.Body,
+		arg.CreatedBy,
+	)
+	var i EmailOutbox
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.Kind,
+		&i.EstimateID,
+		&i.JobID,
+		&i.StorageRecordID,
+		&i.ToAddress,
+		&i.FromAddress,
+		&i.FromName,
+		&i.ReplyTo,
+		&i.Subject,
+		&i.Body,
+		&i.Status,
+		&i.Attempts,
+		&i.NextAttemptAt,
+		&i.LastError,
+		&i.SentAt,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const expireSentEstimates = `-- name: ExpireSentEstimates :many
+UPDATE estimates
+SET
+  status = 'expired',
+  status_changed_at = NOW(),
+  updated_at = NOW()
+WHERE tenant_id = $1
+  AND status = 'sent'
+  AND expires_at <= NOW()
+RETURNING id
+`
+
+func (q *Queries) ExpireSentEstimates(ctx context.Context, tenantID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, expireSentEstimates, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const exportCustomersRows = `-- name: ExportCustomersRows :many
+SELECT
+  id,
+  first_name,
+  last_name,
+  email,
+  phone,
+  created_at,
+  updated_at
+FROM customers
+WHERE tenant_id = $1
+ORDER BY created_at ASC
+`
+
+type ExportCustomersRowsRow struct {
+	ID        uuid.UUID `json:"id"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+	Email     *string   `json:"email"`
+	Phone     *string   `json:"phone"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (q *Queries) ExportCustomersRows(ctx context.Context, tenantID uuid.UUID) ([]ExportCustomersRowsRow, error) {
+	rows, err := q.db.Query(ctx, exportCustomersRows, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ExportCustomersRowsRow{}
+	for rows.Next() {
+		var i ExportCustomersRowsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FirstName,
+			&i.LastName,
+			&i.Email,
+			&i.Phone,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const exportEstimatesRows = `-- name: ExportEstimatesRows :many
+SELECT
+  id,
+  estimate_number,
+  customer_name,
+  email,
+  primary_phone,
+  secondary_phone,
+  status,
+  origin_city,
+  origin_state,
+  origin_postal_code,
+  destination_city,
+  destination_state,
+  destination_postal_code,
+  move_date,
+  pickup_time,
+  lead_source,
+  estimated_total_cents,
+  deposit_cents,
+  notes,
+  created_at,
+  updated_at
+FROM estimates
+WHERE tenant_id = $1
+ORDER BY created_at ASC
+`
+
+type ExportEstimatesRowsRow struct {
+	ID                    uuid.UUID `json:"id"`
+	EstimateNumber        string    `json:"estimate_number"`
+	CustomerName          string    `json:"customer_name"`
+	Email                 string    `json:"email"`
+	PrimaryPhone          string    `json:"primary_phone"`
+	SecondaryPhone        *string   `json:"secondary_phone"`
+	Status                string    `json:"status"`
+	OriginCity            string    `json:"origin_city"`
+	OriginState           string    `json:"origin_state"`
+	OriginPostalCode      string    `json:"origin_postal_code"`
+	DestinationCity       string    `json:"destination_city"`
+	DestinationState      string    `json:"destination_state"`
+	DestinationPostalCode string    `json:"destination_postal_code"`
+	MoveDate              time.Time `json:"move_date"`
+	PickupTime            *string   `json:"pickup_time"`
+	LeadSource            string    `json:"lead_source"`
+	EstimatedTotalCents   *int64    `json:"estimated_total_cents"`
+	DepositCents          *int64    `json:"deposit_cents"`
+	Notes                 *string   `json:"notes"`
+	CreatedAt             time.Time `json:"created_at"`
+	UpdatedAt             time.Time `json:"updated_at"`
+}
+
+func (q *Queries) ExportEstimatesRows(ctx context.Context, tenantID uuid.UUID) ([]ExportEstimatesRowsRow, error) {
+	rows, err := q.db.Query(ctx, exportEstimatesRows, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ExportEstimatesRowsRow{}
+	for rows.Next() {
 		var i ExportEstimatesRowsRow
 		if err := rows.Scan(
 			&i.ID,
@@ -1046,7 +2115,122 @@ func (q *Queries) FindCustomerByPhone(ctx context.Context, arg FindCustomerByPho
 	return i, err
 }
 
-const getCustomerByID = `-- name: GetCustomerByID :one
+const findCustomersByPhoneDigits = `-- name: FindCustomersByPhoneDigits :many
+SELECT
+  id,
+  tenant_id,
+  first_name,
+  last_name,
+  email,
+  phone,
+  created_by,
+  updated_by,
+  created_at,
+  updated_at
+FROM customers
+WHERE tenant_id = $1
+  AND regexp_replace(COALESCE(phone, ''), '[^0-9]', '', 'g') = $2::text
+ORDER BY created_at ASC, id ASC
+LIMIT 5
+`
+
+type FindCustomersByPhoneDigitsParams struct {
+	TenantID    uuid.UUID `json:"tenant_id"`
+	PhoneDigits string    `json:"phone_digits"`
+}
+
+func (q *Queries) FindCustomersByPhoneDigits(ctx context.Context, arg FindCustomersByPhoneDigitsParams) ([]Customer, error) {
+	rows, err := q.db.Query(ctx, findCustomersByPhoneDigits, arg.TenantID, arg.PhoneDigits)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Customer{}
+	for rows.Next() {
+		var i Customer
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.FirstName,
+			&i.LastName,
+			&i.Email,
+			&i.Phone,
+			&i.CreatedBy,
+			&i.UpdatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAPITokenPrincipalByHash = `-- name: GetAPITokenPrincipalByHash :one
+SELECT
+  at.id AS token_id,
+  at.tenant_id,
+  at.user_id,
+  at.scopes,
+  at.expires_at,
+  at.last_used_at,
+  u.email,
+  u.full_name,
+  t.slug AS tenant_slug,
+  t.name AS tenant_name,
+  (m.confirmed_at IS NOT NULL)::boolean AS mfa_enabled,
+  COALESCE(ts.require_mfa_for_sensitive_roles, FALSE)::boolean AS require_mfa_for_sensitive_roles
+FROM api_tokens at
+JOIN users u ON u.id = at.user_id
+JOIN tenants t ON t.id = at.tenant_id
+LEFT JOIN user_mfa m ON m.user_id = at.user_id
+LEFT JOIN tenant_settings ts ON ts.tenant_id = at.tenant_id
+WHERE at.token_hash = $1
+  AND at.revoked_at IS NULL
+  AND (at.expires_at IS NULL OR at.expires_at > NOW())
+  AND u.is_active = TRUE
+`
+
+type GetAPITokenPrincipalByHashRow struct {
+	TokenID                     uuid.UUID  `json:"token_id"`
+	TenantID                    uuid.UUID  `json:"tenant_id"`
+	UserID                      uuid.UUID  `json:"user_id"`
+	Scopes                      []string   `json:"scopes"`
+	ExpiresAt                   *time.Time `json:"expires_at"`
+	LastUsedAt                  *time.Time `json:"last_used_at"`
+	Email                       string     `json:"email"`
+	FullName                    string     `json:"full_name"`
+	TenantSlug                  string     `json:"tenant_slug"`
+	TenantName                  string     `json:"tenant_name"`
+	MfaEnabled                  bool       `json:"mfa_enabled"`
+	RequireMfaForSensitiveRoles bool       `json:"require_mfa_for_sensitive_roles"`
+}
+
+func (q *Queries) GetAPITokenPrincipalByHash(ctx context.Context, tokenHash string) (GetAPITokenPrincipalByHashRow, error) {
+	row := q.db.QueryRow(ctx, getAPITokenPrincipalByHash, tokenHash)
+	var i GetAPITokenPrincipalByHashRow
+	err := row.Scan(
+		&i.TokenID,
+		&i.TenantID,
+		&i.UserID,
+		&i.Scopes,
+		&i.ExpiresAt,
+		&i.LastUsedAt,
+		&i.Email,
+		&i.FullName,
+		&i.TenantSlug,
+		&i.TenantName,
+		&i.MfaEnabled,
+		&i.RequireMfaForSensitiveRoles,
+	)
+	return i, err
+}
+
+const getCustomerByID = `-- name: GetCustomerByID :one
 SELECT
   id,
   tenant_id,
@@ -1086,6 +2270,126 @@ func (q *Queries) GetCustomerByID(ctx context.Context, arg GetCustomerByIDParams
 	return i, err
 }
 
+const getCustomerByIDForUpdate = `-- name: GetCustomerByIDForUpdate :one
+SELECT
+  id,
+  tenant_id,
+  first_name,
+  last_name,
+  email,
+  phone,
+  created_by,
+  updated_by,
+  created_at,
+  updated_at
+FROM customers
+WHERE id = $1
+  AND tenant_id = $2
+FOR UPDATE
+`
+
+type GetCustomerByIDForUpdateParams struct {
+	ID       uuid.UUID `json:"id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+}
+
+func (q *Queries) GetCustomerByIDForUpdate(ctx context.Context, arg GetCustomerByIDForUpdateParams) (Customer, error) {
+	row := q.db.QueryRow(ctx, getCustomerByIDForUpdate, arg.ID, arg.TenantID)
+	var i Customer
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.FirstName,
+		&i.LastName,
+		&i.Email,
+		&i.Phone,
+		&i.CreatedBy,
+		&i.UpdatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getEmailTemplate = `-- name: GetEmailTemplate :one
+SELECT kind, subject, body, updated_at
+FROM email_templates
+WHERE tenant_id = $1
+  AND kind = $2
+`
+
+type GetEmailTemplateParams struct {
+	TenantID uuid.UUID `json:"tenant_id"`
+	Kind     string    `json:"kind"`
+}
+
+type GetEmailTemplateRow struct {
+	Kind      string    `json:"kind"`
+	Subject   string    `json:"subject"`
+	Body      string    `json:"body"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (q *Queries) GetEmailTemplate(ctx context.Context, arg GetEmailTemplateParams) (GetEmailTemplateRow, error) {
+	row := q.db.QueryRow(ctx, getEmailTemplate, arg.TenantID, arg.Kind)
+	var i GetEmailTemplateRow
+	err := row.Scan(
+		&i.Kind,
+		&i.Subject,
+		&i.Body,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getEnabledSSOProviderBySlug = `-- name: GetEnabledSSOProviderBySlug :one
+SELECT
+  p.tenant_id,
+  p.issuer,
+  p.client_id
+FROM tenant_sso_providers p
+JOIN tenants t ON t.id = p.tenant_id
+WHERE lower(t.slug) = lower($1)
+  AND p.enabled = TRUE
+`
+
+type GetEnabledSSOProviderBySlugRow struct {
+	TenantID uuid.UUID `json:"tenant_id"`
+	Issuer   string    `json:"issuer"`
+	ClientID string    `json:"client_id"`
+}
+
+func (q *Queries) GetEnabledSSOProviderBySlug(ctx context.Context, slug string) (GetEnabledSSOProviderBySlugRow, error) {
+	row := q.db.QueryRow(ctx, getEnabledSSOProviderBySlug, slug)
+	var i GetEnabledSSOProviderBySlugRow
+	err := row.Scan(&i.TenantID, &i.Issuer, &i.ClientID)
+	return i, err
+}
+
+const getEstimateAcceptanceLink = `-- name: GetEstimateAcceptanceLink :one
+SELECT id, tenant_id, estimate_id, auto_convert, expires_at, revoked_at, accepted_at, first_viewed_at, created_by, created_at
+FROM estimate_acceptance_links
+WHERE id = $1
+`
+
+func (q *Queries) GetEstimateAcceptanceLink(ctx context.Context, id uuid.UUID) (EstimateAcceptanceLink, error) {
+	row := q.db.QueryRow(ctx, getEstimateAcceptanceLink, id)
+	var i EstimateAcceptanceLink
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.EstimateID,
+		&i.AutoConvert,
+		&i.ExpiresAt,
+		&i.RevokedAt,
+		&i.AcceptedAt,
+		&i.FirstViewedAt,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const getEstimateByID = `-- name: GetEstimateByID :one
 SELECT
   id,
@@ -1112,7 +2416,16 @@ SELECT
   location_type,
   estimated_total_cents,
   deposit_cents,
+  total_cubic_feet,
+  total_weight_lbs,
+  price_source,
+  price_breakdown,
+  priced_at,
   notes,
+  lost_reason,
+  sent_at,
+  expires_at,
+  status_changed_at,
   idempotency_key,
   idempotency_payload_hash,
   created_by,
@@ -1157,7 +2470,16 @@ func (q *Queries) GetEstimateByID(ctx context.Context, arg GetEstimateByIDParams
 		&i.LocationType,
 		&i.EstimatedTotalCents,
 		&i.DepositCents,
+		&i.TotalCubicFeet,
+		&i.TotalWeightLbs,
+		&i.PriceSource,
+		&i.PriceBreakdown,
+		&i.PricedAt,
 		&i.Notes,
+		&i.LostReason,
+		&i.SentAt,
+		&i.ExpiresAt,
+		&i.StatusChangedAt,
 		&i.IdempotencyKey,
 		&i.IdempotencyPayloadHash,
 		&i.CreatedBy,
@@ -1194,7 +2516,16 @@ SELECT
   location_type,
   estimated_total_cents,
   deposit_cents,
+  total_cubic_feet,
+  total_weight_lbs,
+  price_source,
+  price_breakdown,
+  priced_at,
   notes,
+  lost_reason,
+  sent_at,
+  expires_at,
+  status_changed_at,
   idempotency_key,
   idempotency_payload_hash,
   created_by,
@@ -1239,7 +2570,16 @@ func (q *Queries) GetEstimateByIdempotencyKey(ctx context.Context, arg GetEstima
 		&i.LocationType,
 		&i.EstimatedTotalCents,
 		&i.DepositCents,
+		&i.TotalCubicFeet,
+		&i.TotalWeightLbs,
+		&i.PriceSource,
+		&i.PriceBreakdown,
+		&i.PricedAt,
 		&i.Notes,
+		&i.LostReason,
+		&i.SentAt,
+		&i.ExpiresAt,
+		&i.StatusChangedAt,
 		&i.IdempotencyKey,
 		&i.IdempotencyPayloadHash,
 		&i.CreatedBy,
@@ -1276,7 +2616,16 @@ SELECT
   location_type,
   estimated_total_cents,
   deposit_cents,
+  total_cubic_feet,
+  total_weight_lbs,
+  price_source,
+  price_breakdown,
+  priced_at,
   notes,
+  lost_reason,
+  sent_at,
+  expires_at,
+  status_changed_at,
   idempotency_key,
   idempotency_payload_hash,
   created_by,
@@ -1321,7 +2670,16 @@ func (q *Queries) GetEstimateByNumber(ctx context.Context, arg GetEstimateByNumb
 		&i.LocationType,
 		&i.EstimatedTotalCents,
 		&i.DepositCents,
+		&i.TotalCubicFeet,
+		&i.TotalWeightLbs,
+		&i.PriceSource,
+		&i.PriceBreakdown,
+		&i.PricedAt,
 		&i.Notes,
+		&i.LostReason,
+		&i.SentAt,
+		&i.ExpiresAt,
+		&i.StatusChangedAt,
 		&i.IdempotencyKey,
 		&i.IdempotencyPayloadHash,
 		&i.CreatedBy,
@@ -1358,7 +2716,16 @@ SELECT
   e.location_type,
   e.estimated_total_cents,
   e.deposit_cents,
+  e.total_cubic_feet,
+  e.total_weight_lbs,
+  e.price_source,
+  e.price_breakdown,
+  e.priced_at,
   e.notes,
+  e.lost_reason,
+  e.sent_at,
+  e.expires_at,
+  e.status_changed_at,
   e.idempotency_key,
   e.idempotency_payload_hash,
   e.created_by,
@@ -1404,7 +2771,16 @@ type GetEstimateDetailByIDRow struct {
 	LocationType            *string    `json:"location_type"`
 	EstimatedTotalCents     *int64     `json:"estimated_total_cents"`
 	DepositCents            *int64     `json:"deposit_cents"`
+	TotalCubicFeet          int64      `json:"total_cubic_feet"`
+	TotalWeightLbs          int64      `json:"total_weight_lbs"`
+	PriceSource             *string    `json:"price_source"`
+	PriceBreakdown          []byte     `json:"price_breakdown"`
+	PricedAt                *time.Time `json:"priced_at"`
 	Notes                   *string    `json:"notes"`
+	LostReason              *string    `json:"lost_reason"`
+	SentAt                  *time.Time `json:"sent_at"`
+	ExpiresAt               *time.Time `json:"expires_at"`
+	StatusChangedAt         time.Time  `json:"status_changed_at"`
 	IdempotencyKey          *string    `json:"idempotency_key"`
 	IdempotencyPayloadHash  *string    `json:"idempotency_payload_hash"`
 	CreatedBy               *uuid.UUID `json:"created_by"`
@@ -1442,7 +2818,16 @@ func (q *Queries) GetEstimateDetailByID(ctx context.Context, arg GetEstimateDeta
 		&i.LocationType,
 		&i.EstimatedTotalCents,
 		&i.DepositCents,
+		&i.TotalCubicFeet,
+		&i.TotalWeightLbs,
+		&i.PriceSource,
+		&i.PriceBreakdown,
+		&i.PricedAt,
 		&i.Notes,
+		&i.LostReason,
+		&i.SentAt,
+		&i.ExpiresAt,
+		&i.StatusChangedAt,
 		&i.IdempotencyKey,
 		&i.IdempotencyPayloadHash,
 		&i.CreatedBy,
@@ -1454,6 +2839,166 @@ func (q *Queries) GetEstimateDetailByID(ctx context.Context, arg GetEstimateDeta
 	return i, err
 }
 
+const getEstimateDocumentTemplate = `-- name: GetEstimateDocumentTemplate :one
+SELECT
+  t.id AS tenant_id,
+  t.name AS tenant_name,
+  dt.company_name,
+  COALESCE(dt.accent_color, '#1f4e79')::text AS accent_color,
+  dt.logo,
+  dt.logo_content_type,
+  dt.intro_text,
+  dt.terms_text,
+  dt.footer_text,
+  COALESCE(dt.show_line_items, TRUE)::boolean AS show_line_items,
+  dt.updated_at
+FROM tenants t
+LEFT JOIN estimate_document_templates dt ON dt.tenant_id = t.id
+WHERE t.id = $1
+`
+
+type GetEstimateDocumentTemplateRow struct {
+	TenantID        uuid.UUID  `json:"tenant_id"`
+	TenantName      string     `json:"tenant_name"`
+	CompanyName     *string    `json:"company_name"`
+	AccentColor     string     `json:"accent_color"`
+	Logo            []byte     `json:"logo"`
+	LogoContentType *string    `json:"logo_content_type"`
+	IntroText       *string    `json:"intro_text"`
+	TermsText       *string    `json:"terms_text"`
+	FooterText      *string    `json:"footer_text"`
+	ShowLineItems   bool       `json:"show_line_items"`
+	UpdatedAt       *time.Time `json:"updated_at"`
+}
+
+func (q *Queries) GetEstimateDocumentTemplate(ctx context.Context, tenantID uuid.UUID) (GetEstimateDocumentTemplateRow, error) {
+	row := q.db.QueryRow(ctx, getEstimateDocumentTemplate, tenantID)
+	var i GetEstimateDocumentTemplateRow
+	err := row.Scan(
+		&i.TenantID,
+		&i.TenantName,
+		&i.CompanyName,
+		&i.AccentColor,
+		&i.Logo,
+		&i.LogoContentType,
+		&i.IntroText,
+		&i.TermsText,
+		&i.FooterText,
+		&i.ShowLineItems,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getEstimateLineItem = `-- name: GetEstimateLineItem :one
+SELECT id, tenant_id, estimate_id, inventory_item_id, room, item_name, quantity, cubic_feet, weight_lbs, created_by, updated_by, created_at, updated_at
+FROM estimate_line_items
+WHERE id = $1
+  AND tenant_id = $2
+  AND estimate_id = $3
+`
+
+type GetEstimateLineItemParams struct {
+	ID         uuid.UUID `json:"id"`
+	TenantID   uuid.UUID `json:"tenant_id"`
+	EstimateID uuid.UUID `json:"estimate_id"`
+}
+
+func (q *Queries) GetEstimateLineItem(ctx context.Context, arg GetEstimateLineItemParams) (EstimateLineItem, error) {
+	row := q.db.QueryRow(ctx, getEstimateLineItem, arg.ID, arg.TenantID, arg.EstimateID)
+	var i EstimateLineItem
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.EstimateID,
+		&i.InventoryItemID,
+		&i.Room,
+		&i.ItemName,
+		&i.Quantity,
+		&i.CubicFeet,
+		&i.WeightLbs,
+		&i.CreatedBy,
+		&i.UpdatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getEstimateRevision = `-- name: GetEstimateRevision :one
+SELECT
+  r.revision_number,
+  r.change,
+  r.restored_from,
+  r.created_by,
+  u.full_name AS created_by_name,
+  r.created_at,
+  r.snapshot
+FROM estimate_revisions r
+LEFT JOIN users u ON u.id = r.created_by
+WHERE r.tenant_id = $1
+  AND r.estimate_id = $2
+  AND r.revision_number = $3
+`
+
+type GetEstimateRevisionParams struct {
+	TenantID       uuid.UUID `json:"tenant_id"`
+	EstimateID     uuid.UUID `json:"estimate_id"`
+	RevisionNumber int32     `json:"revision_number"`
+}
+
+type GetEstimateRevisionRow struct {
+	RevisionNumber int32      `json:"revision_number"`
+	Change         string     `json:"change"`
+	RestoredFrom   *int32     `json:"restored_from"`
+	CreatedBy      *uuid.UUID `json:"created_by"`
+	CreatedByName  *string    `json:"created_by_name"`
+	CreatedAt      time.Time  `json:"created_at"`
+	Snapshot       []byte     `json:"snapshot"`
+}
+
+func (q *Queries) GetEstimateRevision(ctx context.Context, arg GetEstimateRevisionParams) (GetEstimateRevisionRow, error) {
+	row := q.db.QueryRow(ctx, getEstimateRevision, arg.TenantID, arg.EstimateID, arg.RevisionNumber)
+	var i GetEstimateRevisionRow
+	err := row.Scan(
+		&i.RevisionNumber,
+		&i.Change,
+		&i.RestoredFrom,
+		&i.CreatedBy,
+		&i.CreatedByName,
+		&i.CreatedAt,
+		&i.Snapshot,
+	)
+	return i, err
+}
+
+const getEstimateSignatureByLink = `-- name: GetEstimateSignatureByLink :one
+SELECT id, tenant_id, estimate_id, link_id, revision_number, document_sha256, signer_name, signer_ip, user_agent, signed_at, signature
+FROM estimate_signatures
+WHERE link_id = $1
+ORDER BY signed_at DESC
+LIMIT 1
+`
+
+func (q *Queries) GetEstimateSignatureByLink(ctx context.Context, linkID *uuid.UUID) (EstimateSignature, error) {
+	row := q.db.QueryRow(ctx, getEstimateSignatureByLink, linkID)
+	var i EstimateSignature
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.EstimateID,
+		&i.LinkID,
+		&i.RevisionNumber,
+		&i.DocumentSha256,
+		&i.SignerName,
+		&i.SignerIp,
+		&i.UserAgent,
+		&i.SignedAt,
+		&i.Signature,
+	)
+	return i, err
+}
+
 const getImportIdempotency = `-- name: GetImportIdempotency :one
 SELECT
   tenant_id,
@@ -1532,6 +3077,37 @@ func (q *Queries) GetImportRunByID(ctx context.Context, arg GetImportRunByIDPara
 	return i, err
 }
 
+const getInventoryItem = `-- name: GetInventoryItem :one
+SELECT id, tenant_id, name, default_room, cubic_feet, weight_lbs, active, created_by, updated_by, created_at, updated_at
+FROM inventory_items
+WHERE id = $1
+  AND tenant_id = $2
+`
+
+type GetInventoryItemParams struct {
+	ID       uuid.UUID `json:"id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+}
+
+func (q *Queries) GetInventoryItem(ctx context.Context, arg GetInventoryItemParams) (InventoryItem, error) {
+	row := q.db.QueryRow(ctx, getInventoryItem, arg.ID, arg.TenantID)
+	var i InventoryItem
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.Name,
+		&i.DefaultRoom,
+		&i.CubicFeet,
+		&i.WeightLbs,
+		&i.Active,
+		&i.CreatedBy,
+		&i.UpdatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const getJobByConvertIdempotencyKey = `-- name: GetJobByConvertIdempotencyKey :one
 SELECT
   id,
@@ -1543,6 +3119,8 @@ SELECT
   scheduled_date,
   pickup_time,
   convert_idempotency_key,
+  balance_cents,
+  last_payment_at,
   created_by,
   updated_by,
   created_at,
@@ -1570,6 +3148,8 @@ func (q *Queries) GetJobByConvertIdempotencyKey(ctx context.Context, arg GetJobB
 		&i.ScheduledDate,
 		&i.PickupTime,
 		&i.ConvertIdempotencyKey,
+		&i.BalanceCents,
+		&i.LastPaymentAt,
 		&i.CreatedBy,
 		&i.UpdatedBy,
 		&i.CreatedAt,
@@ -1589,6 +3169,8 @@ SELECT
   scheduled_date,
   pickup_time,
   convert_idempotency_key,
+  balance_cents,
+  last_payment_at,
   created_by,
   updated_by,
   created_at,
@@ -1616,6 +3198,8 @@ func (q *Queries) GetJobByEstimateID(ctx context.Context, arg GetJobByEstimateID
 		&i.ScheduledDate,
 		&i.PickupTime,
 		&i.ConvertIdempotencyKey,
+		&i.BalanceCents,
+		&i.LastPaymentAt,
 		&i.CreatedBy,
 		&i.UpdatedBy,
 		&i.CreatedAt,
@@ -1635,6 +3219,8 @@ SELECT
   scheduled_date,
   pickup_time,
   convert_idempotency_key,
+  balance_cents,
+  last_payment_at,
   created_by,
   updated_by,
   created_at,
@@ -1662,6 +3248,8 @@ func (q *Queries) GetJobByID(ctx context.Context, arg GetJobByIDParams) (Job, er
 		&i.ScheduledDate,
 		&i.PickupTime,
 		&i.ConvertIdempotencyKey,
+		&i.BalanceCents,
+		&i.LastPaymentAt,
 		&i.CreatedBy,
 		&i.UpdatedBy,
 		&i.CreatedAt,
@@ -1670,7 +3258,7 @@ func (q *Queries) GetJobByID(ctx context.Context, arg GetJobByIDParams) (Job, er
 	return i, err
 }
 
-const getJobByJobNumber = `-- name: GetJobByJobNumber :one
+const getJobByIDForUpdate = `-- name: GetJobByIDForUpdate :one
 SELECT
   id,
   tenant_id,
@@ -1681,22 +3269,25 @@ SELECT
   scheduled_date,
   pickup_time,
   convert_idempotency_key,
+  balance_cents,
+  last_payment_at,
   created_by,
   updated_by,
   created_at,
   updated_at
 FROM jobs
-WHERE tenant_id = $1
-  AND job_number = $2
+WHERE id = $1
+  AND tenant_id = $2
+FOR UPDATE
 `
 
-type GetJobByJobNumberParams struct {
-	TenantID  uuid.UUID `json:"tenant_id"`
-	JobNumber string    `json:"job_number"`
+type GetJobByIDForUpdateParams struct {
+	ID       uuid.UUID `json:"id"`
+	TenantID uuid.UUID `json:"tenant_id"`
 }
 
-func (q *Queries) GetJobByJobNumber(ctx context.Context, arg GetJobByJobNumberParams) (Job, error) {
-	row := q.db.QueryRow(ctx, getJobByJobNumber, arg.TenantID, arg.JobNumber)
+func (q *Queries) GetJobByIDForUpdate(ctx context.Context, arg GetJobByIDForUpdateParams) (Job, error) {
+	row := q.db.QueryRow(ctx, getJobByIDForUpdate, arg.ID, arg.TenantID)
 	var i Job
 	err := row.Scan(
 		&i.ID,
@@ -1708,6 +3299,8 @@ func (q *Queries) GetJobByJobNumber(ctx context.Context, arg GetJobByJobNumberPa
 		&i.ScheduledDate,
 		&i.PickupTime,
 		&i.ConvertIdempotencyKey,
+		&i.BalanceCents,
+		&i.LastPaymentAt,
 		&i.CreatedBy,
 		&i.UpdatedBy,
 		&i.CreatedAt,
@@ -1716,21 +3309,73 @@ func (q *Queries) GetJobByJobNumber(ctx context.Context, arg GetJobByJobNumberPa
 	return i, err
 }
 
-const getJobDetailByID = `-- name: GetJobDetailByID :one
+const getJobByJobNumber = `-- name: GetJobByJobNumber :one
 SELECT
-  j.id,
-  j.tenant_id,
-  j.job_number,
-  j.estimate_id,
-  j.customer_id,
-  j.status,
-  j.scheduled_date,
-  j.pickup_time,
-  j.convert_idempotency_key,
-  j.created_by,
-  j.updated_by,
-  j.created_at,
-  j.updated_at,
+  id,
+  tenant_id,
+  job_number,
+  estimate_id,
+  customer_id,
+  status,
+  scheduled_date,
+  pickup_time,
+  convert_idempotency_key,
+  balance_cents,
+  last_payment_at,
+  created_by,
+  updated_by,
+  created_at,
+  updated_at
+FROM jobs
+WHERE tenant_id = $1
+  AND job_number = $2
+`
+
+type GetJobByJobNumberParams struct {
+	TenantID  uuid.UUID `json:"tenant_id"`
+	JobNumber string    `json:"job_number"`
+}
+
+func (q *Queries) GetJobByJobNumber(ctx context.Context, arg GetJobByJobNumberParams) (Job, error) {
+	row := q.db.QueryRow(ctx, getJobByJobNumber, arg.TenantID, arg.JobNumber)
+	var i Job
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.JobNumber,
+		&i.EstimateID,
+		&i.CustomerID,
+		&i.Status,
+		&i.ScheduledDate,
+		&i.PickupTime,
+		&i.ConvertIdempotencyKey,
+		&i.BalanceCents,
+		&i.LastPaymentAt,
+		&i.CreatedBy,
+		&i.UpdatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getJobDetailByID = `-- name: GetJobDetailByID :one
+SELECT
+  j.id,
+  j.tenant_id,
+  j.job_number,
+  j.estimate_id,
+  j.customer_id,
+  j.status,
+  j.scheduled_date,
+  j.pickup_time,
+  j.convert_idempotency_key,
+  j.balance_cents,
+  j.last_payment_at,
+  j.created_by,
+  j.updated_by,
+  j.created_at,
+  j.updated_at,
   c.first_name,
   c.last_name,
   COALESCE(c.phone, e.primary_phone, '') AS phone,
@@ -1761,6 +3406,8 @@ type GetJobDetailByIDRow struct {
 	ScheduledDate         *time.Time `json:"scheduled_date"`
 	PickupTime            *string    `json:"pickup_time"`
 	ConvertIdempotencyKey *string    `json:"convert_idempotency_key"`
+	BalanceCents          int64      `json:"balance_cents"`
+	LastPaymentAt         *time.Time `json:"last_payment_at"`
 	CreatedBy             *uuid.UUID `json:"created_by"`
 	UpdatedBy             *uuid.UUID `json:"updated_by"`
 	CreatedAt             time.Time  `json:"created_at"`
@@ -1784,6 +3431,8 @@ func (q *Queries) GetJobDetailByID(ctx context.Context, arg GetJobDetailByIDPara
 		&i.ScheduledDate,
 		&i.PickupTime,
 		&i.ConvertIdempotencyKey,
+		&i.BalanceCents,
+		&i.LastPaymentAt,
 		&i.CreatedBy,
 		&i.UpdatedBy,
 		&i.CreatedAt,
@@ -1796,651 +3445,2615 @@ func (q *Queries) GetJobDetailByID(ctx context.Context, arg GetJobDetailByIDPara
 	return i, err
 }
 
-const getSessionPrincipalByTokenHash = `-- name: GetSessionPrincipalByTokenHash :one
+const getJobEmailContext = `-- name: GetJobEmailContext :one
 SELECT
-  s.id AS session_id,
-  s.tenant_id,
-  s.user_id,
-  s.csrf_token,
-  s.expires_at,
-  u.email,
-  u.full_name,
-  t.slug AS tenant_slug,
-  t.name AS tenant_name
-FROM sessions s
-JOIN users u ON u.id = s.user_id
-JOIN tenants t ON t.id = s.tenant_id
-WHERE s.token_hash = $1
-  AND s.revoked_at IS NULL
-  AND s.expires_at > NOW()
-  AND u.is_active = TRUE
+  j.id,
+  j.job_number,
+  j.scheduled_date,
+  j.pickup_time,
+  COALESCE(NULLIF(TRIM(c.first_name || ' ' || c.last_name), ''), e.customer_name, '')::text AS customer_name,
+  COALESCE(NULLIF(TRIM(c.email), ''), e.email, '')::text AS email,
+  e.estimate_number,
+  e.origin_city,
+  e.origin_state,
+  e.destination_city,
+  e.destination_state
+FROM jobs j
+JOIN customers c
+  ON c.id = j.customer_id
+  AND c.tenant_id = j.tenant_id
+LEFT JOIN estimates e
+  ON e.id = j.estimate_id
+  AND e.tenant_id = j.tenant_id
+WHERE j.id = $1
+  AND j.tenant_id = $2
 `
 
-type GetSessionPrincipalByTokenHashRow struct {
-	SessionID  uuid.UUID `json:"session_id"`
-	TenantID   uuid.UUID `json:"tenant_id"`
-	UserID     uuid.UUID `json:"user_id"`
-	CsrfToken  string    `json:"csrf_token"`
-	ExpiresAt  time.Time `json:"expires_at"`
-	Email      string    `json:"email"`
-	FullName   string    `json:"full_name"`
-	TenantSlug string    `json:"tenant_slug"`
-	TenantName string    `json:"tenant_name"`
+type GetJobEmailContextParams struct {
+	ID       uuid.UUID `json:"id"`
+	TenantID uuid.UUID `json:"tenant_id"`
 }
 
-func (q *Queries) GetSessionPrincipalByTokenHash(ctx context.Context, tokenHash string) (GetSessionPrincipalByTokenHashRow, error) {
-	row := q.db.QueryRow(ctx, getSessionPrincipalByTokenHash, tokenHash)
-	var i GetSessionPrincipalByTokenHashRow
+type GetJobEmailContextRow struct {
+	ID               uuid.UUID  `json:"id"`
+	JobNumber        string     `json:"job_number"`
+	ScheduledDate    *time.Time `json:"scheduled_date"`
+	PickupTime       *string    `json:"pickup_time"`
+	CustomerName     string     `json:"customer_name"`
+	Email            string     `json:"email"`
+	EstimateNumber   *string    `json:"estimate_number"`
+	OriginCity       *string    `json:"origin_city"`
+	OriginState      *string    `json:"origin_state"`
+	DestinationCity  *string    `json:"destination_city"`
+	DestinationState *string    `json:"destination_state"`
+}
+
+func (q *Queries) GetJobEmailContext(ctx context.Context, arg GetJobEmailContextParams) (GetJobEmailContextRow, error) {
+	row := q.db.QueryRow(ctx, getJobEmailContext, arg.ID, arg.TenantID)
+	var i GetJobEmailContextRow
 	err := row.Scan(
-		&i.SessionID,
-		&i.TenantID,
-		&i.UserID,
-		&i.CsrfToken,
-		&i.ExpiresAt,
+		&i.ID,
+		&i.JobNumber,
+		&i.ScheduledDate,
+		&i.PickupTime,
+		&i.CustomerName,
 		&i.Email,
-		&i.FullName,
-		&i.TenantSlug,
-		&i.TenantName,
+		&i.EstimateNumber,
+		&i.OriginCity,
+		&i.OriginState,
+		&i.DestinationCity,
+		&i.DestinationState,
 	)
 	return i, err
 }
 
-const getStorageRecordByID = `-- name: GetStorageRecordByID :one
+const getLatestEstimateRevisionDigest = `-- name: GetLatestEstimateRevisionDigest :one
 SELECT
-  id,
-  tenant_id,
-  job_id,
-  facility,
-  status,
-  date_in,
-  date_out,
-  next_bill_date,
-  lot_number,
-  location_label,
-  vaults,
-  pads,
-  items,
-  oversize_items,
-  volume,
-  monthly_rate_cents,
-  storage_balance_cents,
-  move_balance_cents,
-  last_payment_at,
-  notes,
-  created_at,
-  updated_at
-FROM storage_record
-WHERE id = $1
-  AND tenant_id = $2
+  revision_number,
+  encode(sha256(convert_to(snapshot::text, 'UTF8')), 'hex')::text AS document_sha256
+FROM estimate_revisions
+WHERE tenant_id = $1
+  AND estimate_id = $2
+ORDER BY revision_number DESC
+LIMIT 1
 `
 
-type GetStorageRecordByIDParams struct {
-	ID       uuid.UUID `json:"id"`
-	TenantID uuid.UUID `json:"tenant_id"`
+type GetLatestEstimateRevisionDigestParams struct {
+	TenantID   uuid.UUID `json:"tenant_id"`
+	EstimateID uuid.UUID `json:"estimate_id"`
 }
 
-func (q *Queries) GetStorageRecordByID(ctx context.Context, arg GetStorageRecordByIDParams) (StorageRecord, error) {
-	row := q.db.QueryRow(ctx, getStorageRecordByID, arg.ID, arg.TenantID)
-	var i StorageRecord
-	err := row.Scan(
-		&i.ID,
-		&i.TenantID,
-		&i.JobID,
-		&i.Facility,
-		&i.Status,
-		&i.DateIn,
-		&i.DateOut,
-		&i.NextBillDate,
-		&i.LotNumber,
-		&i.LocationLabel,
-		&i.Vaults,
-		&i.Pads,
-		&i.Items,
-		&i.OversizeItems,
-		&i.Volume,
-		&i.MonthlyRateCents,
-		&i.StorageBalanceCents,
-		&i.MoveBalanceCents,
-		&i.LastPaymentAt,
-		&i.Notes,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-	)
+type GetLatestEstimateRevisionDigestRow struct {
+	RevisionNumber int32  `json:"revision_number"`
+	DocumentSha256 string `json:"document_sha256"`
+}
+
+func (q *Queries) GetLatestEstimateRevisionDigest(ctx context.Context, arg GetLatestEstimateRevisionDigestParams) (GetLatestEstimateRevisionDigestRow, error) {
+	row := q.db.QueryRow(ctx, getLatestEstimateRevisionDigest, arg.TenantID, arg.EstimateID)
+	var i GetLatestEstimateRevisionDigestRow
+	err := row.Scan(&i.RevisionNumber, &i.DocumentSha256)
 	return i, err
 }
 
-const getStorageRecordByJobID = `-- name: GetStorageRecordByJobID :one
-SELECT
-  id,
-  tenant_id,
-  job_id,
-  facility,
-  status,
-  date_in,
-  date_out,
-  next_bill_date,
-  lot_number,
-  location_label,
-  vaults,
-  pads,
-  items,
-  oversize_items,
-  volume,
-  monthly_rate_cents,
-  storage_balance_cents,
-  move_balance_cents,
-  last_payment_at,
-  notes,
-  created_at,
-  updated_at
-FROM storage_record
-WHERE job_id = $1
-  AND tenant_id = $2
+const getLedgerEntryByIdempotencyKey = `-- name: GetLedgerEntryByIdempotencyKey :one
+SELECT id, tenant_id, job_id, storage_record_id, account, kind, amount_cents, balance_effect_cents, method, reference, memo, occurred_at, idempotency_key, idempotency_payload_hash, created_by, created_at
+FROM ledger_entries
+WHERE tenant_id = $1
+  AND idempotency_key = $2
 `
 
-type GetStorageRecordByJobIDParams struct {
-	JobID    uuid.UUID `json:"job_id"`
-	TenantID uuid.UUID `json:"tenant_id"`
+type GetLedgerEntryByIdempotencyKeyParams struct {
+	TenantID       uuid.UUID `json:"tenant_id"`
+	IdempotencyKey *string   `json:"idempotency_key"`
 }
 
-func (q *Queries) GetStorageRecordByJobID(ctx context.Context, arg GetStorageRecordByJobIDParams) (StorageRecord, error) {
-	row := q.db.QueryRow(ctx, getStorageRecordByJobID, arg.JobID, arg.TenantID)
-	var i StorageRecord
+func (q *Queries) GetLedgerEntryByIdempotencyKey(ctx context.Context, arg GetLedgerEntryByIdempotencyKeyParams) (LedgerEntry, error) {
+	row := q.db.QueryRow(ctx, getLedgerEntryByIdempotencyKey, arg.TenantID, arg.IdempotencyKey)
+	var i LedgerEntry
 	err := row.Scan(
 		&i.ID,
 		&i.TenantID,
 		&i.JobID,
-		&i.Facility,
-		&i.Status,
-		&i.DateIn,
-		&i.DateOut,
-		&i.NextBillDate,
-		&i.LotNumber,
-		&i.LocationLabel,
-		&i.Vaults,
-		&i.Pads,
-		&i.Items,
-		&i.OversizeItems,
-		&i.Volume,
-		&i.MonthlyRateCents,
-		&i.StorageBalanceCents,
-		&i.MoveBalanceCents,
-		&i.LastPaymentAt,
-		&i.Notes,
+		&i.StorageRecordID,
+		&i.Account,
+		&i.Kind,
+		&i.AmountCents,
+		&i.BalanceEffectCents,
+		&i.Method,
+		&i.Reference,
+		&i.Memo,
+		&i.OccurredAt,
+		&i.IdempotencyKey,
+		&i.IdempotencyPayloadHash,
+		&i.CreatedBy,
 		&i.CreatedAt,
-		&i.UpdatedAt,
 	)
 	return i, err
 }
 
-const getStorageRecordDetailByID = `-- name: GetStorageRecordDetailByID :one
-SELECT
-  sr.id,
-  sr.tenant_id,
-  sr.job_id,
-  j.job_number,
-  COALESCE(NULLIF(TRIM(c.first_name || ' ' || c.last_name), ''), COALESCE(e.customer_name, j.job_number))::text AS customer_name,
-  CASE
-    WHEN e.id IS NULL THEN 'other'
-    WHEN NULLIF(TRIM(COALESCE(e.origin_state, '')), '') IS NULL THEN 'other'
-    WHEN NULLIF(TRIM(COALESCE(e.destination_state, '')), '') IS NULL THEN 'other'
-    WHEN UPPER(e.origin_state) = UPPER(e.destination_state) THEN 'local'
-    ELSE 'long_distance'
-  END::text AS move_type,
-  COALESCE(
-    NULLIF(CONCAT_WS(', ', NULLIF(TRIM(e.origin_city), ''), NULLIF(TRIM(e.origin_state), '')), ''),
-    'TBD'
-  )::text AS from_short,
-  COALESCE(
-    NULLIF(CONCAT_WS(', ', NULLIF(TRIM(e.destination_city), ''), NULLIF(TRIM(e.destination_state), '')), ''),
-    'TBD'
-  )::text AS to_short,
-  sr.facility,
-  sr.status,
-  sr.date_in,
-  sr.date_out,
-  sr.next_bill_date,
-  sr.lot_number,
-  sr.location_label,
-  sr.vaults,
-  sr.pads,
-  sr.items,
-  sr.oversize_items,
-  sr.volume,
-  sr.monthly_rate_cents,
-  sr.storage_balance_cents,
-  sr.move_balance_cents,
-  sr.last_payment_at,
-  sr.notes,
-  sr.created_at,
-  sr.updated_at
-FROM storage_record sr
-JOIN jobs j
-  ON j.id = sr.job_id
-  AND j.tenant_id = sr.tenant_id
-JOIN customers c
-  ON c.id = j.customer_id
-  AND c.tenant_id = j.tenant_id
-LEFT JOIN estimates e
-  ON e.id = j.estimate_id
-  AND e.tenant_id = j.tenant_id
-WHERE sr.id = $1
-  AND sr.tenant_id = $2
+const getLoginLockState = `-- name: GetLoginLockState :many
+SELECT user_id, locked_until
+FROM user_login_failures
+WHERE user_id = ANY($1::uuid[])
+  AND locked_until > NOW()
 `
 
-type GetStorageRecordDetailByIDParams struct {
-	ID       uuid.UUID `json:"id"`
+type GetLoginLockStateRow struct {
+	UserID      uuid.UUID  `json:"user_id"`
+	LockedUntil *time.Time `json:"locked_until"`
+}
+
+func (q *Queries) GetLoginLockState(ctx context.Context, userIds []uuid.UUID) ([]GetLoginLockStateRow, error) {
+	rows, err := q.db.Query(ctx, getLoginLockState, userIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetLoginLockStateRow{}
+	for rows.Next() {
+		var i GetLoginLockStateRow
+		if err := rows.Scan(&i.UserID, &i.LockedUntil); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLoginMFAState = `-- name: GetLoginMFAState :one
+SELECT
+  EXISTS (
+    SELECT 1
+    FROM user_mfa m
+    WHERE m.user_id = $1
+      AND m.confirmed_at IS NOT NULL
+  ) AS mfa_enabled,
+  COALESCE((
+    SELECT ts.require_mfa_for_sensitive_roles
+    FROM tenant_settings ts
+    WHERE ts.tenant_id = $2
+  ), FALSE)::boolean AS require_mfa_for_sensitive_roles
+`
+
+type GetLoginMFAStateParams struct {
+	UserID   uuid.UUID `json:"user_id"`
 	TenantID uuid.UUID `json:"tenant_id"`
 }
 
-type GetStorageRecordDetailByIDRow struct {
-	ID                  uuid.UUID  `json:"id"`
-	TenantID            uuid.UUID  `json:"tenant_id"`
-	JobID               uuid.UUID  `json:"job_id"`
-	JobNumber           string     `json:"job_number"`
-	CustomerName        string     `json:"customer_name"`
-	MoveType            string     `json:"move_type"`
-	FromShort           string     `json:"from_short"`
-	ToShort             string     `json:"to_short"`
-	Facility            string     `json:"facility"`
-	Status              string     `json:"status"`
-	DateIn              *time.Time `json:"date_in"`
-	DateOut             *time.Time `json:"date_out"`
-	NextBillDate        *time.Time `json:"next_bill_date"`
-	LotNumber           *string    `json:"lot_number"`
-	LocationLabel       *string    `json:"location_label"`
-	Vaults              int32      `json:"vaults"`
-	Pads                int32      `json:"pads"`
-	Items               int32      `json:"items"`
-	OversizeItems       int32      `json:"oversize_items"`
-	Volume              int32      `json:"volume"`
-	MonthlyRateCents    *int64     `json:"monthly_rate_cents"`
-	StorageBalanceCents int64      `json:"storage_balance_cents"`
-	MoveBalanceCents    int64      `json:"move_balance_cents"`
-	LastPaymentAt       *time.Time `json:"last_payment_at"`
-	Notes               *string    `json:"notes"`
-	CreatedAt           time.Time  `json:"created_at"`
-	UpdatedAt           time.Time  `json:"updated_at"`
+type GetLoginMFAStateRow struct {
+	MfaEnabled                  bool `json:"mfa_enabled"`
+	RequireMfaForSensitiveRoles bool `json:"require_mfa_for_sensitive_roles"`
 }
 
-func (q *Queries) GetStorageRecordDetailByID(ctx context.Context, arg GetStorageRecordDetailByIDParams) (GetStorageRecordDetailByIDRow, error) {
-	row := q.db.QueryRow(ctx, getStorageRecordDetailByID, arg.ID, arg.TenantID)
-	var i GetStorageRecordDetailByIDRow
-	err := row.Scan(
-		&i.ID,
-		&i.TenantID,
-		&i.JobID,
-		&i.JobNumber,
-		&i.CustomerName,
-		&i.MoveType,
-		&i.FromShort,
-		&i.ToShort,
-		&i.Facility,
-		&i.Status,
-		&i.DateIn,
-		&i.DateOut,
-		&i.NextBillDate,
-		&i.LotNumber,
-		&i.LocationLabel,
-		&i.Vaults,
-		&i.Pads,
-		&i.Items,
-		&i.OversizeItems,
-		&i.Volume,
-		&i.MonthlyRateCents,
-		&i.StorageBalanceCents,
-		&i.MoveBalanceCents,
-		&i.LastPaymentAt,
-		&i.Notes,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-	)
+func (q *Queries) GetLoginMFAState(ctx context.Context, arg GetLoginMFAStateParams) (GetLoginMFAStateRow, error) {
+	row := q.db.QueryRow(ctx, getLoginMFAState, arg.UserID, arg.TenantID)
+	var i GetLoginMFAStateRow
+	err := row.Scan(&i.MfaEnabled, &i.RequireMfaForSensitiveRoles)
 	return i, err
 }
 
-const incrementTenantCounter = `-- name: IncrementTenantCounter :one
-INSERT INTO tenant_counters (tenant_id, counter_type, next_value)
-VALUES ($1, $2, 2)
-ON CONFLICT (tenant_id, counter_type) DO UPDATE
-SET
-  next_value = tenant_counters.next_value + 1,
-  updated_at = NOW()
-RETURNING (next_value - 1)::bigint AS value
+const getPasswordResetToken = `-- name: GetPasswordResetToken :one
+SELECT
+  prt.id,
+  prt.tenant_id,
+  prt.user_id,
+  u.email,
+  u.full_name,
+  COALESCE(ts.password_min_length, 10)::int AS password_min_length,
+  COALESCE(ts.password_check_breached, TRUE)::boolean AS password_check_breached
+FROM password_reset_tokens prt
+JOIN users u ON u.id = prt.user_id
+LEFT JOIN tenant_settings ts ON ts.tenant_id = prt.tenant_id
+WHERE prt.token_hash = $1
+  AND prt.used_at IS NULL
+  AND prt.expires_at > NOW()
+  AND u.is_active = TRUE
 `
 
-type IncrementTenantCounterParams struct {
-	TenantID    uuid.UUID `json:"tenant_id"`
-	CounterType string    `json:"counter_type"`
+type GetPasswordResetTokenRow struct {
+	ID                    uuid.UUID `json:"id"`
+	TenantID              uuid.UUID `json:"tenant_id"`
+	UserID                uuid.UUID `json:"user_id"`
+	Email                 string    `json:"email"`
+	FullName              string    `json:"full_name"`
+	PasswordMinLength     int32     `json:"password_min_length"`
+	PasswordCheckBreached bool      `json:"password_check_breached"`
 }
 
-func (q *Queries) IncrementTenantCounter(ctx context.Context, arg IncrementTenantCounterParams) (int64, error) {
-	row := q.db.QueryRow(ctx, incrementTenantCounter, arg.TenantID, arg.CounterType)
-	var value int64
-	err := row.Scan(&value)
-	return value, err
+func (q *Queries) GetPasswordResetToken(ctx context.Context, tokenHash string) (GetPasswordResetTokenRow, error) {
+	row := q.db.QueryRow(ctx, getPasswordResetToken, tokenHash)
+	var i GetPasswordResetTokenRow
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.UserID,
+		&i.Email,
+		&i.FullName,
+		&i.PasswordMinLength,
+		&i.PasswordCheckBreached,
+	)
+	return i, err
 }
 
-const insertAuditLog = `-- name: InsertAuditLog :exec
-INSERT INTO audit_log (
-  tenant_id,
-  user_id,
-  action,
-  entity_type,
-  entity_id,
-  request_id,
-  metadata
-) VALUES (
-  $1,
-  $2,
-  $3,
-  $4,
-  $5,
-  $6,
-  $7
-)
+const getPreviousEstimateRevisionNumber = `-- name: GetPreviousEstimateRevisionNumber :one
+SELECT COALESCE(MAX(revision_number), 0)::int AS revision_number
+FROM estimate_revisions
+WHERE tenant_id = $1
+  AND estimate_id = $2
+  AND revision_number < $3
 `
 
-type InsertAuditLogParams struct {
-	TenantID   uuid.UUID  `json:"tenant_id"`
-	UserID     *uuid.UUID `json:"user_id"`
-	Action     string     `json:"action"`
-	EntityType string     `json:"entity_type"`
-	EntityID   *uuid.UUID `json:"entity_id"`
-	RequestID  *string    `json:"request_id"`
-	Metadata   []byte     `json:"metadata"`
+type GetPreviousEstimateRevisionNumberParams struct {
+	TenantID       uuid.UUID `json:"tenant_id"`
+	EstimateID     uuid.UUID `json:"estimate_id"`
+	RevisionNumber int32     `json:"revision_number"`
 }
 
-func (q *Queries) InsertAuditLog(ctx context.Context, arg InsertAuditLogParams) error {
-	_, err := q.db.Exec(ctx, insertAuditLog,
-		arg.TenantID,
-		arg.UserID,
-		arg.Action,
-		arg.EntityType,
-		arg.EntityID,
-		arg.RequestID,
-		arg.Metadata,
-	)
-	return err
+func (q *Queries) GetPreviousEstimateRevisionNumber(ctx context.Context, arg GetPreviousEstimateRevisionNumberParams) (int32, error) {
+	row := q.db.QueryRow(ctx, getPreviousEstimateRevisionNumber, arg.TenantID, arg.EstimateID, arg.RevisionNumber)
+	var revision_number int32
+	err := row.Scan(&revision_number)
+	return revision_number, err
 }
 
-const listCalendarJobs = `-- name: ListCalendarJobs :many
+const getSSOLoginUser = `-- name: GetSSOLoginUser :one
 SELECT
-  j.id AS job_id,
-  j.job_number,
-  j.scheduled_date,
-  j.pickup_time,
-  COALESCE(NULLIF(TRIM(c.first_name || ' ' || c.last_name), ''), j.job_number) AS customer_name,
-  COALESCE(
-    NULLIF(CONCAT_WS(', ', NULLIF(TRIM(e.origin_city), ''), NULLIF(TRIM(e.origin_state), '')), ''),
-    'TBD'
-  )::text AS origin_short,
-  COALESCE(
-    NULLIF(CONCAT_WS(', ', NULLIF(TRIM(e.destination_city), ''), NULLIF(TRIM(e.destination_state), '')), ''),
-    'TBD'
-  )::text AS destination_short,
-  j.status,
-  FALSE AS has_storage,
-  GREATEST(COALESCE(e.estimated_total_cents, 0) - COALESCE(e.deposit_cents, 0), 0)::bigint AS balance_due_cents
-FROM jobs j
-JOIN customers c
-  ON c.id = j.customer_id
-  AND c.tenant_id = j.tenant_id
-LEFT JOIN estimates e
-  ON e.id = j.estimate_id
-  AND e.tenant_id = j.tenant_id
-WHERE j.tenant_id = $1
-  AND j.scheduled_date IS NOT NULL
-  AND j.scheduled_date >= $2::date
-  AND j.scheduled_date < $3::date
-  AND ($4::text IS NULL OR j.status = $4::text)
+  u.id,
+  u.email,
+  u.full_name,
+  u.is_active,
+  t.slug AS tenant_slug,
+  t.name AS tenant_name,
+  (ui.id IS NOT NULL)::boolean AS identity_linked
+FROM users u
+JOIN tenants t ON t.id = u.tenant_id
+LEFT JOIN user_identities ui
+  ON ui.user_id = u.id
+  AND ui.issuer = $1
+  AND ui.subject = $2
+WHERE u.tenant_id = $3
   AND (
-    $5::text IS NULL
-    OR (
-      CASE
-        WHEN e.id IS NULL THEN 'other'
-        WHEN NULLIF(TRIM(COALESCE(e.origin_state, '')), '') IS NULL THEN 'other'
-        WHEN NULLIF(TRIM(COALESCE(e.destination_state, '')), '') IS NULL THEN 'other'
-        WHEN UPPER(e.origin_state) = UPPER(e.destination_state) THEN 'local'
-        ELSE 'long_distance'
-      END
-    ) = $5::text
+    ui.id IS NOT NULL
+    OR ($4::boolean AND lower(u.email) = lower($5::text))
   )
-ORDER BY j.scheduled_date ASC, COALESCE(j.pickup_time, ''), j.job_number ASC
+ORDER BY (ui.id IS NOT NULL) DESC
+LIMIT 1
 `
 
-type ListCalendarJobsParams struct {
-	TenantID uuid.UUID `json:"tenant_id"`
-	FromDate time.Time `json:"from_date"`
-	ToDate   time.Time `json:"to_date"`
-	Phase    *string   `json:"phase"`
-	JobType  *string   `json:"job_type"`
+type GetSSOLoginUserParams struct {
+	Issuer     string    `json:"issuer"`
+	Subject    string    `json:"subject"`
+	TenantID   uuid.UUID `json:"tenant_id"`
+	MatchEmail bool      `json:"match_email"`
+	Email      string    `json:"email"`
 }
 
-type ListCalendarJobsRow struct {
-	JobID            uuid.UUID  `json:"job_id"`
-	JobNumber        string     `json:"job_number"`
-	ScheduledDate    *time.Time `json:"scheduled_date"`
-	PickupTime       *string    `json:"pickup_time"`
-	CustomerName     string     `json:"customer_name"`
-	OriginShort      string     `json:"origin_short"`
-	DestinationShort string     `json:"destination_short"`
-	Status           string     `json:"status"`
-	HasStorage       bool       `json:"has_storage"`
-	BalanceDueCents  int64      `json:"balance_due_cents"`
+type GetSSOLoginUserRow struct {
+	ID             uuid.UUID `json:"id"`
+	Email          string    `json:"email"`
+	FullName       string    `json:"full_name"`
+	IsActive       bool      `json:"is_active"`
+	TenantSlug     string    `json:"tenant_slug"`
+	TenantName     string    `json:"tenant_name"`
+	IdentityLinked bool      `json:"identity_linked"`
 }
 
-func (q *Queries) ListCalendarJobs(ctx context.Context, arg ListCalendarJobsParams) ([]ListCalendarJobsRow, error) {
-	rows, err := q.db.Query(ctx, listCalendarJobs,
+func (q *Queries) GetSSOLoginUser(ctx context.Context, arg GetSSOLoginUserParams) (GetSSOLoginUserRow, error) {
+	row := q.db.QueryRow(ctx, getSSOLoginUser,
+		arg.Issuer,
+		arg.Subject,
 		arg.TenantID,
-		arg.FromDate,
-		arg.ToDate,
-		arg.Phase,
-		arg.JobType,
+		arg.MatchEmail,
+		arg.Email,
 	)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	items := []ListCalendarJobsRow{}
-	for rows.Next() {
-		var i ListCalendarJobsRow
-		if err := rows.Scan(
-			&i.JobID,
-			&i.JobNumber,
-			&i.ScheduledDate,
-			&i.PickupTime,
-			&i.CustomerName,
-			&i.OriginShort,
-			&i.DestinationShort,
-			&i.Status,
-			&i.HasStorage,
-			&i.BalanceDueCents,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+	var i GetSSOLoginUserRow
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.FullName,
+		&i.IsActive,
+		&i.TenantSlug,
+		&i.TenantName,
+		&i.IdentityLinked,
+	)
+	return i, err
 }
 
-const listImportRowResultsByRun = `-- name: ListImportRowResultsByRun :many
+const getSSOProvider = `-- name: GetSSOProvider :one
 SELECT
-  id,
   tenant_id,
-  import_run_id,
-  row_number,
-  severity,
-  entity_type,
-  idempotency_key,
-  result,
-  field,
-  message,
-  raw_value,
-  target_entity_id,
-  created_at
-FROM import_row_result
+  enabled,
+  issuer,
+  client_id,
+  client_secret,
+  jit_provisioning,
+  default_role_id,
+  groups_claim,
+  updated_at
+FROM tenant_sso_providers
 WHERE tenant_id = $1
-  AND import_run_id = $2
-ORDER BY row_number ASC, created_at ASC
 `
 
-type ListImportRowResultsByRunParams struct {
-	TenantID    uuid.UUID `json:"tenant_id"`
-	ImportRunID uuid.UUID `json:"import_run_id"`
+type GetSSOProviderRow struct {
+	TenantID        uuid.UUID  `json:"tenant_id"`
+	Enabled         bool       `json:"enabled"`
+	Issuer          string     `json:"issuer"`
+	ClientID        string     `json:"client_id"`
+	ClientSecret    string     `json:"client_secret"`
+	JitProvisioning bool       `json:"jit_provisioning"`
+	DefaultRoleID   *uuid.UUID `json:"default_role_id"`
+	GroupsClaim     string     `json:"groups_claim"`
+	UpdatedAt       time.Time  `json:"updated_at"`
 }
 
-func (q *Queries) ListImportRowResultsByRun(ctx context.Context, arg ListImportRowResultsByRunParams) ([]ImportRowResult, error) {
-	rows, err := q.db.Query(ctx, listImportRowResultsByRun, arg.TenantID, arg.ImportRunID)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	items := []ImportRowResult{}
-	for rows.Next() {
-		var i ImportRowResult
-		if err := rows.Scan(
-			&i.ID,
-			&i.TenantID,
-			&i.ImportRunID,
-			&i.RowNumber,
-			&i.Severity,
-			&i.EntityType,
-			&i.IdempotencyKey,
-			&i.Result,
-			&i.Field,
-			&i.Message,
-			&i.RawValue,
-			&i.TargetEntityID,
-			&i.CreatedAt,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+func (q *Queries) GetSSOProvider(ctx context.Context, tenantID uuid.UUID) (GetSSOProviderRow, error) {
+	row := q.db.QueryRow(ctx, getSSOProvider, tenantID)
+	var i GetSSOProviderRow
+	err := row.Scan(
+		&i.TenantID,
+		&i.Enabled,
+		&i.Issuer,
+		&i.ClientID,
+		&i.ClientSecret,
+		&i.JitProvisioning,
+		&i.DefaultRoleID,
+		&i.GroupsClaim,
+		&i.UpdatedAt,
+	)
+	return i, err
 }
 
-const listImportRowResultsByRunAndSeverity = `-- name: ListImportRowResultsByRunAndSeverity :many
+const getSessionLinkedUserIDs = `-- name: GetSessionLinkedUserIDs :one
+SELECT linked_user_ids
+FROM sessions
+WHERE id = $1
+  AND tenant_id = $2
+  AND revoked_at IS NULL
+`
+
+type GetSessionLinkedUserIDsParams struct {
+	ID       uuid.UUID `json:"id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+}
+
+func (q *Queries) GetSessionLinkedUserIDs(ctx context.Context, arg GetSessionLinkedUserIDsParams) ([]uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, getSessionLinkedUserIDs, arg.ID, arg.TenantID)
+	var linked_user_ids []uuid.UUID
+	err := row.Scan(&linked_user_ids)
+	return linked_user_ids, err
+}
+
+const getSessionPrincipalByTokenHash = `-- name: GetSessionPrincipalByTokenHash :one
 SELECT
-  id,
-  tenant_id,
-  import_run_id,
-  row_number,
-  severity,
-  entity_type,
-  idempotency_key,
-  result,
-  field,
-  message,
-  raw_value,
-  target_entity_id,
-  created_at
-FROM import_row_result
-WHERE tenant_id = $1
-  AND import_run_id = $2
-  AND severity = $3
-ORDER BY row_number ASC, created_at ASC
-LIMIT $4
+  s.id AS session_id,
+  s.tenant_id,
+  s.user_id,
+  s.csrf_token,
+  s.expires_at,
+  s.absolute_expires_at,
+  s.last_seen_at,
+  s.token_rotated_at,
+  (s.token_hash = $1)::boolean AS is_current_token,
+  u.email,
+  u.full_name,
+  t.slug AS tenant_slug,
+  t.name AS tenant_name,
+  s.mfa_pending,
+  (m.confirmed_at IS NOT NULL)::boolean AS mfa_enabled,
+  COALESCE(ts.require_mfa_for_sensitive_roles, FALSE)::boolean AS require_mfa_for_sensitive_roles
+FROM sessions s
+JOIN users u ON u.id = s.user_id
+JOIN tenants t ON t.id = s.tenant_id
+LEFT JOIN user_mfa m ON m.user_id = s.user_id
+LEFT JOIN tenant_settings ts ON ts.tenant_id = s.tenant_id
+WHERE (
+    s.token_hash = $1
+    -- Requests already in flight when the token was rotated still carry the
+    -- previous cookie, so it stays valid for a short grace period.
+    OR (s.previous_token_hash = $1 AND s.token_rotated_at > NOW() - INTERVAL '1 minute')
+  )
+  AND s.revoked_at IS NULL
+  AND s.expires_at > NOW()
+  AND s.absolute_expires_at > NOW()
+  AND u.is_active = TRUE
 `
 
-type ListImportRowResultsByRunAndSeverityParams struct {
-	TenantID    uuid.UUID `json:"tenant_id"`
-	ImportRunID uuid.UUID `json:"import_run_id"`
-	Severity    string    `json:"severity"`
-	LimitRows   int32     `json:"limit_rows"`
+type GetSessionPrincipalByTokenHashRow struct {
+	SessionID                   uuid.UUID  `json:"session_id"`
+	TenantID                    uuid.UUID  `json:"tenant_id"`
+	UserID                      uuid.UUID  `json:"user_id"`
+	CsrfToken                   string     `json:"csrf_token"`
+	ExpiresAt                   time.Time  `json:"expires_at"`
+	AbsoluteExpiresAt           time.Time  `json:"absolute_expires_at"`
+	LastSeenAt                  *time.Time `json:"last_seen_at"`
+	TokenRotatedAt              time.Time  `json:"token_rotated_at"`
+	IsCurrentToken              bool       `json:"is_current_token"`
+	Email                       string     `json:"email"`
+	FullName                    string     `json:"full_name"`
+	TenantSlug                  string     `json:"tenant_slug"`
+	TenantName                  string     `json:"tenant_name"`
+	MfaPending                  bool       `json:"mfa_pending"`
+	MfaEnabled                  bool       `json:"mfa_enabled"`
+	RequireMfaForSensitiveRoles bool       `json:"require_mfa_for_sensitive_roles"`
 }
 
-func (q *Queries) ListImportRowResultsByRunAndSeverity(ctx context.Context, arg ListImportRowResultsByRunAndSeverityParams) ([]ImportRowResult, error) {
-	rows, err := q.db.Query(ctx, listImportRowResultsByRunAndSeverity,
-		arg.TenantID,
-		arg.ImportRunID,
-		arg.Severity,
-		arg.LimitRows,
+func (q *Queries) GetSessionPrincipalByTokenHash(ctx context.Context, tokenHash string) (GetSessionPrincipalByTokenHashRow, error) {
+	row := q.db.QueryRow(ctx, getSessionPrincipalByTokenHash, tokenHash)
+	var i GetSessionPrincipalByTokenHashRow
+	err := row.Scan(
+		&i.SessionID,
+		&i.TenantID,
+		&i.UserID,
+		&i.CsrfToken,
+		&i.ExpiresAt,
+		&i.AbsoluteExpiresAt,
+		&i.LastSeenAt,
+		&i.TokenRotatedAt,
+		&i.IsCurrentToken,
+		&i.Email,
+		&i.FullName,
+		&i.TenantSlug,
+		&i.TenantName,
+		&i.MfaPending,
+		&i.MfaEnabled,
+		&i.RequireMfaForSensitiveRoles,
 	)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	items := []ImportRowResult{}
-	for rows.Next() {
-		var i ImportRowResult
-		if err := rows.Scan(
-			&i.ID,
-			&i.TenantID,
-			&i.ImportRunID,
-			&i.RowNumber,
-			&i.Severity,
-			&i.EntityType,
-			&i.IdempotencyKey,
-			&i.Result,
-			&i.Field,
-			&i.Message,
-			&i.RawValue,
-			&i.TargetEntityID,
-			&i.CreatedAt,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+	return i, err
 }
 
-const listStorageRows = `-- name: ListStorageRows :many
+const getStorageRecordByID = `-- name: GetStorageRecordByID :one
 SELECT
-  sr.id AS storage_record_id,
-  j.id AS job_id,
-  j.job_number,
-  COALESCE(NULLIF(TRIM(c.first_name || ' ' || c.last_name), ''), COALESCE(e.customer_name, j.job_number))::text AS customer_name,
-  CASE
-    WHEN e.id IS NULL THEN 'other'
-    WHEN NULLIF(TRIM(COALESCE(e.origin_state, '')), '') IS NULL THEN 'other'
-    WHEN NULLIF(TRIM(COALESCE(e.destination_state, '')), '') IS NULL THEN 'other'
-    WHEN UPPER(e.origin_state) = UPPER(e.destination_state) THEN 'local'
-    ELSE 'long_distance'
-  END::text AS move_type,
-  COALESCE(
-    NULLIF(CONCAT_WS(', ', NULLIF(TRIM(e.origin_city), ''), NULLIF(TRIM(e.origin_state), '')), ''),
-    'TBD'
-  )::text AS from_short,
-  COALESCE(
-    NULLIF(CONCAT_WS(', ', NULLIF(TRIM(e.destination_city), ''), NULLIF(TRIM(e.destination_state), '')), ''),
-    'TBD'
-  )::text AS to_short,
-  sr.status,
-  sr.date_in,
-  sr.date_out,
-  sr.next_bill_date,
-  sr.lot_number,
-  sr.location_label,
-  COALESCE(sr.vaults, 0)::int AS vaults,
-  COALESCE(sr.pads, 0)::int AS pads,
-  COALESCE(sr.items, 0)::int AS items,
-  COALESCE(sr.oversize_items, 0)::int AS oversize_items,
-  COALESCE(sr.volume, 0)::int AS volume,
-  sr.monthly_rate_cents,
-  COALESCE(sr.storage_balance_cents, 0)::bigint AS storage_balance_cents,
-  COALESCE(sr.move_balance_cents, 0)::bigint AS move_balance_cents,
-  COALESCE(sr.facility, $1)::text AS facility,
-  COALESCE(sr.updated_at, j.updated_at) AS sort_updated_at,
-  j.id AS sort_job_id
-FROM jobs j
-JOIN customers c
-  ON c.id = j.customer_id
-  AND c.tenant_id = j.tenant_id
-LEFT JOIN estimates e
-  ON e.id = j.estimate_id
-  AND e.tenant_id = j.tenant_id
-LEFT JOIN storage_record sr
+  id,
+  tenant_id,
+  job_id,
+  facility,
+  status,
+  date_in,
+  date_out,
+  next_bill_date,
+  lot_number,
+  location_label,
+  vaults,
+  pads,
+  items,
+  oversize_items,
+  volume,
+  monthly_rate_cents,
+  storage_balance_cents,
+  move_balance_cents,
+  last_payment_at,
+  notes,
+  created_at,
+  updated_at
+FROM storage_record
+WHERE id = $1
+  AND tenant_id = $2
+`
+
+type GetStorageRecordByIDParams struct {
+	ID       uuid.UUID `json:"id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+}
+
+func (q *Queries) GetStorageRecordByID(ctx context.Context, arg GetStorageRecordByIDParams) (StorageRecord, error) {
+	row := q.db.QueryRow(ctx, getStorageRecordByID, arg.ID, arg.TenantID)
+	var i StorageRecord
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.JobID,
+		&i.Facility,
+		&i.Status,
+		&i.DateIn,
+		&i.DateOut,
+		&i.NextBillDate,
+		&i.LotNumber,
+		&i.LocationLabel,
+		&i.Vaults,
+		&i.Pads,
+		&i.Items,
+		&i.OversizeItems,
+		&i.Volume,
+		&i.MonthlyRateCents,
+		&i.StorageBalanceCents,
+		&i.MoveBalanceCents,
+		&i.LastPaymentAt,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getStorageRecordByJobID = `-- name: GetStorageRecordByJobID :one
+SELECT
+  id,
+  tenant_id,
+  job_id,
+  facility,
+  status,
+  date_in,
+  date_out,
+  next_bill_date,
+  lot_number,
+  location_label,
+  vaults,
+  pads,
+  items,
+  oversize_items,
+  volume,
+  monthly_rate_cents,
+  storage_balance_cents,
+  move_balance_cents,
+  last_payment_at,
+  notes,
+  created_at,
+  updated_at
+FROM storage_record
+WHERE job_id = $1
+  AND tenant_id = $2
+`
+
+type GetStorageRecordByJobIDParams struct {
+	JobID    uuid.UUID `json:"job_id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+}
+
+func (q *Queries) GetStorageRecordByJobID(ctx context.Context, arg GetStorageRecordByJobIDParams) (StorageRecord, error) {
+	row := q.db.QueryRow(ctx, getStorageRecordByJobID, arg.JobID, arg.TenantID)
+	var i StorageRecord
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.JobID,
+		&i.Facility,
+		&i.Status,
+		&i.DateIn,
+		&i.DateOut,
+		&i.NextBillDate,
+		&i.LotNumber,
+		&i.LocationLabel,
+		&i.Vaults,
+		&i.Pads,
+		&i.Items,
+		&i.OversizeItems,
+		&i.Volume,
+		&i.MonthlyRateCents,
+		&i.StorageBalanceCents,
+		&i.MoveBalanceCents,
+		&i.LastPaymentAt,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getStorageRecordDetailByID = `-- name: GetStorageRecordDetailByID :one
+SELECT
+  sr.id,
+  sr.tenant_id,
+  sr.job_id,
+  j.job_number,
+  COALESCE(NULLIF(TRIM(c.first_name || ' ' || c.last_name), ''), COALESCE(e.customer_name, j.job_number))::text AS customer_name,
+  CASE
+    WHEN e.id IS NULL THEN 'other'
+    WHEN NULLIF(TRIM(COALESCE(e.origin_state, '')), '') IS NULL THEN 'other'
+    WHEN NULLIF(TRIM(COALESCE(e.destination_state, '')), '') IS NULL THEN 'other'
+    WHEN UPPER(e.origin_state) = UPPER(e.destination_state) THEN 'local'
+    ELSE 'long_distance'
+  END::text AS move_type,
+  COALESCE(
+    NULLIF(CONCAT_WS(', ', NULLIF(TRIM(e.origin_city), ''), NULLIF(TRIM(e.origin_state), '')), ''),
+    'TBD'
+  )::text AS from_short,
+  COALESCE(
+    NULLIF(CONCAT_WS(', ', NULLIF(TRIM(e.destination_city), ''), NULLIF(TRIM(e.destination_state), '')), ''),
+    'TBD'
+  )::text AS to_short,
+  sr.facility,
+  sr.status,
+  sr.date_in,
+  sr.date_out,
+  sr.next_bill_date,
+  sr.lot_number,
+  sr.location_label,
+  sr.vaults,
+  sr.pads,
+  sr.items,
+  sr.oversize_items,
+  sr.volume,
+  sr.monthly_rate_cents,
+  sr.storage_balance_cents,
+  sr.move_balance_cents,
+  sr.last_payment_at,
+  sr.notes,
+  sr.created_at,
+  sr.updated_at
+FROM storage_record sr
+JOIN jobs j
+  ON j.id = sr.job_id
+  AND j.tenant_id = sr.tenant_id
+JOIN customers c
+  ON c.id = j.customer_id
+  AND c.tenant_id = j.tenant_id
+LEFT JOIN estimates e
+  ON e.id = j.estimate_id
+  AND e.tenant_id = j.tenant_id
+WHERE sr.id = $1
+  AND sr.tenant_id = $2
+`
+
+type GetStorageRecordDetailByIDParams struct {
+	ID       uuid.UUID `json:"id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+}
+
+type GetStorageRecordDetailByIDRow struct {
+	ID                  uuid.UUID  `json:"id"`
+	TenantID            uuid.UUID  `json:"tenant_id"`
+	JobID               uuid.UUID  `json:"job_id"`
+	JobNumber           string     `json:"job_number"`
+	CustomerName        string     `json:"customer_name"`
+	MoveType            string     `json:"move_type"`
+	FromShort           string     `json:"from_short"`
+	ToShort             string     `json:"to_short"`
+	Facility            string     `json:"facility"`
+	Status              string     `json:"status"`
+	DateIn              *time.Time `json:"date_in"`
+	DateOut             *time.Time `json:"date_out"`
+	NextBillDate        *time.Time `json:"next_bill_date"`
+	LotNumber           *string    `json:"lot_number"`
+	LocationLabel       *string    `json:"location_label"`
+	Vaults              int32      `json:"vaults"`
+	Pads                int32      `json:"pads"`
+	Items               int32      `json:"items"`
+	OversizeItems       int32      `json:"oversize_items"`
+	Volume              int32      `json:"volume"`
+	MonthlyRateCents    *int64     `json:"monthly_rate_cents"`
+	StorageBalanceCents int64      `json:"storage_balance_cents"`
+	MoveBalanceCents    int64      `json:"move_balance_cents"`
+	LastPaymentAt       *time.Time `json:"last_payment_at"`
+	Notes               *string    `json:"notes"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+func (q *Queries) GetStorageRecordDetailByID(ctx context.Context, arg GetStorageRecordDetailByIDParams) (GetStorageRecordDetailByIDRow, error) {
+	row := q.db.QueryRow(ctx, getStorageRecordDetailByID, arg.ID, arg.TenantID)
+	var i GetStorageRecordDetailByIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.JobID,
+		&i.JobNumber,
+		&i.CustomerName,
+		&i.MoveType,
+		&i.FromShort,
+		&i.ToShort,
+		&i.Facility,
+		&i.Status,
+		&i.DateIn,
+		&i.DateOut,
+		&i.NextBillDate,
+		&i.LotNumber,
+		&i.LocationLabel,
+		&i.Vaults,
+		&i.Pads,
+		&i.Items,
+		&i.OversizeItems,
+		&i.Volume,
+		&i.MonthlyRateCents,
+		&i.StorageBalanceCents,
+		&i.MoveBalanceCents,
+		&i.LastPaymentAt,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getStorageRecordEmailContext = `-- name: GetStorageRecordEmailContext :one
+SELECT
+  sr.id,
+  sr.job_id,
+  sr.facility,
+  sr.next_bill_date,
+  sr.monthly_rate_cents,
+  sr.storage_balance_cents,
+  j.job_number,
+  COALESCE(NULLIF(TRIM(c.first_name || ' ' || c.last_name), ''), e.customer_name, '')::text AS customer_name,
+  COALESCE(NULLIF(TRIM(c.email), ''), e.email, '')::text AS email
+FROM storage_record sr
+JOIN jobs j
+  ON j.id = sr.job_id
+  AND j.tenant_id = sr.tenant_id
+JOIN customers c
+  ON c.id = j.customer_id
+  AND c.tenant_id = j.tenant_id
+LEFT JOIN estimates e
+  ON e.id = j.estimate_id
+  AND e.tenant_id = j.tenant_id
+WHERE sr.id = $1
+  AND sr.tenant_id = $2
+`
+
+type GetStorageRecordEmailContextParams struct {
+	ID       uuid.UUID `json:"id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+}
+
+type GetStorageRecordEmailContextRow struct {
+	ID                  uuid.UUID  `json:"id"`
+	JobID               uuid.UUID  `json:"job_id"`
+	Facility            string     `json:"facility"`
+	NextBillDate        *time.Time `json:"next_bill_date"`
+	MonthlyRateCents    *int64     `json:"monthly_rate_cents"`
+	StorageBalanceCents int64      `json:"storage_balance_cents"`
+	JobNumber           string     `json:"job_number"`
+	CustomerName        string     `json:"customer_name"`
+	Email               string     `json:"email"`
+}
+
+func (q *Queries) GetStorageRecordEmailContext(ctx context.Context, arg GetStorageRecordEmailContextParams) (GetStorageRecordEmailContextRow, error) {
+	row := q.db.QueryRow(ctx, getStorageRecordEmailContext, arg.ID, arg.TenantID)
+	var i GetStorageRecordEmailContextRow
+	err := row.Scan(
+		&i.ID,
+		&i.JobID,
+		&i.Facility,
+		&i.NextBillDate,
+		&i.MonthlyRateCents,
+		&i.StorageBalanceCents,
+		&i.JobNumber,
+		&i.CustomerName,
+		&i.Email,
+	)
+	return i, err
+}
+
+const getTariff = `-- name: GetTariff :one
+SELECT tenant_id, minimum_hours, minimum_charge_cents, long_distance_threshold_miles, per_mile_cents, per_cwt_cents, fuel_surcharge_bps, updated_by, updated_at
+FROM tariffs
+WHERE tenant_id = $1
+`
+
+func (q *Queries) GetTariff(ctx context.Context, tenantID uuid.UUID) (Tariff, error) {
+	row := q.db.QueryRow(ctx, getTariff, tenantID)
+	var i Tariff
+	err := row.Scan(
+		&i.TenantID,
+		&i.MinimumHours,
+		&i.MinimumChargeCents,
+		&i.LongDistanceThresholdMiles,
+		&i.PerMileCents,
+		&i.PerCwtCents,
+		&i.FuelSurchargeBps,
+		&i.UpdatedBy,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getTenantEmailSettings = `-- name: GetTenantEmailSettings :one
+SELECT
+  t.id AS tenant_id,
+  t.name AS tenant_name,
+  COALESCE(es.enabled, FALSE)::boolean AS enabled,
+  es.from_name,
+  es.from_address,
+  es.reply_to,
+  es.updated_at
+FROM tenants t
+LEFT JOIN tenant_email_settings es ON es.tenant_id = t.id
+WHERE t.id = $1
+`
+
+type GetTenantEmailSettingsRow struct {
+	TenantID    uuid.UUID  `json:"tenant_id"`
+	TenantName  string     `json:"tenant_name"`
+	Enabled     bool       `json:"enabled"`
+	FromName    *string    `json:"from_name"`
+	FromAddress *string    `json:"from_address"`
+	ReplyTo     *string    `json:"reply_to"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+}
+
+func (q *Queries) GetTenantEmailSettings(ctx context.Context, tenantID uuid.UUID) (GetTenantEmailSettingsRow, error) {
+	row := q.db.QueryRow(ctx, getTenantEmailSettings, tenantID)
+	var i GetTenantEmailSettingsRow
+	err := row.Scan(
+		&i.TenantID,
+		&i.TenantName,
+		&i.Enabled,
+		&i.FromName,
+		&i.FromAddress,
+		&i.ReplyTo,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getTenantRoleByID = `-- name: GetTenantRoleByID :one
+SELECT
+  r.id,
+  r.name,
+  r.description,
+  r.created_at,
+  COALESCE(
+    (
+      SELECT ARRAY_AGG(p.name ORDER BY p.name)
+      FROM role_permissions rp
+      JOIN permissions p ON p.id = rp.permission_id
+      WHERE rp.role_id = r.id
+    ),
+    '{}'
+  )::text[] AS permission_names,
+  (
+    SELECT COUNT(*)
+    FROM user_roles ur
+    WHERE ur.role_id = r.id
+      AND ur.tenant_id = r.tenant_id
+  )::bigint AS user_count
+FROM roles r
+WHERE r.id = $1
+  AND r.tenant_id = $2
+`
+
+type GetTenantRoleByIDParams struct {
+	ID       uuid.UUID `json:"id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+}
+
+type GetTenantRoleByIDRow struct {
+	ID              uuid.UUID `json:"id"`
+	Name            string    `json:"name"`
+	Description     string    `json:"description"`
+	CreatedAt       time.Time `json:"created_at"`
+	PermissionNames []string  `json:"permission_names"`
+	UserCount       int64     `json:"user_count"`
+}
+
+func (q *Queries) GetTenantRoleByID(ctx context.Context, arg GetTenantRoleByIDParams) (GetTenantRoleByIDRow, error) {
+	row := q.db.QueryRow(ctx, getTenantRoleByID, arg.ID, arg.TenantID)
+	var i GetTenantRoleByIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.PermissionNames,
+		&i.UserCount,
+	)
+	return i, err
+}
+
+const getTenantSettings = `-- name: GetTenantSettings :one
+SELECT
+  t.id AS tenant_id,
+  COALESCE(ts.require_mfa_for_sensitive_roles, FALSE)::boolean AS require_mfa_for_sensitive_roles,
+  COALESCE(ts.password_min_length, 10)::int AS password_min_length,
+  COALESCE(ts.password_check_breached, TRUE)::boolean AS password_check_breached,
+  COALESCE(ts.estimate_validity_days, 30)::int AS estimate_validity_days,
+  ts.updated_at
+FROM tenants t
+LEFT JOIN tenant_settings ts ON ts.tenant_id = t.id
+WHERE t.id = $1
+`
+
+type GetTenantSettingsRow struct {
+	TenantID                    uuid.UUID  `json:"tenant_id"`
+	RequireMfaForSensitiveRoles bool       `json:"require_mfa_for_sensitive_roles"`
+	PasswordMinLength           int32      `json:"password_min_length"`
+	PasswordCheckBreached       bool       `json:"password_check_breached"`
+	EstimateValidityDays        int32      `json:"estimate_validity_days"`
+	UpdatedAt                   *time.Time `json:"updated_at"`
+}
+
+func (q *Queries) GetTenantSettings(ctx context.Context, tenantID uuid.UUID) (GetTenantSettingsRow, error) {
+	row := q.db.QueryRow(ctx, getTenantSettings, tenantID)
+	var i GetTenantSettingsRow
+	err := row.Scan(
+		&i.TenantID,
+		&i.RequireMfaForSensitiveRoles,
+		&i.PasswordMinLength,
+		&i.PasswordCheckBreached,
+		&i.EstimateValidityDays,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getTenantUserByID = `-- name: GetTenantUserByID :one
+SELECT
+  u.id,
+  u.email,
+  u.full_name,
+  u.is_active,
+  u.created_at,
+  u.updated_at,
+  COALESCE(
+    ARRAY_AGG(r.name ORDER BY r.name) FILTER (WHERE r.id IS NOT NULL),
+    '{}'
+  )::text[] AS role_names,
+  f.locked_until
+FROM users u
+LEFT JOIN user_login_failures f
+  ON f.user_id = u.id
+LEFT JOIN user_roles ur
+  ON ur.user_id = u.id
+  AND ur.tenant_id = u.tenant_id
+LEFT JOIN roles r
+  ON r.id = ur.role_id
+  AND r.tenant_id = u.tenant_id
+WHERE u.id = $1
+  AND u.tenant_id = $2
+GROUP BY u.id, f.user_id
+`
+
+type GetTenantUserByIDParams struct {
+	ID       uuid.UUID `json:"id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+}
+
+type GetTenantUserByIDRow struct {
+	ID          uuid.UUID  `json:"id"`
+	Email       string     `json:"email"`
+	FullName    string     `json:"full_name"`
+	IsActive    bool       `json:"is_active"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	RoleNames   []string   `json:"role_names"`
+	LockedUntil *time.Time `json:"locked_until"`
+}
+
+func (q *Queries) GetTenantUserByID(ctx context.Context, arg GetTenantUserByIDParams) (GetTenantUserByIDRow, error) {
+	row := q.db.QueryRow(ctx, getTenantUserByID, arg.ID, arg.TenantID)
+	var i GetTenantUserByIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.FullName,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.RoleNames,
+		&i.LockedUntil,
+	)
+	return i, err
+}
+
+const getUserMFA = `-- name: GetUserMFA :one
+SELECT user_id, tenant_id, totp_secret, confirmed_at, last_used_step, created_at, updated_at
+FROM user_mfa
+WHERE user_id = $1
+  AND tenant_id = $2
+`
+
+type GetUserMFAParams struct {
+	UserID   uuid.UUID `json:"user_id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+}
+
+func (q *Queries) GetUserMFA(ctx context.Context, arg GetUserMFAParams) (UserMfa, error) {
+	row := q.db.QueryRow(ctx, getUserMFA, arg.UserID, arg.TenantID)
+	var i UserMfa
+	err := row.Scan(
+		&i.UserID,
+		&i.TenantID,
+		&i.TotpSecret,
+		&i.ConfirmedAt,
+		&i.LastUsedStep,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getUserPasswordHash = `-- name: GetUserPasswordHash :one
+SELECT password_hash, email, full_name
+FROM users
+WHERE id = $1
+  AND tenant_id = $2
+  AND is_active = TRUE
+`
+
+type GetUserPasswordHashParams struct {
+	ID       uuid.UUID `json:"id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+}
+
+type GetUserPasswordHashRow struct {
+	PasswordHash string `json:"password_hash"`
+	Email        string `json:"email"`
+	FullName     string `json:"full_name"`
+}
+
+func (q *Queries) GetUserPasswordHash(ctx context.Context, arg GetUserPasswordHashParams) (GetUserPasswordHashRow, error) {
+	row := q.db.QueryRow(ctx, getUserPasswordHash, arg.ID, arg.TenantID)
+	var i GetUserPasswordHashRow
+	err := row.Scan(&i.PasswordHash, &i.Email, &i.FullName)
+	return i, err
+}
+
+const incrementTenantCounter = `-- name: IncrementTenantCounter :one
+INSERT INTO tenant_counters (tenant_id, counter_type, next_value)
+VALUES ($1, $2, 2)
+ON CONFLICT (tenant_id, counter_type) DO UPDATE
+SET
+  next_value = tenant_counters.next_value + 1,
+  updated_at = NOW()
+RETURNING (next_value - 1)::bigint AS value
+`
+
+type IncrementTenantCounterParams struct {
+	TenantID    uuid.UUID `json:"tenant_id"`
+	CounterType string    `json:"counter_type"`
+}
+
+func (q *Queries) IncrementTenantCounter(ctx context.Context, arg IncrementTenantCounterParams) (int64, error) {
+	row := q.db.QueryRow(ctx, incrementTenantCounter, arg.TenantID, arg.CounterType)
+	var value int64
+	err := row.Scan(&value)
+	return value, err
+}
+
+const insertAuditLog = `-- name: InsertAuditLog :exec
+INSERT INTO audit_log (
+  tenant_id,
+  user_id,
+  action,
+  entity_type,
+  entity_id,
+  request_id,
+  metadata
+) VALUES (
+  $1,
+  $2,
+  $3,
+  $4,
+  $5,
+  $6,
+  $7
+)
+`
+
+type InsertAuditLogParams struct {
+	TenantID   uuid.UUID  `json:"tenant_id"`
+	UserID     *uuid.UUID `json:"user_id"`
+	Action     string     `json:"action"`
+	EntityType string     `json:"entity_type"`
+	EntityID   *uuid.UUID `json:"entity_id"`
+	RequestID  *string    `json:"request_id"`
+	Metadata   []byte     `json:"metadata"`
+}
+
+func (q *Queries) InsertAuditLog(ctx context.Context, arg InsertAuditLogParams) error {
+	_, err := q.db.Exec(ctx, insertAuditLog,
+		arg.TenantID,
+		arg.UserID,
+		arg.Action,
+		arg.EntityType,
+		arg.EntityID,
+		arg.RequestID,
+		arg.Metadata,
+	)
+	return err
+}
+
+const insertEmailTemplates = `-- name: InsertEmailTemplates :exec
+INSERT INTO email_templates (tenant_id, kind, subject, body, updated_by)
+SELECT $1, unnest($2::text[]), unnest($3::text[]), unnest($4::text[]), $5
+`
+
+type InsertEmailTemplatesParams struct {
+	TenantID  uuid.UUID  `json:"tenant_id"`
+	Kinds     []string   `json:"kinds"`
+	Subjects  []string   `json:"subjects"`
+	Bodies    []string   `json:"bodies"`
+	UpdatedBy *uuid.UUID `json:"updated_by"`
+}
+
+func (q *Queries) InsertEmailTemplates(ctx context.Context, arg InsertEmailTemplatesParams) error {
+	_, err := q.db.Exec(ctx, insertEmailTemplates,
+		arg.TenantID,
+		arg.Kinds,
+		arg.Subjects,
+		arg.Bodies,
+		arg.UpdatedBy,
+	)
+	return err
+}
+
+const insertEstimateRevision = `-- name: InsertEstimateRevision :one
+INSERT INTO estimate_revisions (
+  tenant_id,
+  estimate_id,
+  revision_number,
+  change,
+  restored_from,
+  snapshot,
+  created_by
+) VALUES (
+  $1,
+  $2,
+  (
+    SELECT COALESCE(MAX(r.revision_number), 0) + 1
+    FROM estimate_revisions r
+    WHERE r.tenant_id = $1
+      AND r.estimate_id = $2
+  ),
+  $3,
+  $4,
+  $5,
+  $6
+)
+RETURNING revision_number
+`
+
+type InsertEstimateRevisionParams struct {
+	TenantID     uuid.UUID  `json:"tenant_id"`
+	EstimateID   uuid.UUID  `json:"estimate_id"`
+	Change       string     `json:"change"`
+	RestoredFrom *int32     `json:"restored_from"`
+	Snapshot     []byte     `json:"snapshot"`
+	CreatedBy    *uuid.UUID `json:"created_by"`
+}
+
+func (q *Queries) InsertEstimateRevision(ctx context.Context, arg InsertEstimateRevisionParams) (int32, error) {
+	row := q.db.QueryRow(ctx, insertEstimateRevision,
+		arg.TenantID,
+		arg.EstimateID,
+		arg.Change,
+		arg.RestoredFrom,
+		arg.Snapshot,
+		arg.CreatedBy,
+	)
+	var revision_number int32
+	err := row.Scan(&revision_number)
+	return revision_number, err
+}
+
+const insertEstimateSignature = `-- name: InsertEstimateSignature :exec
+INSERT INTO estimate_signatures (
+  id,
+  tenant_id,
+  estimate_id,
+  link_id,
+  revision_number,
+  document_sha256,
+  signer_name,
+  signer_ip,
+  user_agent,
+  signed_at,
+  signature
+) VALUES (
+  $1,
+  $2,
+  $3,
+  $4,
+  $5,
+  $6,
+  $7,
+  $8,
+  $9,
+  $10,
+  $11
+)
+`
+
+type InsertEstimateSignatureParams struct {
+	ID             uuid.UUID  `json:"id"`
+	TenantID       uuid.UUID  `json:"tenant_id"`
+	EstimateID     uuid.UUID  `json:"estimate_id"`
+	LinkID         *uuid.UUID `json:"link_id"`
+	RevisionNumber int32      `json:"revision_number"`
+	DocumentSha256 string     `json:"document_sha256"`
+	SignerName     string     `json:"signer_name"`
+	SignerIp       string     `json:"signer_ip"`
+	UserAgent      string     `json:"user_agent"`
+	SignedAt       time.Time  `json:"signed_at"`
+	Signature      string     `json:"signature"`
+}
+
+func (q *Queries) InsertEstimateSignature(ctx context.Context, arg InsertEstimateSignatureParams) error {
+	_, err := q.db.Exec(ctx, insertEstimateSignature,
+		arg.ID,
+		arg.TenantID,
+		arg.EstimateID,
+		arg.LinkID,
+		arg.RevisionNumber,
+		arg.DocumentSha256,
+		arg.SignerName,
+		arg.SignerIp,
+		arg.UserAgent,
+		arg.SignedAt,
+		arg.Signature,
+	)
+	return err
+}
+
+const insertJobStatusHistory = `-- name: InsertJobStatusHistory :exec
+INSERT INTO job_status_history (
+  tenant_id,
+  job_id,
+  from_status,
+  to_status,
+  reason,
+  changed_by
+) VALUES (
+  $1,
+  $2,
+  $3,
+  $4,
+  $5,
+  $6
+)
+`
+
+type InsertJobStatusHistoryParams struct {
+	TenantID   uuid.UUID  `json:"tenant_id"`
+	JobID      uuid.UUID  `json:"job_id"`
+	FromStatus *string    `json:"from_status"`
+	ToStatus   string     `json:"to_status"`
+	Reason     *string    `json:"reason"`
+	ChangedBy  *uuid.UUID `json:"changed_by"`
+}
+
+func (q *Queries) InsertJobStatusHistory(ctx context.Context, arg InsertJobStatusHistoryParams) error {
+	_, err := q.db.Exec(ctx, insertJobStatusHistory,
+		arg.TenantID,
+		arg.JobID,
+		arg.FromStatus,
+		arg.ToStatus,
+		arg.Reason,
+		arg.ChangedBy,
+	)
+	return err
+}
+
+const insertRecoveryCodes = `-- name: InsertRecoveryCodes :exec
+INSERT INTO user_mfa_recovery_codes (user_id, code_hash)
+SELECT $1, unnest($2::text[])
+`
+
+type InsertRecoveryCodesParams struct {
+	UserID     uuid.UUID `json:"user_id"`
+	CodeHashes []string  `json:"code_hashes"`
+}
+
+func (q *Queries) InsertRecoveryCodes(ctx context.Context, arg InsertRecoveryCodesParams) error {
+	_, err := q.db.Exec(ctx, insertRecoveryCodes, arg.UserID, arg.CodeHashes)
+	return err
+}
+
+const insertSSOGroupRoleMappings = `-- name: InsertSSOGroupRoleMappings :exec
+INSERT INTO sso_group_role_mappings (tenant_id, group_name, role_id)
+SELECT $1, unnest($2::text[]), unnest($3::uuid[])
+ON CONFLICT DO NOTHING
+`
+
+type InsertSSOGroupRoleMappingsParams struct {
+	TenantID   uuid.UUID   `json:"tenant_id"`
+	GroupNames []string    `json:"group_names"`
+	RoleIds    []uuid.UUID `json:"role_ids"`
+}
+
+func (q *Queries) InsertSSOGroupRoleMappings(ctx context.Context, arg InsertSSOGroupRoleMappingsParams) error {
+	_, err := q.db.Exec(ctx, insertSSOGroupRoleMappings, arg.TenantID, arg.GroupNames, arg.RoleIds)
+	return err
+}
+
+const insertTariffAccessorials = `-- name: InsertTariffAccessorials :exec
+INSERT INTO tariff_accessorials (tenant_id, code, name, unit, rate_cents)
+SELECT $1, unnest($2::text[]), unnest($3::text[]), unnest($4::text[]), unnest($5::bigint[])
+`
+
+type InsertTariffAccessorialsParams struct {
+	TenantID  uuid.UUID `json:"tenant_id"`
+	Codes     []string  `json:"codes"`
+	Names     []string  `json:"names"`
+	Units     []string  `json:"units"`
+	RateCents []int64   `json:"rate_cents"`
+}
+
+func (q *Queries) InsertTariffAccessorials(ctx context.Context, arg InsertTariffAccessorialsParams) error {
+	_, err := q.db.Exec(ctx, insertTariffAccessorials,
+		arg.TenantID,
+		arg.Codes,
+		arg.Names,
+		arg.Units,
+		arg.RateCents,
+	)
+	return err
+}
+
+const insertTariffCrewRates = `-- name: InsertTariffCrewRates :exec
+INSERT INTO tariff_crew_rates (tenant_id, crew_size, hourly_rate_cents)
+SELECT $1, unnest($2::int[]), unnest($3::bigint[])
+`
+
+type InsertTariffCrewRatesParams struct {
+	TenantID        uuid.UUID `json:"tenant_id"`
+	CrewSizes       []int32   `json:"crew_sizes"`
+	HourlyRateCents []int64   `json:"hourly_rate_cents"`
+}
+
+func (q *Queries) InsertTariffCrewRates(ctx context.Context, arg InsertTariffCrewRatesParams) error {
+	_, err := q.db.Exec(ctx, insertTariffCrewRates, arg.TenantID, arg.CrewSizes, arg.HourlyRateCents)
+	return err
+}
+
+const insertTariffValuationOptions = `-- name: InsertTariffValuationOptions :exec
+INSERT INTO tariff_valuation_options (tenant_id, code, name, cents_per_thousand, minimum_cents_per_lb)
+SELECT $1, unnest($2::text[]), unnest($3::text[]), unnest($4::bigint[]), unnest($5::bigint[])
+`
+
+type InsertTariffValuationOptionsParams struct {
+	TenantID          uuid.UUID `json:"tenant_id"`
+	Codes             []string  `json:"codes"`
+	Names             []string  `json:"names"`
+	CentsPerThousand  []int64   `json:"cents_per_thousand"`
+	MinimumCentsPerLb []int64   `json:"minimum_cents_per_lb"`
+}
+
+func (q *Queries) InsertTariffValuationOptions(ctx context.Context, arg InsertTariffValuationOptionsParams) error {
+	_, err := q.db.Exec(ctx, insertTariffValuationOptions,
+		arg.TenantID,
+		arg.Codes,
+		arg.Names,
+		arg.CentsPerThousand,
+		arg.MinimumCentsPerLb,
+	)
+	return err
+}
+
+const listAPITokens = `-- name: ListAPITokens :many
+SELECT id, name, token_prefix, scopes, expires_at, last_used_at, last_used_ip, created_at
+FROM api_tokens
+WHERE tenant_id = $1
+  AND user_id = $2
+  AND revoked_at IS NULL
+ORDER BY created_at DESC, id
+`
+
+type ListAPITokensParams struct {
+	TenantID uuid.UUID `json:"tenant_id"`
+	UserID   uuid.UUID `json:"user_id"`
+}
+
+type ListAPITokensRow struct {
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	TokenPrefix string     `json:"token_prefix"`
+	Scopes      []string   `json:"scopes"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+	LastUsedAt  *time.Time `json:"last_used_at"`
+	LastUsedIp  *string    `json:"last_used_ip"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+func (q *Queries) ListAPITokens(ctx context.Context, arg ListAPITokensParams) ([]ListAPITokensRow, error) {
+	rows, err := q.db.Query(ctx, listAPITokens, arg.TenantID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListAPITokensRow{}
+	for rows.Next() {
+		var i ListAPITokensRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.TokenPrefix,
+			&i.Scopes,
+			&i.ExpiresAt,
+			&i.LastUsedAt,
+			&i.LastUsedIp,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCalendarJobs = `-- name: ListCalendarJobs :many
+SELECT
+  j.id AS job_id,
+  j.job_number,
+  j.scheduled_date,
+  j.pickup_time,
+  COALESCE(NULLIF(TRIM(c.first_name || ' ' || c.last_name), ''), j.job_number) AS customer_name,
+  COALESCE(
+    NULLIF(CONCAT_WS(', ', NULLIF(TRIM(e.origin_city), ''), NULLIF(TRIM(e.origin_state), '')), ''),
+    'TBD'
+  )::text AS origin_short,
+  COALESCE(
+    NULLIF(CONCAT_WS(', ', NULLIF(TRIM(e.destination_city), ''), NULLIF(TRIM(e.destination_state), '')), ''),
+    'TBD'
+  )::text AS destination_short,
+  j.status,
+  FALSE AS has_storage,
+  GREATEST(j.balance_cents, 0)::bigint AS balance_due_cents
+FROM jobs j
+JOIN customers c
+  ON c.id = j.customer_id
+  AND c.tenant_id = j.tenant_id
+LEFT JOIN estimates e
+  ON e.id = j.estimate_id
+  AND e.tenant_id = j.tenant_id
+WHERE j.tenant_id = $1
+  AND j.scheduled_date IS NOT NULL
+  AND j.scheduled_date >= $2::date
+  AND j.scheduled_date < $3::date
+  AND ($4::text IS NULL OR j.status = $4::text)
+  AND (
+    $5::text IS NULL
+    OR (
+      CASE
+        WHEN e.id IS NULL THEN 'other'
+        WHEN NULLIF(TRIM(COALESCE(e.origin_state, '')), '') IS NULL THEN 'other'
+        WHEN NULLIF(TRIM(COALESCE(e.destination_state, '')), '') IS NULL THEN 'other'
+        WHEN UPPER(e.origin_state) = UPPER(e.destination_state) THEN 'local'
+        ELSE 'long_distance'
+      END
+    ) = $5::text
+  )
+ORDER BY j.scheduled_date ASC, COALESCE(j.pickup_time, ''), j.job_number ASC
+`
+
+type ListCalendarJobsParams struct {
+	TenantID uuid.UUID `json:"tenant_id"`
+	FromDate time.Time `json:"from_date"`
+	ToDate   time.Time `json:"to_date"`
+	Phase    *string   `json:"phase"`
+	JobType  *string   `json:"job_type"`
+}
+
+type ListCalendarJobsRow struct {
+	JobID            uuid.UUID  `json:"job_id"`
+	JobNumber        string     `json:"job_number"`
+	ScheduledDate    *time.Time `json:"scheduled_date"`
+	PickupTime       *string    `json:"pickup_time"`
+	CustomerName     string     `json:"customer_name"`
+	OriginShort      string     `json:"origin_short"`
+	DestinationShort string     `json:"destination_short"`
+	Status           string     `json:"status"`
+	HasStorage       bool       `json:"has_storage"`
+	BalanceDueCents  int64      `json:"balance_due_cents"`
+}
+
+func (q *Queries) ListCalendarJobs(ctx context.Context, arg ListCalendarJobsParams) ([]ListCalendarJobsRow, error) {
+	rows, err := q.db.Query(ctx, listCalendarJobs,
+		arg.TenantID,
+		arg.FromDate,
+		arg.ToDate,
+		arg.Phase,
+		arg.JobType,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListCalendarJobsRow{}
+	for rows.Next() {
+		var i ListCalendarJobsRow
+		if err := rows.Scan(
+			&i.JobID,
+			&i.JobNumber,
+			&i.ScheduledDate,
+			&i.PickupTime,
+			&i.CustomerName,
+			&i.OriginShort,
+			&i.DestinationShort,
+			&i.Status,
+			&i.HasStorage,
+			&i.BalanceDueCents,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCustomers = `-- name: ListCustomers :many
+SELECT
+  id,
+  tenant_id,
+  first_name,
+  last_name,
+  email,
+  phone,
+  created_by,
+  updated_by,
+  created_at,
+  updated_at
+FROM customers
+WHERE tenant_id = $1
+  AND (
+    $2::text IS NULL
+    OR TRIM(first_name || ' ' || last_name) ILIKE '%' || $2::text || '%'
+    OR COALESCE(email, '') ILIKE '%' || $2::text || '%'
+    OR COALESCE(phone, '') ILIKE '%' || $2::text || '%'
+    OR (
+      $3::text IS NOT NULL
+      AND regexp_replace(COALESCE(phone, ''), '[^0-9]', '', 'g') LIKE '%' || $3::text || '%'
+    )
+  )
+  AND (
+    $4::timestamptz IS NULL
+    OR (
+      created_at < $4::timestamptz
+      OR (
+        created_at = $4::timestamptz
+        AND id < $5::uuid
+      )
+    )
+  )
+ORDER BY created_at DESC, id DESC
+LIMIT $6
+`
+
+type ListCustomersParams struct {
+	TenantID          uuid.UUID  `json:"tenant_id"`
+	SearchQ           *string    `json:"search_q"`
+	SearchPhoneDigits *string    `json:"search_phone_digits"`
+	CursorCreatedAt   *time.Time `json:"cursor_created_at"`
+	CursorID          *uuid.UUID `json:"cursor_id"`
+	LimitRows         int32      `json:"limit_rows"`
+}
+
+func (q *Queries) ListCustomers(ctx context.Context, arg ListCustomersParams) ([]Customer, error) {
+	rows, err := q.db.Query(ctx, listCustomers,
+		arg.TenantID,
+		arg.SearchQ,
+		arg.SearchPhoneDigits,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.LimitRows,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Customer{}
+	for rows.Next() {
+		var i Customer
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.FirstName,
+			&i.LastName,
+			&i.Email,
+			&i.Phone,
+			&i.CreatedBy,
+			&i.UpdatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEmailOutbox = `-- name: ListEmailOutbox :many
+SELECT id, tenant_id, kind, estimate_id, job_id, storage_record_id, to_address, from_address, from_name, reply_to, subject, body, status, attempts, next_attempt_at, last_error, sent_at, created_by, created_at, updated_at
+FROM email_outbox
+WHERE tenant_id = $1
+  AND ($2::uuid IS NULL OR estimate_id = $2)
+  AND ($3::uuid IS NULL OR job_id = $3)
+  AND ($4::uuid IS NULL OR storage_record_id = $4)
+ORDER BY created_at DESC, id DESC
+LIMIT $5
+`
+
+type ListEmailOutboxParams struct {
+	TenantID        uuid.UUID  `json:"tenant_id"`
+	EstimateID      *uuid.UUID `json:"estimate_id"`
+	JobID           *uuid.UUID `json:"job_id"`
+	StorageRecordID *uuid.UUID `json:"storage_record_id"`
+	RowLimit        int32      `json:"row_limit"`
+}
+
+func (q *Queries) ListEmailOutbox(ctx context.Context, arg ListEmailOutboxParams) ([]EmailOutbox, error) {
+	rows, err := q.db.Query(ctx, listEmailOutbox,
+		arg.TenantID,
+		arg.EstimateID,
+		arg.JobID,
+		arg.StorageRecordID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []EmailOutbox{}
+	for rows.Next() {
+		var i EmailOutbox
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.Kind,
+			&i.EstimateID,
+			&i.JobID,
+			&i.StorageRecordID,
+			&i.ToAddress,
+			&i.FromAddress,
+			&i.FromName,
+			&i.ReplyTo,
+			&i.Subject,
+			&i.Body,
+			&i.Status,
+			&i.Attempts,
+			&i.NextAttemptAt,
+			&i.LastError,
+			&i.SentAt,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEmailTemplates = `-- name: ListEmailTemplates :many
+SELECT kind, subject, body, updated_at
+FROM email_templates
+WHERE tenant_id = $1
+ORDER BY kind
+`
+
+type ListEmailTemplatesRow struct {
+	Kind      string    `json:"kind"`
+	Subject   string    `json:"subject"`
+	Body      string    `json:"body"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (q *Queries) ListEmailTemplates(ctx context.Context, tenantID uuid.UUID) ([]ListEmailTemplatesRow, error) {
+	rows, err := q.db.Query(ctx, listEmailTemplates, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListEmailTemplatesRow{}
+	for rows.Next() {
+		var i ListEmailTemplatesRow
+		if err := rows.Scan(
+			&i.Kind,
+			&i.Subject,
+			&i.Body,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEstimateLineItems = `-- name: ListEstimateLineItems :many
+SELECT id, tenant_id, estimate_id, inventory_item_id, room, item_name, quantity, cubic_feet, weight_lbs, created_by, updated_by, created_at, updated_at
+FROM estimate_line_items
+WHERE tenant_id = $1
+  AND estimate_id = $2
+ORDER BY room, created_at, id
+`
+
+type ListEstimateLineItemsParams struct {
+	TenantID   uuid.UUID `json:"tenant_id"`
+	EstimateID uuid.UUID `json:"estimate_id"`
+}
+
+func (q *Queries) ListEstimateLineItems(ctx context.Context, arg ListEstimateLineItemsParams) ([]EstimateLineItem, error) {
+	rows, err := q.db.Query(ctx, listEstimateLineItems, arg.TenantID, arg.EstimateID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []EstimateLineItem{}
+	for rows.Next() {
+		var i EstimateLineItem
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.EstimateID,
+			&i.InventoryItemID,
+			&i.Room,
+			&i.ItemName,
+			&i.Quantity,
+			&i.CubicFeet,
+			&i.WeightLbs,
+			&i.CreatedBy,
+			&i.UpdatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEstimateRevisions = `-- name: ListEstimateRevisions :many
+SELECT
+  r.revision_number,
+  r.change,
+  r.restored_from,
+  r.created_by,
+  u.full_name AS created_by_name,
+  r.created_at
+FROM estimate_revisions r
+LEFT JOIN users u ON u.id = r.created_by
+WHERE r.tenant_id = $1
+  AND r.estimate_id = $2
+ORDER BY r.revision_number DESC
+`
+
+type ListEstimateRevisionsParams struct {
+	TenantID   uuid.UUID `json:"tenant_id"`
+	EstimateID uuid.UUID `json:"estimate_id"`
+}
+
+type ListEstimateRevisionsRow struct {
+	RevisionNumber int32      `json:"revision_number"`
+	Change         string     `json:"change"`
+	RestoredFrom   *int32     `json:"restored_from"`
+	CreatedBy      *uuid.UUID `json:"created_by"`
+	CreatedByName  *string    `json:"created_by_name"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+func (q *Queries) ListEstimateRevisions(ctx context.Context, arg ListEstimateRevisionsParams) ([]ListEstimateRevisionsRow, error) {
+	rows, err := q.db.Query(ctx, listEstimateRevisions, arg.TenantID, arg.EstimateID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListEstimateRevisionsRow{}
+	for rows.Next() {
+		var i ListEstimateRevisionsRow
+		if err := rows.Scan(
+			&i.RevisionNumber,
+			&i.Change,
+			&i.RestoredFrom,
+			&i.CreatedBy,
+			&i.CreatedByName,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEstimateSignatures = `-- name: ListEstimateSignatures :many
+SELECT
+  s.id,
+  s.tenant_id,
+  s.estimate_id,
+  s.link_id,
+  s.revision_number,
+  s.document_sha256,
+  s.signer_name,
+  s.signer_ip,
+  s.user_agent,
+  s.signed_at,
+  s.signature,
+  COALESCE(encode(sha256(convert_to(r.snapshot::text, 'UTF8')), 'hex'), '')::text AS revision_sha256
+FROM estimate_signatures s
+LEFT JOIN estimate_revisions r
+  ON r.estimate_id = s.estimate_id
+  AND r.revision_number = s.revision_number
+WHERE s.tenant_id = $1
+  AND s.estimate_id = $2
+ORDER BY s.signed_at DESC
+`
+
+type ListEstimateSignaturesParams struct {
+	TenantID   uuid.UUID `json:"tenant_id"`
+	EstimateID uuid.UUID `json:"estimate_id"`
+}
+
+type ListEstimateSignaturesRow struct {
+	ID             uuid.UUID  `json:"id"`
+	TenantID       uuid.UUID  `json:"tenant_id"`
+	EstimateID     uuid.UUID  `json:"estimate_id"`
+	LinkID         *uuid.UUID `json:"link_id"`
+	RevisionNumber int32      `json:"revision_number"`
+	DocumentSha256 string     `json:"document_sha256"`
+	SignerName     string     `json:"signer_name"`
+	SignerIp       string     `json:"signer_ip"`
+	UserAgent      string     `json:"user_agent"`
+	SignedAt       time.Time  `json:"signed_at"`
+	Signature      string     `json:"signature"`
+	RevisionSha256 string     `json:"revision_sha256"`
+}
+
+func (q *Queries) ListEstimateSignatures(ctx context.Context, arg ListEstimateSignaturesParams) ([]ListEstimateSignaturesRow, error) {
+	rows, err := q.db.Query(ctx, listEstimateSignatures, arg.TenantID, arg.EstimateID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListEstimateSignaturesRow{}
+	for rows.Next() {
+		var i ListEstimateSignaturesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.EstimateID,
+			&i.LinkID,
+			&i.RevisionNumber,
+			&i.DocumentSha256,
+			&i.SignerName,
+			&i.SignerIp,
+			&i.UserAgent,
+			&i.SignedAt,
+			&i.Signature,
+			&i.RevisionSha256,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEstimates = `-- name: ListEstimates :many
+SELECT
+  e.id,
+  e.estimate_number,
+  e.customer_id,
+  e.status,
+  e.customer_name,
+  e.primary_phone,
+  e.email,
+  COALESCE(
+    NULLIF(CONCAT_WS(', ', NULLIF(TRIM(e.origin_city), ''), NULLIF(TRIM(e.origin_state), '')), ''),
+    'TBD'
+  )::text AS origin_short,
+  COALESCE(
+    NULLIF(CONCAT_WS(', ', NULLIF(TRIM(e.destination_city), ''), NULLIF(TRIM(e.destination_state), '')), ''),
+    'TBD'
+  )::text AS destination_short,
+  e.move_date,
+  e.lead_source,
+  e.estimated_total_cents,
+  e.deposit_cents,
+  e.expires_at,
+  e.created_by,
+  e.created_at,
+  e.updated_at,
+  j.id AS converted_job_id
+FROM estimates e
+LEFT JOIN jobs j
+  ON j.tenant_id = e.tenant_id
+  AND j.estimate_id = e.id
+WHERE e.tenant_id = $1
+  AND ($2::text IS NULL OR e.status = $2::text)
+  AND ($3::text IS NULL OR lower(e.lead_source) = lower($3::text))
+  AND ($4::date IS NULL OR e.move_date >= $4::date)
+  AND ($5::date IS NULL OR e.move_date <= $5::date)
+  AND ($6::timestamptz IS NULL OR e.created_at >= $6::timestamptz)
+  AND ($7::timestamptz IS NULL OR e.created_at < $7::timestamptz)
+  AND ($8::uuid IS NULL OR e.created_by = $8::uuid)
+  AND (
+    $9::text IS NULL
+    OR e.customer_name ILIKE '%' || $9::text || '%'
+    OR e.email ILIKE '%' || $9::text || '%'
+    OR e.primary_phone ILIKE '%' || $9::text || '%'
+    OR COALESCE(e.secondary_phone, '') ILIKE '%' || $9::text || '%'
+  )
+  AND (
+    $10::timestamptz IS NULL
+    OR (
+      e.created_at < $10::timestamptz
+      OR (
+        e.created_at = $10::timestamptz
+        AND e.id < $11::uuid
+      )
+    )
+  )
+ORDER BY e.created_at DESC, e.id DESC
+LIMIT $12
+`
+
+type ListEstimatesParams struct {
+	TenantID        uuid.UUID  `json:"tenant_id"`
+	Status          *string    `json:"status"`
+	LeadSource      *string    `json:"lead_source"`
+	MoveDateFrom    *time.Time `json:"move_date_from"`
+	MoveDateTo      *time.Time `json:"move_date_to"`
+	CreatedFrom     *time.Time `json:"created_from"`
+	CreatedTo       *time.Time `json:"created_to"`
+	CreatedBy       *uuid.UUID `json:"created_by"`
+	SearchQ         *string    `json:"search_q"`
+	CursorCreatedAt *time.Time `json:"cursor_created_at"`
+	CursorID        *uuid.UUID `json:"cursor_id"`
+	LimitRows       int32      `json:"limit_rows"`
+}
+
+type ListEstimatesRow struct {
+	ID                  uuid.UUID  `json:"id"`
+	EstimateNumber      string     `json:"estimate_number"`
+	CustomerID          uuid.UUID  `json:"customer_id"`
+	Status              string     `json:"status"`
+	CustomerName        string     `json:"customer_name"`
+	PrimaryPhone        string     `json:"primary_phone"`
+	Email               string     `json:"email"`
+	OriginShort         string     `json:"origin_short"`
+	DestinationShort    string     `json:"destination_short"`
+	MoveDate            time.Time  `json:"move_date"`
+	LeadSource          string     `json:"lead_source"`
+	EstimatedTotalCents *int64     `json:"estimated_total_cents"`
+	DepositCents        *int64     `json:"deposit_cents"`
+	ExpiresAt           *time.Time `json:"expires_at"`
+	CreatedBy           *uuid.UUID `json:"created_by"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+	ConvertedJobID      *uuid.UUID `json:"converted_job_id"`
+}
+
+func (q *Queries) ListEstimates(ctx context.Context, arg ListEstimatesParams) ([]ListEstimatesRow, error) {
+	rows, err := q.db.Query(ctx, listEstimates,
+		arg.TenantID,
+		arg.Status,
+		arg.LeadSource,
+		arg.MoveDateFrom,
+		arg.MoveDateTo,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.CreatedBy,
+		arg.SearchQ,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.LimitRows,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListEstimatesRow{}
+	for rows.Next() {
+		var i ListEstimatesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.EstimateNumber,
+			&i.CustomerID,
+			&i.Status,
+			&i.CustomerName,
+			&i.PrimaryPhone,
+			&i.Email,
+			&i.OriginShort,
+			&i.DestinationShort,
+			&i.MoveDate,
+			&i.LeadSource,
+			&i.EstimatedTotalCents,
+			&i.DepositCents,
+			&i.ExpiresAt,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ConvertedJobID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listImportRowResultsByRun = `-- name: ListImportRowResultsByRun :many
+SELECT
+  id,
+  tenant_id,
+  import_run_id,
+  row_number,
+  severity,
+  entity_type,
+  idempotency_key,
+  result,
+  field,
+  message,
+  raw_value,
+  target_entity_id,
+  created_at
+FROM import_row_result
+WHERE tenant_id = $1
+  AND import_run_id = $2
+ORDER BY row_number ASC, created_at ASC
+`
+
+type ListImportRowResultsByRunParams struct {
+	TenantID    uuid.UUID `json:"tenant_id"`
+	ImportRunID uuid.UUID `json:"import_run_id"`
+}
+
+func (q *Queries) ListImportRowResultsByRun(ctx context.Context, arg ListImportRowResultsByRunParams) ([]ImportRowResult, error) {
+	rows, err := q.db.Query(ctx, listImportRowResultsByRun, arg.TenantID, arg.ImportRunID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ImportRowResult{}
+	for rows.Next() {
+		var i ImportRowResult
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.ImportRunID,
+			&i.RowNumber,
+			&i.Severity,
+			&i.EntityType,
+			&i.IdempotencyKey,
+			&i.Result,
+			&i.Field,
+			&i.Message,
+			&i.RawValue,
+			&i.TargetEntityID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listImportRowResultsByRunAndSeverity = `-- name: ListImportRowResultsByRunAndSeverity :many
+SELECT
+  id,
+  tenant_id,
+  import_run_id,
+  row_number,
+  severity,
+  entity_type,
+  idempotency_key,
+  result,
+  field,
+  message,
+  raw_value,
+  target_entity_id,
+  created_at
+FROM import_row_result
+WHERE tenant_id = $1
+  AND import_run_id = $2
+  AND severity = $3
+ORDER BY row_number ASC, created_at ASC
+LIMIT $4
+`
+
+type ListImportRowResultsByRunAndSeverityParams struct {
+	TenantID    uuid.UUID `json:"tenant_id"`
+	ImportRunID uuid.UUID `json:"import_run_id"`
+	Severity    string    `json:"severity"`
+	LimitRows   int32     `json:"limit_rows"`
+}
+
+func (q *Queries) ListImportRowResultsByRunAndSeverity(ctx context.Context, arg ListImportRowResultsByRunAndSeverityParams) ([]ImportRowResult, error) {
+	rows, err := q.db.Query(ctx, listImportRowResultsByRunAndSeverity,
+		arg.TenantID,
+		arg.ImportRunID,
+		arg.Severity,
+		arg.LimitRows,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ImportRowResult{}
+	for rows.Next() {
+		var i ImportRowResult
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.ImportRunID,
+			&i.RowNumber,
+			&i.Severity,
+			&i.EntityType,
+			&i.IdempotencyKey,
+			&i.Result,
+			&i.Field,
+			&i.Message,
+			&i.RawValue,
+			&i.TargetEntityID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listInventoryItems = `-- name: ListInventoryItems :many
+SELECT id, tenant_id, name, default_room, cubic_feet, weight_lbs, active, created_by, updated_by, created_at, updated_at
+FROM inventory_items
+WHERE tenant_id = $1
+  AND ($2::boolean OR active)
+ORDER BY lower(name), id
+`
+
+type ListInventoryItemsParams struct {
+	TenantID        uuid.UUID `json:"tenant_id"`
+	IncludeInactive bool      `json:"include_inactive"`
+}
+
+func (q *Queries) ListInventoryItems(ctx context.Context, arg ListInventoryItemsParams) ([]InventoryItem, error) {
+	rows, err := q.db.Query(ctx, listInventoryItems, arg.TenantID, arg.IncludeInactive)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []InventoryItem{}
+	for rows.Next() {
+		var i InventoryItem
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.Name,
+			&i.DefaultRoom,
+			&i.CubicFeet,
+			&i.WeightLbs,
+			&i.Active,
+			&i.CreatedBy,
+			&i.UpdatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listJobStatusHistory = `-- name: ListJobStatusHistory :many
+SELECT
+  h.id,
+  h.from_status,
+  h.to_status,
+  h.reason,
+  h.changed_by,
+  u.full_name AS changed_by_name,
+  h.changed_at
+FROM job_status_history h
+LEFT JOIN users u ON u.id = h.changed_by
+WHERE h.tenant_id = $1
+  AND h.job_id = $2
+ORDER BY h.changed_at ASC, h.id ASC
+`
+
+type ListJobStatusHistoryParams struct {
+	TenantID uuid.UUID `json:"tenant_id"`
+	JobID    uuid.UUID `json:"job_id"`
+}
+
+type ListJobStatusHistoryRow struct {
+	ID            uuid.UUID  `json:"id"`
+	FromStatus    *string    `json:"from_status"`
+	ToStatus      string     `json:"to_status"`
+	Reason        *string    `json:"reason"`
+	ChangedBy     *uuid.UUID `json:"changed_by"`
+	ChangedByName *string    `json:"changed_by_name"`
+	ChangedAt     time.Time  `json:"changed_at"`
+}
+
+func (q *Queries) ListJobStatusHistory(ctx context.Context, arg ListJobStatusHistoryParams) ([]ListJobStatusHistoryRow, error) {
+	rows, err := q.db.Query(ctx, listJobStatusHistory, arg.TenantID, arg.JobID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListJobStatusHistoryRow{}
+	for rows.Next() {
+		var i ListJobStatusHistoryRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromStatus,
+			&i.ToStatus,
+			&i.Reason,
+			&i.ChangedBy,
+			&i.ChangedByName,
+			&i.ChangedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listJobs = `-- name: ListJobs :many
+SELECT
+  j.id,
+  j.job_number,
+  j.customer_id,
+  j.estimate_id,
+  j.status,
+  j.scheduled_date,
+  j.pickup_time,
+  COALESCE(NULLIF(TRIM(c.first_name || ' ' || c.last_name), ''), j.job_number) AS customer_name,
+  (CASE WHEN j.convert_idempotency_key IS NOT NULL THEN 'estimate' ELSE 'import' END)::text AS source,
+  COALESCE(
+    NULLIF(CONCAT_WS(', ', NULLIF(TRIM(e.origin_city), ''), NULLIF(TRIM(e.origin_state), '')), ''),
+    'TBD'
+  )::text AS origin_short,
+  COALESCE(
+    NULLIF(CONCAT_WS(', ', NULLIF(TRIM(e.destination_city), ''), NULLIF(TRIM(e.destination_state), '')), ''),
+    'TBD'
+  )::text AS destination_short,
+  GREATEST(j.balance_cents, 0)::bigint AS balance_due_cents,
+  j.created_at,
+  j.updated_at
+FROM jobs j
+JOIN customers c
+  ON c.id = j.customer_id
+  AND c.tenant_id = j.tenant_id
+LEFT JOIN estimates e
+  ON e.id = j.estimate_id
+  AND e.tenant_id = j.tenant_id
+WHERE j.tenant_id = $1
+  AND ($2::text IS NULL OR j.status = $2::text)
+  AND (
+    $3::boolean IS NULL
+    OR ($3::boolean = TRUE AND j.scheduled_date IS NOT NULL)
+    OR ($3::boolean = FALSE AND j.scheduled_date IS NULL)
+  )
+  AND ($4::uuid IS NULL OR j.customer_id = $4::uuid)
+  AND (
+    $5::text IS NULL
+    OR ($5::text = 'estimate' AND j.convert_idempotency_key IS NOT NULL)
+    OR ($5::text = 'import' AND j.convert_idempotency_key IS NULL)
+  )
+  AND (
+    $6::boolean IS NULL
+    OR (
+      $6::boolean = TRUE
+      AND j.balance_cents > 0
+    )
+    OR (
+      $6::boolean = FALSE
+      AND j.balance_cents <= 0
+    )
+  )
+  AND (
+    $7::timestamptz IS NULL
+    OR (
+      j.created_at < $7::timestamptz
+      OR (
+        j.created_at = $7::timestamptz
+        AND j.id < $8::uuid
+      )
+    )
+  )
+ORDER BY j.created_at DESC, j.id DESC
+LIMIT $9
+`
+
+type ListJobsParams struct {
+	TenantID        uuid.UUID  `json:"tenant_id"`
+	Status          *string    `json:"status"`
+	Scheduled       *bool      `json:"scheduled"`
+	CustomerID      *uuid.UUID `json:"customer_id"`
+	Source          *string    `json:"source"`
+	BalanceDue      *bool      `json:"balance_due"`
+	CursorCreatedAt *time.Time `json:"cursor_created_at"`
+	CursorID        *uuid.UUID `json:"cursor_id"`
+	LimitRows       int32      `json:"limit_rows"`
+}
+
+type ListJobsRow struct {
+	ID               uuid.UUID  `json:"id"`
+	JobNumber        string     `json:"job_number"`
+	CustomerID       uuid.UUID  `json:"customer_id"`
+	EstimateID       *uuid.UUID `json:"estimate_id"`
+	Status           string     `json:"status"`
+	ScheduledDate    *time.Time `json:"scheduled_date"`
+	PickupTime       *string    `json:"pickup_time"`
+	CustomerName     string     `json:"customer_name"`
+	Source           string     `json:"source"`
+	OriginShort      string     `json:"origin_short"`
+	DestinationShort string     `json:"destination_short"`
+	BalanceDueCents  int64      `json:"balance_due_cents"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+func (q *Queries) ListJobs(ctx context.Context, arg ListJobsParams) ([]ListJobsRow, error) {
+	rows, err := q.db.Query(ctx, listJobs,
+		arg.TenantID,
+		arg.Status,
+		arg.Scheduled,
+		arg.CustomerID,
+		arg.Source,
+		arg.BalanceDue,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.LimitRows,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListJobsRow{}
+	for rows.Next() {
+		var i ListJobsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.JobNumber,
+			&i.CustomerID,
+			&i.EstimateID,
+			&i.Status,
+			&i.ScheduledDate,
+			&i.PickupTime,
+			&i.CustomerName,
+			&i.Source,
+			&i.OriginShort,
+			&i.DestinationShort,
+			&i.BalanceDueCents,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLedgerEntries = `-- name: ListLedgerEntries :many
+SELECT
+  id,
+  tenant_id,
+  job_id,
+  storage_record_id,
+  account,
+  kind,
+  amount_cents,
+  balance_effect_cents,
+  method,
+  reference,
+  memo,
+  occurred_at,
+  created_by,
+  created_at,
+  (SUM(balance_effect_cents) OVER (ORDER BY occurred_at, created_at, id))::bigint AS balance_after_cents
+FROM ledger_entries
+WHERE tenant_id = $1
+  AND job_id = $2
+  AND account = $3
+ORDER BY occurred_at, created_at, id
+`
+
+type ListLedgerEntriesParams struct {
+	TenantID uuid.UUID `json:"tenant_id"`
+	JobID    uuid.UUID `json:"job_id"`
+	Account  string    `json:"account"`
+}
+
+type ListLedgerEntriesRow struct {
+	ID                 uuid.UUID  `json:"id"`
+	TenantID           uuid.UUID  `json:"tenant_id"`
+	JobID              uuid.UUID  `json:"job_id"`
+	StorageRecordID    *uuid.UUID `json:"storage_record_id"`
+	Account            string     `json:"account"`
+	Kind               string     `json:"kind"`
+	AmountCents        int64      `json:"amount_cents"`
+	BalanceEffectCents int64      `json:"balance_effect_cents"`
+	Method             *string    `json:"method"`
+	Reference          *string    `json:"reference"`
+	Memo               *string    `json:"memo"`
+	OccurredAt         time.Time  `json:"occurred_at"`
+	CreatedBy          *uuid.UUID `json:"created_by"`
+	CreatedAt          time.Time  `json:"created_at"`
+	BalanceAfterCents  int64      `json:"balance_after_cents"`
+}
+
+func (q *Queries) ListLedgerEntries(ctx context.Context, arg ListLedgerEntriesParams) ([]ListLedgerEntriesRow, error) {
+	rows, err := q.db.Query(ctx, listLedgerEntries, arg.TenantID, arg.JobID, arg.Account)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListLedgerEntriesRow{}
+	for rows.Next() {
+		var i ListLedgerEntriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.JobID,
+			&i.StorageRecordID,
+			&i.Account,
+			&i.Kind,
+			&i.AmountCents,
+			&i.BalanceEffectCents,
+			&i.Method,
+			&i.Reference,
+			&i.Memo,
+			&i.OccurredAt,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.BalanceAfterCents,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPermissions = `-- name: ListPermissions :many
+SELECT
+  name,
+  description
+FROM permissions
+ORDER BY name ASC
+`
+
+type ListPermissionsRow struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+func (q *Queries) ListPermissions(ctx context.Context) ([]ListPermissionsRow, error) {
+	rows, err := q.db.Query(ctx, listPermissions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListPermissionsRow{}
+	for rows.Next() {
+		var i ListPermissionsRow
+		if err := rows.Scan(&i.Name, &i.Description); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSSOGroupRoleMappings = `-- name: ListSSOGroupRoleMappings :many
+SELECT group_name, role_id
+FROM sso_group_role_mappings
+WHERE tenant_id = $1
+ORDER BY group_name, role_id
+`
+
+type ListSSOGroupRoleMappingsRow struct {
+	GroupName string    `json:"group_name"`
+	RoleID    uuid.UUID `json:"role_id"`
+}
+
+func (q *Queries) ListSSOGroupRoleMappings(ctx context.Context, tenantID uuid.UUID) ([]ListSSOGroupRoleMappingsRow, error) {
+	rows, err := q.db.Query(ctx, listSSOGroupRoleMappings, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListSSOGroupRoleMappingsRow{}
+	for rows.Next() {
+		var i ListSSOGroupRoleMappingsRow
+		if err := rows.Scan(&i.GroupName, &i.RoleID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStorageRows = `-- name: ListStorageRows :many
+SELECT
+  sr.id AS storage_record_id,
+  j.id AS job_id,
+  j.job_number,
+  COALESCE(NULLIF(TRIM(c.first_name || ' ' || c.last_name), ''), COALESCE(e.customer_name, j.job_number))::text AS customer_name,
+  CASE
+    WHEN e.id IS NULL THEN 'other'
+    WHEN NULLIF(TRIM(COALESCE(e.origin_state, '')), '') IS NULL THEN 'other'
+    WHEN NULLIF(TRIM(COALESCE(e.destination_state, '')), '') IS NULL THEN 'other'
+    WHEN UPPER(e.origin_state) = UPPER(e.destination_state) THEN 'local'
+    ELSE 'long_distance'
+  END::text AS move_type,
+  COALESCE(
+    NULLIF(CONCAT_WS(', ', NULLIF(TRIM(e.origin_city), ''), NULLIF(TRIM(e.origin_state), '')), ''),
+    'TBD'
+  )::text AS from_short,
+  COALESCE(
+    NULLIF(CONCAT_WS(', ', NULLIF(TRIM(e.destination_city), ''), NULLIF(TRIM(e.destination_state), '')), ''),
+    'TBD'
+  )::text AS to_short,
+  sr.status,
+  sr.date_in,
+  sr.date_out,
+  sr.next_bill_date,
+  sr.lot_number,
+  sr.location_label,
+  COALESCE(sr.vaults, 0)::int AS vaults,
+  COALESCE(sr.pads, 0)::int AS pads,
+  COALESCE(sr.items, 0)::int AS items,
+  COALESCE(sr.oversize_items, 0)::int AS oversize_items,
+  COALESCE(sr.volume, 0)::int AS volume,
+  sr.monthly_rate_cents,
+  COALESCE(sr.storage_balance_cents, 0)::bigint AS storage_balance_cents,
+  COALESCE(sr.move_balance_cents, 0)::bigint AS move_balance_cents,
+  COALESCE(sr.facility, $1)::text AS facility,
+  COALESCE(sr.updated_at, j.updated_at) AS sort_updated_at,
+  j.id AS sort_job_id
+FROM jobs j
+JOIN customers c
+  ON c.id = j.customer_id
+  AND c.tenant_id = j.tenant_id
+LEFT JOIN estimates e
+  ON e.id = j.estimate_id
+  AND e.tenant_id = j.tenant_id
+LEFT JOIN storage_record sr
   ON sr.job_id = j.id
   AND sr.tenant_id = j.tenant_id
 WHERE j.tenant_id = $2
@@ -2501,148 +6114,1550 @@ ORDER BY COALESCE(sr.updated_at, j.updated_at) DESC, j.id DESC
 LIMIT $11
 `
 
-type ListStorageRowsParams struct {
-	Facility        string     `json:"facility"`
-	TenantID        uuid.UUID  `json:"tenant_id"`
-	SearchQ         *string    `json:"search_q"`
-	Status          *string    `json:"status"`
-	HasDateOut      *bool      `json:"has_date_out"`
-	BalanceDue      *bool      `json:"balance_due"`
-	HasContainers   *bool      `json:"has_containers"`
-	PastDueDays     *int32     `json:"past_due_days"`
-	CursorUpdatedAt *time.Time `json:"cursor_updated_at"`
-	CursorJobID     *uuid.UUID `json:"cursor_job_id"`
-	LimitRows       int32      `json:"limit_rows"`
+type ListStorageRowsParams struct {
+	Facility        string     `json:"facility"`
+	TenantID        uuid.UUID  `json:"tenant_id"`
+	SearchQ         *string    `json:"search_q"`
+	Status          *string    `json:"status"`
+	HasDateOut      *bool      `json:"has_date_out"`
+	BalanceDue      *bool      `json:"balance_due"`
+	HasContainers   *bool      `json:"has_containers"`
+	PastDueDays     *int32     `json:"past_due_days"`
+	CursorUpdatedAt *time.Time `json:"cursor_updated_at"`
+	CursorJobID     *uuid.UUID `json:"cursor_job_id"`
+	LimitRows       int32      `json:"limit_rows"`
+}
+
+type ListStorageRowsRow struct {
+	StorageRecordID     *uuid.UUID `json:"storage_record_id"`
+	JobID               uuid.UUID  `json:"job_id"`
+	JobNumber           string     `json:"job_number"`
+	CustomerName        string     `json:"customer_name"`
+	MoveType            string     `json:"move_type"`
+	FromShort           string     `json:"from_short"`
+	ToShort             string     `json:"to_short"`
+	Status              *string    `json:"status"`
+	DateIn              *time.Time `json:"date_in"`
+	DateOut             *time.Time `json:"date_out"`
+	NextBillDate        *time.Time `json:"next_bill_date"`
+	LotNumber           *string    `json:"lot_number"`
+	LocationLabel       *string    `json:"location_label"`
+	Vaults              int32      `json:"vaults"`
+	Pads                int32      `json:"pads"`
+	Items               int32      `json:"items"`
+	OversizeItems       int32      `json:"oversize_items"`
+	Volume              int32      `json:"volume"`
+	MonthlyRateCents    *int64     `json:"monthly_rate_cents"`
+	StorageBalanceCents int64      `json:"storage_balance_cents"`
+	MoveBalanceCents    int64      `json:"move_balance_cents"`
+	Facility            string     `json:"facility"`
+	SortUpdatedAt       time.Time  `json:"sort_updated_at"`
+	SortJobID           uuid.UUID  `json:"sort_job_id"`
+}
+
+func (q *Queries) ListStorageRows(ctx context.Context, arg ListStorageRowsParams) ([]ListStorageRowsRow, error) {
+	rows, err := q.db.Query(ctx, listStorageRows,
+		arg.Facility,
+		arg.TenantID,
+		arg.SearchQ,
+		arg.Status,
+		arg.HasDateOut,
+		arg.BalanceDue,
+		arg.HasContainers,
+		arg.PastDueDays,
+		arg.CursorUpdatedAt,
+		arg.CursorJobID,
+		arg.LimitRows,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListStorageRowsRow{}
+	for rows.Next() {
+		var i ListStorageRowsRow
+		if err := rows.Scan(
+			&i.StorageRecordID,
+			&i.JobID,
+			&i.JobNumber,
+			&i.CustomerName,
+			&i.MoveType,
+			&i.FromShort,
+			&i.ToShort,
+			&i.Status,
+			&i.DateIn,
+			&i.DateOut,
+			&i.NextBillDate,
+			&i.LotNumber,
+			&i.LocationLabel,
+			&i.Vaults,
+			&i.Pads,
+			&i.Items,
+			&i.OversizeItems,
+			&i.Volume,
+			&i.MonthlyRateCents,
+			&i.StorageBalanceCents,
+			&i.MoveBalanceCents,
+			&i.Facility,
+			&i.SortUpdatedAt,
+			&i.SortJobID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTariffAccessorials = `-- name: ListTariffAccessorials :many
+SELECT code, name, unit, rate_cents
+FROM tariff_accessorials
+WHERE tenant_id = $1
+ORDER BY code
+`
+
+type ListTariffAccessorialsRow struct {
+	Code      string `json:"code"`
+	Name      string `json:"name"`
+	Unit      string `json:"unit"`
+	RateCents int64  `json:"rate_cents"`
+}
+
+func (q *Queries) ListTariffAccessorials(ctx context.Context, tenantID uuid.UUID) ([]ListTariffAccessorialsRow, error) {
+	rows, err := q.db.Query(ctx, listTariffAccessorials, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListTariffAccessorialsRow{}
+	for rows.Next() {
+		var i ListTariffAccessorialsRow
+		if err := rows.Scan(
+			&i.Code,
+			&i.Name,
+			&i.Unit,
+			&i.RateCents,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTariffCrewRates = `-- name: ListTariffCrewRates :many
+SELECT crew_size, hourly_rate_cents
+FROM tariff_crew_rates
+WHERE tenant_id = $1
+ORDER BY crew_size
+`
+
+type ListTariffCrewRatesRow struct {
+	CrewSize        int32 `json:"crew_size"`
+	HourlyRateCents int64 `json:"hourly_rate_cents"`
+}
+
+func (q *Queries) ListTariffCrewRates(ctx context.Context, tenantID uuid.UUID) ([]ListTariffCrewRatesRow, error) {
+	rows, err := q.db.Query(ctx, listTariffCrewRates, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListTariffCrewRatesRow{}
+	for rows.Next() {
+		var i ListTariffCrewRatesRow
+		if err := rows.Scan(&i.CrewSize, &i.HourlyRateCents); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTariffValuationOptions = `-- name: ListTariffValuationOptions :many
+SELECT code, name, cents_per_thousand, minimum_cents_per_lb
+FROM tariff_valuation_options
+WHERE tenant_id = $1
+ORDER BY code
+`
+
+type ListTariffValuationOptionsRow struct {
+	Code              string `json:"code"`
+	Name              string `json:"name"`
+	CentsPerThousand  int64  `json:"cents_per_thousand"`
+	MinimumCentsPerLb int64  `json:"minimum_cents_per_lb"`
+}
+
+func (q *Queries) ListTariffValuationOptions(ctx context.Context, tenantID uuid.UUID) ([]ListTariffValuationOptionsRow, error) {
+	rows, err := q.db.Query(ctx, listTariffValuationOptions, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListTariffValuationOptionsRow{}
+	for rows.Next() {
+		var i ListTariffValuationOptionsRow
+		if err := rows.Scan(
+			&i.Code,
+			&i.Name,
+			&i.CentsPerThousand,
+			&i.MinimumCentsPerLb,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTenantRoles = `-- name: ListTenantRoles :many
+SELECT
+  r.id,
+  r.name,
+  r.description,
+  r.created_at,
+  COALESCE(
+    (
+      SELECT ARRAY_AGG(p.name ORDER BY p.name)
+      FROM role_permissions rp
+      JOIN permissions p ON p.id = rp.permission_id
+      WHERE rp.role_id = r.id
+    ),
+    '{}'
+  )::text[] AS permission_names,
+  (
+    SELECT COUNT(*)
+    FROM user_roles ur
+    WHERE ur.role_id = r.id
+      AND ur.tenant_id = r.tenant_id
+  )::bigint AS user_count
+FROM roles r
+WHERE r.tenant_id = $1
+ORDER BY lower(r.name) ASC
+`
+
+type ListTenantRolesRow struct {
+	ID              uuid.UUID `json:"id"`
+	Name            string    `json:"name"`
+	Description     string    `json:"description"`
+	CreatedAt       time.Time `json:"created_at"`
+	PermissionNames []string  `json:"permission_names"`
+	UserCount       int64     `json:"user_count"`
+}
+
+func (q *Queries) ListTenantRoles(ctx context.Context, tenantID uuid.UUID) ([]ListTenantRolesRow, error) {
+	rows, err := q.db.Query(ctx, listTenantRoles, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListTenantRolesRow{}
+	for rows.Next() {
+		var i ListTenantRolesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.CreatedAt,
+			&i.PermissionNames,
+			&i.UserCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTenantUsers = `-- name: ListTenantUsers :many
+SELECT
+  u.id,
+  u.email,
+  u.full_name,
+  u.is_active,
+  u.created_at,
+  u.updated_at,
+  COALESCE(
+    ARRAY_AGG(r.name ORDER BY r.name) FILTER (WHERE r.id IS NOT NULL),
+    '{}'
+  )::text[] AS role_names,
+  f.locked_until
+FROM users u
+LEFT JOIN user_login_failures f
+  ON f.user_id = u.id
+LEFT JOIN user_roles ur
+  ON ur.user_id = u.id
+  AND ur.tenant_id = u.tenant_id
+LEFT JOIN roles r
+  ON r.id = ur.role_id
+  AND r.tenant_id = u.tenant_id
+WHERE u.tenant_id = $1
+GROUP BY u.id, f.user_id
+ORDER BY lower(u.email) ASC
+`
+
+type ListTenantUsersRow struct {
+	ID          uuid.UUID  `json:"id"`
+	Email       string     `json:"email"`
+	FullName    string     `json:"full_name"`
+	IsActive    bool       `json:"is_active"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	RoleNames   []string   `json:"role_names"`
+	LockedUntil *time.Time `json:"locked_until"`
+}
+
+func (q *Queries) ListTenantUsers(ctx context.Context, tenantID uuid.UUID) ([]ListTenantUsersRow, error) {
+	rows, err := q.db.Query(ctx, listTenantUsers, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListTenantUsersRow{}
+	for rows.Next() {
+		var i ListTenantUsersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Email,
+			&i.FullName,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.RoleNames,
+			&i.LockedUntil,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserPermissions = `-- name: ListUserPermissions :many
+SELECT DISTINCT p.name
+FROM user_roles ur
+JOIN roles r ON r.id = ur.role_id
+JOIN role_permissions rp ON rp.role_id = r.id
+JOIN permissions p ON p.id = rp.permission_id
+WHERE ur.user_id = $1
+  AND ur.tenant_id = $2
+  AND r.tenant_id = $2
+ORDER BY p.name
+`
+
+type ListUserPermissionsParams struct {
+	UserID   uuid.UUID `json:"user_id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+}
+
+func (q *Queries) ListUserPermissions(ctx context.Context, arg ListUserPermissionsParams) ([]string, error) {
+	rows, err := q.db.Query(ctx, listUserPermissions, arg.UserID, arg.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		items = append(items, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserRoleIDs = `-- name: ListUserRoleIDs :many
+SELECT role_id
+FROM user_roles
+WHERE user_id = $1
+  AND tenant_id = $2
+`
+
+type ListUserRoleIDsParams struct {
+	UserID   uuid.UUID `json:"user_id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+}
+
+func (q *Queries) ListUserRoleIDs(ctx context.Context, arg ListUserRoleIDsParams) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, listUserRoleIDs, arg.UserID, arg.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var role_id uuid.UUID
+		if err := rows.Scan(&role_id); err != nil {
+			return nil, err
+		}
+		items = append(items, role_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserSessions = `-- name: ListUserSessions :many
+SELECT
+  id,
+  created_at,
+  last_seen_at,
+  expires_at,
+  user_agent,
+  ip_address
+FROM sessions
+WHERE user_id = $1
+  AND tenant_id = $2
+  AND revoked_at IS NULL
+  AND expires_at > NOW()
+  AND mfa_pending = FALSE
+ORDER BY COALESCE(last_seen_at, created_at) DESC, id
+`
+
+type ListUserSessionsParams struct {
+	UserID   uuid.UUID `json:"user_id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+}
+
+type ListUserSessionsRow struct {
+	ID         uuid.UUID  `json:"id"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastSeenAt *time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	UserAgent  string     `json:"user_agent"`
+	IpAddress  string     `json:"ip_address"`
+}
+
+func (q *Queries) ListUserSessions(ctx context.Context, arg ListUserSessionsParams) ([]ListUserSessionsRow, error) {
+	rows, err := q.db.Query(ctx, listUserSessions, arg.UserID, arg.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListUserSessionsRow{}
+	for rows.Next() {
+		var i ListUserSessionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.LastSeenAt,
+			&i.ExpiresAt,
+			&i.UserAgent,
+			&i.IpAddress,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsersByEmail = `-- name: ListUsersByEmail :many
+SELECT
+  u.id,
+  u.tenant_id,
+  u.email,
+  u.full_name,
+  u.password_hash,
+  u.is_active,
+  t.slug AS tenant_slug,
+  t.name AS tenant_name
+FROM users u
+JOIN tenants t ON t.id = u.tenant_id
+WHERE lower(u.email) = lower($1)
+ORDER BY t.name, t.id
+`
+
+type ListUsersByEmailRow struct {
+	ID           uuid.UUID `json:"id"`
+	TenantID     uuid.UUID `json:"tenant_id"`
+	Email        string    `json:"email"`
+	FullName     string    `json:"full_name"`
+	PasswordHash string    `json:"password_hash"`
+	IsActive     bool      `json:"is_active"`
+	TenantSlug   string    `json:"tenant_slug"`
+	TenantName   string    `json:"tenant_name"`
+}
+
+func (q *Queries) ListUsersByEmail(ctx context.Context, email string) ([]ListUsersByEmailRow, error) {
+	rows, err := q.db.Query(ctx, listUsersByEmail, email)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListUsersByEmailRow{}
+	for rows.Next() {
+		var i ListUsersByEmailRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.Email,
+			&i.FullName,
+			&i.PasswordHash,
+			&i.IsActive,
+			&i.TenantSlug,
+			&i.TenantName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockEstimate = `-- name: LockEstimate :one
+SELECT id
+FROM estimates
+WHERE id = $1
+  AND tenant_id = $2
+FOR UPDATE
+`
+
+type LockEstimateParams struct {
+	ID       uuid.UUID `json:"id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+}
+
+func (q *Queries) LockEstimate(ctx context.Context, arg LockEstimateParams) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, lockEstimate, arg.ID, arg.TenantID)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
+const lockEstimateAcceptanceLink = `-- name: LockEstimateAcceptanceLink :one
+SELECT id, tenant_id, estimate_id, auto_convert, expires_at, revoked_at, accepted_at, first_viewed_at, created_by, created_at
+FROM estimate_acceptance_links
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) LockEstimateAcceptanceLink(ctx context.Context, id uuid.UUID) (EstimateAcceptanceLink, error) {
+	row := q.db.QueryRow(ctx, lockEstimateAcceptanceLink, id)
+	var i EstimateAcceptanceLink
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.EstimateID,
+		&i.AutoConvert,
+		&i.ExpiresAt,
+		&i.RevokedAt,
+		&i.AcceptedAt,
+		&i.FirstViewedAt,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const lockTenantRoles = `-- name: LockTenantRoles :exec
+SELECT id
+FROM roles
+WHERE tenant_id = $1
+FOR UPDATE
+`
+
+func (q *Queries) LockTenantRoles(ctx context.Context, tenantID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, lockTenantRoles, tenantID)
+	return err
+}
+
+const lockUserLogin = `-- name: LockUserLogin :exec
+UPDATE user_login_failures
+SET failed_count = 0,
+    lock_count = lock_count + 1,
+    locked_until = $1,
+    updated_at = NOW()
+WHERE user_id = $2
+`
+
+type LockUserLoginParams struct {
+	LockedUntil *time.Time `json:"locked_until"`
+	UserID      uuid.UUID  `json:"user_id"`
+}
+
+func (q *Queries) LockUserLogin(ctx context.Context, arg LockUserLoginParams) error {
+	_, err := q.db.Exec(ctx, lockUserLogin, arg.LockedUntil, arg.UserID)
+	return err
+}
+
+const markEmailFailed = `-- name: MarkEmailFailed :execrows
+UPDATE email_outbox
+SET
+  status = 'failed',
+  last_error = $1,
+  updated_at = NOW()
+WHERE id = $2
+  AND status = 'sending'
+  AND attempts = $3
+`
+
+type MarkEmailFailedParams struct {
+	LastError *string   `json:"last_error"`
+	ID        uuid.UUID `json:"id"`
+	Attempts  int32     `json:"attempts"`
+}
+
+func (q *Queries) MarkEmailFailed(ctx context.Context, arg MarkEmailFailedParams) (int64, error) {
+	result, err := q.db.Exec(ctx, markEmailFailed, arg.LastError, arg.ID, arg.Attempts)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const markEmailRetry = `-- name: MarkEmailRetry :execrows
+UPDATE email_outbox
+SET
+  status = 'queued',
+  last_error = $1,
+  next_attempt_at = $2,
+  updated_at = NOW()
+WHERE id = $3
+  AND status = 'sending'
+  AND attempts = $4
+`
+
+type MarkEmailRetryParams struct {
+	LastError     *string   `json:"last_error"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+	ID            uuid.UUID `json:"id"`
+	Attempts      int32     `json:"attempts"`
+}
+
+func (q *Queries) MarkEmailRetry(ctx context.Context, arg MarkEmailRetryParams) (int64, error) {
+	result, err := q.db.Exec(ctx, markEmailRetry,
+		arg.LastError,
+		arg.NextAttemptAt,
+		arg.ID,
+		arg.Attempts,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const markEmailSent = `-- name: MarkEmailSent :execrows
+UPDATE email_outbox
+SET
+  status = 'sent',
+  sent_at = NOW(),
+  last_error = NULL,
+  updated_at = NOW()
+WHERE id = $1
+  AND status = 'sending'
+  AND attempts = $2
+`
+
+type MarkEmailSentParams struct {
+	ID       uuid.UUID `json:"id"`
+	Attempts int32     `json:"attempts"`
+}
+
+func (q *Queries) MarkEmailSent(ctx context.Context, arg MarkEmailSentParams) (int64, error) {
+	result, err := q.db.Exec(ctx, markEmailSent, arg.ID, arg.Attempts)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const markEstimateAcceptanceLinkAccepted = `-- name: MarkEstimateAcceptanceLinkAccepted :exec
+UPDATE estimate_acceptance_links
+SET accepted_at = $1
+WHERE id = $2
+`
+
+type MarkEstimateAcceptanceLinkAcceptedParams struct {
+	AcceptedAt *time.Time `json:"accepted_at"`
+	ID         uuid.UUID  `json:"id"`
+}
+
+func (q *Queries) MarkEstimateAcceptanceLinkAccepted(ctx context.Context, arg MarkEstimateAcceptanceLinkAcceptedParams) error {
+	_, err := q.db.Exec(ctx, markEstimateAcceptanceLinkAccepted, arg.AcceptedAt, arg.ID)
+	return err
+}
+
+const markEstimateAcceptanceLinkViewed = `-- name: MarkEstimateAcceptanceLinkViewed :execrows
+UPDATE estimate_acceptance_links
+SET first_viewed_at = NOW()
+WHERE id = $1
+  AND first_viewed_at IS NULL
+`
+
+func (q *Queries) MarkEstimateAcceptanceLinkViewed(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, markEstimateAcceptanceLinkViewed, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const markEstimateConverted = `-- name: MarkEstimateConverted :execrows
+UPDATE estimates
+SET
+  status = 'converted',
+  updated_by = $1,
+  status_changed_at = NOW(),
+  updated_at = NOW()
+WHERE id = $2
+  AND tenant_id = $3
+  AND status <> 'converted'
+`
+
+type MarkEstimateConvertedParams struct {
+	UpdatedBy *uuid.UUID `json:"updated_by"`
+	ID        uuid.UUID  `json:"id"`
+	TenantID  uuid.UUID  `json:"tenant_id"`
+}
+
+func (q *Queries) MarkEstimateConverted(ctx context.Context, arg MarkEstimateConvertedParams) (int64, error) {
+	result, err := q.db.Exec(ctx, markEstimateConverted, arg.UpdatedBy, arg.ID, arg.TenantID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const reassignCustomerImportIdempotency = `-- name: ReassignCustomerImportIdempotency :exec
+UPDATE import_idempotency
+SET
+  target_entity_id = $1,
+  last_seen_at = NOW()
+WHERE tenant_id = $2
+  AND entity_type = 'customer'
+  AND target_entity_id = $3
+`
+
+type ReassignCustomerImportIdempotencyParams struct {
+	TargetCustomerID uuid.UUID `json:"target_customer_id"`
+	TenantID         uuid.UUID `json:"tenant_id"`
+	SourceCustomerID uuid.UUID `json:"source_customer_id"`
+}
+
+func (q *Queries) ReassignCustomerImportIdempotency(ctx context.Context, arg ReassignCustomerImportIdempotencyParams) error {
+	_, err := q.db.Exec(ctx, reassignCustomerImportIdempotency, arg.TargetCustomerID, arg.TenantID, arg.SourceCustomerID)
+	return err
+}
+
+const reassignEstimatesCustomer = `-- name: ReassignEstimatesCustomer :execrows
+UPDATE estimates
+SET
+  customer_id = $1,
+  updated_by = $2,
+  updated_at = NOW()
+WHERE tenant_id = $3
+  AND customer_id = $4
+`
+
+type ReassignEstimatesCustomerParams struct {
+	TargetCustomerID uuid.UUID  `json:"target_customer_id"`
+	UpdatedBy        *uuid.UUID `json:"updated_by"`
+	TenantID         uuid.UUID  `json:"tenant_id"`
+	SourceCustomerID uuid.UUID  `json:"source_customer_id"`
+}
+
+func (q *Queries) ReassignEstimatesCustomer(ctx context.Context, arg ReassignEstimatesCustomerParams) (int64, error) {
+	result, err := q.db.Exec(ctx, reassignEstimatesCustomer,
+		arg.TargetCustomerID,
+		arg.UpdatedBy,
+		arg.TenantID,
+		arg.SourceCustomerID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const reassignJobsCustomer = `-- name: ReassignJobsCustomer :execrows
+UPDATE jobs
+SET
+  customer_id = $1,
+  updated_by = $2,
+  updated_at = NOW()
+WHERE tenant_id = $3
+  AND customer_id = $4
+`
+
+type ReassignJobsCustomerParams struct {
+	TargetCustomerID uuid.UUID  `json:"target_customer_id"`
+	UpdatedBy        *uuid.UUID `json:"updated_by"`
+	TenantID         uuid.UUID  `json:"tenant_id"`
+	SourceCustomerID uuid.UUID  `json:"source_customer_id"`
+}
+
+func (q *Queries) ReassignJobsCustomer(ctx context.Context, arg ReassignJobsCustomerParams) (int64, error) {
+	result, err := q.db.Exec(ctx, reassignJobsCustomer,
+		arg.TargetCustomerID,
+		arg.UpdatedBy,
+		arg.TenantID,
+		arg.SourceCustomerID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const recalculateEstimateTotals = `-- name: RecalculateEstimateTotals :one
+UPDATE estimates e
+SET
+  total_cubic_feet = totals.cubic_feet,
+  total_weight_lbs = totals.weight_lbs,
+  updated_by = $1,
+  updated_at = NOW()
+FROM (
+  SELECT
+    COALESCE(SUM(li.quantity * li.cubic_feet), 0)::bigint AS cubic_feet,
+    COALESCE(SUM(li.quantity * li.weight_lbs), 0)::bigint AS weight_lbs
+  FROM estimate_line_items li
+  WHERE li.tenant_id = $3
+    AND li.estimate_id = $2
+) totals
+WHERE e.id = $2
+  AND e.tenant_id = $3
+RETURNING e.total_cubic_feet, e.total_weight_lbs
+`
+
+type RecalculateEstimateTotalsParams struct {
+	UpdatedBy *uuid.UUID `json:"updated_by"`
+	ID        uuid.UUID  `json:"id"`
+	TenantID  uuid.UUID  `json:"tenant_id"`
+}
+
+type RecalculateEstimateTotalsRow struct {
+	TotalCubicFeet int64 `json:"total_cubic_feet"`
+	TotalWeightLbs int64 `json:"total_weight_lbs"`
+}
+
+func (q *Queries) RecalculateEstimateTotals(ctx context.Context, arg RecalculateEstimateTotalsParams) (RecalculateEstimateTotalsRow, error) {
+	row := q.db.QueryRow(ctx, recalculateEstimateTotals, arg.UpdatedBy, arg.ID, arg.TenantID)
+	var i RecalculateEstimateTotalsRow
+	err := row.Scan(&i.TotalCubicFeet, &i.TotalWeightLbs)
+	return i, err
+}
+
+const recordLoginFailure = `-- name: RecordLoginFailure :one
+INSERT INTO user_login_failures (user_id, tenant_id, failed_count, last_failed_at)
+VALUES ($1, $2, 1, NOW())
+ON CONFLICT (user_id) DO UPDATE
+SET failed_count = user_login_failures.failed_count + 1,
+    last_failed_at = NOW(),
+    updated_at = NOW()
+RETURNING user_id, tenant_id, failed_count, lock_count, last_failed_at, locked_until, updated_at
+`
+
+type RecordLoginFailureParams struct {
+	UserID   uuid.UUID `json:"user_id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+}
+
+func (q *Queries) RecordLoginFailure(ctx context.Context, arg RecordLoginFailureParams) (UserLoginFailure, error) {
+	row := q.db.QueryRow(ctx, recordLoginFailure, arg.UserID, arg.TenantID)
+	var i UserLoginFailure
+	err := row.Scan(
+		&i.UserID,
+		&i.TenantID,
+		&i.FailedCount,
+		&i.LockCount,
+		&i.LastFailedAt,
+		&i.LockedUntil,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const refreshJobBalance = `-- name: RefreshJobBalance :one
+UPDATE jobs j
+SET
+  balance_cents = l.balance_cents,
+  last_payment_at = l.last_payment_at
+FROM (
+  SELECT
+    COALESCE(SUM(balance_effect_cents), 0)::bigint AS balance_cents,
+    MAX(occurred_at) FILTER (WHERE kind = 'payment') AS last_payment_at
+  FROM ledger_entries
+  WHERE tenant_id = $2
+    AND job_id = $1
+    AND account = 'move'
+) l
+WHERE j.id = $1
+  AND j.tenant_id = $2
+RETURNING j.balance_cents, j.last_payment_at
+`
+
+type RefreshJobBalanceParams struct {
+	JobID    uuid.UUID `json:"job_id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+}
+
+type RefreshJobBalanceRow struct {
+	BalanceCents  int64      `json:"balance_cents"`
+	LastPaymentAt *time.Time `json:"last_payment_at"`
+}
+
+func (q *Queries) RefreshJobBalance(ctx context.Context, arg RefreshJobBalanceParams) (RefreshJobBalanceRow, error) {
+	row := q.db.QueryRow(ctx, refreshJobBalance, arg.JobID, arg.TenantID)
+	var i RefreshJobBalanceRow
+	err := row.Scan(&i.BalanceCents, &i.LastPaymentAt)
+	return i, err
+}
+
+const refreshStorageBalance = `-- name: RefreshStorageBalance :one
+UPDATE storage_record sr
+SET
+  storage_balance_cents = l.balance_cents,
+  last_payment_at = GREATEST(l.last_payment_at, sr.last_payment_at)
+FROM (
+  SELECT
+    COALESCE(SUM(balance_effect_cents), 0)::bigint AS balance_cents,
+    MAX(occurred_at) FILTER (WHERE kind = 'payment') AS last_payment_at
+  FROM ledger_entries
+  WHERE tenant_id = $2
+    AND storage_record_id = $1
+) l
+WHERE sr.id = $1
+  AND sr.tenant_id = $2
+RETURNING sr.storage_balance_cents, sr.last_payment_at
+`
+
+type RefreshStorageBalanceParams struct {
+	ID       uuid.UUID `json:"id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+}
+
+type RefreshStorageBalanceRow struct {
+	StorageBalanceCents int64      `json:"storage_balance_cents"`
+	LastPaymentAt       *time.Time `json:"last_payment_at"`
+}
+
+func (q *Queries) RefreshStorageBalance(ctx context.Context, arg RefreshStorageBalanceParams) (RefreshStorageBalanceRow, error) {
+	row := q.db.QueryRow(ctx, refreshStorageBalance, arg.ID, arg.TenantID)
+	var i RefreshStorageBalanceRow
+	err := row.Scan(&i.StorageBalanceCents, &i.LastPaymentAt)
+	return i, err
+}
+
+const removeUserRoles = `-- name: RemoveUserRoles :execrows
+DELETE FROM user_roles
+WHERE user_id = $1
+  AND tenant_id = $2
+  AND role_id = ANY($3::uuid[])
+`
+
+type RemoveUserRolesParams struct {
+	UserID   uuid.UUID   `json:"user_id"`
+	TenantID uuid.UUID   `json:"tenant_id"`
+	RoleIds  []uuid.UUID `json:"role_ids"`
+}
+
+func (q *Queries) RemoveUserRoles(ctx context.Context, arg RemoveUserRolesParams) (int64, error) {
+	result, err := q.db.Exec(ctx, removeUserRoles, arg.UserID, arg.TenantID, arg.RoleIds)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const renewEmailLease = `-- name: RenewEmailLease :execrows
+UPDATE email_outbox
+SET
+  next_attempt_at = NOW() + make_interval(secs => $1::int),
+  updated_at = NOW()
+WHERE id = $2
+  AND status = 'sending'
+  AND attempts = $3
+  AND next_attempt_at > NOW()
+`
+
+type RenewEmailLeaseParams struct {
+	LeaseSeconds int32     `json:"lease_seconds"`
+	ID           uuid.UUID `json:"id"`
+	Attempts     int32     `json:"attempts"`
+}
+
+func (q *Queries) RenewEmailLease(ctx context.Context, arg RenewEmailLeaseParams) (int64, error) {
+	result, err := q.db.Exec(ctx, renewEmailLease, arg.LeaseSeconds, arg.ID, arg.Attempts)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const restoreEstimateFields = `-- name: RestoreEstimateFields :one
+UPDATE estimates
+SET
+  customer_name = $1,
+  primary_phone = $2,
+  secondary_phone = $3,
+  email = $4,
+  origin_address_line1 = $5,
+  origin_city = $6,
+  origin_state = $7,
+  origin_postal_code = $8,
+  destination_address_line1 = $9,
+  destination_city = $10,
+  destination_state = $11,
+  destination_postal_code = $12,
+  move_date = $13::date,
+  pickup_time = $14,
+  lead_source = $15,
+  move_size = $16,
+  location_type = $17,
+  estimated_total_cents = $18::bigint,
+  price_source = $19,
+  price_breakdown = $20,
+  priced_at = $21,
+  deposit_cents = $22::bigint,
+  notes = $23,
+  updated_by = $24,
+  updated_at = NOW()
+WHERE id = $25
+  AND tenant_id = $26
+RETURNING id, tenant_id, estimate_number, customer_id, status, customer_name, primary_phone, secondary_phone, email, origin_address_line1, origin_city, origin_state, origin_postal_code, destination_address_line1, destination_city, destination_state, destination_postal_code, move_date, pickup_time, lead_source, move_size, location_type, estimated_total_cents, deposit_cents, total_cubic_feet, total_weight_lbs, price_source, price_breakdown, priced_at, notes, lost_reason, sent_at, expires_at, status_changed_at, idempotency_key, idempotency_payload_hash, created_by, updated_by, created_at, updated_at
+`
+
+type RestoreEstimateFieldsParams struct {
+	CustomerName            string     `json:"customer_name"`
+	PrimaryPhone            string     `json:"primary_phone"`
+	SecondaryPhone          *string    `json:"secondary_phone"`
+	Email                   string     `json:"email"`
+	OriginAddressLine1      string     `json:"origin_address_line1"`
+	OriginCity              string     `json:"origin_city"`
+	OriginState             string     `json:"origin_state"`
+	OriginPostalCode        string     `json:"origin_postal_code"`
+	DestinationAddressLine1 string     `json:"destination_address_line1"`
+	DestinationCity         string     `json:"destination_city"`
+	DestinationState        string     `json:"destination_state"`
+	DestinationPostalCode   string     `json:"destination_postal_code"`
+	MoveDate                time.Time  `json:"move_date"`
+	PickupTime              *string    `json:"pickup_time"`
+	LeadSource              string     `json:"lead_source"`
+	MoveSize                *string    `json:"move_size"`
+	LocationType            *string    `json:"location_type"`
+	EstimatedTotalCents     *int64     `json:"estimated_total_cents"`
+	PriceSource             *string    `json:"price_source"`
+	PriceBreakdown          []byte     `json:"price_breakdown"`
+	PricedAt                *time.Time `json:"priced_at"`
+	DepositCents            *int64     `json:"deposit_cents"`
+	Notes                   *string    `json:"notes"`
+	UpdatedBy               *uuid.UUID `json:"updated_by"`
+	ID                      uuid.UUID  `json:"id"`
+	TenantID                uuid.UUID  `json:"tenant_id"`
+}
+
+func (q *Queries) RestoreEstimateFields(ctx context.Context, arg RestoreEstimateFieldsParams) (Estimate, error) {
+	row := q.db.QueryRow(ctx, restoreEstimateFields,
+		arg.CustomerName,
+		arg.PrimaryPhone,
+		arg.SecondaryPhone,
+		arg.Email,
+		arg.OriginAddressLine1,
+		arg.OriginCity,
+		arg.OriginState,
+		arg.OriginPostalCode,
+		arg.DestinationAddressLine1,
+		arg.DestinationCity,
+		arg.DestinationState,
+		arg.DestinationPostalCode,
+		arg.MoveDate,
+		arg.PickupTime,
+		arg.LeadSource,
+		arg.MoveSize,
+		arg.LocationType,
+		arg.EstimatedTotalCents,
+		arg.PriceSource,
+		arg.PriceBreakdown,
+		arg.PricedAt,
+		arg.DepositCents,
+		arg.Notes,
+		arg.UpdatedBy,
+		arg.ID,
+		arg.TenantID,
+	)
+	var i Estimate
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.EstimateNumber,
+		&i.CustomerID,
+		&i.Status,
+		&i.CustomerName,
+		&i.PrimaryPhone,
+		&i.SecondaryPhone,
+		&i.Email,
+		&i.OriginAddressLine1,
+		&i.OriginCity,
+		&i.OriginState,
+		&i.OriginPostalCode,
+		&i.DestinationAddressLine1,
+		&i.DestinationCity,
+		&i.DestinationState,
+		&i.DestinationPostalCode,
+		&i.MoveDate,
+		&i.PickupTime,
+		&i.LeadSource,
+		&i.MoveSize,
+		&i.LocationType,
+		&i.EstimatedTotalCents,
+		&i.DepositCents,
+		&i.TotalCubicFeet,
+		&i.TotalWeightLbs,
+		&i.PriceSource,
+		&i.PriceBreakdown,
+		&i.PricedAt,
+		&i.Notes,
+		&i.LostReason,
+		&i.SentAt,
+		&i.ExpiresAt,
+		&i.StatusChangedAt,
+		&i.IdempotencyKey,
+		&i.IdempotencyPayloadHash,
+		&i.CreatedBy,
+		&i.UpdatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const restoreEstimateLineItem = `-- name: RestoreEstimateLineItem :exec
+INSERT INTO estimate_line_items (
+  id,
+  tenant_id,
+  estimate_id,
+  inventory_item_id,
+  room,
+  item_name,
+  quantity,
+  cubic_feet,
+  weight_lbs,
+  created_by,
+  updated_by,
+  created_at
+) VALUES (
+  $1,
+  $2,
+  $3,
+  (
+    SELECT i.id
+    FROM inventory_items i
+    WHERE i.id = $4
+      AND i.tenant_id = $2
+  ),
+  $5,
+  $6,
+  $7,
+  $8,
+  $9,
+  $10,
+  $10,
+  $11
+)
+`
+
+type RestoreEstimateLineItemParams struct {
+	ID              uuid.UUID  `json:"id"`
+	TenantID        uuid.UUID  `json:"tenant_id"`
+	EstimateID      uuid.UUID  `json:"estimate_id"`
+	InventoryItemID *uuid.UUID `json:"inventory_item_id"`
+	Room            string     `json:"room"`
+	ItemName        string     `json:"item_name"`
+	Quantity        int32      `json:"quantity"`
+	CubicFeet       int32      `json:"cubic_feet"`
+	WeightLbs       int32      `json:"weight_lbs"`
+	UpdatedBy       *uuid.UUID `json:"updated_by"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+func (q *Queries) RestoreEstimateLineItem(ctx context.Context, arg RestoreEstimateLineItemParams) error {
+	_, err := q.db.Exec(ctx, restoreEstimateLineItem,
+		arg.ID,
+		arg.TenantID,
+		arg.EstimateID,
+		arg.InventoryItemID,
+		arg.Room,
+		arg.ItemName,
+		arg.Quantity,
+		arg.CubicFeet,
+		arg.WeightLbs,
+		arg.UpdatedBy,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const revokeAPIToken = `-- name: RevokeAPIToken :execrows
+UPDATE api_tokens
+SET revoked_at = NOW()
+WHERE id = $1
+  AND tenant_id = $2
+  AND user_id = $3
+  AND revoked_at IS NULL
+`
+
+type RevokeAPITokenParams struct {
+	ID       uuid.UUID `json:"id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+	UserID   uuid.UUID `json:"user_id"`
 }
 
-type ListStorageRowsRow struct {
-	StorageRecordID     *uuid.UUID `json:"storage_record_id"`
-	JobID               uuid.UUID  `json:"job_id"`
-	JobNumber           string     `json:"job_number"`
-	CustomerName        string     `json:"customer_name"`
-	MoveType            string     `json:"move_type"`
-	FromShort           string     `json:"from_short"`
-	ToShort             string     `json:"to_short"`
-	Status              *string    `json:"status"`
-	DateIn              *time.Time `json:"date_in"`
-	DateOut             *time.Time `json:"date_out"`
-	NextBillDate        *time.Time `json:"next_bill_date"`
-	LotNumber           *string    `json:"lot_number"`
-	LocationLabel       *string    `json:"location_label"`
-	Vaults              int32      `json:"vaults"`
-	Pads                int32      `json:"pads"`
-	Items               int32      `json:"items"`
-	OversizeItems       int32      `json:"oversize_items"`
-	Volume              int32      `json:"volume"`
-	MonthlyRateCents    *int64     `json:"monthly_rate_cents"`
-	StorageBalanceCents int64      `json:"storage_balance_cents"`
-	MoveBalanceCents    int64      `json:"move_balance_cents"`
-	Facility            string     `json:"facility"`
-	SortUpdatedAt       time.Time  `json:"sort_updated_at"`
-	SortJobID           uuid.UUID  `json:"sort_job_id"`
+func (q *Queries) RevokeAPIToken(ctx context.Context, arg RevokeAPITokenParams) (int64, error) {
+	result, err := q.db.Exec(ctx, revokeAPIToken, arg.ID, arg.TenantID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-func (q *Queries) ListStorageRows(ctx context.Context, arg ListStorageRowsParams) ([]ListStorageRowsRow, error) {
-	rows, err := q.db.Query(ctx, listStorageRows,
-		arg.Facility,
-		arg.TenantID,
-		arg.SearchQ,
-		arg.Status,
-		arg.HasDateOut,
-		arg.BalanceDue,
-		arg.HasContainers,
-		arg.PastDueDays,
-		arg.CursorUpdatedAt,
-		arg.CursorJobID,
-		arg.LimitRows,
-	)
+const revokeEstimateAcceptanceLinks = `-- name: RevokeEstimateAcceptanceLinks :exec
+UPDATE estimate_acceptance_links
+SET revoked_at = NOW()
+WHERE tenant_id = $1
+  AND estimate_id = $2
+  AND revoked_at IS NULL
+  AND accepted_at IS NULL
+`
+
+type RevokeEstimateAcceptanceLinksParams struct {
+	TenantID   uuid.UUID `json:"tenant_id"`
+	EstimateID uuid.UUID `json:"estimate_id"`
+}
+
+func (q *Queries) RevokeEstimateAcceptanceLinks(ctx context.Context, arg RevokeEstimateAcceptanceLinksParams) error {
+	_, err := q.db.Exec(ctx, revokeEstimateAcceptanceLinks, arg.TenantID, arg.EstimateID)
+	return err
+}
+
+const revokeOtherUserSessions = `-- name: RevokeOtherUserSessions :execrows
+UPDATE sessions
+SET revoked_at = NOW()
+WHERE user_id = $1
+  AND tenant_id = $2
+  AND id <> $3
+  AND revoked_at IS NULL
+`
+
+type RevokeOtherUserSessionsParams struct {
+	UserID        uuid.UUID `json:"user_id"`
+	TenantID      uuid.UUID `json:"tenant_id"`
+	KeepSessionID uuid.UUID `json:"keep_session_id"`
+}
+
+func (q *Queries) RevokeOtherUserSessions(ctx context.Context, arg RevokeOtherUserSessionsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, revokeOtherUserSessions, arg.UserID, arg.TenantID, arg.KeepSessionID)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	defer rows.Close()
-	items := []ListStorageRowsRow{}
-	for rows.Next() {
-		var i ListStorageRowsRow
-		if err := rows.Scan(
-			&i.StorageRecordID,
-			&i.JobID,
-			&i.JobNumber,
-			&i.CustomerName,
-			&i.MoveType,
-			&i.FromShort,
-			&i.ToShort,
-			&i.Status,
-			&i.DateIn,
-			&i.DateOut,
-			&i.NextBillDate,
-			&i.LotNumber,
-			&i.LocationLabel,
-			&i.Vaults,
-			&i.Pads,
-			&i.Items,
-			&i.OversizeItems,
-			&i.Volume,
-			&i.MonthlyRateCents,
-			&i.StorageBalanceCents,
-			&i.MoveBalanceCents,
-			&i.Facility,
-			&i.SortUpdatedAt,
-			&i.SortJobID,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
+	return result.RowsAffected(), nil
+}
+
+const revokeSessionByID = `-- name: RevokeSessionByID :execrows
+UPDATE sessions
+SET revoked_at = NOW()
+WHERE id = $1
+  AND tenant_id = $2
+  AND revoked_at IS NULL
+`
+
+type RevokeSessionByIDParams struct {
+	ID       uuid.UUID `json:"id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+}
+
+func (q *Queries) RevokeSessionByID(ctx context.Context, arg RevokeSessionByIDParams) (int64, error) {
+	result, err := q.db.Exec(ctx, revokeSessionByID, arg.ID, arg.TenantID)
+	if err != nil {
+		return 0, err
 	}
-	if err := rows.Err(); err != nil {
-		return nil, err
+	return result.RowsAffected(), nil
+}
+
+const revokeSessionByTokenHash = `-- name: RevokeSessionByTokenHash :execrows
+UPDATE sessions
+SET revoked_at = NOW()
+WHERE token_hash = $1
+  AND revoked_at IS NULL
+`
+
+func (q *Queries) RevokeSessionByTokenHash(ctx context.Context, tokenHash string) (int64, error) {
+	result, err := q.db.Exec(ctx, revokeSessionByTokenHash, tokenHash)
+	if err != nil {
+		return 0, err
 	}
-	return items, nil
+	return result.RowsAffected(), nil
 }
 
-const listUsersByEmail = `-- name: ListUsersByEmail :many
+const revokeSessionsForUser = `-- name: RevokeSessionsForUser :execrows
+UPDATE sessions
+SET revoked_at = NOW()
+WHERE user_id = $1
+  AND tenant_id = $2
+  AND revoked_at IS NULL
+`
+
+type RevokeSessionsForUserParams struct {
+	UserID   uuid.UUID `json:"user_id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+}
+
+func (q *Queries) RevokeSessionsForUser(ctx context.Context, arg RevokeSessionsForUserParams) (int64, error) {
+	result, err := q.db.Exec(ctx, revokeSessionsForUser, arg.UserID, arg.TenantID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const revokeUserSession = `-- name: RevokeUserSession :execrows
+UPDATE sessions
+SET revoked_at = NOW()
+WHERE id = $1
+  AND user_id = $2
+  AND tenant_id = $3
+  AND revoked_at IS NULL
+  AND expires_at > NOW()
+`
+
+type RevokeUserSessionParams struct {
+	ID       uuid.UUID `json:"id"`
+	UserID   uuid.UUID `json:"user_id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+}
+
+func (q *Queries) RevokeUserSession(ctx context.Context, arg RevokeUserSessionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, revokeUserSession, arg.ID, arg.UserID, arg.TenantID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const rotateSessionToken = `-- name: RotateSessionToken :execrows
+UPDATE sessions
+SET previous_token_hash = token_hash,
+    token_hash = $1,
+    token_rotated_at = NOW()
+WHERE id = $2
+  AND token_hash = $3
+  AND revoked_at IS NULL
+`
+
+type RotateSessionTokenParams struct {
+	NewTokenHash     string    `json:"new_token_hash"`
+	ID               uuid.UUID `json:"id"`
+	CurrentTokenHash string    `json:"current_token_hash"`
+}
+
+func (q *Queries) RotateSessionToken(ctx context.Context, arg RotateSessionTokenParams) (int64, error) {
+	result, err := q.db.Exec(ctx, rotateSessionToken, arg.NewTokenHash, arg.ID, arg.CurrentTokenHash)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const searchTenantRecords = `-- name: SearchTenantRecords :many
+WITH hits AS (
+  SELECT
+    'estimate'::text AS hit_type,
+    e.id,
+    e.estimate_number AS title,
+    CONCAT_WS(', ', e.customer_name, NULLIF(TRIM(e.origin_city), ''), e.status)::text AS subtitle,
+    GREATEST(
+      CASE
+        WHEN lower(e.estimate_number) = lower($2::text) THEN 1.0
+        WHEN e.estimate_number ILIKE $2::text || '%' THEN 0.9
+        ELSE 0
+      END,
+      CASE
+        WHEN $3::text IS NOT NULL
+          AND regexp_replace(e.primary_phone, '[^0-9]', '', 'g') LIKE '%' || $3::text || '%'
+          THEN 0.85
+        ELSE 0
+      END,
+      CASE WHEN lower(e.email) = lower($2::text) THEN 0.85 ELSE 0 END,
+      word_similarity($2::text, e.customer_name) * 0.8,
+      word_similarity($2::text, COALESCE(e.origin_city, '')) * 0.5,
+      word_similarity($2::text, COALESCE(e.destination_city, '')) * 0.5
+    )::float8 AS score,
+    e.updated_at
+  FROM estimates e
+  WHERE $4::boolean
+    AND e.tenant_id = $5
+    AND (
+      e.estimate_number ILIKE '%' || $2::text || '%'
+      OR e.customer_name ILIKE '%' || $2::text || '%'
+      OR $2::text <% e.customer_name
+      OR e.email ILIKE '%' || $2::text || '%'
+      OR COALESCE(e.origin_city, '') ILIKE '%' || $2::text || '%'
+      OR COALESCE(e.destination_city, '') ILIKE '%' || $2::text || '%'
+      OR (
+        $3::text IS NOT NULL
+        AND regexp_replace(e.primary_phone, '[^0-9]', '', 'g') LIKE '%' || $3::text || '%'
+      )
+    )
+
+  UNION ALL
+
+  SELECT
+    'job'::text AS hit_type,
+    j.id,
+    j.job_number AS title,
+    CONCAT_WS(', ', NULLIF(TRIM(c.first_name || ' ' || c.last_name), ''), NULLIF(TRIM(e.origin_city), ''), j.status)::text AS subtitle,
+    GREATEST(
+      CASE
+        WHEN lower(j.job_number) = lower($2::text) THEN 1.0
+        WHEN j.job_number ILIKE $2::text || '%' THEN 0.9
+        ELSE 0
+      END,
+      CASE
+        WHEN $3::text IS NOT NULL
+          AND regexp_replace(COALESCE(c.phone, ''), '[^0-9]', '', 'g') LIKE '%' || $3::text || '%'
+          THEN 0.85
+        ELSE 0
+      END,
+      word_similarity($2::text, c.first_name || ' ' || c.last_name) * 0.8,
+      word_similarity($2::text, COALESCE(e.origin_city, '')) * 0.5,
+      word_similarity($2::text, COALESCE(e.destination_city, '')) * 0.5
+    )::float8 AS score,
+    j.updated_at
+  FROM jobs j
+  JOIN customers c
+    ON c.id = j.customer_id
+    AND c.tenant_id = j.tenant_id
+  LEFT JOIN estimates e
+    ON e.id = j.estimate_id
+    AND e.tenant_id = j.tenant_id
+  WHERE $6::boolean
+    AND j.tenant_id = $5
+    AND (
+      j.job_number ILIKE '%' || $2::text || '%'
+      OR (c.first_name || ' ' || c.last_name) ILIKE '%' || $2::text || '%'
+      OR $2::text <% (c.first_name || ' ' || c.last_name)
+      OR COALESCE(e.origin_city, '') ILIKE '%' || $2::text || '%'
+      OR COALESCE(e.destination_city, '') ILIKE '%' || $2::text || '%'
+      OR (
+        $3::text IS NOT NULL
+        AND regexp_replace(COALESCE(c.phone, ''), '[^0-9]', '', 'g') LIKE '%' || $3::text || '%'
+      )
+    )
+
+  UNION ALL
+
+  SELECT
+    'customer'::text AS hit_type,
+    c.id,
+    TRIM(c.first_name || ' ' || c.last_name)::text AS title,
+    CONCAT_WS(', ', NULLIF(TRIM(COALESCE(c.email, '')), ''), NULLIF(TRIM(COALESCE(c.phone, '')), ''))::text AS subtitle,
+    GREATEST(
+      CASE WHEN lower(COALESCE(c.email, '')) = lower($2::text) THEN 0.95 ELSE 0 END,
+      CASE
+        WHEN $3::text IS NOT NULL
+          AND regexp_replace(COALESCE(c.phone, ''), '[^0-9]', '', 'g') LIKE '%' || $3::text || '%'
+          THEN 0.85
+        ELSE 0
+      END,
+      CASE WHEN lower(TRIM(c.first_name || ' ' || c.last_name)) = lower($2::text) THEN 0.95 ELSE 0 END,
+      word_similarity($2::text, c.first_name || ' ' || c.last_name) * 0.85
+    )::float8 AS score,
+    c.updated_at
+  FROM customers c
+  WHERE $7::boolean
+    AND c.tenant_id = $5
+    AND (
+      (c.first_name || ' ' || c.last_name) ILIKE '%' || $2::text || '%'
+      OR $2::text <% (c.first_name || ' ' || c.last_name)
+      OR COALESCE(c.email, '') ILIKE '%' || $2::text || '%'
+      OR (
+        $3::text IS NOT NULL
+        AND regexp_replace(COALESCE(c.phone, ''), '[^0-9]', '', 'g') LIKE '%' || $3::text || '%'
+      )
+    )
+)
 SELECT
-  u.id,
-  u.tenant_id,
-  u.email,
-  u.full_name,
-  u.password_hash,
-  u.is_active,
-  t.slug AS tenant_slug,
-  t.name AS tenant_name
-FROM users u
-JOIN tenants t ON t.id = u.tenant_id
-WHERE lower(u.email) = lower($1)
+  hit_type,
+  id,
+  title,
+  subtitle,
+  score
+FROM hits
+ORDER BY score DESC, updated_at DESC, id DESC
+LIMIT $1
 `
 
-type ListUsersByEmailRow struct {
-	ID           uuid.UUID `json:"id"`
-	TenantID     uuid.UUID `json:"tenant_id"`
-	Email        string    `json:"email"`
-	FullName     string    `json:"full_name"`
-	PasswordHash string    `json:"password_hash"`
-	IsActive     bool      `json:"is_active"`
-	TenantSlug   string    `json:"tenant_slug"`
-	TenantName   string    `json:"tenant_name"`
+type SearchTenantRecordsParams struct {
+	LimitRows        int32     `json:"limit_rows"`
+	Query            string    `json:"query"`
+	PhoneDigits      *string   `json:"phone_digits"`
+	IncludeEstimates bool      `json:"include_estimates"`
+	TenantID         uuid.UUID `json:"tenant_id"`
+	IncludeJobs      bool      `json:"include_jobs"`
+	IncludeCustomers bool      `json:"include_customers"`
 }
 
-func (q *Queries) ListUsersByEmail(ctx context.Context, email string) ([]ListUsersByEmailRow, error) {
-	rows, err := q.db.Query(ctx, listUsersByEmail, email)
+type SearchTenantRecordsRow struct {
+	HitType  string    `json:"hit_type"`
+	ID       uuid.UUID `json:"id"`
+	Title    string    `json:"title"`
+	Subtitle string    `json:"subtitle"`
+	Score    float64   `json:"score"`
+}
+
+func (q *Queries) SearchTenantRecords(ctx context.Context, arg SearchTenantRecordsParams) ([]SearchTenantRecordsRow, error) {
+	rows, err := q.db.Query(ctx, searchTenantRecords,
+		arg.LimitRows,
+		arg.Query,
+		arg.PhoneDigits,
+		arg.IncludeEstimates,
+		arg.TenantID,
+		arg.IncludeJobs,
+		arg.IncludeCustomers,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []ListUsersByEmailRow{}
-	for rows.Next() {
-		var i ListUsersByEmailRow
-		if err := rows.Scan(
-			&i.ID,
-			&i.TenantID,
-			&i.Email,
-			&i.FullName,
-			&i.PasswordHash,
-			&i.IsActive,
-			&i.TenantSlug,
-			&i.TenantName,
+	items := []SearchTenantRecordsRow{}
+	for rows.Next() {
+		var i SearchTenantRecordsRow
+		if err := rows.Scan(
+			&i.HitType,
+			&i.ID,
+			&i.Title,
+			&i.Subtitle,
+			&i.Score,
 		); err != nil {
 			return nil, err
 		}
@@ -2654,78 +7669,336 @@ func (q *Queries) ListUsersByEmail(ctx context.Context, email string) ([]ListUse
 	return items, nil
 }
 
-const markEstimateConverted = `-- name: MarkEstimateConverted :execrows
+const setEstimateDocumentLogo = `-- name: SetEstimateDocumentLogo :exec
+UPDATE estimate_document_templates
+SET
+  logo = $1,
+  logo_content_type = $2
+WHERE tenant_id = $3
+`
+
+type SetEstimateDocumentLogoParams struct {
+	Logo            []byte    `json:"logo"`
+	LogoContentType *string   `json:"logo_content_type"`
+	TenantID        uuid.UUID `json:"tenant_id"`
+}
+
+func (q *Queries) SetEstimateDocumentLogo(ctx context.Context, arg SetEstimateDocumentLogoParams) error {
+	_, err := q.db.Exec(ctx, setEstimateDocumentLogo, arg.Logo, arg.LogoContentType, arg.TenantID)
+	return err
+}
+
+const setEstimatePrice = `-- name: SetEstimatePrice :one
 UPDATE estimates
 SET
-  status = 'converted',
-  updated_by = $1,
+  estimated_total_cents = $1,
+  price_source = 'tariff',
+  price_breakdown = $2,
+  priced_at = NOW(),
+  updated_by = $3,
   updated_at = NOW()
-WHERE id = $2
+WHERE id = $4
+  AND tenant_id = $5
+RETURNING id, tenant_id, estimate_number, customer_id, status, customer_name, primary_phone, secondary_phone, email, origin_address_line1, origin_city, origin_state, origin_postal_code, destination_address_line1, destination_city, destination_state, destination_postal_code, move_date, pickup_time, lead_source, move_size, location_type, estimated_total_cents, deposit_cents, total_cubic_feet, total_weight_lbs, price_source, price_breakdown, priced_at, notes, lost_reason, sent_at, expires_at, status_changed_at, idempotency_key, idempotency_payload_hash, created_by, updated_by, created_at, updated_at
+`
+
+type SetEstimatePriceParams struct {
+	EstimatedTotalCents *int64     `json:"estimated_total_cents"`
+	PriceBreakdown      []byte     `json:"price_breakdown"`
+	UpdatedBy           *uuid.UUID `json:"updated_by"`
+	ID                  uuid.UUID  `json:"id"`
+	TenantID            uuid.UUID  `json:"tenant_id"`
+}
+
+func (q *Queries) SetEstimatePrice(ctx context.Context, arg SetEstimatePriceParams) (Estimate, error) {
+	row := q.db.QueryRow(ctx, setEstimatePrice,
+		arg.EstimatedTotalCents,
+		arg.PriceBreakdown,
+		arg.UpdatedBy,
+		arg.ID,
+		arg.TenantID,
+	)
+	var i Estimate
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.EstimateNumber,
+		&i.CustomerID,
+		&i.Status,
+		&i.CustomerName,
+		&i.PrimaryPhone,
+		&i.SecondaryPhone,
+		&i.Email,
+		&i.OriginAddressLine1,
+		&i.OriginCity,
+		&i.OriginState,
+		&i.OriginPostalCode,
+		&i.DestinationAddressLine1,
+		&i.DestinationCity,
+		&i.DestinationState,
+		&i.DestinationPostalCode,
+		&i.MoveDate,
+		&i.PickupTime,
+		&i.LeadSource,
+		&i.MoveSize,
+		&i.LocationType,
+		&i.EstimatedTotalCents,
+		&i.DepositCents,
+		&i.TotalCubicFeet,
+		&i.TotalWeightLbs,
+		&i.PriceSource,
+		&i.PriceBreakdown,
+		&i.PricedAt,
+		&i.Notes,
+		&i.LostReason,
+		&i.SentAt,
+		&i.ExpiresAt,
+		&i.StatusChangedAt,
+		&i.IdempotencyKey,
+		&i.IdempotencyPayloadHash,
+		&i.CreatedBy,
+		&i.UpdatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const setStorageMoveBalance = `-- name: SetStorageMoveBalance :exec
+UPDATE storage_record
+SET move_balance_cents = $1
+WHERE job_id = $2
   AND tenant_id = $3
-  AND status <> 'converted'
 `
 
-type MarkEstimateConvertedParams struct {
-	UpdatedBy *uuid.UUID `json:"updated_by"`
-	ID        uuid.UUID  `json:"id"`
-	TenantID  uuid.UUID  `json:"tenant_id"`
+type SetStorageMoveBalanceParams struct {
+	MoveBalanceCents int64     `json:"move_balance_cents"`
+	JobID            uuid.UUID `json:"job_id"`
+	TenantID         uuid.UUID `json:"tenant_id"`
 }
 
-func (q *Queries) MarkEstimateConverted(ctx context.Context, arg MarkEstimateConvertedParams) (int64, error) {
-	result, err := q.db.Exec(ctx, markEstimateConverted, arg.UpdatedBy, arg.ID, arg.TenantID)
-	if err != nil {
-		return 0, err
-	}
-	return result.RowsAffected(), nil
+func (q *Queries) SetStorageMoveBalance(ctx context.Context, arg SetStorageMoveBalanceParams) error {
+	_, err := q.db.Exec(ctx, setStorageMoveBalance, arg.MoveBalanceCents, arg.JobID, arg.TenantID)
+	return err
 }
 
-const revokeSessionByID = `-- name: RevokeSessionByID :execrows
-UPDATE sessions
-SET revoked_at = NOW()
-WHERE id = $1
-  AND tenant_id = $2
-  AND revoked_at IS NULL
+const syncSoleEstimateCustomer = `-- name: SyncSoleEstimateCustomer :execrows
+UPDATE customers c
+SET
+  first_name = $1,
+  last_name = $2,
+  email = $3,
+  phone = $4,
+  updated_by = $5,
+  updated_at = NOW()
+WHERE c.id = $6
+  AND c.tenant_id = $7
+  AND NOT EXISTS (
+    SELECT 1
+    FROM estimates e
+    WHERE e.tenant_id = c.tenant_id
+      AND e.customer_id = c.id
+      AND e.id <> $8
+  )
 `
 
-type RevokeSessionByIDParams struct {
-	ID       uuid.UUID `json:"id"`
-	TenantID uuid.UUID `json:"tenant_id"`
+type SyncSoleEstimateCustomerParams struct {
+	FirstName  string     `json:"first_name"`
+	LastName   string     `json:"last_name"`
+	Email      *string    `json:"email"`
+	Phone      *string    `json:"phone"`
+	UpdatedBy  *uuid.UUID `json:"updated_by"`
+	ID         uuid.UUID  `json:"id"`
+	TenantID   uuid.UUID  `json:"tenant_id"`
+	EstimateID uuid.UUID  `json:"estimate_id"`
 }
 
-func (q *Queries) RevokeSessionByID(ctx context.Context, arg RevokeSessionByIDParams) (int64, error) {
-	result, err := q.db.Exec(ctx, revokeSessionByID, arg.ID, arg.TenantID)
+func (q *Queries) SyncSoleEstimateCustomer(ctx context.Context, arg SyncSoleEstimateCustomerParams) (int64, error) {
+	result, err := q.db.Exec(ctx, syncSoleEstimateCustomer,
+		arg.FirstName,
+		arg.LastName,
+		arg.Email,
+		arg.Phone,
+		arg.UpdatedBy,
+		arg.ID,
+		arg.TenantID,
+		arg.EstimateID,
+	)
 	if err != nil {
 		return 0, err
 	}
 	return result.RowsAffected(), nil
 }
 
-const revokeSessionByTokenHash = `-- name: RevokeSessionByTokenHash :execrows
-UPDATE sessions
-SET revoked_at = NOW()
-WHERE token_hash = $1
-  AND revoked_at IS NULL
+const touchAPIToken = `-- name: TouchAPIToken :exec
+UPDATE api_tokens
+SET last_used_at = NOW(),
+    last_used_ip = $1
+WHERE id = $2
 `
 
-func (q *Queries) RevokeSessionByTokenHash(ctx context.Context, tokenHash string) (int64, error) {
-	result, err := q.db.Exec(ctx, revokeSessionByTokenHash, tokenHash)
-	if err != nil {
-		return 0, err
-	}
-	return result.RowsAffected(), nil
+type TouchAPITokenParams struct {
+	LastUsedIp *string   `json:"last_used_ip"`
+	ID         uuid.UUID `json:"id"`
+}
+
+func (q *Queries) TouchAPIToken(ctx context.Context, arg TouchAPITokenParams) error {
+	_, err := q.db.Exec(ctx, touchAPIToken, arg.LastUsedIp, arg.ID)
+	return err
 }
 
 const touchSession = `-- name: TouchSession :exec
 UPDATE sessions
-SET last_seen_at = NOW()
-WHERE id = $1
+SET last_seen_at = NOW(),
+    expires_at = $1
+WHERE id = $2
+  AND revoked_at IS NULL
 `
 
-func (q *Queries) TouchSession(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.Exec(ctx, touchSession, id)
+type TouchSessionParams struct {
+	ExpiresAt time.Time `json:"expires_at"`
+	ID        uuid.UUID `json:"id"`
+}
+
+func (q *Queries) TouchSession(ctx context.Context, arg TouchSessionParams) error {
+	_, err := q.db.Exec(ctx, touchSession, arg.ExpiresAt, arg.ID)
 	return err
 }
 
+const transitionEstimateStatus = `-- name: TransitionEstimateStatus :one
+UPDATE estimates
+SET
+  status = $1,
+  lost_reason = $2,
+  sent_at = $3,
+  expires_at = $4,
+  status_changed_at = CASE WHEN status <> $1 THEN NOW() ELSE status_changed_at END,
+  updated_by = $5,
+  updated_at = NOW()
+WHERE id = $6
+  AND tenant_id = $7
+  AND status = $8
+RETURNING id, tenant_id, estimate_number, customer_id, status, customer_name, primary_phone, secondary_phone, email, origin_address_line1, origin_city, origin_state, origin_postal_code, destination_address_line1, destination_city, destination_state, destination_postal_code, move_date, pickup_time, lead_source, move_size, location_type, estimated_total_cents, deposit_cents, total_cubic_feet, total_weight_lbs, price_source, price_breakdown, priced_at, notes, lost_reason, sent_at, expires_at, status_changed_at, idempotency_key, idempotency_payload_hash, created_by, updated_by, created_at, updated_at
+`
+
+type TransitionEstimateStatusParams struct {
+	Status     string     `json:"status"`
+	LostReason *string    `json:"lost_reason"`
+	SentAt     *time.Time `json:"sent_at"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	UpdatedBy  *uuid.UUID `json:"updated_by"`
+	ID         uuid.UUID  `json:"id"`
+	TenantID   uuid.UUID  `json:"tenant_id"`
+	FromStatus string     `json:"from_status"`
+}
+
+func (q *Queries) TransitionEstimateStatus(ctx context.Context, arg TransitionEstimateStatusParams) (Estimate, error) {
+	row := q.db.QueryRow(ctx, transitionEstimateStatus,
+		arg.Status,
+		arg.LostReason,
+		arg.SentAt,
+		arg.ExpiresAt,
+		arg.UpdatedBy,
+		arg.ID,
+		arg.TenantID,
+		arg.FromStatus,
+	)
+	var i Estimate
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.EstimateNumber,
+		&i.CustomerID,
+		&i.Status,
+		&i.CustomerName,
+		&i.PrimaryPhone,
+		&i.SecondaryPhone,
+		&i.Email,
+		&i.OriginAddressLine1,
+		&i.OriginCity,
+		&i.OriginState,
+		&i.OriginPostalCode,
+		&i.DestinationAddressLine1,
+		&i.DestinationCity,
+		&i.DestinationState,
+		&i.DestinationPostalCode,
+		&i.MoveDate,
+		&i.PickupTime,
+		&i.LeadSource,
+		&i.MoveSize,
+		&i.LocationType,
+		&i.EstimatedTotalCents,
+		&i.DepositCents,
+		&i.TotalCubicFeet,
+		&i.TotalWeightLbs,
+		&i.PriceSource,
+		&i.PriceBreakdown,
+		&i.PricedAt,
+		&i.Notes,
+		&i.LostReason,
+		&i.SentAt,
+		&i.ExpiresAt,
+		&i.StatusChangedAt,
+		&i.IdempotencyKey,
+		&i.IdempotencyPayloadHash,
+		&i.CreatedBy,
+		&i.UpdatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateCustomer = `-- name: UpdateCustomer :one
+UPDATE customers
+SET
+  first_name = $1,
+  last_name = $2,
+  email = $3,
+  phone = $4,
+  updated_by = $5,
+  updated_at = NOW()
+WHERE id = $6
+  AND tenant_id = $7
+RETURNING id, tenant_id, first_name, last_name, email, phone, created_by, updated_by, created_at, updated_at
+`
+
+type UpdateCustomerParams struct {
+	FirstName string     `json:"first_name"`
+	LastName  string     `json:"last_name"`
+	Email     *string    `json:"email"`
+	Phone     *string    `json:"phone"`
+	UpdatedBy *uuid.UUID `json:"updated_by"`
+	ID        uuid.UUID  `json:"id"`
+	TenantID  uuid.UUID  `json:"tenant_id"`
+}
+
+func (q *Queries) UpdateCustomer(ctx context.Context, arg UpdateCustomerParams) (Customer, error) {
+	row := q.db.QueryRow(ctx, updateCustomer,
+		arg.FirstName,
+		arg.LastName,
+		arg.Email,
+		arg.Phone,
+		arg.UpdatedBy,
+		arg.ID,
+		arg.TenantID,
+	)
+	var i Customer
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.FirstName,
+		&i.LastName,
+		&i.Email,
+		&i.Phone,
+		&i.CreatedBy,
+		&i.UpdatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const updateCustomerForEstimate = `-- name: UpdateCustomerForEstimate :one
 UPDATE customers
 SET
@@ -2797,13 +8070,18 @@ SET
   move_size = COALESCE($16, move_size),
   location_type = COALESCE($17, location_type),
   estimated_total_cents = COALESCE($18::bigint, estimated_total_cents),
+  price_source = CASE
+    WHEN $18::bigint IS NULL
+      OR $18::bigint = estimated_total_cents THEN price_source
+    ELSE 'manual'
+  END,
   deposit_cents = COALESCE($19::bigint, deposit_cents),
   notes = COALESCE($20, notes),
   updated_by = $21,
   updated_at = NOW()
 WHERE id = $22
   AND tenant_id = $23
-RETURNING id, tenant_id, estimate_number, customer_id, status, customer_name, primary_phone, secondary_phone, email, origin_address_line1, origin_city, origin_state, origin_postal_code, destination_address_line1, destination_city, destination_state, destination_postal_code, move_date, pickup_time, lead_source, move_size, location_type, estimated_total_cents, deposit_cents, notes, idempotency_key, idempotency_payload_hash, created_by, updated_by, created_at, updated_at
+RETURNING id, tenant_id, estimate_number, customer_id, status, customer_name, primary_phone, secondary_phone, email, origin_address_line1, origin_city, origin_state, origin_postal_code, destination_address_line1, destination_city, destination_state, destination_postal_code, move_date, pickup_time, lead_source, move_size, location_type, estimated_total_cents, deposit_cents, total_cubic_feet, total_weight_lbs, price_source, price_breakdown, priced_at, notes, lost_reason, sent_at, expires_at, status_changed_at, idempotency_key, idempotency_payload_hash, created_by, updated_by, created_at, updated_at
 `
 
 type UpdateEstimateParams struct {
@@ -2884,7 +8162,16 @@ func (q *Queries) UpdateEstimate(ctx context.Context, arg UpdateEstimateParams)
 		&i.LocationType,
 		&i.EstimatedTotalCents,
 		&i.DepositCents,
+		&i.TotalCubicFeet,
+		&i.TotalWeightLbs,
+		&i.PriceSource,
+		&i.PriceBreakdown,
+		&i.PricedAt,
 		&i.Notes,
+		&i.LostReason,
+		&i.SentAt,
+		&i.ExpiresAt,
+		&i.StatusChangedAt,
 		&i.IdempotencyKey,
 		&i.IdempotencyPayloadHash,
 		&i.CreatedBy,
@@ -2918,13 +8205,18 @@ SET
   move_size = $18,
   location_type = $19,
   estimated_total_cents = $20::bigint,
+  price_source = CASE
+    WHEN $20::bigint IS NOT DISTINCT FROM estimated_total_cents THEN price_source
+    WHEN $20::bigint IS NULL THEN NULL
+    ELSE 'manual'
+  END,
   deposit_cents = $21::bigint,
   notes = $22,
   updated_by = $23,
   updated_at = NOW()
 WHERE tenant_id = $24
   AND estimate_number = $25
-RETURNING id, tenant_id, estimate_number, customer_id, status, customer_name, primary_phone, secondary_phone, email, origin_address_line1, origin_city, origin_state, origin_postal_code, destination_address_line1, destination_city, destination_state, destination_postal_code, move_date, pickup_time, lead_source, move_size, location_type, estimated_total_cents, deposit_cents, notes, idempotency_key, idempotency_payload_hash, created_by, updated_by, created_at, updated_at
+RETURNING id, tenant_id, estimate_number, customer_id, status, customer_name, primary_phone, secondary_phone, email, origin_address_line1, origin_city, origin_state, origin_postal_code, destination_address_line1, destination_city, destination_state, destination_postal_code, move_date, pickup_time, lead_source, move_size, location_type, estimated_total_cents, deposit_cents, total_cubic_feet, total_weight_lbs, price_source, price_breakdown, priced_at, notes, lost_reason, sent_at, expires_at, status_changed_at, idempotency_key, idempotency_payload_hash, created_by, updated_by, created_at, updated_at
 `
 
 type UpdateEstimateByNumberParams struct {
@@ -3009,7 +8301,16 @@ func (q *Queries) UpdateEstimateByNumber(ctx context.Context, arg UpdateEstimate
 		&i.LocationType,
 		&i.EstimatedTotalCents,
 		&i.DepositCents,
+		&i.TotalCubicFeet,
+		&i.TotalWeightLbs,
+		&i.PriceSource,
+		&i.PriceBreakdown,
+		&i.PricedAt,
 		&i.Notes,
+		&i.LostReason,
+		&i.SentAt,
+		&i.ExpiresAt,
+		&i.StatusChangedAt,
 		&i.IdempotencyKey,
 		&i.IdempotencyPayloadHash,
 		&i.CreatedBy,
@@ -3020,6 +8321,119 @@ func (q *Queries) UpdateEstimateByNumber(ctx context.Context, arg UpdateEstimate
 	return i, err
 }
 
+const updateEstimateLineItem = `-- name: UpdateEstimateLineItem :one
+UPDATE estimate_line_items
+SET
+  room = COALESCE($1, room),
+  item_name = COALESCE($2, item_name),
+  quantity = COALESCE($3::int, quantity),
+  cubic_feet = COALESCE($4::int, cubic_feet),
+  weight_lbs = COALESCE($5::int, weight_lbs),
+  updated_by = $6,
+  updated_at = NOW()
+WHERE id = $7
+  AND tenant_id = $8
+  AND estimate_id = $9
+RETURNING id, tenant_id, estimate_id, inventory_item_id, room, item_name, quantity, cubic_feet, weight_lbs, created_by, updated_by, created_at, updated_at
+`
+
+type UpdateEstimateLineItemParams struct {
+	Room       *string    `json:"room"`
+	ItemName   *string    `json:"item_name"`
+	Quantity   *int32     `json:"quantity"`
+	CubicFeet  *int32     `json:"cubic_feet"`
+	WeightLbs  *int32     `json:"weight_lbs"`
+	UpdatedBy  *uuid.UUID `json:"updated_by"`
+	ID         uuid.UUID  `json:"id"`
+	TenantID   uuid.UUID  `json:"tenant_id"`
+	EstimateID uuid.UUID  `json:"estimate_id"`
+}
+
+func (q *Queries) UpdateEstimateLineItem(ctx context.Context, arg UpdateEstimateLineItemParams) (EstimateLineItem, error) {
+	row := q.db.QueryRow(ctx, updateEstimateLineItem,
+		arg.Room,
+		arg.ItemName,
+		arg.Quantity,
+		arg.CubicFeet,
+		arg.WeightLbs,
+		arg.UpdatedBy,
+		arg.ID,
+		arg.TenantID,
+		arg.EstimateID,
+	)
+	var i EstimateLineItem
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.EstimateID,
+		&i.InventoryItemID,
+		&i.Room,
+		&i.ItemName,
+		&i.Quantity,
+		&i.CubicFeet,
+		&i.WeightLbs,
+		&i.CreatedBy,
+		&i.UpdatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateInventoryItem = `-- name: UpdateInventoryItem :one
+UPDATE inventory_items
+SET
+  name = COALESCE($1, name),
+  default_room = CASE WHEN $2::text IS NULL THEN default_room ELSE NULLIF($2::text, '') END,
+  cubic_feet = COALESCE($3::int, cubic_feet),
+  weight_lbs = COALESCE($4::int, weight_lbs),
+  active = COALESCE($5::boolean, active),
+  updated_by = $6,
+  updated_at = NOW()
+WHERE id = $7
+  AND tenant_id = $8
+RETURNING id, tenant_id, name, default_room, cubic_feet, weight_lbs, active, created_by, updated_by, created_at, updated_at
+`
+
+type UpdateInventoryItemParams struct {
+	Name        *string    `json:"name"`
+	DefaultRoom *string    `json:"default_room"`
+	CubicFeet   *int32     `json:"cubic_feet"`
+	WeightLbs   *int32     `json:"weight_lbs"`
+	Active      *bool      `json:"active"`
+	UpdatedBy   *uuid.UUID `json:"updated_by"`
+	ID          uuid.UUID  `json:"id"`
+	TenantID    uuid.UUID  `json:"tenant_id"`
+}
+
+func (q *Queries) UpdateInventoryItem(ctx context.Context, arg UpdateInventoryItemParams) (InventoryItem, error) {
+	row := q.db.QueryRow(ctx, updateInventoryItem,
+		arg.Name,
+		arg.DefaultRoom,
+		arg.CubicFeet,
+		arg.WeightLbs,
+		arg.Active,
+		arg.UpdatedBy,
+		arg.ID,
+		arg.TenantID,
+	)
+	var i InventoryItem
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.Name,
+		&i.DefaultRoom,
+		&i.CubicFeet,
+		&i.WeightLbs,
+		&i.Active,
+		&i.CreatedBy,
+		&i.UpdatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const updateJobByJobNumber = `-- name: UpdateJobByJobNumber :one
 UPDATE jobs
 SET
@@ -3032,7 +8446,7 @@ SET
   updated_at = NOW()
 WHERE tenant_id = $7
   AND job_number = $8
-RETURNING id, tenant_id, job_number, estimate_id, customer_id, status, scheduled_date, pickup_time, convert_idempotency_key, created_by, updated_by, created_at, updated_at
+RETURNING id, tenant_id, job_number, estimate_id, customer_id, status, scheduled_date, pickup_time, convert_idempotency_key, balance_cents, last_payment_at, created_by, updated_by, created_at, updated_at
 `
 
 type UpdateJobByJobNumberParams struct {
@@ -3068,6 +8482,8 @@ func (q *Queries) UpdateJobByJobNumber(ctx context.Context, arg UpdateJobByJobNu
 		&i.ScheduledDate,
 		&i.PickupTime,
 		&i.ConvertIdempotencyKey,
+		&i.BalanceCents,
+		&i.LastPaymentAt,
 		&i.CreatedBy,
 		&i.UpdatedBy,
 		&i.CreatedAt,
@@ -3086,7 +8502,7 @@ SET
   updated_at = NOW()
 WHERE id = $5
   AND tenant_id = $6
-RETURNING id, tenant_id, job_number, estimate_id, customer_id, status, scheduled_date, pickup_time, convert_idempotency_key, created_by, updated_by, created_at, updated_at
+RETURNING id, tenant_id, job_number, estimate_id, customer_id, status, scheduled_date, pickup_time, convert_idempotency_key, balance_cents, last_payment_at, created_by, updated_by, created_at, updated_at
 `
 
 type UpdateJobScheduleStatusParams struct {
@@ -3118,6 +8534,8 @@ func (q *Queries) UpdateJobScheduleStatus(ctx context.Context, arg UpdateJobSche
 		&i.ScheduledDate,
 		&i.PickupTime,
 		&i.ConvertIdempotencyKey,
+		&i.BalanceCents,
+		&i.LastPaymentAt,
 		&i.CreatedBy,
 		&i.UpdatedBy,
 		&i.CreatedAt,
@@ -3126,6 +8544,41 @@ func (q *Queries) UpdateJobScheduleStatus(ctx context.Context, arg UpdateJobSche
 	return i, err
 }
 
+const updateRole = `-- name: UpdateRole :one
+UPDATE roles
+SET
+  name = COALESCE($1, name),
+  description = COALESCE($2, description)
+WHERE id = $3
+  AND tenant_id = $4
+RETURNING id, tenant_id, name, description, created_at
+`
+
+type UpdateRoleParams struct {
+	Name        *string   `json:"name"`
+	Description *string   `json:"description"`
+	ID          uuid.UUID `json:"id"`
+	TenantID    uuid.UUID `json:"tenant_id"`
+}
+
+func (q *Queries) UpdateRole(ctx context.Context, arg UpdateRoleParams) (Role, error) {
+	row := q.db.QueryRow(ctx, updateRole,
+		arg.Name,
+		arg.Description,
+		arg.ID,
+		arg.TenantID,
+	)
+	var i Role
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const updateStorageRecordByID = `-- name: UpdateStorageRecordByID :one
 UPDATE storage_record
 SET
@@ -3142,36 +8595,30 @@ SET
   oversize_items = $11,
   volume = $12,
   monthly_rate_cents = $13::bigint,
-  storage_balance_cents = $14,
-  move_balance_cents = $15,
-  last_payment_at = $16::timestamptz,
-  notes = $17,
+  notes = $14,
   updated_at = NOW()
-WHERE id = $18
-  AND tenant_id = $19
+WHERE id = $15
+  AND tenant_id = $16
 RETURNING id, tenant_id, job_id, facility, status, date_in, date_out, next_bill_date, lot_number, location_label, vaults, pads, items, oversize_items, volume, monthly_rate_cents, storage_balance_cents, move_balance_cents, last_payment_at, notes, created_at, updated_at
 `
 
 type UpdateStorageRecordByIDParams struct {
-	Facility            string     `json:"facility"`
-	Status              string     `json:"status"`
-	DateIn              *time.Time `json:"date_in"`
-	DateOut             *time.Time `json:"date_out"`
-	NextBillDate        *time.Time `json:"next_bill_date"`
-	LotNumber           *string    `json:"lot_number"`
-	LocationLabel       *string    `json:"location_label"`
-	Vaults              int32      `json:"vaults"`
-	Pads                int32      `json:"pads"`
-	Items               int32      `json:"items"`
-	OversizeItems       int32      `json:"oversize_items"`
-	Volume              int32      `json:"volume"`
-	MonthlyRateCents    *int64     `json:"monthly_rate_cents"`
-	StorageBalanceCents int64      `json:"storage_balance_cents"`
-	MoveBalanceCents    int64      `json:"move_balance_cents"`
-	LastPaymentAt       *time.Time `json:"last_payment_at"`
-	Notes               *string    `json:"notes"`
-	ID                  uuid.UUID  `json:"id"`
-	TenantID            uuid.UUID  `json:"tenant_id"`
+	Facility         string     `json:"facility"`
+	Status           string     `json:"status"`
+	DateIn           *time.Time `json:"date_in"`
+	DateOut          *time.Time `json:"date_out"`
+	NextBillDate     *time.Time `json:"next_bill_date"`
+	LotNumber        *string    `json:"lot_number"`
+	LocationLabel    *string    `json:"location_label"`
+	Vaults           int32      `json:"vaults"`
+	Pads             int32      `json:"pads"`
+	Items            int32      `json:"items"`
+	OversizeItems    int32      `json:"oversize_items"`
+	Volume           int32      `json:"volume"`
+	MonthlyRateCents *int64     `json:"monthly_rate_cents"`
+	Notes            *string    `json:"notes"`
+	ID               uuid.UUID  `json:"id"`
+	TenantID         uuid.UUID  `json:"tenant_id"`
 }
 
 func (q *Queries) UpdateStorageRecordByID(ctx context.Context, arg UpdateStorageRecordByIDParams) (StorageRecord, error) {
@@ -3189,9 +8636,6 @@ func (q *Queries) UpdateStorageRecordByID(ctx context.Context, arg UpdateStorage
 		arg.OversizeItems,
 		arg.Volume,
 		arg.MonthlyRateCents,
-		arg.StorageBalanceCents,
-		arg.MoveBalanceCents,
-		arg.LastPaymentAt,
 		arg.Notes,
 		arg.ID,
 		arg.TenantID,
@@ -3224,6 +8668,126 @@ func (q *Queries) UpdateStorageRecordByID(ctx context.Context, arg UpdateStorage
 	return i, err
 }
 
+const updateTenantUser = `-- name: UpdateTenantUser :one
+UPDATE users
+SET
+  full_name = COALESCE($1, full_name),
+  is_active = COALESCE($2, is_active),
+  updated_at = NOW()
+WHERE id = $3
+  AND tenant_id = $4
+RETURNING id, tenant_id, email, full_name, password_hash, is_active, created_at, updated_at
+`
+
+type UpdateTenantUserParams struct {
+	FullName *string   `json:"full_name"`
+	IsActive *bool     `json:"is_active"`
+	ID       uuid.UUID `json:"id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+}
+
+func (q *Queries) UpdateTenantUser(ctx context.Context, arg UpdateTenantUserParams) (User, error) {
+	row := q.db.QueryRow(ctx, updateTenantUser,
+		arg.FullName,
+		arg.IsActive,
+		arg.ID,
+		arg.TenantID,
+	)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.Email,
+		&i.FullName,
+		&i.PasswordHash,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateUserPasswordHash = `-- name: UpdateUserPasswordHash :execrows
+UPDATE users
+SET
+  password_hash = $1,
+  updated_at = NOW()
+WHERE id = $2
+  AND tenant_id = $3
+`
+
+type UpdateUserPasswordHashParams struct {
+	PasswordHash string    `json:"password_hash"`
+	ID           uuid.UUID `json:"id"`
+	TenantID     uuid.UUID `json:"tenant_id"`
+}
+
+func (q *Queries) UpdateUserPasswordHash(ctx context.Context, arg UpdateUserPasswordHashParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateUserPasswordHash, arg.PasswordHash, arg.ID, arg.TenantID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const upsertEstimateDocumentTemplate = `-- name: UpsertEstimateDocumentTemplate :exec
+INSERT INTO estimate_document_templates (
+  tenant_id,
+  company_name,
+  accent_color,
+  intro_text,
+  terms_text,
+  footer_text,
+  show_line_items,
+  updated_by,
+  updated_at
+) VALUES (
+  $1,
+  $2,
+  $3,
+  $4,
+  $5,
+  $6,
+  $7,
+  $8,
+  NOW()
+)
+ON CONFLICT (tenant_id) DO UPDATE SET
+  company_name = EXCLUDED.company_name,
+  accent_color = EXCLUDED.accent_color,
+  intro_text = EXCLUDED.intro_text,
+  terms_text = EXCLUDED.terms_text,
+  footer_text = EXCLUDED.footer_text,
+  show_line_items = EXCLUDED.show_line_items,
+  updated_by = EXCLUDED.updated_by,
+  updated_at = NOW()
+`
+
+type UpsertEstimateDocumentTemplateParams struct {
+	TenantID      uuid.UUID  `json:"tenant_id"`
+	CompanyName   *string    `json:"company_name"`
+	AccentColor   string     `json:"accent_color"`
+	IntroText     *string    `json:"intro_text"`
+	TermsText     *string    `json:"terms_text"`
+	FooterText    *string    `json:"footer_text"`
+	ShowLineItems bool       `json:"show_line_items"`
+	UpdatedBy     *uuid.UUID `json:"updated_by"`
+}
+
+func (q *Queries) UpsertEstimateDocumentTemplate(ctx context.Context, arg UpsertEstimateDocumentTemplateParams) error {
+	_, err := q.db.Exec(ctx, upsertEstimateDocumentTemplate,
+		arg.TenantID,
+		arg.CompanyName,
+		arg.AccentColor,
+		arg.IntroText,
+		arg.TermsText,
+		arg.FooterText,
+		arg.ShowLineItems,
+		arg.UpdatedBy,
+	)
+	return err
+}
+
 const upsertImportIdempotency = `-- name: UpsertImportIdempotency :one
 INSERT INTO import_idempotency (
   tenant_id,
@@ -3354,29 +8918,321 @@ func (q *Queries) UpsertImportRowResult(ctx context.Context, arg UpsertImportRow
 	return i, err
 }
 
-const userHasPermission = `-- name: UserHasPermission :one
-SELECT EXISTS (
-  SELECT 1
-  FROM user_roles ur
-  JOIN roles r ON r.id = ur.role_id
-  JOIN role_permissions rp ON rp.role_id = r.id
-  JOIN permissions p ON p.id = rp.permission_id
-  WHERE ur.user_id = $1
-    AND ur.tenant_id = $2
-    AND r.tenant_id = $2
-    AND p.name = $3
-) AS has_permission
+const upsertPendingUserMFA = `-- name: UpsertPendingUserMFA :one
+INSERT INTO user_mfa (user_id, tenant_id, totp_secret)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id) DO UPDATE
+SET totp_secret = EXCLUDED.totp_secret,
+    last_used_step = 0,
+    updated_at = NOW()
+WHERE user_mfa.confirmed_at IS NULL
+RETURNING user_id, tenant_id, totp_secret, confirmed_at, last_used_step, created_at, updated_at
 `
 
-type UserHasPermissionParams struct {
+type UpsertPendingUserMFAParams struct {
 	UserID     uuid.UUID `json:"user_id"`
 	TenantID   uuid.UUID `json:"tenant_id"`
-	Permission string    `json:"permission"`
+	TotpSecret string    `json:"totp_secret"`
+}
+
+func (q *Queries) UpsertPendingUserMFA(ctx context.Context, arg UpsertPendingUserMFAParams) (UserMfa, error) {
+	row := q.db.QueryRow(ctx, upsertPendingUserMFA, arg.UserID, arg.TenantID, arg.TotpSecret)
+	var i UserMfa
+	err := row.Scan(
+		&i.UserID,
+		&i.TenantID,
+		&i.TotpSecret,
+		&i.ConfirmedAt,
+		&i.LastUsedStep,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const upsertSSOProvider = `-- name: UpsertSSOProvider :exec
+INSERT INTO tenant_sso_providers (
+  tenant_id,
+  enabled,
+  issuer,
+  client_id,
+  client_secret,
+  jit_provisioning,
+  default_role_id,
+  groups_claim,
+  updated_by
+) VALUES (
+  $1,
+  $2,
+  $3,
+  $4,
+  $5,
+  $6,
+  $7,
+  $8,
+  $9
+)
+ON CONFLICT (tenant_id) DO UPDATE
+SET enabled = EXCLUDED.enabled,
+    issuer = EXCLUDED.issuer,
+    client_id = EXCLUDED.client_id,
+    client_secret = EXCLUDED.client_secret,
+    jit_provisioning = EXCLUDED.jit_provisioning,
+    default_role_id = EXCLUDED.default_role_id,
+    groups_claim = EXCLUDED.groups_claim,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = NOW()
+`
+
+type UpsertSSOProviderParams struct {
+	TenantID        uuid.UUID  `json:"tenant_id"`
+	Enabled         bool       `json:"enabled"`
+	Issuer          string     `json:"issuer"`
+	ClientID        string     `json:"client_id"`
+	ClientSecret    string     `json:"client_secret"`
+	JitProvisioning bool       `json:"jit_provisioning"`
+	DefaultRoleID   *uuid.UUID `json:"default_role_id"`
+	GroupsClaim     string     `json:"groups_claim"`
+	UpdatedBy       *uuid.UUID `json:"updated_by"`
+}
+
+func (q *Queries) UpsertSSOProvider(ctx context.Context, arg UpsertSSOProviderParams) error {
+	_, err := q.db.Exec(ctx, upsertSSOProvider,
+		arg.TenantID,
+		arg.Enabled,
+		arg.Issuer,
+		arg.ClientID,
+		arg.ClientSecret,
+		arg.JitProvisioning,
+		arg.DefaultRoleID,
+		arg.GroupsClaim,
+		arg.UpdatedBy,
+	)
+	return err
+}
+
+const upsertTariff = `-- name: UpsertTariff :one
+INSERT INTO tariffs (
+  tenant_id,
+  minimum_hours,
+  minimum_charge_cents,
+  long_distance_threshold_miles,
+  per_mile_cents,
+  per_cwt_cents,
+  fuel_surcharge_bps,
+  updated_by,
+  updated_at
+) VALUES (
+  $1,
+  $2,
+  $3,
+  $4,
+  $5,
+  $6,
+  $7,
+  $8,
+  NOW()
+)
+ON CONFLICT (tenant_id) DO UPDATE SET
+  minimum_hours = EXCLUDED.minimum_hours,
+  minimum_charge_cents = EXCLUDED.minimum_charge_cents,
+  long_distance_threshold_miles = EXCLUDED.long_distance_threshold_miles,
+  per_mile_cents = EXCLUDED.per_mile_cents,
+  per_cwt_cents = EXCLUDED.per_cwt_cents,
+  fuel_surcharge_bps = EXCLUDED.fuel_surcharge_bps,
+  updated_by = EXCLUDED.updated_by,
+  updated_at = NOW()
+RETURNING tenant_id, minimum_hours, minimum_charge_cents, long_distance_threshold_miles, per_mile_cents, per_cwt_cents, fuel_surcharge_bps, updated_by, updated_at
+`
+
+type UpsertTariffParams struct {
+	TenantID                   uuid.UUID  `json:"tenant_id"`
+	MinimumHours               int32      `json:"minimum_hours"`
+	MinimumChargeCents         int64      `json:"minimum_charge_cents"`
+	LongDistanceThresholdMiles int32      `json:"long_distance_threshold_miles"`
+	PerMileCents               int64      `json:"per_mile_cents"`
+	PerCwtCents                int64      `json:"per_cwt_cents"`
+	FuelSurchargeBps           int32      `json:"fuel_surcharge_bps"`
+	UpdatedBy                  *uuid.UUID `json:"updated_by"`
+}
+
+func (q *Queries) UpsertTariff(ctx context.Context, arg UpsertTariffParams) (Tariff, error) {
+	row := q.db.QueryRow(ctx, upsertTariff,
+		arg.TenantID,
+		arg.MinimumHours,
+		arg.MinimumChargeCents,
+		arg.LongDistanceThresholdMiles,
+		arg.PerMileCents,
+		arg.PerCwtCents,
+		arg.FuelSurchargeBps,
+		arg.UpdatedBy,
+	)
+	var i Tariff
+	err := row.Scan(
+		&i.TenantID,
+		&i.MinimumHours,
+		&i.MinimumChargeCents,
+		&i.LongDistanceThresholdMiles,
+		&i.PerMileCents,
+		&i.PerCwtCents,
+		&i.FuelSurchargeBps,
+		&i.UpdatedBy,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const upsertTenantEmailSettings = `-- name: UpsertTenantEmailSettings :exec
+INSERT INTO tenant_email_settings (
+  tenant_id,
+  enabled,
+  from_name,
+  from_address,
+  reply_to,
+  updated_by,
+  updated_at
+) VALUES (
+  $1,
+  $2,
+  $3,
+  $4,
+  $5,
+  $6,
+  NOW()
+)
+ON CONFLICT (tenant_id) DO UPDATE SET
+  enabled = EXCLUDED.enabled,
+  from_name = EXCLUDED.from_name,
+  from_address = EXCLUDED.from_address,
+  reply_to = EXCLUDED.reply_to,
+  updated_by = EXCLUDED.updated_by,
+  updated_at = NOW()
+`
+
+type UpsertTenantEmailSettingsParams struct {
+	TenantID    uuid.UUID  `json:"tenant_id"`
+	Enabled     bool       `json:"enabled"`
+	FromName    *string    `json:"from_name"`
+	FromAddress *string    `json:"from_address"`
+	ReplyTo     *string    `json:"reply_to"`
+	UpdatedBy   *uuid.UUID `json:"updated_by"`
+}
+
+func (q *Queries) UpsertTenantEmailSettings(ctx context.Context, arg UpsertTenantEmailSettingsParams) error {
+	_, err := q.db.Exec(ctx, upsertTenantEmailSettings,
+		arg.TenantID,
+		arg.Enabled,
+		arg.FromName,
+		arg.FromAddress,
+		arg.ReplyTo,
+		arg.UpdatedBy,
+	)
+	return err
+}
+
+const upsertTenantSettings = `-- name: UpsertTenantSettings :exec
+INSERT INTO tenant_settings (
+  tenant_id,
+  require_mfa_for_sensitive_roles,
+  password_min_length,
+  password_check_breached,
+  estimate_validity_days,
+  updated_by
+) VALUES (
+  $1,
+  $2,
+  $3,
+  $4,
+  $5,
+  $6
+)
+ON CONFLICT (tenant_id) DO UPDATE
+SET require_mfa_for_sensitive_roles = EXCLUDED.require_mfa_for_sensitive_roles,
+    password_min_length = EXCLUDED.password_min_length,
+    password_check_breached = EXCLUDED.password_check_breached,
+    estimate_validity_days = EXCLUDED.estimate_validity_days,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = NOW()
+`
+
+type UpsertTenantSettingsParams struct {
+	TenantID                    uuid.UUID  `json:"tenant_id"`
+	RequireMfaForSensitiveRoles bool       `json:"require_mfa_for_sensitive_roles"`
+	PasswordMinLength           int32      `json:"password_min_length"`
+	PasswordCheckBreached       bool       `json:"password_check_breached"`
+	EstimateValidityDays        int32      `json:"estimate_validity_days"`
+	UpdatedBy                   *uuid.UUID `json:"updated_by"`
+}
+
+func (q *Queries) UpsertTenantSettings(ctx context.Context, arg UpsertTenantSettingsParams) error {
+	_, err := q.db.Exec(ctx, upsertTenantSettings,
+		arg.TenantID,
+		arg.RequireMfaForSensitiveRoles,
+		arg.PasswordMinLength,
+		arg.PasswordCheckBreached,
+		arg.EstimateValidityDays,
+		arg.UpdatedBy,
+	)
+	return err
+}
+
+const upsertUserIdentity = `-- name: UpsertUserIdentity :exec
+INSERT INTO user_identities (tenant_id, user_id, issuer, subject)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (tenant_id, issuer, subject) DO UPDATE
+SET last_login_at = NOW()
+`
+
+type UpsertUserIdentityParams struct {
+	TenantID uuid.UUID `json:"tenant_id"`
+	UserID   uuid.UUID `json:"user_id"`
+	Issuer   string    `json:"issuer"`
+	Subject  string    `json:"subject"`
+}
+
+func (q *Queries) UpsertUserIdentity(ctx context.Context, arg UpsertUserIdentityParams) error {
+	_, err := q.db.Exec(ctx, upsertUserIdentity,
+		arg.TenantID,
+		arg.UserID,
+		arg.Issuer,
+		arg.Subject,
+	)
+	return err
+}
+
+const usePasswordResetToken = `-- name: UsePasswordResetToken :execrows
+UPDATE password_reset_tokens
+SET used_at = NOW()
+WHERE id = $1
+  AND used_at IS NULL
+  AND expires_at > NOW()
+`
+
+func (q *Queries) UsePasswordResetToken(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, usePasswordResetToken, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-func (q *Queries) UserHasPermission(ctx context.Context, arg UserHasPermissionParams) (bool, error) {
-	row := q.db.QueryRow(ctx, userHasPermission, arg.UserID, arg.TenantID, arg.Permission)
-	var has_permission bool
-	err := row.Scan(&has_permission)
-	return has_permission, err
+const useRecoveryCode = `-- name: UseRecoveryCode :execrows
+UPDATE user_mfa_recovery_codes
+SET used_at = NOW()
+WHERE user_id = $1
+  AND code_hash = $2
+  AND used_at IS NULL
+`
+
+type UseRecoveryCodeParams struct {
+	UserID   uuid.UUID `json:"user_id"`
+	CodeHash string    `json:"code_hash"`
+}
+
+func (q *Queries) UseRecoveryCode(ctx context.Context, arg UseRecoveryCodeParams) (int64, error) {
+	result, err := q.db.Exec(ctx, useRecoveryCode, arg.UserID, arg.CodeHash)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
@@ -14,6 +14,15 @@ import (
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// List the current user's personal API tokens
+	// (GET /auth/api-tokens)
+	GetAuthApiTokens(w http.ResponseWriter, r *http.Request)
+	// Create a personal API token
+	// (POST /auth/api-tokens)
+	PostAuthApiTokens(w http.ResponseWriter, r *http.Request)
+	// Revoke a personal API token
+	// (DELETE /auth/api-tokens/{tokenId})
+	DeleteAuthApiTokensTokenId(w http.ResponseWriter, r *http.Request, tokenId openapi_types.UUID)
 	// Get csrf token for session
 	// (GET /auth/csrf)
 	GetAuthCsrf(w http.ResponseWriter, r *http.Request)
@@ -26,27 +35,114 @@ type ServerInterface interface {
 	// Get current user and tenant
 	// (GET /auth/me)
 	GetAuthMe(w http.ResponseWriter, r *http.Request)
+	// Confirm TOTP enrollment
+	// (POST /auth/mfa/totp/confirm)
+	PostAuthMfaTotpConfirm(w http.ResponseWriter, r *http.Request)
+	// Start TOTP enrollment
+	// (POST /auth/mfa/totp/enroll)
+	PostAuthMfaTotpEnroll(w http.ResponseWriter, r *http.Request)
+	// Complete an MFA login with a TOTP or recovery code
+	// (POST /auth/mfa/verify)
+	PostAuthMfaVerify(w http.ResponseWriter, r *http.Request)
+	// Complete single sign-on
+	// (GET /auth/oidc/callback)
+	GetAuthOidcCallback(w http.ResponseWriter, r *http.Request, params GetAuthOidcCallbackParams)
+	// Start single sign-on with the tenant's OpenID Connect provider
+	// (GET /auth/oidc/{tenantSlug}/start)
+	GetAuthOidcTenantSlugStart(w http.ResponseWriter, r *http.Request, tenantSlug string)
+	// Change the current user's password
+	// (POST /auth/password)
+	PostAuthPassword(w http.ResponseWriter, r *http.Request)
+	// Set a new password with a reset token
+	// (POST /auth/password-reset)
+	PostAuthPasswordReset(w http.ResponseWriter, r *http.Request)
+	// List the current user's active sessions
+	// (GET /auth/sessions)
+	GetAuthSessions(w http.ResponseWriter, r *http.Request)
+	// Revoke one of the current user's sessions
+	// (DELETE /auth/sessions/{sessionId})
+	DeleteAuthSessionsSessionId(w http.ResponseWriter, r *http.Request, sessionId openapi_types.UUID)
+	// Re-issue the session for another tenant
+	// (POST /auth/switch-tenant)
+	PostAuthSwitchTenant(w http.ResponseWriter, r *http.Request)
+	// List tenants the current user can switch to
+	// (GET /auth/tenants)
+	GetAuthTenants(w http.ResponseWriter, r *http.Request)
 	// List scheduled jobs for a monthly calendar range
 	// (GET /calendar)
 	GetCalendar(w http.ResponseWriter, r *http.Request, params GetCalendarParams)
+	// List customers
+	// (GET /customers)
+	GetCustomers(w http.ResponseWriter, r *http.Request, params GetCustomersParams)
 	// Create customer
 	// (POST /customers)
 	PostCustomers(w http.ResponseWriter, r *http.Request)
 	// Get customer by id
 	// (GET /customers/{customerId})
 	GetCustomersCustomerId(w http.ResponseWriter, r *http.Request, customerId openapi_types.UUID)
+	// Update customer fields
+	// (PATCH /customers/{customerId})
+	PatchCustomersCustomerId(w http.ResponseWriter, r *http.Request, customerId openapi_types.UUID)
+	// Merge a duplicate customer into this one
+	// (POST /customers/{customerId}/merge)
+	PostCustomersCustomerIdMerge(w http.ResponseWriter, r *http.Request, customerId openapi_types.UUID)
+	// List estimates with filters
+	// (GET /estimates)
+	GetEstimates(w http.ResponseWriter, r *http.Request, params GetEstimatesParams)
 	// Create a draft estimate
 	// (POST /estimates)
 	PostEstimates(w http.ResponseWriter, r *http.Request, params PostEstimatesParams)
 	// Get estimate by id
 	// (GET /estimates/{estimateId})
 	GetEstimatesEstimateId(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID)
-	// Update estimate fields
+	// Update estimate fields or move it along the status lifecycle
 	// (PATCH /estimates/{estimateId})
 	PatchEstimatesEstimateId(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID)
+	// Create a signed link the customer can use to accept the estimate online
+	// (POST /estimates/{estimateId}/acceptance-links)
+	PostEstimatesEstimateIdAcceptanceLinks(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID)
 	// Convert estimate to job (idempotent)
 	// (POST /estimates/{estimateId}/convert)
 	PostEstimatesEstimateIdConvert(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID, params PostEstimatesEstimateIdConvertParams)
+	// Render the estimate as a branded PDF
+	// (GET /estimates/{estimateId}/document.pdf)
+	GetEstimatesEstimateIdDocumentPdf(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID)
+	// List email queued for the estimate
+	// (GET /estimates/{estimateId}/emails)
+	GetEstimatesEstimateIdEmails(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID)
+	// Queue the `estimate_sent` email for the estimate
+	// (POST /estimates/{estimateId}/emails)
+	PostEstimatesEstimateIdEmails(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID)
+	// List the estimate's inventory line items
+	// (GET /estimates/{estimateId}/line-items)
+	GetEstimatesEstimateIdLineItems(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID)
+	// Add an inventory line item to the estimate
+	// (POST /estimates/{estimateId}/line-items)
+	PostEstimatesEstimateIdLineItems(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID)
+	// Remove an estimate line item
+	// (DELETE /estimates/{estimateId}/line-items/{lineItemId})
+	DeleteEstimatesEstimateIdLineItemsLineItemId(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID, lineItemId openapi_types.UUID)
+	// Update an estimate line item
+	// (PATCH /estimates/{estimateId}/line-items/{lineItemId})
+	PatchEstimatesEstimateIdLineItemsLineItemId(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID, lineItemId openapi_types.UUID)
+	// Price the estimate from the tenant tariff
+	// (POST /estimates/{estimateId}/price)
+	PostEstimatesEstimateIdPrice(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID)
+	// List the estimate's revisions, newest first
+	// (GET /estimates/{estimateId}/revisions)
+	GetEstimatesEstimateIdRevisions(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID)
+	// Get one estimate revision with its snapshot
+	// (GET /estimates/{estimateId}/revisions/{revisionNumber})
+	GetEstimatesEstimateIdRevisionsRevisionNumber(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID, revisionNumber int)
+	// Compare an estimate revision with an earlier one
+	// (GET /estimates/{estimateId}/revisions/{revisionNumber}/diff)
+	GetEstimatesEstimateIdRevisionsRevisionNumberDiff(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID, revisionNumber int, params GetEstimatesEstimateIdRevisionsRevisionNumberDiffParams)
+	// Restore an estimate to a previous revision
+	// (POST /estimates/{estimateId}/revisions/{revisionNumber}/restore)
+	PostEstimatesEstimateIdRevisionsRevisionNumberRestore(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID, revisionNumber int)
+	// List the customer signatures recorded for the estimate
+	// (GET /estimates/{estimateId}/signatures)
+	GetEstimatesEstimateIdSignatures(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID)
 	// Export tenant customers CSV
 	// (GET /exports/customers.csv)
 	GetExportsCustomersCsv(w http.ResponseWriter, r *http.Request)
@@ -80,15 +176,102 @@ type ServerInterface interface {
 	// Download full import report JSON
 	// (GET /imports/{importRunId}/report.json)
 	GetImportsImportRunIdReportJson(w http.ResponseWriter, r *http.Request, importRunId openapi_types.UUID)
+	// List the tenant inventory item catalog
+	// (GET /inventory-items)
+	GetInventoryItems(w http.ResponseWriter, r *http.Request, params GetInventoryItemsParams)
+	// Add an item to the tenant inventory catalog
+	// (POST /inventory-items)
+	PostInventoryItems(w http.ResponseWriter, r *http.Request)
+	// Delete a catalog item
+	// (DELETE /inventory-items/{itemId})
+	DeleteInventoryItemsItemId(w http.ResponseWriter, r *http.Request, itemId openapi_types.UUID)
+	// Update a catalog item
+	// (PATCH /inventory-items/{itemId})
+	PatchInventoryItemsItemId(w http.ResponseWriter, r *http.Request, itemId openapi_types.UUID)
+	// List jobs with filters
+	// (GET /jobs)
+	GetJobs(w http.ResponseWriter, r *http.Request, params GetJobsParams)
 	// Get job by id
 	// (GET /jobs/{jobId})
 	GetJobsJobId(w http.ResponseWriter, r *http.Request, jobId openapi_types.UUID)
 	// Update minimal job scheduling fields
 	// (PATCH /jobs/{jobId})
 	PatchJobsJobId(w http.ResponseWriter, r *http.Request, jobId openapi_types.UUID)
+	// List email queued for the job
+	// (GET /jobs/{jobId}/emails)
+	GetJobsJobIdEmails(w http.ResponseWriter, r *http.Request, jobId openapi_types.UUID)
+	// Queue the `booking_confirmation` email for the job
+	// (POST /jobs/{jobId}/emails)
+	PostJobsJobIdEmails(w http.ResponseWriter, r *http.Request, jobId openapi_types.UUID)
+	// List job ledger entries with the derived balance
+	// (GET /jobs/{jobId}/ledger)
+	GetJobsJobIdLedger(w http.ResponseWriter, r *http.Request, jobId openapi_types.UUID)
+	// Post a charge, payment, refund or adjustment to the job ledger (idempotent)
+	// (POST /jobs/{jobId}/ledger)
+	PostJobsJobIdLedger(w http.ResponseWriter, r *http.Request, jobId openapi_types.UUID, params PostJobsJobIdLedgerParams)
 	// Create a storage record for a job
 	// (POST /jobs/{jobId}/storage)
 	PostJobsJobIdStorage(w http.ResponseWriter, r *http.Request, jobId openapi_types.UUID)
+	// List the global permission catalog
+	// (GET /permissions)
+	GetPermissions(w http.ResponseWriter, r *http.Request)
+	// Show the estimate behind an acceptance link
+	// (GET /public/estimate-acceptance/{token})
+	GetPublicEstimateAcceptanceToken(w http.ResponseWriter, r *http.Request, token AcceptanceToken)
+	// Accept the estimate with a typed-name signature
+	// (POST /public/estimate-acceptance/{token}/accept)
+	PostPublicEstimateAcceptanceTokenAccept(w http.ResponseWriter, r *http.Request, token AcceptanceToken)
+	// Render the estimate behind an acceptance link as a PDF
+	// (GET /public/estimate-acceptance/{token}/document.pdf)
+	GetPublicEstimateAcceptanceTokenDocumentPdf(w http.ResponseWriter, r *http.Request, token AcceptanceToken)
+	// List tenant roles
+	// (GET /roles)
+	GetRoles(w http.ResponseWriter, r *http.Request)
+	// Create a tenant role
+	// (POST /roles)
+	PostRoles(w http.ResponseWriter, r *http.Request)
+	// Delete a tenant role
+	// (DELETE /roles/{roleId})
+	DeleteRolesRoleId(w http.ResponseWriter, r *http.Request, roleId openapi_types.UUID)
+	// Get tenant role by id
+	// (GET /roles/{roleId})
+	GetRolesRoleId(w http.ResponseWriter, r *http.Request, roleId openapi_types.UUID)
+	// Update a tenant role
+	// (PATCH /roles/{roleId})
+	PatchRolesRoleId(w http.ResponseWriter, r *http.Request, roleId openapi_types.UUID)
+	// Quick search across estimates, jobs and customers
+	// (GET /search)
+	GetSearch(w http.ResponseWriter, r *http.Request, params GetSearchParams)
+	// Get tenant settings
+	// (GET /settings)
+	GetSettings(w http.ResponseWriter, r *http.Request)
+	// Update tenant settings
+	// (PATCH /settings)
+	PatchSettings(w http.ResponseWriter, r *http.Request)
+	// Get the tenant's outbound email settings and templates
+	// (GET /settings/email)
+	GetSettingsEmail(w http.ResponseWriter, r *http.Request)
+	// Replace the tenant's outbound email settings
+	// (PUT /settings/email)
+	PutSettingsEmail(w http.ResponseWriter, r *http.Request)
+	// Get the tenant's estimate document template
+	// (GET /settings/estimate-document)
+	GetSettingsEstimateDocument(w http.ResponseWriter, r *http.Request)
+	// Replace the tenant's estimate document template
+	// (PUT /settings/estimate-document)
+	PutSettingsEstimateDocument(w http.ResponseWriter, r *http.Request)
+	// Get the tenant's single sign-on configuration
+	// (GET /settings/sso)
+	GetSettingsSso(w http.ResponseWriter, r *http.Request)
+	// Replace the tenant's single sign-on configuration
+	// (PUT /settings/sso)
+	PutSettingsSso(w http.ResponseWriter, r *http.Request)
+	// Get the tenant's pricing tariff
+	// (GET /settings/tariff)
+	GetSettingsTariff(w http.ResponseWriter, r *http.Request)
+	// Replace the tenant's pricing tariff
+	// (PUT /settings/tariff)
+	PutSettingsTariff(w http.ResponseWriter, r *http.Request)
 	// List storage rows for a facility
 	// (GET /storage)
 	GetStorage(w http.ResponseWriter, r *http.Request, params GetStorageParams)
@@ -98,12 +281,69 @@ type ServerInterface interface {
 	// Replace editable storage record fields
 	// (PUT /storage/{storageRecordId})
 	PutStorageStorageRecordId(w http.ResponseWriter, r *http.Request, storageRecordId openapi_types.UUID)
+	// List email queued for the storage record
+	// (GET /storage/{storageRecordId}/emails)
+	GetStorageStorageRecordIdEmails(w http.ResponseWriter, r *http.Request, storageRecordId openapi_types.UUID)
+	// Queue the `storage_invoice` email for the storage record
+	// (POST /storage/{storageRecordId}/emails)
+	PostStorageStorageRecordIdEmails(w http.ResponseWriter, r *http.Request, storageRecordId openapi_types.UUID)
+	// List storage ledger entries with the derived balance
+	// (GET /storage/{storageRecordId}/ledger)
+	GetStorageStorageRecordIdLedger(w http.ResponseWriter, r *http.Request, storageRecordId openapi_types.UUID)
+	// Post a charge, payment, refund or adjustment to the storage ledger (idempotent)
+	// (POST /storage/{storageRecordId}/ledger)
+	PostStorageStorageRecordIdLedger(w http.ResponseWriter, r *http.Request, storageRecordId openapi_types.UUID, params PostStorageStorageRecordIdLedgerParams)
+	// List users in the tenant
+	// (GET /users)
+	GetUsers(w http.ResponseWriter, r *http.Request)
+	// Create or invite a tenant user
+	// (POST /users)
+	PostUsers(w http.ResponseWriter, r *http.Request)
+	// Get tenant user by id
+	// (GET /users/{userId})
+	GetUsersUserId(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID)
+	// Update a tenant user's name or active flag
+	// (PATCH /users/{userId})
+	PatchUsersUserId(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID)
+	// Reset a tenant user's password
+	// (POST /users/{userId}/password-reset)
+	PostUsersUserIdPasswordReset(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID)
+	// Issue a single-use password reset token for a tenant user
+	// (POST /users/{userId}/password-reset-token)
+	PostUsersUserIdPasswordResetToken(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID)
+	// Replace the roles assigned to a tenant user
+	// (PUT /users/{userId}/roles)
+	PutUsersUserIdRoles(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID)
+	// Revoke all sessions of a tenant user
+	// (DELETE /users/{userId}/sessions)
+	DeleteUsersUserIdSessions(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID)
+	// Clear a user's login lockout
+	// (POST /users/{userId}/unlock)
+	PostUsersUserIdUnlock(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID)
 }
 
 // Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
 
 type Unimplemented struct{}
 
+// List the current user's personal API tokens
+// (GET /auth/api-tokens)
+func (_ Unimplemented) GetAuthApiTokens(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a personal API token
+// (POST /auth/api-tokens)
+func (_ Unimplemented) PostAuthApiTokens(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Revoke a personal API token
+// (DELETE /auth/api-tokens/{tokenId})
+func (_ Unimplemented) DeleteAuthApiTokensTokenId(w http.ResponseWriter, r *http.Request, tokenId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Get csrf token for session
 // (GET /auth/csrf)
 func (_ Unimplemented) GetAuthCsrf(w http.ResponseWriter, r *http.Request) {
@@ -128,12 +368,84 @@ func (_ Unimplemented) GetAuthMe(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Confirm TOTP enrollment
+// (POST /auth/mfa/totp/confirm)
+func (_ Unimplemented) PostAuthMfaTotpConfirm(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Start TOTP enrollment
+// (POST /auth/mfa/totp/enroll)
+func (_ Unimplemented) PostAuthMfaTotpEnroll(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Complete an MFA login with a TOTP or recovery code
+// (POST /auth/mfa/verify)
+func (_ Unimplemented) PostAuthMfaVerify(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Complete single sign-on
+// (GET /auth/oidc/callback)
+func (_ Unimplemented) GetAuthOidcCallback(w http.ResponseWriter, r *http.Request, params GetAuthOidcCallbackParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Start single sign-on with the tenant's OpenID Connect provider
+// (GET /auth/oidc/{tenantSlug}/start)
+func (_ Unimplemented) GetAuthOidcTenantSlugStart(w http.ResponseWriter, r *http.Request, tenantSlug string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Change the current user's password
+// (POST /auth/password)
+func (_ Unimplemented) PostAuthPassword(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Set a new password with a reset token
+// (POST /auth/password-reset)
+func (_ Unimplemented) PostAuthPasswordReset(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the current user's active sessions
+// (GET /auth/sessions)
+func (_ Unimplemented) GetAuthSessions(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Revoke one of the current user's sessions
+// (DELETE /auth/sessions/{sessionId})
+func (_ Unimplemented) DeleteAuthSessionsSessionId(w http.ResponseWriter, r *http.Request, sessionId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Re-issue the session for another tenant
+// (POST /auth/switch-tenant)
+func (_ Unimplemented) PostAuthSwitchTenant(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List tenants the current user can switch to
+// (GET /auth/tenants)
+func (_ Unimplemented) GetAuthTenants(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // List scheduled jobs for a monthly calendar range
 // (GET /calendar)
 func (_ Unimplemented) GetCalendar(w http.ResponseWriter, r *http.Request, params GetCalendarParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// List customers
+// (GET /customers)
+func (_ Unimplemented) GetCustomers(w http.ResponseWriter, r *http.Request, params GetCustomersParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Create customer
 // (POST /customers)
 func (_ Unimplemented) PostCustomers(w http.ResponseWriter, r *http.Request) {
@@ -146,6 +458,24 @@ func (_ Unimplemented) GetCustomersCustomerId(w http.ResponseWriter, r *http.Req
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Update customer fields
+// (PATCH /customers/{customerId})
+func (_ Unimplemented) PatchCustomersCustomerId(w http.ResponseWriter, r *http.Request, customerId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Merge a duplicate customer into this one
+// (POST /customers/{customerId}/merge)
+func (_ Unimplemented) PostCustomersCustomerIdMerge(w http.ResponseWriter, r *http.Request, customerId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List estimates with filters
+// (GET /estimates)
+func (_ Unimplemented) GetEstimates(w http.ResponseWriter, r *http.Request, params GetEstimatesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Create a draft estimate
 // (POST /estimates)
 func (_ Unimplemented) PostEstimates(w http.ResponseWriter, r *http.Request, params PostEstimatesParams) {
@@ -158,18 +488,102 @@ func (_ Unimplemented) GetEstimatesEstimateId(w http.ResponseWriter, r *http.Req
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Update estimate fields
+// Update estimate fields or move it along the status lifecycle
 // (PATCH /estimates/{estimateId})
 func (_ Unimplemented) PatchEstimatesEstimateId(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Create a signed link the customer can use to accept the estimate online
+// (POST /estimates/{estimateId}/acceptance-links)
+func (_ Unimplemented) PostEstimatesEstimateIdAcceptanceLinks(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Convert estimate to job (idempotent)
 // (POST /estimates/{estimateId}/convert)
 func (_ Unimplemented) PostEstimatesEstimateIdConvert(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID, params PostEstimatesEstimateIdConvertParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Render the estimate as a branded PDF
+// (GET /estimates/{estimateId}/document.pdf)
+func (_ Unimplemented) GetEstimatesEstimateIdDocumentPdf(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List email queued for the estimate
+// (GET /estimates/{estimateId}/emails)
+func (_ Unimplemented) GetEstimatesEstimateIdEmails(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Queue the `estimate_sent` email for the estimate
+// (POST /estimates/{estimateId}/emails)
+func (_ Unimplemented) PostEstimatesEstimateIdEmails(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the estimate's inventory line items
+// (GET /estimates/{estimateId}/line-items)
+func (_ Unimplemented) GetEstimatesEstimateIdLineItems(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Add an inventory line item to the estimate
+// (POST /estimates/{estimateId}/line-items)
+func (_ Unimplemented) PostEstimatesEstimateIdLineItems(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Remove an estimate line item
+// (DELETE /estimates/{estimateId}/line-items/{lineItemId})
+func (_ Unimplemented) DeleteEstimatesEstimateIdLineItemsLineItemId(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID, lineItemId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update an estimate line item
+// (PATCH /estimates/{estimateId}/line-items/{lineItemId})
+func (_ Unimplemented) PatchEstimatesEstimateIdLineItemsLineItemId(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID, lineItemId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Price the estimate from the tenant tariff
+// (POST /estimates/{estimateId}/price)
+func (_ Unimplemented) PostEstimatesEstimateIdPrice(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the estimate's revisions, newest first
+// (GET /estimates/{estimateId}/revisions)
+func (_ Unimplemented) GetEstimatesEstimateIdRevisions(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get one estimate revision with its snapshot
+// (GET /estimates/{estimateId}/revisions/{revisionNumber})
+func (_ Unimplemented) GetEstimatesEstimateIdRevisionsRevisionNumber(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID, revisionNumber int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Compare an estimate revision with an earlier one
+// (GET /estimates/{estimateId}/revisions/{revisionNumber}/diff)
+func (_ Unimplemented) GetEstimatesEstimateIdRevisionsRevisionNumberDiff(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID, revisionNumber int, params GetEstimatesEstimateIdRevisionsRevisionNumberDiffParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Restore an estimate to a previous revision
+// (POST /estimates/{estimateId}/revisions/{revisionNumber}/restore)
+func (_ Unimplemented) PostEstimatesEstimateIdRevisionsRevisionNumberRestore(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID, revisionNumber int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the customer signatures recorded for the estimate
+// (GET /estimates/{estimateId}/signatures)
+func (_ Unimplemented) GetEstimatesEstimateIdSignatures(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Export tenant customers CSV
 // (GET /exports/customers.csv)
 func (_ Unimplemented) GetExportsCustomersCsv(w http.ResponseWriter, r *http.Request) {
@@ -236,6 +650,36 @@ func (_ Unimplemented) GetImportsImportRunIdReportJson(w http.ResponseWriter, r
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// List the tenant inventory item catalog
+// (GET /inventory-items)
+func (_ Unimplemented) GetInventoryItems(w http.ResponseWriter, r *http.Request, params GetInventoryItemsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Add an item to the tenant inventory catalog
+// (POST /inventory-items)
+func (_ Unimplemented) PostInventoryItems(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a catalog item
+// (DELETE /inventory-items/{itemId})
+func (_ Unimplemented) DeleteInventoryItemsItemId(w http.ResponseWriter, r *http.Request, itemId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update a catalog item
+// (PATCH /inventory-items/{itemId})
+func (_ Unimplemented) PatchInventoryItemsItemId(w http.ResponseWriter, r *http.Request, itemId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List jobs with filters
+// (GET /jobs)
+func (_ Unimplemented) GetJobs(w http.ResponseWriter, r *http.Request, params GetJobsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Get job by id
 // (GET /jobs/{jobId})
 func (_ Unimplemented) GetJobsJobId(w http.ResponseWriter, r *http.Request, jobId openapi_types.UUID) {
@@ -248,12 +692,156 @@ func (_ Unimplemented) PatchJobsJobId(w http.ResponseWriter, r *http.Request, jo
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// List email queued for the job
+// (GET /jobs/{jobId}/emails)
+func (_ Unimplemented) GetJobsJobIdEmails(w http.ResponseWriter, r *http.Request, jobId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Queue the `booking_confirmation` email for the job
+// (POST /jobs/{jobId}/emails)
+func (_ Unimplemented) PostJobsJobIdEmails(w http.ResponseWriter, r *http.Request, jobId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List job ledger entries with the derived balance
+// (GET /jobs/{jobId}/ledger)
+func (_ Unimplemented) GetJobsJobIdLedger(w http.ResponseWriter, r *http.Request, jobId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Post a charge, payment, refund or adjustment to the job ledger (idempotent)
+// (POST /jobs/{jobId}/ledger)
+func (_ Unimplemented) PostJobsJobIdLedger(w http.ResponseWriter, r *http.Request, jobId openapi_types.UUID, params PostJobsJobIdLedgerParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Create a storage record for a job
 // (POST /jobs/{jobId}/storage)
 func (_ Unimplemented) PostJobsJobIdStorage(w http.ResponseWriter, r *http.Request, jobId openapi_types.UUID) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// List the global permission catalog
+// (GET /permissions)
+func (_ Unimplemented) GetPermissions(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Show the estimate behind an acceptance link
+// (GET /public/estimate-acceptance/{token})
+func (_ Unimplemented) GetPublicEstimateAcceptanceToken(w http.ResponseWriter, r *http.Request, token AcceptanceToken) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Accept the estimate with a typed-name signature
+// (POST /public/estimate-acceptance/{token}/accept)
+func (_ Unimplemented) PostPublicEstimateAcceptanceTokenAccept(w http.ResponseWriter, r *http.Request, token AcceptanceToken) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Render the estimate behind an acceptance link as a PDF
+// (GET /public/estimate-acceptance/{token}/document.pdf)
+func (_ Unimplemented) GetPublicEstimateAcceptanceTokenDocumentPdf(w http.ResponseWriter, r *http.Request, token AcceptanceToken) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List tenant roles
+// (GET /roles)
+func (_ Unimplemented) GetRoles(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a tenant role
+// (POST /roles)
+func (_ Unimplemented) PostRoles(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a tenant role
+// (DELETE /roles/{roleId})
+func (_ Unimplemented) DeleteRolesRoleId(w http.ResponseWriter, r *http.Request, roleId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get tenant role by id
+// (GET /roles/{roleId})
+func (_ Unimplemented) GetRolesRoleId(w http.ResponseWriter, r *http.Request, roleId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update a tenant role
+// (PATCH /roles/{roleId})
+func (_ Unimplemented) PatchRolesRoleId(w http.ResponseWriter, r *http.Request, roleId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Quick search across estimates, jobs and customers
+// (GET /search)
+func (_ Unimplemented) GetSearch(w http.ResponseWriter, r *http.Request, params GetSearchParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get tenant settings
+// (GET /settings)
+func (_ Unimplemented) GetSettings(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update tenant settings
+// (PATCH /settings)
+func (_ Unimplemented) PatchSettings(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the tenant's outbound email settings and templates
+// (GET /settings/email)
+func (_ Unimplemented) GetSettingsEmail(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Replace the tenant's outbound email settings
+// (PUT /settings/email)
+func (_ Unimplemented) PutSettingsEmail(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the tenant's estimate document template
+// (GET /settings/estimate-document)
+func (_ Unimplemented) GetSettingsEstimateDocument(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Replace the tenant's estimate document template
+// (PUT /settings/estimate-document)
+func (_ Unimplemented) PutSettingsEstimateDocument(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the tenant's single sign-on configuration
+// (GET /settings/sso)
+func (_ Unimplemented) GetSettingsSso(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Replace the tenant's single sign-on configuration
+// (PUT /settings/sso)
+func (_ Unimplemented) PutSettingsSso(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the tenant's pricing tariff
+// (GET /settings/tariff)
+func (_ Unimplemented) GetSettingsTariff(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Replace the tenant's pricing tariff
+// (PUT /settings/tariff)
+func (_ Unimplemented) PutSettingsTariff(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // List storage rows for a facility
 // (GET /storage)
 func (_ Unimplemented) GetStorage(w http.ResponseWriter, r *http.Request, params GetStorageParams) {
@@ -272,6 +860,84 @@ func (_ Unimplemented) PutStorageStorageRecordId(w http.ResponseWriter, r *http.
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// List email queued for the storage record
+// (GET /storage/{storageRecordId}/emails)
+func (_ Unimplemented) GetStorageStorageRecordIdEmails(w http.ResponseWriter, r *http.Request, storageRecordId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Queue the `storage_invoice` email for the storage record
+// (POST /storage/{storageRecordId}/emails)
+func (_ Unimplemented) PostStorageStorageRecordIdEmails(w http.ResponseWriter, r *http.Request, storageRecordId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List storage ledger entries with the derived balance
+// (GET /storage/{storageRecordId}/ledger)
+func (_ Unimplemented) GetStorageStorageRecordIdLedger(w http.ResponseWriter, r *http.Request, storageRecordId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Post a charge, payment, refund or adjustment to the storage ledger (idempotent)
+// (POST /storage/{storageRecordId}/ledger)
+func (_ Unimplemented) PostStorageStorageRecordIdLedger(w http.ResponseWriter, r *http.Request, storageRecordId openapi_types.UUID, params PostStorageStorageRecordIdLedgerParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List users in the tenant
+// (GET /users)
+func (_ Unimplemented) GetUsers(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create or invite a tenant user
+// (POST /users)
+func (_ Unimplemented) PostUsers(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get tenant user by id
+// (GET /users/{userId})
+func (_ Unimplemented) GetUsersUserId(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update a tenant user's name or active flag
+// (PATCH /users/{userId})
+func (_ Unimplemented) PatchUsersUserId(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Reset a tenant user's password
+// (POST /users/{userId}/password-reset)
+func (_ Unimplemented) PostUsersUserIdPasswordReset(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Issue a single-use password reset token for a tenant user
+// (POST /users/{userId}/password-reset-token)
+func (_ Unimplemented) PostUsersUserIdPasswordResetToken(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Replace the roles assigned to a tenant user
+// (PUT /users/{userId}/roles)
+func (_ Unimplemented) PutUsersUserIdRoles(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Revoke all sessions of a tenant user
+// (DELETE /users/{userId}/sessions)
+func (_ Unimplemented) DeleteUsersUserIdSessions(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Clear a user's login lockout
+// (POST /users/{userId}/unlock)
+func (_ Unimplemented) PostUsersUserIdUnlock(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // ServerInterfaceWrapper converts contexts to parameters.
 type ServerInterfaceWrapper struct {
 	Handler            ServerInterface
@@ -281,11 +947,11 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
-// GetAuthCsrf operation middleware
-func (siw *ServerInterfaceWrapper) GetAuthCsrf(w http.ResponseWriter, r *http.Request) {
+// GetAuthApiTokens operation middleware
+func (siw *ServerInterfaceWrapper) GetAuthApiTokens(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetAuthCsrf(w, r)
+		siw.Handler.GetAuthApiTokens(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -295,11 +961,11 @@ func (siw *ServerInterfaceWrapper) GetAuthCsrf(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
-// PostAuthLogin operation middleware
-func (siw *ServerInterfaceWrapper) PostAuthLogin(w http.ResponseWriter, r *http.Request) {
+// PostAuthApiTokens operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthApiTokens(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostAuthLogin(w, r)
+		siw.Handler.PostAuthApiTokens(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -309,11 +975,22 @@ func (siw *ServerInterfaceWrapper) PostAuthLogin(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
-// PostAuthLogout operation middleware
-func (siw *ServerInterfaceWrapper) PostAuthLogout(w http.ResponseWriter, r *http.Request) {
+// DeleteAuthApiTokensTokenId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteAuthApiTokensTokenId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "tokenId" -------------
+	var tokenId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tokenId", chi.URLParam(r, "tokenId"), &tokenId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tokenId", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostAuthLogout(w, r)
+		siw.Handler.DeleteAuthApiTokensTokenId(w, r, tokenId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -323,11 +1000,11 @@ func (siw *ServerInterfaceWrapper) PostAuthLogout(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r)
 }
 
-// GetAuthMe operation middleware
-func (siw *ServerInterfaceWrapper) GetAuthMe(w http.ResponseWriter, r *http.Request) {
+// GetAuthCsrf operation middleware
+func (siw *ServerInterfaceWrapper) GetAuthCsrf(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetAuthMe(w, r)
+		siw.Handler.GetAuthCsrf(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -337,78 +1014,39 @@ func (siw *ServerInterfaceWrapper) GetAuthMe(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r)
 }
 
-// GetCalendar operation middleware
-func (siw *ServerInterfaceWrapper) GetCalendar(w http.ResponseWriter, r *http.Request) {
-
-	var err error
+// PostAuthLogin operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthLogin(w http.ResponseWriter, r *http.Request) {
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetCalendarParams
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAuthLogin(w, r)
+	}))
 
-	// ------------- Required query parameter "from" -------------
-
-	if paramValue := r.URL.Query().Get("from"); paramValue != "" {
-
-	} else {
-		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "from"})
-		return
-	}
-
-	err = runtime.BindQueryParameter("form", true, true, "from", r.URL.Query(), &params.From)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
-		return
-	}
-
-	// ------------- Required query parameter "to" -------------
-
-	if paramValue := r.URL.Query().Get("to"); paramValue != "" {
-
-	} else {
-		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "to"})
-		return
-	}
-
-	err = runtime.BindQueryParameter("form", true, true, "to", r.URL.Query(), &params.To)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
-		return
-	}
-
-	// ------------- Optional query parameter "phase" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "phase", r.URL.Query(), &params.Phase)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "phase", Err: err})
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
-	// ------------- Optional query parameter "jobType" -------------
+	handler.ServeHTTP(w, r)
+}
 
-	err = runtime.BindQueryParameter("form", true, false, "jobType", r.URL.Query(), &params.JobType)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "jobType", Err: err})
-		return
-	}
+// PostAuthLogout operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthLogout(w http.ResponseWriter, r *http.Request) {
 
-	// ------------- Optional query parameter "userId" -------------
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAuthLogout(w, r)
+	}))
 
-	err = runtime.BindQueryParameter("form", true, false, "userId", r.URL.Query(), &params.UserId)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
-	// ------------- Optional query parameter "departmentId" -------------
+	handler.ServeHTTP(w, r)
+}
 
-	err = runtime.BindQueryParameter("form", true, false, "departmentId", r.URL.Query(), &params.DepartmentId)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "departmentId", Err: err})
-		return
-	}
+// GetAuthMe operation middleware
+func (siw *ServerInterfaceWrapper) GetAuthMe(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetCalendar(w, r, params)
+		siw.Handler.GetAuthMe(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -418,11 +1056,11 @@ func (siw *ServerInterfaceWrapper) GetCalendar(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
-// PostCustomers operation middleware
-func (siw *ServerInterfaceWrapper) PostCustomers(w http.ResponseWriter, r *http.Request) {
+// PostAuthMfaTotpConfirm operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthMfaTotpConfirm(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostCustomers(w, r)
+		siw.Handler.PostAuthMfaTotpConfirm(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -432,22 +1070,11 @@ func (siw *ServerInterfaceWrapper) PostCustomers(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
-// GetCustomersCustomerId operation middleware
-func (siw *ServerInterfaceWrapper) GetCustomersCustomerId(w http.ResponseWriter, r *http.Request) {
-
-	var err error
-
-	// ------------- Path parameter "customerId" -------------
-	var customerId openapi_types.UUID
-
-	err = runtime.BindStyledParameterWithOptions("simple", "customerId", chi.URLParam(r, "customerId"), &customerId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "customerId", Err: err})
-		return
-	}
+// PostAuthMfaTotpEnroll operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthMfaTotpEnroll(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetCustomersCustomerId(w, r, customerId)
+		siw.Handler.PostAuthMfaTotpEnroll(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -457,41 +1084,11 @@ func (siw *ServerInterfaceWrapper) GetCustomersCustomerId(w http.ResponseWriter,
 	handler.ServeHTTP(w, r)
 }
 
-// PostEstimates operation middleware
-func (siw *ServerInterfaceWrapper) PostEstimates(w http.ResponseWriter, r *http.Request) {
-
-	var err error
-
-	// Parameter object where we will unmarshal all parameters from the context
-	var params PostEstimatesParams
-
-	headers := r.Header
-
-	// ------------- Required header parameter "Idempotency-Key" -------------
-	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
-		var IdempotencyKey IdempotencyKey
-		n := len(valueList)
-		if n != 1 {
-			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
-			return
-		}
-
-		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
-		if err != nil {
-			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
-			return
-		}
-
-		params.IdempotencyKey = IdempotencyKey
-
-	} else {
-		err := fmt.Errorf("Header parameter Idempotency-Key is required, but not found")
-		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "Idempotency-Key", Err: err})
-		return
-	}
+// PostAuthMfaVerify operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthMfaVerify(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostEstimates(w, r, params)
+		siw.Handler.PostAuthMfaVerify(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -501,47 +1098,48 @@ func (siw *ServerInterfaceWrapper) PostEstimates(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
-// GetEstimatesEstimateId operation middleware
-func (siw *ServerInterfaceWrapper) GetEstimatesEstimateId(w http.ResponseWriter, r *http.Request) {
+// GetAuthOidcCallback operation middleware
+func (siw *ServerInterfaceWrapper) GetAuthOidcCallback(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "estimateId" -------------
-	var estimateId openapi_types.UUID
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAuthOidcCallbackParams
 
-	err = runtime.BindStyledParameterWithOptions("simple", "estimateId", chi.URLParam(r, "estimateId"), &estimateId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	// ------------- Optional query parameter "code" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "code", r.URL.Query(), &params.Code)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "estimateId", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "code", Err: err})
 		return
 	}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetEstimatesEstimateId(w, r, estimateId)
-	}))
+	// ------------- Optional query parameter "state" -------------
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+	err = runtime.BindQueryParameter("form", true, false, "state", r.URL.Query(), &params.State)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "state", Err: err})
+		return
 	}
 
-	handler.ServeHTTP(w, r)
-}
-
-// PatchEstimatesEstimateId operation middleware
-func (siw *ServerInterfaceWrapper) PatchEstimatesEstimateId(w http.ResponseWriter, r *http.Request) {
+	// ------------- Optional query parameter "error" -------------
 
-	var err error
+	err = runtime.BindQueryParameter("form", true, false, "error", r.URL.Query(), &params.Error)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "error", Err: err})
+		return
+	}
 
-	// ------------- Path parameter "estimateId" -------------
-	var estimateId openapi_types.UUID
+	// ------------- Optional query parameter "error_description" -------------
 
-	err = runtime.BindStyledParameterWithOptions("simple", "estimateId", chi.URLParam(r, "estimateId"), &estimateId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindQueryParameter("form", true, false, "error_description", r.URL.Query(), &params.ErrorDescription)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "estimateId", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "error_description", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PatchEstimatesEstimateId(w, r, estimateId)
+		siw.Handler.GetAuthOidcCallback(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -551,50 +1149,22 @@ func (siw *ServerInterfaceWrapper) PatchEstimatesEstimateId(w http.ResponseWrite
 	handler.ServeHTTP(w, r)
 }
 
-// PostEstimatesEstimateIdConvert operation middleware
-func (siw *ServerInterfaceWrapper) PostEstimatesEstimateIdConvert(w http.ResponseWriter, r *http.Request) {
+// GetAuthOidcTenantSlugStart operation middleware
+func (siw *ServerInterfaceWrapper) GetAuthOidcTenantSlugStart(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "estimateId" -------------
-	var estimateId openapi_types.UUID
+	// ------------- Path parameter "tenantSlug" -------------
+	var tenantSlug string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "estimateId", chi.URLParam(r, "estimateId"), &estimateId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "tenantSlug", chi.URLParam(r, "tenantSlug"), &tenantSlug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "estimateId", Err: err})
-		return
-	}
-
-	// Parameter object where we will unmarshal all parameters from the context
-	var params PostEstimatesEstimateIdConvertParams
-
-	headers := r.Header
-
-	// ------------- Required header parameter "Idempotency-Key" -------------
-	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
-		var IdempotencyKey IdempotencyKey
-		n := len(valueList)
-		if n != 1 {
-			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
-			return
-		}
-
-		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
-		if err != nil {
-			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
-			return
-		}
-
-		params.IdempotencyKey = IdempotencyKey
-
-	} else {
-		err := fmt.Errorf("Header parameter Idempotency-Key is required, but not found")
-		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "Idempotency-Key", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tenantSlug", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostEstimatesEstimateIdConvert(w, r, estimateId, params)
+		siw.Handler.GetAuthOidcTenantSlugStart(w, r, tenantSlug)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -604,11 +1174,11 @@ func (siw *ServerInterfaceWrapper) PostEstimatesEstimateIdConvert(w http.Respons
 	handler.ServeHTTP(w, r)
 }
 
-// GetExportsCustomersCsv operation middleware
-func (siw *ServerInterfaceWrapper) GetExportsCustomersCsv(w http.ResponseWriter, r *http.Request) {
+// PostAuthPassword operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthPassword(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetExportsCustomersCsv(w, r)
+		siw.Handler.PostAuthPassword(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -618,11 +1188,11 @@ func (siw *ServerInterfaceWrapper) GetExportsCustomersCsv(w http.ResponseWriter,
 	handler.ServeHTTP(w, r)
 }
 
-// GetExportsEstimatesCsv operation middleware
-func (siw *ServerInterfaceWrapper) GetExportsEstimatesCsv(w http.ResponseWriter, r *http.Request) {
+// PostAuthPasswordReset operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthPasswordReset(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetExportsEstimatesCsv(w, r)
+		siw.Handler.PostAuthPasswordReset(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -632,11 +1202,11 @@ func (siw *ServerInterfaceWrapper) GetExportsEstimatesCsv(w http.ResponseWriter,
 	handler.ServeHTTP(w, r)
 }
 
-// GetExportsJobsCsv operation middleware
-func (siw *ServerInterfaceWrapper) GetExportsJobsCsv(w http.ResponseWriter, r *http.Request) {
+// GetAuthSessions operation middleware
+func (siw *ServerInterfaceWrapper) GetAuthSessions(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetExportsJobsCsv(w, r)
+		siw.Handler.GetAuthSessions(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -646,25 +1216,22 @@ func (siw *ServerInterfaceWrapper) GetExportsJobsCsv(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r)
 }
 
-// GetExportsStorageCsv operation middleware
-func (siw *ServerInterfaceWrapper) GetExportsStorageCsv(w http.ResponseWriter, r *http.Request) {
-
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetExportsStorageCsv(w, r)
-	}))
+// DeleteAuthSessionsSessionId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteAuthSessionsSessionId(w http.ResponseWriter, r *http.Request) {
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+	var err error
 
-	handler.ServeHTTP(w, r)
-}
+	// ------------- Path parameter "sessionId" -------------
+	var sessionId openapi_types.UUID
 
-// GetHealth operation middleware
-func (siw *ServerInterfaceWrapper) GetHealth(w http.ResponseWriter, r *http.Request) {
+	err = runtime.BindStyledParameterWithOptions("simple", "sessionId", chi.URLParam(r, "sessionId"), &sessionId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sessionId", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetHealth(w, r)
+		siw.Handler.DeleteAuthSessionsSessionId(w, r, sessionId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -674,11 +1241,11 @@ func (siw *ServerInterfaceWrapper) GetHealth(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r)
 }
 
-// PostImportsApply operation middleware
-func (siw *ServerInterfaceWrapper) PostImportsApply(w http.ResponseWriter, r *http.Request) {
+// PostAuthSwitchTenant operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthSwitchTenant(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostImportsApply(w, r)
+		siw.Handler.PostAuthSwitchTenant(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -688,11 +1255,11 @@ func (siw *ServerInterfaceWrapper) PostImportsApply(w http.ResponseWriter, r *ht
 	handler.ServeHTTP(w, r)
 }
 
-// PostImportsDryRun operation middleware
-func (siw *ServerInterfaceWrapper) PostImportsDryRun(w http.ResponseWriter, r *http.Request) {
+// GetAuthTenants operation middleware
+func (siw *ServerInterfaceWrapper) GetAuthTenants(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostImportsDryRun(w, r)
+		siw.Handler.GetAuthTenants(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -702,47 +1269,1803 @@ func (siw *ServerInterfaceWrapper) PostImportsDryRun(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r)
 }
 
-// GetImportsTemplatesTemplateCsv operation middleware
-func (siw *ServerInterfaceWrapper) GetImportsTemplatesTemplateCsv(w http.ResponseWriter, r *http.Request) {
+// GetCalendar operation middleware
+func (siw *ServerInterfaceWrapper) GetCalendar(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "template" -------------
-	var template ImportTemplate
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCalendarParams
 
-	err = runtime.BindStyledParameterWithOptions("simple", "template", chi.URLParam(r, "template"), &template, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "template", Err: err})
-		return
-	}
+	// ------------- Required query parameter "from" -------------
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetImportsTemplatesTemplateCsv(w, r, template)
-	}))
+	if paramValue := r.URL.Query().Get("from"); paramValue != "" {
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "from"})
+		return
 	}
 
-	handler.ServeHTTP(w, r)
-}
-
-// GetImportsImportRunId operation middleware
-func (siw *ServerInterfaceWrapper) GetImportsImportRunId(w http.ResponseWriter, r *http.Request) {
-
-	var err error
-
-	// ------------- Path parameter "importRunId" -------------
-	var importRunId openapi_types.UUID
-
-	err = runtime.BindStyledParameterWithOptions("simple", "importRunId", chi.URLParam(r, "importRunId"), &importRunId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindQueryParameter("form", true, true, "from", r.URL.Query(), &params.From)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "importRunId", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
 		return
 	}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetImportsImportRunId(w, r, importRunId)
+	// ------------- Required query parameter "to" -------------
+
+	if paramValue := r.URL.Query().Get("to"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "to"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "phase" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "phase", r.URL.Query(), &params.Phase)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "phase", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "jobType" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "jobType", r.URL.Query(), &params.JobType)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "jobType", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "userId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "userId", r.URL.Query(), &params.UserId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "departmentId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "departmentId", r.URL.Query(), &params.DepartmentId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "departmentId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCalendar(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetCustomers operation middleware
+func (siw *ServerInterfaceWrapper) GetCustomers(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCustomersParams
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCustomers(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostCustomers operation middleware
+func (siw *ServerInterfaceWrapper) PostCustomers(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostCustomers(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetCustomersCustomerId operation middleware
+func (siw *ServerInterfaceWrapper) GetCustomersCustomerId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "customerId" -------------
+	var customerId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "customerId", chi.URLParam(r, "customerId"), &customerId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "customerId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCustomersCustomerId(w, r, customerId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PatchCustomersCustomerId operation middleware
+func (siw *ServerInterfaceWrapper) PatchCustomersCustomerId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "customerId" -------------
+	var customerId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "customerId", chi.URLParam(r, "customerId"), &customerId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "customerId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchCustomersCustomerId(w, r, customerId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostCustomersCustomerIdMerge operation middleware
+func (siw *ServerInterfaceWrapper) PostCustomersCustomerIdMerge(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "customerId" -------------
+	var customerId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "customerId", chi.URLParam(r, "customerId"), &customerId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "customerId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostCustomersCustomerIdMerge(w, r, customerId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetEstimates operation middleware
+func (siw *ServerInterfaceWrapper) GetEstimates(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetEstimatesParams
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "leadSource" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "leadSource", r.URL.Query(), &params.LeadSource)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "leadSource", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "moveDateFrom" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "moveDateFrom", r.URL.Query(), &params.MoveDateFrom)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "moveDateFrom", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "moveDateTo" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "moveDateTo", r.URL.Query(), &params.MoveDateTo)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "moveDateTo", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "createdFrom" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "createdFrom", r.URL.Query(), &params.CreatedFrom)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "createdFrom", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "createdTo" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "createdTo", r.URL.Query(), &params.CreatedTo)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "createdTo", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "createdBy" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "createdBy", r.URL.Query(), &params.CreatedBy)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "createdBy", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetEstimates(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostEstimates operation middleware
+func (siw *ServerInterfaceWrapper) PostEstimates(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostEstimatesParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = IdempotencyKey
+
+	} else {
+		err := fmt.Errorf("Header parameter Idempotency-Key is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "Idempotency-Key", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostEstimates(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetEstimatesEstimateId operation middleware
+func (siw *ServerInterfaceWrapper) GetEstimatesEstimateId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "estimateId" -------------
+	var estimateId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "estimateId", chi.URLParam(r, "estimateId"), &estimateId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "estimateId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetEstimatesEstimateId(w, r, estimateId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PatchEstimatesEstimateId operation middleware
+func (siw *ServerInterfaceWrapper) PatchEstimatesEstimateId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "estimateId" -------------
+	var estimateId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "estimateId", chi.URLParam(r, "estimateId"), &estimateId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "estimateId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchEstimatesEstimateId(w, r, estimateId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostEstimatesEstimateIdAcceptanceLinks operation middleware
+func (siw *ServerInterfaceWrapper) PostEstimatesEstimateIdAcceptanceLinks(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "estimateId" -------------
+	var estimateId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "estimateId", chi.URLParam(r, "estimateId"), &estimateId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "estimateId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostEstimatesEstimateIdAcceptanceLinks(w, r, estimateId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostEstimatesEstimateIdConvert operation middleware
+func (siw *ServerInterfaceWrapper) PostEstimatesEstimateIdConvert(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "estimateId" -------------
+	var estimateId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "estimateId", chi.URLParam(r, "estimateId"), &estimateId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "estimateId", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostEstimatesEstimateIdConvertParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = IdempotencyKey
+
+	} else {
+		err := fmt.Errorf("Header parameter Idempotency-Key is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "Idempotency-Key", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostEstimatesEstimateIdConvert(w, r, estimateId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetEstimatesEstimateIdDocumentPdf operation middleware
+func (siw *ServerInterfaceWrapper) GetEstimatesEstimateIdDocumentPdf(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "estimateId" -------------
+	var estimateId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "estimateId", chi.URLParam(r, "estimateId"), &estimateId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "estimateId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetEstimatesEstimateIdDocumentPdf(w, r, estimateId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetEstimatesEstimateIdEmails operation middleware
+func (siw *ServerInterfaceWrapper) GetEstimatesEstimateIdEmails(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "estimateId" -------------
+	var estimateId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "estimateId", chi.URLParam(r, "estimateId"), &estimateId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "estimateId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetEstimatesEstimateIdEmails(w, r, estimateId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostEstimatesEstimateIdEmails operation middleware
+func (siw *ServerInterfaceWrapper) PostEstimatesEstimateIdEmails(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "estimateId" -------------
+	var estimateId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "estimateId", chi.URLParam(r, "estimateId"), &estimateId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "estimateId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostEstimatesEstimateIdEmails(w, r, estimateId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetEstimatesEstimateIdLineItems operation middleware
+func (siw *ServerInterfaceWrapper) GetEstimatesEstimateIdLineItems(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "estimateId" -------------
+	var estimateId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "estimateId", chi.URLParam(r, "estimateId"), &estimateId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "estimateId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetEstimatesEstimateIdLineItems(w, r, estimateId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostEstimatesEstimateIdLineItems operation middleware
+func (siw *ServerInterfaceWrapper) PostEstimatesEstimateIdLineItems(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "estimateId" -------------
+	var estimateId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "estimateId", chi.URLParam(r, "estimateId"), &estimateId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "estimateId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostEstimatesEstimateIdLineItems(w, r, estimateId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteEstimatesEstimateIdLineItemsLineItemId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteEstimatesEstimateIdLineItemsLineItemId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "estimateId" -------------
+	var estimateId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "estimateId", chi.URLParam(r, "estimateId"), &estimateId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "estimateId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "lineItemId" -------------
+	var lineItemId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "lineItemId", chi.URLParam(r, "lineItemId"), &lineItemId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "lineItemId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteEstimatesEstimateIdLineItemsLineItemId(w, r, estimateId, lineItemId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PatchEstimatesEstimateIdLineItemsLineItemId operation middleware
+func (siw *ServerInterfaceWrapper) PatchEstimatesEstimateIdLineItemsLineItemId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "estimateId" -------------
+	var estimateId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "estimateId", chi.URLParam(r, "estimateId"), &estimateId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "estimateId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "lineItemId" -------------
+	var lineItemId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "lineItemId", chi.URLParam(r, "lineItemId"), &lineItemId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "lineItemId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchEstimatesEstimateIdLineItemsLineItemId(w, r, estimateId, lineItemId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostEstimatesEstimateIdPrice operation middleware
+func (siw *ServerInterfaceWrapper) PostEstimatesEstimateIdPrice(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "estimateId" -------------
+	var estimateId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "estimateId", chi.URLParam(r, "estimateId"), &estimateId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "estimateId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostEstimatesEstimateIdPrice(w, r, estimateId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetEstimatesEstimateIdRevisions operation middleware
+func (siw *ServerInterfaceWrapper) GetEstimatesEstimateIdRevisions(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "estimateId" -------------
+	var estimateId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "estimateId", chi.URLParam(r, "estimateId"), &estimateId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "estimateId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetEstimatesEstimateIdRevisions(w, r, estimateId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetEstimatesEstimateIdRevisionsRevisionNumber operation middleware
+func (siw *ServerInterfaceWrapper) GetEstimatesEstimateIdRevisionsRevisionNumber(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "estimateId" -------------
+	var estimateId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "estimateId", chi.URLParam(r, "estimateId"), &estimateId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "estimateId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "revisionNumber" -------------
+	var revisionNumber int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "revisionNumber", chi.URLParam(r, "revisionNumber"), &revisionNumber, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "revisionNumber", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetEstimatesEstimateIdRevisionsRevisionNumber(w, r, estimateId, revisionNumber)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetEstimatesEstimateIdRevisionsRevisionNumberDiff operation middleware
+func (siw *ServerInterfaceWrapper) GetEstimatesEstimateIdRevisionsRevisionNumberDiff(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "estimateId" -------------
+	var estimateId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "estimateId", chi.URLParam(r, "estimateId"), &estimateId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "estimateId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "revisionNumber" -------------
+	var revisionNumber int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "revisionNumber", chi.URLParam(r, "revisionNumber"), &revisionNumber, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "revisionNumber", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetEstimatesEstimateIdRevisionsRevisionNumberDiffParams
+
+	// ------------- Optional query parameter "against" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "against", r.URL.Query(), &params.Against)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "against", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetEstimatesEstimateIdRevisionsRevisionNumberDiff(w, r, estimateId, revisionNumber, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostEstimatesEstimateIdRevisionsRevisionNumberRestore operation middleware
+func (siw *ServerInterfaceWrapper) PostEstimatesEstimateIdRevisionsRevisionNumberRestore(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "estimateId" -------------
+	var estimateId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "estimateId", chi.URLParam(r, "estimateId"), &estimateId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "estimateId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "revisionNumber" -------------
+	var revisionNumber int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "revisionNumber", chi.URLParam(r, "revisionNumber"), &revisionNumber, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "revisionNumber", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostEstimatesEstimateIdRevisionsRevisionNumberRestore(w, r, estimateId, revisionNumber)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetEstimatesEstimateIdSignatures operation middleware
+func (siw *ServerInterfaceWrapper) GetEstimatesEstimateIdSignatures(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "estimateId" -------------
+	var estimateId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "estimateId", chi.URLParam(r, "estimateId"), &estimateId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "estimateId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetEstimatesEstimateIdSignatures(w, r, estimateId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetExportsCustomersCsv operation middleware
+func (siw *ServerInterfaceWrapper) GetExportsCustomersCsv(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetExportsCustomersCsv(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetExportsEstimatesCsv operation middleware
+func (siw *ServerInterfaceWrapper) GetExportsEstimatesCsv(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetExportsEstimatesCsv(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetExportsJobsCsv operation middleware
+func (siw *ServerInterfaceWrapper) GetExportsJobsCsv(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetExportsJobsCsv(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetExportsStorageCsv operation middleware
+func (siw *ServerInterfaceWrapper) GetExportsStorageCsv(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetExportsStorageCsv(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetHealth operation middleware
+func (siw *ServerInterfaceWrapper) GetHealth(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetHealth(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostImportsApply operation middleware
+func (siw *ServerInterfaceWrapper) PostImportsApply(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostImportsApply(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostImportsDryRun operation middleware
+func (siw *ServerInterfaceWrapper) PostImportsDryRun(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostImportsDryRun(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetImportsTemplatesTemplateCsv operation middleware
+func (siw *ServerInterfaceWrapper) GetImportsTemplatesTemplateCsv(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "template" -------------
+	var template ImportTemplate
+
+	err = runtime.BindStyledParameterWithOptions("simple", "template", chi.URLParam(r, "template"), &template, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "template", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetImportsTemplatesTemplateCsv(w, r, template)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetImportsImportRunId operation middleware
+func (siw *ServerInterfaceWrapper) GetImportsImportRunId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "importRunId" -------------
+	var importRunId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "importRunId", chi.URLParam(r, "importRunId"), &importRunId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "importRunId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetImportsImportRunId(w, r, importRunId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetImportsImportRunIdErrorsCsv operation middleware
+func (siw *ServerInterfaceWrapper) GetImportsImportRunIdErrorsCsv(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "importRunId" -------------
+	var importRunId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "importRunId", chi.URLParam(r, "importRunId"), &importRunId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "importRunId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetImportsImportRunIdErrorsCsv(w, r, importRunId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetImportsImportRunIdReportJson operation middleware
+func (siw *ServerInterfaceWrapper) GetImportsImportRunIdReportJson(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "importRunId" -------------
+	var importRunId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "importRunId", chi.URLParam(r, "importRunId"), &importRunId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "importRunId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetImportsImportRunIdReportJson(w, r, importRunId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetInventoryItems operation middleware
+func (siw *ServerInterfaceWrapper) GetInventoryItems(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetInventoryItemsParams
+
+	// ------------- Optional query parameter "includeInactive" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "includeInactive", r.URL.Query(), &params.IncludeInactive)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "includeInactive", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetInventoryItems(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostInventoryItems operation middleware
+func (siw *ServerInterfaceWrapper) PostInventoryItems(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostInventoryItems(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteInventoryItemsItemId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteInventoryItemsItemId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "itemId" -------------
+	var itemId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "itemId", chi.URLParam(r, "itemId"), &itemId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "itemId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteInventoryItemsItemId(w, r, itemId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PatchInventoryItemsItemId operation middleware
+func (siw *ServerInterfaceWrapper) PatchInventoryItemsItemId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "itemId" -------------
+	var itemId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "itemId", chi.URLParam(r, "itemId"), &itemId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "itemId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchInventoryItemsItemId(w, r, itemId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetJobs operation middleware
+func (siw *ServerInterfaceWrapper) GetJobs(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetJobsParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "scheduled" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "scheduled", r.URL.Query(), &params.Scheduled)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "scheduled", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "customerId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "customerId", r.URL.Query(), &params.CustomerId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "customerId", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "source" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "source", r.URL.Query(), &params.Source)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "source", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "balanceDue" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "balanceDue", r.URL.Query(), &params.BalanceDue)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "balanceDue", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetJobs(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetJobsJobId operation middleware
+func (siw *ServerInterfaceWrapper) GetJobsJobId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "jobId" -------------
+	var jobId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "jobId", chi.URLParam(r, "jobId"), &jobId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "jobId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetJobsJobId(w, r, jobId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PatchJobsJobId operation middleware
+func (siw *ServerInterfaceWrapper) PatchJobsJobId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "jobId" -------------
+	var jobId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "jobId", chi.URLParam(r, "jobId"), &jobId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "jobId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchJobsJobId(w, r, jobId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetJobsJobIdEmails operation middleware
+func (siw *ServerInterfaceWrapper) GetJobsJobIdEmails(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "jobId" -------------
+	var jobId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "jobId", chi.URLParam(r, "jobId"), &jobId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "jobId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetJobsJobIdEmails(w, r, jobId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostJobsJobIdEmails operation middleware
+func (siw *ServerInterfaceWrapper) PostJobsJobIdEmails(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "jobId" -------------
+	var jobId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "jobId", chi.URLParam(r, "jobId"), &jobId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "jobId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostJobsJobIdEmails(w, r, jobId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetJobsJobIdLedger operation middleware
+func (siw *ServerInterfaceWrapper) GetJobsJobIdLedger(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "jobId" -------------
+	var jobId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "jobId", chi.URLParam(r, "jobId"), &jobId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "jobId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetJobsJobIdLedger(w, r, jobId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostJobsJobIdLedger operation middleware
+func (siw *ServerInterfaceWrapper) PostJobsJobIdLedger(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "jobId" -------------
+	var jobId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "jobId", chi.URLParam(r, "jobId"), &jobId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "jobId", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostJobsJobIdLedgerParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = IdempotencyKey
+
+	} else {
+		err := fmt.Errorf("Header parameter Idempotency-Key is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "Idempotency-Key", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostJobsJobIdLedger(w, r, jobId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostJobsJobIdStorage operation middleware
+func (siw *ServerInterfaceWrapper) PostJobsJobIdStorage(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "jobId" -------------
+	var jobId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "jobId", chi.URLParam(r, "jobId"), &jobId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "jobId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostJobsJobIdStorage(w, r, jobId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPermissions operation middleware
+func (siw *ServerInterfaceWrapper) GetPermissions(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPermissions(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPublicEstimateAcceptanceToken operation middleware
+func (siw *ServerInterfaceWrapper) GetPublicEstimateAcceptanceToken(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "token" -------------
+	var token AcceptanceToken
+
+	err = runtime.BindStyledParameterWithOptions("simple", "token", chi.URLParam(r, "token"), &token, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "token", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPublicEstimateAcceptanceToken(w, r, token)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostPublicEstimateAcceptanceTokenAccept operation middleware
+func (siw *ServerInterfaceWrapper) PostPublicEstimateAcceptanceTokenAccept(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "token" -------------
+	var token AcceptanceToken
+
+	err = runtime.BindStyledParameterWithOptions("simple", "token", chi.URLParam(r, "token"), &token, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "token", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostPublicEstimateAcceptanceTokenAccept(w, r, token)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPublicEstimateAcceptanceTokenDocumentPdf operation middleware
+func (siw *ServerInterfaceWrapper) GetPublicEstimateAcceptanceTokenDocumentPdf(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "token" -------------
+	var token AcceptanceToken
+
+	err = runtime.BindStyledParameterWithOptions("simple", "token", chi.URLParam(r, "token"), &token, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "token", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPublicEstimateAcceptanceTokenDocumentPdf(w, r, token)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetRoles operation middleware
+func (siw *ServerInterfaceWrapper) GetRoles(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRoles(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostRoles operation middleware
+func (siw *ServerInterfaceWrapper) PostRoles(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostRoles(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteRolesRoleId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteRolesRoleId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "roleId" -------------
+	var roleId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "roleId", chi.URLParam(r, "roleId"), &roleId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "roleId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteRolesRoleId(w, r, roleId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetRolesRoleId operation middleware
+func (siw *ServerInterfaceWrapper) GetRolesRoleId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "roleId" -------------
+	var roleId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "roleId", chi.URLParam(r, "roleId"), &roleId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "roleId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRolesRoleId(w, r, roleId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PatchRolesRoleId operation middleware
+func (siw *ServerInterfaceWrapper) PatchRolesRoleId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "roleId" -------------
+	var roleId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "roleId", chi.URLParam(r, "roleId"), &roleId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "roleId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchRolesRoleId(w, r, roleId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetSearch operation middleware
+func (siw *ServerInterfaceWrapper) GetSearch(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSearchParams
+
+	// ------------- Required query parameter "q" -------------
+
+	if paramValue := r.URL.Query().Get("q"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "q"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSearch(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetSettings operation middleware
+func (siw *ServerInterfaceWrapper) GetSettings(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSettings(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PatchSettings operation middleware
+func (siw *ServerInterfaceWrapper) PatchSettings(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchSettings(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetSettingsEmail operation middleware
+func (siw *ServerInterfaceWrapper) GetSettingsEmail(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSettingsEmail(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutSettingsEmail operation middleware
+func (siw *ServerInterfaceWrapper) PutSettingsEmail(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutSettingsEmail(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetSettingsEstimateDocument operation middleware
+func (siw *ServerInterfaceWrapper) GetSettingsEstimateDocument(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSettingsEstimateDocument(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutSettingsEstimateDocument operation middleware
+func (siw *ServerInterfaceWrapper) PutSettingsEstimateDocument(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutSettingsEstimateDocument(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetSettingsSso operation middleware
+func (siw *ServerInterfaceWrapper) GetSettingsSso(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSettingsSso(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutSettingsSso operation middleware
+func (siw *ServerInterfaceWrapper) PutSettingsSso(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutSettingsSso(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetSettingsTariff operation middleware
+func (siw *ServerInterfaceWrapper) GetSettingsTariff(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSettingsTariff(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutSettingsTariff operation middleware
+func (siw *ServerInterfaceWrapper) PutSettingsTariff(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutSettingsTariff(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -752,22 +3075,95 @@ func (siw *ServerInterfaceWrapper) GetImportsImportRunId(w http.ResponseWriter,
 	handler.ServeHTTP(w, r)
 }
 
-// GetImportsImportRunIdErrorsCsv operation middleware
-func (siw *ServerInterfaceWrapper) GetImportsImportRunIdErrorsCsv(w http.ResponseWriter, r *http.Request) {
+// GetStorage operation middleware
+func (siw *ServerInterfaceWrapper) GetStorage(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "importRunId" -------------
-	var importRunId openapi_types.UUID
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetStorageParams
 
-	err = runtime.BindStyledParameterWithOptions("simple", "importRunId", chi.URLParam(r, "importRunId"), &importRunId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	// ------------- Required query parameter "facility" -------------
+
+	if paramValue := r.URL.Query().Get("facility"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "facility"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "facility", r.URL.Query(), &params.Facility)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "importRunId", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "facility", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "hasDateOut" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "hasDateOut", r.URL.Query(), &params.HasDateOut)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "hasDateOut", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "balanceDue" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "balanceDue", r.URL.Query(), &params.BalanceDue)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "balanceDue", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "pastDueDays" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "pastDueDays", r.URL.Query(), &params.PastDueDays)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pastDueDays", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "hasContainers" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "hasContainers", r.URL.Query(), &params.HasContainers)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "hasContainers", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetImportsImportRunIdErrorsCsv(w, r, importRunId)
+		siw.Handler.GetStorage(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -777,22 +3173,22 @@ func (siw *ServerInterfaceWrapper) GetImportsImportRunIdErrorsCsv(w http.Respons
 	handler.ServeHTTP(w, r)
 }
 
-// GetImportsImportRunIdReportJson operation middleware
-func (siw *ServerInterfaceWrapper) GetImportsImportRunIdReportJson(w http.ResponseWriter, r *http.Request) {
+// GetStorageStorageRecordId operation middleware
+func (siw *ServerInterfaceWrapper) GetStorageStorageRecordId(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "importRunId" -------------
-	var importRunId openapi_types.UUID
+	// ------------- Path parameter "storageRecordId" -------------
+	var storageRecordId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "importRunId", chi.URLParam(r, "importRunId"), &importRunId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "storageRecordId", chi.URLParam(r, "storageRecordId"), &storageRecordId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "importRunId", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "storageRecordId", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetImportsImportRunIdReportJson(w, r, importRunId)
+		siw.Handler.GetStorageStorageRecordId(w, r, storageRecordId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -802,22 +3198,178 @@ func (siw *ServerInterfaceWrapper) GetImportsImportRunIdReportJson(w http.Respon
 	handler.ServeHTTP(w, r)
 }
 
-// GetJobsJobId operation middleware
-func (siw *ServerInterfaceWrapper) GetJobsJobId(w http.ResponseWriter, r *http.Request) {
+// PutStorageStorageRecordId operation middleware
+func (siw *ServerInterfaceWrapper) PutStorageStorageRecordId(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "jobId" -------------
-	var jobId openapi_types.UUID
+	// ------------- Path parameter "storageRecordId" -------------
+	var storageRecordId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "jobId", chi.URLParam(r, "jobId"), &jobId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "storageRecordId", chi.URLParam(r, "storageRecordId"), &storageRecordId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "jobId", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "storageRecordId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutStorageStorageRecordId(w, r, storageRecordId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetStorageStorageRecordIdEmails operation middleware
+func (siw *ServerInterfaceWrapper) GetStorageStorageRecordIdEmails(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "storageRecordId" -------------
+	var storageRecordId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "storageRecordId", chi.URLParam(r, "storageRecordId"), &storageRecordId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "storageRecordId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetStorageStorageRecordIdEmails(w, r, storageRecordId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostStorageStorageRecordIdEmails operation middleware
+func (siw *ServerInterfaceWrapper) PostStorageStorageRecordIdEmails(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "storageRecordId" -------------
+	var storageRecordId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "storageRecordId", chi.URLParam(r, "storageRecordId"), &storageRecordId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "storageRecordId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostStorageStorageRecordIdEmails(w, r, storageRecordId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetStorageStorageRecordIdLedger operation middleware
+func (siw *ServerInterfaceWrapper) GetStorageStorageRecordIdLedger(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "storageRecordId" -------------
+	var storageRecordId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "storageRecordId", chi.URLParam(r, "storageRecordId"), &storageRecordId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "storageRecordId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetStorageStorageRecordIdLedger(w, r, storageRecordId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostStorageStorageRecordIdLedger operation middleware
+func (siw *ServerInterfaceWrapper) PostStorageStorageRecordIdLedger(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "storageRecordId" -------------
+	var storageRecordId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "storageRecordId", chi.URLParam(r, "storageRecordId"), &storageRecordId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "storageRecordId", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostStorageStorageRecordIdLedgerParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = IdempotencyKey
+
+	} else {
+		err := fmt.Errorf("Header parameter Idempotency-Key is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "Idempotency-Key", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetJobsJobId(w, r, jobId)
+		siw.Handler.PostStorageStorageRecordIdLedger(w, r, storageRecordId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetUsers operation middleware
+func (siw *ServerInterfaceWrapper) GetUsers(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUsers(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostUsers operation middleware
+func (siw *ServerInterfaceWrapper) PostUsers(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostUsers(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -827,22 +3379,22 @@ func (siw *ServerInterfaceWrapper) GetJobsJobId(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
-// PatchJobsJobId operation middleware
-func (siw *ServerInterfaceWrapper) PatchJobsJobId(w http.ResponseWriter, r *http.Request) {
+// GetUsersUserId operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersUserId(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "jobId" -------------
-	var jobId openapi_types.UUID
+	// ------------- Path parameter "userId" -------------
+	var userId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "jobId", chi.URLParam(r, "jobId"), &jobId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", chi.URLParam(r, "userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "jobId", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PatchJobsJobId(w, r, jobId)
+		siw.Handler.GetUsersUserId(w, r, userId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -852,22 +3404,22 @@ func (siw *ServerInterfaceWrapper) PatchJobsJobId(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r)
 }
 
-// PostJobsJobIdStorage operation middleware
-func (siw *ServerInterfaceWrapper) PostJobsJobIdStorage(w http.ResponseWriter, r *http.Request) {
+// PatchUsersUserId operation middleware
+func (siw *ServerInterfaceWrapper) PatchUsersUserId(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "jobId" -------------
-	var jobId openapi_types.UUID
+	// ------------- Path parameter "userId" -------------
+	var userId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "jobId", chi.URLParam(r, "jobId"), &jobId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", chi.URLParam(r, "userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "jobId", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostJobsJobIdStorage(w, r, jobId)
+		siw.Handler.PatchUsersUserId(w, r, userId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -877,95 +3429,72 @@ func (siw *ServerInterfaceWrapper) PostJobsJobIdStorage(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r)
 }
 
-// GetStorage operation middleware
-func (siw *ServerInterfaceWrapper) GetStorage(w http.ResponseWriter, r *http.Request) {
+// PostUsersUserIdPasswordReset operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersUserIdPasswordReset(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetStorageParams
-
-	// ------------- Required query parameter "facility" -------------
-
-	if paramValue := r.URL.Query().Get("facility"); paramValue != "" {
-
-	} else {
-		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "facility"})
-		return
-	}
+	// ------------- Path parameter "userId" -------------
+	var userId openapi_types.UUID
 
-	err = runtime.BindQueryParameter("form", true, true, "facility", r.URL.Query(), &params.Facility)
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", chi.URLParam(r, "userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "facility", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "q" -------------
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostUsersUserIdPasswordReset(w, r, userId)
+	}))
 
-	err = runtime.BindQueryParameter("form", true, false, "q", r.URL.Query(), &params.Q)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
-	// ------------- Optional query parameter "status" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
-		return
-	}
+	handler.ServeHTTP(w, r)
+}
 
-	// ------------- Optional query parameter "hasDateOut" -------------
+// PostUsersUserIdPasswordResetToken operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersUserIdPasswordResetToken(w http.ResponseWriter, r *http.Request) {
 
-	err = runtime.BindQueryParameter("form", true, false, "hasDateOut", r.URL.Query(), &params.HasDateOut)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "hasDateOut", Err: err})
-		return
-	}
+	var err error
 
-	// ------------- Optional query parameter "balanceDue" -------------
+	// ------------- Path parameter "userId" -------------
+	var userId openapi_types.UUID
 
-	err = runtime.BindQueryParameter("form", true, false, "balanceDue", r.URL.Query(), &params.BalanceDue)
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", chi.URLParam(r, "userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "balanceDue", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "pastDueDays" -------------
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostUsersUserIdPasswordResetToken(w, r, userId)
+	}))
 
-	err = runtime.BindQueryParameter("form", true, false, "pastDueDays", r.URL.Query(), &params.PastDueDays)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pastDueDays", Err: err})
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
-	// ------------- Optional query parameter "hasContainers" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "hasContainers", r.URL.Query(), &params.HasContainers)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "hasContainers", Err: err})
-		return
-	}
+	handler.ServeHTTP(w, r)
+}
 
-	// ------------- Optional query parameter "limit" -------------
+// PutUsersUserIdRoles operation middleware
+func (siw *ServerInterfaceWrapper) PutUsersUserIdRoles(w http.ResponseWriter, r *http.Request) {
 
-	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
-		return
-	}
+	var err error
 
-	// ------------- Optional query parameter "cursor" -------------
+	// ------------- Path parameter "userId" -------------
+	var userId openapi_types.UUID
 
-	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", chi.URLParam(r, "userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetStorage(w, r, params)
+		siw.Handler.PutUsersUserIdRoles(w, r, userId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -975,22 +3504,22 @@ func (siw *ServerInterfaceWrapper) GetStorage(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// GetStorageStorageRecordId operation middleware
-func (siw *ServerInterfaceWrapper) GetStorageStorageRecordId(w http.ResponseWriter, r *http.Request) {
+// DeleteUsersUserIdSessions operation middleware
+func (siw *ServerInterfaceWrapper) DeleteUsersUserIdSessions(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "storageRecordId" -------------
-	var storageRecordId openapi_types.UUID
+	// ------------- Path parameter "userId" -------------
+	var userId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "storageRecordId", chi.URLParam(r, "storageRecordId"), &storageRecordId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", chi.URLParam(r, "userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "storageRecordId", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetStorageStorageRecordId(w, r, storageRecordId)
+		siw.Handler.DeleteUsersUserIdSessions(w, r, userId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1000,22 +3529,22 @@ func (siw *ServerInterfaceWrapper) GetStorageStorageRecordId(w http.ResponseWrit
 	handler.ServeHTTP(w, r)
 }
 
-// PutStorageStorageRecordId operation middleware
-func (siw *ServerInterfaceWrapper) PutStorageStorageRecordId(w http.ResponseWriter, r *http.Request) {
+// PostUsersUserIdUnlock operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersUserIdUnlock(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "storageRecordId" -------------
-	var storageRecordId openapi_types.UUID
+	// ------------- Path parameter "userId" -------------
+	var userId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "storageRecordId", chi.URLParam(r, "storageRecordId"), &storageRecordId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", chi.URLParam(r, "userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "storageRecordId", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PutStorageStorageRecordId(w, r, storageRecordId)
+		siw.Handler.PostUsersUserIdUnlock(w, r, userId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1138,6 +3667,15 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/auth/api-tokens", wrapper.GetAuthApiTokens)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/api-tokens", wrapper.PostAuthApiTokens)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/auth/api-tokens/{tokenId}", wrapper.DeleteAuthApiTokensTokenId)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/auth/csrf", wrapper.GetAuthCsrf)
 	})
@@ -1150,15 +3688,60 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/auth/me", wrapper.GetAuthMe)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/mfa/totp/confirm", wrapper.PostAuthMfaTotpConfirm)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/mfa/totp/enroll", wrapper.PostAuthMfaTotpEnroll)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/mfa/verify", wrapper.PostAuthMfaVerify)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/auth/oidc/callback", wrapper.GetAuthOidcCallback)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/auth/oidc/{tenantSlug}/start", wrapper.GetAuthOidcTenantSlugStart)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/password", wrapper.PostAuthPassword)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/password-reset", wrapper.PostAuthPasswordReset)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/auth/sessions", wrapper.GetAuthSessions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/auth/sessions/{sessionId}", wrapper.DeleteAuthSessionsSessionId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/switch-tenant", wrapper.PostAuthSwitchTenant)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/auth/tenants", wrapper.GetAuthTenants)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/calendar", wrapper.GetCalendar)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/customers", wrapper.GetCustomers)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/customers", wrapper.PostCustomers)
 	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/customers/{customerId}", wrapper.GetCustomersCustomerId)
 	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/customers/{customerId}", wrapper.PatchCustomersCustomerId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/customers/{customerId}/merge", wrapper.PostCustomersCustomerIdMerge)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/estimates", wrapper.GetEstimates)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/estimates", wrapper.PostEstimates)
 	})
@@ -1168,9 +3751,51 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Patch(options.BaseURL+"/estimates/{estimateId}", wrapper.PatchEstimatesEstimateId)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/estimates/{estimateId}/acceptance-links", wrapper.PostEstimatesEstimateIdAcceptanceLinks)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/estimates/{estimateId}/convert", wrapper.PostEstimatesEstimateIdConvert)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/estimates/{estimateId}/document.pdf", wrapper.GetEstimatesEstimateIdDocumentPdf)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/estimates/{estimateId}/emails", wrapper.GetEstimatesEstimateIdEmails)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/estimates/{estimateId}/emails", wrapper.PostEstimatesEstimateIdEmails)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/estimates/{estimateId}/line-items", wrapper.GetEstimatesEstimateIdLineItems)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/estimates/{estimateId}/line-items", wrapper.PostEstimatesEstimateIdLineItems)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/estimates/{estimateId}/line-items/{lineItemId}", wrapper.DeleteEstimatesEstimateIdLineItemsLineItemId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/estimates/{estimateId}/line-items/{lineItemId}", wrapper.PatchEstimatesEstimateIdLineItemsLineItemId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/estimates/{estimateId}/price", wrapper.PostEstimatesEstimateIdPrice)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/estimates/{estimateId}/revisions", wrapper.GetEstimatesEstimateIdRevisions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/estimates/{estimateId}/revisions/{revisionNumber}", wrapper.GetEstimatesEstimateIdRevisionsRevisionNumber)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/estimates/{estimateId}/revisions/{revisionNumber}/diff", wrapper.GetEstimatesEstimateIdRevisionsRevisionNumberDiff)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/estimates/{estimateId}/revisions/{revisionNumber}/restore", wrapper.PostEstimatesEstimateIdRevisionsRevisionNumberRestore)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/estimates/{estimateId}/signatures", wrapper.GetEstimatesEstimateIdSignatures)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/exports/customers.csv", wrapper.GetExportsCustomersCsv)
 	})
@@ -1204,15 +3829,102 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/imports/{importRunId}/report.json", wrapper.GetImportsImportRunIdReportJson)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/inventory-items", wrapper.GetInventoryItems)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/inventory-items", wrapper.PostInventoryItems)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/inventory-items/{itemId}", wrapper.DeleteInventoryItemsItemId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/inventory-items/{itemId}", wrapper.PatchInventoryItemsItemId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/jobs", wrapper.GetJobs)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/jobs/{jobId}", wrapper.GetJobsJobId)
 	})
 	r.Group(func(r chi.Router) {
 		r.Patch(options.BaseURL+"/jobs/{jobId}", wrapper.PatchJobsJobId)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/jobs/{jobId}/emails", wrapper.GetJobsJobIdEmails)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/jobs/{jobId}/emails", wrapper.PostJobsJobIdEmails)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/jobs/{jobId}/ledger", wrapper.GetJobsJobIdLedger)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/jobs/{jobId}/ledger", wrapper.PostJobsJobIdLedger)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/jobs/{jobId}/storage", wrapper.PostJobsJobIdStorage)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/permissions", wrapper.GetPermissions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/public/estimate-acceptance/{token}", wrapper.GetPublicEstimateAcceptanceToken)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/public/estimate-acceptance/{token}/accept", wrapper.PostPublicEstimateAcceptanceTokenAccept)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/public/estimate-acceptance/{token}/document.pdf", wrapper.GetPublicEstimateAcceptanceTokenDocumentPdf)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/roles", wrapper.GetRoles)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/roles", wrapper.PostRoles)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/roles/{roleId}", wrapper.DeleteRolesRoleId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/roles/{roleId}", wrapper.GetRolesRoleId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/roles/{roleId}", wrapper.PatchRolesRoleId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/search", wrapper.GetSearch)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/settings", wrapper.GetSettings)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/settings", wrapper.PatchSettings)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/settings/email", wrapper.GetSettingsEmail)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/settings/email", wrapper.PutSettingsEmail)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/settings/estimate-document", wrapper.GetSettingsEstimateDocument)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/settings/estimate-document", wrapper.PutSettingsEstimateDocument)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/settings/sso", wrapper.GetSettingsSso)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/settings/sso", wrapper.PutSettingsSso)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/settings/tariff", wrapper.GetSettingsTariff)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/settings/tariff", wrapper.PutSettingsTariff)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/storage", wrapper.GetStorage)
 	})
@@ -1222,6 +3934,45 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/storage/{storageRecordId}", wrapper.PutStorageStorageRecordId)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/storage/{storageRecordId}/emails", wrapper.GetStorageStorageRecordIdEmails)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/storage/{storageRecordId}/emails", wrapper.PostStorageStorageRecordIdEmails)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/storage/{storageRecordId}/ledger", wrapper.GetStorageStorageRecordIdLedger)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/storage/{storageRecordId}/ledger", wrapper.PostStorageStorageRecordIdLedger)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users", wrapper.GetUsers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users", wrapper.PostUsers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/{userId}", wrapper.GetUsersUserId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/users/{userId}", wrapper.PatchUsersUserId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/{userId}/password-reset", wrapper.PostUsersUserIdPasswordReset)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/{userId}/password-reset-token", wrapper.PostUsersUserIdPasswordResetToken)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/users/{userId}/roles", wrapper.PutUsersUserIdRoles)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/users/{userId}/sessions", wrapper.DeleteUsersUserIdSessions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/{userId}/unlock", wrapper.PostUsersUserIdUnlock)
+	})
 
 	return r
 }
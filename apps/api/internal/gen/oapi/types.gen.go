@@ -13,16 +13,123 @@ import (
 
 // Defines values for CalendarJobCardStatus.
 const (
-	CalendarJobCardStatusBooked    CalendarJobCardStatus = "booked"
-	CalendarJobCardStatusCancelled CalendarJobCardStatus = "cancelled"
-	CalendarJobCardStatusCompleted CalendarJobCardStatus = "completed"
-	CalendarJobCardStatusScheduled CalendarJobCardStatus = "scheduled"
+	CalendarJobCardStatusBooked     CalendarJobCardStatus = "booked"
+	CalendarJobCardStatusCancelled  CalendarJobCardStatus = "cancelled"
+	CalendarJobCardStatusCompleted  CalendarJobCardStatus = "completed"
+	CalendarJobCardStatusInProgress CalendarJobCardStatus = "in_progress"
+	CalendarJobCardStatusOnHold     CalendarJobCardStatus = "on_hold"
+	CalendarJobCardStatusScheduled  CalendarJobCardStatus = "scheduled"
+)
+
+// Defines values for CustomerDuplicateHintMatchedOn.
+const (
+	CustomerDuplicateHintMatchedOnPhone CustomerDuplicateHintMatchedOn = "phone"
+)
+
+// Defines values for EmailMessageKind.
+const (
+	EmailMessageKindBookingConfirmation EmailMessageKind = "booking_confirmation"
+	EmailMessageKindEstimateSent        EmailMessageKind = "estimate_sent"
+	EmailMessageKindStorageInvoice      EmailMessageKind = "storage_invoice"
+)
+
+// Defines values for EmailMessageStatus.
+const (
+	EmailMessageStatusFailed  EmailMessageStatus = "failed"
+	EmailMessageStatusQueued  EmailMessageStatus = "queued"
+	EmailMessageStatusSending EmailMessageStatus = "sending"
+	EmailMessageStatusSent    EmailMessageStatus = "sent"
+)
+
+// Defines values for EmailTemplateKind.
+const (
+	EmailTemplateKindBookingConfirmation EmailTemplateKind = "booking_confirmation"
+	EmailTemplateKindEstimateSent        EmailTemplateKind = "estimate_sent"
+	EmailTemplateKindStorageInvoice      EmailTemplateKind = "storage_invoice"
+)
+
+// Defines values for EmailTemplateInputKind.
+const (
+	BookingConfirmation EmailTemplateInputKind = "booking_confirmation"
+	EstimateSent        EmailTemplateInputKind = "estimate_sent"
+	StorageInvoice      EmailTemplateInputKind = "storage_invoice"
+)
+
+// Defines values for EstimatePriceSource.
+const (
+	EstimatePriceSourceManual EstimatePriceSource = "manual"
+	EstimatePriceSourceTariff EstimatePriceSource = "tariff"
 )
 
 // Defines values for EstimateStatus.
 const (
-	Converted EstimateStatus = "converted"
-	Draft     EstimateStatus = "draft"
+	EstimateStatusAccepted  EstimateStatus = "accepted"
+	EstimateStatusConverted EstimateStatus = "converted"
+	EstimateStatusDeclined  EstimateStatus = "declined"
+	EstimateStatusDraft     EstimateStatus = "draft"
+	EstimateStatusExpired   EstimateStatus = "expired"
+	EstimateStatusSent      EstimateStatus = "sent"
+)
+
+// Defines values for EstimateCustomerMatchMatchedOn.
+const (
+	EstimateCustomerMatchMatchedOnCustomerId EstimateCustomerMatchMatchedOn = "customer_id"
+	EstimateCustomerMatchMatchedOnEmail      EstimateCustomerMatchMatchedOn = "email"
+	EstimateCustomerMatchMatchedOnNone       EstimateCustomerMatchMatchedOn = "none"
+	EstimateCustomerMatchMatchedOnPhone      EstimateCustomerMatchMatchedOn = "phone"
+)
+
+// Defines values for EstimateListItemStatus.
+const (
+	EstimateListItemStatusAccepted  EstimateListItemStatus = "accepted"
+	EstimateListItemStatusConverted EstimateListItemStatus = "converted"
+	EstimateListItemStatusDeclined  EstimateListItemStatus = "declined"
+	EstimateListItemStatusDraft     EstimateListItemStatus = "draft"
+	EstimateListItemStatusExpired   EstimateListItemStatus = "expired"
+	EstimateListItemStatusSent      EstimateListItemStatus = "sent"
+)
+
+// Defines values for EstimatePriceBreakdownMode.
+const (
+	EstimatePriceBreakdownModeLocal        EstimatePriceBreakdownMode = "local"
+	EstimatePriceBreakdownModeLongDistance EstimatePriceBreakdownMode = "long_distance"
+)
+
+// Defines values for EstimatePriceLineKind.
+const (
+	Accessorial      EstimatePriceLineKind = "accessorial"
+	FuelSurcharge    EstimatePriceLineKind = "fuel_surcharge"
+	Labor            EstimatePriceLineKind = "labor"
+	LinehaulDistance EstimatePriceLineKind = "linehaul_distance"
+	LinehaulWeight   EstimatePriceLineKind = "linehaul_weight"
+	MinimumCharge    EstimatePriceLineKind = "minimum_charge"
+	Valuation        EstimatePriceLineKind = "valuation"
+)
+
+// Defines values for EstimateRevisionChange.
+const (
+	EstimateRevisionChangeConverted     EstimateRevisionChange = "converted"
+	EstimateRevisionChangeCreated       EstimateRevisionChange = "created"
+	EstimateRevisionChangeExpired       EstimateRevisionChange = "expired"
+	EstimateRevisionChangeImported      EstimateRevisionChange = "imported"
+	EstimateRevisionChangeLineItems     EstimateRevisionChange = "line_items"
+	EstimateRevisionChangePriced        EstimateRevisionChange = "priced"
+	EstimateRevisionChangeRestored      EstimateRevisionChange = "restored"
+	EstimateRevisionChangeStatusChanged EstimateRevisionChange = "status_changed"
+	EstimateRevisionChangeUpdated       EstimateRevisionChange = "updated"
+)
+
+// Defines values for EstimateRevisionSummaryChange.
+const (
+	EstimateRevisionSummaryChangeConverted     EstimateRevisionSummaryChange = "converted"
+	EstimateRevisionSummaryChangeCreated       EstimateRevisionSummaryChange = "created"
+	EstimateRevisionSummaryChangeExpired       EstimateRevisionSummaryChange = "expired"
+	EstimateRevisionSummaryChangeImported      EstimateRevisionSummaryChange = "imported"
+	EstimateRevisionSummaryChangeLineItems     EstimateRevisionSummaryChange = "line_items"
+	EstimateRevisionSummaryChangePriced        EstimateRevisionSummaryChange = "priced"
+	EstimateRevisionSummaryChangeRestored      EstimateRevisionSummaryChange = "restored"
+	EstimateRevisionSummaryChangeStatusChanged EstimateRevisionSummaryChange = "status_changed"
+	EstimateRevisionSummaryChangeUpdated       EstimateRevisionSummaryChange = "updated"
 )
 
 // Defines values for ImportMode.
@@ -49,9 +156,9 @@ const (
 
 // Defines values for ImportRowMessageSeverity.
 const (
-	ImportRowMessageSeverityError ImportRowMessageSeverity = "error"
-	ImportRowMessageSeverityInfo  ImportRowMessageSeverity = "info"
-	ImportRowMessageSeverityWarn  ImportRowMessageSeverity = "warn"
+	Error ImportRowMessageSeverity = "error"
+	Info  ImportRowMessageSeverity = "info"
+	Warn  ImportRowMessageSeverity = "warn"
 )
 
 // Defines values for ImportRunStatus.
@@ -68,19 +175,77 @@ const (
 
 // Defines values for ImportTemplate.
 const (
-	Combined  ImportTemplate = "combined"
-	Customers ImportTemplate = "customers"
-	Estimates ImportTemplate = "estimates"
-	Jobs      ImportTemplate = "jobs"
-	Storage   ImportTemplate = "storage"
+	ImportTemplateCombined  ImportTemplate = "combined"
+	ImportTemplateCustomers ImportTemplate = "customers"
+	ImportTemplateEstimates ImportTemplate = "estimates"
+	ImportTemplateJobs      ImportTemplate = "jobs"
+	ImportTemplateStorage   ImportTemplate = "storage"
 )
 
 // Defines values for JobStatus.
 const (
-	JobStatusBooked    JobStatus = "booked"
-	JobStatusCancelled JobStatus = "cancelled"
-	JobStatusCompleted JobStatus = "completed"
-	JobStatusScheduled JobStatus = "scheduled"
+	JobStatusBooked     JobStatus = "booked"
+	JobStatusCancelled  JobStatus = "cancelled"
+	JobStatusCompleted  JobStatus = "completed"
+	JobStatusInProgress JobStatus = "in_progress"
+	JobStatusOnHold     JobStatus = "on_hold"
+	JobStatusScheduled  JobStatus = "scheduled"
+)
+
+// Defines values for JobListItemSource.
+const (
+	JobListItemSourceEstimate JobListItemSource = "estimate"
+	JobListItemSourceImport   JobListItemSource = "import"
+)
+
+// Defines values for JobListItemStatus.
+const (
+	JobListItemStatusBooked     JobListItemStatus = "booked"
+	JobListItemStatusCancelled  JobListItemStatus = "cancelled"
+	JobListItemStatusCompleted  JobListItemStatus = "completed"
+	JobListItemStatusInProgress JobListItemStatus = "in_progress"
+	JobListItemStatusOnHold     JobListItemStatus = "on_hold"
+	JobListItemStatusScheduled  JobListItemStatus = "scheduled"
+)
+
+// Defines values for LedgerAccount.
+const (
+	LedgerAccountMove    LedgerAccount = "move"
+	LedgerAccountStorage LedgerAccount = "storage"
+)
+
+// Defines values for LedgerEntryKind.
+const (
+	Adjustment LedgerEntryKind = "adjustment"
+	Charge     LedgerEntryKind = "charge"
+	Payment    LedgerEntryKind = "payment"
+	Refund     LedgerEntryKind = "refund"
+)
+
+// Defines values for PaymentMethod.
+const (
+	Ach   PaymentMethod = "ach"
+	Card  PaymentMethod = "card"
+	Cash  PaymentMethod = "cash"
+	Check PaymentMethod = "check"
+	Other PaymentMethod = "other"
+)
+
+// Defines values for PublicEstimateStatus.
+const (
+	PublicEstimateStatusAccepted  PublicEstimateStatus = "accepted"
+	PublicEstimateStatusConverted PublicEstimateStatus = "converted"
+	PublicEstimateStatusDeclined  PublicEstimateStatus = "declined"
+	PublicEstimateStatusDraft     PublicEstimateStatus = "draft"
+	PublicEstimateStatusExpired   PublicEstimateStatus = "expired"
+	PublicEstimateStatusSent      PublicEstimateStatus = "sent"
+)
+
+// Defines values for SearchHitType.
+const (
+	SearchHitTypeCustomer SearchHitType = "customer"
+	SearchHitTypeEstimate SearchHitType = "estimate"
+	SearchHitTypeJob      SearchHitType = "job"
 )
 
 // Defines values for StorageListItemStatus.
@@ -97,33 +262,114 @@ const (
 	StorageStatusSit       StorageStatus = "sit"
 )
 
+// Defines values for UpdateEstimateRequestStatus.
+const (
+	UpdateEstimateRequestStatusAccepted UpdateEstimateRequestStatus = "accepted"
+	UpdateEstimateRequestStatusDeclined UpdateEstimateRequestStatus = "declined"
+	UpdateEstimateRequestStatusDraft    UpdateEstimateRequestStatus = "draft"
+	UpdateEstimateRequestStatusExpired  UpdateEstimateRequestStatus = "expired"
+	UpdateEstimateRequestStatusSent     UpdateEstimateRequestStatus = "sent"
+)
+
 // Defines values for UpdateJobRequestStatus.
 const (
-	UpdateJobRequestStatusBooked    UpdateJobRequestStatus = "booked"
-	UpdateJobRequestStatusCancelled UpdateJobRequestStatus = "cancelled"
-	UpdateJobRequestStatusCompleted UpdateJobRequestStatus = "completed"
-	UpdateJobRequestStatusScheduled UpdateJobRequestStatus = "scheduled"
+	UpdateJobRequestStatusBooked     UpdateJobRequestStatus = "booked"
+	UpdateJobRequestStatusCancelled  UpdateJobRequestStatus = "cancelled"
+	UpdateJobRequestStatusCompleted  UpdateJobRequestStatus = "completed"
+	UpdateJobRequestStatusInProgress UpdateJobRequestStatus = "in_progress"
+	UpdateJobRequestStatusOnHold     UpdateJobRequestStatus = "on_hold"
+	UpdateJobRequestStatusScheduled  UpdateJobRequestStatus = "scheduled"
 )
 
 // Defines values for GetCalendarParamsPhase.
 const (
-	Booked    GetCalendarParamsPhase = "booked"
-	Cancelled GetCalendarParamsPhase = "cancelled"
-	Completed GetCalendarParamsPhase = "completed"
-	Scheduled GetCalendarParamsPhase = "scheduled"
+	GetCalendarParamsPhaseBooked     GetCalendarParamsPhase = "booked"
+	GetCalendarParamsPhaseCancelled  GetCalendarParamsPhase = "cancelled"
+	GetCalendarParamsPhaseCompleted  GetCalendarParamsPhase = "completed"
+	GetCalendarParamsPhaseInProgress GetCalendarParamsPhase = "in_progress"
+	GetCalendarParamsPhaseOnHold     GetCalendarParamsPhase = "on_hold"
+	GetCalendarParamsPhaseScheduled  GetCalendarParamsPhase = "scheduled"
 )
 
 // Defines values for GetCalendarParamsJobType.
 const (
-	Local        GetCalendarParamsJobType = "local"
-	LongDistance GetCalendarParamsJobType = "long_distance"
-	Other        GetCalendarParamsJobType = "other"
+	GetCalendarParamsJobTypeLocal        GetCalendarParamsJobType = "local"
+	GetCalendarParamsJobTypeLongDistance GetCalendarParamsJobType = "long_distance"
+	GetCalendarParamsJobTypeOther        GetCalendarParamsJobType = "other"
+)
+
+// Defines values for GetEstimatesParamsStatus.
+const (
+	Accepted  GetEstimatesParamsStatus = "accepted"
+	Converted GetEstimatesParamsStatus = "converted"
+	Declined  GetEstimatesParamsStatus = "declined"
+	Draft     GetEstimatesParamsStatus = "draft"
+	Expired   GetEstimatesParamsStatus = "expired"
+	Sent      GetEstimatesParamsStatus = "sent"
+)
+
+// Defines values for GetJobsParamsStatus.
+const (
+	Booked     GetJobsParamsStatus = "booked"
+	Cancelled  GetJobsParamsStatus = "cancelled"
+	Completed  GetJobsParamsStatus = "completed"
+	InProgress GetJobsParamsStatus = "in_progress"
+	OnHold     GetJobsParamsStatus = "on_hold"
+	Scheduled  GetJobsParamsStatus = "scheduled"
+)
+
+// Defines values for GetJobsParamsSource.
+const (
+	GetJobsParamsSourceEstimate GetJobsParamsSource = "estimate"
+	GetJobsParamsSourceImport   GetJobsParamsSource = "import"
 )
 
+// AcceptEstimateRequest defines model for AcceptEstimateRequest.
+type AcceptEstimateRequest struct {
+	// AgreeToTerms Must be true.
+	AgreeToTerms bool `json:"agreeToTerms"`
+
+	// SignerName The customer's full name, typed as their signature.
+	SignerName string `json:"signerName"`
+}
+
+// ApiToken defines model for ApiToken.
+type ApiToken struct {
+	CreatedAt  time.Time          `json:"createdAt"`
+	ExpiresAt  *time.Time         `json:"expiresAt"`
+	Id         openapi_types.UUID `json:"id"`
+	LastUsedAt *time.Time         `json:"lastUsedAt"`
+	LastUsedIp *string            `json:"lastUsedIp"`
+	Name       string             `json:"name"`
+
+	// Prefix First characters of the token, to tell tokens apart.
+	Prefix string   `json:"prefix"`
+	Scopes []string `json:"scopes"`
+}
+
+// ApiTokenListResponse defines model for ApiTokenListResponse.
+type ApiTokenListResponse struct {
+	Items     []ApiToken `json:"items"`
+	RequestId string     `json:"requestId"`
+}
+
 // AuthSessionResponse defines model for AuthSessionResponse.
 type AuthSessionResponse struct {
-	Tenant Tenant `json:"tenant"`
-	User   User   `json:"user"`
+	MfaEnabled bool `json:"mfaEnabled"`
+
+	// MfaEnrollmentRequired True when the tenant requires MFA for this user's permissions and the user has not enrolled. Only the `/auth` endpoints are available until enrollment.
+	MfaEnrollmentRequired bool `json:"mfaEnrollmentRequired"`
+
+	// Permissions Effective permission names granted to the user in this tenant, sorted.
+	Permissions []string `json:"permissions"`
+	Tenant      Tenant   `json:"tenant"`
+	User        User     `json:"user"`
+}
+
+// AuthTenantListResponse defines model for AuthTenantListResponse.
+type AuthTenantListResponse struct {
+	CurrentTenantId openapi_types.UUID `json:"currentTenantId"`
+	Items           []Tenant           `json:"items"`
 }
 
 // CalendarJobCard defines model for CalendarJobCard.
@@ -149,6 +395,43 @@ type CalendarResponse struct {
 	RequestId string            `json:"requestId"`
 }
 
+// ChangePasswordRequest defines model for ChangePasswordRequest.
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"currentPassword"`
+	NewPassword     string `json:"newPassword"`
+}
+
+// CompletePasswordResetRequest defines model for CompletePasswordResetRequest.
+type CompletePasswordResetRequest struct {
+	NewPassword string `json:"newPassword"`
+	Token       string `json:"token"`
+}
+
+// CreateAcceptanceLinkRequest defines model for CreateAcceptanceLinkRequest.
+type CreateAcceptanceLinkRequest struct {
+	// AutoConvert Convert the estimate to a job as soon as the customer accepts.
+	AutoConvert *bool `json:"autoConvert,omitempty"`
+
+	// ExpiresInDays Days until the link expires, instead of the estimate's own expiry.
+	ExpiresInDays *int `json:"expiresInDays,omitempty"`
+}
+
+// CreateApiTokenRequest defines model for CreateApiTokenRequest.
+type CreateApiTokenRequest struct {
+	ExpiresAt *time.Time `json:"expiresAt"`
+	Name      string     `json:"name"`
+
+	// Scopes Permission names; each must be held by the creating user.
+	Scopes []string `json:"scopes"`
+}
+
+// CreateApiTokenResponse defines model for CreateApiTokenResponse.
+type CreateApiTokenResponse struct {
+	RequestId string   `json:"requestId"`
+	Secret    string   `json:"secret"`
+	Token     ApiToken `json:"token"`
+}
+
 // CreateCustomerRequest defines model for CreateCustomerRequest.
 type CreateCustomerRequest struct {
 	Email     *openapi_types.Email `json:"email,omitempty"`
@@ -157,8 +440,22 @@ type CreateCustomerRequest struct {
 	Phone     *string              `json:"phone,omitempty"`
 }
 
+// CreateEstimateLineItemRequest defines model for CreateEstimateLineItemRequest.
+type CreateEstimateLineItemRequest struct {
+	CubicFeet       *int                `json:"cubicFeet,omitempty"`
+	InventoryItemId *openapi_types.UUID `json:"inventoryItemId,omitempty"`
+	ItemName        *string             `json:"itemName,omitempty"`
+	Quantity        *int                `json:"quantity,omitempty"`
+	Room            *string             `json:"room,omitempty"`
+
+	// WeightLbs Defaults to 7 lbs per cubic foot when neither this nor a catalog item supplies it.
+	WeightLbs *int `json:"weightLbs,omitempty"`
+}
+
 // CreateEstimateRequest defines model for CreateEstimateRequest.
 type CreateEstimateRequest struct {
+	// CustomerId Existing customer to attach the estimate to. Without it, the estimate is attached to the customer with the same email, or with the same phone number and name, and a new customer is created only when neither matches.
+	CustomerId              *openapi_types.UUID `json:"customerId,omitempty"`
 	CustomerName            string              `json:"customerName"`
 	DepositCents            *int64              `json:"depositCents,omitempty"`
 	DestinationAddressLine1 string              `json:"destinationAddressLine1"`
@@ -181,25 +478,62 @@ type CreateEstimateRequest struct {
 	SecondaryPhone          *string             `json:"secondaryPhone,omitempty"`
 }
 
-// CreateStorageRecordRequest defines model for CreateStorageRecordRequest.
+// CreateInventoryItemRequest defines model for CreateInventoryItemRequest.
+type CreateInventoryItemRequest struct {
+	CubicFeet   int     `json:"cubicFeet"`
+	DefaultRoom *string `json:"defaultRoom,omitempty"`
+	Name        string  `json:"name"`
+
+	// WeightLbs Defaults to 7 lbs per cubic foot.
+	WeightLbs *int `json:"weightLbs,omitempty"`
+}
+
+// CreateLedgerEntryRequest defines model for CreateLedgerEntryRequest.
+type CreateLedgerEntryRequest struct {
+	// AmountCents Positive for charges, payments and refunds; adjustments may be negative but not zero.
+	AmountCents int64           `json:"amountCents"`
+	Kind        LedgerEntryKind `json:"kind"`
+	Memo        *string         `json:"memo,omitempty"`
+	Method      *PaymentMethod  `json:"method,omitempty"`
+
+	// OccurredAt When the money moved; defaults to now and cannot be in the future.
+	OccurredAt *time.Time `json:"occurredAt,omitempty"`
+
+	// Reference Check number, card authorization or transfer id.
+	Reference *string `json:"reference,omitempty"`
+}
+
+// CreateRoleRequest defines model for CreateRoleRequest.
+type CreateRoleRequest struct {
+	Description *string  `json:"description,omitempty"`
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+}
+
+// CreateStorageRecordRequest Balances are not accepted here; post ledger entries to change them.
 type CreateStorageRecordRequest struct {
-	DateIn              *openapi_types.Date `json:"dateIn,omitempty"`
-	DateOut             *openapi_types.Date `json:"dateOut,omitempty"`
-	Facility            string              `json:"facility"`
-	Items               *int                `json:"items,omitempty"`
-	LastPaymentAt       *time.Time          `json:"lastPaymentAt,omitempty"`
-	LocationLabel       *string             `json:"locationLabel,omitempty"`
-	LotNumber           *string             `json:"lotNumber,omitempty"`
-	MonthlyRateCents    *int64              `json:"monthlyRateCents,omitempty"`
-	MoveBalanceCents    *int64              `json:"moveBalanceCents,omitempty"`
-	NextBillDate        *openapi_types.Date `json:"nextBillDate,omitempty"`
-	Notes               *string             `json:"notes,omitempty"`
-	OversizeItems       *int                `json:"oversizeItems,omitempty"`
-	Pads                *int                `json:"pads,omitempty"`
-	Status              *StorageStatus      `json:"status,omitempty"`
-	StorageBalanceCents *int64              `json:"storageBalanceCents,omitempty"`
-	Vaults              *int                `json:"vaults,omitempty"`
-	Volume              *int                `json:"volume,omitempty"`
+	DateIn           *openapi_types.Date `json:"dateIn,omitempty"`
+	DateOut          *openapi_types.Date `json:"dateOut,omitempty"`
+	Facility         string              `json:"facility"`
+	Items            *int                `json:"items,omitempty"`
+	LocationLabel    *string             `json:"locationLabel,omitempty"`
+	LotNumber        *string             `json:"lotNumber,omitempty"`
+	MonthlyRateCents *int64              `json:"monthlyRateCents,omitempty"`
+	NextBillDate     *openapi_types.Date `json:"nextBillDate,omitempty"`
+	Notes            *string             `json:"notes,omitempty"`
+	OversizeItems    *int                `json:"oversizeItems,omitempty"`
+	Pads             *int                `json:"pads,omitempty"`
+	Status           *StorageStatus      `json:"status,omitempty"`
+	Vaults           *int                `json:"vaults,omitempty"`
+	Volume           *int                `json:"volume,omitempty"`
+}
+
+// CreateTenantUserRequest defines model for CreateTenantUserRequest.
+type CreateTenantUserRequest struct {
+	Email    openapi_types.Email   `json:"email"`
+	FullName string                `json:"fullName"`
+	Password *string               `json:"password,omitempty"`
+	RoleIds  *[]openapi_types.UUID `json:"roleIds,omitempty"`
 }
 
 // Customer defines model for Customer.
@@ -214,6 +548,105 @@ type Customer struct {
 	UpdatedAt time.Time            `json:"updatedAt"`
 }
 
+// CustomerDuplicateHint defines model for CustomerDuplicateHint.
+type CustomerDuplicateHint struct {
+	CustomerId   openapi_types.UUID             `json:"customerId"`
+	CustomerName string                         `json:"customerName"`
+	MatchedOn    CustomerDuplicateHintMatchedOn `json:"matchedOn"`
+}
+
+// CustomerDuplicateHintMatchedOn defines model for CustomerDuplicateHint.MatchedOn.
+type CustomerDuplicateHintMatchedOn string
+
+// CustomerListResponse defines model for CustomerListResponse.
+type CustomerListResponse struct {
+	Items      []Customer `json:"items"`
+	NextCursor *string    `json:"nextCursor"`
+	RequestId  string     `json:"requestId"`
+}
+
+// EmailMessage defines model for EmailMessage.
+type EmailMessage struct {
+	Attempts  int       `json:"attempts"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	// CreatedBy Absent when the message was queued by a status change made by the system.
+	CreatedBy *openapi_types.UUID `json:"createdBy,omitempty"`
+	Id        openapi_types.UUID  `json:"id"`
+	Kind      EmailMessageKind    `json:"kind"`
+
+	// LastError Why the last delivery attempt failed.
+	LastError     *string            `json:"lastError,omitempty"`
+	NextAttemptAt time.Time          `json:"nextAttemptAt"`
+	SentAt        *time.Time         `json:"sentAt,omitempty"`
+	Status        EmailMessageStatus `json:"status"`
+	Subject       string             `json:"subject"`
+	ToAddress     string             `json:"toAddress"`
+}
+
+// EmailMessageKind defines model for EmailMessage.Kind.
+type EmailMessageKind string
+
+// EmailMessageStatus defines model for EmailMessage.Status.
+type EmailMessageStatus string
+
+// EmailMessageListResponse defines model for EmailMessageListResponse.
+type EmailMessageListResponse struct {
+	Items     []EmailMessage `json:"items"`
+	RequestId string         `json:"requestId"`
+}
+
+// EmailMessageResponse defines model for EmailMessageResponse.
+type EmailMessageResponse struct {
+	Email     EmailMessage `json:"email"`
+	RequestId string       `json:"requestId"`
+}
+
+// EmailSettings defines model for EmailSettings.
+type EmailSettings struct {
+	// DefaultFromAddress Platform sender used when fromAddress is not set.
+	DefaultFromAddress *string `json:"defaultFromAddress"`
+	Enabled            bool    `json:"enabled"`
+	FromAddress        *string `json:"fromAddress"`
+
+	// FromName Sender name. Defaults to the tenant name.
+	FromName  *string         `json:"fromName"`
+	ReplyTo   *string         `json:"replyTo"`
+	Templates []EmailTemplate `json:"templates"`
+	UpdatedAt *time.Time      `json:"updatedAt"`
+}
+
+// EmailSettingsResponse defines model for EmailSettingsResponse.
+type EmailSettingsResponse struct {
+	RequestId string        `json:"requestId"`
+	Settings  EmailSettings `json:"settings"`
+}
+
+// EmailTemplate defines model for EmailTemplate.
+type EmailTemplate struct {
+	Body string `json:"body"`
+
+	// Customized False when the tenant uses the default template.
+	Customized   bool              `json:"customized"`
+	Kind         EmailTemplateKind `json:"kind"`
+	Placeholders []string          `json:"placeholders"`
+	Subject      string            `json:"subject"`
+}
+
+// EmailTemplateKind defines model for EmailTemplate.Kind.
+type EmailTemplateKind string
+
+// EmailTemplateInput defines model for EmailTemplateInput.
+type EmailTemplateInput struct {
+	Body    string                 `json:"body"`
+	Kind    EmailTemplateInputKind `json:"kind"`
+	Subject string                 `json:"subject"`
+}
+
+// EmailTemplateInputKind defines model for EmailTemplateInput.Kind.
+type EmailTemplateInputKind string
+
 // ErrorEnvelope defines model for ErrorEnvelope.
 type ErrorEnvelope struct {
 	Error struct {
@@ -238,31 +671,326 @@ type Estimate struct {
 	Email                   openapi_types.Email `json:"email"`
 	EstimateNumber          string              `json:"estimateNumber"`
 	EstimatedTotalCents     *int64              `json:"estimatedTotalCents,omitempty"`
-	Id                      openapi_types.UUID  `json:"id"`
-	LeadSource              string              `json:"leadSource"`
-	LocationType            *string             `json:"locationType,omitempty"`
-	MoveDate                openapi_types.Date  `json:"moveDate"`
-	MoveSize                *string             `json:"moveSize,omitempty"`
-	Notes                   *string             `json:"notes,omitempty"`
-	OriginAddressLine1      string              `json:"originAddressLine1"`
-	OriginCity              string              `json:"originCity"`
-	OriginPostalCode        string              `json:"originPostalCode"`
-	OriginState             string              `json:"originState"`
-	PickupTime              *string             `json:"pickupTime,omitempty"`
-	PrimaryPhone            string              `json:"primaryPhone"`
-	SecondaryPhone          *string             `json:"secondaryPhone,omitempty"`
-	Status                  EstimateStatus      `json:"status"`
-	TenantId                openapi_types.UUID  `json:"tenantId"`
-	UpdatedAt               time.Time           `json:"updatedAt"`
+
+	// ExpiresAt When a sent estimate expires if it is not accepted or declined.
+	ExpiresAt          *time.Time         `json:"expiresAt,omitempty"`
+	Id                 openapi_types.UUID `json:"id"`
+	LastEmail          *EmailMessage      `json:"lastEmail,omitempty"`
+	LeadSource         string             `json:"leadSource"`
+	LocationType       *string            `json:"locationType,omitempty"`
+	LostReason         *string            `json:"lostReason,omitempty"`
+	MoveDate           openapi_types.Date `json:"moveDate"`
+	MoveSize           *string            `json:"moveSize,omitempty"`
+	Notes              *string            `json:"notes,omitempty"`
+	OriginAddressLine1 string             `json:"originAddressLine1"`
+	OriginCity         string             `json:"originCity"`
+	OriginPostalCode   string             `json:"originPostalCode"`
+	OriginState        string             `json:"originState"`
+	PickupTime         *string            `json:"pickupTime,omitempty"`
+
+	// PriceBreakdown The last tariff price. It is kept when the total is overridden by hand.
+	PriceBreakdown *EstimatePriceBreakdown `json:"priceBreakdown,omitempty"`
+
+	// PriceSource Whether estimatedTotalCents was entered by hand or computed from the tariff.
+	PriceSource     *EstimatePriceSource `json:"priceSource,omitempty"`
+	PricedAt        *time.Time           `json:"pricedAt,omitempty"`
+	PrimaryPhone    string               `json:"primaryPhone"`
+	SecondaryPhone  *string              `json:"secondaryPhone,omitempty"`
+	SentAt          *time.Time           `json:"sentAt,omitempty"`
+	Status          EstimateStatus       `json:"status"`
+	StatusChangedAt time.Time            `json:"statusChangedAt"`
+	TenantId        openapi_types.UUID   `json:"tenantId"`
+
+	// TotalCubicFeet Sum of quantity times cubic feet over the estimate's line items.
+	TotalCubicFeet int `json:"totalCubicFeet"`
+
+	// TotalWeightLbs Sum of quantity times weight over the estimate's line items.
+	TotalWeightLbs int       `json:"totalWeightLbs"`
+	UpdatedAt      time.Time `json:"updatedAt"`
 }
 
+// EstimatePriceSource Whether estimatedTotalCents was entered by hand or computed from the tariff.
+type EstimatePriceSource string
+
 // EstimateStatus defines model for Estimate.Status.
 type EstimateStatus string
 
+// EstimateAcceptanceLink defines model for EstimateAcceptanceLink.
+type EstimateAcceptanceLink struct {
+	AutoConvert bool               `json:"autoConvert"`
+	CreatedAt   time.Time          `json:"createdAt"`
+	ExpiresAt   time.Time          `json:"expiresAt"`
+	Id          openapi_types.UUID `json:"id"`
+	Token       string             `json:"token"`
+
+	// Url The customer-facing page in the web app.
+	Url string `json:"url"`
+}
+
+// EstimateAcceptanceLinkResponse defines model for EstimateAcceptanceLinkResponse.
+type EstimateAcceptanceLinkResponse struct {
+	Link      EstimateAcceptanceLink `json:"link"`
+	RequestId string                 `json:"requestId"`
+}
+
+// EstimateCustomerMatch How a new estimate was attached to a customer. Only returned when the estimate is created.
+type EstimateCustomerMatch struct {
+	CustomerId openapi_types.UUID `json:"customerId"`
+
+	// MatchedOn `none` means a new customer was created. `phone` matches need the same name as well, since a phone number is often shared by a household.
+	MatchedOn EstimateCustomerMatchMatchedOn `json:"matchedOn"`
+
+	// PossibleDuplicates Existing customers with the same phone number but a different name. The estimate was given a new customer; merge the customers if they are the same person.
+	PossibleDuplicates []CustomerDuplicateHint `json:"possibleDuplicates"`
+}
+
+// EstimateCustomerMatchMatchedOn `none` means a new customer was created. `phone` matches need the same name as well, since a phone number is often shared by a household.
+type EstimateCustomerMatchMatchedOn string
+
+// EstimateDocumentTemplate defines model for EstimateDocumentTemplate.
+type EstimateDocumentTemplate struct {
+	AccentColor string `json:"accentColor"`
+
+	// CompanyName Name printed on documents. Defaults to the tenant name.
+	CompanyName     *string `json:"companyName"`
+	FooterText      *string `json:"footerText"`
+	HasLogo         bool    `json:"hasLogo"`
+	IntroText       *string `json:"introText"`
+	LogoContentType *string `json:"logoContentType"`
+
+	// ShowLineItems When false, documents show inventory totals instead of the line item table.
+	ShowLineItems bool       `json:"showLineItems"`
+	TermsText     *string    `json:"termsText"`
+	UpdatedAt     *time.Time `json:"updatedAt"`
+}
+
+// EstimateDocumentTemplateResponse defines model for EstimateDocumentTemplateResponse.
+type EstimateDocumentTemplateResponse struct {
+	RequestId string                   `json:"requestId"`
+	Template  EstimateDocumentTemplate `json:"template"`
+}
+
+// EstimateFieldChange defines model for EstimateFieldChange.
+type EstimateFieldChange struct {
+	// Field The API field name, such as `moveDate` or `estimatedTotalCents`.
+	Field string `json:"field"`
+
+	// From The earlier value; missing when the field was not set.
+	From *interface{} `json:"from,omitempty"`
+
+	// To The later value; missing when the field was cleared.
+	To *interface{} `json:"to,omitempty"`
+}
+
+// EstimateLineItem defines model for EstimateLineItem.
+type EstimateLineItem struct {
+	CreatedAt time.Time `json:"createdAt"`
+
+	// CubicFeet Cubic feet of a single unit.
+	CubicFeet       int                 `json:"cubicFeet"`
+	EstimateId      openapi_types.UUID  `json:"estimateId"`
+	Id              openapi_types.UUID  `json:"id"`
+	InventoryItemId *openapi_types.UUID `json:"inventoryItemId,omitempty"`
+	ItemName        string              `json:"itemName"`
+	Quantity        int                 `json:"quantity"`
+	Room            string              `json:"room"`
+	UpdatedAt       time.Time           `json:"updatedAt"`
+
+	// WeightLbs Weight of a single unit in pounds.
+	WeightLbs int `json:"weightLbs"`
+}
+
+// EstimateLineItemChange defines model for EstimateLineItemChange.
+type EstimateLineItemChange struct {
+	Fields     []EstimateFieldChange `json:"fields"`
+	ItemName   string                `json:"itemName"`
+	LineItemId openapi_types.UUID    `json:"lineItemId"`
+}
+
+// EstimateLineItemListResponse defines model for EstimateLineItemListResponse.
+type EstimateLineItemListResponse struct {
+	Items          []EstimateLineItem `json:"items"`
+	RequestId      string             `json:"requestId"`
+	TotalCubicFeet int                `json:"totalCubicFeet"`
+	TotalWeightLbs int                `json:"totalWeightLbs"`
+}
+
+// EstimateLineItemResponse defines model for EstimateLineItemResponse.
+type EstimateLineItemResponse struct {
+	LineItem       EstimateLineItem `json:"lineItem"`
+	RequestId      string           `json:"requestId"`
+	TotalCubicFeet int              `json:"totalCubicFeet"`
+	TotalWeightLbs int              `json:"totalWeightLbs"`
+}
+
+// EstimateListItem defines model for EstimateListItem.
+type EstimateListItem struct {
+	ConvertedJobId      *openapi_types.UUID    `json:"convertedJobId,omitempty"`
+	CreatedAt           time.Time              `json:"createdAt"`
+	CreatedBy           *openapi_types.UUID    `json:"createdBy,omitempty"`
+	CustomerId          openapi_types.UUID     `json:"customerId"`
+	CustomerName        string                 `json:"customerName"`
+	DepositCents        *int64                 `json:"depositCents,omitempty"`
+	DestinationShort    string                 `json:"destinationShort"`
+	Email               openapi_types.Email    `json:"email"`
+	EstimateNumber      string                 `json:"estimateNumber"`
+	EstimatedTotalCents *int64                 `json:"estimatedTotalCents,omitempty"`
+	ExpiresAt           *time.Time             `json:"expiresAt,omitempty"`
+	Id                  openapi_types.UUID     `json:"id"`
+	LeadSource          string                 `json:"leadSource"`
+	MoveDate            openapi_types.Date     `json:"moveDate"`
+	OriginShort         string                 `json:"originShort"`
+	PrimaryPhone        string                 `json:"primaryPhone"`
+	Status              EstimateListItemStatus `json:"status"`
+	UpdatedAt           time.Time              `json:"updatedAt"`
+}
+
+// EstimateListItemStatus defines model for EstimateListItem.Status.
+type EstimateListItemStatus string
+
+// EstimateListResponse defines model for EstimateListResponse.
+type EstimateListResponse struct {
+	Items      []EstimateListItem `json:"items"`
+	NextCursor *string            `json:"nextCursor"`
+	RequestId  string             `json:"requestId"`
+}
+
+// EstimatePriceBreakdown The last tariff price. It is kept when the total is overridden by hand.
+type EstimatePriceBreakdown struct {
+	CrewSize           *int   `json:"crewSize,omitempty"`
+	DeclaredValueCents *int64 `json:"declaredValueCents,omitempty"`
+
+	// LaborMinutes Billed minutes after the tariff minimum.
+	LaborMinutes  *int                       `json:"laborMinutes,omitempty"`
+	Lines         []EstimatePriceLine        `json:"lines"`
+	Miles         int                        `json:"miles"`
+	Mode          EstimatePriceBreakdownMode `json:"mode"`
+	TotalCents    int64                      `json:"totalCents"`
+	ValuationCode *string                    `json:"valuationCode,omitempty"`
+	WeightLbs     int                        `json:"weightLbs"`
+}
+
+// EstimatePriceBreakdownMode defines model for EstimatePriceBreakdown.Mode.
+type EstimatePriceBreakdownMode string
+
+// EstimatePriceLine defines model for EstimatePriceLine.
+type EstimatePriceLine struct {
+	AmountCents int64                 `json:"amountCents"`
+	Code        *string               `json:"code,omitempty"`
+	Description string                `json:"description"`
+	Kind        EstimatePriceLineKind `json:"kind"`
+
+	// Quantity Minutes for labor, hundredweights, miles, units or started $1,000 of declared value.
+	Quantity        int64 `json:"quantity"`
+	UnitAmountCents int64 `json:"unitAmountCents"`
+}
+
+// EstimatePriceLineKind defines model for EstimatePriceLine.Kind.
+type EstimatePriceLineKind string
+
 // EstimateResponse defines model for EstimateResponse.
 type EstimateResponse struct {
-	Estimate  Estimate `json:"estimate"`
-	RequestId string   `json:"requestId"`
+	// CustomerMatch How a new estimate was attached to a customer. Only returned when the estimate is created.
+	CustomerMatch *EstimateCustomerMatch `json:"customerMatch,omitempty"`
+	Estimate      Estimate               `json:"estimate"`
+	RequestId     string                 `json:"requestId"`
+}
+
+// EstimateRevision defines model for EstimateRevision.
+type EstimateRevision struct {
+	Change    EstimateRevisionChange `json:"change"`
+	CreatedAt time.Time              `json:"createdAt"`
+
+	// CreatedBy Missing for system changes such as expiry, or when the user was deleted.
+	CreatedBy     *openapi_types.UUID `json:"createdBy,omitempty"`
+	CreatedByName *string             `json:"createdByName,omitempty"`
+
+	// RestoredFrom The revision a `restored` revision was copied from.
+	RestoredFrom   *int                     `json:"restoredFrom,omitempty"`
+	RevisionNumber int                      `json:"revisionNumber"`
+	Snapshot       EstimateRevisionSnapshot `json:"snapshot"`
+}
+
+// EstimateRevisionChange defines model for EstimateRevision.Change.
+type EstimateRevisionChange string
+
+// EstimateRevisionDiff defines model for EstimateRevisionDiff.
+type EstimateRevisionDiff struct {
+	Fields []EstimateFieldChange `json:"fields"`
+
+	// FromRevision 0 when the revision is compared with an empty estimate.
+	FromRevision     int                      `json:"fromRevision"`
+	LineItemsAdded   []EstimateLineItem       `json:"lineItemsAdded"`
+	LineItemsChanged []EstimateLineItemChange `json:"lineItemsChanged"`
+	LineItemsRemoved []EstimateLineItem       `json:"lineItemsRemoved"`
+	ToRevision       int                      `json:"toRevision"`
+}
+
+// EstimateRevisionDiffResponse defines model for EstimateRevisionDiffResponse.
+type EstimateRevisionDiffResponse struct {
+	Diff      EstimateRevisionDiff `json:"diff"`
+	RequestId string               `json:"requestId"`
+}
+
+// EstimateRevisionListResponse defines model for EstimateRevisionListResponse.
+type EstimateRevisionListResponse struct {
+	Items     []EstimateRevisionSummary `json:"items"`
+	RequestId string                    `json:"requestId"`
+}
+
+// EstimateRevisionResponse defines model for EstimateRevisionResponse.
+type EstimateRevisionResponse struct {
+	RequestId string           `json:"requestId"`
+	Revision  EstimateRevision `json:"revision"`
+}
+
+// EstimateRevisionSnapshot defines model for EstimateRevisionSnapshot.
+type EstimateRevisionSnapshot struct {
+	Estimate  Estimate           `json:"estimate"`
+	LineItems []EstimateLineItem `json:"lineItems"`
+}
+
+// EstimateRevisionSummary defines model for EstimateRevisionSummary.
+type EstimateRevisionSummary struct {
+	Change    EstimateRevisionSummaryChange `json:"change"`
+	CreatedAt time.Time                     `json:"createdAt"`
+
+	// CreatedBy Missing for system changes such as expiry, or when the user was deleted.
+	CreatedBy     *openapi_types.UUID `json:"createdBy,omitempty"`
+	CreatedByName *string             `json:"createdByName,omitempty"`
+
+	// RestoredFrom The revision a `restored` revision was copied from.
+	RestoredFrom   *int `json:"restoredFrom,omitempty"`
+	RevisionNumber int  `json:"revisionNumber"`
+}
+
+// EstimateRevisionSummaryChange defines model for EstimateRevisionSummary.Change.
+type EstimateRevisionSummaryChange string
+
+// EstimateSignature defines model for EstimateSignature.
+type EstimateSignature struct {
+	// DocumentSha256 SHA-256 of the accepted revision's snapshot.
+	DocumentSha256 string             `json:"documentSha256"`
+	Id             openapi_types.UUID `json:"id"`
+
+	// LinkId Missing when the link has since been deleted.
+	LinkId *openapi_types.UUID `json:"linkId,omitempty"`
+
+	// RevisionNumber The estimate revision that was accepted.
+	RevisionNumber int       `json:"revisionNumber"`
+	SignedAt       time.Time `json:"signedAt"`
+	SignerIp       string    `json:"signerIp"`
+	SignerName     string    `json:"signerName"`
+	UserAgent      string    `json:"userAgent"`
+
+	// Verified Whether the record and the signed revision are unchanged since signing.
+	Verified bool `json:"verified"`
+}
+
+// EstimateSignatureListResponse defines model for EstimateSignatureListResponse.
+type EstimateSignatureListResponse struct {
+	Items     []EstimateSignature `json:"items"`
+	RequestId string              `json:"requestId"`
 }
 
 // ImportDownloadUrls defines model for ImportDownloadUrls.
@@ -372,8 +1100,34 @@ type ImportUploadRequest struct {
 	Options ImportOptions      `json:"options"`
 }
 
+// InventoryItem defines model for InventoryItem.
+type InventoryItem struct {
+	Active      bool               `json:"active"`
+	CreatedAt   time.Time          `json:"createdAt"`
+	CubicFeet   int                `json:"cubicFeet"`
+	DefaultRoom *string            `json:"defaultRoom,omitempty"`
+	Id          openapi_types.UUID `json:"id"`
+	Name        string             `json:"name"`
+	UpdatedAt   time.Time          `json:"updatedAt"`
+	WeightLbs   int                `json:"weightLbs"`
+}
+
+// InventoryItemListResponse defines model for InventoryItemListResponse.
+type InventoryItemListResponse struct {
+	Items     []InventoryItem `json:"items"`
+	RequestId string          `json:"requestId"`
+}
+
+// InventoryItemResponse defines model for InventoryItemResponse.
+type InventoryItemResponse struct {
+	Item      InventoryItem `json:"item"`
+	RequestId string        `json:"requestId"`
+}
+
 // Job defines model for Job.
 type Job struct {
+	// BalanceCents Move account balance derived from the job ledger; negative means the customer is in credit.
+	BalanceCents  int64               `json:"balanceCents"`
 	CreatedAt     time.Time           `json:"createdAt"`
 	CustomerId    openapi_types.UUID  `json:"customerId"`
 	CustomerName  string              `json:"customerName"`
@@ -381,53 +1135,372 @@ type Job struct {
 	EstimateId    *openapi_types.UUID `json:"estimateId,omitempty"`
 	Id            openapi_types.UUID  `json:"id"`
 	JobNumber     string              `json:"jobNumber"`
+	LastEmail     *EmailMessage       `json:"lastEmail,omitempty"`
+	LastPaymentAt *time.Time          `json:"lastPaymentAt,omitempty"`
 	PickupTime    *string             `json:"pickupTime,omitempty"`
 	PrimaryPhone  string              `json:"primaryPhone"`
 	ScheduledDate *openapi_types.Date `json:"scheduledDate,omitempty"`
 	Status        JobStatus           `json:"status"`
-	TenantId      openapi_types.UUID  `json:"tenantId"`
-	UpdatedAt     time.Time           `json:"updatedAt"`
+
+	// StatusHistory Status changes, oldest first.
+	StatusHistory []JobStatusChange  `json:"statusHistory"`
+	TenantId      openapi_types.UUID `json:"tenantId"`
+	UpdatedAt     time.Time          `json:"updatedAt"`
 }
 
 // JobStatus defines model for Job.Status.
 type JobStatus string
 
+// JobListItem defines model for JobListItem.
+type JobListItem struct {
+	BalanceDueCents  int64               `json:"balanceDueCents"`
+	CreatedAt        time.Time           `json:"createdAt"`
+	CustomerId       openapi_types.UUID  `json:"customerId"`
+	CustomerName     string              `json:"customerName"`
+	DestinationShort string              `json:"destinationShort"`
+	EstimateId       *openapi_types.UUID `json:"estimateId,omitempty"`
+	Id               openapi_types.UUID  `json:"id"`
+	JobNumber        string              `json:"jobNumber"`
+	OriginShort      string              `json:"originShort"`
+	PickupTime       *string             `json:"pickupTime,omitempty"`
+	ScheduledDate    *openapi_types.Date `json:"scheduledDate,omitempty"`
+	Source           JobListItemSource   `json:"source"`
+	Status           JobListItemStatus   `json:"status"`
+	UpdatedAt        time.Time           `json:"updatedAt"`
+}
+
+// JobListItemSource defines model for JobListItem.Source.
+type JobListItemSource string
+
+// JobListItemStatus defines model for JobListItem.Status.
+type JobListItemStatus string
+
+// JobListResponse defines model for JobListResponse.
+type JobListResponse struct {
+	Items      []JobListItem `json:"items"`
+	NextCursor *string       `json:"nextCursor"`
+	RequestId  string        `json:"requestId"`
+}
+
 // JobResponse defines model for JobResponse.
 type JobResponse struct {
 	Job       Job    `json:"job"`
 	RequestId string `json:"requestId"`
 }
 
+// JobStatusChange defines model for JobStatusChange.
+type JobStatusChange struct {
+	ChangedAt     time.Time           `json:"changedAt"`
+	ChangedBy     *openapi_types.UUID `json:"changedBy,omitempty"`
+	ChangedByName *string             `json:"changedByName,omitempty"`
+
+	// FromStatus Absent for the entry written when the job was created.
+	FromStatus *string            `json:"fromStatus,omitempty"`
+	Id         openapi_types.UUID `json:"id"`
+	Reason     *string            `json:"reason,omitempty"`
+	ToStatus   string             `json:"toStatus"`
+}
+
+// LedgerAccount defines model for LedgerAccount.
+type LedgerAccount string
+
+// LedgerEntry defines model for LedgerEntry.
+type LedgerEntry struct {
+	Account     LedgerAccount `json:"account"`
+	AmountCents int64         `json:"amountCents"`
+
+	// BalanceAfterCents Running balance after this entry. Only set in ledger listings.
+	BalanceAfterCents *int64 `json:"balanceAfterCents,omitempty"`
+
+	// BalanceEffectCents Signed change to the balance; payments reduce it.
+	BalanceEffectCents int64               `json:"balanceEffectCents"`
+	CreatedAt          time.Time           `json:"createdAt"`
+	CreatedBy          *openapi_types.UUID `json:"createdBy,omitempty"`
+	Id                 openapi_types.UUID  `json:"id"`
+	JobId              openapi_types.UUID  `json:"jobId"`
+	Kind               LedgerEntryKind     `json:"kind"`
+	Memo               *string             `json:"memo,omitempty"`
+	Method             *PaymentMethod      `json:"method,omitempty"`
+	OccurredAt         time.Time           `json:"occurredAt"`
+	Reference          *string             `json:"reference,omitempty"`
+	StorageRecordId    *openapi_types.UUID `json:"storageRecordId,omitempty"`
+}
+
+// LedgerEntryKind defines model for LedgerEntryKind.
+type LedgerEntryKind string
+
+// LedgerEntryResponse defines model for LedgerEntryResponse.
+type LedgerEntryResponse struct {
+	// BalanceCents Account balance after the entry was posted.
+	BalanceCents int64       `json:"balanceCents"`
+	Entry        LedgerEntry `json:"entry"`
+	RequestId    string      `json:"requestId"`
+}
+
+// LedgerResponse defines model for LedgerResponse.
+type LedgerResponse struct {
+	Account         LedgerAccount       `json:"account"`
+	BalanceCents    int64               `json:"balanceCents"`
+	Entries         []LedgerEntry       `json:"entries"`
+	JobId           openapi_types.UUID  `json:"jobId"`
+	LastPaymentAt   *time.Time          `json:"lastPaymentAt,omitempty"`
+	RequestId       string              `json:"requestId"`
+	StorageRecordId *openapi_types.UUID `json:"storageRecordId,omitempty"`
+}
+
 // LoginRequest defines model for LoginRequest.
 type LoginRequest struct {
 	Email    openapi_types.Email `json:"email"`
 	Password string              `json:"password"`
+
+	// TenantSlug Required when the credentials match accounts in several tenants.
+	TenantSlug *string `json:"tenantSlug,omitempty"`
+}
+
+// MergeCustomerRequest defines model for MergeCustomerRequest.
+type MergeCustomerRequest struct {
+	SourceCustomerId openapi_types.UUID `json:"sourceCustomerId"`
+}
+
+// MergeCustomerResponse defines model for MergeCustomerResponse.
+type MergeCustomerResponse struct {
+	Customer       Customer `json:"customer"`
+	EstimatesMoved int64    `json:"estimatesMoved"`
+	JobsMoved      int64    `json:"jobsMoved"`
+	RequestId      string   `json:"requestId"`
+}
+
+// MfaChallengeResponse defines model for MfaChallengeResponse.
+type MfaChallengeResponse struct {
+	ExpiresAt   time.Time `json:"expiresAt"`
+	MfaRequired bool      `json:"mfaRequired"`
+}
+
+// MfaVerifyRequest defines model for MfaVerifyRequest.
+type MfaVerifyRequest struct {
+	Code         *string `json:"code,omitempty"`
+	RecoveryCode *string `json:"recoveryCode,omitempty"`
+}
+
+// PasswordResetTokenResponse defines model for PasswordResetTokenResponse.
+type PasswordResetTokenResponse struct {
+	ExpiresAt time.Time `json:"expiresAt"`
+	RequestId string    `json:"requestId"`
+	Token     string    `json:"token"`
+}
+
+// PaymentMethod defines model for PaymentMethod.
+type PaymentMethod string
+
+// Permission defines model for Permission.
+type Permission struct {
+	Description string `json:"description"`
+	Name        string `json:"name"`
+}
+
+// PermissionListResponse defines model for PermissionListResponse.
+type PermissionListResponse struct {
+	Items     []Permission `json:"items"`
+	RequestId string       `json:"requestId"`
+}
+
+// PriceAccessorial defines model for PriceAccessorial.
+type PriceAccessorial struct {
+	Code     string `json:"code"`
+	Quantity int    `json:"quantity"`
+}
+
+// PriceEstimateRequest defines model for PriceEstimateRequest.
+type PriceEstimateRequest struct {
+	Accessorials       *[]PriceAccessorial `json:"accessorials,omitempty"`
+	CrewSize           *int                `json:"crewSize,omitempty"`
+	DeclaredValueCents *int64              `json:"declaredValueCents,omitempty"`
+	LaborHours         *float64            `json:"laborHours,omitempty"`
+	Miles              int                 `json:"miles"`
+	ValuationCode      *string             `json:"valuationCode,omitempty"`
+
+	// WeightLbs Overrides the estimate's inventory weight.
+	WeightLbs *int `json:"weightLbs,omitempty"`
+}
+
+// PublicEstimate What the customer sees; no internal notes, ids or contact details beyond their own name.
+type PublicEstimate struct {
+	Acceptance              *PublicEstimateAcceptance `json:"acceptance,omitempty"`
+	CanAccept               bool                      `json:"canAccept"`
+	CompanyName             string                    `json:"companyName"`
+	CustomerName            string                    `json:"customerName"`
+	DepositCents            *int64                    `json:"depositCents,omitempty"`
+	DestinationAddressLine1 string                    `json:"destinationAddressLine1"`
+	DestinationCity         string                    `json:"destinationCity"`
+	DestinationPostalCode   string                    `json:"destinationPostalCode"`
+	DestinationState        string                    `json:"destinationState"`
+	EstimateNumber          string                    `json:"estimateNumber"`
+	EstimatedTotalCents     *int64                    `json:"estimatedTotalCents,omitempty"`
+	LineItems               []PublicEstimateLineItem  `json:"lineItems"`
+	LinkExpiresAt           time.Time                 `json:"linkExpiresAt"`
+	MoveDate                openapi_types.Date        `json:"moveDate"`
+	OriginAddressLine1      string                    `json:"originAddressLine1"`
+	OriginCity              string                    `json:"originCity"`
+	OriginPostalCode        string                    `json:"originPostalCode"`
+	OriginState             string                    `json:"originState"`
+	PickupTime              *string                   `json:"pickupTime,omitempty"`
+	Status                  PublicEstimateStatus      `json:"status"`
+	TermsText               *string                   `json:"termsText,omitempty"`
+
+	// ValidUntil When the estimate itself expires.
+	ValidUntil *time.Time `json:"validUntil,omitempty"`
+}
+
+// PublicEstimateStatus defines model for PublicEstimate.Status.
+type PublicEstimateStatus string
+
+// PublicEstimateAcceptance defines model for PublicEstimateAcceptance.
+type PublicEstimateAcceptance struct {
+	SignedAt   time.Time `json:"signedAt"`
+	SignerName string    `json:"signerName"`
+}
+
+// PublicEstimateLineItem defines model for PublicEstimateLineItem.
+type PublicEstimateLineItem struct {
+	ItemName string `json:"itemName"`
+	Quantity int    `json:"quantity"`
+	Room     string `json:"room"`
+}
+
+// PublicEstimateResponse defines model for PublicEstimateResponse.
+type PublicEstimateResponse struct {
+	// Estimate What the customer sees; no internal notes, ids or contact details beyond their own name.
+	Estimate  PublicEstimate `json:"estimate"`
+	RequestId string         `json:"requestId"`
+}
+
+// RecoveryCodesResponse defines model for RecoveryCodesResponse.
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+// ResetUserPasswordRequest defines model for ResetUserPasswordRequest.
+type ResetUserPasswordRequest struct {
+	Password *string `json:"password,omitempty"`
+}
+
+// RevokeSessionsResponse defines model for RevokeSessionsResponse.
+type RevokeSessionsResponse struct {
+	RequestId    string `json:"requestId"`
+	RevokedCount int64  `json:"revokedCount"`
+}
+
+// Role defines model for Role.
+type Role struct {
+	CreatedAt   time.Time          `json:"createdAt"`
+	Description string             `json:"description"`
+	Id          openapi_types.UUID `json:"id"`
+	Name        string             `json:"name"`
+	Permissions []string           `json:"permissions"`
+	UserCount   int                `json:"userCount"`
+}
+
+// RoleListResponse defines model for RoleListResponse.
+type RoleListResponse struct {
+	Items     []Role `json:"items"`
+	RequestId string `json:"requestId"`
+}
+
+// RoleResponse defines model for RoleResponse.
+type RoleResponse struct {
+	RequestId string `json:"requestId"`
+	Role      Role   `json:"role"`
+}
+
+// SearchHit defines model for SearchHit.
+type SearchHit struct {
+	Id       openapi_types.UUID `json:"id"`
+	Score    float64            `json:"score"`
+	Subtitle string             `json:"subtitle"`
+	Title    string             `json:"title"`
+	Type     SearchHitType      `json:"type"`
+}
+
+// SearchHitType defines model for SearchHit.Type.
+type SearchHitType string
+
+// SearchResponse defines model for SearchResponse.
+type SearchResponse struct {
+	Items     []SearchHit `json:"items"`
+	RequestId string      `json:"requestId"`
+}
+
+// SendEmailRequest defines model for SendEmailRequest.
+type SendEmailRequest struct {
+	// To Recipient instead of the customer email.
+	To *string `json:"to,omitempty"`
+}
+
+// SetUserRolesRequest defines model for SetUserRolesRequest.
+type SetUserRolesRequest struct {
+	RoleIds []openapi_types.UUID `json:"roleIds"`
+}
+
+// SsoGroupRoleMapping defines model for SsoGroupRoleMapping.
+type SsoGroupRoleMapping struct {
+	Group  string             `json:"group"`
+	RoleId openapi_types.UUID `json:"roleId"`
+}
+
+// SsoSettings defines model for SsoSettings.
+type SsoSettings struct {
+	ClientId        string `json:"clientId"`
+	ClientSecretSet bool   `json:"clientSecretSet"`
+
+	// DefaultRoleId Role given to provisioned users whose groups match no mapping.
+	DefaultRoleId     *openapi_types.UUID   `json:"defaultRoleId"`
+	Enabled           bool                  `json:"enabled"`
+	GroupRoleMappings []SsoGroupRoleMapping `json:"groupRoleMappings"`
+	GroupsClaim       string                `json:"groupsClaim"`
+	Issuer            string                `json:"issuer"`
+
+	// JitProvisioning Create users on their first SSO login instead of only linking existing ones.
+	JitProvisioning bool `json:"jitProvisioning"`
+
+	// RedirectUri Callback URL to register with the identity provider.
+	RedirectUri string     `json:"redirectUri"`
+	UpdatedAt   *time.Time `json:"updatedAt"`
+}
+
+// SsoSettingsResponse defines model for SsoSettingsResponse.
+type SsoSettingsResponse struct {
+	RequestId string      `json:"requestId"`
+	Settings  SsoSettings `json:"settings"`
 }
 
 // StorageListItem defines model for StorageListItem.
 type StorageListItem struct {
-	CustomerName        string                 `json:"customerName"`
-	DateIn              *openapi_types.Date    `json:"dateIn"`
-	DateOut             *openapi_types.Date    `json:"dateOut"`
-	Facility            string                 `json:"facility"`
-	FromShort           string                 `json:"fromShort"`
-	Items               int                    `json:"items"`
-	JobId               openapi_types.UUID     `json:"jobId"`
-	JobNumber           string                 `json:"jobNumber"`
-	LocationLabel       *string                `json:"locationLabel"`
-	LotNumber           *string                `json:"lotNumber"`
-	MonthlyRateCents    *int64                 `json:"monthlyRateCents"`
-	MoveBalanceCents    int64                  `json:"moveBalanceCents"`
-	MoveType            *string                `json:"moveType"`
-	NextBillDate        *openapi_types.Date    `json:"nextBillDate"`
-	OversizeItems       int                    `json:"oversizeItems"`
-	Pads                int                    `json:"pads"`
-	Status              *StorageListItemStatus `json:"status"`
-	StorageBalanceCents int64                  `json:"storageBalanceCents"`
-	StorageRecordId     *openapi_types.UUID    `json:"storageRecordId"`
-	ToShort             string                 `json:"toShort"`
-	Vaults              int                    `json:"vaults"`
-	Volume              int                    `json:"volume"`
+	CustomerName     string              `json:"customerName"`
+	DateIn           *openapi_types.Date `json:"dateIn"`
+	DateOut          *openapi_types.Date `json:"dateOut"`
+	Facility         string              `json:"facility"`
+	FromShort        string              `json:"fromShort"`
+	Items            int                 `json:"items"`
+	JobId            openapi_types.UUID  `json:"jobId"`
+	JobNumber        string              `json:"jobNumber"`
+	LocationLabel    *string             `json:"locationLabel"`
+	LotNumber        *string             `json:"lotNumber"`
+	MonthlyRateCents *int64              `json:"monthlyRateCents"`
+
+	// MoveBalanceCents Move account balance of the linked job, derived from the ledger.
+	MoveBalanceCents int64                  `json:"moveBalanceCents"`
+	MoveType         *string                `json:"moveType"`
+	NextBillDate     *openapi_types.Date    `json:"nextBillDate"`
+	OversizeItems    int                    `json:"oversizeItems"`
+	Pads             int                    `json:"pads"`
+	Status           *StorageListItemStatus `json:"status"`
+
+	// StorageBalanceCents Storage account balance derived from the ledger; negative means the customer is in credit.
+	StorageBalanceCents int64               `json:"storageBalanceCents"`
+	StorageRecordId     *openapi_types.UUID `json:"storageRecordId"`
+	ToShort             string              `json:"toShort"`
+	Vaults              int                 `json:"vaults"`
+	Volume              int                 `json:"volume"`
 }
 
 // StorageListItemStatus defines model for StorageListItem.Status.
@@ -442,32 +1515,38 @@ type StorageListResponse struct {
 
 // StorageRecord defines model for StorageRecord.
 type StorageRecord struct {
-	CreatedAt           time.Time           `json:"createdAt"`
-	CustomerName        string              `json:"customerName"`
-	DateIn              *openapi_types.Date `json:"dateIn,omitempty"`
-	DateOut             *openapi_types.Date `json:"dateOut,omitempty"`
-	Facility            string              `json:"facility"`
-	FromShort           string              `json:"fromShort"`
-	Id                  openapi_types.UUID  `json:"id"`
-	Items               int                 `json:"items"`
-	JobId               openapi_types.UUID  `json:"jobId"`
-	JobNumber           string              `json:"jobNumber"`
-	LastPaymentAt       *time.Time          `json:"lastPaymentAt,omitempty"`
-	LocationLabel       *string             `json:"locationLabel,omitempty"`
-	LotNumber           *string             `json:"lotNumber,omitempty"`
-	MonthlyRateCents    *int64              `json:"monthlyRateCents,omitempty"`
-	MoveBalanceCents    int64               `json:"moveBalanceCents"`
-	MoveType            *string             `json:"moveType,omitempty"`
-	NextBillDate        *openapi_types.Date `json:"nextBillDate,omitempty"`
-	Notes               *string             `json:"notes,omitempty"`
-	OversizeItems       int                 `json:"oversizeItems"`
-	Pads                int                 `json:"pads"`
-	Status              StorageStatus       `json:"status"`
-	StorageBalanceCents int64               `json:"storageBalanceCents"`
-	ToShort             string              `json:"toShort"`
-	UpdatedAt           time.Time           `json:"updatedAt"`
-	Vaults              int                 `json:"vaults"`
-	Volume              int                 `json:"volume"`
+	CreatedAt    time.Time           `json:"createdAt"`
+	CustomerName string              `json:"customerName"`
+	DateIn       *openapi_types.Date `json:"dateIn,omitempty"`
+	DateOut      *openapi_types.Date `json:"dateOut,omitempty"`
+	Facility     string              `json:"facility"`
+	FromShort    string              `json:"fromShort"`
+	Id           openapi_types.UUID  `json:"id"`
+	Items        int                 `json:"items"`
+	JobId        openapi_types.UUID  `json:"jobId"`
+	JobNumber    string              `json:"jobNumber"`
+
+	// LastPaymentAt Most recent payment posted to the storage account.
+	LastPaymentAt    *time.Time `json:"lastPaymentAt,omitempty"`
+	LocationLabel    *string    `json:"locationLabel,omitempty"`
+	LotNumber        *string    `json:"lotNumber,omitempty"`
+	MonthlyRateCents *int64     `json:"monthlyRateCents,omitempty"`
+
+	// MoveBalanceCents Move account balance of the linked job, derived from the ledger.
+	MoveBalanceCents int64               `json:"moveBalanceCents"`
+	MoveType         *string             `json:"moveType,omitempty"`
+	NextBillDate     *openapi_types.Date `json:"nextBillDate,omitempty"`
+	Notes            *string             `json:"notes,omitempty"`
+	OversizeItems    int                 `json:"oversizeItems"`
+	Pads             int                 `json:"pads"`
+	Status           StorageStatus       `json:"status"`
+
+	// StorageBalanceCents Storage account balance derived from the ledger; negative means the customer is in credit.
+	StorageBalanceCents int64     `json:"storageBalanceCents"`
+	ToShort             string    `json:"toShort"`
+	UpdatedAt           time.Time `json:"updatedAt"`
+	Vaults              int       `json:"vaults"`
+	Volume              int       `json:"volume"`
 }
 
 // StorageRecordResponse defines model for StorageRecordResponse.
@@ -479,6 +1558,66 @@ type StorageRecordResponse struct {
 // StorageStatus defines model for StorageStatus.
 type StorageStatus string
 
+// SwitchTenantRequest defines model for SwitchTenantRequest.
+type SwitchTenantRequest struct {
+	// Password Password of the account in the target tenant; required unless the session already proved it.
+	Password   *string `json:"password,omitempty"`
+	TenantSlug string  `json:"tenantSlug"`
+}
+
+// Tariff defines model for Tariff.
+type Tariff struct {
+	Accessorials []TariffAccessorial `json:"accessorials"`
+	Configured   bool                `json:"configured"`
+	CrewRates    []TariffCrewRate    `json:"crewRates"`
+
+	// FuelSurchargeBps Fuel surcharge on the labor or linehaul charge, in basis points.
+	FuelSurchargeBps int `json:"fuelSurchargeBps"`
+
+	// LongDistanceThresholdMiles Moves of at least this many miles are priced by weight and distance. Zero prices every move by the hour.
+	LongDistanceThresholdMiles int                     `json:"longDistanceThresholdMiles"`
+	MinimumChargeCents         int64                   `json:"minimumChargeCents"`
+	MinimumHours               int                     `json:"minimumHours"`
+	PerCwtCents                int64                   `json:"perCwtCents"`
+	PerMileCents               int64                   `json:"perMileCents"`
+	UpdatedAt                  *time.Time              `json:"updatedAt"`
+	ValuationOptions           []TariffValuationOption `json:"valuationOptions"`
+}
+
+// TariffAccessorial defines model for TariffAccessorial.
+type TariffAccessorial struct {
+	// Code Lowercase letters, digits and underscores, e.g. `stairs` or `long_carry`.
+	Code      string `json:"code"`
+	Name      string `json:"name"`
+	RateCents int64  `json:"rateCents"`
+
+	// Unit What one unit is, e.g. `flight` or `item`.
+	Unit string `json:"unit"`
+}
+
+// TariffCrewRate defines model for TariffCrewRate.
+type TariffCrewRate struct {
+	CrewSize        int   `json:"crewSize"`
+	HourlyRateCents int64 `json:"hourlyRateCents"`
+}
+
+// TariffResponse defines model for TariffResponse.
+type TariffResponse struct {
+	RequestId string `json:"requestId"`
+	Tariff    Tariff `json:"tariff"`
+}
+
+// TariffValuationOption defines model for TariffValuationOption.
+type TariffValuationOption struct {
+	// CentsPerThousand Charge per started $1,000 of declared value. Zero for released value.
+	CentsPerThousand int64  `json:"centsPerThousand"`
+	Code             string `json:"code"`
+
+	// MinimumCentsPerLb Lowest declared value per pound of shipment weight.
+	MinimumCentsPerLb int64  `json:"minimumCentsPerLb"`
+	Name              string `json:"name"`
+}
+
 // Tenant defines model for Tenant.
 type Tenant struct {
 	Id   openapi_types.UUID `json:"id"`
@@ -486,6 +1625,111 @@ type Tenant struct {
 	Slug string             `json:"slug"`
 }
 
+// TenantSettings defines model for TenantSettings.
+type TenantSettings struct {
+	// EstimateValidityDays Days a sent estimate stays valid before it expires automatically.
+	EstimateValidityDays int `json:"estimateValidityDays"`
+
+	// PasswordCheckBreached Reject new passwords found on the bundled breached-password list.
+	PasswordCheckBreached bool `json:"passwordCheckBreached"`
+
+	// PasswordMinLength Minimum length for new passwords.
+	PasswordMinLength int `json:"passwordMinLength"`
+
+	// RequireMfaForSensitiveRoles Require MFA for users holding `storage.write` or `imports.write`.
+	RequireMfaForSensitiveRoles bool       `json:"requireMfaForSensitiveRoles"`
+	UpdatedAt                   *time.Time `json:"updatedAt"`
+}
+
+// TenantSettingsResponse defines model for TenantSettingsResponse.
+type TenantSettingsResponse struct {
+	RequestId string         `json:"requestId"`
+	Settings  TenantSettings `json:"settings"`
+}
+
+// TenantUser defines model for TenantUser.
+type TenantUser struct {
+	CreatedAt time.Time           `json:"createdAt"`
+	Email     openapi_types.Email `json:"email"`
+	FullName  string              `json:"fullName"`
+	Id        openapi_types.UUID  `json:"id"`
+	IsActive  bool                `json:"isActive"`
+
+	// LockedUntil Set while the account is locked after repeated failed logins.
+	LockedUntil *time.Time `json:"lockedUntil"`
+	Roles       []string   `json:"roles"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+}
+
+// TenantUserListResponse defines model for TenantUserListResponse.
+type TenantUserListResponse struct {
+	Items     []TenantUser `json:"items"`
+	RequestId string       `json:"requestId"`
+}
+
+// TenantUserResponse defines model for TenantUserResponse.
+type TenantUserResponse struct {
+	RequestId string `json:"requestId"`
+
+	// TemporaryPassword Only present when the server generated the password.
+	TemporaryPassword *string    `json:"temporaryPassword,omitempty"`
+	User              TenantUser `json:"user"`
+}
+
+// TotpConfirmRequest defines model for TotpConfirmRequest.
+type TotpConfirmRequest struct {
+	Code string `json:"code"`
+}
+
+// TotpEnrollmentResponse defines model for TotpEnrollmentResponse.
+type TotpEnrollmentResponse struct {
+	// ProvisioningUri otpauth:// URI to render as a QR code.
+	ProvisioningUri string `json:"provisioningUri"`
+
+	// Secret Base32 secret for manual entry.
+	Secret string `json:"secret"`
+}
+
+// UpdateCustomerRequest defines model for UpdateCustomerRequest.
+type UpdateCustomerRequest struct {
+	Email     *openapi_types.Email `json:"email,omitempty"`
+	FirstName *string              `json:"firstName,omitempty"`
+	LastName  *string              `json:"lastName,omitempty"`
+	Phone     *string              `json:"phone,omitempty"`
+}
+
+// UpdateEmailSettingsRequest defines model for UpdateEmailSettingsRequest.
+type UpdateEmailSettingsRequest struct {
+	Enabled     bool                  `json:"enabled"`
+	FromAddress *string               `json:"fromAddress,omitempty"`
+	FromName    *string               `json:"fromName,omitempty"`
+	ReplyTo     *string               `json:"replyTo,omitempty"`
+	Templates   *[]EmailTemplateInput `json:"templates,omitempty"`
+}
+
+// UpdateEstimateDocumentTemplateRequest defines model for UpdateEstimateDocumentTemplateRequest.
+type UpdateEstimateDocumentTemplateRequest struct {
+	AccentColor *string `json:"accentColor,omitempty"`
+	CompanyName *string `json:"companyName,omitempty"`
+	FooterText  *string `json:"footerText,omitempty"`
+	IntroText   *string `json:"introText,omitempty"`
+
+	// Logo Base64 PNG or JPEG logo, at most 512 KB.
+	Logo          *[]byte `json:"logo,omitempty"`
+	RemoveLogo    *bool   `json:"removeLogo,omitempty"`
+	ShowLineItems *bool   `json:"showLineItems,omitempty"`
+	TermsText     *string `json:"termsText,omitempty"`
+}
+
+// UpdateEstimateLineItemRequest defines model for UpdateEstimateLineItemRequest.
+type UpdateEstimateLineItemRequest struct {
+	CubicFeet *int    `json:"cubicFeet,omitempty"`
+	ItemName  *string `json:"itemName,omitempty"`
+	Quantity  *int    `json:"quantity,omitempty"`
+	Room      *string `json:"room,omitempty"`
+	WeightLbs *int    `json:"weightLbs,omitempty"`
+}
+
 // UpdateEstimateRequest defines model for UpdateEstimateRequest.
 type UpdateEstimateRequest struct {
 	CustomerName            *string              `json:"customerName,omitempty"`
@@ -498,47 +1742,115 @@ type UpdateEstimateRequest struct {
 	EstimatedTotalCents     *int64               `json:"estimatedTotalCents,omitempty"`
 	LeadSource              *string              `json:"leadSource,omitempty"`
 	LocationType            *string              `json:"locationType,omitempty"`
-	MoveDate                *openapi_types.Date  `json:"moveDate,omitempty"`
-	MoveSize                *string              `json:"moveSize,omitempty"`
-	Notes                   *string              `json:"notes,omitempty"`
-	OriginAddressLine1      *string              `json:"originAddressLine1,omitempty"`
-	OriginCity              *string              `json:"originCity,omitempty"`
-	OriginPostalCode        *string              `json:"originPostalCode,omitempty"`
-	OriginState             *string              `json:"originState,omitempty"`
-	PickupTime              *string              `json:"pickupTime,omitempty"`
-	PrimaryPhone            *string              `json:"primaryPhone,omitempty"`
-	SecondaryPhone          *string              `json:"secondaryPhone,omitempty"`
+
+	// LostReason Why the quote was lost. Required when declining.
+	LostReason         *string             `json:"lostReason,omitempty"`
+	MoveDate           *openapi_types.Date `json:"moveDate,omitempty"`
+	MoveSize           *string             `json:"moveSize,omitempty"`
+	Notes              *string             `json:"notes,omitempty"`
+	OriginAddressLine1 *string             `json:"originAddressLine1,omitempty"`
+	OriginCity         *string             `json:"originCity,omitempty"`
+	OriginPostalCode   *string             `json:"originPostalCode,omitempty"`
+	OriginState        *string             `json:"originState,omitempty"`
+	PickupTime         *string             `json:"pickupTime,omitempty"`
+	PrimaryPhone       *string             `json:"primaryPhone,omitempty"`
+	SecondaryPhone     *string             `json:"secondaryPhone,omitempty"`
+
+	// Status Target status. Only transitions allowed by the estimate lifecycle are accepted; use the convert endpoint to convert.
+	Status *UpdateEstimateRequestStatus `json:"status,omitempty"`
+}
+
+// UpdateEstimateRequestStatus Target status. Only transitions allowed by the estimate lifecycle are accepted; use the convert endpoint to convert.
+type UpdateEstimateRequestStatus string
+
+// UpdateInventoryItemRequest defines model for UpdateInventoryItemRequest.
+type UpdateInventoryItemRequest struct {
+	Active    *bool `json:"active,omitempty"`
+	CubicFeet *int  `json:"cubicFeet,omitempty"`
+
+	// DefaultRoom An empty string clears the default room.
+	DefaultRoom *string `json:"defaultRoom,omitempty"`
+	Name        *string `json:"name,omitempty"`
+	WeightLbs   *int    `json:"weightLbs,omitempty"`
 }
 
 // UpdateJobRequest defines model for UpdateJobRequest.
 type UpdateJobRequest struct {
-	PickupTime    *string                 `json:"pickupTime,omitempty"`
-	ScheduledDate *openapi_types.Date     `json:"scheduledDate,omitempty"`
-	Status        *UpdateJobRequestStatus `json:"status,omitempty"`
+	PickupTime *string `json:"pickupTime,omitempty"`
+
+	// Reason Why the status changed. Required when cancelling.
+	Reason        *string             `json:"reason,omitempty"`
+	ScheduledDate *openapi_types.Date `json:"scheduledDate,omitempty"`
+
+	// Status Target status. Only transitions allowed by the job lifecycle are accepted.
+	Status *UpdateJobRequestStatus `json:"status,omitempty"`
 }
 
-// UpdateJobRequestStatus defines model for UpdateJobRequest.Status.
+// UpdateJobRequestStatus Target status. Only transitions allowed by the job lifecycle are accepted.
 type UpdateJobRequestStatus string
 
-// UpdateStorageRecordRequest defines model for UpdateStorageRecordRequest.
+// UpdateRoleRequest defines model for UpdateRoleRequest.
+type UpdateRoleRequest struct {
+	Description *string   `json:"description,omitempty"`
+	Name        *string   `json:"name,omitempty"`
+	Permissions *[]string `json:"permissions,omitempty"`
+}
+
+// UpdateSsoSettingsRequest defines model for UpdateSsoSettingsRequest.
+type UpdateSsoSettingsRequest struct {
+	ClientId          string                 `json:"clientId"`
+	ClientSecret      *string                `json:"clientSecret,omitempty"`
+	DefaultRoleId     *openapi_types.UUID    `json:"defaultRoleId"`
+	Enabled           bool                   `json:"enabled"`
+	GroupRoleMappings *[]SsoGroupRoleMapping `json:"groupRoleMappings,omitempty"`
+	GroupsClaim       *string                `json:"groupsClaim,omitempty"`
+	Issuer            string                 `json:"issuer"`
+	JitProvisioning   *bool                  `json:"jitProvisioning,omitempty"`
+}
+
+// UpdateStorageRecordRequest Balances are not accepted here; post ledger entries to change them.
 type UpdateStorageRecordRequest struct {
-	DateIn              *openapi_types.Date `json:"dateIn,omitempty"`
-	DateOut             *openapi_types.Date `json:"dateOut,omitempty"`
-	Facility            string              `json:"facility"`
-	Items               int                 `json:"items"`
-	LastPaymentAt       *time.Time          `json:"lastPaymentAt,omitempty"`
-	LocationLabel       *string             `json:"locationLabel,omitempty"`
-	LotNumber           *string             `json:"lotNumber,omitempty"`
-	MonthlyRateCents    *int64              `json:"monthlyRateCents,omitempty"`
-	MoveBalanceCents    int64               `json:"moveBalanceCents"`
-	NextBillDate        *openapi_types.Date `json:"nextBillDate,omitempty"`
-	Notes               *string             `json:"notes,omitempty"`
-	OversizeItems       int                 `json:"oversizeItems"`
-	Pads                int                 `json:"pads"`
-	Status              StorageStatus       `json:"status"`
-	StorageBalanceCents int64               `json:"storageBalanceCents"`
-	Vaults              int                 `json:"vaults"`
-	Volume              int                 `json:"volume"`
+	DateIn           *openapi_types.Date `json:"dateIn,omitempty"`
+	DateOut          *openapi_types.Date `json:"dateOut,omitempty"`
+	Facility         string              `json:"facility"`
+	Items            int                 `json:"items"`
+	LocationLabel    *string             `json:"locationLabel,omitempty"`
+	LotNumber        *string             `json:"lotNumber,omitempty"`
+	MonthlyRateCents *int64              `json:"monthlyRateCents,omitempty"`
+	NextBillDate     *openapi_types.Date `json:"nextBillDate,omitempty"`
+	Notes            *string             `json:"notes,omitempty"`
+	OversizeItems    int                 `json:"oversizeItems"`
+	Pads             int                 `json:"pads"`
+	Status           StorageStatus       `json:"status"`
+	Vaults           int                 `json:"vaults"`
+	Volume           int                 `json:"volume"`
+}
+
+// UpdateTariffRequest defines model for UpdateTariffRequest.
+type UpdateTariffRequest struct {
+	Accessorials               []TariffAccessorial     `json:"accessorials"`
+	CrewRates                  []TariffCrewRate        `json:"crewRates"`
+	FuelSurchargeBps           int                     `json:"fuelSurchargeBps"`
+	LongDistanceThresholdMiles int                     `json:"longDistanceThresholdMiles"`
+	MinimumChargeCents         int64                   `json:"minimumChargeCents"`
+	MinimumHours               int                     `json:"minimumHours"`
+	PerCwtCents                int64                   `json:"perCwtCents"`
+	PerMileCents               int64                   `json:"perMileCents"`
+	ValuationOptions           []TariffValuationOption `json:"valuationOptions"`
+}
+
+// UpdateTenantSettingsRequest defines model for UpdateTenantSettingsRequest.
+type UpdateTenantSettingsRequest struct {
+	EstimateValidityDays        *int  `json:"estimateValidityDays,omitempty"`
+	PasswordCheckBreached       *bool `json:"passwordCheckBreached,omitempty"`
+	PasswordMinLength           *int  `json:"passwordMinLength,omitempty"`
+	RequireMfaForSensitiveRoles *bool `json:"requireMfaForSensitiveRoles,omitempty"`
+}
+
+// UpdateTenantUserRequest defines model for UpdateTenantUserRequest.
+type UpdateTenantUserRequest struct {
+	FullName *string `json:"fullName,omitempty"`
+	IsActive *bool   `json:"isActive,omitempty"`
 }
 
 // User defines model for User.
@@ -548,12 +1860,42 @@ type User struct {
 	Id       openapi_types.UUID  `json:"id"`
 }
 
+// UserSession defines model for UserSession.
+type UserSession struct {
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Current True for the session making this request.
+	Current    bool               `json:"current"`
+	ExpiresAt  time.Time          `json:"expiresAt"`
+	Id         openapi_types.UUID `json:"id"`
+	IpAddress  string             `json:"ipAddress"`
+	LastSeenAt *time.Time         `json:"lastSeenAt"`
+	UserAgent  string             `json:"userAgent"`
+}
+
+// UserSessionListResponse defines model for UserSessionListResponse.
+type UserSessionListResponse struct {
+	Items     []UserSession `json:"items"`
+	RequestId string        `json:"requestId"`
+}
+
+// AcceptanceToken defines model for AcceptanceToken.
+type AcceptanceToken = string
+
 // IdempotencyKey defines model for IdempotencyKey.
 type IdempotencyKey = string
 
 // ErrorResponse defines model for ErrorResponse.
 type ErrorResponse = ErrorEnvelope
 
+// GetAuthOidcCallbackParams defines parameters for GetAuthOidcCallback.
+type GetAuthOidcCallbackParams struct {
+	Code             *string `form:"code,omitempty" json:"code,omitempty"`
+	State            *string `form:"state,omitempty" json:"state,omitempty"`
+	Error            *string `form:"error,omitempty" json:"error,omitempty"`
+	ErrorDescription *string `form:"error_description,omitempty" json:"error_description,omitempty"`
+}
+
 // GetCalendarParams defines parameters for GetCalendar.
 type GetCalendarParams struct {
 	From    openapi_types.Date        `form:"from" json:"from"`
@@ -574,6 +1916,30 @@ type GetCalendarParamsPhase string
 // GetCalendarParamsJobType defines parameters for GetCalendar.
 type GetCalendarParamsJobType string
 
+// GetCustomersParams defines parameters for GetCustomers.
+type GetCustomersParams struct {
+	Q      *string `form:"q,omitempty" json:"q,omitempty"`
+	Limit  *int    `form:"limit,omitempty" json:"limit,omitempty"`
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetEstimatesParams defines parameters for GetEstimates.
+type GetEstimatesParams struct {
+	Q            *string                   `form:"q,omitempty" json:"q,omitempty"`
+	Status       *GetEstimatesParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+	LeadSource   *string                   `form:"leadSource,omitempty" json:"leadSource,omitempty"`
+	MoveDateFrom *openapi_types.Date       `form:"moveDateFrom,omitempty" json:"moveDateFrom,omitempty"`
+	MoveDateTo   *openapi_types.Date       `form:"moveDateTo,omitempty" json:"moveDateTo,omitempty"`
+	CreatedFrom  *time.Time                `form:"createdFrom,omitempty" json:"createdFrom,omitempty"`
+	CreatedTo    *time.Time                `form:"createdTo,omitempty" json:"createdTo,omitempty"`
+	CreatedBy    *openapi_types.UUID       `form:"createdBy,omitempty" json:"createdBy,omitempty"`
+	Limit        *int                      `form:"limit,omitempty" json:"limit,omitempty"`
+	Cursor       *string                   `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetEstimatesParamsStatus defines parameters for GetEstimates.
+type GetEstimatesParamsStatus string
+
 // PostEstimatesParams defines parameters for PostEstimates.
 type PostEstimatesParams struct {
 	IdempotencyKey IdempotencyKey `json:"Idempotency-Key"`
@@ -584,6 +1950,44 @@ type PostEstimatesEstimateIdConvertParams struct {
 	IdempotencyKey IdempotencyKey `json:"Idempotency-Key"`
 }
 
+// GetEstimatesEstimateIdRevisionsRevisionNumberDiffParams defines parameters for GetEstimatesEstimateIdRevisionsRevisionNumberDiff.
+type GetEstimatesEstimateIdRevisionsRevisionNumberDiffParams struct {
+	Against *int `form:"against,omitempty" json:"against,omitempty"`
+}
+
+// GetInventoryItemsParams defines parameters for GetInventoryItems.
+type GetInventoryItemsParams struct {
+	IncludeInactive *bool `form:"includeInactive,omitempty" json:"includeInactive,omitempty"`
+}
+
+// GetJobsParams defines parameters for GetJobs.
+type GetJobsParams struct {
+	Status     *GetJobsParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+	Scheduled  *bool                `form:"scheduled,omitempty" json:"scheduled,omitempty"`
+	CustomerId *openapi_types.UUID  `form:"customerId,omitempty" json:"customerId,omitempty"`
+	Source     *GetJobsParamsSource `form:"source,omitempty" json:"source,omitempty"`
+	BalanceDue *bool                `form:"balanceDue,omitempty" json:"balanceDue,omitempty"`
+	Limit      *int                 `form:"limit,omitempty" json:"limit,omitempty"`
+	Cursor     *string              `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetJobsParamsStatus defines parameters for GetJobs.
+type GetJobsParamsStatus string
+
+// GetJobsParamsSource defines parameters for GetJobs.
+type GetJobsParamsSource string
+
+// PostJobsJobIdLedgerParams defines parameters for PostJobsJobIdLedger.
+type PostJobsJobIdLedgerParams struct {
+	IdempotencyKey IdempotencyKey `json:"Idempotency-Key"`
+}
+
+// GetSearchParams defines parameters for GetSearch.
+type GetSearchParams struct {
+	Q     string `form:"q" json:"q"`
+	Limit *int   `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
 // GetStorageParams defines parameters for GetStorage.
 type GetStorageParams struct {
 	Facility      string         `form:"facility" json:"facility"`
@@ -597,33 +2001,131 @@ type GetStorageParams struct {
 	Cursor        *string        `form:"cursor,omitempty" json:"cursor,omitempty"`
 }
 
+// PostStorageStorageRecordIdLedgerParams defines parameters for PostStorageStorageRecordIdLedger.
+type PostStorageStorageRecordIdLedgerParams struct {
+	IdempotencyKey IdempotencyKey `json:"Idempotency-Key"`
+}
+
+// PostAuthApiTokensJSONRequestBody defines body for PostAuthApiTokens for application/json ContentType.
+type PostAuthApiTokensJSONRequestBody = CreateApiTokenRequest
+
 // PostAuthLoginJSONRequestBody defines body for PostAuthLogin for application/json ContentType.
 type PostAuthLoginJSONRequestBody = LoginRequest
 
+// PostAuthMfaTotpConfirmJSONRequestBody defines body for PostAuthMfaTotpConfirm for application/json ContentType.
+type PostAuthMfaTotpConfirmJSONRequestBody = TotpConfirmRequest
+
+// PostAuthMfaVerifyJSONRequestBody defines body for PostAuthMfaVerify for application/json ContentType.
+type PostAuthMfaVerifyJSONRequestBody = MfaVerifyRequest
+
+// PostAuthPasswordJSONRequestBody defines body for PostAuthPassword for application/json ContentType.
+type PostAuthPasswordJSONRequestBody = ChangePasswordRequest
+
+// PostAuthPasswordResetJSONRequestBody defines body for PostAuthPasswordReset for application/json ContentType.
+type PostAuthPasswordResetJSONRequestBody = CompletePasswordResetRequest
+
+// PostAuthSwitchTenantJSONRequestBody defines body for PostAuthSwitchTenant for application/json ContentType.
+type PostAuthSwitchTenantJSONRequestBody = SwitchTenantRequest
+
 // PostCustomersJSONRequestBody defines body for PostCustomers for application/json ContentType.
 type PostCustomersJSONRequestBody = CreateCustomerRequest
 
+// PatchCustomersCustomerIdJSONRequestBody defines body for PatchCustomersCustomerId for application/json ContentType.
+type PatchCustomersCustomerIdJSONRequestBody = UpdateCustomerRequest
+
+// PostCustomersCustomerIdMergeJSONRequestBody defines body for PostCustomersCustomerIdMerge for application/json ContentType.
+type PostCustomersCustomerIdMergeJSONRequestBody = MergeCustomerRequest
+
 // PostEstimatesJSONRequestBody defines body for PostEstimates for application/json ContentType.
 type PostEstimatesJSONRequestBody = CreateEstimateRequest
 
 // PatchEstimatesEstimateIdJSONRequestBody defines body for PatchEstimatesEstimateId for application/json ContentType.
 type PatchEstimatesEstimateIdJSONRequestBody = UpdateEstimateRequest
 
+// PostEstimatesEstimateIdAcceptanceLinksJSONRequestBody defines body for PostEstimatesEstimateIdAcceptanceLinks for application/json ContentType.
+type PostEstimatesEstimateIdAcceptanceLinksJSONRequestBody = CreateAcceptanceLinkRequest
+
+// PostEstimatesEstimateIdEmailsJSONRequestBody defines body for PostEstimatesEstimateIdEmails for application/json ContentType.
+type PostEstimatesEstimateIdEmailsJSONRequestBody = SendEmailRequest
+
+// PostEstimatesEstimateIdLineItemsJSONRequestBody defines body for PostEstimatesEstimateIdLineItems for application/json ContentType.
+type PostEstimatesEstimateIdLineItemsJSONRequestBody = CreateEstimateLineItemRequest
+
+// PatchEstimatesEstimateIdLineItemsLineItemIdJSONRequestBody defines body for PatchEstimatesEstimateIdLineItemsLineItemId for application/json ContentType.
+type PatchEstimatesEstimateIdLineItemsLineItemIdJSONRequestBody = UpdateEstimateLineItemRequest
+
+// PostEstimatesEstimateIdPriceJSONRequestBody defines body for PostEstimatesEstimateIdPrice for application/json ContentType.
+type PostEstimatesEstimateIdPriceJSONRequestBody = PriceEstimateRequest
+
 // PostImportsApplyMultipartRequestBody defines body for PostImportsApply for multipart/form-data ContentType.
 type PostImportsApplyMultipartRequestBody = ImportUploadRequest
 
 // PostImportsDryRunMultipartRequestBody defines body for PostImportsDryRun for multipart/form-data ContentType.
 type PostImportsDryRunMultipartRequestBody = ImportUploadRequest
 
+// PostInventoryItemsJSONRequestBody defines body for PostInventoryItems for application/json ContentType.
+type PostInventoryItemsJSONRequestBody = CreateInventoryItemRequest
+
+// PatchInventoryItemsItemIdJSONRequestBody defines body for PatchInventoryItemsItemId for application/json ContentType.
+type PatchInventoryItemsItemIdJSONRequestBody = UpdateInventoryItemRequest
+
 // PatchJobsJobIdJSONRequestBody defines body for PatchJobsJobId for application/json ContentType.
 type PatchJobsJobIdJSONRequestBody = UpdateJobRequest
 
+// PostJobsJobIdEmailsJSONRequestBody defines body for PostJobsJobIdEmails for application/json ContentType.
+type PostJobsJobIdEmailsJSONRequestBody = SendEmailRequest
+
+// PostJobsJobIdLedgerJSONRequestBody defines body for PostJobsJobIdLedger for application/json ContentType.
+type PostJobsJobIdLedgerJSONRequestBody = CreateLedgerEntryRequest
+
 // PostJobsJobIdStorageJSONRequestBody defines body for PostJobsJobIdStorage for application/json ContentType.
 type PostJobsJobIdStorageJSONRequestBody = CreateStorageRecordRequest
 
+// PostPublicEstimateAcceptanceTokenAcceptJSONRequestBody defines body for PostPublicEstimateAcceptanceTokenAccept for application/json ContentType.
+type PostPublicEstimateAcceptanceTokenAcceptJSONRequestBody = AcceptEstimateRequest
+
+// PostRolesJSONRequestBody defines body for PostRoles for application/json ContentType.
+type PostRolesJSONRequestBody = CreateRoleRequest
+
+// PatchRolesRoleIdJSONRequestBody defines body for PatchRolesRoleId for application/json ContentType.
+type PatchRolesRoleIdJSONRequestBody = UpdateRoleRequest
+
+// PatchSettingsJSONRequestBody defines body for PatchSettings for application/json ContentType.
+type PatchSettingsJSONRequestBody = UpdateTenantSettingsRequest
+
+// PutSettingsEmailJSONRequestBody defines body for PutSettingsEmail for application/json ContentType.
+type PutSettingsEmailJSONRequestBody = UpdateEmailSettingsRequest
+
+// PutSettingsEstimateDocumentJSONRequestBody defines body for PutSettingsEstimateDocument for application/json ContentType.
+type PutSettingsEstimateDocumentJSONRequestBody = UpdateEstimateDocumentTemplateRequest
+
+// PutSettingsSsoJSONRequestBody defines body for PutSettingsSso for application/json ContentType.
+type PutSettingsSsoJSONRequestBody = UpdateSsoSettingsRequest
+
+// PutSettingsTariffJSONRequestBody defines body for PutSettingsTariff for application/json ContentType.
+type PutSettingsTariffJSONRequestBody = UpdateTariffRequest
+
 // PutStorageStorageRecordIdJSONRequestBody defines body for PutStorageStorageRecordId for application/json ContentType.
 type PutStorageStorageRecordIdJSONRequestBody = UpdateStorageRecordRequest
 
+// PostStorageStorageRecordIdEmailsJSONRequestBody defines body for PostStorageStorageRecordIdEmails for application/json ContentType.
+type PostStorageStorageRecordIdEmailsJSONRequestBody = SendEmailRequest
+
+// PostStorageStorageRecordIdLedgerJSONRequestBody defines body for PostStorageStorageRecordIdLedger for application/json ContentType.
+type PostStorageStorageRecordIdLedgerJSONRequestBody = CreateLedgerEntryRequest
+
+// PostUsersJSONRequestBody defines body for PostUsers for application/json ContentType.
+type PostUsersJSONRequestBody = CreateTenantUserRequest
+
+// PatchUsersUserIdJSONRequestBody defines body for PatchUsersUserId for application/json ContentType.
+type PatchUsersUserIdJSONRequestBody = UpdateTenantUserRequest
+
+// PostUsersUserIdPasswordResetJSONRequestBody defines body for PostUsersUserIdPasswordReset for application/json ContentType.
+type PostUsersUserIdPasswordResetJSONRequestBody = ResetUserPasswordRequest
+
+// PutUsersUserIdRolesJSONRequestBody defines body for PutUsersUserIdRoles for application/json ContentType.
+type PutUsersUserIdRolesJSONRequestBody = SetUserRolesRequest
+
 // AsImportOptionsMapping0 returns the union data inside the ImportOptions_Mapping_AdditionalProperties as a ImportOptionsMapping0
 func (t ImportOptions_Mapping_AdditionalProperties) AsImportOptionsMapping0() (ImportOptionsMapping0, error) {
 	var body ImportOptionsMapping0
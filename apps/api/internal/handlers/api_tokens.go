@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moveops-platform/apps/api/internal/audit"
+	"github.com/moveops-platform/apps/api/internal/auth"
+	gen "github.com/moveops-platform/apps/api/internal/gen/db"
+	"github.com/moveops-platform/apps/api/internal/gen/oapi"
+	"github.com/moveops-platform/apps/api/internal/httpx"
+	"github.com/moveops-platform/apps/api/internal/middleware"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// apiTokenPrefixLength is how much of the token is kept in clear text so
+// users can tell their tokens apart.
+const apiTokenPrefixLength = 12
+
+func (s *Server) GetAuthApiTokens(w http.ResponseWriter, r *http.Request) {
+	_, tenantID, userID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	rows, err := s.Q.ListAPITokens(r.Context(), gen.ListAPITokensParams{TenantID: tenantID, UserID: userID})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load API tokens", nil)
+		return
+	}
+
+	items := make([]oapi.ApiToken, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, mapAPIToken(gen.CreateAPITokenRow(row)))
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, oapi.ApiTokenListResponse{
+		Items:     items,
+		RequestId: middleware.RequestIDFromContext(r.Context()),
+	})
+}
+
+func (s *Server) PostAuthApiTokens(w http.ResponseWriter, r *http.Request) {
+	actor, tenantID, userID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req oapi.CreateApiTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "invalid_body", "Malformed JSON body", nil)
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "name is required", nil)
+		return
+	}
+	scopes := normalizePermissionNames(req.Scopes)
+	if len(scopes) == 0 {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "At least one scope is required", nil)
+		return
+	}
+	// A token can never grant more than its creator holds right now.
+	var missing []string
+	for _, scope := range scopes {
+		if !actor.Permissions.Has(scope) {
+			missing = append(missing, scope)
+		}
+	}
+	if len(missing) > 0 {
+		httpx.WriteError(w, r, http.StatusBadRequest, "invalid_scopes", "Scopes must be permissions you hold", map[string]any{"scopes": missing})
+		return
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "expiresAt must be in the future", nil)
+		return
+	}
+
+	random, err := auth.GenerateToken()
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to create API token", nil)
+		return
+	}
+	secret := middleware.APITokenPrefix + random
+
+	row, err := s.Q.CreateAPIToken(r.Context(), gen.CreateAPITokenParams{
+		TenantID:    tenantID,
+		UserID:      userID,
+		Name:        name,
+		TokenHash:   auth.HashToken(secret),
+		TokenPrefix: secret[:apiTokenPrefixLength],
+		Scopes:      scopes,
+		ExpiresAt:   req.ExpiresAt,
+	})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to save API token", nil)
+		return
+	}
+
+	tokenID := row.ID
+	_ = s.Audit.Log(r.Context(), audit.Entry{
+		TenantID:   tenantID,
+		UserID:     &userID,
+		Action:     "api_tokens.create",
+		EntityType: "api_token",
+		EntityID:   &tokenID,
+		RequestID:  middleware.RequestIDFromContext(r.Context()),
+		Metadata: map[string]any{
+			"name":      name,
+			"scopes":    scopes,
+			"expiresAt": req.ExpiresAt,
+		},
+	})
+
+	httpx.WriteJSON(w, http.StatusCreated, oapi.CreateApiTokenResponse{
+		Token:     mapAPIToken(row),
+		Secret:    secret,
+		RequestId: middleware.RequestIDFromContext(r.Context()),
+	})
+}
+
+func (s *Server) DeleteAuthApiTokensTokenId(w http.ResponseWriter, r *http.Request, tokenId openapi_types.UUID) {
+	_, tenantID, userID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	tokenID := uuid.UUID(tokenId)
+	affected, err := s.Q.RevokeAPIToken(r.Context(), gen.RevokeAPITokenParams{ID: tokenID, TenantID: tenantID, UserID: userID})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to revoke API token", nil)
+		return
+	}
+	if affected == 0 {
+		httpx.WriteError(w, r, http.StatusNotFound, "api_token_not_found", "API token was not found", nil)
+		return
+	}
+
+	_ = s.Audit.Log(r.Context(), audit.Entry{
+		TenantID:   tenantID,
+		UserID:     &userID,
+		Action:     "api_tokens.revoke",
+		EntityType: "api_token",
+		EntityID:   &tokenID,
+		RequestID:  middleware.RequestIDFromContext(r.Context()),
+	})
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func mapAPIToken(row gen.CreateAPITokenRow) oapi.ApiToken {
+	return oapi.ApiToken{
+		Id:         row.ID,
+		Name:       row.Name,
+		Prefix:     row.TokenPrefix,
+		Scopes:     row.Scopes,
+		ExpiresAt:  row.ExpiresAt,
+		LastUsedAt: row.LastUsedAt,
+		LastUsedIp: row.LastUsedIp,
+		CreatedAt:  row.CreatedAt,
+	}
+}
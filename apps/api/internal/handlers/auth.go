@@ -0,0 +1,429 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/moveops-platform/apps/api/internal/audit"
+	"github.com/moveops-platform/apps/api/internal/auth"
+	gen "github.com/moveops-platform/apps/api/internal/gen/db"
+	"github.com/moveops-platform/apps/api/internal/gen/oapi"
+	"github.com/moveops-platform/apps/api/internal/httpx"
+	"github.com/moveops-platform/apps/api/internal/middleware"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// sessionPrincipal is the user/tenant pair a new session is issued for.
+type sessionPrincipal struct {
+	UserID     uuid.UUID
+	TenantID   uuid.UUID
+	Email      string
+	FullName   string
+	TenantSlug string
+	TenantName string
+	// LinkedUserIDs are the accounts with the same email whose password the
+	// holder proved while signing in; tenant switches between them need no
+	// password.
+	LinkedUserIDs []uuid.UUID
+}
+
+func sessionPrincipalFromLoginRow(row gen.ListUsersByEmailRow) sessionPrincipal {
+	return sessionPrincipal{
+		UserID:     row.ID,
+		TenantID:   row.TenantID,
+		Email:      row.Email,
+		FullName:   row.FullName,
+		TenantSlug: row.TenantSlug,
+		TenantName: row.TenantName,
+	}
+}
+
+// mfaChallengeTTL bounds how long a half-authenticated session waits for the
+// second factor.
+const mfaChallengeTTL = 5 * time.Minute
+
+// maxSessionUserAgentLength caps the user agent stored for the session list.
+const maxSessionUserAgentLength = 512
+
+// startSession revokes the session carried by the request cookie, if any,
+// creates a new session for p and sets the session cookie. On failure it has
+// already written the error response.
+func (s *Server) startSession(w http.ResponseWriter, r *http.Request, p sessionPrincipal) (oapi.AuthSessionResponse, bool) {
+	permissions, err := s.Q.ListUserPermissions(r.Context(), gen.ListUserPermissionsParams{
+		UserID:   p.UserID,
+		TenantID: p.TenantID,
+	})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load permissions", nil)
+		return oapi.AuthSessionResponse{}, false
+	}
+	mfaState, err := s.Q.GetLoginMFAState(r.Context(), gen.GetLoginMFAStateParams{UserID: p.UserID, TenantID: p.TenantID})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load MFA state", nil)
+		return oapi.AuthSessionResponse{}, false
+	}
+
+	if _, ok := s.issueSession(w, r, p, false); !ok {
+		return oapi.AuthSessionResponse{}, false
+	}
+
+	permissionSet := middleware.NewPermissionSet(permissions)
+	return oapi.AuthSessionResponse{
+		User: oapi.User{
+			Id:       p.UserID,
+			Email:    openapi_types.Email(p.Email),
+			FullName: p.FullName,
+		},
+		Tenant: oapi.Tenant{
+			Id:   p.TenantID,
+			Slug: p.TenantSlug,
+			Name: p.TenantName,
+		},
+		Permissions:           permissionSet.Names(),
+		MfaEnabled:            mfaState.MfaEnabled,
+		MfaEnrollmentRequired: middleware.MFAEnrollmentRequired(mfaState.RequireMfaForSensitiveRoles, mfaState.MfaEnabled, permissionSet),
+	}, true
+}
+
+// startMFAChallenge issues a half-authenticated session for p that only
+// POST /auth/mfa/verify accepts, and writes the 202 challenge response.
+func (s *Server) startMFAChallenge(w http.ResponseWriter, r *http.Request, p sessionPrincipal) bool {
+	expiresAt, ok := s.issueSession(w, r, p, true)
+	if !ok {
+		return false
+	}
+	httpx.WriteJSON(w, http.StatusAccepted, oapi.MfaChallengeResponse{
+		MfaRequired: true,
+		ExpiresAt:   expiresAt.UTC(),
+	})
+	return true
+}
+
+func (s *Server) issueSession(w http.ResponseWriter, r *http.Request, p sessionPrincipal, mfaPending bool) (time.Time, bool) {
+	if old, err := r.Cookie(s.Config.SessionCookieName); err == nil && old.Value != "" {
+		_, _ = s.Q.RevokeSessionByTokenHash(r.Context(), auth.HashToken(old.Value))
+	}
+
+	sessionToken, err := auth.GenerateToken()
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to create session", nil)
+		return time.Time{}, false
+	}
+	csrfToken, err := auth.GenerateToken()
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to create CSRF token", nil)
+		return time.Time{}, false
+	}
+
+	now := time.Now()
+	absoluteExpiresAt := now.Add(s.Config.SessionTTL)
+	expiresAt := auth.SessionIdleExpiry(now, s.Config.SessionIdleTimeout, absoluteExpiresAt)
+	if mfaPending {
+		absoluteExpiresAt = now.Add(mfaChallengeTTL)
+		expiresAt = absoluteExpiresAt
+	}
+
+	_, err = s.Q.CreateSession(r.Context(), gen.CreateSessionParams{
+		TenantID:          p.TenantID,
+		UserID:            p.UserID,
+		TokenHash:         auth.HashToken(sessionToken),
+		CsrfToken:         csrfToken,
+		ExpiresAt:         expiresAt,
+		AbsoluteExpiresAt: absoluteExpiresAt,
+		MfaPending:        mfaPending,
+		UserAgent:         strings.ToValidUTF8(truncateText(r.UserAgent(), maxSessionUserAgentLength), ""),
+		IpAddress:         middleware.ClientIP(r),
+		LinkedUserIds:     linkedUserIDs(p.UserID, p.LinkedUserIDs),
+	})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to save session", nil)
+		return time.Time{}, false
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     s.Config.SessionCookieName,
+		Value:    sessionToken,
+		Path:     "/",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+		Secure:   s.Config.SecureCookies,
+		// The browser keeps the cookie for the absolute lifetime; the idle
+		// timeout is enforced server-side.
+		Expires: absoluteExpiresAt,
+	})
+	return expiresAt, true
+}
+
+// linkedUserIDs returns ids with userID added once.
+func linkedUserIDs(userID uuid.UUID, ids []uuid.UUID) []uuid.UUID {
+	out := make([]uuid.UUID, 0, len(ids)+1)
+	out = append(out, userID)
+	for _, id := range ids {
+		if !slices.Contains(out, id) {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// sessionLinkedUserIDs loads the accounts the actor's session has proven,
+// which always include the actor's own.
+func (s *Server) sessionLinkedUserIDs(ctx context.Context, actor middleware.Actor, tenantID, userID uuid.UUID) ([]uuid.UUID, error) {
+	sessionID, err := uuid.Parse(actor.SessionID)
+	if err != nil {
+		return []uuid.UUID{userID}, nil
+	}
+	ids, err := s.Q.GetSessionLinkedUserIDs(ctx, gen.GetSessionLinkedUserIDsParams{ID: sessionID, TenantID: tenantID})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+	return linkedUserIDs(userID, ids), nil
+}
+
+func (s *Server) GetAuthTenants(w http.ResponseWriter, r *http.Request) {
+	actor, tenantID, userID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	users, err := s.Q.ListUsersByEmail(r.Context(), actor.Email)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load tenants", nil)
+		return
+	}
+	linked, err := s.sessionLinkedUserIDs(r.Context(), actor, tenantID, userID)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load tenants", nil)
+		return
+	}
+
+	// An account that merely shares the email is not listed: anyone able to
+	// create users in some tenant could otherwise enumerate memberships.
+	items := make([]oapi.Tenant, 0, len(users))
+	for _, user := range users {
+		if !user.IsActive || !slices.Contains(linked, user.ID) {
+			continue
+		}
+		items = append(items, oapi.Tenant{Id: user.TenantID, Slug: user.TenantSlug, Name: user.TenantName})
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, oapi.AuthTenantListResponse{
+		Items:           items,
+		CurrentTenantId: tenantID,
+	})
+}
+
+func (s *Server) PostAuthSwitchTenant(w http.ResponseWriter, r *http.Request) {
+	actor, tenantID, userID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+	if actor.APITokenID != "" {
+		httpx.WriteError(w, r, http.StatusUnauthorized, "api_token_not_allowed", "API tokens cannot be used for this endpoint", nil)
+		return
+	}
+
+	var req oapi.SwitchTenantRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "invalid_body", "Malformed JSON body", nil)
+		return
+	}
+	tenantSlug := strings.TrimSpace(req.TenantSlug)
+	if tenantSlug == "" {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "tenantSlug is required", nil)
+		return
+	}
+	if strings.EqualFold(tenantSlug, actor.TenantSlug) {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "Session is already signed in to this tenant", nil)
+		return
+	}
+
+	users, err := s.Q.ListUsersByEmail(r.Context(), actor.Email)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load user", nil)
+		return
+	}
+
+	// The email only finds the target account. Anyone who can create users in
+	// some tenant controls an account with any email, so the target must have
+	// been proven by this session or by its own password.
+	var target *gen.ListUsersByEmailRow
+	for i := range users {
+		if users[i].IsActive && strings.EqualFold(users[i].TenantSlug, tenantSlug) {
+			target = &users[i]
+			break
+		}
+	}
+	if target == nil {
+		httpx.WriteError(w, r, http.StatusNotFound, "tenant_not_found", "No active account for this email in that tenant", nil)
+		return
+	}
+
+	locks, err := s.Q.GetLoginLockState(r.Context(), []uuid.UUID{target.ID})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to check account lock", nil)
+		return
+	}
+	if len(locks) > 0 && locks[0].LockedUntil != nil {
+		lockedUntil := *locks[0].LockedUntil
+		retryAfter := int(time.Until(lockedUntil).Round(time.Second).Seconds())
+		if retryAfter < 1 {
+			retryAfter = 1
+		}
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+		httpx.WriteError(w, r, http.StatusLocked, "account_locked", "Account is temporarily locked after repeated failed logins", map[string]any{"lockedUntil": lockedUntil.UTC()})
+		return
+	}
+
+	linked, err := s.sessionLinkedUserIDs(r.Context(), actor, tenantID, userID)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load session", nil)
+		return
+	}
+	principal := sessionPrincipalFromLoginRow(*target)
+	passwordProven := false
+	if !slices.Contains(linked, target.ID) {
+		password := derefString(req.Password)
+		if password == "" {
+			httpx.WriteError(w, r, http.StatusForbidden, "password_required", "Enter the password of your account in that tenant", nil)
+			return
+		}
+		matched, err := auth.VerifyPassword(password, target.PasswordHash)
+		if err != nil {
+			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Password verification failed", nil)
+			return
+		}
+		if !matched {
+			s.recordLoginFailures(r, []sessionPrincipal{principal})
+			httpx.WriteError(w, r, http.StatusUnauthorized, "invalid_credentials", "Invalid password", nil)
+			return
+		}
+		s.upgradePasswordHash(r.Context(), target.ID, target.TenantID, password, target.PasswordHash)
+		passwordProven = true
+	}
+	principal.LinkedUserIDs = append(linked, target.ID)
+
+	// Switching must not skip the target account's own second factor.
+	targetMFA, err := s.Q.GetLoginMFAState(r.Context(), gen.GetLoginMFAStateParams{UserID: target.ID, TenantID: target.TenantID})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load MFA state", nil)
+		return
+	}
+
+	requestID := middleware.RequestIDFromContext(r.Context())
+	targetTenantID := target.TenantID
+	targetUserID := target.ID
+	if targetMFA.MfaEnabled {
+		if !s.startMFAChallenge(w, r, principal) {
+			return
+		}
+		_ = s.Audit.Log(r.Context(), audit.Entry{
+			TenantID:   tenantID,
+			UserID:     &userID,
+			Action:     "auth.switch_tenant",
+			EntityType: "session",
+			RequestID:  requestID,
+			Metadata: map[string]any{
+				"toTenantId":     targetTenantID.String(),
+				"mfaRequired":    true,
+				"passwordProven": passwordProven,
+			},
+		})
+		return
+	}
+
+	// Like a login, a proven password only resets the failure counter once no
+	// second factor is pending.
+	if passwordProven {
+		if _, err := s.Q.ClearLoginFailures(r.Context(), gen.ClearLoginFailuresParams{UserID: target.ID, TenantID: target.TenantID}); err != nil {
+			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to reset login failures", nil)
+			return
+		}
+	}
+
+	resp, ok := s.startSession(w, r, principal)
+	if !ok {
+		return
+	}
+
+	_ = s.Audit.Log(r.Context(), audit.Entry{
+		TenantID:   tenantID,
+		UserID:     &userID,
+		Action:     "auth.switch_tenant",
+		EntityType: "session",
+		RequestID:  requestID,
+		Metadata: map[string]any{
+			"toTenantId":     targetTenantID.String(),
+			"passwordProven": passwordProven,
+		},
+	})
+	_ = s.Audit.Log(r.Context(), audit.Entry{
+		TenantID:   targetTenantID,
+		UserID:     &targetUserID,
+		Action:     "auth.login",
+		EntityType: "session",
+		RequestID:  requestID,
+		Metadata: map[string]any{
+			"method":       "tenant_switch",
+			"fromTenantId": tenantID.String(),
+		},
+	})
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+// recordLoginFailures bumps the failure counter of each user whose password
+// did not match and locks accounts that reach the configured threshold. Lock
+// durations double with every lock since the last successful login.
+func (s *Server) recordLoginFailures(r *http.Request, users []sessionPrincipal) {
+	requestID := middleware.RequestIDFromContext(r.Context())
+	ip := middleware.ClientIP(r)
+	for _, user := range users {
+		userID := user.UserID
+		row, err := s.Q.RecordLoginFailure(r.Context(), gen.RecordLoginFailureParams{UserID: user.UserID, TenantID: user.TenantID})
+		if err != nil {
+			s.Logger.Warn("record login failure", "user_id", user.UserID, "error", err)
+			continue
+		}
+		_ = s.Audit.Log(r.Context(), audit.Entry{
+			TenantID:   user.TenantID,
+			Action:     "auth.login_failed",
+			EntityType: "user",
+			EntityID:   &userID,
+			RequestID:  requestID,
+			Metadata: map[string]any{
+				"failedCount": row.FailedCount,
+				"ip":          ip,
+			},
+		})
+
+		if s.Config.LoginLockoutThreshold <= 0 || int(row.FailedCount) < s.Config.LoginLockoutThreshold {
+			continue
+		}
+		lockedUntil := time.Now().Add(auth.LockoutDuration(s.Config.LoginLockoutBaseBackoff, s.Config.LoginLockoutMaxBackoff, int(row.LockCount)))
+		if err := s.Q.LockUserLogin(r.Context(), gen.LockUserLoginParams{UserID: user.UserID, LockedUntil: &lockedUntil}); err != nil {
+			s.Logger.Warn("lock user login", "user_id", user.UserID, "error", err)
+			continue
+		}
+		_ = s.Audit.Log(r.Context(), audit.Entry{
+			TenantID:   user.TenantID,
+			Action:     "auth.locked",
+			EntityType: "user",
+			EntityID:   &userID,
+			RequestID:  requestID,
+			Metadata: map[string]any{
+				"lockedUntil": lockedUntil.UTC(),
+				"lockCount":   row.LockCount + 1,
+				"ip":          ip,
+			},
+		})
+	}
+}
@@ -0,0 +1,307 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/moveops-platform/apps/api/internal/audit"
+	gen "github.com/moveops-platform/apps/api/internal/gen/db"
+	"github.com/moveops-platform/apps/api/internal/gen/oapi"
+	"github.com/moveops-platform/apps/api/internal/httpx"
+	"github.com/moveops-platform/apps/api/internal/middleware"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+func (s *Server) GetCustomers(w http.ResponseWriter, r *http.Request, params oapi.GetCustomersParams) {
+	_, tenantID, _, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	limit, ok := resolveListLimit(w, r, params.Limit)
+	if !ok {
+		return
+	}
+	cursorCreatedAt, cursorID, ok := decodeOptionalListCursor(w, r, params.Cursor)
+	if !ok {
+		return
+	}
+
+	searchQ := sanitizeOptional(params.Q)
+	var phoneDigits *string
+	if searchQ != nil {
+		if digits := normalizePhone(*searchQ); len(digits) >= 3 {
+			phoneDigits = &digits
+		}
+	}
+
+	rows, err := s.Q.ListCustomers(r.Context(), gen.ListCustomersParams{
+		TenantID:          tenantID,
+		SearchQ:           searchQ,
+		SearchPhoneDigits: phoneDigits,
+		CursorCreatedAt:   cursorCreatedAt,
+		CursorID:          cursorID,
+		LimitRows:         int32(limit + 1),
+	})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load customers", nil)
+		return
+	}
+
+	var nextCursor *string
+	if len(rows) > limit {
+		cursor := encodeListCursor(rows[limit-1].CreatedAt, rows[limit-1].ID)
+		nextCursor = &cursor
+		rows = rows[:limit]
+	}
+
+	items := make([]oapi.Customer, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, mapCustomer(row))
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, oapi.CustomerListResponse{
+		Items:      items,
+		NextCursor: nextCursor,
+		RequestId:  middleware.RequestIDFromContext(r.Context()),
+	})
+}
+
+func (s *Server) PatchCustomersCustomerId(w http.ResponseWriter, r *http.Request, customerId openapi_types.UUID) {
+	_, tenantID, userID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req oapi.UpdateCustomerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "invalid_body", "Malformed JSON body", nil)
+		return
+	}
+
+	customerID := uuid.UUID(customerId)
+	before, err := s.Q.GetCustomerByID(r.Context(), gen.GetCustomerByIDParams{ID: customerID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusNotFound, "customer_not_found", "Customer was not found", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load customer", nil)
+		return
+	}
+
+	params := gen.UpdateCustomerParams{
+		ID:        customerID,
+		TenantID:  tenantID,
+		FirstName: before.FirstName,
+		LastName:  before.LastName,
+		Email:     before.Email,
+		Phone:     before.Phone,
+		UpdatedBy: &userID,
+	}
+	if req.FirstName != nil {
+		params.FirstName = strings.TrimSpace(*req.FirstName)
+	}
+	if req.LastName != nil {
+		params.LastName = strings.TrimSpace(*req.LastName)
+	}
+	if params.FirstName == "" || params.LastName == "" {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "firstName and lastName cannot be blank", nil)
+		return
+	}
+	if req.Email != nil {
+		params.Email = emailToStringPtr(req.Email)
+	}
+	if req.Phone != nil {
+		params.Phone = sanitizeOptional(req.Phone)
+	}
+
+	updated, err := s.Q.UpdateCustomer(r.Context(), params)
+	if err != nil {
+		if isUniqueConstraint(err, "customers_tenant_email_uidx") {
+			httpx.WriteError(w, r, http.StatusConflict, "customer_email_conflict", "Another customer already uses this email", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to update customer", nil)
+		return
+	}
+
+	changes := customerChangedFields(before, updated)
+	if len(changes) > 0 {
+		_ = s.Audit.Log(r.Context(), audit.Entry{
+			TenantID:   tenantID,
+			UserID:     &userID,
+			Action:     "customers.update",
+			EntityType: "customer",
+			EntityID:   &customerID,
+			RequestID:  middleware.RequestIDFromContext(r.Context()),
+			Metadata: map[string]any{
+				"fieldsChanged": changes,
+			},
+		})
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, mapCustomer(updated))
+}
+
+func (s *Server) PostCustomersCustomerIdMerge(w http.ResponseWriter, r *http.Request, customerId openapi_types.UUID) {
+	_, tenantID, userID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req oapi.MergeCustomerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "invalid_body", "Malformed JSON body", nil)
+		return
+	}
+
+	survivorID := uuid.UUID(customerId)
+	sourceID := uuid.UUID(req.SourceCustomerId)
+	if sourceID == uuid.Nil || sourceID == survivorID {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "sourceCustomerId must reference a different customer", nil)
+		return
+	}
+
+	tx, err := s.DB.Begin(r.Context())
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to begin transaction", nil)
+		return
+	}
+	defer tx.Rollback(r.Context())
+	qtx := s.Q.WithTx(tx)
+
+	survivor, err := qtx.GetCustomerByIDForUpdate(r.Context(), gen.GetCustomerByIDForUpdateParams{ID: survivorID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusNotFound, "customer_not_found", "Customer was not found", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load customer", nil)
+		return
+	}
+	source, err := qtx.GetCustomerByIDForUpdate(r.Context(), gen.GetCustomerByIDForUpdateParams{ID: sourceID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusNotFound, "customer_not_found", "Source customer was not found", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load customer", nil)
+		return
+	}
+
+	estimatesMoved, err := qtx.ReassignEstimatesCustomer(r.Context(), gen.ReassignEstimatesCustomerParams{
+		TenantID:         tenantID,
+		SourceCustomerID: sourceID,
+		TargetCustomerID: survivorID,
+		UpdatedBy:        &userID,
+	})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to move estimates", nil)
+		return
+	}
+	jobsMoved, err := qtx.ReassignJobsCustomer(r.Context(), gen.ReassignJobsCustomerParams{
+		TenantID:         tenantID,
+		SourceCustomerID: sourceID,
+		TargetCustomerID: survivorID,
+		UpdatedBy:        &userID,
+	})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to move jobs", nil)
+		return
+	}
+	if err := qtx.ReassignCustomerImportIdempotency(r.Context(), gen.ReassignCustomerImportIdempotencyParams{
+		TenantID:         tenantID,
+		SourceCustomerID: sourceID,
+		TargetCustomerID: survivorID,
+	}); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to move import references", nil)
+		return
+	}
+	if _, err := qtx.DeleteCustomer(r.Context(), gen.DeleteCustomerParams{ID: sourceID, TenantID: tenantID}); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to remove merged customer", nil)
+		return
+	}
+
+	// The duplicate is gone, so its email can move to the survivor without
+	// tripping the per-tenant email uniqueness index.
+	email := survivor.Email
+	if strings.TrimSpace(derefString(email)) == "" {
+		email = source.Email
+	}
+	phone := survivor.Phone
+	if strings.TrimSpace(derefString(phone)) == "" {
+		phone = source.Phone
+	}
+	merged, err := qtx.UpdateCustomer(r.Context(), gen.UpdateCustomerParams{
+		ID:        survivorID,
+		TenantID:  tenantID,
+		FirstName: survivor.FirstName,
+		LastName:  survivor.LastName,
+		Email:     email,
+		Phone:     phone,
+		UpdatedBy: &userID,
+	})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to update customer", nil)
+		return
+	}
+
+	if err := tx.Commit(r.Context()); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to commit merge", nil)
+		return
+	}
+
+	_ = s.Audit.Log(r.Context(), audit.Entry{
+		TenantID:   tenantID,
+		UserID:     &userID,
+		Action:     "customers.merge",
+		EntityType: "customer",
+		EntityID:   &survivorID,
+		RequestID:  middleware.RequestIDFromContext(r.Context()),
+		Metadata: map[string]any{
+			"sourceCustomerId": sourceID.String(),
+			"sourceName":       strings.TrimSpace(source.FirstName + " " + source.LastName),
+			"estimatesMoved":   estimatesMoved,
+			"jobsMoved":        jobsMoved,
+			"fieldsChanged":    customerChangedFields(survivor, merged),
+		},
+	})
+
+	httpx.WriteJSON(w, http.StatusOK, oapi.MergeCustomerResponse{
+		Customer:       mapCustomer(merged),
+		EstimatesMoved: estimatesMoved,
+		JobsMoved:      jobsMoved,
+		RequestId:      middleware.RequestIDFromContext(r.Context()),
+	})
+}
+
+func customerChangedFields(before, after gen.Customer) map[string]any {
+	changes := map[string]any{}
+
+	add := func(field string, beforeValue, afterValue any) {
+		changes[field] = map[string]any{
+			"before": beforeValue,
+			"after":  afterValue,
+		}
+	}
+
+	if before.FirstName != after.FirstName {
+		add("firstName", before.FirstName, after.FirstName)
+	}
+	if before.LastName != after.LastName {
+		add("lastName", before.LastName, after.LastName)
+	}
+	if !strPtrEqual(before.Email, after.Email) {
+		add("email", before.Email, after.Email)
+	}
+	if !strPtrEqual(before.Phone, after.Phone) {
+		add("phone", before.Phone, after.Phone)
+	}
+
+	return changes
+}
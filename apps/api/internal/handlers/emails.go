@@ -0,0 +1,695 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/moveops-platform/apps/api/internal/audit"
+	gen "github.com/moveops-platform/apps/api/internal/gen/db"
+	"github.com/moveops-platform/apps/api/internal/gen/oapi"
+	"github.com/moveops-platform/apps/api/internal/httpx"
+	"github.com/moveops-platform/apps/api/internal/mail"
+	"github.com/moveops-platform/apps/api/internal/middleware"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+const (
+	emailKindEstimateSent        = "estimate_sent"
+	emailKindBookingConfirmation = "booking_confirmation"
+	emailKindStorageInvoice      = "storage_invoice"
+
+	// Reasons a message was not queued. They double as error codes.
+	emailReasonDisabled         = "email_disabled"
+	emailReasonSenderMissing    = "email_sender_missing"
+	emailReasonRecipientMissing = "email_recipient_missing"
+
+	emailListLimit    = 50
+	emailValueUnknown = "to be confirmed"
+)
+
+// emailTemplateDefault is the message a kind uses until the tenant saves
+// its own, and the placeholders the kind fills in.
+type emailTemplateDefault struct {
+	kind         string
+	subject      string
+	body         string
+	placeholders []string
+}
+
+var emailTemplateDefaults = []emailTemplateDefault{
+	{
+		kind:    emailKindEstimateSent,
+		subject: "Your moving estimate {{estimateNumber}} from {{companyName}}",
+		body: `Hi {{customerName}},
+
+Thank you for considering {{companyName}} for your move from {{origin}} to {{destination}} on {{moveDate}}.
+
+Estimate: {{estimateNumber}}
+Estimated total: {{estimatedTotal}}
+Deposit: {{deposit}}
+Valid until: {{validUntil}}
+
+Reply to this email with any questions or to book your move.
+
+{{companyName}}
+`,
+		placeholders: []string{"companyName", "customerName", "estimateNumber", "moveDate", "validUntil", "estimatedTotal", "deposit", "origin", "destination"},
+	},
+	{
+		kind:    emailKindBookingConfirmation,
+		subject: "Your move is booked: {{jobNumber}}",
+		body: `Hi {{customerName}},
+
+Your move with {{companyName}} is booked.
+
+Job number: {{jobNumber}}
+Move date: {{moveDate}}
+Pickup time: {{pickupTime}}
+From: {{origin}}
+To: {{destination}}
+
+We will be in touch before moving day. Reply to this email if anything changes.
+
+{{companyName}}
+`,
+		placeholders: []string{"companyName", "customerName", "jobNumber", "estimateNumber", "moveDate", "pickupTime", "origin", "destination"},
+	},
+	{
+		kind:    emailKindStorageInvoice,
+		subject: "Storage statement for {{jobNumber}}",
+		body: `Hi {{customerName}},
+
+This is your storage statement from {{companyName}} for your belongings at {{facility}}.
+
+Monthly rate: {{monthlyRate}}
+Balance due: {{storageBalance}}
+Next billing date: {{nextBillDate}}
+
+Reply to this email with any questions about your account.
+
+{{companyName}}
+`,
+		placeholders: []string{"companyName", "customerName", "jobNumber", "facility", "monthlyRate", "storageBalance", "nextBillDate"},
+	},
+}
+
+var emailPlaceholderPattern = regexp.MustCompile(`\{\{([^{}]*)\}\}`)
+
+func emailTemplateDefaultFor(kind string) (emailTemplateDefault, bool) {
+	for _, def := range emailTemplateDefaults {
+		if def.kind == kind {
+			return def, true
+		}
+	}
+	return emailTemplateDefault{}, false
+}
+
+// unknownEmailPlaceholder returns the first placeholder in text that the
+// kind does not fill in, or "" when there is none.
+func unknownEmailPlaceholder(def emailTemplateDefault, text string) string {
+	for _, match := range emailPlaceholderPattern.FindAllStringSubmatch(text, -1) {
+		if !slices.Contains(def.placeholders, match[1]) {
+			return match[0]
+		}
+	}
+	return ""
+}
+
+// renderEmailTemplate fills the placeholders in subject and body. The
+// subject is folded onto one line since it becomes a header.
+func renderEmailTemplate(subject, body string, values map[string]string) (string, string) {
+	pairs := make([]string, 0, 2*len(values))
+	for name, value := range values {
+		pairs = append(pairs, "{{"+name+"}}", value)
+	}
+	replacer := strings.NewReplacer(pairs...)
+	return strings.Join(strings.Fields(replacer.Replace(subject)), " "), replacer.Replace(body)
+}
+
+// queueEmail renders the tenant's template for msg.Kind with values and
+// adds the message to the outbox with q, so it is only sent if the
+// surrounding transaction commits. msg carries the tenant, the entity, the
+// recipient and the author; the sender, subject and body are filled in
+// here. When the tenant cannot send the message it returns one of the
+// emailReason codes instead of queueing it.
+func (s *Server) queueEmail(ctx context.Context, q *gen.Queries, msg gen.EnqueueEmailParams, values map[string]string) (gen.EmailOutbox, string, error) {
+	settings, err := q.GetTenantEmailSettings(ctx, msg.TenantID)
+	if err != nil {
+		return gen.EmailOutbox{}, "", err
+	}
+	if !settings.Enabled {
+		return gen.EmailOutbox{}, emailReasonDisabled, nil
+	}
+	msg.FromAddress = derefString(settings.FromAddress)
+	if msg.FromAddress == "" {
+		msg.FromAddress = s.Config.MailFromAddress
+	}
+	if msg.FromAddress == "" {
+		return gen.EmailOutbox{}, emailReasonSenderMissing, nil
+	}
+	to, err := mail.NormalizeAddress(msg.ToAddress)
+	if err != nil {
+		return gen.EmailOutbox{}, emailReasonRecipientMissing, nil
+	}
+	msg.ToAddress = to
+
+	companyName := settings.TenantName
+	if settings.FromName != nil {
+		companyName = *settings.FromName
+	}
+	msg.FromName = &companyName
+	msg.ReplyTo = settings.ReplyTo
+
+	def, _ := emailTemplateDefaultFor(msg.Kind)
+	subject, body := def.subject, def.body
+	stored, err := q.GetEmailTemplate(ctx, gen.GetEmailTemplateParams{TenantID: msg.TenantID, Kind: msg.Kind})
+	switch {
+	case err == nil:
+		subject, body = stored.Subject, stored.Body
+	case !errors.Is(err, pgx.ErrNoRows):
+		return gen.EmailOutbox{}, "", err
+	}
+	values["companyName"] = companyName
+	msg.Subject, msg.Body = renderEmailTemplate(subject, body, values)
+
+	queued, err := q.EnqueueEmail(ctx, msg)
+	return queued, "", err
+}
+
+func estimateEmailValues(estimate gen.Estimate) map[string]string {
+	validUntil := emailValueUnknown
+	if estimate.ExpiresAt != nil {
+		validUntil = estimate.ExpiresAt.UTC().Format(documentDateLayout)
+	}
+	return map[string]string{
+		"customerName":   estimate.CustomerName,
+		"estimateNumber": estimate.EstimateNumber,
+		"moveDate":       estimate.MoveDate.UTC().Format(documentDateLayout),
+		"validUntil":     validUntil,
+		"estimatedTotal": formatEmailCents(estimate.EstimatedTotalCents),
+		"deposit":        formatEmailCents(estimate.DepositCents),
+		"origin":         documentCityLine(estimate.OriginCity, estimate.OriginState, ""),
+		"destination":    documentCityLine(estimate.DestinationCity, estimate.DestinationState, ""),
+	}
+}
+
+func jobEmailValues(job gen.GetJobEmailContextRow) map[string]string {
+	moveDate := emailValueUnknown
+	if job.ScheduledDate != nil {
+		moveDate = job.ScheduledDate.UTC().Format(documentDateLayout)
+	}
+	return map[string]string{
+		"customerName":   job.CustomerName,
+		"jobNumber":      job.JobNumber,
+		"estimateNumber": derefString(job.EstimateNumber),
+		"moveDate":       moveDate,
+		"pickupTime":     documentValueOr(job.PickupTime, emailValueUnknown),
+		"origin":         emailValueOr(documentCityLine(derefString(job.OriginCity), derefString(job.OriginState), "")),
+		"destination":    emailValueOr(documentCityLine(derefString(job.DestinationCity), derefString(job.DestinationState), "")),
+	}
+}
+
+func storageEmailValues(record gen.GetStorageRecordEmailContextRow) map[string]string {
+	nextBillDate := "not scheduled"
+	if record.NextBillDate != nil {
+		nextBillDate = record.NextBillDate.UTC().Format(documentDateLayout)
+	}
+	return map[string]string{
+		"customerName":   record.CustomerName,
+		"jobNumber":      record.JobNumber,
+		"facility":       record.Facility,
+		"monthlyRate":    formatEmailCents(record.MonthlyRateCents),
+		"storageBalance": formatDocumentCents(record.StorageBalanceCents),
+		"nextBillDate":   nextBillDate,
+	}
+}
+
+func emailValueOr(value string) string {
+	if value == "" {
+		return emailValueUnknown
+	}
+	return value
+}
+
+func formatEmailCents(cents *int64) string {
+	if cents == nil {
+		return emailValueUnknown
+	}
+	return formatDocumentCents(*cents)
+}
+
+// GetSettingsEmail returns the tenant's sender settings and every template,
+// with defaults for the kinds the tenant has not customized.
+func (s *Server) GetSettingsEmail(w http.ResponseWriter, r *http.Request) {
+	_, tenantID, _, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+	s.writeEmailSettings(w, r, s.Q, tenantID)
+}
+
+// PutSettingsEmail replaces the tenant's sender settings and templates.
+// Templates that match the default are not stored, so they keep following
+// it.
+func (s *Server) PutSettingsEmail(w http.ResponseWriter, r *http.Request) {
+	_, tenantID, userID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req oapi.UpdateEmailSettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "invalid_body", "Malformed JSON body", nil)
+		return
+	}
+
+	fromAddress := sanitizeOptional(req.FromAddress)
+	replyTo := sanitizeOptional(req.ReplyTo)
+	for field, value := range map[string]*string{"fromAddress": fromAddress, "replyTo": replyTo} {
+		if value == nil {
+			continue
+		}
+		if _, err := mail.NormalizeAddress(*value); err != nil {
+			httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", field+" must be an email address", map[string]any{"field": field})
+			return
+		}
+	}
+	fromName := sanitizeOptional(req.FromName)
+	if fromName != nil && strings.ContainsAny(*fromName, "\r\n") {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "fromName must be a single line", map[string]any{"field": "fromName"})
+		return
+	}
+	if req.Enabled && fromAddress == nil && s.Config.MailFromAddress == "" {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "fromAddress is required to enable email", map[string]any{"field": "fromAddress"})
+		return
+	}
+
+	templates := gen.InsertEmailTemplatesParams{TenantID: tenantID, UpdatedBy: &userID}
+	var kinds []string
+	if req.Templates != nil {
+		for _, input := range *req.Templates {
+			kind := string(input.Kind)
+			def, known := emailTemplateDefaultFor(kind)
+			if !known {
+				httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "Unknown template kind", map[string]any{"kind": kind})
+				return
+			}
+			if slices.Contains(kinds, kind) {
+				httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "Each template kind may be listed once", map[string]any{"kind": kind})
+				return
+			}
+			kinds = append(kinds, kind)
+
+			subject, body := strings.TrimSpace(input.Subject), strings.TrimSpace(input.Body)+"\n"
+			if subject == "" || strings.TrimSpace(body) == "" {
+				httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "Template subject and body are required", map[string]any{"kind": kind})
+				return
+			}
+			for _, text := range []string{subject, body} {
+				if placeholder := unknownEmailPlaceholder(def, text); placeholder != "" {
+					httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "Template uses a placeholder that is not available for its kind", map[string]any{
+						"kind":         kind,
+						"placeholder":  placeholder,
+						"placeholders": def.placeholders,
+					})
+					return
+				}
+			}
+			if subject == def.subject && body == def.body {
+				continue
+			}
+			templates.Kinds = append(templates.Kinds, kind)
+			templates.Subjects = append(templates.Subjects, subject)
+			templates.Bodies = append(templates.Bodies, body)
+		}
+	}
+
+	tx, err := s.DB.Begin(r.Context())
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to begin transaction", nil)
+		return
+	}
+	defer tx.Rollback(r.Context())
+	qtx := s.Q.WithTx(tx)
+
+	if err := qtx.UpsertTenantEmailSettings(r.Context(), gen.UpsertTenantEmailSettingsParams{
+		TenantID:    tenantID,
+		Enabled:     req.Enabled,
+		FromName:    fromName,
+		FromAddress: fromAddress,
+		ReplyTo:     replyTo,
+		UpdatedBy:   &userID,
+	}); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to update email settings", nil)
+		return
+	}
+	if err := qtx.DeleteEmailTemplates(r.Context(), tenantID); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to update email templates", nil)
+		return
+	}
+	if len(templates.Kinds) > 0 {
+		if err := qtx.InsertEmailTemplates(r.Context(), templates); err != nil {
+			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to update email templates", nil)
+			return
+		}
+	}
+	if err := tx.Commit(r.Context()); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to commit email settings", nil)
+		return
+	}
+
+	customized := templates.Kinds
+	if customized == nil {
+		customized = []string{}
+	}
+	_ = s.Audit.Log(r.Context(), audit.Entry{
+		TenantID:   tenantID,
+		UserID:     &userID,
+		Action:     "settings.email_update",
+		EntityType: "tenant",
+		EntityID:   &tenantID,
+		RequestID:  middleware.RequestIDFromContext(r.Context()),
+		Metadata: map[string]any{
+			"enabled":             req.Enabled,
+			"fromAddress":         derefString(fromAddress),
+			"customizedTemplates": customized,
+		},
+	})
+
+	s.writeEmailSettings(w, r, s.Q, tenantID)
+}
+
+func (s *Server) writeEmailSettings(w http.ResponseWriter, r *http.Request, q *gen.Queries, tenantID uuid.UUID) {
+	settings, err := q.GetTenantEmailSettings(r.Context(), tenantID)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load email settings", nil)
+		return
+	}
+	stored, err := q.ListEmailTemplates(r.Context(), tenantID)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load email templates", nil)
+		return
+	}
+
+	templates := make([]oapi.EmailTemplate, 0, len(emailTemplateDefaults))
+	for _, def := range emailTemplateDefaults {
+		template := oapi.EmailTemplate{
+			Kind:         oapi.EmailTemplateKind(def.kind),
+			Subject:      def.subject,
+			Body:         def.body,
+			Placeholders: def.placeholders,
+		}
+		for _, row := range stored {
+			if row.Kind == def.kind {
+				template.Subject, template.Body, template.Customized = row.Subject, row.Body, true
+			}
+		}
+		templates = append(templates, template)
+	}
+
+	var defaultFromAddress *string
+	if s.Config.MailFromAddress != "" {
+		defaultFromAddress = &s.Config.MailFromAddress
+	}
+	httpx.WriteJSON(w, http.StatusOK, oapi.EmailSettingsResponse{
+		Settings: oapi.EmailSettings{
+			Enabled:            settings.Enabled,
+			FromName:           settings.FromName,
+			FromAddress:        settings.FromAddress,
+			ReplyTo:            settings.ReplyTo,
+			DefaultFromAddress: defaultFromAddress,
+			Templates:          templates,
+			UpdatedAt:          settings.UpdatedAt,
+		},
+		RequestId: middleware.RequestIDFromContext(r.Context()),
+	})
+}
+
+func (s *Server) GetEstimatesEstimateIdEmails(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID) {
+	_, tenantID, _, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+	estimateID := uuid.UUID(estimateId)
+	if _, err := s.Q.GetEstimateByID(r.Context(), gen.GetEstimateByIDParams{ID: estimateID, TenantID: tenantID}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusNotFound, "estimate_not_found", "Estimate was not found", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load estimate", nil)
+		return
+	}
+	s.writeEmailList(w, r, gen.ListEmailOutboxParams{TenantID: tenantID, EstimateID: &estimateID})
+}
+
+// PostEstimatesEstimateIdEmails queues the estimate_sent message again, for
+// example after the customer lost it. The estimate status is not touched.
+func (s *Server) PostEstimatesEstimateIdEmails(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID) {
+	_, tenantID, userID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+	req, ok := decodeSendEmailRequest(w, r)
+	if !ok {
+		return
+	}
+
+	s.expireEstimates(r.Context(), tenantID)
+
+	estimateID := uuid.UUID(estimateId)
+	estimate, err := s.Q.GetEstimateByID(r.Context(), gen.GetEstimateByIDParams{ID: estimateID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusNotFound, "estimate_not_found", "Estimate was not found", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load estimate", nil)
+		return
+	}
+
+	s.sendEmail(w, r, gen.EnqueueEmailParams{
+		TenantID:   tenantID,
+		Kind:       emailKindEstimateSent,
+		EstimateID: &estimateID,
+		ToAddress:  emailRecipient(req, estimate.Email),
+		CreatedBy:  &userID,
+	}, estimateEmailValues(estimate), "estimate", estimateID)
+}
+
+func (s *Server) GetJobsJobIdEmails(w http.ResponseWriter, r *http.Request, jobId openapi_types.UUID) {
+	_, tenantID, _, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+	jobID := uuid.UUID(jobId)
+	if _, err := s.Q.GetJobEmailContext(r.Context(), gen.GetJobEmailContextParams{ID: jobID, TenantID: tenantID}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusNotFound, "job_not_found", "Job was not found", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load job", nil)
+		return
+	}
+	s.writeEmailList(w, r, gen.ListEmailOutboxParams{TenantID: tenantID, JobID: &jobID})
+}
+
+// PostJobsJobIdEmails queues the booking_confirmation message, which is
+// also queued automatically when an estimate is converted.
+func (s *Server) PostJobsJobIdEmails(w http.ResponseWriter, r *http.Request, jobId openapi_types.UUID) {
+	_, tenantID, userID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+	req, ok := decodeSendEmailRequest(w, r)
+	if !ok {
+		return
+	}
+
+	jobID := uuid.UUID(jobId)
+	job, err := s.Q.GetJobEmailContext(r.Context(), gen.GetJobEmailContextParams{ID: jobID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusNotFound, "job_not_found", "Job was not found", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load job", nil)
+		return
+	}
+
+	s.sendEmail(w, r, gen.EnqueueEmailParams{
+		TenantID:  tenantID,
+		Kind:      emailKindBookingConfirmation,
+		JobID:     &jobID,
+		ToAddress: emailRecipient(req, job.Email),
+		CreatedBy: &userID,
+	}, jobEmailValues(job), "job", jobID)
+}
+
+func (s *Server) GetStorageStorageRecordIdEmails(w http.ResponseWriter, r *http.Request, storageRecordId openapi_types.UUID) {
+	_, tenantID, _, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+	recordID := uuid.UUID(storageRecordId)
+	if _, err := s.Q.GetStorageRecordEmailContext(r.Context(), gen.GetStorageRecordEmailContextParams{ID: recordID, TenantID: tenantID}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusNotFound, "storage_record_not_found", "Storage record was not found", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load storage record", nil)
+		return
+	}
+	s.writeEmailList(w, r, gen.ListEmailOutboxParams{TenantID: tenantID, StorageRecordID: &recordID})
+}
+
+// PostStorageStorageRecordIdEmails queues a storage_invoice statement with
+// the record's current rate and balance.
+func (s *Server) PostStorageStorageRecordIdEmails(w http.ResponseWriter, r *http.Request, storageRecordId openapi_types.UUID) {
+	_, tenantID, userID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+	req, ok := decodeSendEmailRequest(w, r)
+	if !ok {
+		return
+	}
+
+	recordID := uuid.UUID(storageRecordId)
+	record, err := s.Q.GetStorageRecordEmailContext(r.Context(), gen.GetStorageRecordEmailContextParams{ID: recordID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusNotFound, "storage_record_not_found", "Storage record was not found", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load storage record", nil)
+		return
+	}
+
+	s.sendEmail(w, r, gen.EnqueueEmailParams{
+		TenantID:        tenantID,
+		Kind:            emailKindStorageInvoice,
+		StorageRecordID: &recordID,
+		ToAddress:       emailRecipient(req, record.Email),
+		CreatedBy:       &userID,
+	}, storageEmailValues(record), "storage_record", recordID)
+}
+
+func decodeSendEmailRequest(w http.ResponseWriter, r *http.Request) (oapi.SendEmailRequest, bool) {
+	var req oapi.SendEmailRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "invalid_body", "Malformed JSON body", nil)
+		return req, false
+	}
+	if to := sanitizeOptional(req.To); to != nil {
+		if _, err := mail.NormalizeAddress(*to); err != nil {
+			httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "to must be an email address", map[string]any{"field": "to"})
+			return req, false
+		}
+	}
+	return req, true
+}
+
+func emailRecipient(req oapi.SendEmailRequest, fallback string) string {
+	if to := sanitizeOptional(req.To); to != nil {
+		return *to
+	}
+	return fallback
+}
+
+// sendEmail queues msg for a POST .../emails request and writes the 202
+// response, or a 409 when the tenant cannot send it.
+func (s *Server) sendEmail(w http.ResponseWriter, r *http.Request, msg gen.EnqueueEmailParams, values map[string]string, entityType string, entityID uuid.UUID) {
+	queued, reason, err := s.queueEmail(r.Context(), s.Q, msg, values)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to queue email", nil)
+		return
+	}
+	if reason != "" {
+		writeEmailReason(w, r, reason)
+		return
+	}
+
+	logEmailQueued(r.Context(), s.Audit, queued, entityType, entityID, msg.CreatedBy)
+	httpx.WriteJSON(w, http.StatusAccepted, oapi.EmailMessageResponse{
+		Email:     mapEmailMessage(queued),
+		RequestId: middleware.RequestIDFromContext(r.Context()),
+	})
+}
+
+func writeEmailReason(w http.ResponseWriter, r *http.Request, reason string) {
+	message := map[string]string{
+		emailReasonDisabled:         "Email is disabled for this tenant",
+		emailReasonSenderMissing:    "No sender address is configured",
+		emailReasonRecipientMissing: "There is no valid recipient address",
+	}[reason]
+	httpx.WriteError(w, r, http.StatusConflict, reason, message, nil)
+}
+
+func logEmailQueued(ctx context.Context, auditLogger *audit.Logger, queued gen.EmailOutbox, entityType string, entityID uuid.UUID, userID *uuid.UUID) {
+	_ = auditLogger.Log(ctx, audit.Entry{
+		TenantID:   queued.TenantID,
+		UserID:     userID,
+		Action:     "email.queued",
+		EntityType: entityType,
+		EntityID:   &entityID,
+		RequestID:  middleware.RequestIDFromContext(ctx),
+		Metadata: map[string]any{
+			"emailId": queued.ID,
+			"kind":    queued.Kind,
+			"to":      queued.ToAddress,
+		},
+	})
+}
+
+func (s *Server) writeEmailList(w http.ResponseWriter, r *http.Request, params gen.ListEmailOutboxParams) {
+	params.RowLimit = emailListLimit
+	rows, err := s.Q.ListEmailOutbox(r.Context(), params)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load email", nil)
+		return
+	}
+	items := make([]oapi.EmailMessage, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, mapEmailMessage(row))
+	}
+	httpx.WriteJSON(w, http.StatusOK, oapi.EmailMessageListResponse{
+		Items:     items,
+		RequestId: middleware.RequestIDFromContext(r.Context()),
+	})
+}
+
+// lastEmail loads the newest message about an entity for the delivery
+// status shown on estimates and jobs.
+func (s *Server) lastEmail(ctx context.Context, params gen.ListEmailOutboxParams) (*oapi.EmailMessage, error) {
+	params.RowLimit = 1
+	rows, err := s.Q.ListEmailOutbox(ctx, params)
+	if err != nil || len(rows) == 0 {
+		return nil, err
+	}
+	message := mapEmailMessage(rows[0])
+	return &message, nil
+}
+
+func mapEmailMessage(row gen.EmailOutbox) oapi.EmailMessage {
+	return oapi.EmailMessage{
+		Id:            row.ID,
+		Kind:          oapi.EmailMessageKind(row.Kind),
+		Status:        oapi.EmailMessageStatus(row.Status),
+		ToAddress:     row.ToAddress,
+		Subject:       row.Subject,
+		Body:          row.Body,
+		Attempts:      int(row.Attempts),
+		LastError:     row.LastError,
+		NextAttemptAt: row.NextAttemptAt,
+		SentAt:        row.SentAt,
+		CreatedBy:     row.CreatedBy,
+		CreatedAt:     row.CreatedAt,
+	}
+}
@@ -0,0 +1,561 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/moveops-platform/apps/api/internal/acceptance"
+	"github.com/moveops-platform/apps/api/internal/audit"
+	gen "github.com/moveops-platform/apps/api/internal/gen/db"
+	"github.com/moveops-platform/apps/api/internal/gen/oapi"
+	"github.com/moveops-platform/apps/api/internal/httpx"
+	"github.com/moveops-platform/apps/api/internal/middleware"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+const (
+	// defaultAcceptanceLinkDays applies when the estimate has no expiry.
+	defaultAcceptanceLinkDays = 14
+	maxSignerUserAgentLength  = 500
+)
+
+// PostEstimatesEstimateIdAcceptanceLinks creates the link a customer uses
+// to accept a sent estimate without an account. Only the newest link
+// works; creating one revokes the others.
+func (s *Server) PostEstimatesEstimateIdAcceptanceLinks(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID) {
+	actor, tenantID, userID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req oapi.CreateAcceptanceLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "invalid_body", "Malformed JSON body", nil)
+		return
+	}
+	autoConvert := req.AutoConvert != nil && *req.AutoConvert
+	// The customer converts with the link creator's authority, so the
+	// creator has to be allowed to convert.
+	if autoConvert && !actor.Permissions.Has("estimates.convert") {
+		httpx.WriteError(w, r, http.StatusForbidden, "forbidden", "Permission denied", map[string]string{"permission": "estimates.convert"})
+		return
+	}
+
+	s.expireEstimates(r.Context(), tenantID)
+
+	estimateID := uuid.UUID(estimateId)
+	tx, err := s.DB.Begin(r.Context())
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to start transaction", nil)
+		return
+	}
+	defer tx.Rollback(r.Context())
+	qtx := s.Q.WithTx(tx)
+
+	estimate, err := qtx.GetEstimateByID(r.Context(), gen.GetEstimateByIDParams{ID: estimateID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusNotFound, "estimate_not_found", "Estimate was not found", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load estimate", nil)
+		return
+	}
+	if estimate.Status != string(oapi.EstimateStatusSent) {
+		httpx.WriteError(w, r, http.StatusConflict, "estimate_not_sent", "Only sent estimates can be accepted online", map[string]any{
+			"status": estimate.Status,
+		})
+		return
+	}
+
+	now := time.Now()
+	expiresAt := now.AddDate(0, 0, defaultAcceptanceLinkDays)
+	switch {
+	case req.ExpiresInDays != nil:
+		expiresAt = now.AddDate(0, 0, *req.ExpiresInDays)
+	case estimate.ExpiresAt != nil:
+		expiresAt = *estimate.ExpiresAt
+	}
+	// Tokens sign the expiry in whole seconds.
+	expiresAt = expiresAt.Truncate(time.Second)
+
+	if err := qtx.RevokeEstimateAcceptanceLinks(r.Context(), gen.RevokeEstimateAcceptanceLinksParams{TenantID: tenantID, EstimateID: estimateID}); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to revoke earlier links", nil)
+		return
+	}
+	link, err := qtx.CreateEstimateAcceptanceLink(r.Context(), gen.CreateEstimateAcceptanceLinkParams{
+		TenantID:    tenantID,
+		EstimateID:  estimateID,
+		AutoConvert: autoConvert,
+		ExpiresAt:   expiresAt,
+		CreatedBy:   &userID,
+	})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to create acceptance link", nil)
+		return
+	}
+	if err := tx.Commit(r.Context()); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to commit acceptance link", nil)
+		return
+	}
+
+	_ = s.Audit.Log(r.Context(), audit.Entry{
+		TenantID:   tenantID,
+		UserID:     &userID,
+		Action:     "estimate.acceptance_link_create",
+		EntityType: "estimate",
+		EntityID:   &estimateID,
+		RequestID:  middleware.RequestIDFromContext(r.Context()),
+		Metadata: map[string]any{
+			"linkId":      link.ID,
+			"expiresAt":   link.ExpiresAt.UTC(),
+			"autoConvert": link.AutoConvert,
+		},
+	})
+
+	token := s.Acceptance.LinkToken(link.ID, link.ExpiresAt)
+	httpx.WriteJSON(w, http.StatusCreated, oapi.EstimateAcceptanceLinkResponse{
+		Link: oapi.EstimateAcceptanceLink{
+			Id:          openapi_types.UUID(link.ID),
+			Token:       token,
+			Url:         s.Config.WebBaseURL + "/accept/" + token,
+			ExpiresAt:   link.ExpiresAt,
+			AutoConvert: link.AutoConvert,
+			CreatedAt:   link.CreatedAt,
+		},
+		RequestId: middleware.RequestIDFromContext(r.Context()),
+	})
+}
+
+// GetEstimatesEstimateIdSignatures lists the estimate's signatures and
+// re-verifies each one against the server key and the signed revision.
+func (s *Server) GetEstimatesEstimateIdSignatures(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID) {
+	_, tenantID, _, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+	estimateID := uuid.UUID(estimateId)
+	if _, err := s.Q.GetEstimateByID(r.Context(), gen.GetEstimateByIDParams{ID: estimateID, TenantID: tenantID}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusNotFound, "estimate_not_found", "Estimate was not found", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load estimate", nil)
+		return
+	}
+
+	rows, err := s.Q.ListEstimateSignatures(r.Context(), gen.ListEstimateSignaturesParams{TenantID: tenantID, EstimateID: estimateID})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to list signatures", nil)
+		return
+	}
+	items := make([]oapi.EstimateSignature, 0, len(rows))
+	for _, row := range rows {
+		record := acceptance.Signature{
+			ID:             row.ID,
+			TenantID:       row.TenantID,
+			EstimateID:     row.EstimateID,
+			RevisionNumber: row.RevisionNumber,
+			DocumentSHA256: row.DocumentSha256,
+			SignerName:     row.SignerName,
+			SignerIP:       row.SignerIp,
+			UserAgent:      row.UserAgent,
+			SignedAt:       row.SignedAt,
+		}
+		items = append(items, oapi.EstimateSignature{
+			Id:             openapi_types.UUID(row.ID),
+			LinkId:         (*openapi_types.UUID)(row.LinkID),
+			RevisionNumber: int(row.RevisionNumber),
+			DocumentSha256: row.DocumentSha256,
+			SignerName:     row.SignerName,
+			SignerIp:       row.SignerIp,
+			UserAgent:      row.UserAgent,
+			SignedAt:       row.SignedAt,
+			Verified:       s.Acceptance.Verify(record, row.Signature) && row.RevisionSha256 == row.DocumentSha256,
+		})
+	}
+	httpx.WriteJSON(w, http.StatusOK, oapi.EstimateSignatureListResponse{
+		Items:     items,
+		RequestId: middleware.RequestIDFromContext(r.Context()),
+	})
+}
+
+// GetPublicEstimateAcceptanceToken shows the customer the estimate behind
+// a link. It is unauthenticated; the signed token is the only credential.
+func (s *Server) GetPublicEstimateAcceptanceToken(w http.ResponseWriter, r *http.Request, token oapi.AcceptanceToken) {
+	link, ok := s.loadAcceptanceLink(w, r, s.Q, token, false)
+	if !ok {
+		return
+	}
+	if !acceptanceLinkOpen(link) {
+		writeAcceptanceLinkExpired(w, r)
+		return
+	}
+	s.expireEstimatesForRead(r.Context(), link.TenantID)
+
+	viewed, err := s.Q.MarkEstimateAcceptanceLinkViewed(r.Context(), link.ID)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to record view", nil)
+		return
+	}
+	if viewed > 0 {
+		_ = s.Audit.Log(r.Context(), audit.Entry{
+			TenantID:   link.TenantID,
+			Action:     "estimate.acceptance_link_view",
+			EntityType: "estimate",
+			EntityID:   &link.EstimateID,
+			RequestID:  middleware.RequestIDFromContext(r.Context()),
+			Metadata: map[string]any{
+				"linkId": link.ID,
+				"ip":     middleware.ClientIP(r),
+			},
+		})
+	}
+
+	s.writePublicEstimate(w, r, link, http.StatusOK)
+}
+
+// GetPublicEstimateAcceptanceTokenDocumentPdf renders the same PDF staff
+// download, for the customer to read before signing.
+func (s *Server) GetPublicEstimateAcceptanceTokenDocumentPdf(w http.ResponseWriter, r *http.Request, token oapi.AcceptanceToken) {
+	link, ok := s.loadAcceptanceLink(w, r, s.Q, token, false)
+	if !ok {
+		return
+	}
+	if !acceptanceLinkOpen(link) {
+		writeAcceptanceLinkExpired(w, r)
+		return
+	}
+
+	estimate, err := s.Q.GetEstimateByID(r.Context(), gen.GetEstimateByIDParams{ID: link.EstimateID, TenantID: link.TenantID})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load estimate", nil)
+		return
+	}
+	items, err := s.Q.ListEstimateLineItems(r.Context(), gen.ListEstimateLineItemsParams{TenantID: link.TenantID, EstimateID: link.EstimateID})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load line items", nil)
+		return
+	}
+	template, err := s.Q.GetEstimateDocumentTemplate(r.Context(), link.TenantID)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load estimate document template", nil)
+		return
+	}
+
+	document, pages, err := renderEstimateDocument(estimate, items, template, time.Now().UTC())
+	if err != nil {
+		s.Logger.Error("render estimate document", "estimate_id", link.EstimateID, "error", err)
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to render estimate document", nil)
+		return
+	}
+
+	_ = s.Audit.Log(r.Context(), audit.Entry{
+		TenantID:   link.TenantID,
+		Action:     "estimate.document_render",
+		EntityType: "estimate",
+		EntityID:   &link.EstimateID,
+		RequestID:  middleware.RequestIDFromContext(r.Context()),
+		Metadata: map[string]any{
+			"estimateNumber": estimate.EstimateNumber,
+			"status":         estimate.Status,
+			"pages":          pages,
+			"bytes":          len(document),
+			"linkId":         link.ID,
+		},
+	})
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", estimate.EstimateNumber+".pdf"))
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(document)
+}
+
+// PostPublicEstimateAcceptanceTokenAccept records the customer's typed-name
+// signature and accepts the estimate. The signature covers the revision
+// the acceptance creates, so the record pins exactly what was accepted.
+func (s *Server) PostPublicEstimateAcceptanceTokenAccept(w http.ResponseWriter, r *http.Request, token oapi.AcceptanceToken) {
+	var req oapi.AcceptEstimateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "invalid_body", "Malformed JSON body", nil)
+		return
+	}
+	signerName := strings.Join(strings.Fields(req.SignerName), " ")
+	if utf8.RuneCountInString(signerName) < 2 || utf8.RuneCountInString(signerName) > 200 || strings.IndexFunc(signerName, unicode.IsControl) >= 0 {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "signerName must be your full name", map[string]any{"field": "signerName"})
+		return
+	}
+	if !req.AgreeToTerms {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "agreeToTerms must be true to accept the estimate", map[string]any{"field": "agreeToTerms"})
+		return
+	}
+
+	// Validate the token before anything touches the tenant's data.
+	link, ok := s.loadAcceptanceLink(w, r, s.Q, token, false)
+	if !ok {
+		return
+	}
+	s.expireEstimates(r.Context(), link.TenantID)
+
+	tx, err := s.DB.Begin(r.Context())
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to start transaction", nil)
+		return
+	}
+	defer tx.Rollback(r.Context())
+	qtx := s.Q.WithTx(tx)
+
+	link, ok = s.loadAcceptanceLink(w, r, qtx, token, true)
+	if !ok {
+		return
+	}
+	if link.AcceptedAt != nil {
+		httpx.WriteError(w, r, http.StatusConflict, "acceptance_link_used", "This estimate was already accepted with this link", nil)
+		return
+	}
+	if !acceptanceLinkOpen(link) {
+		writeAcceptanceLinkExpired(w, r)
+		return
+	}
+
+	estimate, err := qtx.GetEstimateByID(r.Context(), gen.GetEstimateByIDParams{ID: link.EstimateID, TenantID: link.TenantID})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load estimate", nil)
+		return
+	}
+	if estimate.Status != string(oapi.EstimateStatusSent) {
+		httpx.WriteError(w, r, http.StatusConflict, "estimate_not_acceptable", "This estimate can no longer be accepted online; please contact us", map[string]any{
+			"status": estimate.Status,
+		})
+		return
+	}
+
+	if _, err := qtx.TransitionEstimateStatus(r.Context(), gen.TransitionEstimateStatusParams{
+		Status:     string(oapi.EstimateStatusAccepted),
+		SentAt:     estimate.SentAt,
+		ExpiresAt:  estimate.ExpiresAt,
+		ID:         estimate.ID,
+		TenantID:   estimate.TenantID,
+		FromStatus: estimate.Status,
+	}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusConflict, "estimate_not_acceptable", "This estimate can no longer be accepted online; please contact us", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to accept estimate", nil)
+		return
+	}
+	if err := recordEstimateRevision(r.Context(), qtx, link.TenantID, link.EstimateID, nil, revisionStatusChanged, nil); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to record estimate revision", nil)
+		return
+	}
+	digest, err := qtx.GetLatestEstimateRevisionDigest(r.Context(), gen.GetLatestEstimateRevisionDigestParams{TenantID: link.TenantID, EstimateID: link.EstimateID})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load accepted revision", nil)
+		return
+	}
+
+	userAgent := r.UserAgent()
+	if len(userAgent) > maxSignerUserAgentLength {
+		userAgent = strings.ToValidUTF8(userAgent[:maxSignerUserAgentLength], "")
+	}
+	record := acceptance.Signature{
+		ID:             uuid.New(),
+		TenantID:       link.TenantID,
+		EstimateID:     link.EstimateID,
+		RevisionNumber: digest.RevisionNumber,
+		DocumentSHA256: digest.DocumentSha256,
+		SignerName:     signerName,
+		SignerIP:       middleware.ClientIP(r),
+		UserAgent:      userAgent,
+		SignedAt:       time.Now().UTC().Truncate(time.Microsecond),
+	}
+	if err := qtx.InsertEstimateSignature(r.Context(), gen.InsertEstimateSignatureParams{
+		ID:             record.ID,
+		TenantID:       record.TenantID,
+		EstimateID:     record.EstimateID,
+		LinkID:         &link.ID,
+		RevisionNumber: record.RevisionNumber,
+		DocumentSha256: record.DocumentSHA256,
+		SignerName:     record.SignerName,
+		SignerIp:       record.SignerIP,
+		UserAgent:      record.UserAgent,
+		SignedAt:       record.SignedAt,
+		Signature:      s.Acceptance.Sign(record),
+	}); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to record signature", nil)
+		return
+	}
+	if err := qtx.MarkEstimateAcceptanceLinkAccepted(r.Context(), gen.MarkEstimateAcceptanceLinkAcceptedParams{AcceptedAt: &record.SignedAt, ID: link.ID}); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to record acceptance", nil)
+		return
+	}
+
+	var conversion *estimateConversion
+	if link.AutoConvert {
+		// One key per link, so a retried acceptance can never book twice.
+		converted, ok := s.convertEstimateTx(w, r, qtx, link.TenantID, link.EstimateID, link.CreatedBy, "estimate-acceptance:"+link.ID.String())
+		if !ok {
+			return
+		}
+		conversion = &converted
+	}
+
+	if err := tx.Commit(r.Context()); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to commit acceptance", nil)
+		return
+	}
+
+	_ = s.Audit.Log(r.Context(), audit.Entry{
+		TenantID:   link.TenantID,
+		Action:     "estimate.status_change",
+		EntityType: "estimate",
+		EntityID:   &link.EstimateID,
+		RequestID:  middleware.RequestIDFromContext(r.Context()),
+		Metadata: map[string]any{
+			"from": estimate.Status,
+			"to":   oapi.EstimateStatusAccepted,
+		},
+	})
+	_ = s.Audit.Log(r.Context(), audit.Entry{
+		TenantID:   link.TenantID,
+		Action:     "estimate.accepted_online",
+		EntityType: "estimate",
+		EntityID:   &link.EstimateID,
+		RequestID:  middleware.RequestIDFromContext(r.Context()),
+		Metadata: map[string]any{
+			"linkId":         link.ID,
+			"signatureId":    record.ID,
+			"signerName":     record.SignerName,
+			"ip":             record.SignerIP,
+			"revisionNumber": record.RevisionNumber,
+		},
+	})
+	if conversion != nil {
+		s.logEstimateConversion(r.Context(), link.TenantID, link.CreatedBy, link.EstimateID, *conversion)
+	}
+
+	link.AcceptedAt = &record.SignedAt
+	s.writePublicEstimate(w, r, link, http.StatusOK)
+}
+
+// loadAcceptanceLink resolves a token to its link, locking the row when
+// lock is set. Unknown ids and forged or altered tokens get the same 404.
+func (s *Server) loadAcceptanceLink(w http.ResponseWriter, r *http.Request, q *gen.Queries, token string, lock bool) (gen.EstimateAcceptanceLink, bool) {
+	linkID, ok := acceptance.LinkID(token)
+	if !ok {
+		writeAcceptanceLinkNotFound(w, r)
+		return gen.EstimateAcceptanceLink{}, false
+	}
+	var (
+		link gen.EstimateAcceptanceLink
+		err  error
+	)
+	if lock {
+		link, err = q.LockEstimateAcceptanceLink(r.Context(), linkID)
+	} else {
+		link, err = q.GetEstimateAcceptanceLink(r.Context(), linkID)
+	}
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			writeAcceptanceLinkNotFound(w, r)
+			return gen.EstimateAcceptanceLink{}, false
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load acceptance link", nil)
+		return gen.EstimateAcceptanceLink{}, false
+	}
+	if !s.Acceptance.VerifyLinkToken(token, link.ID, link.ExpiresAt) {
+		writeAcceptanceLinkNotFound(w, r)
+		return gen.EstimateAcceptanceLink{}, false
+	}
+	return link, true
+}
+
+// acceptanceLinkOpen reports whether the link may still be used. Accepted
+// links stay viewable until they expire.
+func acceptanceLinkOpen(link gen.EstimateAcceptanceLink) bool {
+	return link.RevokedAt == nil && time.Now().Before(link.ExpiresAt)
+}
+
+func writeAcceptanceLinkNotFound(w http.ResponseWriter, r *http.Request) {
+	httpx.WriteError(w, r, http.StatusNotFound, "acceptance_link_not_found", "This link is not valid", nil)
+}
+
+func writeAcceptanceLinkExpired(w http.ResponseWriter, r *http.Request) {
+	httpx.WriteError(w, r, http.StatusGone, "acceptance_link_expired", "This link has expired; please ask for a new one", nil)
+}
+
+// writePublicEstimate writes what the customer may see of the estimate:
+// no notes, contact details, internal ids or line item measurements.
+func (s *Server) writePublicEstimate(w http.ResponseWriter, r *http.Request, link gen.EstimateAcceptanceLink, status int) {
+	estimate, err := s.Q.GetEstimateByID(r.Context(), gen.GetEstimateByIDParams{ID: link.EstimateID, TenantID: link.TenantID})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load estimate", nil)
+		return
+	}
+	items, err := s.Q.ListEstimateLineItems(r.Context(), gen.ListEstimateLineItemsParams{TenantID: link.TenantID, EstimateID: link.EstimateID})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load line items", nil)
+		return
+	}
+	template, err := s.Q.GetEstimateDocumentTemplate(r.Context(), link.TenantID)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load estimate document template", nil)
+		return
+	}
+
+	public := oapi.PublicEstimate{
+		CompanyName:             documentValueOr(template.CompanyName, template.TenantName),
+		EstimateNumber:          estimate.EstimateNumber,
+		Status:                  oapi.PublicEstimateStatus(estimate.Status),
+		CustomerName:            estimate.CustomerName,
+		OriginAddressLine1:      estimate.OriginAddressLine1,
+		OriginCity:              estimate.OriginCity,
+		OriginState:             estimate.OriginState,
+		OriginPostalCode:        estimate.OriginPostalCode,
+		DestinationAddressLine1: estimate.DestinationAddressLine1,
+		DestinationCity:         estimate.DestinationCity,
+		DestinationState:        estimate.DestinationState,
+		DestinationPostalCode:   estimate.DestinationPostalCode,
+		MoveDate:                openapi_types.Date{Time: estimate.MoveDate},
+		PickupTime:              estimate.PickupTime,
+		EstimatedTotalCents:     estimate.EstimatedTotalCents,
+		DepositCents:            estimate.DepositCents,
+		ValidUntil:              estimate.ExpiresAt,
+		LinkExpiresAt:           link.ExpiresAt,
+		TermsText:               template.TermsText,
+		LineItems:               make([]oapi.PublicEstimateLineItem, 0, len(items)),
+		CanAccept:               link.AcceptedAt == nil && acceptanceLinkOpen(link) && estimate.Status == string(oapi.EstimateStatusSent),
+	}
+	for _, item := range items {
+		public.LineItems = append(public.LineItems, oapi.PublicEstimateLineItem{
+			Room:     item.Room,
+			ItemName: item.ItemName,
+			Quantity: int(item.Quantity),
+		})
+	}
+	if link.AcceptedAt != nil {
+		signature, err := s.Q.GetEstimateSignatureByLink(r.Context(), &link.ID)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load signature", nil)
+			return
+		}
+		if err == nil {
+			public.Acceptance = &oapi.PublicEstimateAcceptance{SignerName: signature.SignerName, SignedAt: signature.SignedAt}
+		}
+	}
+
+	httpx.WriteJSON(w, status, oapi.PublicEstimateResponse{
+		Estimate:  public,
+		RequestId: middleware.RequestIDFromContext(r.Context()),
+	})
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	gen "github.com/moveops-platform/apps/api/internal/gen/db"
+	"github.com/moveops-platform/apps/api/internal/gen/oapi"
+	"github.com/moveops-platform/apps/api/internal/httpx"
+)
+
+// resolveEstimateCustomer picks the customer a new estimate belongs to, in
+// order: the requested customerId, the customer with the same email, the
+// customer with the same phone number and name, and otherwise a new
+// customer. Emails and phone numbers are compared with the import
+// normalization. Phone matches with a different name are not attached,
+// since households share numbers; they come back as possible duplicates so
+// the user can merge them. It writes the error response when it fails.
+func (s *Server) resolveEstimateCustomer(w http.ResponseWriter, r *http.Request, qtx *gen.Queries, tenantID, userID uuid.UUID, req oapi.CreateEstimateRequest) (oapi.EstimateCustomerMatch, bool) {
+	match := oapi.EstimateCustomerMatch{PossibleDuplicates: []oapi.CustomerDuplicateHint{}}
+
+	if req.CustomerId != nil {
+		customer, err := qtx.GetCustomerByID(r.Context(), gen.GetCustomerByIDParams{ID: uuid.UUID(*req.CustomerId), TenantID: tenantID})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				httpx.WriteError(w, r, http.StatusNotFound, "customer_not_found", "Customer was not found", nil)
+				return match, false
+			}
+			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load customer", nil)
+			return match, false
+		}
+		match.CustomerId = customer.ID
+		match.MatchedOn = oapi.EstimateCustomerMatchMatchedOnCustomerId
+		return match, true
+	}
+
+	if email := normalizeEmail(string(req.Email)); email != "" {
+		customer, err := qtx.FindCustomerByEmail(r.Context(), gen.FindCustomerByEmailParams{TenantID: tenantID, Email: email})
+		if err == nil {
+			match.CustomerId = customer.ID
+			match.MatchedOn = oapi.EstimateCustomerMatchMatchedOnEmail
+			return match, true
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to match customer", nil)
+			return match, false
+		}
+	}
+
+	if digits := normalizePhone(req.PrimaryPhone); digits != "" {
+		candidates, err := qtx.FindCustomersByPhoneDigits(r.Context(), gen.FindCustomersByPhoneDigitsParams{TenantID: tenantID, PhoneDigits: digits})
+		if err != nil {
+			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to match customer", nil)
+			return match, false
+		}
+		for _, candidate := range candidates {
+			candidateName := strings.TrimSpace(candidate.FirstName + " " + candidate.LastName)
+			if normalizeCustomerName(candidateName) == normalizeCustomerName(req.CustomerName) {
+				match.CustomerId = candidate.ID
+				match.MatchedOn = oapi.EstimateCustomerMatchMatchedOnPhone
+				match.PossibleDuplicates = match.PossibleDuplicates[:0]
+				return match, true
+			}
+			match.PossibleDuplicates = append(match.PossibleDuplicates, oapi.CustomerDuplicateHint{
+				CustomerId:   candidate.ID,
+				CustomerName: candidateName,
+				MatchedOn:    oapi.CustomerDuplicateHintMatchedOnPhone,
+			})
+		}
+	}
+
+	firstName, lastName := splitName(req.CustomerName)
+	customer, err := qtx.CreateCustomerForEstimate(r.Context(), gen.CreateCustomerForEstimateParams{
+		TenantID:  tenantID,
+		FirstName: firstName,
+		LastName:  lastName,
+		Email:     ptr(strings.TrimSpace(string(req.Email))),
+		Phone:     ptr(strings.TrimSpace(req.PrimaryPhone)),
+		CreatedBy: &userID,
+		UpdatedBy: &userID,
+	})
+	if err != nil {
+		if isUniqueConstraint(err, "customers_tenant_email_uidx") {
+			httpx.WriteError(w, r, http.StatusConflict, "customer_conflict", "A customer with this email was created concurrently; retry the request", nil)
+			return match, false
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to create customer", nil)
+		return match, false
+	}
+	match.CustomerId = customer.ID
+	match.MatchedOn = oapi.EstimateCustomerMatchMatchedOnNone
+	return match, true
+}
+
+// normalizeCustomerName compares names case-insensitively and ignores
+// spacing.
+func normalizeCustomerName(value string) string {
+	return strings.ToLower(strings.Join(strings.Fields(value), " "))
+}
+
+// syncEstimateCustomer copies an edited estimate's contact details to its
+// customer while the estimate is that customer's only one. A customer shared
+// by several estimates is edited through /customers, so changes to an older
+// estimate never overwrite it with stale details.
+func syncEstimateCustomer(ctx context.Context, qtx *gen.Queries, tenantID, userID uuid.UUID, estimate gen.Estimate) error {
+	firstName, lastName := splitName(estimate.CustomerName)
+	_, err := qtx.SyncSoleEstimateCustomer(ctx, gen.SyncSoleEstimateCustomerParams{
+		FirstName:  firstName,
+		LastName:   lastName,
+		Email:      &estimate.Email,
+		Phone:      &estimate.PrimaryPhone,
+		UpdatedBy:  &userID,
+		ID:         estimate.CustomerID,
+		TenantID:   tenantID,
+		EstimateID: estimate.ID,
+	})
+	return err
+}
@@ -0,0 +1,600 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/moveops-platform/apps/api/internal/audit"
+	gen "github.com/moveops-platform/apps/api/internal/gen/db"
+	"github.com/moveops-platform/apps/api/internal/gen/oapi"
+	"github.com/moveops-platform/apps/api/internal/httpx"
+	"github.com/moveops-platform/apps/api/internal/middleware"
+	"github.com/moveops-platform/apps/api/internal/pdf"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+const (
+	defaultDocumentAccentColor = "#1f4e79"
+	maxDocumentLogoBytes       = 512 << 10
+
+	documentMargin        = 48.0
+	documentFooterHeight  = 28.0
+	documentContentWidth  = pdf.PageWidth - 2*documentMargin
+	documentMaxLogoWidth  = 150.0
+	documentMaxLogoHeight = 60.0
+	documentDateLayout    = "January 2, 2006"
+)
+
+var (
+	documentMutedColor = pdf.Color{R: 96, G: 96, B: 96}
+	documentRuleColor  = pdf.Color{R: 200, G: 200, B: 200}
+	documentShadeColor = pdf.Color{R: 244, G: 244, B: 244}
+)
+
+// GetSettingsEstimateDocument returns the tenant's estimate document
+// template, with defaults when none was saved.
+func (s *Server) GetSettingsEstimateDocument(w http.ResponseWriter, r *http.Request) {
+	_, tenantID, _, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	template, err := s.Q.GetEstimateDocumentTemplate(r.Context(), tenantID)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load estimate document template", nil)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, oapi.EstimateDocumentTemplateResponse{
+		Template:  mapEstimateDocumentTemplate(template),
+		RequestId: middleware.RequestIDFromContext(r.Context()),
+	})
+}
+
+// PutSettingsEstimateDocument replaces the tenant's estimate document
+// template. The logo is only touched when a new one is uploaded or
+// removeLogo is set.
+func (s *Server) PutSettingsEstimateDocument(w http.ResponseWriter, r *http.Request) {
+	_, tenantID, userID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req oapi.UpdateEstimateDocumentTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "invalid_body", "Malformed JSON body", nil)
+		return
+	}
+
+	accentColor := defaultDocumentAccentColor
+	if req.AccentColor != nil {
+		accentColor = strings.ToLower(strings.TrimSpace(*req.AccentColor))
+		if _, err := pdf.ParseHexColor(accentColor); err != nil {
+			httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "accentColor must be a #rrggbb color", nil)
+			return
+		}
+	}
+	showLineItems := true
+	if req.ShowLineItems != nil {
+		showLineItems = *req.ShowLineItems
+	}
+
+	removeLogo := req.RemoveLogo != nil && *req.RemoveLogo
+	var logo []byte
+	var logoContentType string
+	if req.Logo != nil && len(*req.Logo) > 0 {
+		if removeLogo {
+			httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "logo and removeLogo cannot be combined", nil)
+			return
+		}
+		logo = *req.Logo
+		if len(logo) > maxDocumentLogoBytes {
+			httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "logo must be at most 512 KB", nil)
+			return
+		}
+		logoContentType = http.DetectContentType(logo)
+		if logoContentType != "image/png" && logoContentType != "image/jpeg" {
+			httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "logo must be a PNG or JPEG image", nil)
+			return
+		}
+		if _, err := pdf.New("").AddImage(logo); err != nil {
+			httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "logo could not be read as an image", nil)
+			return
+		}
+	}
+
+	tx, err := s.DB.Begin(r.Context())
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to begin transaction", nil)
+		return
+	}
+	defer tx.Rollback(r.Context())
+	qtx := s.Q.WithTx(tx)
+
+	if err := qtx.UpsertEstimateDocumentTemplate(r.Context(), gen.UpsertEstimateDocumentTemplateParams{
+		TenantID:      tenantID,
+		CompanyName:   sanitizeOptional(req.CompanyName),
+		AccentColor:   accentColor,
+		IntroText:     sanitizeOptional(req.IntroText),
+		TermsText:     sanitizeOptional(req.TermsText),
+		FooterText:    sanitizeOptional(req.FooterText),
+		ShowLineItems: showLineItems,
+		UpdatedBy:     &userID,
+	}); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to update estimate document template", nil)
+		return
+	}
+	if logo != nil || removeLogo {
+		params := gen.SetEstimateDocumentLogoParams{TenantID: tenantID}
+		if logo != nil {
+			params.Logo = logo
+			params.LogoContentType = &logoContentType
+		}
+		if err := qtx.SetEstimateDocumentLogo(r.Context(), params); err != nil {
+			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to update logo", nil)
+			return
+		}
+	}
+
+	template, err := qtx.GetEstimateDocumentTemplate(r.Context(), tenantID)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load estimate document template", nil)
+		return
+	}
+	if err := tx.Commit(r.Context()); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to commit estimate document template", nil)
+		return
+	}
+
+	_ = s.Audit.Log(r.Context(), audit.Entry{
+		TenantID:   tenantID,
+		UserID:     &userID,
+		Action:     "settings.estimate_document_update",
+		EntityType: "tenant",
+		EntityID:   &tenantID,
+		RequestID:  middleware.RequestIDFromContext(r.Context()),
+		Metadata: map[string]any{
+			"accentColor":   template.AccentColor,
+			"showLineItems": template.ShowLineItems,
+			"hasLogo":       len(template.Logo) > 0,
+			"logoReplaced":  logo != nil,
+			"logoRemoved":   removeLogo,
+		},
+	})
+
+	httpx.WriteJSON(w, http.StatusOK, oapi.EstimateDocumentTemplateResponse{
+		Template:  mapEstimateDocumentTemplate(template),
+		RequestId: middleware.RequestIDFromContext(r.Context()),
+	})
+}
+
+// GetEstimatesEstimateIdDocumentPdf renders the estimate as a PDF with the
+// tenant's document template. Renders are audited since the document is
+// what customers are sent.
+func (s *Server) GetEstimatesEstimateIdDocumentPdf(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID) {
+	_, tenantID, userID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	s.expireEstimatesForRead(r.Context(), tenantID)
+
+	estimateID := uuid.UUID(estimateId)
+	estimate, err := s.Q.GetEstimateByID(r.Context(), gen.GetEstimateByIDParams{ID: estimateID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusNotFound, "estimate_not_found", "Estimate was not found", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load estimate", nil)
+		return
+	}
+	items, err := s.Q.ListEstimateLineItems(r.Context(), gen.ListEstimateLineItemsParams{TenantID: tenantID, EstimateID: estimateID})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load line items", nil)
+		return
+	}
+	template, err := s.Q.GetEstimateDocumentTemplate(r.Context(), tenantID)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load estimate document template", nil)
+		return
+	}
+
+	document, pages, err := renderEstimateDocument(estimate, items, template, time.Now().UTC())
+	if err != nil {
+		s.Logger.Error("render estimate document", "estimate_id", estimateID, "error", err)
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to render estimate document", nil)
+		return
+	}
+
+	_ = s.Audit.Log(r.Context(), audit.Entry{
+		TenantID:   tenantID,
+		UserID:     &userID,
+		Action:     "estimate.document_render",
+		EntityType: "estimate",
+		EntityID:   &estimateID,
+		RequestID:  middleware.RequestIDFromContext(r.Context()),
+		Metadata: map[string]any{
+			"estimateNumber": estimate.EstimateNumber,
+			"status":         estimate.Status,
+			"pages":          pages,
+			"bytes":          len(document),
+		},
+	})
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", estimate.EstimateNumber+".pdf"))
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(document)
+}
+
+func mapEstimateDocumentTemplate(row gen.GetEstimateDocumentTemplateRow) oapi.EstimateDocumentTemplate {
+	return oapi.EstimateDocumentTemplate{
+		CompanyName:     row.CompanyName,
+		AccentColor:     row.AccentColor,
+		HasLogo:         len(row.Logo) > 0,
+		LogoContentType: row.LogoContentType,
+		IntroText:       row.IntroText,
+		TermsText:       row.TermsText,
+		FooterText:      row.FooterText,
+		ShowLineItems:   row.ShowLineItems,
+		UpdatedAt:       row.UpdatedAt,
+	}
+}
+
+// renderEstimateDocument lays out the estimate on US Letter pages and
+// returns the PDF and its page count. preparedAt is the date printed on
+// estimates that were never sent.
+func renderEstimateDocument(estimate gen.Estimate, items []gen.EstimateLineItem, template gen.GetEstimateDocumentTemplateRow, preparedAt time.Time) ([]byte, int, error) {
+	accent, err := pdf.ParseHexColor(template.AccentColor)
+	if err != nil {
+		accent, _ = pdf.ParseHexColor(defaultDocumentAccentColor)
+	}
+	companyName := template.TenantName
+	if template.CompanyName != nil {
+		companyName = *template.CompanyName
+	}
+	if estimate.SentAt != nil {
+		preparedAt = *estimate.SentAt
+	}
+	validUntil := ""
+	if estimate.ExpiresAt != nil {
+		validUntil = estimate.ExpiresAt.UTC().Format(documentDateLayout)
+	}
+	placeholders := strings.NewReplacer(
+		"{{companyName}}", companyName,
+		"{{customerName}}", estimate.CustomerName,
+		"{{estimateNumber}}", estimate.EstimateNumber,
+		"{{moveDate}}", estimate.MoveDate.UTC().Format(documentDateLayout),
+		"{{validUntil}}", validUntil,
+	)
+
+	l := &documentLayout{doc: pdf.New("Estimate " + estimate.EstimateNumber), accent: accent}
+	l.newPage()
+
+	// Header: logo and company name on the left, estimate details on the right.
+	nameX := documentMargin
+	if len(template.Logo) > 0 {
+		// Stored logos were decoded on upload; one that no longer decodes is
+		// left out rather than failing the document.
+		if logo, err := l.doc.AddImage(template.Logo); err == nil {
+			width, height := fitDocumentImage(logo.Width(), logo.Height())
+			l.page.Image(logo, documentMargin, documentMargin, width, height)
+			nameX += width + 12
+		}
+	}
+	right := pdf.PageWidth - documentMargin
+	l.page.Text(nameX, documentMargin+18, pdf.HelveticaBold, 16, accent, truncateDocumentText(pdf.HelveticaBold, 16, right-160-nameX, companyName))
+	l.page.TextRight(right, documentMargin+18, pdf.HelveticaBold, 20, accent, "ESTIMATE")
+	l.page.TextRight(right, documentMargin+34, pdf.Helvetica, 10, pdf.Black, estimate.EstimateNumber)
+	l.page.TextRight(right, documentMargin+48, pdf.Helvetica, 9, documentMutedColor, "Date: "+preparedAt.UTC().Format(documentDateLayout))
+	if validUntil != "" {
+		l.page.TextRight(right, documentMargin+60, pdf.Helvetica, 9, documentMutedColor, "Valid until: "+validUntil)
+	}
+	l.y = documentMargin + 70
+	l.page.Rect(documentMargin, l.y, documentContentWidth, 2, accent)
+	l.y += 16
+
+	l.columns(
+		append([]string{"Prepared for", estimate.CustomerName}, nonEmptyStrings(estimate.Email, estimate.PrimaryPhone)...),
+		[]string{"Moving from", estimate.OriginAddressLine1, documentCityLine(estimate.OriginCity, estimate.OriginState, estimate.OriginPostalCode)},
+		[]string{"Moving to", estimate.DestinationAddressLine1, documentCityLine(estimate.DestinationCity, estimate.DestinationState, estimate.DestinationPostalCode)},
+	)
+	l.columns(
+		[]string{"Move date", estimate.MoveDate.UTC().Format(documentDateLayout)},
+		[]string{"Pickup time", documentValueOr(estimate.PickupTime, "To be confirmed")},
+		[]string{"Move size", documentValueOr(estimate.MoveSize, "Not specified")},
+	)
+
+	if template.IntroText != nil {
+		l.y += 6
+		l.paragraph(placeholders.Replace(*template.IntroText), pdf.Helvetica, 10, pdf.Black)
+	}
+
+	if template.ShowLineItems && len(items) > 0 {
+		l.heading("Inventory")
+		l.lineItemTable(items, estimate)
+	} else if estimate.TotalCubicFeet > 0 || estimate.TotalWeightLbs > 0 {
+		l.heading("Inventory")
+		l.paragraph(fmt.Sprintf("Estimated volume %s cu ft, estimated weight %s lbs.",
+			formatDocumentNumber(int64(estimate.TotalCubicFeet)), formatDocumentNumber(int64(estimate.TotalWeightLbs))), pdf.Helvetica, 10, pdf.Black)
+	}
+
+	l.heading("Charges")
+	if breakdown := mapPriceBreakdown(estimate.PriceBreakdown); breakdown != nil && derefString(estimate.PriceSource) == "tariff" {
+		for _, line := range breakdown.Lines {
+			l.amountRow(line.Description, formatDocumentCents(line.AmountCents), false)
+		}
+		l.reserve(8)
+		l.page.Line(documentMargin, l.y+2, right, l.y+2, 0.5, documentRuleColor)
+		l.y += 8
+	}
+	if estimate.EstimatedTotalCents != nil {
+		l.amountRow("Estimated total", formatDocumentCents(*estimate.EstimatedTotalCents), true)
+	} else {
+		l.amountRow("Estimated total", "To be confirmed", true)
+	}
+	if estimate.DepositCents != nil && *estimate.DepositCents > 0 {
+		l.amountRow("Deposit due to book", formatDocumentCents(*estimate.DepositCents), false)
+		if estimate.EstimatedTotalCents != nil {
+			l.amountRow("Balance due on move day", formatDocumentCents(*estimate.EstimatedTotalCents-*estimate.DepositCents), false)
+		}
+	}
+
+	if template.TermsText != nil {
+		l.heading("Terms")
+		l.paragraph(placeholders.Replace(*template.TermsText), pdf.Helvetica, 8.5, documentMutedColor)
+	}
+
+	// Footers go on last so every page knows the page count.
+	footer := ""
+	if template.FooterText != nil {
+		footer = strings.Join(strings.Fields(placeholders.Replace(*template.FooterText)), " ")
+	}
+	baseline := pdf.PageHeight - documentMargin
+	for i, page := range l.pages {
+		page.Line(documentMargin, baseline-14, right, baseline-14, 0.5, documentRuleColor)
+		if footer != "" {
+			page.Text(documentMargin, baseline, pdf.Helvetica, 8, documentMutedColor, truncateDocumentText(pdf.Helvetica, 8, documentContentWidth-80, footer))
+		}
+		page.TextRight(right, baseline, pdf.Helvetica, 8, documentMutedColor, fmt.Sprintf("Page %d of %d", i+1, len(l.pages)))
+	}
+
+	out, err := l.doc.Bytes()
+	if err != nil {
+		return nil, 0, err
+	}
+	return out, len(l.pages), nil
+}
+
+// documentLayout tracks the current page and the top of the next block, in
+// points from the top of the page.
+type documentLayout struct {
+	doc    *pdf.Document
+	pages  []*pdf.Page
+	page   *pdf.Page
+	y      float64
+	accent pdf.Color
+}
+
+func (l *documentLayout) newPage() {
+	l.page = l.doc.AddPage()
+	l.pages = append(l.pages, l.page)
+	l.y = documentMargin
+}
+
+// reserve starts a new page unless height still fits above the footer.
+func (l *documentLayout) reserve(height float64) bool {
+	if l.y+height > pdf.PageHeight-documentMargin-documentFooterHeight {
+		l.newPage()
+		return true
+	}
+	return false
+}
+
+func (l *documentLayout) heading(title string) {
+	// Keep the heading on the same page as the first lines under it.
+	l.reserve(48)
+	l.y += 12
+	l.page.Text(documentMargin, l.y+11, pdf.HelveticaBold, 11, l.accent, title)
+	l.y += 16
+	l.page.Line(documentMargin, l.y, pdf.PageWidth-documentMargin, l.y, 0.5, documentRuleColor)
+	l.y += 8
+}
+
+func (l *documentLayout) paragraph(text string, font pdf.Font, size float64, c pdf.Color) {
+	lineHeight := size * 1.4
+	for _, line := range pdf.Wrap(font, size, documentContentWidth, text) {
+		l.reserve(lineHeight)
+		l.page.Text(documentMargin, l.y+size, font, size, c, line)
+		l.y += lineHeight
+	}
+}
+
+// columns draws side-by-side blocks whose first entry is a label. Empty
+// values are skipped.
+func (l *documentLayout) columns(blocks ...[]string) {
+	const gap, labelHeight, lineHeight = 18.0, 13.0, 13.0
+	width := (documentContentWidth - gap*float64(len(blocks)-1)) / float64(len(blocks))
+	wrapped := make([][]string, len(blocks))
+	rows := 0
+	for i, block := range blocks {
+		for _, value := range block[1:] {
+			if strings.TrimSpace(value) == "" {
+				continue
+			}
+			wrapped[i] = append(wrapped[i], pdf.Wrap(pdf.Helvetica, 10, width, value)...)
+		}
+		rows = max(rows, len(wrapped[i]))
+	}
+	l.reserve(labelHeight + float64(rows)*lineHeight + 10)
+	for i, block := range blocks {
+		x := documentMargin + float64(i)*(width+gap)
+		l.page.Text(x, l.y+8, pdf.HelveticaBold, 8, documentMutedColor, strings.ToUpper(block[0]))
+		for row, line := range wrapped[i] {
+			l.page.Text(x, l.y+labelHeight+10+float64(row)*lineHeight, pdf.Helvetica, 10, pdf.Black, line)
+		}
+	}
+	l.y += labelHeight + float64(rows)*lineHeight + 10
+}
+
+// lineItemColumns are the left edges of the room and item columns and the
+// right edges of the numeric ones.
+var lineItemColumns = struct{ room, item, quantity, cubicFeet, weight float64 }{
+	room:      documentMargin + 6,
+	item:      documentMargin + 132,
+	quantity:  documentMargin + 370,
+	cubicFeet: documentMargin + 440,
+	weight:    pdf.PageWidth - documentMargin - 6,
+}
+
+func (l *documentLayout) lineItemTable(items []gen.EstimateLineItem, estimate gen.Estimate) {
+	const rowHeight = 16.0
+	l.lineItemHeader()
+	quantity := int64(0)
+	for i, item := range items {
+		if l.reserve(rowHeight) {
+			l.lineItemHeader()
+		}
+		if i%2 == 1 {
+			l.page.Rect(documentMargin, l.y, documentContentWidth, rowHeight, documentShadeColor)
+		}
+		c := lineItemColumns
+		baseline := l.y + 11
+		l.page.Text(c.room, baseline, pdf.Helvetica, 9, pdf.Black, truncateDocumentText(pdf.Helvetica, 9, c.item-c.room-8, item.Room))
+		l.page.Text(c.item, baseline, pdf.Helvetica, 9, pdf.Black, truncateDocumentText(pdf.Helvetica, 9, c.quantity-c.item-40, item.ItemName))
+		l.page.TextRight(c.quantity, baseline, pdf.Helvetica, 9, pdf.Black, strconv.Itoa(int(item.Quantity)))
+		l.page.TextRight(c.cubicFeet, baseline, pdf.Helvetica, 9, pdf.Black, formatDocumentNumber(int64(item.Quantity)*int64(item.CubicFeet)))
+		l.page.TextRight(c.weight, baseline, pdf.Helvetica, 9, pdf.Black, formatDocumentNumber(int64(item.Quantity)*int64(item.WeightLbs)))
+		quantity += int64(item.Quantity)
+		l.y += rowHeight
+	}
+	l.reserve(rowHeight + 4)
+	l.page.Line(documentMargin, l.y+1, pdf.PageWidth-documentMargin, l.y+1, 0.75, pdf.Black)
+	c := lineItemColumns
+	baseline := l.y + 13
+	l.page.Text(c.item, baseline, pdf.HelveticaBold, 9, pdf.Black, "Total")
+	l.page.TextRight(c.quantity, baseline, pdf.HelveticaBold, 9, pdf.Black, formatDocumentNumber(quantity))
+	l.page.TextRight(c.cubicFeet, baseline, pdf.HelveticaBold, 9, pdf.Black, formatDocumentNumber(int64(estimate.TotalCubicFeet)))
+	l.page.TextRight(c.weight, baseline, pdf.HelveticaBold, 9, pdf.Black, formatDocumentNumber(int64(estimate.TotalWeightLbs)))
+	l.y += rowHeight + 4
+}
+
+func (l *documentLayout) lineItemHeader() {
+	const height = 18.0
+	c := lineItemColumns
+	l.page.Rect(documentMargin, l.y, documentContentWidth, height, l.accent)
+	baseline := l.y + 12
+	l.page.Text(c.room, baseline, pdf.HelveticaBold, 9, pdf.White, "Room")
+	l.page.Text(c.item, baseline, pdf.HelveticaBold, 9, pdf.White, "Item")
+	l.page.TextRight(c.quantity, baseline, pdf.HelveticaBold, 9, pdf.White, "Qty")
+	l.page.TextRight(c.cubicFeet, baseline, pdf.HelveticaBold, 9, pdf.White, "Cu ft")
+	l.page.TextRight(c.weight, baseline, pdf.HelveticaBold, 9, pdf.White, "Weight (lbs)")
+	l.y += height
+}
+
+func (l *documentLayout) amountRow(label, amount string, bold bool) {
+	font, size := pdf.Helvetica, 10.0
+	if bold {
+		font, size = pdf.HelveticaBold, 11.0
+	}
+	right := pdf.PageWidth - documentMargin
+	amountWidth := pdf.TextWidth(font, size, amount)
+	lines := pdf.Wrap(font, size, documentContentWidth-amountWidth-24, label)
+	lineHeight := size * 1.5
+	l.reserve(float64(len(lines)) * lineHeight)
+	for i, line := range lines {
+		l.page.Text(documentMargin, l.y+size+float64(i)*lineHeight, font, size, pdf.Black, line)
+	}
+	l.page.TextRight(right, l.y+size, font, size, pdf.Black, amount)
+	l.y += float64(len(lines)) * lineHeight
+}
+
+// fitDocumentImage scales an image to the logo box, keeping its aspect
+// ratio.
+func fitDocumentImage(width, height int) (float64, float64) {
+	scale := min(documentMaxLogoWidth/float64(width), documentMaxLogoHeight/float64(height))
+	return float64(width) * scale, float64(height) * scale
+}
+
+// truncateDocumentText shortens text with an ellipsis to fit maxWidth.
+func truncateDocumentText(font pdf.Font, size, maxWidth float64, text string) string {
+	if pdf.TextWidth(font, size, text) <= maxWidth {
+		return text
+	}
+	for text != "" {
+		_, last := utf8.DecodeLastRuneInString(text)
+		text = strings.TrimRight(text[:len(text)-last], " ")
+		if pdf.TextWidth(font, size, text+"…") <= maxWidth {
+			return text + "…"
+		}
+	}
+	return ""
+}
+
+func documentCityLine(city, state, postalCode string) string {
+	line := strings.TrimSpace(city)
+	if state = strings.TrimSpace(state); state != "" {
+		if line != "" {
+			line += ", "
+		}
+		line += state
+	}
+	if postalCode = strings.TrimSpace(postalCode); postalCode != "" {
+		line = strings.TrimSpace(line + " " + postalCode)
+	}
+	return line
+}
+
+func documentValueOr(value *string, fallback string) string {
+	if value == nil || strings.TrimSpace(*value) == "" {
+		return fallback
+	}
+	return *value
+}
+
+func nonEmptyStrings(values ...string) []string {
+	out := make([]string, 0, len(values))
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			out = append(out, value)
+		}
+	}
+	return out
+}
+
+// formatDocumentCents formats cents as dollars with thousands separators,
+// e.g. $1,234.56.
+func formatDocumentCents(cents int64) string {
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s$%s.%02d", sign, formatDocumentNumber(cents/100), cents%100)
+}
+
+// formatDocumentNumber formats n with thousands separators.
+func formatDocumentNumber(n int64) string {
+	digits := strconv.FormatInt(n, 10)
+	sign := ""
+	if n < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(d)
+	}
+	return sign + b.String()
+}
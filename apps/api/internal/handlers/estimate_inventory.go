@@ -0,0 +1,599 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/moveops-platform/apps/api/internal/audit"
+	gen "github.com/moveops-platform/apps/api/internal/gen/db"
+	"github.com/moveops-platform/apps/api/internal/gen/oapi"
+	"github.com/moveops-platform/apps/api/internal/httpx"
+	"github.com/moveops-platform/apps/api/internal/middleware"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// poundsPerCubicFoot is the industry rule of thumb used when an item has no
+// weight of its own.
+const poundsPerCubicFoot = 7
+
+// Upper bounds for inventory amounts. A line's quantity times its size
+// stays inside INT; estimate totals are BIGINT since they sum any number
+// of lines.
+const (
+	maxLineItemQuantity = 1000
+	maxItemCubicFeet    = 10000
+	maxItemWeightLbs    = 100000
+)
+
+func (s *Server) GetInventoryItems(w http.ResponseWriter, r *http.Request, params oapi.GetInventoryItemsParams) {
+	_, tenantID, _, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	rows, err := s.Q.ListInventoryItems(r.Context(), gen.ListInventoryItemsParams{
+		TenantID:        tenantID,
+		IncludeInactive: params.IncludeInactive != nil && *params.IncludeInactive,
+	})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load inventory items", nil)
+		return
+	}
+
+	items := make([]oapi.InventoryItem, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, mapInventoryItem(row))
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, oapi.InventoryItemListResponse{
+		Items:     items,
+		RequestId: middleware.RequestIDFromContext(r.Context()),
+	})
+}
+
+func (s *Server) PostInventoryItems(w http.ResponseWriter, r *http.Request) {
+	_, tenantID, userID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req oapi.CreateInventoryItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "invalid_body", "Malformed JSON body", nil)
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "name is required", nil)
+		return
+	}
+	weightLbs := req.CubicFeet * poundsPerCubicFoot
+	if req.WeightLbs != nil {
+		weightLbs = *req.WeightLbs
+	}
+	if !validateInventoryAmounts(w, r, nil, &req.CubicFeet, &weightLbs) {
+		return
+	}
+
+	item, err := s.Q.CreateInventoryItem(r.Context(), gen.CreateInventoryItemParams{
+		TenantID:    tenantID,
+		Name:        name,
+		DefaultRoom: sanitizeOptional(req.DefaultRoom),
+		CubicFeet:   int32(req.CubicFeet),
+		WeightLbs:   int32(weightLbs),
+		CreatedBy:   &userID,
+	})
+	if err != nil {
+		if isUniqueConstraint(err, "inventory_items_tenant_name_key") {
+			httpx.WriteError(w, r, http.StatusConflict, "inventory_item_name_conflict", "An inventory item with this name already exists", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to create inventory item", nil)
+		return
+	}
+
+	itemID := item.ID
+	_ = s.Audit.Log(r.Context(), audit.Entry{
+		TenantID:   tenantID,
+		UserID:     &userID,
+		Action:     "inventory_item.create",
+		EntityType: "inventory_item",
+		EntityID:   &itemID,
+		RequestID:  middleware.RequestIDFromContext(r.Context()),
+		Metadata: map[string]any{
+			"name":      item.Name,
+			"cubicFeet": item.CubicFeet,
+			"weightLbs": item.WeightLbs,
+		},
+	})
+
+	httpx.WriteJSON(w, http.StatusCreated, oapi.InventoryItemResponse{
+		Item:      mapInventoryItem(item),
+		RequestId: middleware.RequestIDFromContext(r.Context()),
+	})
+}
+
+func (s *Server) PatchInventoryItemsItemId(w http.ResponseWriter, r *http.Request, itemId openapi_types.UUID) {
+	_, tenantID, userID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req oapi.UpdateInventoryItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "invalid_body", "Malformed JSON body", nil)
+		return
+	}
+	if req.Name == nil && req.DefaultRoom == nil && req.CubicFeet == nil && req.WeightLbs == nil && req.Active == nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "At least one field must be provided", nil)
+		return
+	}
+
+	name := sanitizeOptional(req.Name)
+	if req.Name != nil && name == nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "name cannot be blank", nil)
+		return
+	}
+	var defaultRoom *string
+	if req.DefaultRoom != nil {
+		trimmed := strings.TrimSpace(*req.DefaultRoom)
+		defaultRoom = &trimmed
+	}
+	if !validateInventoryAmounts(w, r, nil, req.CubicFeet, req.WeightLbs) {
+		return
+	}
+
+	item, err := s.Q.UpdateInventoryItem(r.Context(), gen.UpdateInventoryItemParams{
+		Name:        name,
+		DefaultRoom: defaultRoom,
+		CubicFeet:   intToInt32Ptr(req.CubicFeet),
+		WeightLbs:   intToInt32Ptr(req.WeightLbs),
+		Active:      req.Active,
+		UpdatedBy:   &userID,
+		ID:          uuid.UUID(itemId),
+		TenantID:    tenantID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusNotFound, "inventory_item_not_found", "Inventory item was not found", nil)
+			return
+		}
+		if isUniqueConstraint(err, "inventory_items_tenant_name_key") {
+			httpx.WriteError(w, r, http.StatusConflict, "inventory_item_name_conflict", "An inventory item with this name already exists", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to update inventory item", nil)
+		return
+	}
+
+	itemID := item.ID
+	_ = s.Audit.Log(r.Context(), audit.Entry{
+		TenantID:   tenantID,
+		UserID:     &userID,
+		Action:     "inventory_item.update",
+		EntityType: "inventory_item",
+		EntityID:   &itemID,
+		RequestID:  middleware.RequestIDFromContext(r.Context()),
+		Metadata: map[string]any{
+			"name":      item.Name,
+			"cubicFeet": item.CubicFeet,
+			"weightLbs": item.WeightLbs,
+			"active":    item.Active,
+		},
+	})
+
+	httpx.WriteJSON(w, http.StatusOK, oapi.InventoryItemResponse{
+		Item:      mapInventoryItem(item),
+		RequestId: middleware.RequestIDFromContext(r.Context()),
+	})
+}
+
+func (s *Server) DeleteInventoryItemsItemId(w http.ResponseWriter, r *http.Request, itemId openapi_types.UUID) {
+	_, tenantID, userID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	itemID := uuid.UUID(itemId)
+	deleted, err := s.Q.DeleteInventoryItem(r.Context(), gen.DeleteInventoryItemParams{ID: itemID, TenantID: tenantID})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to delete inventory item", nil)
+		return
+	}
+	if deleted == 0 {
+		httpx.WriteError(w, r, http.StatusNotFound, "inventory_item_not_found", "Inventory item was not found", nil)
+		return
+	}
+
+	_ = s.Audit.Log(r.Context(), audit.Entry{
+		TenantID:   tenantID,
+		UserID:     &userID,
+		Action:     "inventory_item.delete",
+		EntityType: "inventory_item",
+		EntityID:   &itemID,
+		RequestID:  middleware.RequestIDFromContext(r.Context()),
+	})
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) GetEstimatesEstimateIdLineItems(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID) {
+	_, tenantID, _, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	estimateID := uuid.UUID(estimateId)
+	estimate, err := s.Q.GetEstimateByID(r.Context(), gen.GetEstimateByIDParams{ID: estimateID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusNotFound, "estimate_not_found", "Estimate was not found", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load estimate", nil)
+		return
+	}
+
+	rows, err := s.Q.ListEstimateLineItems(r.Context(), gen.ListEstimateLineItemsParams{TenantID: tenantID, EstimateID: estimateID})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load line items", nil)
+		return
+	}
+
+	items := make([]oapi.EstimateLineItem, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, mapEstimateLineItem(row))
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, oapi.EstimateLineItemListResponse{
+		Items:          items,
+		TotalCubicFeet: int(estimate.TotalCubicFeet),
+		TotalWeightLbs: int(estimate.TotalWeightLbs),
+		RequestId:      middleware.RequestIDFromContext(r.Context()),
+	})
+}
+
+func (s *Server) PostEstimatesEstimateIdLineItems(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID) {
+	_, tenantID, userID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req oapi.CreateEstimateLineItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "invalid_body", "Malformed JSON body", nil)
+		return
+	}
+
+	var (
+		inventoryItemID *uuid.UUID
+		itemName        = sanitizeOptional(req.ItemName)
+		room            = sanitizeOptional(req.Room)
+		cubicFeet       = req.CubicFeet
+		weightLbs       = req.WeightLbs
+	)
+	if req.InventoryItemId != nil {
+		item, err := s.Q.GetInventoryItem(r.Context(), gen.GetInventoryItemParams{ID: uuid.UUID(*req.InventoryItemId), TenantID: tenantID})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				httpx.WriteError(w, r, http.StatusNotFound, "inventory_item_not_found", "Inventory item was not found", nil)
+				return
+			}
+			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load inventory item", nil)
+			return
+		}
+		if !item.Active {
+			httpx.WriteError(w, r, http.StatusConflict, "inventory_item_inactive", "Inventory item is no longer active", nil)
+			return
+		}
+		inventoryItemID = &item.ID
+		if itemName == nil {
+			itemName = &item.Name
+		}
+		if room == nil {
+			room = item.DefaultRoom
+		}
+		if cubicFeet == nil {
+			cubicFeet = ptr(int(item.CubicFeet))
+		}
+		if weightLbs == nil {
+			weightLbs = ptr(int(item.WeightLbs))
+		}
+	}
+	if itemName == nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "itemName is required without an inventoryItemId", nil)
+		return
+	}
+	if room == nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "room is required", nil)
+		return
+	}
+	if cubicFeet == nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "cubicFeet is required without an inventoryItemId", nil)
+		return
+	}
+	if weightLbs == nil {
+		weightLbs = ptr(*cubicFeet * poundsPerCubicFoot)
+	}
+	quantity := 1
+	if req.Quantity != nil {
+		quantity = *req.Quantity
+	}
+	if !validateInventoryAmounts(w, r, &quantity, cubicFeet, weightLbs) {
+		return
+	}
+
+	estimateID := uuid.UUID(estimateId)
+	tx, err := s.DB.Begin(r.Context())
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to begin transaction", nil)
+		return
+	}
+	defer tx.Rollback(r.Context())
+	qtx := s.Q.WithTx(tx)
+
+	if !s.lockEstimate(w, r, qtx, tenantID, estimateID) {
+		return
+	}
+	lineItem, err := qtx.CreateEstimateLineItem(r.Context(), gen.CreateEstimateLineItemParams{
+		TenantID:        tenantID,
+		EstimateID:      estimateID,
+		InventoryItemID: inventoryItemID,
+		Room:            *room,
+		ItemName:        *itemName,
+		Quantity:        int32(quantity),
+		CubicFeet:       int32(*cubicFeet),
+		WeightLbs:       int32(*weightLbs),
+		CreatedBy:       &userID,
+	})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to create line item", nil)
+		return
+	}
+	totals, err := qtx.RecalculateEstimateTotals(r.Context(), gen.RecalculateEstimateTotalsParams{UpdatedBy: &userID, ID: estimateID, TenantID: tenantID})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to recalculate estimate totals", nil)
+		return
+	}
+	if err := recordEstimateRevision(r.Context(), qtx, tenantID, estimateID, &userID, revisionLineItems, nil); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to record estimate revision", nil)
+		return
+	}
+
+	if err := tx.Commit(r.Context()); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to commit line item", nil)
+		return
+	}
+
+	s.auditLineItemChange(r, tenantID, userID, "estimate.line_item_add", lineItem, totals)
+	writeLineItemResponse(w, r, http.StatusCreated, lineItem, totals)
+}
+
+func (s *Server) PatchEstimatesEstimateIdLineItemsLineItemId(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID, lineItemId openapi_types.UUID) {
+	_, tenantID, userID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req oapi.UpdateEstimateLineItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "invalid_body", "Malformed JSON body", nil)
+		return
+	}
+	if req.Room == nil && req.ItemName == nil && req.Quantity == nil && req.CubicFeet == nil && req.WeightLbs == nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "At least one field must be provided", nil)
+		return
+	}
+
+	room := sanitizeOptional(req.Room)
+	if req.Room != nil && room == nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "room cannot be blank", nil)
+		return
+	}
+	itemName := sanitizeOptional(req.ItemName)
+	if req.ItemName != nil && itemName == nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "itemName cannot be blank", nil)
+		return
+	}
+	if !validateInventoryAmounts(w, r, req.Quantity, req.CubicFeet, req.WeightLbs) {
+		return
+	}
+
+	estimateID := uuid.UUID(estimateId)
+	tx, err := s.DB.Begin(r.Context())
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to begin transaction", nil)
+		return
+	}
+	defer tx.Rollback(r.Context())
+	qtx := s.Q.WithTx(tx)
+
+	if !s.lockEstimate(w, r, qtx, tenantID, estimateID) {
+		return
+	}
+	lineItem, err := qtx.UpdateEstimateLineItem(r.Context(), gen.UpdateEstimateLineItemParams{
+		Room:       room,
+		ItemName:   itemName,
+		Quantity:   intToInt32Ptr(req.Quantity),
+		CubicFeet:  intToInt32Ptr(req.CubicFeet),
+		WeightLbs:  intToInt32Ptr(req.WeightLbs),
+		UpdatedBy:  &userID,
+		ID:         uuid.UUID(lineItemId),
+		TenantID:   tenantID,
+		EstimateID: estimateID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusNotFound, "line_item_not_found", "Line item was not found", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to update line item", nil)
+		return
+	}
+	totals, err := qtx.RecalculateEstimateTotals(r.Context(), gen.RecalculateEstimateTotalsParams{UpdatedBy: &userID, ID: estimateID, TenantID: tenantID})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to recalculate estimate totals", nil)
+		return
+	}
+	if err := recordEstimateRevision(r.Context(), qtx, tenantID, estimateID, &userID, revisionLineItems, nil); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to record estimate revision", nil)
+		return
+	}
+
+	if err := tx.Commit(r.Context()); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to commit line item", nil)
+		return
+	}
+
+	s.auditLineItemChange(r, tenantID, userID, "estimate.line_item_update", lineItem, totals)
+	writeLineItemResponse(w, r, http.StatusOK, lineItem, totals)
+}
+
+func (s *Server) DeleteEstimatesEstimateIdLineItemsLineItemId(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID, lineItemId openapi_types.UUID) {
+	_, tenantID, userID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	estimateID := uuid.UUID(estimateId)
+	tx, err := s.DB.Begin(r.Context())
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to begin transaction", nil)
+		return
+	}
+	defer tx.Rollback(r.Context())
+	qtx := s.Q.WithTx(tx)
+
+	if !s.lockEstimate(w, r, qtx, tenantID, estimateID) {
+		return
+	}
+	lineItem, err := qtx.GetEstimateLineItem(r.Context(), gen.GetEstimateLineItemParams{ID: uuid.UUID(lineItemId), TenantID: tenantID, EstimateID: estimateID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusNotFound, "line_item_not_found", "Line item was not found", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load line item", nil)
+		return
+	}
+	if _, err := qtx.DeleteEstimateLineItem(r.Context(), gen.DeleteEstimateLineItemParams{ID: lineItem.ID, TenantID: tenantID, EstimateID: estimateID}); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to delete line item", nil)
+		return
+	}
+	totals, err := qtx.RecalculateEstimateTotals(r.Context(), gen.RecalculateEstimateTotalsParams{UpdatedBy: &userID, ID: estimateID, TenantID: tenantID})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to recalculate estimate totals", nil)
+		return
+	}
+	if err := recordEstimateRevision(r.Context(), qtx, tenantID, estimateID, &userID, revisionLineItems, nil); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to record estimate revision", nil)
+		return
+	}
+
+	if err := tx.Commit(r.Context()); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to commit line item removal", nil)
+		return
+	}
+
+	s.auditLineItemChange(r, tenantID, userID, "estimate.line_item_remove", lineItem, totals)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// lockEstimate takes the estimate row lock that serializes line item changes,
+// so each recomputation sees every committed line item.
+func (s *Server) lockEstimate(w http.ResponseWriter, r *http.Request, qtx *gen.Queries, tenantID, estimateID uuid.UUID) bool {
+	if _, err := qtx.LockEstimate(r.Context(), gen.LockEstimateParams{ID: estimateID, TenantID: tenantID}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusNotFound, "estimate_not_found", "Estimate was not found", nil)
+			return false
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to lock estimate", nil)
+		return false
+	}
+	return true
+}
+
+func (s *Server) auditLineItemChange(r *http.Request, tenantID, userID uuid.UUID, action string, lineItem gen.EstimateLineItem, totals gen.RecalculateEstimateTotalsRow) {
+	estimateID := lineItem.EstimateID
+	_ = s.Audit.Log(r.Context(), audit.Entry{
+		TenantID:   tenantID,
+		UserID:     &userID,
+		Action:     action,
+		EntityType: "estimate",
+		EntityID:   &estimateID,
+		RequestID:  middleware.RequestIDFromContext(r.Context()),
+		Metadata: map[string]any{
+			"lineItemId":     lineItem.ID,
+			"room":           lineItem.Room,
+			"itemName":       lineItem.ItemName,
+			"quantity":       lineItem.Quantity,
+			"totalCubicFeet": totals.TotalCubicFeet,
+			"totalWeightLbs": totals.TotalWeightLbs,
+		},
+	})
+}
+
+func writeLineItemResponse(w http.ResponseWriter, r *http.Request, status int, lineItem gen.EstimateLineItem, totals gen.RecalculateEstimateTotalsRow) {
+	httpx.WriteJSON(w, status, oapi.EstimateLineItemResponse{
+		LineItem:       mapEstimateLineItem(lineItem),
+		TotalCubicFeet: int(totals.TotalCubicFeet),
+		TotalWeightLbs: int(totals.TotalWeightLbs),
+		RequestId:      middleware.RequestIDFromContext(r.Context()),
+	})
+}
+
+// validateInventoryAmounts checks the optional quantity, cubic feet and
+// weight against their bounds.
+func validateInventoryAmounts(w http.ResponseWriter, r *http.Request, quantity, cubicFeet, weightLbs *int) bool {
+	if quantity != nil && (*quantity < 1 || *quantity > maxLineItemQuantity) {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "quantity must be between 1 and 1000", nil)
+		return false
+	}
+	if cubicFeet != nil && (*cubicFeet < 0 || *cubicFeet > maxItemCubicFeet) {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "cubicFeet must be between 0 and 10000", nil)
+		return false
+	}
+	if weightLbs != nil && (*weightLbs < 0 || *weightLbs > maxItemWeightLbs) {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "weightLbs must be between 0 and 100000", nil)
+		return false
+	}
+	return true
+}
+
+func mapInventoryItem(item gen.InventoryItem) oapi.InventoryItem {
+	return oapi.InventoryItem{
+		Id:          item.ID,
+		Name:        item.Name,
+		DefaultRoom: item.DefaultRoom,
+		CubicFeet:   int(item.CubicFeet),
+		WeightLbs:   int(item.WeightLbs),
+		Active:      item.Active,
+		CreatedAt:   item.CreatedAt.UTC(),
+		UpdatedAt:   item.UpdatedAt.UTC(),
+	}
+}
+
+func mapEstimateLineItem(item gen.EstimateLineItem) oapi.EstimateLineItem {
+	var inventoryItemID *openapi_types.UUID
+	if item.InventoryItemID != nil {
+		id := openapi_types.UUID(*item.InventoryItemID)
+		inventoryItemID = &id
+	}
+	return oapi.EstimateLineItem{
+		Id:              item.ID,
+		EstimateId:      item.EstimateID,
+		InventoryItemId: inventoryItemID,
+		Room:            item.Room,
+		ItemName:        item.ItemName,
+		Quantity:        int(item.Quantity),
+		CubicFeet:       int(item.CubicFeet),
+		WeightLbs:       int(item.WeightLbs),
+		CreatedAt:       item.CreatedAt.UTC(),
+		UpdatedAt:       item.UpdatedAt.UTC(),
+	}
+}
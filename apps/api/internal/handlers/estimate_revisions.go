@@ -0,0 +1,486 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/moveops-platform/apps/api/internal/audit"
+	gen "github.com/moveops-platform/apps/api/internal/gen/db"
+	"github.com/moveops-platform/apps/api/internal/gen/oapi"
+	"github.com/moveops-platform/apps/api/internal/httpx"
+	"github.com/moveops-platform/apps/api/internal/middleware"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// Revision change kinds, matching the estimate_revisions.change check.
+const (
+	revisionCreated       = "created"
+	revisionUpdated       = "updated"
+	revisionStatusChanged = "status_changed"
+	revisionLineItems     = "line_items"
+	revisionPriced        = "priced"
+	revisionConverted     = "converted"
+	revisionExpired       = "expired"
+	revisionImported      = "imported"
+	revisionRestored      = "restored"
+)
+
+// recordEstimateRevision stores the estimate and its line items as the next
+// revision. Callers run it in the transaction that changed the estimate,
+// after updating or locking the estimate row; that row lock is what keeps
+// revision numbers sequential under concurrent edits.
+func recordEstimateRevision(ctx context.Context, q *gen.Queries, tenantID, estimateID uuid.UUID, userID *uuid.UUID, change string, restoredFrom *int32) error {
+	snapshot, err := loadEstimateSnapshot(ctx, q, tenantID, estimateID)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	_, err = q.InsertEstimateRevision(ctx, gen.InsertEstimateRevisionParams{
+		TenantID:     tenantID,
+		EstimateID:   estimateID,
+		Change:       change,
+		RestoredFrom: restoredFrom,
+		Snapshot:     payload,
+		CreatedBy:    userID,
+	})
+	return err
+}
+
+func loadEstimateSnapshot(ctx context.Context, q *gen.Queries, tenantID, estimateID uuid.UUID) (oapi.EstimateRevisionSnapshot, error) {
+	detail, err := q.GetEstimateDetailByID(ctx, gen.GetEstimateDetailByIDParams{ID: estimateID, TenantID: tenantID})
+	if err != nil {
+		return oapi.EstimateRevisionSnapshot{}, err
+	}
+	items, err := q.ListEstimateLineItems(ctx, gen.ListEstimateLineItemsParams{TenantID: tenantID, EstimateID: estimateID})
+	if err != nil {
+		return oapi.EstimateRevisionSnapshot{}, err
+	}
+	lineItems := make([]oapi.EstimateLineItem, 0, len(items))
+	for _, item := range items {
+		lineItems = append(lineItems, mapEstimateLineItem(item))
+	}
+	return oapi.EstimateRevisionSnapshot{Estimate: mapEstimateDetail(detail), LineItems: lineItems}, nil
+}
+
+func (s *Server) GetEstimatesEstimateIdRevisions(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID) {
+	_, tenantID, _, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	estimateID := uuid.UUID(estimateId)
+	if _, err := s.Q.GetEstimateByID(r.Context(), gen.GetEstimateByIDParams{ID: estimateID, TenantID: tenantID}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusNotFound, "estimate_not_found", "Estimate was not found", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load estimate", nil)
+		return
+	}
+
+	rows, err := s.Q.ListEstimateRevisions(r.Context(), gen.ListEstimateRevisionsParams{TenantID: tenantID, EstimateID: estimateID})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to list estimate revisions", nil)
+		return
+	}
+	items := make([]oapi.EstimateRevisionSummary, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, oapi.EstimateRevisionSummary{
+			RevisionNumber: int(row.RevisionNumber),
+			Change:         oapi.EstimateRevisionSummaryChange(row.Change),
+			RestoredFrom:   int32PtrToIntPtr(row.RestoredFrom),
+			CreatedBy:      row.CreatedBy,
+			CreatedByName:  row.CreatedByName,
+			CreatedAt:      row.CreatedAt.UTC(),
+		})
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, oapi.EstimateRevisionListResponse{
+		Items:     items,
+		RequestId: middleware.RequestIDFromContext(r.Context()),
+	})
+}
+
+func (s *Server) GetEstimatesEstimateIdRevisionsRevisionNumber(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID, revisionNumber int) {
+	_, tenantID, _, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	revision, snapshot, ok := s.loadEstimateRevision(w, r, s.Q, tenantID, uuid.UUID(estimateId), revisionNumber)
+	if !ok {
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, oapi.EstimateRevisionResponse{
+		Revision: oapi.EstimateRevision{
+			RevisionNumber: int(revision.RevisionNumber),
+			Change:         oapi.EstimateRevisionChange(revision.Change),
+			RestoredFrom:   int32PtrToIntPtr(revision.RestoredFrom),
+			CreatedBy:      revision.CreatedBy,
+			CreatedByName:  revision.CreatedByName,
+			CreatedAt:      revision.CreatedAt.UTC(),
+			Snapshot:       snapshot,
+		},
+		RequestId: middleware.RequestIDFromContext(r.Context()),
+	})
+}
+
+func (s *Server) GetEstimatesEstimateIdRevisionsRevisionNumberDiff(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID, revisionNumber int, params oapi.GetEstimatesEstimateIdRevisionsRevisionNumberDiffParams) {
+	_, tenantID, _, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	estimateID := uuid.UUID(estimateId)
+	_, to, ok := s.loadEstimateRevision(w, r, s.Q, tenantID, estimateID, revisionNumber)
+	if !ok {
+		return
+	}
+
+	against := 0
+	if params.Against != nil {
+		against = *params.Against
+	} else {
+		previous, err := s.Q.GetPreviousEstimateRevisionNumber(r.Context(), gen.GetPreviousEstimateRevisionNumberParams{
+			TenantID:       tenantID,
+			EstimateID:     estimateID,
+			RevisionNumber: int32(revisionNumber),
+		})
+		if err != nil {
+			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load previous revision", nil)
+			return
+		}
+		against = int(previous)
+	}
+
+	// Revision 0 is the empty estimate the first revision is compared with.
+	var from *oapi.EstimateRevisionSnapshot
+	if against > 0 {
+		_, snapshot, ok := s.loadEstimateRevision(w, r, s.Q, tenantID, estimateID, against)
+		if !ok {
+			return
+		}
+		from = &snapshot
+	}
+
+	diff, err := diffEstimateSnapshots(from, to)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to compare revisions", nil)
+		return
+	}
+	diff.FromRevision = against
+	diff.ToRevision = revisionNumber
+
+	httpx.WriteJSON(w, http.StatusOK, oapi.EstimateRevisionDiffResponse{
+		Diff:      diff,
+		RequestId: middleware.RequestIDFromContext(r.Context()),
+	})
+}
+
+func (s *Server) PostEstimatesEstimateIdRevisionsRevisionNumberRestore(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID, revisionNumber int) {
+	_, tenantID, userID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	estimateID := uuid.UUID(estimateId)
+	tx, err := s.DB.Begin(r.Context())
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to start transaction", nil)
+		return
+	}
+	defer tx.Rollback(r.Context())
+	qtx := s.Q.WithTx(tx)
+
+	if !s.lockEstimate(w, r, qtx, tenantID, estimateID) {
+		return
+	}
+	before, err := qtx.GetEstimateByID(r.Context(), gen.GetEstimateByIDParams{ID: estimateID, TenantID: tenantID})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load estimate", nil)
+		return
+	}
+	_, snapshot, ok := s.loadEstimateRevision(w, r, qtx, tenantID, estimateID, revisionNumber)
+	if !ok {
+		return
+	}
+
+	restored := snapshot.Estimate
+	if depositPosted(before, restored.DepositCents) {
+		writeDepositPosted(w, r)
+		return
+	}
+	var priceSource *string
+	if restored.PriceSource != nil {
+		source := string(*restored.PriceSource)
+		priceSource = &source
+	}
+	var priceBreakdown []byte
+	if restored.PriceBreakdown != nil {
+		priceBreakdown, err = json.Marshal(restored.PriceBreakdown)
+		if err != nil {
+			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to restore price breakdown", nil)
+			return
+		}
+	}
+
+	updated, err := qtx.RestoreEstimateFields(r.Context(), gen.RestoreEstimateFieldsParams{
+		CustomerName:            restored.CustomerName,
+		PrimaryPhone:            restored.PrimaryPhone,
+		SecondaryPhone:          restored.SecondaryPhone,
+		Email:                   string(restored.Email),
+		OriginAddressLine1:      restored.OriginAddressLine1,
+		OriginCity:              restored.OriginCity,
+		OriginState:             restored.OriginState,
+		OriginPostalCode:        restored.OriginPostalCode,
+		DestinationAddressLine1: restored.DestinationAddressLine1,
+		DestinationCity:         restored.DestinationCity,
+		DestinationState:        restored.DestinationState,
+		DestinationPostalCode:   restored.DestinationPostalCode,
+		MoveDate:                restored.MoveDate.Time,
+		PickupTime:              restored.PickupTime,
+		LeadSource:              restored.LeadSource,
+		MoveSize:                restored.MoveSize,
+		LocationType:            restored.LocationType,
+		EstimatedTotalCents:     restored.EstimatedTotalCents,
+		PriceSource:             priceSource,
+		PriceBreakdown:          priceBreakdown,
+		PricedAt:                restored.PricedAt,
+		DepositCents:            restored.DepositCents,
+		Notes:                   restored.Notes,
+		UpdatedBy:               &userID,
+		ID:                      estimateID,
+		TenantID:                tenantID,
+	})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to restore estimate", nil)
+		return
+	}
+
+	if updated.CustomerName != before.CustomerName || updated.Email != before.Email || updated.PrimaryPhone != before.PrimaryPhone {
+		if err := syncEstimateCustomer(r.Context(), qtx, tenantID, userID, updated); err != nil {
+			if isUniqueConstraint(err, "customers_tenant_email_uidx") {
+				httpx.WriteError(w, r, http.StatusConflict, "customer_email_conflict", "Another customer already uses this email", nil)
+				return
+			}
+			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to update customer", nil)
+			return
+		}
+	}
+
+	if err := qtx.DeleteEstimateLineItems(r.Context(), gen.DeleteEstimateLineItemsParams{TenantID: tenantID, EstimateID: estimateID}); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to restore line items", nil)
+		return
+	}
+	for _, item := range snapshot.LineItems {
+		if err := qtx.RestoreEstimateLineItem(r.Context(), gen.RestoreEstimateLineItemParams{
+			ID:              item.Id,
+			TenantID:        tenantID,
+			EstimateID:      estimateID,
+			InventoryItemID: item.InventoryItemId,
+			Room:            item.Room,
+			ItemName:        item.ItemName,
+			Quantity:        int32(item.Quantity),
+			CubicFeet:       int32(item.CubicFeet),
+			WeightLbs:       int32(item.WeightLbs),
+			UpdatedBy:       &userID,
+			CreatedAt:       item.CreatedAt,
+		}); err != nil {
+			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to restore line items", nil)
+			return
+		}
+	}
+	if _, err := qtx.RecalculateEstimateTotals(r.Context(), gen.RecalculateEstimateTotalsParams{
+		UpdatedBy: &userID,
+		ID:        estimateID,
+		TenantID:  tenantID,
+	}); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to recalculate estimate totals", nil)
+		return
+	}
+
+	restoredFrom := int32(revisionNumber)
+	if err := recordEstimateRevision(r.Context(), qtx, tenantID, estimateID, &userID, revisionRestored, &restoredFrom); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to record estimate revision", nil)
+		return
+	}
+
+	if err := tx.Commit(r.Context()); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to commit estimate restore", nil)
+		return
+	}
+
+	_ = s.Audit.Log(r.Context(), audit.Entry{
+		TenantID:   tenantID,
+		UserID:     &userID,
+		Action:     "estimate.revision_restore",
+		EntityType: "estimate",
+		EntityID:   &estimateID,
+		RequestID:  middleware.RequestIDFromContext(r.Context()),
+		Metadata: map[string]any{
+			"restoredFrom":  revisionNumber,
+			"fieldsChanged": estimateChangedFields(before, updated),
+			"lineItems":     len(snapshot.LineItems),
+		},
+	})
+
+	s.writeEstimateResponse(w, r, tenantID, estimateID, http.StatusOK)
+}
+
+// loadEstimateRevision loads a revision and decodes its snapshot, writing
+// the error response when it fails.
+func (s *Server) loadEstimateRevision(w http.ResponseWriter, r *http.Request, q *gen.Queries, tenantID, estimateID uuid.UUID, revisionNumber int) (gen.GetEstimateRevisionRow, oapi.EstimateRevisionSnapshot, bool) {
+	revision, err := q.GetEstimateRevision(r.Context(), gen.GetEstimateRevisionParams{
+		TenantID:       tenantID,
+		EstimateID:     estimateID,
+		RevisionNumber: int32(revisionNumber),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusNotFound, "estimate_revision_not_found", "Estimate revision was not found", map[string]any{
+				"revisionNumber": revisionNumber,
+			})
+			return gen.GetEstimateRevisionRow{}, oapi.EstimateRevisionSnapshot{}, false
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load estimate revision", nil)
+		return gen.GetEstimateRevisionRow{}, oapi.EstimateRevisionSnapshot{}, false
+	}
+	var snapshot oapi.EstimateRevisionSnapshot
+	if err := json.Unmarshal(revision.Snapshot, &snapshot); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to read estimate revision", nil)
+		return gen.GetEstimateRevisionRow{}, oapi.EstimateRevisionSnapshot{}, false
+	}
+	return revision, snapshot, true
+}
+
+// diffEstimateSnapshots compares two snapshots field by field using their API
+// JSON names. A nil from stands for an empty estimate. updatedAt is left out
+// since every revision changes it.
+func diffEstimateSnapshots(from *oapi.EstimateRevisionSnapshot, to oapi.EstimateRevisionSnapshot) (oapi.EstimateRevisionDiff, error) {
+	diff := oapi.EstimateRevisionDiff{
+		Fields:           []oapi.EstimateFieldChange{},
+		LineItemsAdded:   []oapi.EstimateLineItem{},
+		LineItemsRemoved: []oapi.EstimateLineItem{},
+		LineItemsChanged: []oapi.EstimateLineItemChange{},
+	}
+
+	var fromEstimate any
+	fromItems := map[uuid.UUID]oapi.EstimateLineItem{}
+	if from != nil {
+		fromEstimate = from.Estimate
+		for _, item := range from.LineItems {
+			fromItems[item.Id] = item
+		}
+	}
+	fields, err := diffJSONFields(fromEstimate, to.Estimate)
+	if err != nil {
+		return oapi.EstimateRevisionDiff{}, err
+	}
+	diff.Fields = fields
+
+	for _, item := range to.LineItems {
+		before, ok := fromItems[item.Id]
+		if !ok {
+			diff.LineItemsAdded = append(diff.LineItemsAdded, item)
+			continue
+		}
+		delete(fromItems, item.Id)
+		changes, err := diffJSONFields(before, item)
+		if err != nil {
+			return oapi.EstimateRevisionDiff{}, err
+		}
+		if len(changes) > 0 {
+			diff.LineItemsChanged = append(diff.LineItemsChanged, oapi.EstimateLineItemChange{
+				LineItemId: item.Id,
+				ItemName:   item.ItemName,
+				Fields:     changes,
+			})
+		}
+	}
+	if from != nil {
+		for _, item := range from.LineItems {
+			if _, ok := fromItems[item.Id]; ok {
+				diff.LineItemsRemoved = append(diff.LineItemsRemoved, item)
+			}
+		}
+	}
+	return diff, nil
+}
+
+// diffJSONFields returns the top-level JSON fields that differ between from
+// and to, sorted by name. A nil from has no fields.
+func diffJSONFields(from, to any) ([]oapi.EstimateFieldChange, error) {
+	fromFields, err := jsonFields(from)
+	if err != nil {
+		return nil, err
+	}
+	toFields, err := jsonFields(to)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(toFields))
+	for name := range toFields {
+		names = append(names, name)
+	}
+	for name := range fromFields {
+		if _, ok := toFields[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	changes := []oapi.EstimateFieldChange{}
+	for _, name := range names {
+		if name == "updatedAt" {
+			continue
+		}
+		before, hadBefore := fromFields[name]
+		after, hasAfter := toFields[name]
+		if hadBefore == hasAfter && reflect.DeepEqual(before, after) {
+			continue
+		}
+		change := oapi.EstimateFieldChange{Field: name}
+		if hadBefore {
+			change.From = &before
+		}
+		if hasAfter {
+			change.To = &after
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+func jsonFields(v any) (map[string]any, error) {
+	fields := map[string]any{}
+	if v == nil {
+		return fields, nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func int32PtrToIntPtr(v *int32) *int {
+	if v == nil {
+		return nil
+	}
+	out := int(*v)
+	return &out
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moveops-platform/apps/api/internal/audit"
+	"github.com/moveops-platform/apps/api/internal/gen/oapi"
+	"github.com/moveops-platform/apps/api/internal/middleware"
+)
+
+// maxEstimateValidityDays bounds the tenant estimate validity setting.
+const maxEstimateValidityDays = 365
+
+// estimateStatusTransitions is the estimate lifecycle that PATCH enforces.
+// Converted estimates are final and only the convert endpoint gets them there.
+var estimateStatusTransitions = map[oapi.EstimateStatus][]oapi.EstimateStatus{
+	oapi.EstimateStatusDraft:    {oapi.EstimateStatusSent, oapi.EstimateStatusDeclined},
+	oapi.EstimateStatusSent:     {oapi.EstimateStatusDraft, oapi.EstimateStatusAccepted, oapi.EstimateStatusDeclined, oapi.EstimateStatusExpired},
+	oapi.EstimateStatusAccepted: {oapi.EstimateStatusDraft, oapi.EstimateStatusDeclined},
+	oapi.EstimateStatusDeclined: {oapi.EstimateStatusDraft},
+	oapi.EstimateStatusExpired:  {oapi.EstimateStatusDraft, oapi.EstimateStatusSent},
+}
+
+func canTransitionEstimate(from, to oapi.EstimateStatus) bool {
+	return slices.Contains(estimateStatusTransitions[from], to)
+}
+
+// estimateConvertible reports whether an estimate in status may become a job.
+// Lost quotes have to be reopened first.
+func estimateConvertible(status oapi.EstimateStatus) bool {
+	switch status {
+	case oapi.EstimateStatusDraft, oapi.EstimateStatusSent, oapi.EstimateStatusAccepted, oapi.EstimateStatusConverted:
+		return true
+	default:
+		return false
+	}
+}
+
+// estimateKeepsLostReason reports whether status is a lost outcome that
+// carries a lost reason. Moving out of one clears the reason.
+func estimateKeepsLostReason(status oapi.EstimateStatus) bool {
+	return status == oapi.EstimateStatusDeclined || status == oapi.EstimateStatusExpired
+}
+
+// estimateExpiryRuns remembers when each tenant's estimates were last
+// expired, so reads can skip the write transaction in between.
+type estimateExpiryRuns struct {
+	mu   sync.Mutex
+	last map[uuid.UUID]time.Time
+}
+
+func newEstimateExpiryRuns() *estimateExpiryRuns {
+	return &estimateExpiryRuns{last: map[uuid.UUID]time.Time{}}
+}
+
+// claim reports whether a read should expire the tenant's estimates now, and
+// if so records the run so concurrent reads do not repeat it.
+func (e *estimateExpiryRuns) claim(tenantID uuid.UUID, now time.Time, interval time.Duration) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if last, ok := e.last[tenantID]; ok && now.Sub(last) < interval {
+		return false
+	}
+	e.last[tenantID] = now
+	return true
+}
+
+func (e *estimateExpiryRuns) record(tenantID uuid.UUID, now time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.last[tenantID] = now
+}
+
+// expireEstimatesForRead is expireEstimates for read-only endpoints. It runs
+// at most once per ESTIMATE_EXPIRY_INTERVAL_SEC per tenant, so a read may
+// show an estimate as sent for that long after it lapsed.
+func (s *Server) expireEstimatesForRead(ctx context.Context, tenantID uuid.UUID) {
+	if s.Config.EstimateExpiryInterval > 0 && !s.expiryRuns.claim(tenantID, time.Now(), s.Config.EstimateExpiryInterval) {
+		return
+	}
+	s.expireEstimates(ctx, tenantID)
+}
+
+// expireEstimates moves the tenant's sent estimates past their validity to
+// expired. It runs before estimate writes instead of on a timer, so a lapsed
+// estimate can never be sent, accepted or converted. Failures only delay the
+// expiry.
+func (s *Server) expireEstimates(ctx context.Context, tenantID uuid.UUID) {
+	started := time.Now()
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		s.Logger.Warn("expire estimates", "tenant_id", tenantID, "error", err)
+		return
+	}
+	defer tx.Rollback(ctx)
+	qtx := s.Q.WithTx(tx)
+
+	ids, err := qtx.ExpireSentEstimates(ctx, tenantID)
+	if err != nil {
+		s.Logger.Warn("expire estimates", "tenant_id", tenantID, "error", err)
+		return
+	}
+	for _, id := range ids {
+		if err := recordEstimateRevision(ctx, qtx, tenantID, id, nil, revisionExpired, nil); err != nil {
+			s.Logger.Warn("expire estimates", "tenant_id", tenantID, "estimate_id", id, "error", err)
+			return
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		s.Logger.Warn("expire estimates", "tenant_id", tenantID, "error", err)
+		return
+	}
+	s.expiryRuns.record(tenantID, started)
+	for _, id := range ids {
+		estimateID := id
+		_ = s.Audit.Log(ctx, audit.Entry{
+			TenantID:   tenantID,
+			Action:     "estimate.status_change",
+			EntityType: "estimate",
+			EntityID:   &estimateID,
+			RequestID:  middleware.RequestIDFromContext(ctx),
+			Metadata: map[string]any{
+				"from":      oapi.EstimateStatusSent,
+				"to":        oapi.EstimateStatusExpired,
+				"automatic": true,
+			},
+		})
+	}
+}
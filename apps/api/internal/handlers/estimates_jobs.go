@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
@@ -21,6 +22,111 @@ import (
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+func (s *Server) GetEstimates(w http.ResponseWriter, r *http.Request, params oapi.GetEstimatesParams) {
+	_, tenantID, _, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+	s.expireEstimatesForRead(r.Context(), tenantID)
+
+	limit, ok := resolveListLimit(w, r, params.Limit)
+	if !ok {
+		return
+	}
+	cursorCreatedAt, cursorID, ok := decodeOptionalListCursor(w, r, params.Cursor)
+	if !ok {
+		return
+	}
+
+	moveDateFrom := dateToTimePtr(params.MoveDateFrom)
+	moveDateTo := dateToTimePtr(params.MoveDateTo)
+	if moveDateFrom != nil && moveDateTo != nil && moveDateTo.Before(*moveDateFrom) {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "moveDateTo must be on or after moveDateFrom", nil)
+		return
+	}
+	if params.CreatedFrom != nil && params.CreatedTo != nil && !params.CreatedTo.After(*params.CreatedFrom) {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "createdTo must be after createdFrom", nil)
+		return
+	}
+
+	var status *string
+	if params.Status != nil {
+		value := string(*params.Status)
+		status = &value
+	}
+	var createdBy *uuid.UUID
+	if params.CreatedBy != nil {
+		value := uuid.UUID(*params.CreatedBy)
+		createdBy = &value
+	}
+
+	rows, err := s.Q.ListEstimates(r.Context(), gen.ListEstimatesParams{
+		TenantID:        tenantID,
+		Status:          status,
+		LeadSource:      sanitizeOptional(params.LeadSource),
+		MoveDateFrom:    moveDateFrom,
+		MoveDateTo:      moveDateTo,
+		CreatedFrom:     params.CreatedFrom,
+		CreatedTo:       params.CreatedTo,
+		CreatedBy:       createdBy,
+		SearchQ:         sanitizeOptional(params.Q),
+		CursorCreatedAt: cursorCreatedAt,
+		CursorID:        cursorID,
+		LimitRows:       int32(limit + 1),
+	})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load estimates", nil)
+		return
+	}
+
+	var nextCursor *string
+	if len(rows) > limit {
+		cursor := encodeListCursor(rows[limit-1].CreatedAt, rows[limit-1].ID)
+		nextCursor = &cursor
+		rows = rows[:limit]
+	}
+
+	items := make([]oapi.EstimateListItem, 0, len(rows))
+	for _, row := range rows {
+		var convertedJobID *openapi_types.UUID
+		if row.ConvertedJobID != nil {
+			id := openapi_types.UUID(*row.ConvertedJobID)
+			convertedJobID = &id
+		}
+		var createdByID *openapi_types.UUID
+		if row.CreatedBy != nil {
+			id := openapi_types.UUID(*row.CreatedBy)
+			createdByID = &id
+		}
+		items = append(items, oapi.EstimateListItem{
+			Id:                  row.ID,
+			EstimateNumber:      row.EstimateNumber,
+			CustomerId:          row.CustomerID,
+			CustomerName:        row.CustomerName,
+			PrimaryPhone:        row.PrimaryPhone,
+			Email:               openapi_types.Email(row.Email),
+			Status:              oapi.EstimateListItemStatus(row.Status),
+			OriginShort:         row.OriginShort,
+			DestinationShort:    row.DestinationShort,
+			MoveDate:            dateOnly(row.MoveDate),
+			LeadSource:          row.LeadSource,
+			EstimatedTotalCents: row.EstimatedTotalCents,
+			DepositCents:        row.DepositCents,
+			ExpiresAt:           row.ExpiresAt,
+			ConvertedJobId:      convertedJobID,
+			CreatedBy:           createdByID,
+			CreatedAt:           row.CreatedAt,
+			UpdatedAt:           row.UpdatedAt,
+		})
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, oapi.EstimateListResponse{
+		Items:      items,
+		NextCursor: nextCursor,
+		RequestId:  middleware.RequestIDFromContext(r.Context()),
+	})
+}
+
 func (s *Server) PostEstimates(w http.ResponseWriter, r *http.Request, params oapi.PostEstimatesParams) {
 	_, tenantID, userID, ok := requireActorIDs(w, r)
 	if !ok {
@@ -98,27 +204,16 @@ func (s *Server) PostEstimates(w http.ResponseWriter, r *http.Request, params oa
 	}
 
 	estimateNumber := fmt.Sprintf("E-%06d", counter)
-	firstName, lastName := splitName(req.CustomerName)
-
-	customer, err := qtx.CreateCustomerForEstimate(r.Context(), gen.CreateCustomerForEstimateParams{
-		TenantID:  tenantID,
-		FirstName: firstName,
-		LastName:  lastName,
-		Email:     ptr(strings.TrimSpace(string(req.Email))),
-		Phone:     ptr(strings.TrimSpace(req.PrimaryPhone)),
-		CreatedBy: &userID,
-		UpdatedBy: &userID,
-	})
-	if err != nil {
-		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to create customer", nil)
+	customerMatch, ok := s.resolveEstimateCustomer(w, r, qtx, tenantID, userID, req)
+	if !ok {
 		return
 	}
 
 	estimate, err := qtx.CreateEstimate(r.Context(), gen.CreateEstimateParams{
 		TenantID:                tenantID,
 		EstimateNumber:          estimateNumber,
-		CustomerID:              customer.ID,
-		Status:                  string(oapi.Draft),
+		CustomerID:              customerMatch.CustomerId,
+		Status:                  string(oapi.EstimateStatusDraft),
 		CustomerName:            strings.TrimSpace(req.CustomerName),
 		PrimaryPhone:            strings.TrimSpace(req.PrimaryPhone),
 		SecondaryPhone:          sanitizeOptional(req.SecondaryPhone),
@@ -160,6 +255,10 @@ func (s *Server) PostEstimates(w http.ResponseWriter, r *http.Request, params oa
 		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to create estimate", nil)
 		return
 	}
+	if err := recordEstimateRevision(r.Context(), qtx, tenantID, estimate.ID, &userID, revisionCreated, nil); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to record estimate revision", nil)
+		return
+	}
 
 	if err := tx.Commit(r.Context()); err != nil {
 		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to commit estimate", nil)
@@ -179,10 +278,12 @@ func (s *Server) PostEstimates(w http.ResponseWriter, r *http.Request, params oa
 			"status":         estimate.Status,
 			"leadSource":     estimate.LeadSource,
 			"moveDate":       estimate.MoveDate.Format("2006-01-02"),
+			"customerId":     estimate.CustomerID,
+			"customerMatch":  customerMatch.MatchedOn,
 		},
 	})
 
-	s.writeEstimateResponse(w, r, tenantID, estimate.ID, http.StatusCreated)
+	s.writeEstimateResponseWithMatch(w, r, tenantID, estimate.ID, http.StatusCreated, &customerMatch)
 }
 
 func (s *Server) GetEstimatesEstimateId(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID) {
@@ -191,6 +292,7 @@ func (s *Server) GetEstimatesEstimateId(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
+	s.expireEstimatesForRead(r.Context(), tenantID)
 	s.writeEstimateResponse(w, r, tenantID, uuid.UUID(estimateId), http.StatusOK)
 }
 
@@ -206,15 +308,18 @@ func (s *Server) PatchEstimatesEstimateId(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	if req.CustomerName == nil && req.PrimaryPhone == nil && req.SecondaryPhone == nil && req.Email == nil &&
-		req.OriginAddressLine1 == nil && req.OriginCity == nil && req.OriginState == nil && req.OriginPostalCode == nil &&
-		req.DestinationAddressLine1 == nil && req.DestinationCity == nil && req.DestinationState == nil && req.DestinationPostalCode == nil &&
-		req.MoveDate == nil && req.PickupTime == nil && req.LeadSource == nil && req.MoveSize == nil && req.LocationType == nil &&
-		req.EstimatedTotalCents == nil && req.DepositCents == nil && req.Notes == nil {
+	fieldsProvided := req.CustomerName != nil || req.PrimaryPhone != nil || req.SecondaryPhone != nil || req.Email != nil ||
+		req.OriginAddressLine1 != nil || req.OriginCity != nil || req.OriginState != nil || req.OriginPostalCode != nil ||
+		req.DestinationAddressLine1 != nil || req.DestinationCity != nil || req.DestinationState != nil || req.DestinationPostalCode != nil ||
+		req.MoveDate != nil || req.PickupTime != nil || req.LeadSource != nil || req.MoveSize != nil || req.LocationType != nil ||
+		req.EstimatedTotalCents != nil || req.DepositCents != nil || req.Notes != nil
+	if !fieldsProvided && req.Status == nil && req.LostReason == nil {
 		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "At least one field must be provided", nil)
 		return
 	}
 
+	s.expireEstimates(r.Context(), tenantID)
+
 	targetEstimateID := uuid.UUID(estimateId)
 	before, err := s.Q.GetEstimateByID(r.Context(), gen.GetEstimateByIDParams{ID: targetEstimateID, TenantID: tenantID})
 	if err != nil {
@@ -226,67 +331,209 @@ func (s *Server) PatchEstimatesEstimateId(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	updated, err := s.Q.UpdateEstimate(r.Context(), gen.UpdateEstimateParams{
-		CustomerName:            sanitizeOptional(req.CustomerName),
-		PrimaryPhone:            sanitizeOptional(req.PrimaryPhone),
-		SecondaryPhone:          sanitizeOptional(req.SecondaryPhone),
-		Email:                   emailToStringPtr(req.Email),
-		OriginAddressLine1:      sanitizeOptional(req.OriginAddressLine1),
-		OriginCity:              sanitizeOptional(req.OriginCity),
-		OriginState:             sanitizeOptional(req.OriginState),
-		OriginPostalCode:        sanitizeOptional(req.OriginPostalCode),
-		DestinationAddressLine1: sanitizeOptional(req.DestinationAddressLine1),
-		DestinationCity:         sanitizeOptional(req.DestinationCity),
-		DestinationState:        sanitizeOptional(req.DestinationState),
-		DestinationPostalCode:   sanitizeOptional(req.DestinationPostalCode),
-		MoveDate:                dateToTimePtr(req.MoveDate),
-		PickupTime:              sanitizeOptional(req.PickupTime),
-		LeadSource:              sanitizeOptional(req.LeadSource),
-		MoveSize:                sanitizeOptional(req.MoveSize),
-		LocationType:            sanitizeOptional(req.LocationType),
-		EstimatedTotalCents:     req.EstimatedTotalCents,
-		DepositCents:            req.DepositCents,
-		Notes:                   sanitizeOptional(req.Notes),
-		UpdatedBy:               &userID,
-		ID:                      targetEstimateID,
-		TenantID:                tenantID,
-	})
+	if req.DepositCents != nil && depositPosted(before, req.DepositCents) {
+		writeDepositPosted(w, r)
+		return
+	}
+
+	fromStatus := oapi.EstimateStatus(before.Status)
+	toStatus := fromStatus
+	if req.Status != nil {
+		toStatus = oapi.EstimateStatus(*req.Status)
+	}
+	statusChanged := toStatus != fromStatus
+	if statusChanged && !canTransitionEstimate(fromStatus, toStatus) {
+		httpx.WriteError(w, r, http.StatusConflict, "invalid_status_transition", fmt.Sprintf("Estimate cannot move from %s to %s", fromStatus, toStatus), map[string]any{
+			"from":    fromStatus,
+			"to":      toStatus,
+			"allowed": estimateStatusTransitions[fromStatus],
+		})
+		return
+	}
+	lostReason := sanitizeOptional(req.LostReason)
+	if lostReason != nil && !estimateKeepsLostReason(toStatus) {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "lostReason can only be set on declined or expired estimates", nil)
+		return
+	}
+	if statusChanged && toStatus == oapi.EstimateStatusDeclined && lostReason == nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "lost_reason_required", "lostReason is required when declining an estimate", nil)
+		return
+	}
+
+	tx, err := s.DB.Begin(r.Context())
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			httpx.WriteError(w, r, http.StatusNotFound, "estimate_not_found", "Estimate was not found", nil)
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to start transaction", nil)
+		return
+	}
+	defer tx.Rollback(r.Context())
+	qtx := s.Q.WithTx(tx)
+
+	updated := before
+	if fieldsProvided {
+		updated, err = qtx.UpdateEstimate(r.Context(), gen.UpdateEstimateParams{
+			CustomerName:            sanitizeOptional(req.CustomerName),
+			PrimaryPhone:            sanitizeOptional(req.PrimaryPhone),
+			SecondaryPhone:          sanitizeOptional(req.SecondaryPhone),
+			Email:                   emailToStringPtr(req.Email),
+			OriginAddressLine1:      sanitizeOptional(req.OriginAddressLine1),
+			OriginCity:              sanitizeOptional(req.OriginCity),
+			OriginState:             sanitizeOptional(req.OriginState),
+			OriginPostalCode:        sanitizeOptional(req.OriginPostalCode),
+			DestinationAddressLine1: sanitizeOptional(req.DestinationAddressLine1),
+			DestinationCity:         sanitizeOptional(req.DestinationCity),
+			DestinationState:        sanitizeOptional(req.DestinationState),
+			DestinationPostalCode:   sanitizeOptional(req.DestinationPostalCode),
+			MoveDate:                dateToTimePtr(req.MoveDate),
+			PickupTime:              sanitizeOptional(req.PickupTime),
+			LeadSource:              sanitizeOptional(req.LeadSource),
+			MoveSize:                sanitizeOptional(req.MoveSize),
+			LocationType:            sanitizeOptional(req.LocationType),
+			EstimatedTotalCents:     req.EstimatedTotalCents,
+			DepositCents:            req.DepositCents,
+			Notes:                   sanitizeOptional(req.Notes),
+			UpdatedBy:               &userID,
+			ID:                      targetEstimateID,
+			TenantID:                tenantID,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				httpx.WriteError(w, r, http.StatusNotFound, "estimate_not_found", "Estimate was not found", nil)
+				return
+			}
+			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to update estimate", nil)
 			return
 		}
-		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to update estimate", nil)
-		return
+
+		if req.CustomerName != nil || req.Email != nil || req.PrimaryPhone != nil {
+			if err := syncEstimateCustomer(r.Context(), qtx, tenantID, userID, updated); err != nil {
+				if isUniqueConstraint(err, "customers_tenant_email_uidx") {
+					httpx.WriteError(w, r, http.StatusConflict, "customer_email_conflict", "Another customer already uses this email", nil)
+					return
+				}
+				httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to update customer", nil)
+				return
+			}
+		}
 	}
 
-	firstName, lastName := splitName(updated.CustomerName)
-	_, err = s.Q.UpdateCustomerForEstimate(r.Context(), gen.UpdateCustomerForEstimateParams{
-		FirstName: &firstName,
-		LastName:  &lastName,
-		Email:     &updated.Email,
-		Phone:     &updated.PrimaryPhone,
-		UpdatedBy: &userID,
-		ID:        updated.CustomerID,
-		TenantID:  tenantID,
-	})
-	if err != nil {
-		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to update customer", nil)
+	if statusChanged || lostReason != nil {
+		transition := gen.TransitionEstimateStatusParams{
+			Status:     string(toStatus),
+			SentAt:     before.SentAt,
+			ExpiresAt:  before.ExpiresAt,
+			UpdatedBy:  &userID,
+			ID:         targetEstimateID,
+			TenantID:   tenantID,
+			FromStatus: before.Status,
+		}
+		if estimateKeepsLostReason(toStatus) {
+			transition.LostReason = before.LostReason
+			if lostReason != nil {
+				transition.LostReason = lostReason
+			}
+		}
+		switch {
+		case toStatus == oapi.EstimateStatusDraft:
+			transition.SentAt = nil
+			transition.ExpiresAt = nil
+		case toStatus == oapi.EstimateStatusSent && statusChanged:
+			settings, err := qtx.GetTenantSettings(r.Context(), tenantID)
+			if err != nil {
+				httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load estimate validity", nil)
+				return
+			}
+			now := time.Now()
+			expiresAt := now.AddDate(0, 0, int(settings.EstimateValidityDays))
+			transition.SentAt = &now
+			transition.ExpiresAt = &expiresAt
+		}
+
+		updated, err = qtx.TransitionEstimateStatus(r.Context(), transition)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				httpx.WriteError(w, r, http.StatusConflict, "estimate_status_conflict", "Estimate status changed while updating; reload and try again", nil)
+				return
+			}
+			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to update estimate status", nil)
+			return
+		}
+	}
+
+	fieldsChanged := estimateChangedFields(before, updated)
+	if statusChanged || len(fieldsChanged) > 0 {
+		change := revisionUpdated
+		if statusChanged {
+			change = revisionStatusChanged
+		}
+		if err := recordEstimateRevision(r.Context(), qtx, tenantID, targetEstimateID, &userID, change, nil); err != nil {
+			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to record estimate revision", nil)
+			return
+		}
+	}
+
+	// Sending an estimate emails it to the customer when the tenant has
+	// email set up; otherwise the status change goes ahead without it.
+	var queuedEmail *gen.EmailOutbox
+	if toStatus == oapi.EstimateStatusSent && statusChanged {
+		queued, reason, err := s.queueEmail(r.Context(), qtx, gen.EnqueueEmailParams{
+			TenantID:   tenantID,
+			Kind:       emailKindEstimateSent,
+			EstimateID: &targetEstimateID,
+			ToAddress:  updated.Email,
+			CreatedBy:  &userID,
+		}, estimateEmailValues(updated))
+		if err != nil {
+			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to queue estimate email", nil)
+			return
+		}
+		if reason == "" {
+			queuedEmail = &queued
+		}
+	}
+
+	if err := tx.Commit(r.Context()); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to commit estimate update", nil)
 		return
 	}
 
 	estimateID := updated.ID
-	_ = s.Audit.Log(r.Context(), audit.Entry{
-		TenantID:   tenantID,
-		UserID:     &userID,
-		Action:     "estimate.update",
-		EntityType: "estimate",
-		EntityID:   &estimateID,
-		RequestID:  middleware.RequestIDFromContext(r.Context()),
-		Metadata: map[string]any{
-			"fieldsChanged": estimateChangedFields(before, updated),
-		},
-	})
+	if fieldsProvided || (lostReason != nil && !statusChanged) {
+		_ = s.Audit.Log(r.Context(), audit.Entry{
+			TenantID:   tenantID,
+			UserID:     &userID,
+			Action:     "estimate.update",
+			EntityType: "estimate",
+			EntityID:   &estimateID,
+			RequestID:  middleware.RequestIDFromContext(r.Context()),
+			Metadata: map[string]any{
+				"fieldsChanged": fieldsChanged,
+			},
+		})
+	}
+	if statusChanged {
+		metadata := map[string]any{
+			"from": fromStatus,
+			"to":   toStatus,
+		}
+		if updated.LostReason != nil {
+			metadata["lostReason"] = *updated.LostReason
+		}
+		if updated.ExpiresAt != nil && toStatus == oapi.EstimateStatusSent {
+			metadata["expiresAt"] = updated.ExpiresAt.UTC()
+		}
+		_ = s.Audit.Log(r.Context(), audit.Entry{
+			TenantID:   tenantID,
+			UserID:     &userID,
+			Action:     "estimate.status_change",
+			EntityType: "estimate",
+			EntityID:   &estimateID,
+			RequestID:  middleware.RequestIDFromContext(r.Context()),
+			Metadata:   metadata,
+		})
+	}
+	if queuedEmail != nil {
+		logEmailQueued(r.Context(), s.Audit, *queuedEmail, "estimate", estimateID, &userID)
+	}
 
 	s.writeEstimateResponse(w, r, tenantID, updated.ID, http.StatusOK)
 }
@@ -304,6 +551,7 @@ func (s *Server) PostEstimatesEstimateIdConvert(w http.ResponseWriter, r *http.R
 	}
 	idempotencyKeyPtr := &idempotencyKey
 	targetEstimateID := uuid.UUID(estimateId)
+	s.expireEstimates(r.Context(), tenantID)
 
 	if jobByKey, err := s.Q.GetJobByConvertIdempotencyKey(r.Context(), gen.GetJobByConvertIdempotencyKeyParams{
 		TenantID:              tenantID,
@@ -326,50 +574,90 @@ func (s *Server) PostEstimatesEstimateIdConvert(w http.ResponseWriter, r *http.R
 		return
 	}
 	defer tx.Rollback(r.Context())
-	qtx := s.Q.WithTx(tx)
 
+	conversion, ok := s.convertEstimateTx(w, r, s.Q.WithTx(tx), tenantID, targetEstimateID, &userID, idempotencyKey)
+	if !ok {
+		return
+	}
+	if err := tx.Commit(r.Context()); err != nil {
+		message := "Failed to commit conversion"
+		if conversion.replayed {
+			message = "Failed to finalize idempotent conversion"
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", message, nil)
+		return
+	}
+	s.logEstimateConversion(r.Context(), tenantID, &userID, targetEstimateID, conversion)
+
+	s.writeJobResponse(w, r, tenantID, conversion.jobID, conversion.statusCode)
+}
+
+// estimateConversion is the outcome of convertEstimateTx.
+type estimateConversion struct {
+	jobID      uuid.UUID
+	jobNumber  string
+	statusCode int
+	// created is set when this conversion created the job, replayed when
+	// the idempotency key had already created it.
+	created  bool
+	replayed bool
+	// fromStatus is the estimate status before it was marked converted, or
+	// empty when it already was.
+	fromStatus string
+	// chargedCents and depositCents are the move charge and deposit payment
+	// posted to the new job's ledger.
+	chargedCents int64
+	depositCents int64
+	queuedEmail  *gen.EmailOutbox
+}
+
+// convertEstimateTx converts an estimate to a job inside the caller's
+// transaction: it replays an earlier conversion with the same idempotency
+// key, reuses the estimate's existing job or books a new one and charges
+// the estimate total to its ledger, marks the estimate converted and queues
+// the booking confirmation. userID is nil
+// when no user triggered the conversion. The caller commits and then calls
+// logEstimateConversion.
+func (s *Server) convertEstimateTx(w http.ResponseWriter, r *http.Request, qtx *gen.Queries, tenantID, targetEstimateID uuid.UUID, userID *uuid.UUID, idempotencyKey string) (estimateConversion, bool) {
+	idempotencyKeyPtr := &idempotencyKey
 	if jobByKey, err := qtx.GetJobByConvertIdempotencyKey(r.Context(), gen.GetJobByConvertIdempotencyKeyParams{
 		TenantID:              tenantID,
 		ConvertIdempotencyKey: idempotencyKeyPtr,
 	}); err == nil {
 		if jobByKey.EstimateID == nil || *jobByKey.EstimateID != targetEstimateID {
 			httpx.WriteError(w, r, http.StatusConflict, "IDEMPOTENCY_KEY_REUSE", "Idempotency key was already used for a different estimate conversion", nil)
-			return
+			return estimateConversion{}, false
 		}
-		if err := tx.Commit(r.Context()); err != nil {
-			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to finalize idempotent conversion", nil)
-			return
-		}
-		s.writeJobResponse(w, r, tenantID, jobByKey.ID, http.StatusOK)
-		return
+		return estimateConversion{jobID: jobByKey.ID, jobNumber: jobByKey.JobNumber, statusCode: http.StatusOK, replayed: true}, true
 	} else if !errors.Is(err, pgx.ErrNoRows) {
 		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to check conversion idempotency", nil)
-		return
+		return estimateConversion{}, false
 	}
 
 	estimate, err := qtx.GetEstimateByID(r.Context(), gen.GetEstimateByIDParams{ID: targetEstimateID, TenantID: tenantID})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			httpx.WriteError(w, r, http.StatusNotFound, "estimate_not_found", "Estimate was not found", nil)
-			return
+			return estimateConversion{}, false
 		}
 		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load estimate", nil)
-		return
+		return estimateConversion{}, false
+	}
+	if !estimateConvertible(oapi.EstimateStatus(estimate.Status)) {
+		httpx.WriteError(w, r, http.StatusConflict, "estimate_not_convertible", fmt.Sprintf("A %s estimate cannot be converted; reopen it first", estimate.Status), map[string]any{
+			"status": estimate.Status,
+		})
+		return estimateConversion{}, false
 	}
 
-	var (
-		jobID       uuid.UUID
-		jobNumber   string
-		statusCode  = http.StatusCreated
-		createdNew  = false
-		estimateRef = &targetEstimateID
-	)
+	conversion := estimateConversion{statusCode: http.StatusCreated}
+	estimateRef := &targetEstimateID
 
 	existingJob, err := qtx.GetJobByEstimateID(r.Context(), gen.GetJobByEstimateIDParams{TenantID: tenantID, EstimateID: estimateRef})
 	if err == nil {
-		jobID = existingJob.ID
-		jobNumber = existingJob.JobNumber
-		statusCode = http.StatusOK
+		conversion.jobID = existingJob.ID
+		conversion.jobNumber = existingJob.JobNumber
+		conversion.statusCode = http.StatusOK
 	} else if errors.Is(err, pgx.ErrNoRows) {
 		counter, counterErr := qtx.IncrementTenantCounter(r.Context(), gen.IncrementTenantCounterParams{
 			TenantID:    tenantID,
@@ -377,22 +665,22 @@ func (s *Server) PostEstimatesEstimateIdConvert(w http.ResponseWriter, r *http.R
 		})
 		if counterErr != nil {
 			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to allocate job number", nil)
-			return
+			return estimateConversion{}, false
 		}
 
-		jobNumber = fmt.Sprintf("J-%06d", counter)
+		conversion.jobNumber = fmt.Sprintf("J-%06d", counter)
 		scheduledDate := estimate.MoveDate
 		job, createErr := qtx.CreateJob(r.Context(), gen.CreateJobParams{
 			TenantID:              tenantID,
-			JobNumber:             jobNumber,
+			JobNumber:             conversion.jobNumber,
 			EstimateID:            estimateRef,
 			CustomerID:            estimate.CustomerID,
 			Status:                string(oapi.JobStatusBooked),
 			ScheduledDate:         &scheduledDate,
 			PickupTime:            estimate.PickupTime,
 			ConvertIdempotencyKey: idempotencyKeyPtr,
-			CreatedBy:             &userID,
-			UpdatedBy:             &userID,
+			CreatedBy:             userID,
+			UpdatedBy:             userID,
 		})
 		if createErr != nil {
 			switch {
@@ -400,63 +688,133 @@ func (s *Server) PostEstimatesEstimateIdConvert(w http.ResponseWriter, r *http.R
 				existingJob, lookupErr := qtx.GetJobByEstimateID(r.Context(), gen.GetJobByEstimateIDParams{TenantID: tenantID, EstimateID: estimateRef})
 				if lookupErr != nil {
 					httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load converted job", nil)
-					return
+					return estimateConversion{}, false
 				}
-				jobID = existingJob.ID
-				jobNumber = existingJob.JobNumber
-				statusCode = http.StatusOK
+				conversion.jobID = existingJob.ID
+				conversion.jobNumber = existingJob.JobNumber
+				conversion.statusCode = http.StatusOK
 			case isUniqueConstraint(createErr, "jobs_tenant_convert_idempotency_uidx"):
 				existingByKey, lookupErr := qtx.GetJobByConvertIdempotencyKey(r.Context(), gen.GetJobByConvertIdempotencyKeyParams{
 					TenantID:              tenantID,
 					ConvertIdempotencyKey: idempotencyKeyPtr,
 				})
 				if lookupErr == nil && existingByKey.EstimateID != nil && *existingByKey.EstimateID == targetEstimateID {
-					jobID = existingByKey.ID
-					jobNumber = existingByKey.JobNumber
-					statusCode = http.StatusOK
+					conversion.jobID = existingByKey.ID
+					conversion.jobNumber = existingByKey.JobNumber
+					conversion.statusCode = http.StatusOK
 				} else {
 					httpx.WriteError(w, r, http.StatusConflict, "IDEMPOTENCY_KEY_REUSE", "Idempotency key was already used for a different estimate conversion", nil)
-					return
+					return estimateConversion{}, false
 				}
 			default:
 				httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to create job", nil)
-				return
+				return estimateConversion{}, false
 			}
 		} else {
-			jobID = job.ID
-			createdNew = true
+			conversion.jobID = job.ID
+			conversion.created = true
+			if err := qtx.InsertJobStatusHistory(r.Context(), gen.InsertJobStatusHistoryParams{
+				TenantID:  tenantID,
+				JobID:     job.ID,
+				ToStatus:  job.Status,
+				ChangedBy: userID,
+			}); err != nil {
+				httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to record job status", nil)
+				return estimateConversion{}, false
+			}
 		}
 	} else {
 		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to check existing conversion", nil)
-		return
+		return estimateConversion{}, false
 	}
 
-	_, _ = qtx.MarkEstimateConverted(r.Context(), gen.MarkEstimateConvertedParams{
-		UpdatedBy: &userID,
+	markedConverted, _ := qtx.MarkEstimateConverted(r.Context(), gen.MarkEstimateConvertedParams{
+		UpdatedBy: userID,
 		ID:        targetEstimateID,
 		TenantID:  tenantID,
 	})
+	if markedConverted > 0 {
+		conversion.fromStatus = estimate.Status
+		if err := recordEstimateRevision(r.Context(), qtx, tenantID, targetEstimateID, userID, revisionConverted, nil); err != nil {
+			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to record estimate revision", nil)
+			return estimateConversion{}, false
+		}
+	}
 
-	if err := tx.Commit(r.Context()); err != nil {
-		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to commit conversion", nil)
-		return
+	if conversion.created {
+		charged, deposit, err := postMoveCharges(r.Context(), qtx, tenantID, userID, conversion.jobID, "estimate "+estimate.EstimateNumber, estimate.EstimatedTotalCents, estimate.DepositCents)
+		if err != nil {
+			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to post move charge", nil)
+			return estimateConversion{}, false
+		}
+		conversion.chargedCents = charged
+		conversion.depositCents = deposit
 	}
 
-	_ = s.Audit.Log(r.Context(), audit.Entry{
+	if conversion.created {
+		job, err := qtx.GetJobEmailContext(r.Context(), gen.GetJobEmailContextParams{ID: conversion.jobID, TenantID: tenantID})
+		if err != nil {
+			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load converted job", nil)
+			return estimateConversion{}, false
+		}
+		queued, reason, err := s.queueEmail(r.Context(), qtx, gen.EnqueueEmailParams{
+			TenantID:  tenantID,
+			Kind:      emailKindBookingConfirmation,
+			JobID:     &conversion.jobID,
+			ToAddress: job.Email,
+			CreatedBy: userID,
+		}, jobEmailValues(job))
+		if err != nil {
+			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to queue booking confirmation", nil)
+			return estimateConversion{}, false
+		}
+		if reason == "" {
+			conversion.queuedEmail = &queued
+		}
+	}
+
+	return conversion, true
+}
+
+// logEstimateConversion audits a committed conversion. Replays were
+// audited when they first happened.
+func (s *Server) logEstimateConversion(ctx context.Context, tenantID uuid.UUID, userID *uuid.UUID, estimateID uuid.UUID, conversion estimateConversion) {
+	if conversion.replayed {
+		return
+	}
+	_ = s.Audit.Log(ctx, audit.Entry{
 		TenantID:   tenantID,
-		UserID:     &userID,
+		UserID:     userID,
 		Action:     "estimate.convert_to_job",
 		EntityType: "estimate",
-		EntityID:   &targetEstimateID,
-		RequestID:  middleware.RequestIDFromContext(r.Context()),
+		EntityID:   &estimateID,
+		RequestID:  middleware.RequestIDFromContext(ctx),
 		Metadata: map[string]any{
-			"jobId":     jobID,
-			"jobNumber": jobNumber,
-			"created":   createdNew,
+			"jobId":        conversion.jobID,
+			"jobNumber":    conversion.jobNumber,
+			"created":      conversion.created,
+			"chargedCents": conversion.chargedCents,
+			"depositCents": conversion.depositCents,
 		},
 	})
-
-	s.writeJobResponse(w, r, tenantID, jobID, statusCode)
+	if conversion.fromStatus != "" {
+		_ = s.Audit.Log(ctx, audit.Entry{
+			TenantID:   tenantID,
+			UserID:     userID,
+			Action:     "estimate.status_change",
+			EntityType: "estimate",
+			EntityID:   &estimateID,
+			RequestID:  middleware.RequestIDFromContext(ctx),
+			Metadata: map[string]any{
+				"from":  conversion.fromStatus,
+				"to":    oapi.EstimateStatusConverted,
+				"jobId": conversion.jobID,
+			},
+		})
+	}
+	if conversion.queuedEmail != nil {
+		logEmailQueued(ctx, s.Audit, *conversion.queuedEmail, "job", conversion.jobID, userID)
+	}
 }
 
 func (s *Server) GetCalendar(w http.ResponseWriter, r *http.Request, params oapi.GetCalendarParams) {
@@ -520,6 +878,92 @@ func (s *Server) GetCalendar(w http.ResponseWriter, r *http.Request, params oapi
 	})
 }
 
+func (s *Server) GetJobs(w http.ResponseWriter, r *http.Request, params oapi.GetJobsParams) {
+	_, tenantID, _, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	limit, ok := resolveListLimit(w, r, params.Limit)
+	if !ok {
+		return
+	}
+	cursorCreatedAt, cursorID, ok := decodeOptionalListCursor(w, r, params.Cursor)
+	if !ok {
+		return
+	}
+
+	var status *string
+	if params.Status != nil {
+		value := string(*params.Status)
+		status = &value
+	}
+	var source *string
+	if params.Source != nil {
+		value := string(*params.Source)
+		source = &value
+	}
+	var customerID *uuid.UUID
+	if params.CustomerId != nil {
+		value := uuid.UUID(*params.CustomerId)
+		customerID = &value
+	}
+
+	rows, err := s.Q.ListJobs(r.Context(), gen.ListJobsParams{
+		TenantID:        tenantID,
+		Status:          status,
+		Scheduled:       params.Scheduled,
+		CustomerID:      customerID,
+		Source:          source,
+		BalanceDue:      params.BalanceDue,
+		CursorCreatedAt: cursorCreatedAt,
+		CursorID:        cursorID,
+		LimitRows:       int32(limit + 1),
+	})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load jobs", nil)
+		return
+	}
+
+	var nextCursor *string
+	if len(rows) > limit {
+		cursor := encodeListCursor(rows[limit-1].CreatedAt, rows[limit-1].ID)
+		nextCursor = &cursor
+		rows = rows[:limit]
+	}
+
+	items := make([]oapi.JobListItem, 0, len(rows))
+	for _, row := range rows {
+		var estimateID *openapi_types.UUID
+		if row.EstimateID != nil {
+			id := openapi_types.UUID(*row.EstimateID)
+			estimateID = &id
+		}
+		items = append(items, oapi.JobListItem{
+			Id:               row.ID,
+			JobNumber:        row.JobNumber,
+			CustomerId:       row.CustomerID,
+			CustomerName:     row.CustomerName,
+			EstimateId:       estimateID,
+			Status:           oapi.JobListItemStatus(row.Status),
+			Source:           oapi.JobListItemSource(row.Source),
+			ScheduledDate:    dateToDatePtr(row.ScheduledDate),
+			PickupTime:       row.PickupTime,
+			OriginShort:      row.OriginShort,
+			DestinationShort: row.DestinationShort,
+			BalanceDueCents:  row.BalanceDueCents,
+			CreatedAt:        row.CreatedAt,
+			UpdatedAt:        row.UpdatedAt,
+		})
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, oapi.JobListResponse{
+		Items:      items,
+		NextCursor: nextCursor,
+		RequestId:  middleware.RequestIDFromContext(r.Context()),
+	})
+}
+
 func (s *Server) GetJobsJobId(w http.ResponseWriter, r *http.Request, jobId openapi_types.UUID) {
 	_, tenantID, _, ok := requireActorIDs(w, r)
 	if !ok {
@@ -544,9 +988,18 @@ func (s *Server) PatchJobsJobId(w http.ResponseWriter, r *http.Request, jobId op
 		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "At least one field must be provided", nil)
 		return
 	}
+	reason := sanitizeOptional(req.Reason)
+
+	tx, err := s.DB.Begin(r.Context())
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to start transaction", nil)
+		return
+	}
+	defer tx.Rollback(r.Context())
+	qtx := s.Q.WithTx(tx)
 
 	targetJobID := uuid.UUID(jobId)
-	before, err := s.Q.GetJobByID(r.Context(), gen.GetJobByIDParams{ID: targetJobID, TenantID: tenantID})
+	before, err := qtx.GetJobByIDForUpdate(r.Context(), gen.GetJobByIDForUpdateParams{ID: targetJobID, TenantID: tenantID})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			httpx.WriteError(w, r, http.StatusNotFound, "job_not_found", "Job was not found", nil)
@@ -556,7 +1009,34 @@ func (s *Server) PatchJobsJobId(w http.ResponseWriter, r *http.Request, jobId op
 		return
 	}
 
-	updated, err := s.Q.UpdateJobScheduleStatus(r.Context(), gen.UpdateJobScheduleStatusParams{
+	fromStatus := oapi.JobStatus(before.Status)
+	toStatus := fromStatus
+	if req.Status != nil {
+		toStatus = oapi.JobStatus(*req.Status)
+	}
+	phaseChanged := toStatus != fromStatus
+	if phaseChanged && !canTransitionJob(fromStatus, toStatus) {
+		httpx.WriteError(w, r, http.StatusConflict, "invalid_status_transition", fmt.Sprintf("Job cannot move from %s to %s", fromStatus, toStatus), map[string]any{
+			"from":    fromStatus,
+			"to":      toStatus,
+			"allowed": jobStatusTransitions[fromStatus],
+		})
+		return
+	}
+	if reason != nil && !phaseChanged {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "reason is only accepted with a status change", nil)
+		return
+	}
+	if phaseChanged && toStatus == oapi.JobStatusCancelled && reason == nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "cancellation_reason_required", "reason is required when cancelling a job", nil)
+		return
+	}
+	if phaseChanged && jobStatusNeedsDate(toStatus) && req.ScheduledDate == nil && before.ScheduledDate == nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "scheduled_date_required", "A job needs a scheduledDate before it can be "+strings.ReplaceAll(string(toStatus), "_", " "), nil)
+		return
+	}
+
+	updated, err := qtx.UpdateJobScheduleStatus(r.Context(), gen.UpdateJobScheduleStatusParams{
 		ScheduledDate: dateToTimePtr(req.ScheduledDate),
 		PickupTime:    sanitizeOptional(req.PickupTime),
 		Status:        updateJobStatusToPtr(req.Status),
@@ -572,10 +1052,26 @@ func (s *Server) PatchJobsJobId(w http.ResponseWriter, r *http.Request, jobId op
 		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to update job", nil)
 		return
 	}
+	if phaseChanged {
+		if err := qtx.InsertJobStatusHistory(r.Context(), gen.InsertJobStatusHistoryParams{
+			TenantID:   tenantID,
+			JobID:      targetJobID,
+			FromStatus: &before.Status,
+			ToStatus:   updated.Status,
+			Reason:     reason,
+			ChangedBy:  &userID,
+		}); err != nil {
+			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to record job status change", nil)
+			return
+		}
+	}
+	if err := tx.Commit(r.Context()); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to commit job update", nil)
+		return
+	}
 
 	jobID := updated.ID
 	scheduleChanged := !timePtrEqual(before.ScheduledDate, updated.ScheduledDate) || !strPtrEqual(before.PickupTime, updated.PickupTime)
-	phaseChanged := before.Status != updated.Status
 
 	if scheduleChanged {
 		_ = s.Audit.Log(r.Context(), audit.Entry{
@@ -592,6 +1088,13 @@ func (s *Server) PatchJobsJobId(w http.ResponseWriter, r *http.Request, jobId op
 		})
 	}
 	if phaseChanged {
+		metadata := map[string]any{
+			"before": before.Status,
+			"after":  updated.Status,
+		}
+		if reason != nil {
+			metadata["reason"] = *reason
+		}
 		_ = s.Audit.Log(r.Context(), audit.Entry{
 			TenantID:   tenantID,
 			UserID:     &userID,
@@ -599,10 +1102,7 @@ func (s *Server) PatchJobsJobId(w http.ResponseWriter, r *http.Request, jobId op
 			EntityType: "job",
 			EntityID:   &jobID,
 			RequestID:  middleware.RequestIDFromContext(r.Context()),
-			Metadata: map[string]any{
-				"before": before.Status,
-				"after":  updated.Status,
-			},
+			Metadata:   metadata,
 		})
 	}
 
@@ -610,6 +1110,10 @@ func (s *Server) PatchJobsJobId(w http.ResponseWriter, r *http.Request, jobId op
 }
 
 func (s *Server) writeEstimateResponse(w http.ResponseWriter, r *http.Request, tenantID, estimateID uuid.UUID, status int) {
+	s.writeEstimateResponseWithMatch(w, r, tenantID, estimateID, status, nil)
+}
+
+func (s *Server) writeEstimateResponseWithMatch(w http.ResponseWriter, r *http.Request, tenantID, estimateID uuid.UUID, status int, customerMatch *oapi.EstimateCustomerMatch) {
 	detail, err := s.Q.GetEstimateDetailByID(r.Context(), gen.GetEstimateDetailByIDParams{
 		ID:       estimateID,
 		TenantID: tenantID,
@@ -623,9 +1127,18 @@ func (s *Server) writeEstimateResponse(w http.ResponseWriter, r *http.Request, t
 		return
 	}
 
+	lastEmail, err := s.lastEmail(r.Context(), gen.ListEmailOutboxParams{TenantID: tenantID, EstimateID: &estimateID})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load email status", nil)
+		return
+	}
+
+	estimate := mapEstimateDetail(detail)
+	estimate.LastEmail = lastEmail
 	httpx.WriteJSON(w, status, oapi.EstimateResponse{
-		Estimate:  mapEstimateDetail(detail),
-		RequestId: middleware.RequestIDFromContext(r.Context()),
+		Estimate:      estimate,
+		CustomerMatch: customerMatch,
+		RequestId:     middleware.RequestIDFromContext(r.Context()),
 	})
 }
 
@@ -643,8 +1156,22 @@ func (s *Server) writeJobResponse(w http.ResponseWriter, r *http.Request, tenant
 		return
 	}
 
+	history, err := s.Q.ListJobStatusHistory(r.Context(), gen.ListJobStatusHistoryParams{TenantID: tenantID, JobID: jobID})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load job status history", nil)
+		return
+	}
+
+	lastEmail, err := s.lastEmail(r.Context(), gen.ListEmailOutboxParams{TenantID: tenantID, JobID: &jobID})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load email status", nil)
+		return
+	}
+
+	job := mapJobDetail(detail, history)
+	job.LastEmail = lastEmail
 	httpx.WriteJSON(w, status, oapi.JobResponse{
-		Job:       mapJobDetail(detail),
+		Job:       job,
 		RequestId: middleware.RequestIDFromContext(r.Context()),
 	})
 }
@@ -682,14 +1209,23 @@ func mapEstimateDetail(detail gen.GetEstimateDetailByIDRow) oapi.Estimate {
 		LocationType:            detail.LocationType,
 		EstimatedTotalCents:     detail.EstimatedTotalCents,
 		DepositCents:            detail.DepositCents,
+		TotalCubicFeet:          int(detail.TotalCubicFeet),
+		TotalWeightLbs:          int(detail.TotalWeightLbs),
+		PriceSource:             (*oapi.EstimatePriceSource)(detail.PriceSource),
+		PriceBreakdown:          mapPriceBreakdown(detail.PriceBreakdown),
+		PricedAt:                detail.PricedAt,
 		Notes:                   detail.Notes,
+		LostReason:              detail.LostReason,
+		SentAt:                  detail.SentAt,
+		ExpiresAt:               detail.ExpiresAt,
+		StatusChangedAt:         detail.StatusChangedAt.UTC(),
 		ConvertedJobId:          convertedJobID,
 		CreatedAt:               detail.CreatedAt.UTC(),
 		UpdatedAt:               detail.UpdatedAt.UTC(),
 	}
 }
 
-func mapJobDetail(detail gen.GetJobDetailByIDRow) oapi.Job {
+func mapJobDetail(detail gen.GetJobDetailByIDRow, history []gen.ListJobStatusHistoryRow) oapi.Job {
 	var estimateID *openapi_types.UUID
 	if detail.EstimateID != nil {
 		id := openapi_types.UUID(*detail.EstimateID)
@@ -718,6 +1254,9 @@ func mapJobDetail(detail gen.GetJobDetailByIDRow) oapi.Job {
 		Status:        oapi.JobStatus(detail.Status),
 		ScheduledDate: scheduledDate,
 		PickupTime:    detail.PickupTime,
+		StatusHistory: mapJobStatusHistory(history),
+		BalanceCents:  detail.BalanceCents,
+		LastPaymentAt: detail.LastPaymentAt,
 		CreatedAt:     detail.CreatedAt.UTC(),
 		UpdatedAt:     detail.UpdatedAt.UTC(),
 	}
@@ -725,6 +1264,7 @@ func mapJobDetail(detail gen.GetJobDetailByIDRow) oapi.Job {
 
 func hashCreateEstimateRequest(req oapi.CreateEstimateRequest) string {
 	type fingerprint struct {
+		CustomerID              *string `json:"customerId,omitempty"`
 		CustomerName            string  `json:"customerName"`
 		PrimaryPhone            string  `json:"primaryPhone"`
 		SecondaryPhone          *string `json:"secondaryPhone,omitempty"`
@@ -747,7 +1287,13 @@ func hashCreateEstimateRequest(req oapi.CreateEstimateRequest) string {
 		Notes                   *string `json:"notes,omitempty"`
 	}
 
+	var customerID *string
+	if req.CustomerId != nil {
+		id := req.CustomerId.String()
+		customerID = &id
+	}
 	payload := fingerprint{
+		CustomerID:              customerID,
 		CustomerName:            strings.TrimSpace(req.CustomerName),
 		PrimaryPhone:            strings.TrimSpace(req.PrimaryPhone),
 		SecondaryPhone:          sanitizeOptional(req.SecondaryPhone),
@@ -837,6 +1383,9 @@ func estimateChangedFields(before, after gen.Estimate) []string {
 	if !strPtrEqual(before.Notes, after.Notes) {
 		fields = append(fields, "notes")
 	}
+	if !strPtrEqual(before.LostReason, after.LostReason) {
+		fields = append(fields, "lostReason")
+	}
 	return fields
 }
 
@@ -959,3 +1508,15 @@ func timePtrEqual(a, b *time.Time) bool {
 func ptr[T any](v T) *T {
 	return &v
 }
+
+// depositPosted reports whether deposit would change the deposit of an
+// estimate that was already converted. Conversion posts the deposit to the
+// job's ledger as a payment; from then on the ledger is the only record of
+// it and corrections are posted there.
+func depositPosted(estimate gen.Estimate, deposit *int64) bool {
+	return estimate.Status == string(oapi.EstimateStatusConverted) && !int64PtrEqual(estimate.DepositCents, deposit)
+}
+
+func writeDepositPosted(w http.ResponseWriter, r *http.Request) {
+	httpx.WriteError(w, r, http.StatusConflict, "deposit_posted", "The deposit was posted to the job's ledger at conversion; record changes there", nil)
+}
@@ -630,6 +630,9 @@ func (s *Server) upsertOrSimulateEstimate(
 		if err != nil {
 			return outcome, nil, err
 		}
+		if err := recordEstimateRevision(r.Context(), s.Q, tenantID, created.ID, &userID, revisionImported, nil); err != nil {
+			return outcome, nil, err
+		}
 		id := created.ID
 		outcome.targetEntityID = &id
 		_, _ = s.Q.UpsertImportIdempotency(r.Context(), gen.UpsertImportIdempotencyParams{
@@ -679,6 +682,13 @@ func (s *Server) upsertOrSimulateEstimate(
 	if err != nil {
 		return outcome, nil, err
 	}
+	// Re-importing an unchanged row rewrites the estimate without changing
+	// it; only real changes become revisions.
+	if updated.Status != existing.Status || updated.CustomerID != existing.CustomerID || len(estimateChangedFields(*existing, updated)) > 0 {
+		if err := recordEstimateRevision(r.Context(), s.Q, tenantID, updated.ID, &userID, revisionImported, nil); err != nil {
+			return outcome, nil, err
+		}
+	}
 
 	id := updated.ID
 	outcome.targetEntityID = &id
@@ -772,6 +782,9 @@ func (s *Server) upsertOrSimulateJob(
 			IdempotencyKey: jobKey,
 			TargetEntityID: created.ID,
 		})
+		if err := s.importMoveBalance(r, tenantID, userID, row, created.ID, true); err != nil {
+			return outcome, uuid.Nil, err
+		}
 		return outcome, created.ID, nil
 	}
 
@@ -805,9 +818,37 @@ func (s *Server) upsertOrSimulateJob(
 		IdempotencyKey: jobKey,
 		TargetEntityID: updated.ID,
 	})
+	if err := s.importMoveBalance(r, tenantID, userID, row, updated.ID, false); err != nil {
+		return outcome, uuid.Nil, err
+	}
 	return outcome, updated.ID, nil
 }
 
+// importMoveBalance carries the row's move balance over to the job ledger.
+// A new job without one is opened like a converted estimate: the estimate
+// total is charged and the deposit is posted as a payment.
+func (s *Server) importMoveBalance(r *http.Request, tenantID, userID uuid.UUID, row canonicalImportRow, jobID uuid.UUID, created bool) error {
+	account := ledgerAccount{name: oapi.LedgerAccountMove, jobID: jobID}
+	if moveBalanceCents, err := parseMoneyCents(row.MoveBalance); err == nil && moveBalanceCents != nil {
+		return s.reconcileLedgerBalance(r.Context(), tenantID, &userID, account, *moveBalanceCents, "Imported balance")
+	}
+	if !created {
+		return nil
+	}
+	totalCents, _ := parseMoneyCents(row.EstimatedTotal)
+	depositCents, _ := parseMoneyCents(row.Deposit)
+
+	tx, err := s.DB.Begin(r.Context())
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(r.Context())
+	if _, _, err := postMoveCharges(r.Context(), s.Q.WithTx(tx), tenantID, &userID, jobID, "import", totalCents, depositCents); err != nil {
+		return err
+	}
+	return tx.Commit(r.Context())
+}
+
 func (s *Server) upsertOrSimulateStorage(
 	r *http.Request,
 	tenantID, userID uuid.UUID,
@@ -844,7 +885,6 @@ func (s *Server) upsertOrSimulateStorage(
 	volume, _ := parseIntNonNegative(row.Volume)
 	monthlyRateCents, _ := parseMoneyCents(row.MonthlyRate)
 	storageBalanceCents, _ := parseMoneyCents(row.StorageBalance)
-	moveBalanceCents, _ := parseMoneyCents(row.MoveBalance)
 
 	existing, err := s.Q.GetStorageRecordByJobID(r.Context(), gen.GetStorageRecordByJobIDParams{
 		JobID:    jobID,
@@ -861,25 +901,22 @@ func (s *Server) upsertOrSimulateStorage(
 			return outcome, nil
 		}
 		created, err := s.Q.CreateStorageRecord(r.Context(), gen.CreateStorageRecordParams{
-			TenantID:            tenantID,
-			JobID:               jobID,
-			Facility:            facility,
-			Status:              &status,
-			DateIn:              dateIn,
-			DateOut:             dateOut,
-			NextBillDate:        nextBillDate,
-			LotNumber:           stringPtrOrNil(row.LotNumber),
-			LocationLabel:       stringPtrOrNil(row.LocationLabel),
-			Vaults:              int32Ptr(vaults),
-			Pads:                int32Ptr(pads),
-			Items:               int32Ptr(items),
-			OversizeItems:       int32Ptr(oversizeItems),
-			Volume:              int32Ptr(volume),
-			MonthlyRateCents:    monthlyRateCents,
-			StorageBalanceCents: int64Ptr(defaultInt64(storageBalanceCents, 0)),
-			MoveBalanceCents:    int64Ptr(defaultInt64(moveBalanceCents, 0)),
-			LastPaymentAt:       nil,
-			Notes:               stringPtrOrNil(row.PricingNotes),
+			TenantID:         tenantID,
+			JobID:            jobID,
+			Facility:         facility,
+			Status:           &status,
+			DateIn:           dateIn,
+			DateOut:          dateOut,
+			NextBillDate:     nextBillDate,
+			LotNumber:        stringPtrOrNil(row.LotNumber),
+			LocationLabel:    stringPtrOrNil(row.LocationLabel),
+			Vaults:           int32Ptr(vaults),
+			Pads:             int32Ptr(pads),
+			Items:            int32Ptr(items),
+			OversizeItems:    int32Ptr(oversizeItems),
+			Volume:           int32Ptr(volume),
+			MonthlyRateCents: monthlyRateCents,
+			Notes:            stringPtrOrNil(row.PricingNotes),
 		})
 		if err != nil {
 			return outcome, err
@@ -892,6 +929,9 @@ func (s *Server) upsertOrSimulateStorage(
 			IdempotencyKey: storageKey,
 			TargetEntityID: created.ID,
 		})
+		if err := s.importStorageBalance(r, tenantID, userID, created, storageBalanceCents); err != nil {
+			return outcome, err
+		}
 		return outcome, nil
 	}
 
@@ -904,25 +944,22 @@ func (s *Server) upsertOrSimulateStorage(
 	}
 
 	updated, err := s.Q.UpdateStorageRecordByID(r.Context(), gen.UpdateStorageRecordByIDParams{
-		Facility:            facility,
-		Status:              status,
-		DateIn:              dateIn,
-		DateOut:             dateOut,
-		NextBillDate:        nextBillDate,
-		LotNumber:           stringPtrOrNil(row.LotNumber),
-		LocationLabel:       stringPtrOrNil(row.LocationLabel),
-		Vaults:              int32(vaults),
-		Pads:                int32(pads),
-		Items:               int32(items),
-		OversizeItems:       int32(oversizeItems),
-		Volume:              int32(volume),
-		MonthlyRateCents:    monthlyRateCents,
-		StorageBalanceCents: defaultInt64(storageBalanceCents, 0),
-		MoveBalanceCents:    defaultInt64(moveBalanceCents, 0),
-		LastPaymentAt:       nil,
-		Notes:               stringPtrOrNil(row.PricingNotes),
-		ID:                  existing.ID,
-		TenantID:            tenantID,
+		Facility:         facility,
+		Status:           status,
+		DateIn:           dateIn,
+		DateOut:          dateOut,
+		NextBillDate:     nextBillDate,
+		LotNumber:        stringPtrOrNil(row.LotNumber),
+		LocationLabel:    stringPtrOrNil(row.LocationLabel),
+		Vaults:           int32(vaults),
+		Pads:             int32(pads),
+		Items:            int32(items),
+		OversizeItems:    int32(oversizeItems),
+		Volume:           int32(volume),
+		MonthlyRateCents: monthlyRateCents,
+		Notes:            stringPtrOrNil(row.PricingNotes),
+		ID:               existing.ID,
+		TenantID:         tenantID,
 	})
 	if err != nil {
 		return outcome, err
@@ -935,9 +972,22 @@ func (s *Server) upsertOrSimulateStorage(
 		IdempotencyKey: storageKey,
 		TargetEntityID: updated.ID,
 	})
+	if err := s.importStorageBalance(r, tenantID, userID, updated, storageBalanceCents); err != nil {
+		return outcome, err
+	}
 	return outcome, nil
 }
 
+// importStorageBalance carries the row's storage balance over to the
+// storage ledger. A blank balance leaves the ledger alone.
+func (s *Server) importStorageBalance(r *http.Request, tenantID, userID uuid.UUID, record gen.StorageRecord, balanceCents *int64) error {
+	if balanceCents == nil {
+		return nil
+	}
+	account := ledgerAccount{name: oapi.LedgerAccountStorage, jobID: record.JobID, storageRecordID: &record.ID}
+	return s.reconcileLedgerBalance(r.Context(), tenantID, &userID, account, *balanceCents, "Imported balance")
+}
+
 func (s *Server) GetImportsImportRunId(w http.ResponseWriter, r *http.Request, importRunId openapi_types.UUID) {
 	_, tenantID, _, ok := requireActorIDs(w, r)
 	if !ok {
@@ -1702,6 +1752,10 @@ func normalizeJobStatus(value string) string {
 		return "booked"
 	case "scheduled":
 		return "scheduled"
+	case "in_progress", "in progress":
+		return "in_progress"
+	case "on_hold", "on hold":
+		return "on_hold"
 	case "completed":
 		return "completed"
 	case "cancelled", "canceled":
@@ -1939,17 +1993,6 @@ func int32Ptr(value int) *int32 {
 	return &v
 }
 
-func int64Ptr(value int64) *int64 {
-	return &value
-}
-
-func defaultInt64(value *int64, fallback int64) int64 {
-	if value == nil {
-		return fallback
-	}
-	return *value
-}
-
 func derefString(value *string) string {
 	if value == nil {
 		return ""
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"slices"
+
+	gen "github.com/moveops-platform/apps/api/internal/gen/db"
+	"github.com/moveops-platform/apps/api/internal/gen/oapi"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// jobStatusTransitions is the job lifecycle that PATCH enforces. Completed
+// jobs are final; cancelled jobs can only be rebooked.
+var jobStatusTransitions = map[oapi.JobStatus][]oapi.JobStatus{
+	oapi.JobStatusBooked:     {oapi.JobStatusScheduled, oapi.JobStatusOnHold, oapi.JobStatusCancelled},
+	oapi.JobStatusScheduled:  {oapi.JobStatusBooked, oapi.JobStatusInProgress, oapi.JobStatusOnHold, oapi.JobStatusCancelled},
+	oapi.JobStatusInProgress: {oapi.JobStatusCompleted, oapi.JobStatusOnHold},
+	oapi.JobStatusOnHold:     {oapi.JobStatusBooked, oapi.JobStatusScheduled, oapi.JobStatusInProgress, oapi.JobStatusCancelled},
+	oapi.JobStatusCancelled:  {oapi.JobStatusBooked},
+}
+
+func canTransitionJob(from, to oapi.JobStatus) bool {
+	return slices.Contains(jobStatusTransitions[from], to)
+}
+
+// jobStatusNeedsDate reports whether a job in status must have a scheduled
+// date.
+func jobStatusNeedsDate(status oapi.JobStatus) bool {
+	return status == oapi.JobStatusScheduled || status == oapi.JobStatusInProgress
+}
+
+func mapJobStatusHistory(rows []gen.ListJobStatusHistoryRow) []oapi.JobStatusChange {
+	items := make([]oapi.JobStatusChange, 0, len(rows))
+	for _, row := range rows {
+		var changedBy *openapi_types.UUID
+		if row.ChangedBy != nil {
+			id := openapi_types.UUID(*row.ChangedBy)
+			changedBy = &id
+		}
+		items = append(items, oapi.JobStatusChange{
+			Id:            row.ID,
+			FromStatus:    row.FromStatus,
+			ToStatus:      row.ToStatus,
+			Reason:        row.Reason,
+			ChangedBy:     changedBy,
+			ChangedByName: row.ChangedByName,
+			ChangedAt:     row.ChangedAt.UTC(),
+		})
+	}
+	return items
+}
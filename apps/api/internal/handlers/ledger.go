@@ -0,0 +1,491 @@
+package handlers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/moveops-platform/apps/api/internal/audit"
+	gen "github.com/moveops-platform/apps/api/internal/gen/db"
+	"github.com/moveops-platform/apps/api/internal/gen/oapi"
+	"github.com/moveops-platform/apps/api/internal/httpx"
+	"github.com/moveops-platform/apps/api/internal/middleware"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// ledgerClockSkew is how far in the future occurredAt may be before it is
+// rejected, to absorb clients whose clocks run slightly ahead.
+const ledgerClockSkew = 5 * time.Minute
+
+// ledgerAccount identifies one balance: the move account of a job, or the
+// storage account of its storage record.
+type ledgerAccount struct {
+	name            oapi.LedgerAccount
+	jobID           uuid.UUID
+	storageRecordID *uuid.UUID
+}
+
+func (s *Server) GetJobsJobIdLedger(w http.ResponseWriter, r *http.Request, jobId openapi_types.UUID) {
+	_, tenantID, _, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+	job, err := s.Q.GetJobByID(r.Context(), gen.GetJobByIDParams{ID: uuid.UUID(jobId), TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusNotFound, "job_not_found", "Job was not found", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load job", nil)
+		return
+	}
+	s.writeLedger(w, r, tenantID, ledgerAccount{name: oapi.LedgerAccountMove, jobID: job.ID}, job.BalanceCents, job.LastPaymentAt)
+}
+
+func (s *Server) GetStorageStorageRecordIdLedger(w http.ResponseWriter, r *http.Request, storageRecordId openapi_types.UUID) {
+	_, tenantID, _, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+	record, err := s.Q.GetStorageRecordByID(r.Context(), gen.GetStorageRecordByIDParams{ID: uuid.UUID(storageRecordId), TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusNotFound, "storage_record_not_found", "Storage record was not found", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load storage record", nil)
+		return
+	}
+	account := ledgerAccount{name: oapi.LedgerAccountStorage, jobID: record.JobID, storageRecordID: &record.ID}
+	s.writeLedger(w, r, tenantID, account, record.StorageBalanceCents, record.LastPaymentAt)
+}
+
+// PostJobsJobIdLedger posts to the move account of the job.
+func (s *Server) PostJobsJobIdLedger(w http.ResponseWriter, r *http.Request, jobId openapi_types.UUID, params oapi.PostJobsJobIdLedgerParams) {
+	_, tenantID, userID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+	job, err := s.Q.GetJobByID(r.Context(), gen.GetJobByIDParams{ID: uuid.UUID(jobId), TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusNotFound, "job_not_found", "Job was not found", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load job", nil)
+		return
+	}
+	s.createLedgerEntry(w, r, tenantID, userID, ledgerAccount{name: oapi.LedgerAccountMove, jobID: job.ID}, params.IdempotencyKey)
+}
+
+// PostStorageStorageRecordIdLedger posts to the storage account of the
+// record.
+func (s *Server) PostStorageStorageRecordIdLedger(w http.ResponseWriter, r *http.Request, storageRecordId openapi_types.UUID, params oapi.PostStorageStorageRecordIdLedgerParams) {
+	_, tenantID, userID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+	record, err := s.Q.GetStorageRecordByID(r.Context(), gen.GetStorageRecordByIDParams{ID: uuid.UUID(storageRecordId), TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusNotFound, "storage_record_not_found", "Storage record was not found", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load storage record", nil)
+		return
+	}
+	account := ledgerAccount{name: oapi.LedgerAccountStorage, jobID: record.JobID, storageRecordID: &record.ID}
+	s.createLedgerEntry(w, r, tenantID, userID, account, params.IdempotencyKey)
+}
+
+func (s *Server) writeLedger(w http.ResponseWriter, r *http.Request, tenantID uuid.UUID, account ledgerAccount, balanceCents int64, lastPaymentAt *time.Time) {
+	rows, err := s.Q.ListLedgerEntries(r.Context(), gen.ListLedgerEntriesParams{
+		TenantID: tenantID,
+		JobID:    account.jobID,
+		Account:  string(account.name),
+	})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to list ledger entries", nil)
+		return
+	}
+
+	entries := make([]oapi.LedgerEntry, 0, len(rows))
+	for _, row := range rows {
+		entry := mapLedgerEntry(gen.LedgerEntry{
+			ID:                 row.ID,
+			JobID:              row.JobID,
+			StorageRecordID:    row.StorageRecordID,
+			Account:            row.Account,
+			Kind:               row.Kind,
+			AmountCents:        row.AmountCents,
+			BalanceEffectCents: row.BalanceEffectCents,
+			Method:             row.Method,
+			Reference:          row.Reference,
+			Memo:               row.Memo,
+			OccurredAt:         row.OccurredAt,
+			CreatedBy:          row.CreatedBy,
+			CreatedAt:          row.CreatedAt,
+		})
+		balanceAfter := row.BalanceAfterCents
+		entry.BalanceAfterCents = &balanceAfter
+		entries = append(entries, entry)
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, oapi.LedgerResponse{
+		Account:         account.name,
+		JobId:           account.jobID,
+		StorageRecordId: account.storageRecordID,
+		BalanceCents:    balanceCents,
+		LastPaymentAt:   lastPaymentAt,
+		Entries:         entries,
+		RequestId:       middleware.RequestIDFromContext(r.Context()),
+	})
+}
+
+func (s *Server) createLedgerEntry(w http.ResponseWriter, r *http.Request, tenantID, userID uuid.UUID, account ledgerAccount, idempotencyKey string) {
+	var req oapi.CreateLedgerEntryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "invalid_body", "Malformed JSON body", nil)
+		return
+	}
+
+	idempotencyKey = strings.TrimSpace(idempotencyKey)
+	if idempotencyKey == "" {
+		httpx.WriteError(w, r, http.StatusBadRequest, "missing_idempotency_key", "Idempotency-Key header is required", nil)
+		return
+	}
+
+	switch req.Kind {
+	case oapi.Charge, oapi.Payment, oapi.Refund:
+		if req.AmountCents <= 0 {
+			httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "amountCents must be positive; post an adjustment to reduce a balance", nil)
+			return
+		}
+	case oapi.Adjustment:
+		if req.AmountCents == 0 {
+			httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "amountCents must not be zero", nil)
+			return
+		}
+	default:
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "kind must be charge, payment, refund or adjustment", nil)
+		return
+	}
+	movesMoney := req.Kind == oapi.Payment || req.Kind == oapi.Refund
+	if movesMoney && req.Method == nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "method is required for payments and refunds", nil)
+		return
+	}
+	if !movesMoney && req.Method != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "method only applies to payments and refunds", nil)
+		return
+	}
+	if req.OccurredAt != nil && req.OccurredAt.After(time.Now().Add(ledgerClockSkew)) {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "occurredAt cannot be in the future", nil)
+		return
+	}
+
+	payloadHash := hashCreateLedgerEntryRequest(account, req)
+	if s.replayLedgerEntry(w, r, s.Q, tenantID, idempotencyKey, payloadHash) {
+		return
+	}
+
+	tx, err := s.DB.Begin(r.Context())
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to start transaction", nil)
+		return
+	}
+	defer tx.Rollback(r.Context())
+	qtx := s.Q.WithTx(tx)
+
+	var method *string
+	if req.Method != nil {
+		value := string(*req.Method)
+		method = &value
+	}
+	entry, balanceCents, err := postLedgerEntry(r.Context(), qtx, gen.CreateLedgerEntryParams{
+		TenantID:               tenantID,
+		JobID:                  account.jobID,
+		StorageRecordID:        account.storageRecordID,
+		Account:                string(account.name),
+		Kind:                   string(req.Kind),
+		AmountCents:            req.AmountCents,
+		Method:                 method,
+		Reference:              sanitizeOptional(req.Reference),
+		Memo:                   sanitizeOptional(req.Memo),
+		OccurredAt:             req.OccurredAt,
+		IdempotencyKey:         &idempotencyKey,
+		IdempotencyPayloadHash: &payloadHash,
+		CreatedBy:              &userID,
+	})
+	if err != nil {
+		if isUniqueConstraint(err, "ledger_entries_tenant_idempotency_uidx") {
+			if s.replayLedgerEntry(w, r, s.Q, tenantID, idempotencyKey, payloadHash) {
+				return
+			}
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to post ledger entry", nil)
+		return
+	}
+	if err := tx.Commit(r.Context()); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to commit ledger entry", nil)
+		return
+	}
+
+	entryID := entry.ID
+	_ = s.Audit.Log(r.Context(), audit.Entry{
+		TenantID:   tenantID,
+		UserID:     &userID,
+		Action:     "ledger_entry.create",
+		EntityType: "ledger_entry",
+		EntityID:   &entryID,
+		RequestID:  middleware.RequestIDFromContext(r.Context()),
+		Metadata: map[string]any{
+			"account":         entry.Account,
+			"jobId":           entry.JobID,
+			"storageRecordId": entry.StorageRecordID,
+			"kind":            entry.Kind,
+			"amountCents":     entry.AmountCents,
+			"method":          entry.Method,
+			"reference":       entry.Reference,
+			"balanceCents":    balanceCents,
+		},
+	})
+
+	httpx.WriteJSON(w, http.StatusCreated, oapi.LedgerEntryResponse{
+		Entry:        mapLedgerEntry(entry),
+		BalanceCents: balanceCents,
+		RequestId:    middleware.RequestIDFromContext(r.Context()),
+	})
+}
+
+// replayLedgerEntry answers a request whose idempotency key was already
+// used: with the original entry when the payload matches, with a conflict
+// otherwise. It reports false when the key is unused.
+func (s *Server) replayLedgerEntry(w http.ResponseWriter, r *http.Request, q *gen.Queries, tenantID uuid.UUID, idempotencyKey, payloadHash string) bool {
+	existing, err := q.GetLedgerEntryByIdempotencyKey(r.Context(), gen.GetLedgerEntryByIdempotencyKeyParams{
+		TenantID:       tenantID,
+		IdempotencyKey: &idempotencyKey,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false
+	}
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to check idempotency key", nil)
+		return true
+	}
+	if existing.IdempotencyPayloadHash == nil || *existing.IdempotencyPayloadHash != payloadHash {
+		httpx.WriteError(w, r, http.StatusConflict, "IDEMPOTENCY_KEY_REUSE", "Idempotency key was already used with a different payload", nil)
+		return true
+	}
+
+	balanceCents, err := ledgerBalance(r.Context(), q, existing)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load balance", nil)
+		return true
+	}
+	httpx.WriteJSON(w, http.StatusOK, oapi.LedgerEntryResponse{
+		Entry:        mapLedgerEntry(existing),
+		BalanceCents: balanceCents,
+		RequestId:    middleware.RequestIDFromContext(r.Context()),
+	})
+	return true
+}
+
+// postLedgerEntry appends an entry and recomputes the balance columns of
+// its account in the caller's transaction. The job row is locked first so
+// concurrent postings to the same job serialize. It returns the account
+// balance after the entry.
+func postLedgerEntry(ctx context.Context, qtx *gen.Queries, params gen.CreateLedgerEntryParams) (gen.LedgerEntry, int64, error) {
+	if _, err := qtx.GetJobByIDForUpdate(ctx, gen.GetJobByIDForUpdateParams{ID: params.JobID, TenantID: params.TenantID}); err != nil {
+		return gen.LedgerEntry{}, 0, err
+	}
+	entry, err := qtx.CreateLedgerEntry(ctx, params)
+	if err != nil {
+		return gen.LedgerEntry{}, 0, err
+	}
+
+	if entry.StorageRecordID != nil {
+		storage, err := qtx.RefreshStorageBalance(ctx, gen.RefreshStorageBalanceParams{ID: *entry.StorageRecordID, TenantID: entry.TenantID})
+		if err != nil {
+			return gen.LedgerEntry{}, 0, err
+		}
+		return entry, storage.StorageBalanceCents, nil
+	}
+
+	job, err := qtx.RefreshJobBalance(ctx, gen.RefreshJobBalanceParams{JobID: entry.JobID, TenantID: entry.TenantID})
+	if err != nil {
+		return gen.LedgerEntry{}, 0, err
+	}
+	// storage_record repeats the move balance so storage views need no join.
+	if err := qtx.SetStorageMoveBalance(ctx, gen.SetStorageMoveBalanceParams{
+		MoveBalanceCents: job.BalanceCents,
+		JobID:            entry.JobID,
+		TenantID:         entry.TenantID,
+	}); err != nil {
+		return gen.LedgerEntry{}, 0, err
+	}
+	return entry, job.BalanceCents, nil
+}
+
+// postMoveCharges opens a job's move account from its estimate: the total
+// as a charge and the deposit as a payment. Conversion, imports and the
+// ledger migration all open accounts this way, so the balance due does not
+// depend on how the job was created. source names where the numbers came
+// from in the memos.
+func postMoveCharges(ctx context.Context, qtx *gen.Queries, tenantID uuid.UUID, userID *uuid.UUID, jobID uuid.UUID, source string, totalCents, depositCents *int64) (int64, int64, error) {
+	var charged, deposit int64
+	if totalCents != nil && *totalCents > 0 {
+		memo := "Move charge from " + source
+		if _, _, err := postLedgerEntry(ctx, qtx, gen.CreateLedgerEntryParams{
+			TenantID:    tenantID,
+			JobID:       jobID,
+			Account:     string(oapi.LedgerAccountMove),
+			Kind:        string(oapi.Charge),
+			AmountCents: *totalCents,
+			Memo:        &memo,
+			CreatedBy:   userID,
+		}); err != nil {
+			return 0, 0, err
+		}
+		charged = *totalCents
+	}
+	if depositCents != nil && *depositCents > 0 {
+		memo := "Deposit from " + source
+		method := string(oapi.Other)
+		if _, _, err := postLedgerEntry(ctx, qtx, gen.CreateLedgerEntryParams{
+			TenantID:    tenantID,
+			JobID:       jobID,
+			Account:     string(oapi.LedgerAccountMove),
+			Kind:        string(oapi.Payment),
+			AmountCents: *depositCents,
+			Method:      &method,
+			Memo:        &memo,
+			CreatedBy:   userID,
+		}); err != nil {
+			return 0, 0, err
+		}
+		deposit = *depositCents
+	}
+	return charged, deposit, nil
+}
+
+// ledgerBalance reads the current balance of the account an entry was
+// posted to.
+func ledgerBalance(ctx context.Context, q *gen.Queries, entry gen.LedgerEntry) (int64, error) {
+	if entry.StorageRecordID != nil {
+		record, err := q.GetStorageRecordByID(ctx, gen.GetStorageRecordByIDParams{ID: *entry.StorageRecordID, TenantID: entry.TenantID})
+		return record.StorageBalanceCents, err
+	}
+	job, err := q.GetJobByID(ctx, gen.GetJobByIDParams{ID: entry.JobID, TenantID: entry.TenantID})
+	return job.BalanceCents, err
+}
+
+func mapLedgerEntry(entry gen.LedgerEntry) oapi.LedgerEntry {
+	var method *oapi.PaymentMethod
+	if entry.Method != nil {
+		value := oapi.PaymentMethod(*entry.Method)
+		method = &value
+	}
+	return oapi.LedgerEntry{
+		Id:                 entry.ID,
+		Account:            oapi.LedgerAccount(entry.Account),
+		JobId:              entry.JobID,
+		StorageRecordId:    entry.StorageRecordID,
+		Kind:               oapi.LedgerEntryKind(entry.Kind),
+		AmountCents:        entry.AmountCents,
+		BalanceEffectCents: entry.BalanceEffectCents,
+		Method:             method,
+		Reference:          entry.Reference,
+		Memo:               entry.Memo,
+		OccurredAt:         entry.OccurredAt.UTC(),
+		CreatedBy:          entry.CreatedBy,
+		CreatedAt:          entry.CreatedAt.UTC(),
+	}
+}
+
+// hashCreateLedgerEntryRequest fingerprints a posting together with its
+// account, so a key reused on another job is a conflict, not a replay.
+func hashCreateLedgerEntryRequest(account ledgerAccount, req oapi.CreateLedgerEntryRequest) string {
+	type fingerprint struct {
+		Account         string     `json:"account"`
+		JobID           uuid.UUID  `json:"jobId"`
+		StorageRecordID *uuid.UUID `json:"storageRecordId,omitempty"`
+		Kind            string     `json:"kind"`
+		AmountCents     int64      `json:"amountCents"`
+		Method          *string    `json:"method,omitempty"`
+		Reference       *string    `json:"reference,omitempty"`
+		Memo            *string    `json:"memo,omitempty"`
+		OccurredAt      *string    `json:"occurredAt,omitempty"`
+	}
+
+	payload := fingerprint{
+		Account:         string(account.name),
+		JobID:           account.jobID,
+		StorageRecordID: account.storageRecordID,
+		Kind:            string(req.Kind),
+		AmountCents:     req.AmountCents,
+		Reference:       sanitizeOptional(req.Reference),
+		Memo:            sanitizeOptional(req.Memo),
+	}
+	if req.Method != nil {
+		method := string(*req.Method)
+		payload.Method = &method
+	}
+	if req.OccurredAt != nil {
+		occurredAt := req.OccurredAt.UTC().Format(time.RFC3339Nano)
+		payload.OccurredAt = &occurredAt
+	}
+
+	encoded, _ := json.Marshal(payload)
+	digest := sha256.Sum256(encoded)
+	return hex.EncodeToString(digest[:])
+}
+
+// reconcileLedgerBalance brings an account to targetCents with a single
+// adjustment, in its own transaction. Imports use it to carry balances over
+// from another system; nothing is posted when the balance already matches.
+func (s *Server) reconcileLedgerBalance(ctx context.Context, tenantID uuid.UUID, userID *uuid.UUID, account ledgerAccount, targetCents int64, memo string) error {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	qtx := s.Q.WithTx(tx)
+
+	job, err := qtx.GetJobByIDForUpdate(ctx, gen.GetJobByIDForUpdateParams{ID: account.jobID, TenantID: tenantID})
+	if err != nil {
+		return err
+	}
+	currentCents := job.BalanceCents
+	if account.storageRecordID != nil {
+		record, err := qtx.GetStorageRecordByID(ctx, gen.GetStorageRecordByIDParams{ID: *account.storageRecordID, TenantID: tenantID})
+		if err != nil {
+			return err
+		}
+		currentCents = record.StorageBalanceCents
+	}
+	if currentCents == targetCents {
+		return nil
+	}
+
+	if _, _, err := postLedgerEntry(ctx, qtx, gen.CreateLedgerEntryParams{
+		TenantID:        tenantID,
+		JobID:           account.jobID,
+		StorageRecordID: account.storageRecordID,
+		Account:         string(account.name),
+		Kind:            string(oapi.Adjustment),
+		AmountCents:     targetCents - currentCents,
+		Memo:            &memo,
+		CreatedBy:       userID,
+	}); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,297 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/moveops-platform/apps/api/internal/audit"
+	"github.com/moveops-platform/apps/api/internal/auth"
+	gen "github.com/moveops-platform/apps/api/internal/gen/db"
+	"github.com/moveops-platform/apps/api/internal/gen/oapi"
+	"github.com/moveops-platform/apps/api/internal/httpx"
+	"github.com/moveops-platform/apps/api/internal/middleware"
+)
+
+const (
+	totpIssuer        = "MoveOps"
+	recoveryCodeCount = 10
+)
+
+func sessionPrincipalFromActor(actor middleware.Actor, userID, tenantID uuid.UUID) sessionPrincipal {
+	return sessionPrincipal{
+		UserID:     userID,
+		TenantID:   tenantID,
+		Email:      actor.Email,
+		FullName:   actor.FullName,
+		TenantSlug: actor.TenantSlug,
+		TenantName: actor.TenantName,
+	}
+}
+
+// PostAuthMfaVerify completes a login started by the password step. Wrong
+// codes count towards the same lockout as wrong passwords.
+func (s *Server) PostAuthMfaVerify(w http.ResponseWriter, r *http.Request) {
+	actor, tenantID, userID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req oapi.MfaVerifyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "invalid_body", "Malformed JSON body", nil)
+		return
+	}
+	code := strings.TrimSpace(derefString(req.Code))
+	recoveryCode := auth.NormalizeRecoveryCode(derefString(req.RecoveryCode))
+	if (code == "") == (recoveryCode == "") {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "Provide exactly one of code or recoveryCode", nil)
+		return
+	}
+
+	locks, err := s.Q.GetLoginLockState(r.Context(), []uuid.UUID{userID})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to check account lock", nil)
+		return
+	}
+	if len(locks) > 0 && locks[0].LockedUntil != nil {
+		if sessionID, err := uuid.Parse(actor.SessionID); err == nil {
+			_, _ = s.Q.RevokeSessionByID(r.Context(), gen.RevokeSessionByIDParams{ID: sessionID, TenantID: tenantID})
+		}
+		lockedUntil := *locks[0].LockedUntil
+		retryAfter := int(time.Until(lockedUntil).Round(time.Second).Seconds())
+		if retryAfter < 1 {
+			retryAfter = 1
+		}
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+		httpx.WriteError(w, r, http.StatusLocked, "account_locked", "Account is temporarily locked after repeated failed logins", map[string]any{"lockedUntil": lockedUntil.UTC()})
+		return
+	}
+
+	mfa, err := s.Q.GetUserMFA(r.Context(), gen.GetUserMFAParams{UserID: userID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusConflict, "mfa_not_enabled", "Two-factor authentication is not enabled", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load MFA settings", nil)
+		return
+	}
+	if mfa.ConfirmedAt == nil {
+		httpx.WriteError(w, r, http.StatusConflict, "mfa_not_enabled", "Two-factor authentication is not enabled", nil)
+		return
+	}
+
+	method := "mfa_totp"
+	verified := false
+	if code != "" {
+		step, valid, err := auth.VerifyTOTP(mfa.TotpSecret, code, time.Now(), mfa.LastUsedStep)
+		if err != nil {
+			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to verify code", nil)
+			return
+		}
+		if valid {
+			// The conditional update makes each step single-use even when two
+			// requests race with the same code.
+			affected, err := s.Q.AdvanceUserMFAStep(r.Context(), gen.AdvanceUserMFAStepParams{UserID: userID, Step: step})
+			if err != nil {
+				httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to verify code", nil)
+				return
+			}
+			verified = affected == 1
+		}
+	} else {
+		method = "mfa_recovery_code"
+		affected, err := s.Q.UseRecoveryCode(r.Context(), gen.UseRecoveryCodeParams{UserID: userID, CodeHash: auth.HashToken(recoveryCode)})
+		if err != nil {
+			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to verify recovery code", nil)
+			return
+		}
+		verified = affected == 1
+	}
+
+	principal := sessionPrincipalFromActor(actor, userID, tenantID)
+	// The full session keeps the accounts the password step proved.
+	principal.LinkedUserIDs, err = s.sessionLinkedUserIDs(r.Context(), actor, tenantID, userID)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load session", nil)
+		return
+	}
+	if !verified {
+		s.recordLoginFailures(r, []sessionPrincipal{principal})
+		httpx.WriteError(w, r, http.StatusUnauthorized, "invalid_mfa_code", "Invalid two-factor code", nil)
+		return
+	}
+
+	if _, err := s.Q.ClearLoginFailures(r.Context(), gen.ClearLoginFailuresParams{UserID: userID, TenantID: tenantID}); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to reset login failures", nil)
+		return
+	}
+
+	resp, ok := s.startSession(w, r, principal)
+	if !ok {
+		return
+	}
+
+	_ = s.Audit.Log(r.Context(), audit.Entry{
+		TenantID:   tenantID,
+		UserID:     &userID,
+		Action:     "auth.login",
+		EntityType: "session",
+		RequestID:  middleware.RequestIDFromContext(r.Context()),
+		Metadata: map[string]any{
+			"method": method,
+		},
+	})
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+// PostAuthMfaTotpEnroll starts (or restarts) TOTP enrollment. The secret is
+// not used for logins until PostAuthMfaTotpConfirm proves the authenticator
+// app has it.
+func (s *Server) PostAuthMfaTotpEnroll(w http.ResponseWriter, r *http.Request) {
+	actor, tenantID, userID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+	if actor.MFAEnabled {
+		httpx.WriteError(w, r, http.StatusConflict, "mfa_already_enabled", "Two-factor authentication is already enabled", nil)
+		return
+	}
+
+	secret, err := auth.GenerateTOTPSecret()
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to generate secret", nil)
+		return
+	}
+
+	if _, err := s.Q.UpsertPendingUserMFA(r.Context(), gen.UpsertPendingUserMFAParams{
+		UserID:     userID,
+		TenantID:   tenantID,
+		TotpSecret: secret,
+	}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusConflict, "mfa_already_enabled", "Two-factor authentication is already enabled", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to start enrollment", nil)
+		return
+	}
+
+	_ = s.Audit.Log(r.Context(), audit.Entry{
+		TenantID:   tenantID,
+		UserID:     &userID,
+		Action:     "auth.mfa_enrollment_started",
+		EntityType: "user",
+		EntityID:   &userID,
+		RequestID:  middleware.RequestIDFromContext(r.Context()),
+		Metadata:   map[string]any{"method": "totp"},
+	})
+
+	httpx.WriteJSON(w, http.StatusOK, oapi.TotpEnrollmentResponse{
+		Secret:          secret,
+		ProvisioningUri: auth.TOTPProvisioningURI(secret, totpIssuer, actor.Email+" ("+actor.TenantSlug+")"),
+	})
+}
+
+// PostAuthMfaTotpConfirm enables TOTP after the first valid code and returns
+// a fresh set of recovery codes. Only their hashes are stored.
+func (s *Server) PostAuthMfaTotpConfirm(w http.ResponseWriter, r *http.Request) {
+	_, tenantID, userID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req oapi.TotpConfirmRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "invalid_body", "Malformed JSON body", nil)
+		return
+	}
+
+	mfa, err := s.Q.GetUserMFA(r.Context(), gen.GetUserMFAParams{UserID: userID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusConflict, "mfa_not_enrolling", "Start enrollment before confirming", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load MFA settings", nil)
+		return
+	}
+	if mfa.ConfirmedAt != nil {
+		httpx.WriteError(w, r, http.StatusConflict, "mfa_already_enabled", "Two-factor authentication is already enabled", nil)
+		return
+	}
+
+	step, valid, err := auth.VerifyTOTP(mfa.TotpSecret, req.Code, time.Now(), mfa.LastUsedStep)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to verify code", nil)
+		return
+	}
+	if !valid {
+		httpx.WriteError(w, r, http.StatusBadRequest, "invalid_mfa_code", "Invalid two-factor code", nil)
+		return
+	}
+
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for len(codes) < recoveryCodeCount {
+		code, err := auth.GenerateRecoveryCode()
+		if err != nil {
+			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to generate recovery codes", nil)
+			return
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, auth.HashToken(auth.NormalizeRecoveryCode(code)))
+	}
+
+	tx, err := s.DB.Begin(r.Context())
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to start transaction", nil)
+		return
+	}
+	defer tx.Rollback(r.Context())
+	qtx := s.Q.WithTx(tx)
+
+	affected, err := qtx.ConfirmUserMFA(r.Context(), gen.ConfirmUserMFAParams{UserID: userID, Step: step})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to enable MFA", nil)
+		return
+	}
+	if affected != 1 {
+		httpx.WriteError(w, r, http.StatusConflict, "mfa_already_enabled", "Two-factor authentication is already enabled", nil)
+		return
+	}
+	if err := qtx.DeleteRecoveryCodes(r.Context(), userID); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to store recovery codes", nil)
+		return
+	}
+	if err := qtx.InsertRecoveryCodes(r.Context(), gen.InsertRecoveryCodesParams{UserID: userID, CodeHashes: hashes}); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to store recovery codes", nil)
+		return
+	}
+	if err := tx.Commit(r.Context()); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to commit MFA enrollment", nil)
+		return
+	}
+
+	_ = s.Audit.Log(r.Context(), audit.Entry{
+		TenantID:   tenantID,
+		UserID:     &userID,
+		Action:     "auth.mfa_enabled",
+		EntityType: "user",
+		EntityID:   &userID,
+		RequestID:  middleware.RequestIDFromContext(r.Context()),
+		Metadata: map[string]any{
+			"method":            "totp",
+			"recoveryCodeCount": len(codes),
+		},
+	})
+
+	httpx.WriteJSON(w, http.StatusOK, oapi.RecoveryCodesResponse{RecoveryCodes: codes})
+}
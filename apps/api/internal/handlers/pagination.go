@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moveops-platform/apps/api/internal/httpx"
+)
+
+const (
+	defaultListLimit = 25
+	maxListLimit     = 100
+)
+
+// resolveListLimit applies the shared list limit rules: default when unset,
+// reject values below 1 and clamp anything above the maximum.
+func resolveListLimit(w http.ResponseWriter, r *http.Request, requested *int) (int, bool) {
+	if requested == nil {
+		return defaultListLimit, true
+	}
+	switch {
+	case *requested < 1:
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "limit must be at least 1", nil)
+		return 0, false
+	case *requested > maxListLimit:
+		return maxListLimit, true
+	default:
+		return *requested, true
+	}
+}
+
+// decodeOptionalListCursor decodes a keyset cursor when one was supplied and
+// writes a 400 response when it is malformed.
+func decodeOptionalListCursor(w http.ResponseWriter, r *http.Request, raw *string) (*time.Time, *uuid.UUID, bool) {
+	if raw == nil || strings.TrimSpace(*raw) == "" {
+		return nil, nil, true
+	}
+	sortAt, id, err := decodeListCursor(*raw)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "invalid_cursor", "cursor is invalid", nil)
+		return nil, nil, false
+	}
+	return &sortAt, &id, true
+}
+
+func encodeListCursor(sortAt time.Time, id uuid.UUID) string {
+	payload := sortAt.UTC().Format(time.RFC3339Nano) + "|" + id.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(payload))
+}
+
+func decodeListCursor(raw string) (time.Time, uuid.UUID, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimSpace(raw))
+	if err != nil {
+		return time.Time{}, uuid.Nil, fmt.Errorf("decode cursor: %w", err)
+	}
+	parts := strings.SplitN(string(decoded), "|", 2)
+	if len(parts) != 2 {
+		return time.Time{}, uuid.Nil, errors.New("cursor payload is malformed")
+	}
+
+	sortAt, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return time.Time{}, uuid.Nil, fmt.Errorf("parse cursor timestamp: %w", err)
+	}
+	id, err := uuid.Parse(parts[1])
+	if err != nil {
+		return time.Time{}, uuid.Nil, fmt.Errorf("parse cursor id: %w", err)
+	}
+	return sortAt, id, nil
+}
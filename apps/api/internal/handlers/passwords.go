@@ -0,0 +1,327 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/moveops-platform/apps/api/internal/audit"
+	"github.com/moveops-platform/apps/api/internal/auth"
+	gen "github.com/moveops-platform/apps/api/internal/gen/db"
+	"github.com/moveops-platform/apps/api/internal/gen/oapi"
+	"github.com/moveops-platform/apps/api/internal/httpx"
+	"github.com/moveops-platform/apps/api/internal/middleware"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// PostAuthPassword changes the caller's password. A wrong current password
+// counts towards the account lockout like a failed login.
+func (s *Server) PostAuthPassword(w http.ResponseWriter, r *http.Request) {
+	actor, tenantID, userID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+	sessionID, err := uuid.Parse(actor.SessionID)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusUnauthorized, "unauthorized", "Invalid session", nil)
+		return
+	}
+
+	var req oapi.ChangePasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "invalid_body", "Malformed JSON body", nil)
+		return
+	}
+
+	current, err := s.Q.GetUserPasswordHash(r.Context(), gen.GetUserPasswordHashParams{ID: userID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusUnauthorized, "unauthorized", "Authentication required", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load user", nil)
+		return
+	}
+	matches, err := auth.VerifyPassword(req.CurrentPassword, current.PasswordHash)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Password verification failed", nil)
+		return
+	}
+	if !matches {
+		s.recordLoginFailures(r, []sessionPrincipal{{UserID: userID, TenantID: tenantID, Email: current.Email, FullName: current.FullName}})
+		httpx.WriteError(w, r, http.StatusBadRequest, "invalid_current_password", "Current password is incorrect", nil)
+		return
+	}
+	if req.NewPassword == req.CurrentPassword {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "newPassword must differ from the current password", nil)
+		return
+	}
+
+	policy, ok := s.tenantPasswordPolicy(w, r, tenantID)
+	if !ok {
+		return
+	}
+	if !checkPasswordPolicy(w, r, policy, req.NewPassword, current.Email, current.FullName) {
+		return
+	}
+	passwordHash, err := auth.HashPassword(req.NewPassword)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to hash password", nil)
+		return
+	}
+
+	tx, err := s.DB.Begin(r.Context())
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to begin transaction", nil)
+		return
+	}
+	defer tx.Rollback(r.Context())
+	qtx := s.Q.WithTx(tx)
+
+	if _, err := qtx.UpdateUserPasswordHash(r.Context(), gen.UpdateUserPasswordHashParams{
+		ID:           userID,
+		TenantID:     tenantID,
+		PasswordHash: passwordHash,
+	}); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to change password", nil)
+		return
+	}
+	sessionsRevoked, err := qtx.RevokeOtherUserSessions(r.Context(), gen.RevokeOtherUserSessionsParams{
+		UserID:        userID,
+		TenantID:      tenantID,
+		KeepSessionID: sessionID,
+	})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to revoke sessions", nil)
+		return
+	}
+	if err := qtx.DeleteUnusedPasswordResetTokens(r.Context(), gen.DeleteUnusedPasswordResetTokensParams{UserID: userID, TenantID: tenantID}); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to invalidate reset tokens", nil)
+		return
+	}
+	if err := tx.Commit(r.Context()); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to commit password change", nil)
+		return
+	}
+
+	_ = s.Audit.Log(r.Context(), audit.Entry{
+		TenantID:   tenantID,
+		UserID:     &userID,
+		Action:     "auth.password_changed",
+		EntityType: "user",
+		EntityID:   &userID,
+		RequestID:  middleware.RequestIDFromContext(r.Context()),
+		Metadata: map[string]any{
+			"sessionsRevoked": sessionsRevoked,
+		},
+	})
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PostUsersUserIdPasswordResetToken issues a reset token for an admin to hand
+// to the user. Only its hash is stored.
+func (s *Server) PostUsersUserIdPasswordResetToken(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
+	actor, tenantID, actorUserID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+	targetID := uuid.UUID(userId)
+	if !s.canResetCredentials(w, r, actor, tenantID, targetID) {
+		return
+	}
+
+	token, err := auth.GenerateToken()
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to generate reset token", nil)
+		return
+	}
+
+	tx, err := s.DB.Begin(r.Context())
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to begin transaction", nil)
+		return
+	}
+	defer tx.Rollback(r.Context())
+	qtx := s.Q.WithTx(tx)
+
+	if err := qtx.DeleteUnusedPasswordResetTokens(r.Context(), gen.DeleteUnusedPasswordResetTokensParams{UserID: targetID, TenantID: tenantID}); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to invalidate reset tokens", nil)
+		return
+	}
+	created, err := qtx.CreatePasswordResetToken(r.Context(), gen.CreatePasswordResetTokenParams{
+		TokenHash: auth.HashToken(token),
+		CreatedBy: &actorUserID,
+		ExpiresAt: time.Now().Add(s.Config.PasswordResetTokenTTL),
+		UserID:    targetID,
+		TenantID:  tenantID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusNotFound, "user_not_found", "User was not found", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to create reset token", nil)
+		return
+	}
+	if err := tx.Commit(r.Context()); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to commit reset token", nil)
+		return
+	}
+
+	_ = s.Audit.Log(r.Context(), audit.Entry{
+		TenantID:   tenantID,
+		UserID:     &actorUserID,
+		Action:     "users.password_reset_token",
+		EntityType: "user",
+		EntityID:   &targetID,
+		RequestID:  middleware.RequestIDFromContext(r.Context()),
+		Metadata: map[string]any{
+			"expiresAt": created.ExpiresAt.UTC(),
+		},
+	})
+
+	httpx.WriteJSON(w, http.StatusCreated, oapi.PasswordResetTokenResponse{
+		Token:     token,
+		ExpiresAt: created.ExpiresAt.UTC(),
+		RequestId: middleware.RequestIDFromContext(r.Context()),
+	})
+}
+
+// PostAuthPasswordReset sets a new password with a reset token. Unknown, used
+// and expired tokens all get the same answer.
+func (s *Server) PostAuthPasswordReset(w http.ResponseWriter, r *http.Request) {
+	var req oapi.CompletePasswordResetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "invalid_body", "Malformed JSON body", nil)
+		return
+	}
+
+	reset, err := s.Q.GetPasswordResetToken(r.Context(), auth.HashToken(strings.TrimSpace(req.Token)))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusBadRequest, "invalid_reset_token", "Reset token is invalid or has expired", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load reset token", nil)
+		return
+	}
+
+	policy := auth.PasswordPolicy{MinLength: int(reset.PasswordMinLength), CheckBreached: reset.PasswordCheckBreached}
+	if !checkPasswordPolicy(w, r, policy, req.NewPassword, reset.Email, reset.FullName) {
+		return
+	}
+	passwordHash, err := auth.HashPassword(req.NewPassword)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to hash password", nil)
+		return
+	}
+
+	tx, err := s.DB.Begin(r.Context())
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to begin transaction", nil)
+		return
+	}
+	defer tx.Rollback(r.Context())
+	qtx := s.Q.WithTx(tx)
+
+	// The conditional update keeps the token single-use when two requests
+	// race with it.
+	used, err := qtx.UsePasswordResetToken(r.Context(), reset.ID)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to use reset token", nil)
+		return
+	}
+	if used != 1 {
+		httpx.WriteError(w, r, http.StatusBadRequest, "invalid_reset_token", "Reset token is invalid or has expired", nil)
+		return
+	}
+	if _, err := qtx.UpdateUserPasswordHash(r.Context(), gen.UpdateUserPasswordHashParams{
+		ID:           reset.UserID,
+		TenantID:     reset.TenantID,
+		PasswordHash: passwordHash,
+	}); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to reset password", nil)
+		return
+	}
+	sessionsRevoked, err := qtx.RevokeSessionsForUser(r.Context(), gen.RevokeSessionsForUserParams{UserID: reset.UserID, TenantID: reset.TenantID})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to revoke sessions", nil)
+		return
+	}
+	if _, err := qtx.ClearLoginFailures(r.Context(), gen.ClearLoginFailuresParams{UserID: reset.UserID, TenantID: reset.TenantID}); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to reset login failures", nil)
+		return
+	}
+	if err := tx.Commit(r.Context()); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to commit password reset", nil)
+		return
+	}
+
+	_ = s.Audit.Log(r.Context(), audit.Entry{
+		TenantID:   reset.TenantID,
+		UserID:     &reset.UserID,
+		Action:     "auth.password_reset_completed",
+		EntityType: "user",
+		EntityID:   &reset.UserID,
+		RequestID:  middleware.RequestIDFromContext(r.Context()),
+		Metadata: map[string]any{
+			"sessionsRevoked": sessionsRevoked,
+			"ip":              middleware.ClientIP(r),
+		},
+	})
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) tenantPasswordPolicy(w http.ResponseWriter, r *http.Request, tenantID uuid.UUID) (auth.PasswordPolicy, bool) {
+	settings, err := s.Q.GetTenantSettings(r.Context(), tenantID)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load password policy", nil)
+		return auth.PasswordPolicy{}, false
+	}
+	return auth.PasswordPolicy{MinLength: int(settings.PasswordMinLength), CheckBreached: settings.PasswordCheckBreached}, true
+}
+
+// checkPasswordPolicy writes 400 weak_password listing the violations when
+// password breaks policy.
+func checkPasswordPolicy(w http.ResponseWriter, r *http.Request, policy auth.PasswordPolicy, password string, accountInputs ...string) bool {
+	err := policy.Check(password, accountInputs...)
+	if err == nil {
+		return true
+	}
+	var policyErr *auth.PasswordPolicyError
+	if !errors.As(err, &policyErr) {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to check password", nil)
+		return false
+	}
+	httpx.WriteError(w, r, http.StatusBadRequest, "weak_password", "Password does not meet the password policy", map[string]any{
+		"violations": policyErr.Violations,
+		"minLength":  max(policy.MinLength, auth.MinPasswordLengthFloor),
+	})
+	return false
+}
+
+// upgradePasswordHash rehashes a just-verified password whose hash uses old
+// argon2 parameters. Failures only cost the upgrade, not the login.
+func (s *Server) upgradePasswordHash(ctx context.Context, userID, tenantID uuid.UUID, password, encoded string) {
+	if !auth.PasswordNeedsRehash(encoded) {
+		return
+	}
+	passwordHash, err := auth.HashPassword(password)
+	if err == nil {
+		_, err = s.Q.UpdateUserPasswordHash(ctx, gen.UpdateUserPasswordHashParams{
+			ID:           userID,
+			TenantID:     tenantID,
+			PasswordHash: passwordHash,
+		})
+	}
+	if err != nil {
+		s.Logger.Warn("password rehash failed", "user_id", userID, "error", err)
+	}
+}
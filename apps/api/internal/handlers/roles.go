@@ -0,0 +1,568 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/moveops-platform/apps/api/internal/audit"
+	gen "github.com/moveops-platform/apps/api/internal/gen/db"
+	"github.com/moveops-platform/apps/api/internal/gen/oapi"
+	"github.com/moveops-platform/apps/api/internal/httpx"
+	"github.com/moveops-platform/apps/api/internal/middleware"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// adminCapablePermission marks a role as able to repair tenant access. Every
+// tenant must keep at least one active user holding it.
+const adminCapablePermission = "roles.manage"
+
+func (s *Server) GetPermissions(w http.ResponseWriter, r *http.Request) {
+	if _, _, _, ok := requireActorIDs(w, r); !ok {
+		return
+	}
+
+	rows, err := s.Q.ListPermissions(r.Context())
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load permissions", nil)
+		return
+	}
+
+	items := make([]oapi.Permission, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, oapi.Permission{Name: row.Name, Description: row.Description})
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, oapi.PermissionListResponse{
+		Items:     items,
+		RequestId: middleware.RequestIDFromContext(r.Context()),
+	})
+}
+
+func (s *Server) GetRoles(w http.ResponseWriter, r *http.Request) {
+	_, tenantID, _, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	rows, err := s.Q.ListTenantRoles(r.Context(), tenantID)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load roles", nil)
+		return
+	}
+
+	items := make([]oapi.Role, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, mapRole(gen.GetTenantRoleByIDRow(row)))
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, oapi.RoleListResponse{
+		Items:     items,
+		RequestId: middleware.RequestIDFromContext(r.Context()),
+	})
+}
+
+func (s *Server) PostRoles(w http.ResponseWriter, r *http.Request) {
+	_, tenantID, userID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req oapi.CreateRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "invalid_body", "Malformed JSON body", nil)
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "name is required", nil)
+		return
+	}
+	description := ""
+	if req.Description != nil {
+		description = strings.TrimSpace(*req.Description)
+	}
+	permissions := normalizePermissionNames(req.Permissions)
+
+	tx, err := s.DB.Begin(r.Context())
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to begin transaction", nil)
+		return
+	}
+	defer tx.Rollback(r.Context())
+	qtx := s.Q.WithTx(tx)
+
+	if !s.validatePermissionNames(w, r, qtx, permissions) {
+		return
+	}
+
+	role, err := qtx.CreateRole(r.Context(), gen.CreateRoleParams{
+		TenantID:    tenantID,
+		Name:        name,
+		Description: description,
+	})
+	if err != nil {
+		if isUniqueConstraint(err, "roles_tenant_id_name_key") {
+			httpx.WriteError(w, r, http.StatusConflict, "role_name_conflict", "A role with this name already exists", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to create role", nil)
+		return
+	}
+	if len(permissions) > 0 {
+		if err := qtx.AddRolePermissionsByName(r.Context(), gen.AddRolePermissionsByNameParams{RoleID: role.ID, Names: permissions}); err != nil {
+			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to assign permissions", nil)
+			return
+		}
+	}
+
+	if err := tx.Commit(r.Context()); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to commit role", nil)
+		return
+	}
+
+	roleID := role.ID
+	_ = s.Audit.Log(r.Context(), audit.Entry{
+		TenantID:   tenantID,
+		UserID:     &userID,
+		Action:     "roles.create",
+		EntityType: "role",
+		EntityID:   &roleID,
+		RequestID:  middleware.RequestIDFromContext(r.Context()),
+		Metadata: map[string]any{
+			"name":        name,
+			"permissions": permissions,
+		},
+	})
+
+	s.writeRoleResponse(w, r, tenantID, role.ID, http.StatusCreated)
+}
+
+func (s *Server) GetRolesRoleId(w http.ResponseWriter, r *http.Request, roleId openapi_types.UUID) {
+	_, tenantID, _, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	s.writeRoleResponse(w, r, tenantID, uuid.UUID(roleId), http.StatusOK)
+}
+
+func (s *Server) PatchRolesRoleId(w http.ResponseWriter, r *http.Request, roleId openapi_types.UUID) {
+	_, tenantID, userID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req oapi.UpdateRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "invalid_body", "Malformed JSON body", nil)
+		return
+	}
+
+	name := sanitizeOptional(req.Name)
+	if req.Name != nil && name == nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "name cannot be blank", nil)
+		return
+	}
+	var description *string
+	if req.Description != nil {
+		trimmed := strings.TrimSpace(*req.Description)
+		description = &trimmed
+	}
+
+	roleID := uuid.UUID(roleId)
+	tx, err := s.DB.Begin(r.Context())
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to begin transaction", nil)
+		return
+	}
+	defer tx.Rollback(r.Context())
+	qtx := s.Q.WithTx(tx)
+
+	if err := qtx.LockTenantRoles(r.Context(), tenantID); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to lock roles", nil)
+		return
+	}
+	adminCapableBefore, ok := s.countAdminCapableUsers(w, r, qtx, tenantID)
+	if !ok {
+		return
+	}
+	before, err := qtx.GetTenantRoleByID(r.Context(), gen.GetTenantRoleByIDParams{ID: roleID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusNotFound, "role_not_found", "Role was not found", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load role", nil)
+		return
+	}
+
+	after, err := qtx.UpdateRole(r.Context(), gen.UpdateRoleParams{
+		ID:          roleID,
+		TenantID:    tenantID,
+		Name:        name,
+		Description: description,
+	})
+	if err != nil {
+		if isUniqueConstraint(err, "roles_tenant_id_name_key") {
+			httpx.WriteError(w, r, http.StatusConflict, "role_name_conflict", "A role with this name already exists", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to update role", nil)
+		return
+	}
+
+	var added, removed []string
+	if req.Permissions != nil {
+		permissions := normalizePermissionNames(*req.Permissions)
+		if !s.validatePermissionNames(w, r, qtx, permissions) {
+			return
+		}
+		if err := qtx.ClearRolePermissions(r.Context(), roleID); err != nil {
+			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to update permissions", nil)
+			return
+		}
+		if len(permissions) > 0 {
+			if err := qtx.AddRolePermissionsByName(r.Context(), gen.AddRolePermissionsByNameParams{RoleID: roleID, Names: permissions}); err != nil {
+				httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to update permissions", nil)
+				return
+			}
+		}
+		added, removed = diffStringSets(before.PermissionNames, permissions)
+		if !s.ensureAdminCapableUserRemains(w, r, qtx, tenantID, adminCapableBefore) {
+			return
+		}
+	}
+
+	if err := tx.Commit(r.Context()); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to commit role", nil)
+		return
+	}
+
+	fieldsChanged := map[string]any{}
+	if before.Name != after.Name {
+		fieldsChanged["name"] = map[string]any{"before": before.Name, "after": after.Name}
+	}
+	if before.Description != after.Description {
+		fieldsChanged["description"] = map[string]any{"before": before.Description, "after": after.Description}
+	}
+	if len(fieldsChanged) > 0 || len(added) > 0 || len(removed) > 0 {
+		_ = s.Audit.Log(r.Context(), audit.Entry{
+			TenantID:   tenantID,
+			UserID:     &userID,
+			Action:     "roles.update",
+			EntityType: "role",
+			EntityID:   &roleID,
+			RequestID:  middleware.RequestIDFromContext(r.Context()),
+			Metadata: map[string]any{
+				"fieldsChanged":      fieldsChanged,
+				"permissionsAdded":   added,
+				"permissionsRemoved": removed,
+			},
+		})
+	}
+
+	s.writeRoleResponse(w, r, tenantID, roleID, http.StatusOK)
+}
+
+func (s *Server) DeleteRolesRoleId(w http.ResponseWriter, r *http.Request, roleId openapi_types.UUID) {
+	_, tenantID, userID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	roleID := uuid.UUID(roleId)
+	tx, err := s.DB.Begin(r.Context())
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to begin transaction", nil)
+		return
+	}
+	defer tx.Rollback(r.Context())
+	qtx := s.Q.WithTx(tx)
+
+	if err := qtx.LockTenantRoles(r.Context(), tenantID); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to lock roles", nil)
+		return
+	}
+	adminCapableBefore, ok := s.countAdminCapableUsers(w, r, qtx, tenantID)
+	if !ok {
+		return
+	}
+	role, err := qtx.GetTenantRoleByID(r.Context(), gen.GetTenantRoleByIDParams{ID: roleID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusNotFound, "role_not_found", "Role was not found", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load role", nil)
+		return
+	}
+	if _, err := qtx.DeleteRole(r.Context(), gen.DeleteRoleParams{ID: roleID, TenantID: tenantID}); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to delete role", nil)
+		return
+	}
+	if !s.ensureAdminCapableUserRemains(w, r, qtx, tenantID, adminCapableBefore) {
+		return
+	}
+
+	if err := tx.Commit(r.Context()); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to commit role", nil)
+		return
+	}
+
+	_ = s.Audit.Log(r.Context(), audit.Entry{
+		TenantID:   tenantID,
+		UserID:     &userID,
+		Action:     "roles.delete",
+		EntityType: "role",
+		EntityID:   &roleID,
+		RequestID:  middleware.RequestIDFromContext(r.Context()),
+		Metadata: map[string]any{
+			"name":        role.Name,
+			"permissions": role.PermissionNames,
+			"userCount":   role.UserCount,
+		},
+	})
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) PutUsersUserIdRoles(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
+	_, tenantID, actorUserID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req oapi.SetUserRolesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "invalid_body", "Malformed JSON body", nil)
+		return
+	}
+
+	roleIDs := make([]uuid.UUID, 0, len(req.RoleIds))
+	seen := map[uuid.UUID]struct{}{}
+	for _, id := range req.RoleIds {
+		roleID := uuid.UUID(id)
+		if _, dup := seen[roleID]; dup {
+			continue
+		}
+		seen[roleID] = struct{}{}
+		roleIDs = append(roleIDs, roleID)
+	}
+
+	targetID := uuid.UUID(userId)
+	tx, err := s.DB.Begin(r.Context())
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to begin transaction", nil)
+		return
+	}
+	defer tx.Rollback(r.Context())
+	qtx := s.Q.WithTx(tx)
+
+	if err := qtx.LockTenantRoles(r.Context(), tenantID); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to lock roles", nil)
+		return
+	}
+	adminCapableBefore, ok := s.countAdminCapableUsers(w, r, qtx, tenantID)
+	if !ok {
+		return
+	}
+	before, err := qtx.GetTenantUserByID(r.Context(), gen.GetTenantUserByIDParams{ID: targetID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusNotFound, "user_not_found", "User was not found", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load user", nil)
+		return
+	}
+	if len(roleIDs) > 0 {
+		count, err := qtx.CountTenantRolesByIDs(r.Context(), gen.CountTenantRolesByIDsParams{TenantID: tenantID, RoleIds: roleIDs})
+		if err != nil {
+			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load roles", nil)
+			return
+		}
+		if count != int64(len(roleIDs)) {
+			httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "roleIds must reference roles in this tenant", nil)
+			return
+		}
+	}
+
+	if err := qtx.ClearUserRoles(r.Context(), gen.ClearUserRolesParams{UserID: targetID, TenantID: tenantID}); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to update roles", nil)
+		return
+	}
+	for _, roleID := range roleIDs {
+		if _, err := qtx.AssignUserRole(r.Context(), gen.AssignUserRoleParams{
+			UserID:   targetID,
+			RoleID:   roleID,
+			TenantID: tenantID,
+		}); err != nil {
+			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to update roles", nil)
+			return
+		}
+	}
+	if !s.ensureAdminCapableUserRemains(w, r, qtx, tenantID, adminCapableBefore) {
+		return
+	}
+
+	after, err := qtx.GetTenantUserByID(r.Context(), gen.GetTenantUserByIDParams{ID: targetID, TenantID: tenantID})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load user", nil)
+		return
+	}
+
+	if err := tx.Commit(r.Context()); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to commit roles", nil)
+		return
+	}
+
+	added, removed := diffStringSets(before.RoleNames, after.RoleNames)
+	if len(added) > 0 || len(removed) > 0 {
+		_ = s.Audit.Log(r.Context(), audit.Entry{
+			TenantID:   tenantID,
+			UserID:     &actorUserID,
+			Action:     "users.roles_update",
+			EntityType: "user",
+			EntityID:   &targetID,
+			RequestID:  middleware.RequestIDFromContext(r.Context()),
+			Metadata: map[string]any{
+				"rolesAdded":   added,
+				"rolesRemoved": removed,
+			},
+		})
+	}
+
+	s.writeTenantUserResponse(w, r, tenantID, targetID, http.StatusOK, nil)
+}
+
+// countAdminCapableUsers returns how many active users hold
+// adminCapablePermission. Callers read it after LockTenantRoles and pass it to
+// ensureAdminCapableUserRemains once the change has been applied.
+func (s *Server) countAdminCapableUsers(w http.ResponseWriter, r *http.Request, qtx *gen.Queries, tenantID uuid.UUID) (int64, bool) {
+	count, err := qtx.CountActiveUsersWithPermission(r.Context(), gen.CountActiveUsersWithPermissionParams{
+		TenantID:   tenantID,
+		Permission: adminCapablePermission,
+	})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to verify admin access", nil)
+		return 0, false
+	}
+	return count, true
+}
+
+// ensureAdminCapableUserRemains rejects a change that leaves the tenant without
+// an active user holding adminCapablePermission. Tenants that had none before
+// the change are not blocked.
+func (s *Server) ensureAdminCapableUserRemains(w http.ResponseWriter, r *http.Request, qtx *gen.Queries, tenantID uuid.UUID, before int64) bool {
+	if before == 0 {
+		return true
+	}
+	remaining, ok := s.countAdminCapableUsers(w, r, qtx, tenantID)
+	if !ok {
+		return false
+	}
+	if remaining == 0 {
+		httpx.WriteError(w, r, http.StatusConflict, "last_admin_role", "The tenant must keep at least one active user with "+adminCapablePermission, nil)
+		return false
+	}
+	return true
+}
+
+func (s *Server) validatePermissionNames(w http.ResponseWriter, r *http.Request, qtx *gen.Queries, names []string) bool {
+	if len(names) == 0 {
+		return true
+	}
+	count, err := qtx.CountPermissionsByNames(r.Context(), names)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load permissions", nil)
+		return false
+	}
+	if count != int64(len(names)) {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "permissions must reference the permission catalog", nil)
+		return false
+	}
+	return true
+}
+
+func (s *Server) writeRoleResponse(w http.ResponseWriter, r *http.Request, tenantID, roleID uuid.UUID, status int) {
+	role, err := s.Q.GetTenantRoleByID(r.Context(), gen.GetTenantRoleByIDParams{ID: roleID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusNotFound, "role_not_found", "Role was not found", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load role", nil)
+		return
+	}
+
+	httpx.WriteJSON(w, status, oapi.RoleResponse{
+		Role:      mapRole(role),
+		RequestId: middleware.RequestIDFromContext(r.Context()),
+	})
+}
+
+func mapRole(role gen.GetTenantRoleByIDRow) oapi.Role {
+	permissions := role.PermissionNames
+	if permissions == nil {
+		permissions = []string{}
+	}
+	return oapi.Role{
+		Id:          role.ID,
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: permissions,
+		UserCount:   int(role.UserCount),
+		CreatedAt:   role.CreatedAt.UTC(),
+	}
+}
+
+func normalizePermissionNames(names []string) []string {
+	seen := map[string]struct{}{}
+	out := make([]string, 0, len(names))
+	for _, name := range names {
+		trimmed := strings.TrimSpace(name)
+		if trimmed == "" {
+			continue
+		}
+		if _, dup := seen[trimmed]; dup {
+			continue
+		}
+		seen[trimmed] = struct{}{}
+		out = append(out, trimmed)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func diffStringSets(before, after []string) ([]string, []string) {
+	beforeSet := map[string]struct{}{}
+	for _, value := range before {
+		beforeSet[value] = struct{}{}
+	}
+	afterSet := map[string]struct{}{}
+	for _, value := range after {
+		afterSet[value] = struct{}{}
+	}
+
+	added := make([]string, 0)
+	for _, value := range after {
+		if _, ok := beforeSet[value]; !ok {
+			added = append(added, value)
+		}
+	}
+	removed := make([]string, 0)
+	for _, value := range before {
+		if _, ok := afterSet[value]; !ok {
+			removed = append(removed, value)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
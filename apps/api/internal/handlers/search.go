@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	gen "github.com/moveops-platform/apps/api/internal/gen/db"
+	"github.com/moveops-platform/apps/api/internal/gen/oapi"
+	"github.com/moveops-platform/apps/api/internal/httpx"
+	"github.com/moveops-platform/apps/api/internal/middleware"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 50
+	minSearchQueryLen  = 2
+	minSearchPhoneLen  = 4
+)
+
+func (s *Server) GetSearch(w http.ResponseWriter, r *http.Request, params oapi.GetSearchParams) {
+	actor, tenantID, _, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	query := strings.TrimSpace(params.Q)
+	if utf8.RuneCountInString(query) < minSearchQueryLen {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "q must be at least 2 characters", nil)
+		return
+	}
+
+	limit := defaultSearchLimit
+	if params.Limit != nil {
+		switch {
+		case *params.Limit < 1:
+			httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "limit must be at least 1", nil)
+			return
+		case *params.Limit > maxSearchLimit:
+			limit = maxSearchLimit
+		default:
+			limit = *params.Limit
+		}
+	}
+
+	// The route only requires one of the read permissions; each entity type is
+	// searched only when the caller can also open the hit.
+	include := map[string]bool{}
+	for _, permission := range []string{"estimates.read", "jobs.read", "customers.read"} {
+		include[permission] = actor.Permissions.Has(permission)
+	}
+
+	var phoneDigits *string
+	if digits := normalizePhone(query); len(digits) >= minSearchPhoneLen {
+		phoneDigits = &digits
+	}
+
+	rows, err := s.Q.SearchTenantRecords(r.Context(), gen.SearchTenantRecordsParams{
+		TenantID:         tenantID,
+		Query:            query,
+		PhoneDigits:      phoneDigits,
+		IncludeEstimates: include["estimates.read"],
+		IncludeJobs:      include["jobs.read"],
+		IncludeCustomers: include["customers.read"],
+		LimitRows:        int32(limit),
+	})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to search records", nil)
+		return
+	}
+
+	items := make([]oapi.SearchHit, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, oapi.SearchHit{
+			Type:     oapi.SearchHitType(row.HitType),
+			Id:       row.ID,
+			Title:    row.Title,
+			Subtitle: row.Subtitle,
+			Score:    row.Score,
+		})
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, oapi.SearchResponse{
+		Items:     items,
+		RequestId: middleware.RequestIDFromContext(r.Context()),
+	})
+}
@@ -5,11 +5,14 @@ import (
 	"errors"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/moveops-platform/apps/api/internal/acceptance"
 	"github.com/moveops-platform/apps/api/internal/audit"
 	"github.com/moveops-platform/apps/api/internal/auth"
 	"github.com/moveops-platform/apps/api/internal/config"
@@ -26,10 +29,25 @@ type Server struct {
 	Audit  *audit.Logger
 	Logger *slog.Logger
 	DB     *pgxpool.Pool
+	// HTTPClient is used for calls to tenants' identity providers.
+	HTTPClient *http.Client
+	// Acceptance signs estimate acceptance links and signatures.
+	Acceptance *acceptance.Signer
+
+	expiryRuns *estimateExpiryRuns
 }
 
 func NewServer(cfg config.Config, q *gen.Queries, auditLogger *audit.Logger, logger *slog.Logger, db *pgxpool.Pool) *Server {
-	return &Server{Config: cfg, Q: q, Audit: auditLogger, Logger: logger, DB: db}
+	return &Server{
+		Config:     cfg,
+		Q:          q,
+		Audit:      auditLogger,
+		Logger:     logger,
+		DB:         db,
+		HTTPClient: ssoHTTPClient(cfg.SSOAllowPrivateIssuers),
+		Acceptance: acceptance.NewSigner([]byte(cfg.AcceptanceSigningKey)),
+		expiryRuns: newEstimateExpiryRuns(),
+	}
 }
 
 func (s *Server) GetHealth(w http.ResponseWriter, r *http.Request) {
@@ -49,87 +67,135 @@ func (s *Server) PostAuthLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var matched *gen.ListUsersByEmailRow
-	for i := range users {
-		user := users[i]
+	// Accounts outside the requested tenant are still checked so the session
+	// remembers which other accounts share the password; only the requested
+	// tenant decides the outcome and counts failures.
+	tenantSlug := strings.TrimSpace(derefString(req.TenantSlug))
+	inScope := func(user gen.ListUsersByEmailRow) bool {
+		return tenantSlug == "" || strings.EqualFold(user.TenantSlug, tenantSlug)
+	}
+	candidates := make([]gen.ListUsersByEmailRow, 0, len(users))
+	candidateIDs := make([]uuid.UUID, 0, len(users))
+	for _, user := range users {
 		if !user.IsActive {
 			continue
 		}
+		candidates = append(candidates, user)
+		candidateIDs = append(candidateIDs, user.ID)
+	}
+
+	lockedUntil := map[uuid.UUID]time.Time{}
+	if len(candidateIDs) > 0 {
+		lockRows, err := s.Q.GetLoginLockState(r.Context(), candidateIDs)
+		if err != nil {
+			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load user", nil)
+			return
+		}
+		for _, row := range lockRows {
+			if row.LockedUntil != nil {
+				lockedUntil[row.UserID] = *row.LockedUntil
+			}
+		}
+	}
+
+	// Locked accounts are skipped without checking the password so guesses made
+	// during a lockout reveal nothing and do not extend it.
+	var matches, failed []gen.ListUsersByEmailRow
+	var linked []uuid.UUID
+	var earliestUnlock time.Time
+	for _, user := range candidates {
+		if until, locked := lockedUntil[user.ID]; locked {
+			if inScope(user) && (earliestUnlock.IsZero() || until.Before(earliestUnlock)) {
+				earliestUnlock = until
+			}
+			continue
+		}
 		ok, err := auth.VerifyPassword(req.Password, user.PasswordHash)
 		if err != nil {
 			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Password verification failed", nil)
 			return
 		}
 		if ok {
-			matched = &user
-			break
+			linked = append(linked, user.ID)
+		}
+		if !inScope(user) {
+			continue
+		}
+		if ok {
+			matches = append(matches, user)
+		} else {
+			failed = append(failed, user)
 		}
 	}
 
-	if matched == nil {
+	if len(matches) == 0 {
+		// A password that matches one tenant but not another is a normal login
+		// for people with several accounts, so failures only count when nothing
+		// matched.
+		failedPrincipals := make([]sessionPrincipal, 0, len(failed))
+		for _, user := range failed {
+			failedPrincipals = append(failedPrincipals, sessionPrincipalFromLoginRow(user))
+		}
+		s.recordLoginFailures(r, failedPrincipals)
+		if len(failed) == 0 && !earliestUnlock.IsZero() {
+			retryAfter := int(time.Until(earliestUnlock).Round(time.Second).Seconds())
+			if retryAfter < 1 {
+				retryAfter = 1
+			}
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+			httpx.WriteError(w, r, http.StatusLocked, "account_locked", "Account is temporarily locked after repeated failed logins", map[string]any{"lockedUntil": earliestUnlock.UTC()})
+			return
+		}
 		httpx.WriteError(w, r, http.StatusUnauthorized, "invalid_credentials", "Invalid email or password", nil)
 		return
 	}
-
-	if old, err := r.Cookie(s.Config.SessionCookieName); err == nil && old.Value != "" {
-		_, _ = s.Q.RevokeSessionByTokenHash(r.Context(), auth.HashToken(old.Value))
+	// Tenants are only listed after the password has matched in each of them,
+	// so the picker does not reveal memberships to someone guessing emails.
+	if len(matches) > 1 {
+		tenants := make([]oapi.Tenant, 0, len(matches))
+		for _, user := range matches {
+			tenants = append(tenants, oapi.Tenant{Id: user.TenantID, Slug: user.TenantSlug, Name: user.TenantName})
+		}
+		httpx.WriteError(w, r, http.StatusConflict, "tenant_selection_required", "Choose which company to sign in to", map[string]any{"tenants": tenants})
+		return
 	}
+	matched := matches[0]
+	principal := sessionPrincipalFromLoginRow(matched)
+	principal.LinkedUserIDs = linked
+	s.upgradePasswordHash(r.Context(), matched.ID, matched.TenantID, req.Password, matched.PasswordHash)
 
-	sessionToken, err := auth.GenerateToken()
+	mfaState, err := s.Q.GetLoginMFAState(r.Context(), gen.GetLoginMFAStateParams{UserID: matched.ID, TenantID: matched.TenantID})
 	if err != nil {
-		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to create session", nil)
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load MFA state", nil)
 		return
 	}
-	csrfToken, err := auth.GenerateToken()
-	if err != nil {
-		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to create CSRF token", nil)
+	// Failure counters are only reset once the second factor passes, so a
+	// known password cannot be used to keep brute-forcing codes.
+	if mfaState.MfaEnabled {
+		s.startMFAChallenge(w, r, principal)
 		return
 	}
 
-	_, err = s.Q.CreateSession(r.Context(), gen.CreateSessionParams{
-		TenantID:  matched.TenantID,
-		UserID:    matched.ID,
-		TokenHash: auth.HashToken(sessionToken),
-		CsrfToken: csrfToken,
-		ExpiresAt: time.Now().Add(s.Config.SessionTTL),
-	})
-	if err != nil {
-		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to save session", nil)
+	if _, err := s.Q.ClearLoginFailures(r.Context(), gen.ClearLoginFailuresParams{UserID: matched.ID, TenantID: matched.TenantID}); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to reset login failures", nil)
 		return
 	}
 
-	http.SetCookie(w, &http.Cookie{
-		Name:     s.Config.SessionCookieName,
-		Value:    sessionToken,
-		Path:     "/",
-		HttpOnly: true,
-		SameSite: http.SameSiteLaxMode,
-		Secure:   s.Config.SecureCookies,
-		Expires:  time.Now().Add(s.Config.SessionTTL),
-	})
+	resp, ok := s.startSession(w, r, principal)
+	if !ok {
+		return
+	}
 
-	requestID := middleware.RequestIDFromContext(r.Context())
 	userID := matched.ID
 	_ = s.Audit.Log(r.Context(), audit.Entry{
 		TenantID:   matched.TenantID,
 		UserID:     &userID,
 		Action:     "auth.login",
 		EntityType: "session",
-		RequestID:  requestID,
+		RequestID:  middleware.RequestIDFromContext(r.Context()),
 	})
 
-	httpx.WriteJSON(w, http.StatusOK, oapi.AuthSessionResponse{
-		User: oapi.User{
-			Id:       matched.ID,
-			Email:    openapi_types.Email(matched.Email),
-			FullName: matched.FullName,
-		},
-		Tenant: oapi.Tenant{
-			Id:   matched.TenantID,
-			Slug: matched.TenantSlug,
-			Name: matched.TenantName,
-		},
-	})
+	httpx.WriteJSON(w, http.StatusOK, resp)
 }
 
 func (s *Server) PostAuthLogout(w http.ResponseWriter, r *http.Request) {
@@ -155,15 +221,7 @@ func (s *Server) PostAuthLogout(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	http.SetCookie(w, &http.Cookie{
-		Name:     s.Config.SessionCookieName,
-		Value:    "",
-		Path:     "/",
-		HttpOnly: true,
-		SameSite: http.SameSiteLaxMode,
-		Secure:   s.Config.SecureCookies,
-		MaxAge:   -1,
-	})
+	s.clearSessionCookie(w)
 
 	userID, _ := uuid.Parse(actor.UserID)
 	_ = s.Audit.Log(r.Context(), audit.Entry{
@@ -196,8 +254,11 @@ func (s *Server) GetAuthMe(w http.ResponseWriter, r *http.Request) {
 	}
 
 	httpx.WriteJSON(w, http.StatusOK, oapi.AuthSessionResponse{
-		User:   oapi.User{Id: userID, Email: openapi_types.Email(actor.Email), FullName: actor.FullName},
-		Tenant: oapi.Tenant{Id: tenantID, Slug: actor.TenantSlug, Name: actor.TenantName},
+		User:                  oapi.User{Id: userID, Email: openapi_types.Email(actor.Email), FullName: actor.FullName},
+		Tenant:                oapi.Tenant{Id: tenantID, Slug: actor.TenantSlug, Name: actor.TenantName},
+		Permissions:           actor.Permissions.Names(),
+		MfaEnabled:            actor.MFAEnabled,
+		MfaEnrollmentRequired: actor.MFAEnrollmentRequired,
 	})
 }
 
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/moveops-platform/apps/api/internal/audit"
+	gen "github.com/moveops-platform/apps/api/internal/gen/db"
+	"github.com/moveops-platform/apps/api/internal/gen/oapi"
+	"github.com/moveops-platform/apps/api/internal/httpx"
+	"github.com/moveops-platform/apps/api/internal/middleware"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+func (s *Server) GetAuthSessions(w http.ResponseWriter, r *http.Request) {
+	actor, tenantID, userID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	rows, err := s.Q.ListUserSessions(r.Context(), gen.ListUserSessionsParams{UserID: userID, TenantID: tenantID})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load sessions", nil)
+		return
+	}
+
+	items := make([]oapi.UserSession, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, oapi.UserSession{
+			Id:         row.ID,
+			CreatedAt:  row.CreatedAt,
+			LastSeenAt: row.LastSeenAt,
+			ExpiresAt:  row.ExpiresAt,
+			UserAgent:  row.UserAgent,
+			IpAddress:  row.IpAddress,
+			Current:    row.ID.String() == actor.SessionID,
+		})
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, oapi.UserSessionListResponse{
+		Items:     items,
+		RequestId: middleware.RequestIDFromContext(r.Context()),
+	})
+}
+
+func (s *Server) DeleteAuthSessionsSessionId(w http.ResponseWriter, r *http.Request, sessionId openapi_types.UUID) {
+	actor, tenantID, userID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	sessionID := uuid.UUID(sessionId)
+	affected, err := s.Q.RevokeUserSession(r.Context(), gen.RevokeUserSessionParams{
+		ID:       sessionID,
+		UserID:   userID,
+		TenantID: tenantID,
+	})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to revoke session", nil)
+		return
+	}
+	// Sessions of other users look the same as unknown ones.
+	if affected == 0 {
+		httpx.WriteError(w, r, http.StatusNotFound, "session_not_found", "Session was not found", nil)
+		return
+	}
+
+	current := sessionID.String() == actor.SessionID
+	if current {
+		s.clearSessionCookie(w)
+	}
+
+	_ = s.Audit.Log(r.Context(), audit.Entry{
+		TenantID:   tenantID,
+		UserID:     &userID,
+		Action:     "auth.session_revoked",
+		EntityType: "session",
+		EntityID:   &sessionID,
+		RequestID:  middleware.RequestIDFromContext(r.Context()),
+		Metadata: map[string]any{
+			"current": current,
+		},
+	})
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) DeleteUsersUserIdSessions(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
+	_, tenantID, actorUserID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	targetID := uuid.UUID(userId)
+	if _, err := s.Q.GetTenantUserByID(r.Context(), gen.GetTenantUserByIDParams{ID: targetID, TenantID: tenantID}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusNotFound, "user_not_found", "User was not found", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load user", nil)
+		return
+	}
+
+	revoked, err := s.Q.RevokeSessionsForUser(r.Context(), gen.RevokeSessionsForUserParams{UserID: targetID, TenantID: tenantID})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to revoke sessions", nil)
+		return
+	}
+
+	_ = s.Audit.Log(r.Context(), audit.Entry{
+		TenantID:   tenantID,
+		UserID:     &actorUserID,
+		Action:     "users.revoke_sessions",
+		EntityType: "user",
+		EntityID:   &targetID,
+		RequestID:  middleware.RequestIDFromContext(r.Context()),
+		Metadata: map[string]any{
+			"sessionsRevoked": revoked,
+		},
+	})
+
+	httpx.WriteJSON(w, http.StatusOK, oapi.RevokeSessionsResponse{
+		RevokedCount: revoked,
+		RequestId:    middleware.RequestIDFromContext(r.Context()),
+	})
+}
+
+func (s *Server) clearSessionCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     s.Config.SessionCookieName,
+		Value:    "",
+		Path:     "/",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+		Secure:   s.Config.SecureCookies,
+		MaxAge:   -1,
+	})
+}
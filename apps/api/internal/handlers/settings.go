@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/moveops-platform/apps/api/internal/audit"
+	"github.com/moveops-platform/apps/api/internal/auth"
+	gen "github.com/moveops-platform/apps/api/internal/gen/db"
+	"github.com/moveops-platform/apps/api/internal/gen/oapi"
+	"github.com/moveops-platform/apps/api/internal/httpx"
+	"github.com/moveops-platform/apps/api/internal/middleware"
+)
+
+func (s *Server) GetSettings(w http.ResponseWriter, r *http.Request) {
+	_, tenantID, _, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	settings, err := s.Q.GetTenantSettings(r.Context(), tenantID)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load settings", nil)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, oapi.TenantSettingsResponse{
+		Settings:  mapTenantSettings(settings),
+		RequestId: middleware.RequestIDFromContext(r.Context()),
+	})
+}
+
+func (s *Server) PatchSettings(w http.ResponseWriter, r *http.Request) {
+	_, tenantID, userID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req oapi.UpdateTenantSettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "invalid_body", "Malformed JSON body", nil)
+		return
+	}
+
+	before, err := s.Q.GetTenantSettings(r.Context(), tenantID)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load settings", nil)
+		return
+	}
+
+	requireMFA := before.RequireMfaForSensitiveRoles
+	if req.RequireMfaForSensitiveRoles != nil {
+		requireMFA = *req.RequireMfaForSensitiveRoles
+	}
+	passwordMinLength := before.PasswordMinLength
+	if req.PasswordMinLength != nil {
+		if *req.PasswordMinLength < auth.MinPasswordLengthFloor || *req.PasswordMinLength > auth.MaxPasswordLength {
+			httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "passwordMinLength must be between 8 and 128", nil)
+			return
+		}
+		passwordMinLength = int32(*req.PasswordMinLength)
+	}
+	passwordCheckBreached := before.PasswordCheckBreached
+	if req.PasswordCheckBreached != nil {
+		passwordCheckBreached = *req.PasswordCheckBreached
+	}
+	estimateValidityDays := before.EstimateValidityDays
+	if req.EstimateValidityDays != nil {
+		if *req.EstimateValidityDays < 1 || *req.EstimateValidityDays > maxEstimateValidityDays {
+			httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "estimateValidityDays must be between 1 and 365", nil)
+			return
+		}
+		estimateValidityDays = int32(*req.EstimateValidityDays)
+	}
+
+	if err := s.Q.UpsertTenantSettings(r.Context(), gen.UpsertTenantSettingsParams{
+		TenantID:                    tenantID,
+		RequireMfaForSensitiveRoles: requireMFA,
+		PasswordMinLength:           passwordMinLength,
+		PasswordCheckBreached:       passwordCheckBreached,
+		EstimateValidityDays:        estimateValidityDays,
+		UpdatedBy:                   &userID,
+	}); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to update settings", nil)
+		return
+	}
+
+	after, err := s.Q.GetTenantSettings(r.Context(), tenantID)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load settings", nil)
+		return
+	}
+
+	_ = s.Audit.Log(r.Context(), audit.Entry{
+		TenantID:   tenantID,
+		UserID:     &userID,
+		Action:     "settings.update",
+		EntityType: "tenant",
+		EntityID:   &tenantID,
+		RequestID:  middleware.RequestIDFromContext(r.Context()),
+		Metadata: map[string]any{
+			"before": settingsAuditSnapshot(before),
+			"after":  settingsAuditSnapshot(after),
+		},
+	})
+
+	httpx.WriteJSON(w, http.StatusOK, oapi.TenantSettingsResponse{
+		Settings:  mapTenantSettings(after),
+		RequestId: middleware.RequestIDFromContext(r.Context()),
+	})
+}
+
+func mapTenantSettings(row gen.GetTenantSettingsRow) oapi.TenantSettings {
+	return oapi.TenantSettings{
+		RequireMfaForSensitiveRoles: row.RequireMfaForSensitiveRoles,
+		PasswordMinLength:           int(row.PasswordMinLength),
+		PasswordCheckBreached:       row.PasswordCheckBreached,
+		EstimateValidityDays:        int(row.EstimateValidityDays),
+		UpdatedAt:                   row.UpdatedAt,
+	}
+}
+
+func settingsAuditSnapshot(row gen.GetTenantSettingsRow) map[string]any {
+	return map[string]any{
+		"requireMfaForSensitiveRoles": row.RequireMfaForSensitiveRoles,
+		"passwordMinLength":           row.PasswordMinLength,
+		"passwordCheckBreached":       row.PasswordCheckBreached,
+		"estimateValidityDays":        row.EstimateValidityDays,
+	}
+}
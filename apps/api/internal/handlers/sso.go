@@ -0,0 +1,757 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/moveops-platform/apps/api/internal/audit"
+	"github.com/moveops-platform/apps/api/internal/auth"
+	gen "github.com/moveops-platform/apps/api/internal/gen/db"
+	"github.com/moveops-platform/apps/api/internal/gen/oapi"
+	"github.com/moveops-platform/apps/api/internal/httpx"
+	"github.com/moveops-platform/apps/api/internal/middleware"
+	"github.com/moveops-platform/apps/api/internal/oidc"
+)
+
+// oidcStateTTL bounds how long the browser may spend at the identity provider.
+const oidcStateTTL = 10 * time.Minute
+
+const defaultSSOGroupsClaim = "groups"
+
+// ssoLogin is the outcome of matching an ID token to a tenant user.
+type ssoLogin struct {
+	principal    sessionPrincipal
+	provisioned  bool
+	rolesAdded   []uuid.UUID
+	rolesRemoved []uuid.UUID
+}
+
+// ssoError is a login failure reported to the web app as ?ssoError=<code>.
+type ssoError struct {
+	code string
+	err  error
+}
+
+func (e *ssoError) Error() string {
+	if e.err == nil {
+		return e.code
+	}
+	return e.code + ": " + e.err.Error()
+}
+
+// GetAuthOidcTenantSlugStart redirects to the tenant's identity provider. The
+// state is stored hashed with the PKCE verifier and nonce, and also set in a
+// short-lived cookie so the callback only completes in the browser that
+// started the login.
+func (s *Server) GetAuthOidcTenantSlugStart(w http.ResponseWriter, r *http.Request, tenantSlug string) {
+	provider, err := s.Q.GetEnabledSSOProviderBySlug(r.Context(), strings.TrimSpace(tenantSlug))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusNotFound, "sso_not_configured", "Single sign-on is not configured for this tenant", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load SSO configuration", nil)
+		return
+	}
+
+	discovered, err := oidc.Discover(r.Context(), s.HTTPClient, provider.Issuer)
+	if err != nil {
+		s.Logger.Warn("oidc discovery failed", "tenant_id", provider.TenantID, "error", err)
+		httpx.WriteError(w, r, http.StatusBadGateway, "sso_provider_unavailable", "The identity provider is unavailable", nil)
+		return
+	}
+
+	var values [3]string
+	for i := range values {
+		if values[i], err = auth.GenerateToken(); err != nil {
+			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to start single sign-on", nil)
+			return
+		}
+	}
+	state, nonce, verifier := values[0], values[1], values[2]
+
+	_ = s.Q.DeleteExpiredOIDCLoginStates(r.Context())
+	expiresAt := time.Now().Add(oidcStateTTL)
+	if err := s.Q.CreateOIDCLoginState(r.Context(), gen.CreateOIDCLoginStateParams{
+		StateHash:    auth.HashToken(state),
+		TenantID:     provider.TenantID,
+		CodeVerifier: verifier,
+		Nonce:        nonce,
+		ExpiresAt:    expiresAt,
+	}); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to start single sign-on", nil)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     s.oidcStateCookieName(),
+		Value:    state,
+		Path:     "/",
+		HttpOnly: true,
+		// Lax so the cookie comes back on the provider's top-level redirect.
+		SameSite: http.SameSiteLaxMode,
+		Secure:   s.Config.SecureCookies,
+		Expires:  expiresAt,
+	})
+	http.Redirect(w, r, discovered.AuthCodeURL(provider.ClientID, s.Config.OIDCRedirectURL, state, nonce, oidc.PKCEChallenge(verifier)), http.StatusFound)
+}
+
+// GetAuthOidcCallback finishes the login started by GetAuthOidcTenantSlugStart.
+// It always answers with a redirect to the web app.
+func (s *Server) GetAuthOidcCallback(w http.ResponseWriter, r *http.Request, params oapi.GetAuthOidcCallbackParams) {
+	login, err := s.completeSSOLogin(r, params)
+	s.clearOIDCStateCookie(w)
+	if err != nil {
+		s.ssoRedirectError(w, r, err)
+		return
+	}
+
+	p := login.principal
+	if login.provisioned {
+		_ = s.Audit.Log(r.Context(), audit.Entry{
+			TenantID:   p.TenantID,
+			UserID:     &p.UserID,
+			Action:     "users.provision",
+			EntityType: "user",
+			EntityID:   &p.UserID,
+			RequestID:  middleware.RequestIDFromContext(r.Context()),
+			Metadata: map[string]any{
+				"source": "oidc",
+				"email":  p.Email,
+			},
+		})
+	}
+	if len(login.rolesAdded) > 0 || len(login.rolesRemoved) > 0 {
+		_ = s.Audit.Log(r.Context(), audit.Entry{
+			TenantID:   p.TenantID,
+			UserID:     &p.UserID,
+			Action:     "users.roles_sync",
+			EntityType: "user",
+			EntityID:   &p.UserID,
+			RequestID:  middleware.RequestIDFromContext(r.Context()),
+			Metadata: map[string]any{
+				"source":       "oidc",
+				"rolesAdded":   uuidStrings(login.rolesAdded),
+				"rolesRemoved": uuidStrings(login.rolesRemoved),
+			},
+		})
+	}
+
+	// A lockout from failed passwords or MFA codes covers SSO too, or the
+	// identity provider would be a way around it.
+	locks, err := s.Q.GetLoginLockState(r.Context(), []uuid.UUID{p.UserID})
+	if err != nil {
+		s.ssoRedirectError(w, r, &ssoError{code: "internal_error", err: err})
+		return
+	}
+	if len(locks) > 0 && locks[0].LockedUntil != nil && locks[0].LockedUntil.After(time.Now()) {
+		s.ssoRedirectError(w, r, &ssoError{code: "account_locked"})
+		return
+	}
+
+	mfaState, err := s.Q.GetLoginMFAState(r.Context(), gen.GetLoginMFAStateParams{UserID: p.UserID, TenantID: p.TenantID})
+	if err != nil {
+		s.ssoRedirectError(w, r, &ssoError{code: "internal_error", err: err})
+		return
+	}
+	if mfaState.MfaEnabled {
+		// A TOTP factor enrolled here still applies; the login page picks up
+		// the half-authenticated session and asks for the code.
+		if _, ok := s.issueSession(w, r, p, true); !ok {
+			return
+		}
+		http.Redirect(w, r, s.Config.WebBaseURL+"/login?mfa=required", http.StatusFound)
+		return
+	}
+
+	if _, ok := s.startSession(w, r, p); !ok {
+		return
+	}
+
+	_ = s.Audit.Log(r.Context(), audit.Entry{
+		TenantID:   p.TenantID,
+		UserID:     &p.UserID,
+		Action:     "auth.login",
+		EntityType: "session",
+		RequestID:  middleware.RequestIDFromContext(r.Context()),
+		Metadata: map[string]any{
+			"method": "oidc",
+		},
+	})
+
+	http.Redirect(w, r, s.Config.WebBaseURL+"/", http.StatusFound)
+}
+
+// ssoRedirectError logs a failed SSO login and sends the browser back to the
+// web app's login page with the error code.
+func (s *Server) ssoRedirectError(w http.ResponseWriter, r *http.Request, err error) {
+	var failure *ssoError
+	if !errors.As(err, &failure) {
+		failure = &ssoError{code: "internal_error", err: err}
+	}
+	s.Logger.Warn("oidc login failed", "code", failure.code, "error", failure.Error(), "request_id", middleware.RequestIDFromContext(r.Context()))
+	http.Redirect(w, r, s.Config.WebBaseURL+"/login?ssoError="+url.QueryEscape(failure.code), http.StatusFound)
+}
+
+func (s *Server) completeSSOLogin(r *http.Request, params oapi.GetAuthOidcCallbackParams) (ssoLogin, error) {
+	if providerError := derefString(params.Error); providerError != "" {
+		return ssoLogin{}, &ssoError{code: "provider_error", err: fmt.Errorf("%s: %s", providerError, derefString(params.ErrorDescription))}
+	}
+	state := derefString(params.State)
+	code := derefString(params.Code)
+	if state == "" || code == "" {
+		return ssoLogin{}, &ssoError{code: "invalid_request"}
+	}
+	cookie, err := r.Cookie(s.oidcStateCookieName())
+	if err != nil || cookie.Value != state {
+		return ssoLogin{}, &ssoError{code: "invalid_state", err: errors.New("state does not match the browser that started the login")}
+	}
+
+	pending, err := s.Q.ConsumeOIDCLoginState(r.Context(), auth.HashToken(state))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ssoLogin{}, &ssoError{code: "invalid_state", err: errors.New("state is unknown, used or expired")}
+		}
+		return ssoLogin{}, err
+	}
+
+	provider, err := s.Q.GetSSOProvider(r.Context(), pending.TenantID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ssoLogin{}, &ssoError{code: "sso_not_configured"}
+		}
+		return ssoLogin{}, err
+	}
+	if !provider.Enabled {
+		return ssoLogin{}, &ssoError{code: "sso_not_configured"}
+	}
+
+	discovered, err := oidc.Discover(r.Context(), s.HTTPClient, provider.Issuer)
+	if err != nil {
+		return ssoLogin{}, &ssoError{code: "provider_unavailable", err: err}
+	}
+	rawIDToken, err := discovered.Exchange(r.Context(), s.HTTPClient, provider.ClientID, provider.ClientSecret, code, s.Config.OIDCRedirectURL, pending.CodeVerifier)
+	if err != nil {
+		return ssoLogin{}, &ssoError{code: "token_exchange_failed", err: err}
+	}
+	claims, err := discovered.VerifyIDToken(r.Context(), s.HTTPClient, rawIDToken, provider.ClientID, pending.Nonce, time.Now())
+	if err != nil {
+		return ssoLogin{}, &ssoError{code: "invalid_id_token", err: err}
+	}
+
+	return s.provisionSSOUser(r.Context(), provider, claims)
+}
+
+// provisionSSOUser finds the user for claims by linked identity, then by
+// verified email, and otherwise creates one when JIT provisioning is on. Roles
+// named in the group mappings are synced from the groups claim; roles that are
+// not mapped are left alone.
+func (s *Server) provisionSSOUser(ctx context.Context, provider gen.GetSSOProviderRow, claims oidc.Claims) (ssoLogin, error) {
+	tenantID := provider.TenantID
+	issuer := strings.TrimSuffix(provider.Issuer, "/")
+	email := strings.ToLower(strings.TrimSpace(claims.Email))
+	lookup := gen.GetSSOLoginUserParams{
+		Issuer:     issuer,
+		Subject:    claims.Subject,
+		TenantID:   tenantID,
+		MatchEmail: claims.EmailVerified && email != "",
+		Email:      email,
+	}
+
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return ssoLogin{}, err
+	}
+	defer tx.Rollback(ctx)
+	qtx := s.Q.WithTx(tx)
+
+	// Serializes JIT creation and role sync with role administration.
+	if err := qtx.LockTenantRoles(ctx, tenantID); err != nil {
+		return ssoLogin{}, err
+	}
+
+	result := ssoLogin{}
+	user, err := qtx.GetSSOLoginUser(ctx, lookup)
+	if errors.Is(err, pgx.ErrNoRows) {
+		if !provider.JitProvisioning {
+			return ssoLogin{}, &ssoError{code: "account_not_found"}
+		}
+		if !lookup.MatchEmail {
+			return ssoLogin{}, &ssoError{code: "email_not_verified"}
+		}
+		// Provisioned users sign in through the provider; nobody knows this
+		// password until an admin resets it.
+		password, err := auth.GenerateToken()
+		if err != nil {
+			return ssoLogin{}, err
+		}
+		passwordHash, err := auth.HashPassword(password)
+		if err != nil {
+			return ssoLogin{}, err
+		}
+		fullName := strings.TrimSpace(claims.Name)
+		if fullName == "" {
+			fullName = email
+		}
+		if _, err := qtx.CreateUser(ctx, gen.CreateUserParams{
+			TenantID:     tenantID,
+			Email:        email,
+			FullName:     truncateText(fullName, 200),
+			PasswordHash: passwordHash,
+		}); err != nil {
+			return ssoLogin{}, err
+		}
+		result.provisioned = true
+		user, err = qtx.GetSSOLoginUser(ctx, lookup)
+		if err != nil {
+			return ssoLogin{}, err
+		}
+	} else if err != nil {
+		return ssoLogin{}, err
+	}
+	if !user.IsActive {
+		return ssoLogin{}, &ssoError{code: "account_disabled"}
+	}
+
+	if err := qtx.UpsertUserIdentity(ctx, gen.UpsertUserIdentityParams{
+		TenantID: tenantID,
+		UserID:   user.ID,
+		Issuer:   issuer,
+		Subject:  claims.Subject,
+	}); err != nil {
+		return ssoLogin{}, err
+	}
+
+	if result.rolesAdded, result.rolesRemoved, err = s.syncSSORoles(ctx, tx, qtx, provider, user.ID, claims, result.provisioned); err != nil {
+		return ssoLogin{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return ssoLogin{}, err
+	}
+
+	result.principal = sessionPrincipal{
+		UserID:     user.ID,
+		TenantID:   tenantID,
+		Email:      user.Email,
+		FullName:   user.FullName,
+		TenantSlug: user.TenantSlug,
+		TenantName: user.TenantName,
+	}
+	return result, nil
+}
+
+func (s *Server) syncSSORoles(ctx context.Context, tx pgx.Tx, qtx *gen.Queries, provider gen.GetSSOProviderRow, userID uuid.UUID, claims oidc.Claims, provisioned bool) ([]uuid.UUID, []uuid.UUID, error) {
+	tenantID := provider.TenantID
+	mappings, err := qtx.ListSSOGroupRoleMappings(ctx, tenantID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	groups := map[string]struct{}{}
+	for _, group := range claims.StringList(provider.GroupsClaim) {
+		groups[group] = struct{}{}
+	}
+	managed := map[uuid.UUID]struct{}{}
+	granted := map[uuid.UUID]struct{}{}
+	for _, mapping := range mappings {
+		managed[mapping.RoleID] = struct{}{}
+		if _, ok := groups[mapping.GroupName]; ok {
+			granted[mapping.RoleID] = struct{}{}
+		}
+	}
+	if provisioned && len(granted) == 0 && provider.DefaultRoleID != nil {
+		granted[*provider.DefaultRoleID] = struct{}{}
+	}
+
+	currentIDs, err := qtx.ListUserRoleIDs(ctx, gen.ListUserRoleIDsParams{UserID: userID, TenantID: tenantID})
+	if err != nil {
+		return nil, nil, err
+	}
+	current := map[uuid.UUID]struct{}{}
+	for _, id := range currentIDs {
+		current[id] = struct{}{}
+	}
+
+	added := []uuid.UUID{}
+	for roleID := range granted {
+		if _, ok := current[roleID]; ok {
+			continue
+		}
+		affected, err := qtx.AssignUserRole(ctx, gen.AssignUserRoleParams{UserID: userID, RoleID: roleID, TenantID: tenantID})
+		if err != nil {
+			return nil, nil, err
+		}
+		if affected == 1 {
+			added = append(added, roleID)
+		}
+	}
+
+	removed := []uuid.UUID{}
+	for roleID := range managed {
+		_, held := current[roleID]
+		_, keep := granted[roleID]
+		if held && !keep {
+			removed = append(removed, roleID)
+		}
+	}
+	if len(removed) == 0 {
+		return added, removed, nil
+	}
+
+	// Removals run in a savepoint so an IdP group change can never take away
+	// the tenant's last admin; in that case the user keeps their roles.
+	adminCapableBefore, err := qtx.CountActiveUsersWithPermission(ctx, gen.CountActiveUsersWithPermissionParams{TenantID: tenantID, Permission: adminCapablePermission})
+	if err != nil {
+		return nil, nil, err
+	}
+	sp, err := tx.Begin(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer sp.Rollback(ctx)
+	qsp := s.Q.WithTx(sp)
+	if _, err := qsp.RemoveUserRoles(ctx, gen.RemoveUserRolesParams{UserID: userID, TenantID: tenantID, RoleIds: removed}); err != nil {
+		return nil, nil, err
+	}
+	adminCapableAfter, err := qsp.CountActiveUsersWithPermission(ctx, gen.CountActiveUsersWithPermissionParams{TenantID: tenantID, Permission: adminCapablePermission})
+	if err != nil {
+		return nil, nil, err
+	}
+	if adminCapableBefore > 0 && adminCapableAfter == 0 {
+		s.Logger.Warn("oidc role sync skipped removals that would remove the last admin", "tenant_id", tenantID, "user_id", userID)
+		if err := sp.Rollback(ctx); err != nil {
+			return nil, nil, err
+		}
+		return added, []uuid.UUID{}, nil
+	}
+	if err := sp.Commit(ctx); err != nil {
+		return nil, nil, err
+	}
+	return added, removed, nil
+}
+
+func (s *Server) oidcStateCookieName() string {
+	return s.Config.SessionCookieName + "_oidc"
+}
+
+func (s *Server) clearOIDCStateCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     s.oidcStateCookieName(),
+		Value:    "",
+		Path:     "/",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+		Secure:   s.Config.SecureCookies,
+		MaxAge:   -1,
+	})
+}
+
+func (s *Server) GetSettingsSso(w http.ResponseWriter, r *http.Request) {
+	_, tenantID, _, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	settings, ok := s.loadSSOSettings(w, r, s.Q, tenantID)
+	if !ok {
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, oapi.SsoSettingsResponse{
+		Settings:  settings,
+		RequestId: middleware.RequestIDFromContext(r.Context()),
+	})
+}
+
+// PutSettingsSso replaces the tenant's SSO configuration. Enabling it checks
+// that the issuer's discovery document can be loaded.
+func (s *Server) PutSettingsSso(w http.ResponseWriter, r *http.Request) {
+	_, tenantID, userID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req oapi.UpdateSsoSettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "invalid_body", "Malformed JSON body", nil)
+		return
+	}
+
+	issuer := strings.TrimSuffix(strings.TrimSpace(req.Issuer), "/")
+	clientID := strings.TrimSpace(req.ClientId)
+	if !validIssuerURL(issuer, s.Config.SSOAllowPrivateIssuers) {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "issuer must be an https URL", nil)
+		return
+	}
+	if !s.Config.SSOAllowPrivateIssuers && !resolvesToPublicAddresses(r.Context(), issuer) {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "issuer must resolve to a public address", nil)
+		return
+	}
+	if clientID == "" {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "clientId is required", nil)
+		return
+	}
+	groupsClaim := defaultSSOGroupsClaim
+	if req.GroupsClaim != nil && strings.TrimSpace(*req.GroupsClaim) != "" {
+		groupsClaim = strings.TrimSpace(*req.GroupsClaim)
+	}
+	jitProvisioning := true
+	if req.JitProvisioning != nil {
+		jitProvisioning = *req.JitProvisioning
+	}
+
+	groupNames := []string{}
+	mappedRoleIDs := []uuid.UUID{}
+	roleSet := map[uuid.UUID]struct{}{}
+	if req.GroupRoleMappings != nil {
+		for _, mapping := range *req.GroupRoleMappings {
+			group := strings.TrimSpace(mapping.Group)
+			if group == "" {
+				httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "groupRoleMappings groups must not be blank", nil)
+				return
+			}
+			groupNames = append(groupNames, group)
+			mappedRoleIDs = append(mappedRoleIDs, uuid.UUID(mapping.RoleId))
+			roleSet[uuid.UUID(mapping.RoleId)] = struct{}{}
+		}
+	}
+	var defaultRoleID *uuid.UUID
+	if req.DefaultRoleId != nil {
+		id := uuid.UUID(*req.DefaultRoleId)
+		defaultRoleID = &id
+		roleSet[id] = struct{}{}
+	}
+
+	if req.Enabled {
+		if _, err := oidc.Discover(r.Context(), s.HTTPClient, issuer); err != nil {
+			// The reason stays in the log; echoing it would let the endpoint
+			// be used to probe what the server can reach.
+			s.Logger.Warn("oidc discovery failed", "tenant_id", tenantID, "error", err)
+			httpx.WriteError(w, r, http.StatusBadRequest, "sso_discovery_failed", "Could not load the issuer's OpenID configuration", nil)
+			return
+		}
+	}
+
+	tx, err := s.DB.Begin(r.Context())
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to begin transaction", nil)
+		return
+	}
+	defer tx.Rollback(r.Context())
+	qtx := s.Q.WithTx(tx)
+
+	if len(roleSet) > 0 {
+		roleIDs := make([]uuid.UUID, 0, len(roleSet))
+		for id := range roleSet {
+			roleIDs = append(roleIDs, id)
+		}
+		count, err := qtx.CountTenantRolesByIDs(r.Context(), gen.CountTenantRolesByIDsParams{TenantID: tenantID, RoleIds: roleIDs})
+		if err != nil {
+			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load roles", nil)
+			return
+		}
+		if count != int64(len(roleIDs)) {
+			httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "Role ids must reference roles in this tenant", nil)
+			return
+		}
+	}
+
+	clientSecret := ""
+	before, err := qtx.GetSSOProvider(r.Context(), tenantID)
+	switch {
+	case err == nil:
+		clientSecret = before.ClientSecret
+	case !errors.Is(err, pgx.ErrNoRows):
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load SSO configuration", nil)
+		return
+	}
+	if req.ClientSecret != nil {
+		clientSecret = *req.ClientSecret
+	}
+
+	if err := qtx.UpsertSSOProvider(r.Context(), gen.UpsertSSOProviderParams{
+		TenantID:        tenantID,
+		Enabled:         req.Enabled,
+		Issuer:          issuer,
+		ClientID:        clientID,
+		ClientSecret:    clientSecret,
+		JitProvisioning: jitProvisioning,
+		DefaultRoleID:   defaultRoleID,
+		GroupsClaim:     groupsClaim,
+		UpdatedBy:       &userID,
+	}); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to update SSO configuration", nil)
+		return
+	}
+	if err := qtx.DeleteSSOGroupRoleMappings(r.Context(), tenantID); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to update group mappings", nil)
+		return
+	}
+	if len(groupNames) > 0 {
+		if err := qtx.InsertSSOGroupRoleMappings(r.Context(), gen.InsertSSOGroupRoleMappingsParams{
+			TenantID:   tenantID,
+			GroupNames: groupNames,
+			RoleIds:    mappedRoleIDs,
+		}); err != nil {
+			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to update group mappings", nil)
+			return
+		}
+	}
+
+	settings, ok := s.loadSSOSettings(w, r, qtx, tenantID)
+	if !ok {
+		return
+	}
+	if err := tx.Commit(r.Context()); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to commit SSO configuration", nil)
+		return
+	}
+
+	_ = s.Audit.Log(r.Context(), audit.Entry{
+		TenantID:   tenantID,
+		UserID:     &userID,
+		Action:     "settings.sso_update",
+		EntityType: "tenant",
+		EntityID:   &tenantID,
+		RequestID:  middleware.RequestIDFromContext(r.Context()),
+		Metadata: map[string]any{
+			"enabled":             settings.Enabled,
+			"issuer":              settings.Issuer,
+			"clientId":            settings.ClientId,
+			"clientSecretChanged": req.ClientSecret != nil,
+			"jitProvisioning":     settings.JitProvisioning,
+			"groupMappingCount":   len(settings.GroupRoleMappings),
+		},
+	})
+
+	httpx.WriteJSON(w, http.StatusOK, oapi.SsoSettingsResponse{
+		Settings:  settings,
+		RequestId: middleware.RequestIDFromContext(r.Context()),
+	})
+}
+
+func (s *Server) loadSSOSettings(w http.ResponseWriter, r *http.Request, q *gen.Queries, tenantID uuid.UUID) (oapi.SsoSettings, bool) {
+	settings := oapi.SsoSettings{
+		JitProvisioning:   true,
+		GroupsClaim:       defaultSSOGroupsClaim,
+		GroupRoleMappings: []oapi.SsoGroupRoleMapping{},
+		RedirectUri:       s.Config.OIDCRedirectURL,
+	}
+
+	provider, err := q.GetSSOProvider(r.Context(), tenantID)
+	switch {
+	case err == nil:
+		updatedAt := provider.UpdatedAt
+		settings.Enabled = provider.Enabled
+		settings.Issuer = provider.Issuer
+		settings.ClientId = provider.ClientID
+		settings.ClientSecretSet = provider.ClientSecret != ""
+		settings.JitProvisioning = provider.JitProvisioning
+		settings.DefaultRoleId = provider.DefaultRoleID
+		settings.GroupsClaim = provider.GroupsClaim
+		settings.UpdatedAt = &updatedAt
+	case errors.Is(err, pgx.ErrNoRows):
+		return settings, true
+	default:
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load SSO configuration", nil)
+		return oapi.SsoSettings{}, false
+	}
+
+	mappings, err := q.ListSSOGroupRoleMappings(r.Context(), tenantID)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load group mappings", nil)
+		return oapi.SsoSettings{}, false
+	}
+	for _, mapping := range mappings {
+		settings.GroupRoleMappings = append(settings.GroupRoleMappings, oapi.SsoGroupRoleMapping{
+			Group:  mapping.GroupName,
+			RoleId: mapping.RoleID,
+		})
+	}
+	return settings, true
+}
+
+// validIssuerURL requires https. Plain http is accepted only with
+// allowInsecure, for identity providers run locally in development.
+func validIssuerURL(raw string, allowInsecure bool) bool {
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Host == "" || parsed.User != nil || parsed.RawQuery != "" || parsed.Fragment != "" {
+		return false
+	}
+	return parsed.Scheme == "https" || (allowInsecure && parsed.Scheme == "http")
+}
+
+// resolvesToPublicAddresses reports whether every address the issuer's host
+// resolves to is public. It gives a clear error when saving the settings;
+// ssoHTTPClient enforces the same rule on every connection.
+func resolvesToPublicAddresses(ctx context.Context, issuer string) bool {
+	parsed, err := url.Parse(issuer)
+	if err != nil {
+		return false
+	}
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, parsed.Hostname())
+	if err != nil || len(addrs) == 0 {
+		return false
+	}
+	for _, addr := range addrs {
+		if !publicIP(addr.IP) {
+			return false
+		}
+	}
+	return true
+}
+
+// publicIP rejects loopback, private, link-local, CGNAT and other
+// non-routable addresses, which include cloud metadata endpoints.
+func publicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsMulticast() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+		return false
+	}
+	return !sharedAddressSpace.Contains(ip)
+}
+
+// sharedAddressSpace is the carrier-grade NAT range (RFC 6598).
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// ssoHTTPClient is the client for calls to identity providers. Its dialer
+// refuses non-public addresses after DNS resolution, so redirects and
+// rebinding cannot reach internal services. allowPrivate lifts that for
+// development.
+func ssoHTTPClient(allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: 5 * time.Second}
+	if !allowPrivate {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !publicIP(ip) {
+				return fmt.Errorf("refusing to connect to non-public address %s", host)
+			}
+			return nil
+		}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	transport.Proxy = nil
+	return &http.Client{Timeout: 10 * time.Second, Transport: transport}
+}
+
+func uuidStrings(ids []uuid.UUID) []string {
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, id.String())
+	}
+	return out
+}
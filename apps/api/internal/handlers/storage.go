@@ -1,10 +1,8 @@
 package handlers
 
 import (
-	"encoding/base64"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"net/http"
 	"strings"
 	"time"
@@ -19,11 +17,6 @@ import (
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
-const (
-	defaultStorageListLimit = 25
-	maxStorageListLimit     = 100
-)
-
 func (s *Server) GetStorage(w http.ResponseWriter, r *http.Request, params oapi.GetStorageParams) {
 	_, tenantID, _, ok := requireActorIDs(w, r)
 	if !ok {
@@ -36,32 +29,17 @@ func (s *Server) GetStorage(w http.ResponseWriter, r *http.Request, params oapi.
 		return
 	}
 
-	limit := defaultStorageListLimit
-	if params.Limit != nil {
-		switch {
-		case *params.Limit < 1:
-			httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "limit must be at least 1", nil)
-			return
-		case *params.Limit > maxStorageListLimit:
-			limit = maxStorageListLimit
-		default:
-			limit = *params.Limit
-		}
+	limit, ok := resolveListLimit(w, r, params.Limit)
+	if !ok {
+		return
 	}
 
 	searchQ := sanitizeOptional(params.Q)
 	status := storageStatusToPtr(params.Status)
 
-	var cursorUpdatedAt *time.Time
-	var cursorJobID *uuid.UUID
-	if params.Cursor != nil && strings.TrimSpace(*params.Cursor) != "" {
-		decodedAt, decodedJobID, err := decodeStorageCursor(*params.Cursor)
-		if err != nil {
-			httpx.WriteError(w, r, http.StatusBadRequest, "invalid_cursor", "cursor is invalid", nil)
-			return
-		}
-		cursorUpdatedAt = &decodedAt
-		cursorJobID = &decodedJobID
+	cursorUpdatedAt, cursorJobID, ok := decodeOptionalListCursor(w, r, params.Cursor)
+	if !ok {
+		return
 	}
 
 	var pastDueDays *int32
@@ -94,7 +72,7 @@ func (s *Server) GetStorage(w http.ResponseWriter, r *http.Request, params oapi.
 
 	var nextCursor *string
 	if len(rows) > limit {
-		cursor := encodeStorageCursor(rows[limit-1].SortUpdatedAt, rows[limit-1].SortJobID)
+		cursor := encodeListCursor(rows[limit-1].SortUpdatedAt, rows[limit-1].SortJobID)
 		nextCursor = &cursor
 		rows = rows[:limit]
 	}
@@ -191,25 +169,22 @@ func (s *Server) PutStorageStorageRecordId(w http.ResponseWriter, r *http.Reques
 	}
 
 	updated, err := s.Q.UpdateStorageRecordByID(r.Context(), gen.UpdateStorageRecordByIDParams{
-		Facility:            facility,
-		Status:              string(req.Status),
-		DateIn:              dateToTimePtr(req.DateIn),
-		DateOut:             dateToTimePtr(req.DateOut),
-		NextBillDate:        dateToTimePtr(req.NextBillDate),
-		LotNumber:           sanitizeOptional(req.LotNumber),
-		LocationLabel:       sanitizeOptional(req.LocationLabel),
-		Vaults:              int32(req.Vaults),
-		Pads:                int32(req.Pads),
-		Items:               int32(req.Items),
-		OversizeItems:       int32(req.OversizeItems),
-		Volume:              int32(req.Volume),
-		MonthlyRateCents:    req.MonthlyRateCents,
-		StorageBalanceCents: req.StorageBalanceCents,
-		MoveBalanceCents:    req.MoveBalanceCents,
-		LastPaymentAt:       req.LastPaymentAt,
-		Notes:               sanitizeOptional(req.Notes),
-		ID:                  targetID,
-		TenantID:            tenantID,
+		Facility:         facility,
+		Status:           string(req.Status),
+		DateIn:           dateToTimePtr(req.DateIn),
+		DateOut:          dateToTimePtr(req.DateOut),
+		NextBillDate:     dateToTimePtr(req.NextBillDate),
+		LotNumber:        sanitizeOptional(req.LotNumber),
+		LocationLabel:    sanitizeOptional(req.LocationLabel),
+		Vaults:           int32(req.Vaults),
+		Pads:             int32(req.Pads),
+		Items:            int32(req.Items),
+		OversizeItems:    int32(req.OversizeItems),
+		Volume:           int32(req.Volume),
+		MonthlyRateCents: req.MonthlyRateCents,
+		Notes:            sanitizeOptional(req.Notes),
+		ID:               targetID,
+		TenantID:         tenantID,
 	})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -258,8 +233,18 @@ func (s *Server) PostJobsJobIdStorage(w http.ResponseWriter, r *http.Request, jo
 		return
 	}
 
+	tx, err := s.DB.Begin(r.Context())
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to start transaction", nil)
+		return
+	}
+	defer tx.Rollback(r.Context())
+	qtx := s.Q.WithTx(tx)
+
+	// The job row lock keeps ledger postings from racing the move balance
+	// the new record starts with.
 	targetJobID := uuid.UUID(jobId)
-	if _, err := s.Q.GetJobByID(r.Context(), gen.GetJobByIDParams{
+	if _, err := qtx.GetJobByIDForUpdate(r.Context(), gen.GetJobByIDForUpdateParams{
 		ID:       targetJobID,
 		TenantID: tenantID,
 	}); err != nil {
@@ -271,7 +256,7 @@ func (s *Server) PostJobsJobIdStorage(w http.ResponseWriter, r *http.Request, jo
 		return
 	}
 
-	existing, err := s.Q.GetStorageRecordByJobID(r.Context(), gen.GetStorageRecordByJobIDParams{
+	existing, err := qtx.GetStorageRecordByJobID(r.Context(), gen.GetStorageRecordByJobIDParams{
 		JobID:    targetJobID,
 		TenantID: tenantID,
 	})
@@ -288,26 +273,23 @@ func (s *Server) PostJobsJobIdStorage(w http.ResponseWriter, r *http.Request, jo
 	}
 
 	status := storageStatusToPtr(req.Status)
-	created, err := s.Q.CreateStorageRecord(r.Context(), gen.CreateStorageRecordParams{
-		TenantID:            tenantID,
-		JobID:               targetJobID,
-		Facility:            facility,
-		Status:              status,
-		DateIn:              dateToTimePtr(req.DateIn),
-		DateOut:             dateToTimePtr(req.DateOut),
-		NextBillDate:        dateToTimePtr(req.NextBillDate),
-		LotNumber:           sanitizeOptional(req.LotNumber),
-		LocationLabel:       sanitizeOptional(req.LocationLabel),
-		Vaults:              intToInt32Ptr(req.Vaults),
-		Pads:                intToInt32Ptr(req.Pads),
-		Items:               intToInt32Ptr(req.Items),
-		OversizeItems:       intToInt32Ptr(req.OversizeItems),
-		Volume:              intToInt32Ptr(req.Volume),
-		MonthlyRateCents:    req.MonthlyRateCents,
-		StorageBalanceCents: req.StorageBalanceCents,
-		MoveBalanceCents:    req.MoveBalanceCents,
-		LastPaymentAt:       req.LastPaymentAt,
-		Notes:               sanitizeOptional(req.Notes),
+	created, err := qtx.CreateStorageRecord(r.Context(), gen.CreateStorageRecordParams{
+		TenantID:         tenantID,
+		JobID:            targetJobID,
+		Facility:         facility,
+		Status:           status,
+		DateIn:           dateToTimePtr(req.DateIn),
+		DateOut:          dateToTimePtr(req.DateOut),
+		NextBillDate:     dateToTimePtr(req.NextBillDate),
+		LotNumber:        sanitizeOptional(req.LotNumber),
+		LocationLabel:    sanitizeOptional(req.LocationLabel),
+		Vaults:           intToInt32Ptr(req.Vaults),
+		Pads:             intToInt32Ptr(req.Pads),
+		Items:            intToInt32Ptr(req.Items),
+		OversizeItems:    intToInt32Ptr(req.OversizeItems),
+		Volume:           intToInt32Ptr(req.Volume),
+		MonthlyRateCents: req.MonthlyRateCents,
+		Notes:            sanitizeOptional(req.Notes),
 	})
 	if err != nil {
 		if isUniqueConstraint(err, "storage_record_tenant_job_uidx") {
@@ -317,6 +299,10 @@ func (s *Server) PostJobsJobIdStorage(w http.ResponseWriter, r *http.Request, jo
 		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to create storage record", nil)
 		return
 	}
+	if err := tx.Commit(r.Context()); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to commit storage record", nil)
+		return
+	}
 
 	storageID := created.ID
 	_ = s.Audit.Log(r.Context(), audit.Entry{
@@ -412,32 +398,6 @@ func intToInt32Ptr(value *int) *int32 {
 	return &converted
 }
 
-func encodeStorageCursor(updatedAt time.Time, jobID uuid.UUID) string {
-	payload := updatedAt.UTC().Format(time.RFC3339Nano) + "|" + jobID.String()
-	return base64.RawURLEncoding.EncodeToString([]byte(payload))
-}
-
-func decodeStorageCursor(raw string) (time.Time, uuid.UUID, error) {
-	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimSpace(raw))
-	if err != nil {
-		return time.Time{}, uuid.Nil, fmt.Errorf("decode cursor: %w", err)
-	}
-	parts := strings.SplitN(string(decoded), "|", 2)
-	if len(parts) != 2 {
-		return time.Time{}, uuid.Nil, errors.New("cursor payload is malformed")
-	}
-
-	updatedAt, err := time.Parse(time.RFC3339Nano, parts[0])
-	if err != nil {
-		return time.Time{}, uuid.Nil, fmt.Errorf("parse cursor updatedAt: %w", err)
-	}
-	jobID, err := uuid.Parse(parts[1])
-	if err != nil {
-		return time.Time{}, uuid.Nil, fmt.Errorf("parse cursor jobId: %w", err)
-	}
-	return updatedAt, jobID, nil
-}
-
 func storageRecordChangedFields(before, after gen.StorageRecord) map[string]any {
 	changes := map[string]any{}
 
@@ -487,15 +447,6 @@ func storageRecordChangedFields(before, after gen.StorageRecord) map[string]any
 	if !int64PtrEqual(before.MonthlyRateCents, after.MonthlyRateCents) {
 		add("monthlyRateCents", before.MonthlyRateCents, after.MonthlyRateCents)
 	}
-	if before.StorageBalanceCents != after.StorageBalanceCents {
-		add("storageBalanceCents", before.StorageBalanceCents, after.StorageBalanceCents)
-	}
-	if before.MoveBalanceCents != after.MoveBalanceCents {
-		add("moveBalanceCents", before.MoveBalanceCents, after.MoveBalanceCents)
-	}
-	if !timePtrEqual(before.LastPaymentAt, after.LastPaymentAt) {
-		add("lastPaymentAt", formatTimePtr(before.LastPaymentAt), formatTimePtr(after.LastPaymentAt))
-	}
 	if !strPtrEqual(before.Notes, after.Notes) {
 		add("notesChanged", before.Notes != nil, after.Notes != nil)
 	}
@@ -510,11 +461,3 @@ func formatDatePtr(value *time.Time) *string {
 	formatted := value.UTC().Format("2006-01-02")
 	return &formatted
 }
-
-func formatTimePtr(value *time.Time) *string {
-	if value == nil {
-		return nil
-	}
-	formatted := value.UTC().Format(time.RFC3339)
-	return &formatted
-}
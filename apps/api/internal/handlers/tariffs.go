@@ -0,0 +1,441 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/moveops-platform/apps/api/internal/audit"
+	gen "github.com/moveops-platform/apps/api/internal/gen/db"
+	"github.com/moveops-platform/apps/api/internal/gen/oapi"
+	"github.com/moveops-platform/apps/api/internal/httpx"
+	"github.com/moveops-platform/apps/api/internal/middleware"
+	"github.com/moveops-platform/apps/api/internal/pricing"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// Bounds on pricing inputs so a quote cannot overflow its cents arithmetic.
+const (
+	maxPricingMiles      = 10000
+	maxPricingLaborHours = 240
+	maxTariffCodeLength  = 40
+)
+
+func (s *Server) GetSettingsTariff(w http.ResponseWriter, r *http.Request) {
+	_, tenantID, _, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	tariff, ok := s.loadTariff(w, r, s.Q, tenantID)
+	if !ok {
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, oapi.TariffResponse{
+		Tariff:    tariff,
+		RequestId: middleware.RequestIDFromContext(r.Context()),
+	})
+}
+
+// PutSettingsTariff replaces the tenant's tariff, including its crew rates,
+// accessorials and valuation options.
+func (s *Server) PutSettingsTariff(w http.ResponseWriter, r *http.Request) {
+	_, tenantID, userID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req oapi.UpdateTariffRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "invalid_body", "Malformed JSON body", nil)
+		return
+	}
+
+	crewSizes := make([]int32, 0, len(req.CrewRates))
+	hourlyRates := make([]int64, 0, len(req.CrewRates))
+	seenCrewSizes := map[int]struct{}{}
+	for _, rate := range req.CrewRates {
+		if _, dup := seenCrewSizes[rate.CrewSize]; dup {
+			httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", fmt.Sprintf("crewRates lists crew size %d more than once", rate.CrewSize), nil)
+			return
+		}
+		seenCrewSizes[rate.CrewSize] = struct{}{}
+		crewSizes = append(crewSizes, int32(rate.CrewSize))
+		hourlyRates = append(hourlyRates, rate.HourlyRateCents)
+	}
+
+	var accessorials gen.InsertTariffAccessorialsParams
+	seenCodes := map[string]struct{}{}
+	for _, item := range req.Accessorials {
+		code := strings.TrimSpace(item.Code)
+		name := strings.TrimSpace(item.Name)
+		unit := strings.TrimSpace(item.Unit)
+		if !validTariffCode(code) || name == "" || unit == "" {
+			httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "accessorials need a code of lowercase letters, digits and underscores, a name and a unit", nil)
+			return
+		}
+		if _, dup := seenCodes[code]; dup {
+			httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", fmt.Sprintf("accessorial code %q is listed more than once", code), nil)
+			return
+		}
+		seenCodes[code] = struct{}{}
+		accessorials.Codes = append(accessorials.Codes, code)
+		accessorials.Names = append(accessorials.Names, name)
+		accessorials.Units = append(accessorials.Units, unit)
+		accessorials.RateCents = append(accessorials.RateCents, item.RateCents)
+	}
+
+	var valuations gen.InsertTariffValuationOptionsParams
+	seenCodes = map[string]struct{}{}
+	for _, option := range req.ValuationOptions {
+		code := strings.TrimSpace(option.Code)
+		name := strings.TrimSpace(option.Name)
+		if !validTariffCode(code) || name == "" {
+			httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "valuationOptions need a code of lowercase letters, digits and underscores and a name", nil)
+			return
+		}
+		if _, dup := seenCodes[code]; dup {
+			httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", fmt.Sprintf("valuation code %q is listed more than once", code), nil)
+			return
+		}
+		seenCodes[code] = struct{}{}
+		valuations.Codes = append(valuations.Codes, code)
+		valuations.Names = append(valuations.Names, name)
+		valuations.CentsPerThousand = append(valuations.CentsPerThousand, option.CentsPerThousand)
+		valuations.MinimumCentsPerLb = append(valuations.MinimumCentsPerLb, option.MinimumCentsPerLb)
+	}
+
+	tx, err := s.DB.Begin(r.Context())
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to begin transaction", nil)
+		return
+	}
+	defer tx.Rollback(r.Context())
+	qtx := s.Q.WithTx(tx)
+
+	if _, err := qtx.UpsertTariff(r.Context(), gen.UpsertTariffParams{
+		TenantID:                   tenantID,
+		MinimumHours:               int32(req.MinimumHours),
+		MinimumChargeCents:         req.MinimumChargeCents,
+		LongDistanceThresholdMiles: int32(req.LongDistanceThresholdMiles),
+		PerMileCents:               req.PerMileCents,
+		PerCwtCents:                req.PerCwtCents,
+		FuelSurchargeBps:           int32(req.FuelSurchargeBps),
+		UpdatedBy:                  &userID,
+	}); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to update tariff", nil)
+		return
+	}
+
+	if err := qtx.DeleteTariffCrewRates(r.Context(), tenantID); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to update crew rates", nil)
+		return
+	}
+	if len(crewSizes) > 0 {
+		if err := qtx.InsertTariffCrewRates(r.Context(), gen.InsertTariffCrewRatesParams{TenantID: tenantID, CrewSizes: crewSizes, HourlyRateCents: hourlyRates}); err != nil {
+			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to update crew rates", nil)
+			return
+		}
+	}
+	if err := qtx.DeleteTariffAccessorials(r.Context(), tenantID); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to update accessorials", nil)
+		return
+	}
+	if len(accessorials.Codes) > 0 {
+		accessorials.TenantID = tenantID
+		if err := qtx.InsertTariffAccessorials(r.Context(), accessorials); err != nil {
+			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to update accessorials", nil)
+			return
+		}
+	}
+	if err := qtx.DeleteTariffValuationOptions(r.Context(), tenantID); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to update valuation options", nil)
+		return
+	}
+	if len(valuations.Codes) > 0 {
+		valuations.TenantID = tenantID
+		if err := qtx.InsertTariffValuationOptions(r.Context(), valuations); err != nil {
+			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to update valuation options", nil)
+			return
+		}
+	}
+
+	tariff, ok := s.loadTariff(w, r, qtx, tenantID)
+	if !ok {
+		return
+	}
+	if err := tx.Commit(r.Context()); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to commit tariff", nil)
+		return
+	}
+
+	_ = s.Audit.Log(r.Context(), audit.Entry{
+		TenantID:   tenantID,
+		UserID:     &userID,
+		Action:     "settings.tariff_update",
+		EntityType: "tenant",
+		EntityID:   &tenantID,
+		RequestID:  middleware.RequestIDFromContext(r.Context()),
+		Metadata: map[string]any{
+			"minimumHours":               tariff.MinimumHours,
+			"minimumChargeCents":         tariff.MinimumChargeCents,
+			"longDistanceThresholdMiles": tariff.LongDistanceThresholdMiles,
+			"perMileCents":               tariff.PerMileCents,
+			"perCwtCents":                tariff.PerCwtCents,
+			"fuelSurchargeBps":           tariff.FuelSurchargeBps,
+			"crewRateCount":              len(tariff.CrewRates),
+			"accessorialCount":           len(tariff.Accessorials),
+			"valuationOptionCount":       len(tariff.ValuationOptions),
+		},
+	})
+
+	httpx.WriteJSON(w, http.StatusOK, oapi.TariffResponse{
+		Tariff:    tariff,
+		RequestId: middleware.RequestIDFromContext(r.Context()),
+	})
+}
+
+// PostEstimatesEstimateIdPrice prices the estimate from the tenant tariff and
+// stores the total with its breakdown. The weight comes from the estimate's
+// inventory unless the request overrides it.
+func (s *Server) PostEstimatesEstimateIdPrice(w http.ResponseWriter, r *http.Request, estimateId openapi_types.UUID) {
+	_, tenantID, userID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req oapi.PriceEstimateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "invalid_body", "Malformed JSON body", nil)
+		return
+	}
+	if req.Miles < 0 || req.Miles > maxPricingMiles {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", fmt.Sprintf("miles must be between 0 and %d", maxPricingMiles), nil)
+		return
+	}
+	laborMinutes := 0
+	if req.LaborHours != nil {
+		if *req.LaborHours < 0 || *req.LaborHours > maxPricingLaborHours {
+			httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", fmt.Sprintf("laborHours must be between 0 and %d", maxPricingLaborHours), nil)
+			return
+		}
+		laborMinutes = int(math.Round(*req.LaborHours * 60))
+	}
+	if req.WeightLbs != nil && (*req.WeightLbs < 0 || *req.WeightLbs > maxLineItemQuantity*maxItemWeightLbs) {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "weightLbs is out of range", nil)
+		return
+	}
+
+	settings, ok := s.loadTariff(w, r, s.Q, tenantID)
+	if !ok {
+		return
+	}
+	if !settings.Configured {
+		httpx.WriteError(w, r, http.StatusConflict, "tariff_not_configured", "Set up the tenant tariff before pricing estimates", nil)
+		return
+	}
+
+	estimateID := uuid.UUID(estimateId)
+	tx, err := s.DB.Begin(r.Context())
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to begin transaction", nil)
+		return
+	}
+	defer tx.Rollback(r.Context())
+	qtx := s.Q.WithTx(tx)
+
+	if !s.lockEstimate(w, r, qtx, tenantID, estimateID) {
+		return
+	}
+	before, err := qtx.GetEstimateByID(r.Context(), gen.GetEstimateByIDParams{ID: estimateID, TenantID: tenantID})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load estimate", nil)
+		return
+	}
+
+	pricingReq := pricing.Request{
+		WeightLbs:     int(before.TotalWeightLbs),
+		Miles:         req.Miles,
+		LaborMinutes:  laborMinutes,
+		ValuationCode: strings.TrimSpace(derefString(req.ValuationCode)),
+	}
+	if req.WeightLbs != nil {
+		pricingReq.WeightLbs = *req.WeightLbs
+	}
+	if req.CrewSize != nil {
+		pricingReq.CrewSize = *req.CrewSize
+	}
+	if req.DeclaredValueCents != nil {
+		pricingReq.DeclaredValueCents = *req.DeclaredValueCents
+	}
+	if req.Accessorials != nil {
+		for _, item := range *req.Accessorials {
+			pricingReq.Accessorials = append(pricingReq.Accessorials, pricing.AccessorialQuantity{Code: strings.TrimSpace(item.Code), Quantity: item.Quantity})
+		}
+	}
+
+	quote, err := pricing.Price(pricingTariff(settings), pricingReq)
+	if err != nil {
+		var pricingErr *pricing.Error
+		if errors.As(err, &pricingErr) {
+			httpx.WriteError(w, r, http.StatusBadRequest, pricingErr.Code, pricingErr.Message, nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to price estimate", nil)
+		return
+	}
+	breakdown, err := json.Marshal(quote)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to encode price breakdown", nil)
+		return
+	}
+
+	if _, err := qtx.SetEstimatePrice(r.Context(), gen.SetEstimatePriceParams{
+		EstimatedTotalCents: &quote.TotalCents,
+		PriceBreakdown:      breakdown,
+		UpdatedBy:           &userID,
+		ID:                  estimateID,
+		TenantID:            tenantID,
+	}); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to store estimate price", nil)
+		return
+	}
+	if err := recordEstimateRevision(r.Context(), qtx, tenantID, estimateID, &userID, revisionPriced, nil); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to record estimate revision", nil)
+		return
+	}
+	if err := tx.Commit(r.Context()); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to commit estimate price", nil)
+		return
+	}
+
+	_ = s.Audit.Log(r.Context(), audit.Entry{
+		TenantID:   tenantID,
+		UserID:     &userID,
+		Action:     "estimate.priced",
+		EntityType: "estimate",
+		EntityID:   &estimateID,
+		RequestID:  middleware.RequestIDFromContext(r.Context()),
+		Metadata: map[string]any{
+			"mode":                quote.Mode,
+			"totalCents":          quote.TotalCents,
+			"previousTotalCents":  before.EstimatedTotalCents,
+			"previousPriceSource": before.PriceSource,
+		},
+	})
+
+	s.writeEstimateResponse(w, r, tenantID, estimateID, http.StatusOK)
+}
+
+// loadTariff returns the tenant tariff, or an unconfigured empty one.
+func (s *Server) loadTariff(w http.ResponseWriter, r *http.Request, q *gen.Queries, tenantID uuid.UUID) (oapi.Tariff, bool) {
+	tariff := oapi.Tariff{
+		CrewRates:        []oapi.TariffCrewRate{},
+		Accessorials:     []oapi.TariffAccessorial{},
+		ValuationOptions: []oapi.TariffValuationOption{},
+	}
+
+	row, err := q.GetTariff(r.Context(), tenantID)
+	switch {
+	case err == nil:
+		updatedAt := row.UpdatedAt
+		tariff.Configured = true
+		tariff.MinimumHours = int(row.MinimumHours)
+		tariff.MinimumChargeCents = row.MinimumChargeCents
+		tariff.LongDistanceThresholdMiles = int(row.LongDistanceThresholdMiles)
+		tariff.PerMileCents = row.PerMileCents
+		tariff.PerCwtCents = row.PerCwtCents
+		tariff.FuelSurchargeBps = int(row.FuelSurchargeBps)
+		tariff.UpdatedAt = &updatedAt
+	case errors.Is(err, pgx.ErrNoRows):
+		return tariff, true
+	default:
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load tariff", nil)
+		return oapi.Tariff{}, false
+	}
+
+	crewRates, err := q.ListTariffCrewRates(r.Context(), tenantID)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load crew rates", nil)
+		return oapi.Tariff{}, false
+	}
+	for _, rate := range crewRates {
+		tariff.CrewRates = append(tariff.CrewRates, oapi.TariffCrewRate{CrewSize: int(rate.CrewSize), HourlyRateCents: rate.HourlyRateCents})
+	}
+	accessorials, err := q.ListTariffAccessorials(r.Context(), tenantID)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load accessorials", nil)
+		return oapi.Tariff{}, false
+	}
+	for _, item := range accessorials {
+		tariff.Accessorials = append(tariff.Accessorials, oapi.TariffAccessorial{Code: item.Code, Name: item.Name, Unit: item.Unit, RateCents: item.RateCents})
+	}
+	valuations, err := q.ListTariffValuationOptions(r.Context(), tenantID)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load valuation options", nil)
+		return oapi.Tariff{}, false
+	}
+	for _, option := range valuations {
+		tariff.ValuationOptions = append(tariff.ValuationOptions, oapi.TariffValuationOption{
+			Code:              option.Code,
+			Name:              option.Name,
+			CentsPerThousand:  option.CentsPerThousand,
+			MinimumCentsPerLb: option.MinimumCentsPerLb,
+		})
+	}
+	return tariff, true
+}
+
+func pricingTariff(t oapi.Tariff) pricing.Tariff {
+	out := pricing.Tariff{
+		MinimumHours:               t.MinimumHours,
+		MinimumChargeCents:         t.MinimumChargeCents,
+		LongDistanceThresholdMiles: t.LongDistanceThresholdMiles,
+		PerMileCents:               t.PerMileCents,
+		PerCwtCents:                t.PerCwtCents,
+		FuelSurchargeBps:           t.FuelSurchargeBps,
+		CrewRates:                  make(map[int]int64, len(t.CrewRates)),
+		Accessorials:               make(map[string]pricing.Accessorial, len(t.Accessorials)),
+		Valuations:                 make(map[string]pricing.Valuation, len(t.ValuationOptions)),
+	}
+	for _, rate := range t.CrewRates {
+		out.CrewRates[rate.CrewSize] = rate.HourlyRateCents
+	}
+	for _, item := range t.Accessorials {
+		out.Accessorials[item.Code] = pricing.Accessorial{Name: item.Name, Unit: item.Unit, RateCents: item.RateCents}
+	}
+	for _, option := range t.ValuationOptions {
+		out.Valuations[option.Code] = pricing.Valuation{Name: option.Name, CentsPerThousand: option.CentsPerThousand, MinimumCentsPerLb: option.MinimumCentsPerLb}
+	}
+	return out
+}
+
+// mapPriceBreakdown decodes the stored breakdown. A row priced by hand has
+// none.
+func mapPriceBreakdown(raw []byte) *oapi.EstimatePriceBreakdown {
+	if len(raw) == 0 {
+		return nil
+	}
+	var breakdown oapi.EstimatePriceBreakdown
+	if err := json.Unmarshal(raw, &breakdown); err != nil {
+		return nil
+	}
+	return &breakdown
+}
+
+func validTariffCode(code string) bool {
+	if code == "" || len(code) > maxTariffCodeLength {
+		return false
+	}
+	for _, c := range code {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '_' {
+			return false
+		}
+	}
+	return true
+}
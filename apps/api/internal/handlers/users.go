@@ -0,0 +1,492 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/moveops-platform/apps/api/internal/audit"
+	"github.com/moveops-platform/apps/api/internal/auth"
+	gen "github.com/moveops-platform/apps/api/internal/gen/db"
+	"github.com/moveops-platform/apps/api/internal/gen/oapi"
+	"github.com/moveops-platform/apps/api/internal/httpx"
+	"github.com/moveops-platform/apps/api/internal/middleware"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+func (s *Server) GetUsers(w http.ResponseWriter, r *http.Request) {
+	_, tenantID, _, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	rows, err := s.Q.ListTenantUsers(r.Context(), tenantID)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load users", nil)
+		return
+	}
+
+	items := make([]oapi.TenantUser, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, mapTenantUser(gen.GetTenantUserByIDRow(row)))
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, oapi.TenantUserListResponse{
+		Items:     items,
+		RequestId: middleware.RequestIDFromContext(r.Context()),
+	})
+}
+
+func (s *Server) PostUsers(w http.ResponseWriter, r *http.Request) {
+	actor, tenantID, actorUserID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req oapi.CreateTenantUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "invalid_body", "Malformed JSON body", nil)
+		return
+	}
+
+	email := strings.ToLower(strings.TrimSpace(string(req.Email)))
+	fullName := strings.TrimSpace(req.FullName)
+	if email == "" || fullName == "" {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "email and fullName are required", nil)
+		return
+	}
+
+	password, temporaryPassword, ok := s.resolveAdminPassword(w, r, tenantID, req.Password, email, fullName)
+	if !ok {
+		return
+	}
+	passwordHash, err := auth.HashPassword(password)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to hash password", nil)
+		return
+	}
+
+	roleIDs := make([]uuid.UUID, 0)
+	if req.RoleIds != nil {
+		seen := map[uuid.UUID]struct{}{}
+		for _, id := range *req.RoleIds {
+			roleID := uuid.UUID(id)
+			if _, dup := seen[roleID]; dup {
+				continue
+			}
+			seen[roleID] = struct{}{}
+			roleIDs = append(roleIDs, roleID)
+		}
+	}
+	// Assigning roles is the same grant as PUT /users/{id}/roles.
+	if len(roleIDs) > 0 && !actor.Permissions.Has(adminCapablePermission) {
+		httpx.WriteError(w, r, http.StatusForbidden, "forbidden", "Permission denied", map[string]string{"permission": adminCapablePermission})
+		return
+	}
+
+	tx, err := s.DB.Begin(r.Context())
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to begin transaction", nil)
+		return
+	}
+	defer tx.Rollback(r.Context())
+	qtx := s.Q.WithTx(tx)
+
+	if len(roleIDs) > 0 {
+		count, err := qtx.CountTenantRolesByIDs(r.Context(), gen.CountTenantRolesByIDsParams{TenantID: tenantID, RoleIds: roleIDs})
+		if err != nil {
+			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load roles", nil)
+			return
+		}
+		if count != int64(len(roleIDs)) {
+			httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "roleIds must reference roles in this tenant", nil)
+			return
+		}
+	}
+
+	created, err := qtx.CreateUser(r.Context(), gen.CreateUserParams{
+		TenantID:     tenantID,
+		Email:        email,
+		FullName:     fullName,
+		PasswordHash: passwordHash,
+	})
+	if err != nil {
+		if isUniqueConstraint(err, "users_tenant_email_uidx") {
+			httpx.WriteError(w, r, http.StatusConflict, "user_email_conflict", "A user with this email already exists", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to create user", nil)
+		return
+	}
+
+	for _, roleID := range roleIDs {
+		if _, err := qtx.AssignUserRole(r.Context(), gen.AssignUserRoleParams{
+			UserID:   created.ID,
+			RoleID:   roleID,
+			TenantID: tenantID,
+		}); err != nil {
+			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to assign roles", nil)
+			return
+		}
+	}
+
+	if err := tx.Commit(r.Context()); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to commit user", nil)
+		return
+	}
+
+	createdID := created.ID
+	roleIDStrings := make([]string, 0, len(roleIDs))
+	for _, roleID := range roleIDs {
+		roleIDStrings = append(roleIDStrings, roleID.String())
+	}
+	_ = s.Audit.Log(r.Context(), audit.Entry{
+		TenantID:   tenantID,
+		UserID:     &actorUserID,
+		Action:     "users.create",
+		EntityType: "user",
+		EntityID:   &createdID,
+		RequestID:  middleware.RequestIDFromContext(r.Context()),
+		Metadata: map[string]any{
+			"email":             email,
+			"roleIds":           roleIDStrings,
+			"temporaryPassword": temporaryPassword != nil,
+		},
+	})
+
+	s.writeTenantUserResponse(w, r, tenantID, created.ID, http.StatusCreated, temporaryPassword)
+}
+
+func (s *Server) GetUsersUserId(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
+	_, tenantID, _, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	s.writeTenantUserResponse(w, r, tenantID, uuid.UUID(userId), http.StatusOK, nil)
+}
+
+func (s *Server) PatchUsersUserId(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
+	_, tenantID, actorUserID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req oapi.UpdateTenantUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "invalid_body", "Malformed JSON body", nil)
+		return
+	}
+
+	targetID := uuid.UUID(userId)
+	fullName := sanitizeOptional(req.FullName)
+	if req.FullName != nil && fullName == nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "validation_error", "fullName cannot be blank", nil)
+		return
+	}
+	if req.IsActive != nil && !*req.IsActive && targetID == actorUserID {
+		httpx.WriteError(w, r, http.StatusConflict, "cannot_deactivate_self", "You cannot deactivate your own account", nil)
+		return
+	}
+
+	before, err := s.Q.GetTenantUserByID(r.Context(), gen.GetTenantUserByIDParams{ID: targetID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusNotFound, "user_not_found", "User was not found", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load user", nil)
+		return
+	}
+
+	tx, err := s.DB.Begin(r.Context())
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to begin transaction", nil)
+		return
+	}
+	defer tx.Rollback(r.Context())
+	qtx := s.Q.WithTx(tx)
+
+	var adminCapableBefore int64
+	if req.IsActive != nil && !*req.IsActive {
+		if err := qtx.LockTenantRoles(r.Context(), tenantID); err != nil {
+			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to lock roles", nil)
+			return
+		}
+		if adminCapableBefore, ok = s.countAdminCapableUsers(w, r, qtx, tenantID); !ok {
+			return
+		}
+	}
+
+	updated, err := qtx.UpdateTenantUser(r.Context(), gen.UpdateTenantUserParams{
+		ID:       targetID,
+		TenantID: tenantID,
+		FullName: fullName,
+		IsActive: req.IsActive,
+	})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to update user", nil)
+		return
+	}
+
+	var sessionsRevoked int64
+	if before.IsActive && !updated.IsActive {
+		sessionsRevoked, err = qtx.RevokeSessionsForUser(r.Context(), gen.RevokeSessionsForUserParams{UserID: targetID, TenantID: tenantID})
+		if err != nil {
+			httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to revoke sessions", nil)
+			return
+		}
+		if !s.ensureAdminCapableUserRemains(w, r, qtx, tenantID, adminCapableBefore) {
+			return
+		}
+	}
+
+	if err := tx.Commit(r.Context()); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to commit user", nil)
+		return
+	}
+
+	requestID := middleware.RequestIDFromContext(r.Context())
+	if before.FullName != updated.FullName {
+		_ = s.Audit.Log(r.Context(), audit.Entry{
+			TenantID:   tenantID,
+			UserID:     &actorUserID,
+			Action:     "users.update",
+			EntityType: "user",
+			EntityID:   &targetID,
+			RequestID:  requestID,
+			Metadata: map[string]any{
+				"fieldsChanged": map[string]any{
+					"fullName": map[string]any{"before": before.FullName, "after": updated.FullName},
+				},
+			},
+		})
+	}
+	if before.IsActive != updated.IsActive {
+		action := "users.reactivate"
+		if !updated.IsActive {
+			action = "users.deactivate"
+		}
+		_ = s.Audit.Log(r.Context(), audit.Entry{
+			TenantID:   tenantID,
+			UserID:     &actorUserID,
+			Action:     action,
+			EntityType: "user",
+			EntityID:   &targetID,
+			RequestID:  requestID,
+			Metadata: map[string]any{
+				"sessionsRevoked": sessionsRevoked,
+			},
+		})
+	}
+
+	s.writeTenantUserResponse(w, r, tenantID, targetID, http.StatusOK, nil)
+}
+
+func (s *Server) PostUsersUserIdPasswordReset(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
+	actor, tenantID, actorUserID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req oapi.ResetUserPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "invalid_body", "Malformed JSON body", nil)
+		return
+	}
+
+	targetID := uuid.UUID(userId)
+	target, err := s.Q.GetTenantUserByID(r.Context(), gen.GetTenantUserByIDParams{ID: targetID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusNotFound, "user_not_found", "User was not found", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load user", nil)
+		return
+	}
+	if !s.canResetCredentials(w, r, actor, tenantID, targetID) {
+		return
+	}
+
+	password, temporaryPassword, ok := s.resolveAdminPassword(w, r, tenantID, req.Password, target.Email, target.FullName)
+	if !ok {
+		return
+	}
+	passwordHash, err := auth.HashPassword(password)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to hash password", nil)
+		return
+	}
+
+	tx, err := s.DB.Begin(r.Context())
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to begin transaction", nil)
+		return
+	}
+	defer tx.Rollback(r.Context())
+	qtx := s.Q.WithTx(tx)
+
+	affected, err := qtx.UpdateUserPasswordHash(r.Context(), gen.UpdateUserPasswordHashParams{
+		ID:           targetID,
+		TenantID:     tenantID,
+		PasswordHash: passwordHash,
+	})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to reset password", nil)
+		return
+	}
+	if affected == 0 {
+		httpx.WriteError(w, r, http.StatusNotFound, "user_not_found", "User was not found", nil)
+		return
+	}
+	sessionsRevoked, err := qtx.RevokeSessionsForUser(r.Context(), gen.RevokeSessionsForUserParams{UserID: targetID, TenantID: tenantID})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to revoke sessions", nil)
+		return
+	}
+
+	if err := tx.Commit(r.Context()); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to commit password reset", nil)
+		return
+	}
+
+	_ = s.Audit.Log(r.Context(), audit.Entry{
+		TenantID:   tenantID,
+		UserID:     &actorUserID,
+		Action:     "users.password_reset",
+		EntityType: "user",
+		EntityID:   &targetID,
+		RequestID:  middleware.RequestIDFromContext(r.Context()),
+		Metadata: map[string]any{
+			"temporaryPassword": temporaryPassword != nil,
+			"sessionsRevoked":   sessionsRevoked,
+		},
+	})
+
+	s.writeTenantUserResponse(w, r, tenantID, targetID, http.StatusOK, temporaryPassword)
+}
+
+func (s *Server) PostUsersUserIdUnlock(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
+	_, tenantID, actorUserID, ok := requireActorIDs(w, r)
+	if !ok {
+		return
+	}
+
+	targetID := uuid.UUID(userId)
+	before, err := s.Q.GetTenantUserByID(r.Context(), gen.GetTenantUserByIDParams{ID: targetID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusNotFound, "user_not_found", "User was not found", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load user", nil)
+		return
+	}
+
+	if _, err := s.Q.ClearLoginFailures(r.Context(), gen.ClearLoginFailuresParams{UserID: targetID, TenantID: tenantID}); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to unlock user", nil)
+		return
+	}
+
+	_ = s.Audit.Log(r.Context(), audit.Entry{
+		TenantID:   tenantID,
+		UserID:     &actorUserID,
+		Action:     "users.unlock",
+		EntityType: "user",
+		EntityID:   &targetID,
+		RequestID:  middleware.RequestIDFromContext(r.Context()),
+		Metadata: map[string]any{
+			"wasLocked": mapTenantUser(before).LockedUntil != nil,
+		},
+	})
+
+	s.writeTenantUserResponse(w, r, tenantID, targetID, http.StatusOK, nil)
+}
+
+// canResetCredentials refuses password resets for users who hold
+// roles.manage unless the actor holds it too, so users.manage alone cannot
+// take over an admin account. On refusal it has written the error response.
+func (s *Server) canResetCredentials(w http.ResponseWriter, r *http.Request, actor middleware.Actor, tenantID, targetID uuid.UUID) bool {
+	if actor.Permissions.Has(adminCapablePermission) {
+		return true
+	}
+	permissions, err := s.Q.ListUserPermissions(r.Context(), gen.ListUserPermissionsParams{UserID: targetID, TenantID: tenantID})
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load permissions", nil)
+		return false
+	}
+	if slices.Contains(permissions, adminCapablePermission) {
+		httpx.WriteError(w, r, http.StatusForbidden, "forbidden", "Permission denied", map[string]string{"permission": adminCapablePermission})
+		return false
+	}
+	return true
+}
+
+// resolveAdminPassword returns the password to hash and, when the admin did
+// not supply one, the generated temporary password to hand back once. A
+// supplied password must meet the tenant's password policy.
+func (s *Server) resolveAdminPassword(w http.ResponseWriter, r *http.Request, tenantID uuid.UUID, requested *string, accountInputs ...string) (string, *string, bool) {
+	if requested != nil {
+		policy, ok := s.tenantPasswordPolicy(w, r, tenantID)
+		if !ok {
+			return "", nil, false
+		}
+		if !checkPasswordPolicy(w, r, policy, *requested, accountInputs...) {
+			return "", nil, false
+		}
+		return *requested, nil, true
+	}
+
+	generated, err := auth.GenerateToken()
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to generate password", nil)
+		return "", nil, false
+	}
+	return generated, &generated, true
+}
+
+func (s *Server) writeTenantUserResponse(w http.ResponseWriter, r *http.Request, tenantID, userID uuid.UUID, status int, temporaryPassword *string) {
+	user, err := s.Q.GetTenantUserByID(r.Context(), gen.GetTenantUserByIDParams{ID: userID, TenantID: tenantID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			httpx.WriteError(w, r, http.StatusNotFound, "user_not_found", "User was not found", nil)
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load user", nil)
+		return
+	}
+
+	httpx.WriteJSON(w, status, oapi.TenantUserResponse{
+		User:              mapTenantUser(user),
+		TemporaryPassword: temporaryPassword,
+		RequestId:         middleware.RequestIDFromContext(r.Context()),
+	})
+}
+
+func mapTenantUser(user gen.GetTenantUserByIDRow) oapi.TenantUser {
+	roles := user.RoleNames
+	if roles == nil {
+		roles = []string{}
+	}
+	var lockedUntil *time.Time
+	if user.LockedUntil != nil && user.LockedUntil.After(time.Now()) {
+		until := user.LockedUntil.UTC()
+		lockedUntil = &until
+	}
+	return oapi.TenantUser{
+		Id:          user.ID,
+		Email:       openapi_types.Email(user.Email),
+		FullName:    user.FullName,
+		IsActive:    user.IsActive,
+		Roles:       roles,
+		LockedUntil: lockedUntil,
+		CreatedAt:   user.CreatedAt.UTC(),
+		UpdatedAt:   user.UpdatedAt.UTC(),
+	}
+}
@@ -0,0 +1,244 @@
+// Package mail formats plain-text email and delivers it over SMTP. It knows
+// nothing about tenants or the outbox; callers hand it finished messages.
+package mail
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	netmail "net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Message is one plain-text email.
+type Message struct {
+	// ID becomes the local part of the Message-ID header.
+	ID       string
+	From     string
+	FromName string
+	To       string
+	ReplyTo  string
+	Subject  string
+	Body     string
+}
+
+// Sender delivers messages.
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// TLSMode is how the SMTP connection is secured.
+type TLSMode string
+
+const (
+	// TLSStartTLS upgrades a plain connection and fails if the server does
+	// not offer STARTTLS.
+	TLSStartTLS TLSMode = "starttls"
+	// TLSImplicit connects with TLS from the start, usually on port 465.
+	TLSImplicit TLSMode = "tls"
+	// TLSNone sends in the clear. Only for local relays and tests.
+	TLSNone TLSMode = "none"
+)
+
+// ParseTLSMode accepts starttls, tls or none.
+func ParseTLSMode(value string) (TLSMode, error) {
+	switch mode := TLSMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case TLSStartTLS, TLSImplicit, TLSNone:
+		return mode, nil
+	}
+	return "", fmt.Errorf("unknown SMTP TLS mode %q", value)
+}
+
+// SMTPSender delivers messages through an SMTP relay, one connection per
+// message.
+type SMTPSender struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	TLS      TLSMode
+	// HelloName is sent with EHLO. It defaults to localhost.
+	HelloName string
+	// Timeout bounds the whole exchange when ctx has no earlier deadline.
+	Timeout time.Duration
+}
+
+// Send delivers msg. Rejections of the recipient or the content with a 5xx
+// reply are permanent, see IsPermanent; everything else may succeed later.
+func (s *SMTPSender) Send(ctx context.Context, msg Message) error {
+	data, err := msg.Bytes(time.Now())
+	if err != nil {
+		return permanent(err)
+	}
+
+	timeout := s.Timeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	deadline := time.Now().Add(timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+
+	addr := net.JoinHostPort(s.Host, strconv.Itoa(s.Port))
+	dialer := &net.Dialer{Deadline: deadline}
+	var conn net.Conn
+	if s.TLS == TLSImplicit {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: s.Host}}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("connect to %s: %w", addr, err)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+
+	client, err := smtp.NewClient(conn, s.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("smtp greeting: %w", err)
+	}
+	defer client.Close()
+
+	helloName := s.HelloName
+	if helloName == "" {
+		helloName = "localhost"
+	}
+	if err := client.Hello(helloName); err != nil {
+		return fmt.Errorf("smtp hello: %w", err)
+	}
+	if s.TLS == TLSStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return errors.New("smtp server does not offer STARTTLS")
+		}
+		if err := client.StartTLS(&tls.Config{ServerName: s.Host}); err != nil {
+			return fmt.Errorf("smtp starttls: %w", err)
+		}
+	}
+	if s.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.Username, s.Password, s.Host)); err != nil {
+			return fmt.Errorf("smtp auth: %w", err)
+		}
+	}
+	if err := client.Mail(msg.From); err != nil {
+		return fmt.Errorf("smtp sender: %w", err)
+	}
+	if err := client.Rcpt(msg.To); err != nil {
+		return classify(fmt.Errorf("smtp recipient: %w", err))
+	}
+	body, err := client.Data()
+	if err != nil {
+		return classify(fmt.Errorf("smtp data: %w", err))
+	}
+	if _, err := body.Write(data); err != nil {
+		return fmt.Errorf("smtp data: %w", err)
+	}
+	if err := body.Close(); err != nil {
+		return classify(fmt.Errorf("smtp data: %w", err))
+	}
+	// The message is accepted once DATA is; a failed QUIT does not matter.
+	_ = client.Quit()
+	return nil
+}
+
+// Bytes formats msg as an RFC 5322 message with a quoted-printable UTF-8
+// body.
+func (msg Message) Bytes(now time.Time) ([]byte, error) {
+	from, err := NormalizeAddress(msg.From)
+	if err != nil {
+		return nil, fmt.Errorf("from: %w", err)
+	}
+	to, err := NormalizeAddress(msg.To)
+	if err != nil {
+		return nil, fmt.Errorf("to: %w", err)
+	}
+	for _, value := range []string{msg.FromName, msg.Subject, msg.ID} {
+		if strings.ContainsAny(value, "\r\n") {
+			return nil, errors.New("header values must not contain line breaks")
+		}
+	}
+
+	var buf bytes.Buffer
+	header := func(name, value string) {
+		buf.WriteString(name + ": " + value + "\r\n")
+	}
+	header("From", (&netmail.Address{Name: msg.FromName, Address: from}).String())
+	header("To", (&netmail.Address{Address: to}).String())
+	if msg.ReplyTo != "" {
+		replyTo, err := NormalizeAddress(msg.ReplyTo)
+		if err != nil {
+			return nil, fmt.Errorf("reply-to: %w", err)
+		}
+		header("Reply-To", (&netmail.Address{Address: replyTo}).String())
+	}
+	header("Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	header("Date", now.Format(time.RFC1123Z))
+	if msg.ID != "" {
+		header("Message-ID", "<"+msg.ID+"@"+from[strings.LastIndex(from, "@")+1:]+">")
+	}
+	header("MIME-Version", "1.0")
+	header("Content-Type", "text/plain; charset=utf-8")
+	header("Content-Transfer-Encoding", "quoted-printable")
+	buf.WriteString("\r\n")
+
+	qp := quotedprintable.NewWriter(&buf)
+	if _, err := qp.Write([]byte(strings.ReplaceAll(msg.Body, "\r\n", "\n"))); err != nil {
+		return nil, err
+	}
+	if err := qp.Close(); err != nil {
+		return nil, err
+	}
+	buf.WriteString("\r\n")
+	return buf.Bytes(), nil
+}
+
+// NormalizeAddress checks that value is a single bare email address and
+// returns it trimmed.
+func NormalizeAddress(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	parsed, err := netmail.ParseAddress(value)
+	if err != nil || parsed.Address != value {
+		return "", fmt.Errorf("%q is not an email address", value)
+	}
+	return value, nil
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+func permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+// classify marks 5xx replies as permanent.
+func classify(err error) error {
+	var reply *textproto.Error
+	if errors.As(err, &reply) && reply.Code >= 500 {
+		return permanent(err)
+	}
+	return err
+}
+
+// IsPermanent reports whether retrying err cannot help: the relay rejected
+// the recipient or the message with a 5xx reply, or the message itself is
+// malformed. Connection, authentication and 4xx failures are temporary.
+func IsPermanent(err error) bool {
+	var target *permanentError
+	return errors.As(err, &target)
+}
@@ -0,0 +1,94 @@
+package mail
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moveops-platform/apps/api/internal/mail/mailtest"
+)
+
+func TestMessageBytesEncodesHeadersAndBody(t *testing.T) {
+	msg := Message{
+		ID:       "0b6f4d2e",
+		From:     "quotes@acme.test",
+		FromName: "Acmé Movers",
+		To:       "jane@example.com",
+		ReplyTo:  "office@acme.test",
+		Subject:  "Your estimate – E-000001",
+		Body:     "Hi Jane,\n\nTotal: $1,200.00 = deposit + balance.\n",
+	}
+	data, err := msg.Bytes(time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("format: %v", err)
+	}
+	text := string(data)
+	for _, want := range []string{
+		"From: =?utf-8?q?Acm=C3=A9_Movers?= <quotes@acme.test>\r\n",
+		"To: <jane@example.com>\r\n",
+		"Reply-To: <office@acme.test>\r\n",
+		"Subject: =?utf-8?q?Your_estimate_=E2=80=93_E-000001?=\r\n",
+		"Message-ID: <0b6f4d2e@acme.test>\r\n",
+		"Content-Transfer-Encoding: quoted-printable\r\n",
+		"\r\n\r\nHi Jane,\r\n\r\nTotal: $1,200.00 =3D deposit + balance.\r\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in\n%s", want, text)
+		}
+	}
+
+	msg.Subject = "Hello\r\nBcc: victim@example.com"
+	if _, err := msg.Bytes(time.Now()); err == nil {
+		t.Fatalf("expected header injection to be rejected")
+	}
+	msg.Subject = "Hello"
+	msg.To = "Jane <jane@example.com>"
+	if _, err := msg.Bytes(time.Now()); err == nil {
+		t.Fatalf("expected a display name in To to be rejected")
+	}
+}
+
+func TestSMTPSenderDeliversAndClassifiesFailures(t *testing.T) {
+	server := mailtest.NewServer()
+	defer server.Close()
+	sender := &SMTPSender{Host: server.Host, Port: server.Port, TLS: TLSNone, Timeout: 5 * time.Second}
+	msg := Message{ID: "1", From: "quotes@acme.test", To: "jane@example.com", Subject: "Estimate", Body: "See you soon.\n.\nBye"}
+
+	if err := sender.Send(context.Background(), msg); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	messages := server.Messages()
+	if len(messages) != 1 || messages[0].From != "quotes@acme.test" || messages[0].To[0] != "jane@example.com" {
+		t.Fatalf("unexpected deliveries %+v", messages)
+	}
+	if messages[0].Subject() != "Estimate" || messages[0].Body() != "See you soon.\n.\nBye\n" {
+		t.Fatalf("unexpected message %q / %q", messages[0].Subject(), messages[0].Body())
+	}
+
+	server.FailNext(1, 451)
+	if err := sender.Send(context.Background(), msg); err == nil || IsPermanent(err) {
+		t.Fatalf("expected a temporary failure, got %v", err)
+	}
+	server.FailNext(1, 550)
+	if err := sender.Send(context.Background(), msg); err == nil || !IsPermanent(err) {
+		t.Fatalf("expected a permanent failure, got %v", err)
+	}
+
+	starttls := &SMTPSender{Host: server.Host, Port: server.Port, TLS: TLSStartTLS, Timeout: 5 * time.Second}
+	if err := starttls.Send(context.Background(), msg); err == nil || IsPermanent(err) {
+		t.Fatalf("expected a missing STARTTLS to fail temporarily, got %v", err)
+	}
+	if len(server.Messages()) != 1 {
+		t.Fatalf("expected failed deliveries not to be stored")
+	}
+}
+
+func TestParseTLSMode(t *testing.T) {
+	if mode, err := ParseTLSMode(" STARTTLS "); err != nil || mode != TLSStartTLS {
+		t.Fatalf("unexpected mode %q (%v)", mode, err)
+	}
+	if _, err := ParseTLSMode("ssl"); err == nil {
+		t.Fatalf("expected an unknown mode to be rejected")
+	}
+}
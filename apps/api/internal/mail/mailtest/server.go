@@ -0,0 +1,206 @@
+// Package mailtest runs a minimal in-process SMTP server for tests. It
+// accepts every message without authentication or TLS and keeps it in
+// memory; FailNext makes deliveries fail with a chosen reply code.
+package mailtest
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Message is one accepted delivery.
+type Message struct {
+	From string
+	To   []string
+	Data []byte
+}
+
+// Header parses the message headers.
+func (m Message) Header() mail.Header {
+	parsed, err := mail.ReadMessage(bytes.NewReader(m.Data))
+	if err != nil {
+		return mail.Header{}
+	}
+	return parsed.Header
+}
+
+// Subject is the decoded Subject header.
+func (m Message) Subject() string {
+	subject := m.Header().Get("Subject")
+	decoded, err := new(mime.WordDecoder).DecodeHeader(subject)
+	if err != nil {
+		return subject
+	}
+	return decoded
+}
+
+// Body is the decoded text body with LF line endings.
+func (m Message) Body() string {
+	parsed, err := mail.ReadMessage(bytes.NewReader(m.Data))
+	if err != nil {
+		return ""
+	}
+	var reader io.Reader = parsed.Body
+	if strings.EqualFold(parsed.Header.Get("Content-Transfer-Encoding"), "quoted-printable") {
+		reader = quotedprintable.NewReader(reader)
+	}
+	body, _ := io.ReadAll(reader)
+	return strings.ReplaceAll(string(body), "\r\n", "\n")
+}
+
+type Server struct {
+	// Host and Port are where the server listens.
+	Host string
+	Port int
+
+	listener net.Listener
+	wg       sync.WaitGroup
+
+	mu       sync.Mutex
+	messages []Message
+	failures []int
+}
+
+// NewServer starts a server on a free local port. Close it when done.
+func NewServer() *Server {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic("mailtest: listen: " + err.Error())
+	}
+	addr := listener.Addr().(*net.TCPAddr)
+	s := &Server{Host: addr.IP.String(), Port: addr.Port, listener: listener}
+	s.wg.Add(1)
+	go s.serve()
+	return s
+}
+
+// Close stops the server and waits for open sessions to end.
+func (s *Server) Close() {
+	_ = s.listener.Close()
+	s.wg.Wait()
+}
+
+// Messages returns the accepted messages in arrival order.
+func (s *Server) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Message(nil), s.messages...)
+}
+
+// FailNext rejects the next count messages at the end of DATA with code,
+// 4xx for a temporary and 5xx for a permanent failure.
+func (s *Server) FailNext(count, code int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := 0; i < count; i++ {
+		s.failures = append(s.failures, code)
+	}
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer conn.Close()
+			s.session(textproto.NewConn(conn))
+		}()
+	}
+}
+
+var pathPattern = regexp.MustCompile(`<([^>]*)>`)
+
+func (s *Server) session(c *textproto.Conn) {
+	reply := func(format string, args ...any) bool {
+		return c.PrintfLine(format, args...) == nil
+	}
+	if !reply("220 mailtest ESMTP ready") {
+		return
+	}
+
+	var from string
+	var to []string
+	for {
+		line, err := c.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO":
+			reply("250-mailtest")
+			reply("250 8BITMIME")
+		case "HELO":
+			reply("250 mailtest")
+		case "MAIL":
+			match := pathPattern.FindStringSubmatch(arg)
+			if match == nil {
+				reply("501 syntax: MAIL FROM:<address>")
+				continue
+			}
+			from, to = match[1], nil
+			reply("250 OK")
+		case "RCPT":
+			match := pathPattern.FindStringSubmatch(arg)
+			if match == nil || match[1] == "" {
+				reply("501 syntax: RCPT TO:<address>")
+				continue
+			}
+			to = append(to, match[1])
+			reply("250 OK")
+		case "DATA":
+			if len(to) == 0 {
+				reply("503 need RCPT first")
+				continue
+			}
+			reply("354 end data with <CR><LF>.<CR><LF>")
+			data, err := c.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			if code := s.nextFailure(); code != 0 {
+				reply("%d mailtest rejected the message", code)
+			} else {
+				s.mu.Lock()
+				s.messages = append(s.messages, Message{From: from, To: to, Data: data})
+				s.mu.Unlock()
+				reply("250 OK queued")
+			}
+			from, to = "", nil
+		case "RSET":
+			from, to = "", nil
+			reply("250 OK")
+		case "NOOP":
+			reply("250 OK")
+		case "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("502 %s not implemented", verb)
+		}
+	}
+}
+
+func (s *Server) nextFailure() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.failures) == 0 {
+		return 0
+	}
+	code := s.failures[0]
+	s.failures = s.failures[1:]
+	return code
+}
@@ -2,6 +2,8 @@ package middleware
 
 import (
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/moveops-platform/apps/api/internal/auth"
@@ -9,12 +11,59 @@ import (
 )
 
 type AuthMiddleware struct {
-	Queries    *gen.Queries
-	CookieName string
+	Queries       *gen.Queries
+	CookieName    string
+	SecureCookies bool
+	// IdleTimeout, RotateInterval and TouchInterval mirror the session
+	// settings in config.Config.
+	IdleTimeout    time.Duration
+	RotateInterval time.Duration
+	TouchInterval  time.Duration
 }
 
+type sessionMode int
+
+const (
+	// sessionFull admits fully authenticated sessions that satisfy the
+	// tenant's MFA policy.
+	sessionFull sessionMode = iota
+	// sessionSetup also admits sessions that still have to enroll in MFA, so
+	// the user can reach the enrollment endpoints.
+	sessionSetup
+	// sessionMFAChallenge admits only half-authenticated sessions waiting for
+	// the second factor.
+	sessionMFAChallenge
+)
+
 func (m AuthMiddleware) RequireAuth(next http.Handler) http.Handler {
+	return m.requireSession(next, sessionFull)
+}
+
+// RequireSession is RequireAuth for the account and MFA enrollment routes
+// that must stay reachable while the tenant's MFA policy is unmet.
+func (m AuthMiddleware) RequireSession(next http.Handler) http.Handler {
+	return m.requireSession(next, sessionSetup)
+}
+
+// RequireMFAChallenge admits only sessions issued by the password step of an
+// MFA login.
+func (m AuthMiddleware) RequireMFAChallenge(next http.Handler) http.Handler {
+	return m.requireSession(next, sessionMFAChallenge)
+}
+
+func (m AuthMiddleware) requireSession(next http.Handler, mode sessionMode) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token, ok := bearerToken(r); ok {
+			// Tokens are for integrations; login, MFA and session management
+			// stay cookie-only.
+			if mode != sessionFull {
+				writeError(w, r, http.StatusUnauthorized, "api_token_not_allowed", "API tokens cannot be used for this endpoint", nil)
+				return
+			}
+			m.serveAPIToken(w, r, next, token)
+			return
+		}
+
 		cookie, err := r.Cookie(m.CookieName)
 		if err != nil || cookie.Value == "" {
 			writeError(w, r, http.StatusUnauthorized, "unauthorized", "Authentication required", nil)
@@ -31,20 +80,172 @@ func (m AuthMiddleware) RequireAuth(next http.Handler) http.Handler {
 			return
 		}
 
-		_ = m.Queries.TouchSession(r.Context(), principal.SessionID)
+		switch {
+		case principal.MfaPending && mode != sessionMFAChallenge:
+			writeError(w, r, http.StatusUnauthorized, "mfa_required", "Two-factor verification is required", nil)
+			return
+		case !principal.MfaPending && mode == sessionMFAChallenge:
+			writeError(w, r, http.StatusConflict, "mfa_not_pending", "Session is not waiting for two-factor verification", nil)
+			return
+		}
+
+		permissions, err := m.Queries.ListUserPermissions(r.Context(), gen.ListUserPermissionsParams{
+			UserID:   principal.UserID,
+			TenantID: principal.TenantID,
+		})
+		if err != nil {
+			writeError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load permissions", nil)
+			return
+		}
+		permissionSet := NewPermissionSet(permissions)
+		enrollmentRequired := MFAEnrollmentRequired(principal.RequireMfaForSensitiveRoles, principal.MfaEnabled, permissionSet)
+		if enrollmentRequired && mode == sessionFull {
+			writeError(w, r, http.StatusForbidden, "mfa_enrollment_required", "Set up two-factor authentication to continue", nil)
+			return
+		}
+
+		expiresAt := principal.ExpiresAt
+		if !principal.MfaPending {
+			expiresAt = m.refreshSession(w, r, principal, cookie.Value)
+		}
 
 		ctx := WithActor(r.Context(), Actor{
-			SessionID:  principal.SessionID.String(),
-			UserID:     principal.UserID.String(),
-			TenantID:   principal.TenantID.String(),
-			Email:      principal.Email,
-			FullName:   principal.FullName,
-			TenantSlug: principal.TenantSlug,
-			TenantName: principal.TenantName,
-			CSRFToken:  principal.CsrfToken,
-			ExpiresAt:  principal.ExpiresAt,
+			SessionID:             principal.SessionID.String(),
+			UserID:                principal.UserID.String(),
+			TenantID:              principal.TenantID.String(),
+			Email:                 principal.Email,
+			FullName:              principal.FullName,
+			TenantSlug:            principal.TenantSlug,
+			TenantName:            principal.TenantName,
+			CSRFToken:             principal.CsrfToken,
+			ExpiresAt:             expiresAt,
+			Permissions:           permissionSet,
+			MFAPending:            principal.MfaPending,
+			MFAEnabled:            principal.MfaEnabled,
+			MFAEnrollmentRequired: enrollmentRequired,
 		})
 
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+// refreshSession slides the idle expiry and rotates the session token when
+// they are due, and returns the session's current expiry. Both are best
+// effort: a failed write leaves the session as it was.
+func (m AuthMiddleware) refreshSession(w http.ResponseWriter, r *http.Request, principal gen.GetSessionPrincipalByTokenHashRow, token string) time.Time {
+	now := time.Now()
+	expiresAt := principal.ExpiresAt
+
+	// Only write when the last write is older than the touch interval, so a
+	// busy client does not cause an UPDATE per request.
+	if auth.SessionRefreshDue(principal.LastSeenAt, now, m.TouchInterval) {
+		next := auth.SessionIdleExpiry(now, m.IdleTimeout, principal.AbsoluteExpiresAt)
+		if err := m.Queries.TouchSession(r.Context(), gen.TouchSessionParams{ID: principal.SessionID, ExpiresAt: next}); err == nil {
+			expiresAt = next
+		}
+	}
+
+	// A request that arrives with the previous token during the grace period
+	// must not rotate again; the client already has the new cookie in flight.
+	if m.RotateInterval <= 0 || !principal.IsCurrentToken || !auth.SessionRefreshDue(&principal.TokenRotatedAt, now, m.RotateInterval) {
+		return expiresAt
+	}
+	newToken, err := auth.GenerateToken()
+	if err != nil {
+		return expiresAt
+	}
+	rotated, err := m.Queries.RotateSessionToken(r.Context(), gen.RotateSessionTokenParams{
+		ID:               principal.SessionID,
+		CurrentTokenHash: auth.HashToken(token),
+		NewTokenHash:     auth.HashToken(newToken),
+	})
+	if err != nil || rotated != 1 {
+		return expiresAt
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     m.CookieName,
+		Value:    newToken,
+		Path:     "/",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+		Secure:   m.SecureCookies,
+		Expires:  principal.AbsoluteExpiresAt,
+	})
+	return expiresAt
+}
+
+// APITokenPrefix marks personal API tokens so they are recognisable in logs
+// and secret scanners.
+const APITokenPrefix = "mo_pat_"
+
+func bearerToken(r *http.Request) (string, bool) {
+	header := strings.TrimSpace(r.Header.Get("Authorization"))
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// serveAPIToken authenticates a personal API token. The token acts as its
+// user, limited to the intersection of the user's current permissions and the
+// token's scopes, and carries no CSRF token because it is never sent by a
+// browser automatically.
+func (m AuthMiddleware) serveAPIToken(w http.ResponseWriter, r *http.Request, next http.Handler, token string) {
+	principal, err := m.Queries.GetAPITokenPrincipalByHash(r.Context(), auth.HashToken(token))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			writeError(w, r, http.StatusUnauthorized, "unauthorized", "API token is invalid", nil)
+			return
+		}
+		writeError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load API token", nil)
+		return
+	}
+
+	permissions, err := m.Queries.ListUserPermissions(r.Context(), gen.ListUserPermissionsParams{
+		UserID:   principal.UserID,
+		TenantID: principal.TenantID,
+	})
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, "internal_error", "Failed to load permissions", nil)
+		return
+	}
+	userPermissions := NewPermissionSet(permissions)
+	if MFAEnrollmentRequired(principal.RequireMfaForSensitiveRoles, principal.MfaEnabled, userPermissions) {
+		writeError(w, r, http.StatusForbidden, "mfa_enrollment_required", "Set up two-factor authentication to continue", nil)
+		return
+	}
+	scoped := make(PermissionSet, len(principal.Scopes))
+	for _, scope := range principal.Scopes {
+		if userPermissions.Has(scope) {
+			scoped[scope] = struct{}{}
+		}
+	}
+
+	if auth.SessionRefreshDue(principal.LastUsedAt, time.Now(), m.TouchInterval) {
+		_ = m.Queries.TouchAPIToken(r.Context(), gen.TouchAPITokenParams{ID: principal.TokenID, LastUsedIp: ptrString(ClientIP(r))})
+	}
+
+	var expiresAt time.Time
+	if principal.ExpiresAt != nil {
+		expiresAt = *principal.ExpiresAt
+	}
+	ctx := WithActor(r.Context(), Actor{
+		APITokenID:  principal.TokenID.String(),
+		UserID:      principal.UserID.String(),
+		TenantID:    principal.TenantID.String(),
+		Email:       principal.Email,
+		FullName:    principal.FullName,
+		TenantSlug:  principal.TenantSlug,
+		TenantName:  principal.TenantName,
+		ExpiresAt:   expiresAt,
+		Permissions: scoped,
+		MFAEnabled:  principal.MfaEnabled,
+	})
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
+
+func ptrString(value string) *string {
+	return &value
+}
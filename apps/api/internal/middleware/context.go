@@ -2,11 +2,15 @@ package middleware
 
 import (
 	"context"
+	"sort"
 	"time"
 )
 
 type Actor struct {
-	SessionID  string
+	SessionID string
+	// APITokenID is set instead of SessionID when the request authenticated
+	// with a personal API token.
+	APITokenID string
 	UserID     string
 	TenantID   string
 	Email      string
@@ -15,6 +19,60 @@ type Actor struct {
 	TenantName string
 	CSRFToken  string
 	ExpiresAt  time.Time
+	// Loaded once by RequireAuth and checked in memory for the rest of the
+	// request.
+	Permissions PermissionSet
+	// MFAPending marks a half-authenticated session that has passed the
+	// password step but not the second factor.
+	MFAPending bool
+	MFAEnabled bool
+	// MFAEnrollmentRequired is set when the tenant requires MFA for the
+	// actor's permissions and the actor has not enrolled yet.
+	MFAEnrollmentRequired bool
+}
+
+// MFASensitivePermissions are the permissions a tenant can require MFA for.
+var MFASensitivePermissions = []string{"storage.write", "imports.write"}
+
+// MFAEnrollmentRequired reports whether a user with permissions must enroll
+// in MFA before using the rest of the API.
+func MFAEnrollmentRequired(tenantRequiresMFA, mfaEnabled bool, permissions PermissionSet) bool {
+	if !tenantRequiresMFA || mfaEnabled {
+		return false
+	}
+	for _, permission := range MFASensitivePermissions {
+		if permissions.Has(permission) {
+			return true
+		}
+	}
+	return false
+}
+
+// PermissionSet is the effective set of permission names granted to an actor
+// through all of their roles in the session tenant.
+type PermissionSet map[string]struct{}
+
+func NewPermissionSet(names []string) PermissionSet {
+	set := make(PermissionSet, len(names))
+	for _, name := range names {
+		set[name] = struct{}{}
+	}
+	return set
+}
+
+func (p PermissionSet) Has(name string) bool {
+	_, ok := p[name]
+	return ok
+}
+
+// Names returns the permission names in sorted order.
+func (p PermissionSet) Names() []string {
+	names := make([]string, 0, len(p))
+	for name := range p {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
 
 type contextKey string
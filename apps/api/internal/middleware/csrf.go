@@ -17,6 +17,12 @@ func EnforceCSRF(enabled bool) func(http.Handler) http.Handler {
 				writeError(w, r, http.StatusUnauthorized, "unauthorized", "Authentication required", nil)
 				return
 			}
+			// Bearer tokens are never attached by the browser on its own, so
+			// they cannot be used for cross-site request forgery.
+			if actor.APITokenID != "" {
+				next.ServeHTTP(w, r)
+				return
+			}
 			token := strings.TrimSpace(r.Header.Get("X-CSRF-Token"))
 			if token == "" || token != actor.CSRFToken {
 				writeError(w, r, http.StatusForbidden, "CSRF_INVALID", "Invalid CSRF token", nil)
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEnforceCSRFSkipsOnlyAPITokenActors(t *testing.T) {
+	handler := EnforceCSRF(true)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	cases := []struct {
+		name   string
+		actor  Actor
+		header string
+		want   int
+	}{
+		{name: "session without token", actor: Actor{SessionID: "s1", CSRFToken: "csrf"}, want: http.StatusForbidden},
+		{name: "session with token", actor: Actor{SessionID: "s1", CSRFToken: "csrf"}, header: "csrf", want: http.StatusNoContent},
+		{name: "api token", actor: Actor{APITokenID: "t1"}, want: http.StatusNoContent},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/customers", nil)
+			if tc.header != "" {
+				req.Header.Set("X-CSRF-Token", tc.header)
+			}
+			req = req.WithContext(WithActor(req.Context(), tc.actor))
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+			if rr.Code != tc.want {
+				t.Fatalf("expected status %d, got %d", tc.want, rr.Code)
+			}
+		})
+	}
+}
+
+func TestBearerToken(t *testing.T) {
+	cases := map[string]struct {
+		header string
+		want   string
+		ok     bool
+	}{
+		"bearer":      {header: "Bearer mo_pat_abc", want: "mo_pat_abc", ok: true},
+		"lower case":  {header: "bearer  mo_pat_abc ", want: "mo_pat_abc", ok: true},
+		"basic":       {header: "Basic dXNlcjpwYXNz", ok: false},
+		"empty token": {header: "Bearer ", ok: false},
+		"missing":     {header: "", ok: false},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/customers", nil)
+			if tc.header != "" {
+				req.Header.Set("Authorization", tc.header)
+			}
+			got, ok := bearerToken(req)
+			if ok != tc.ok || got != tc.want {
+				t.Fatalf("expected (%q, %v), got (%q, %v)", tc.want, tc.ok, got, ok)
+			}
+		})
+	}
+}
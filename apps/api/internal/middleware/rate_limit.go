@@ -144,3 +144,8 @@ func (rl *ipRateLimiter) enforceBound() {
 		delete(rl.attempt, oldestIP)
 	}
 }
+
+// ClientIP returns the request's remote host without the port.
+func ClientIP(r *http.Request) string {
+	return clientIP(r.RemoteAddr)
+}
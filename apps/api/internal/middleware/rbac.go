@@ -2,29 +2,16 @@ package middleware
 
 import (
 	"net/http"
-
-	"github.com/google/uuid"
-	gen "github.com/moveops-platform/apps/api/internal/gen/db"
 )
 
-func RequirePermission(queries *gen.Queries, permission string) func(http.Handler) http.Handler {
+func RequirePermission(permission string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			userID, tenantID, ok := actorIDsFromRequest(w, r)
+			actor, ok := actorFromRequest(w, r)
 			if !ok {
 				return
 			}
-
-			has, err := queries.UserHasPermission(r.Context(), gen.UserHasPermissionParams{
-				UserID:     userID,
-				TenantID:   tenantID,
-				Permission: permission,
-			})
-			if err != nil {
-				writeError(w, r, http.StatusInternalServerError, "internal_error", "Permission check failed", nil)
-				return
-			}
-			if !has {
+			if !actor.Permissions.Has(permission) {
 				writeError(w, r, http.StatusForbidden, "forbidden", "Permission denied", map[string]string{"permission": permission})
 				return
 			}
@@ -33,10 +20,10 @@ func RequirePermission(queries *gen.Queries, permission string) func(http.Handle
 	}
 }
 
-func RequireAnyPermission(queries *gen.Queries, permissions ...string) func(http.Handler) http.Handler {
+func RequireAnyPermission(permissions ...string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			userID, tenantID, ok := actorIDsFromRequest(w, r)
+			actor, ok := actorFromRequest(w, r)
 			if !ok {
 				return
 			}
@@ -46,16 +33,7 @@ func RequireAnyPermission(queries *gen.Queries, permissions ...string) func(http
 			}
 
 			for _, permission := range permissions {
-				has, err := queries.UserHasPermission(r.Context(), gen.UserHasPermissionParams{
-					UserID:     userID,
-					TenantID:   tenantID,
-					Permission: permission,
-				})
-				if err != nil {
-					writeError(w, r, http.StatusInternalServerError, "internal_error", "Permission check failed", nil)
-					return
-				}
-				if has {
+				if actor.Permissions.Has(permission) {
 					next.ServeHTTP(w, r)
 					return
 				}
@@ -66,22 +44,11 @@ func RequireAnyPermission(queries *gen.Queries, permissions ...string) func(http
 	}
 }
 
-func actorIDsFromRequest(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+func actorFromRequest(w http.ResponseWriter, r *http.Request) (Actor, bool) {
 	actor, ok := ActorFromContext(r.Context())
 	if !ok {
 		writeError(w, r, http.StatusUnauthorized, "unauthorized", "Authentication required", nil)
-		return uuid.Nil, uuid.Nil, false
-	}
-
-	userID, err := uuid.Parse(actor.UserID)
-	if err != nil {
-		writeError(w, r, http.StatusUnauthorized, "unauthorized", "Invalid actor", nil)
-		return uuid.Nil, uuid.Nil, false
-	}
-	tenantID, err := uuid.Parse(actor.TenantID)
-	if err != nil {
-		writeError(w, r, http.StatusUnauthorized, "unauthorized", "Invalid actor", nil)
-		return uuid.Nil, uuid.Nil, false
+		return Actor{}, false
 	}
-	return userID, tenantID, true
+	return actor, true
 }
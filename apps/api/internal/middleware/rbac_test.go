@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequirePermissionChecksActorPermissionSet(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	actor := Actor{Permissions: NewPermissionSet([]string{"jobs.read", "customers.read"})}
+
+	cases := []struct {
+		name     string
+		mw       func(http.Handler) http.Handler
+		withAuth bool
+		want     int
+	}{
+		{name: "granted", mw: RequirePermission("jobs.read"), withAuth: true, want: http.StatusOK},
+		{name: "denied", mw: RequirePermission("jobs.write"), withAuth: true, want: http.StatusForbidden},
+		{name: "any granted", mw: RequireAnyPermission("estimates.read", "customers.read"), withAuth: true, want: http.StatusOK},
+		{name: "any denied", mw: RequireAnyPermission("estimates.read", "storage.read"), withAuth: true, want: http.StatusForbidden},
+		{name: "no actor", mw: RequirePermission("jobs.read"), withAuth: false, want: http.StatusUnauthorized},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/jobs", nil)
+			if tc.withAuth {
+				req = req.WithContext(WithActor(req.Context(), actor))
+			}
+			rr := httptest.NewRecorder()
+			tc.mw(handler).ServeHTTP(rr, req)
+			if rr.Code != tc.want {
+				t.Fatalf("expected status %d, got %d", tc.want, rr.Code)
+			}
+		})
+	}
+}
+
+func TestPermissionSetNamesAreSorted(t *testing.T) {
+	names := NewPermissionSet([]string{"jobs.read", "customers.read", "jobs.read"}).Names()
+	if len(names) != 2 || names[0] != "customers.read" || names[1] != "jobs.read" {
+		t.Fatalf("unexpected permission names: %v", names)
+	}
+	if names := (PermissionSet)(nil).Names(); names == nil || len(names) != 0 {
+		t.Fatalf("expected empty non-nil names for nil set, got %#v", names)
+	}
+}
+
+func TestMFAEnrollmentRequiredOnlyForSensitivePermissions(t *testing.T) {
+	sensitive := NewPermissionSet([]string{"jobs.read", "storage.write"})
+	plain := NewPermissionSet([]string{"jobs.read"})
+
+	if !MFAEnrollmentRequired(true, false, sensitive) {
+		t.Fatal("expected enrollment to be required for storage.write without MFA")
+	}
+	if MFAEnrollmentRequired(true, true, sensitive) {
+		t.Fatal("expected no enrollment requirement once MFA is enabled")
+	}
+	if MFAEnrollmentRequired(true, false, plain) {
+		t.Fatal("expected no enrollment requirement without sensitive permissions")
+	}
+	if MFAEnrollmentRequired(false, false, sensitive) {
+		t.Fatal("expected no enrollment requirement when the tenant policy is off")
+	}
+}
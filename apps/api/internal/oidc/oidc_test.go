@@ -0,0 +1,108 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/moveops-platform/apps/api/internal/oidc/oidctest"
+)
+
+func TestAuthorizationCodeFlowWithPKCE(t *testing.T) {
+	idp := oidctest.NewServer("moveops", "s3cret")
+	defer idp.Close()
+	idp.SetIdentity(oidctest.Identity{Subject: "user-1", Email: "dispatch@example.com", EmailVerified: true, Name: "Dispatch", Groups: []string{"ops"}})
+
+	ctx := context.Background()
+	provider, err := Discover(ctx, http.DefaultClient, idp.Issuer())
+	if err != nil {
+		t.Fatalf("discover: %v", err)
+	}
+
+	const redirectURI = "http://app.test/callback"
+	verifier := "verifier-verifier-verifier-verifier-verifier"
+	authURL := provider.AuthCodeURL("moveops", redirectURI, "state-1", "nonce-1", PKCEChallenge(verifier))
+
+	noRedirect := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	res, err := noRedirect.Get(authURL)
+	if err != nil {
+		t.Fatalf("authorize: %v", err)
+	}
+	res.Body.Close()
+	callback, err := url.Parse(res.Header.Get("Location"))
+	if err != nil || callback.Query().Get("state") != "state-1" {
+		t.Fatalf("unexpected authorize redirect %q", res.Header.Get("Location"))
+	}
+	code := callback.Query().Get("code")
+
+	if _, err := provider.Exchange(ctx, http.DefaultClient, "moveops", "s3cret", code, redirectURI, "wrong-verifier"); err == nil {
+		t.Fatal("expected exchange with the wrong PKCE verifier to fail")
+	}
+
+	res, err = noRedirect.Get(authURL)
+	if err != nil {
+		t.Fatalf("authorize: %v", err)
+	}
+	res.Body.Close()
+	callback, _ = url.Parse(res.Header.Get("Location"))
+	idToken, err := provider.Exchange(ctx, http.DefaultClient, "moveops", "s3cret", callback.Query().Get("code"), redirectURI, verifier)
+	if err != nil {
+		t.Fatalf("exchange: %v", err)
+	}
+
+	claims, err := provider.VerifyIDToken(ctx, http.DefaultClient, idToken, "moveops", "nonce-1", time.Now())
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if claims.Subject != "user-1" || claims.Email != "dispatch@example.com" || !claims.EmailVerified {
+		t.Fatalf("unexpected claims: %+v", claims)
+	}
+	if groups := claims.StringList("groups"); len(groups) != 1 || groups[0] != "ops" {
+		t.Fatalf("unexpected groups: %v", groups)
+	}
+}
+
+func TestVerifyIDTokenRejectsBadClaims(t *testing.T) {
+	idp := oidctest.NewServer("moveops", "")
+	defer idp.Close()
+
+	ctx := context.Background()
+	provider, err := Discover(ctx, http.DefaultClient, idp.Issuer())
+	if err != nil {
+		t.Fatalf("discover: %v", err)
+	}
+	now := time.Now()
+	identity := oidctest.Identity{Subject: "user-1"}
+
+	cases := map[string]struct {
+		mutate func(map[string]any)
+		token  func(string) string
+		want   error
+	}{
+		"wrong nonce":    {mutate: func(c map[string]any) { c["nonce"] = "other" }, want: ErrNonce},
+		"wrong audience": {mutate: func(c map[string]any) { c["aud"] = "someone-else" }, want: ErrInvalidToken},
+		"wrong issuer":   {mutate: func(c map[string]any) { c["iss"] = "https://evil.example" }, want: ErrInvalidToken},
+		"expired":        {mutate: func(c map[string]any) { c["exp"] = now.Add(-time.Hour).Unix() }, want: ErrInvalidToken},
+		"tampered": {
+			mutate: func(map[string]any) {},
+			token:  func(raw string) string { return raw[:len(raw)-4] + "AAAA" },
+			want:   ErrInvalidToken,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			claims := idp.Claims(identity, "nonce-1", now)
+			tc.mutate(claims)
+			raw := idp.SignIDToken(claims)
+			if tc.token != nil {
+				raw = tc.token(raw)
+			}
+			if _, err := provider.VerifyIDToken(ctx, http.DefaultClient, raw, "moveops", "nonce-1", now); !errors.Is(err, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+// Package outbox delivers the email that requests queue in email_outbox.
+// Requests only insert rows, in the same transaction as the change that
+// triggered them; the worker sends them afterwards and retries failures, so
+// a slow or unavailable relay never fails or delays an API call.
+package outbox
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/moveops-platform/apps/api/internal/audit"
+	gen "github.com/moveops-platform/apps/api/internal/gen/db"
+	"github.com/moveops-platform/apps/api/internal/mail"
+)
+
+// Worker sends due messages. Any number of workers may run against the
+// same database: rows are claimed with SKIP LOCKED and leased, and a row
+// whose lease runs out, because its worker died mid-send, becomes due again.
+// The lease is renewed right before each send and every outcome is written
+// only for the attempt the worker claimed, so a message reclaimed by
+// another worker is neither sent twice by this one nor marked by it.
+type Worker struct {
+	q      *gen.Queries
+	sender mail.Sender
+	audit  *audit.Logger
+	logger *slog.Logger
+
+	// Interval is the pause between polls when nothing is due.
+	Interval time.Duration
+	// BatchSize is how many messages one poll claims.
+	BatchSize int
+	// MaxAttempts is how many deliveries a message gets before it fails.
+	MaxAttempts int
+	// Lease is how long a claimed message is reserved. It is renewed before
+	// each send, and sends are cut off before it runs out.
+	Lease time.Duration
+	// Backoff is the delay before retrying after the given attempt.
+	Backoff func(attempt int) time.Duration
+}
+
+func NewWorker(q *gen.Queries, sender mail.Sender, auditLogger *audit.Logger, logger *slog.Logger, maxAttempts int) *Worker {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	return &Worker{
+		q:           q,
+		sender:      sender,
+		audit:       auditLogger,
+		logger:      logger,
+		Interval:    15 * time.Second,
+		BatchSize:   20,
+		MaxAttempts: maxAttempts,
+		Lease:       2 * time.Minute,
+		Backoff:     DefaultBackoff,
+	}
+}
+
+// DefaultBackoff waits 1, 4, 16 and 64 minutes, then 4 hours between
+// attempts.
+func DefaultBackoff(attempt int) time.Duration {
+	delay := time.Minute
+	for i := 1; i < attempt && delay < 4*time.Hour; i++ {
+		delay *= 4
+	}
+	return min(delay, 4*time.Hour)
+}
+
+// Run polls until ctx is cancelled. A full batch is followed straight away
+// by the next one.
+func (w *Worker) Run(ctx context.Context) {
+	for {
+		claimed, err := w.ProcessOnce(ctx)
+		if err != nil && ctx.Err() == nil {
+			w.logger.Error("email outbox poll", "error", err)
+		}
+		if claimed >= w.BatchSize && err == nil {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(w.Interval):
+		}
+	}
+}
+
+// ProcessOnce claims the messages that are due and tries each once. It
+// returns how many it claimed.
+func (w *Worker) ProcessOnce(ctx context.Context) (int, error) {
+	claimed, err := w.q.ClaimDueEmails(ctx, gen.ClaimDueEmailsParams{
+		LeaseSeconds: int32(w.Lease / time.Second),
+		BatchSize:    int32(w.BatchSize),
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, msg := range claimed {
+		w.deliver(ctx, msg)
+	}
+	return len(claimed), nil
+}
+
+func (w *Worker) deliver(ctx context.Context, msg gen.EmailOutbox) {
+	attempt := int(msg.Attempts)
+	if attempt > w.MaxAttempts {
+		// The lease of the last allowed attempt ran out before it finished.
+		w.fail(ctx, msg, "delivery was interrupted on the last attempt")
+		return
+	}
+
+	// Earlier sends in the batch may have used up most of the claim's lease.
+	renewed, err := w.q.RenewEmailLease(ctx, gen.RenewEmailLeaseParams{
+		ID:           msg.ID,
+		LeaseSeconds: int32(w.Lease / time.Second),
+		Attempts:     msg.Attempts,
+	})
+	if err != nil {
+		w.logger.Error("renew email lease", "email_id", msg.ID, "error", err)
+		return
+	}
+	if renewed == 0 {
+		w.logger.Warn("email lease lost before sending", "email_id", msg.ID, "attempt", attempt)
+		return
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, w.Lease/2)
+	err = w.sender.Send(sendCtx, mail.Message{
+		ID:       msg.ID.String(),
+		From:     msg.FromAddress,
+		FromName: derefString(msg.FromName),
+		To:       msg.ToAddress,
+		ReplyTo:  derefString(msg.ReplyTo),
+		Subject:  msg.Subject,
+		Body:     msg.Body,
+	})
+	cancel()
+
+	switch {
+	case err == nil:
+		marked, err := w.q.MarkEmailSent(ctx, gen.MarkEmailSentParams{ID: msg.ID, Attempts: msg.Attempts})
+		if err != nil {
+			w.logger.Error("mark email sent", "email_id", msg.ID, "error", err)
+			return
+		}
+		if marked == 0 {
+			w.logger.Warn("email sent after its lease was lost", "email_id", msg.ID, "attempt", attempt)
+			return
+		}
+		w.log(ctx, msg, "email.sent", map[string]any{"attempts": attempt})
+	case mail.IsPermanent(err) || attempt >= w.MaxAttempts:
+		w.fail(ctx, msg, err.Error())
+	default:
+		lastError := err.Error()
+		nextAttemptAt := time.Now().Add(w.Backoff(attempt))
+		marked, err := w.q.MarkEmailRetry(ctx, gen.MarkEmailRetryParams{ID: msg.ID, LastError: &lastError, NextAttemptAt: nextAttemptAt, Attempts: msg.Attempts})
+		if err != nil {
+			w.logger.Error("mark email retry", "email_id", msg.ID, "error", err)
+			return
+		}
+		if marked == 0 {
+			return
+		}
+		w.logger.Warn("email delivery failed, will retry", "email_id", msg.ID, "attempt", attempt, "next_attempt_at", nextAttemptAt, "error", lastError)
+	}
+}
+
+func (w *Worker) fail(ctx context.Context, msg gen.EmailOutbox, reason string) {
+	marked, err := w.q.MarkEmailFailed(ctx, gen.MarkEmailFailedParams{ID: msg.ID, LastError: &reason, Attempts: msg.Attempts})
+	if err != nil {
+		w.logger.Error("mark email failed", "email_id", msg.ID, "error", err)
+		return
+	}
+	if marked == 0 {
+		return
+	}
+	w.log(ctx, msg, "email.failed", map[string]any{"attempts": msg.Attempts, "error": reason})
+}
+
+// log audits the outcome on the estimate, job or storage record the
+// message is about.
+func (w *Worker) log(ctx context.Context, msg gen.EmailOutbox, action string, metadata map[string]any) {
+	entityType, entityID := "storage_record", msg.StorageRecordID
+	switch {
+	case msg.EstimateID != nil:
+		entityType, entityID = "estimate", msg.EstimateID
+	case msg.JobID != nil:
+		entityType, entityID = "job", msg.JobID
+	}
+	metadata["emailId"] = msg.ID
+	metadata["kind"] = msg.Kind
+	metadata["to"] = msg.ToAddress
+	_ = w.audit.Log(ctx, audit.Entry{
+		TenantID:   msg.TenantID,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Metadata:   metadata,
+	})
+}
+
+func derefString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
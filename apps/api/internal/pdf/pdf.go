@@ -0,0 +1,327 @@
+// Package pdf writes simple PDF documents: text in the standard Helvetica
+// fonts, lines, filled rectangles and raster images. The fonts are the PDF
+// base fonts every viewer ships, so nothing is embedded and no external
+// tools are needed. Coordinates are in points from the top-left corner.
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/jpeg" // logo formats accepted by AddImage
+	_ "image/png"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// US Letter, in points.
+const (
+	PageWidth  = 612.0
+	PageHeight = 792.0
+)
+
+// MaxImagePixels bounds images passed to AddImage so a logo cannot blow up
+// the document size or the memory used to render it.
+const MaxImagePixels = 4_000_000
+
+// Font is one of the standard fonts.
+type Font int
+
+const (
+	Helvetica Font = iota
+	HelveticaBold
+)
+
+func (f Font) resourceName() string {
+	if f == HelveticaBold {
+		return "F2"
+	}
+	return "F1"
+}
+
+// Color is an RGB color.
+type Color struct {
+	R, G, B uint8
+}
+
+var (
+	Black = Color{}
+	White = Color{R: 255, G: 255, B: 255}
+)
+
+// ParseHexColor parses a #rrggbb color.
+func ParseHexColor(value string) (Color, error) {
+	if len(value) != 7 || value[0] != '#' {
+		return Color{}, fmt.Errorf("color %q is not in #rrggbb form", value)
+	}
+	rgb, err := strconv.ParseUint(value[1:], 16, 32)
+	if err != nil {
+		return Color{}, fmt.Errorf("color %q is not in #rrggbb form", value)
+	}
+	return Color{R: uint8(rgb >> 16), G: uint8(rgb >> 8), B: uint8(rgb)}, nil
+}
+
+func (c Color) operands() string {
+	return fmt.Sprintf("%s %s %s", num(float64(c.R)/255), num(float64(c.G)/255), num(float64(c.B)/255))
+}
+
+// Document is a PDF under construction.
+type Document struct {
+	title  string
+	pages  []*Page
+	images []*Image
+}
+
+// New starts an empty document. The title goes into the document info.
+func New(title string) *Document {
+	return &Document{title: title}
+}
+
+// Page is one page of a Document.
+type Page struct {
+	content bytes.Buffer
+	images  map[*Image]struct{}
+}
+
+// Image is a raster image added to a Document. It can be drawn on any page.
+type Image struct {
+	id            int // resource name suffix, Im1, Im2, ...
+	width, height int
+	data          []byte
+}
+
+// Width and Height are the image size in pixels.
+func (img *Image) Width() int  { return img.width }
+func (img *Image) Height() int { return img.height }
+
+// AddPage appends a blank page.
+func (d *Document) AddPage() *Page {
+	page := &Page{images: map[*Image]struct{}{}}
+	d.pages = append(d.pages, page)
+	return page
+}
+
+// PageCount is the number of pages added so far.
+func (d *Document) PageCount() int {
+	return len(d.pages)
+}
+
+// AddImage decodes a PNG or JPEG image for drawing. Transparent pixels are
+// flattened onto white.
+func (d *Document) AddImage(data []byte) (*Image, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("decode image: %w", err)
+	}
+	if config.Width < 1 || config.Height < 1 || config.Width*config.Height > MaxImagePixels {
+		return nil, errors.New("image dimensions are out of range")
+	}
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("decode image: %w", err)
+	}
+
+	bounds := decoded.Bounds()
+	rgb := make([]byte, 0, bounds.Dx()*bounds.Dy()*3)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := color.NRGBAModel.Convert(decoded.At(x, y)).(color.NRGBA)
+			rgb = append(rgb, overWhite(c.R, c.A), overWhite(c.G, c.A), overWhite(c.B, c.A))
+		}
+	}
+	compressed, err := deflate(rgb)
+	if err != nil {
+		return nil, err
+	}
+	img := &Image{id: len(d.images) + 1, width: bounds.Dx(), height: bounds.Dy(), data: compressed}
+	d.images = append(d.images, img)
+	return img, nil
+}
+
+func overWhite(channel, alpha uint8) uint8 {
+	return uint8((uint32(channel)*uint32(alpha) + 255*(255-uint32(alpha)) + 127) / 255)
+}
+
+// Text draws text with its baseline at y.
+func (p *Page) Text(x, y float64, font Font, size float64, c Color, text string) {
+	fmt.Fprintf(&p.content, "BT %s rg /%s %s Tf %s %s Td (%s) Tj ET\n",
+		c.operands(), font.resourceName(), num(size), num(x), num(PageHeight-y), encodeText(text))
+}
+
+// TextRight draws text ending at x.
+func (p *Page) TextRight(x, y float64, font Font, size float64, c Color, text string) {
+	p.Text(x-TextWidth(font, size, text), y, font, size, c, text)
+}
+
+// Line draws a straight line.
+func (p *Page) Line(x1, y1, x2, y2, width float64, c Color) {
+	fmt.Fprintf(&p.content, "%s RG %s w %s %s m %s %s l S\n",
+		c.operands(), num(width), num(x1), num(PageHeight-y1), num(x2), num(PageHeight-y2))
+}
+
+// Rect fills a rectangle whose top-left corner is at x, y.
+func (p *Page) Rect(x, y, width, height float64, c Color) {
+	fmt.Fprintf(&p.content, "%s rg %s %s %s %s re f\n",
+		c.operands(), num(x), num(PageHeight-y-height), num(width), num(height))
+}
+
+// Image draws img scaled to width by height with its top-left corner at x, y.
+func (p *Page) Image(img *Image, x, y, width, height float64) {
+	p.images[img] = struct{}{}
+	fmt.Fprintf(&p.content, "q %s 0 0 %s %s %s cm /Im%d Do Q\n",
+		num(width), num(height), num(x), num(PageHeight-y-height), img.id)
+}
+
+// Bytes serializes the document.
+func (d *Document) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := d.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteTo serializes the document to w.
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+
+	// Object numbers: 1 catalog, 2 page tree, 3 and 4 fonts, 5 info, then
+	// images, then a page object and its content stream per page.
+	const firstImageObject = 6
+	imageObject := func(img *Image) int { return firstImageObject + img.id - 1 }
+	firstPageObject := firstImageObject + len(d.images)
+	pageObject := func(i int) int { return firstPageObject + 2*i }
+
+	out := &countingWriter{}
+	offsets := []int{}
+	object := func(body string, stream []byte) {
+		offsets = append(offsets, out.n)
+		fmt.Fprintf(out, "%d 0 obj\n%s\n", len(offsets), body)
+		if stream != nil {
+			out.WriteString("stream\n")
+			out.Write(stream)
+			out.WriteString("\nendstream\n")
+		}
+		out.WriteString("endobj\n")
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>", nil)
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", pageObject(i))
+	}
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)), nil)
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>", nil)
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>", nil)
+	object(fmt.Sprintf("<< /Title (%s) /Producer (MoveOps) >>", encodeText(d.title)), nil)
+	for _, img := range d.images {
+		object(fmt.Sprintf("<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /FlateDecode /Length %d >>",
+			img.width, img.height, len(img.data)), img.data)
+	}
+	for i, page := range d.pages {
+		var xobjects []string
+		for _, img := range d.images {
+			if _, ok := page.images[img]; ok {
+				xobjects = append(xobjects, fmt.Sprintf("/Im%d %d 0 R", img.id, imageObject(img)))
+			}
+		}
+		resources := "/Font << /F1 3 0 R /F2 4 0 R >>"
+		if len(xobjects) > 0 {
+			resources += fmt.Sprintf(" /XObject << %s >>", strings.Join(xobjects, " "))
+		}
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << %s >> /Contents %d 0 R >>",
+			num(PageWidth), num(PageHeight), resources, pageObject(i)+1), nil)
+		content, err := deflate(page.content.Bytes())
+		if err != nil {
+			return 0, err
+		}
+		object(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>", len(content)), content)
+	}
+
+	xref := out.n
+	fmt.Fprintf(out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(out, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	n, err := w.Write(out.buf.Bytes())
+	return int64(n), err
+}
+
+type countingWriter struct {
+	buf bytes.Buffer
+	n   int
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.buf.Write(p)
+	c.n += n
+	return n, err
+}
+
+func (c *countingWriter) WriteString(s string) {
+	_, _ = c.Write([]byte(s))
+}
+
+func deflate(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// num formats a number the way PDF operators expect, without exponents.
+func num(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// winAnsiExtras maps the characters WinAnsiEncoding places in 0x80-0x9f.
+var winAnsiExtras = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87, 'ˆ': 0x88,
+	'‰': 0x89, 'Š': 0x8a, '‹': 0x8b, 'Œ': 0x8c, 'Ž': 0x8e, '‘': 0x91, '’': 0x92, '“': 0x93,
+	'”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '˜': 0x98, '™': 0x99, 'š': 0x9a, '›': 0x9b,
+	'œ': 0x9c, 'ž': 0x9e, 'Ÿ': 0x9f,
+}
+
+// winAnsi converts r to its WinAnsiEncoding byte. Characters the standard
+// fonts cannot show become '?'.
+func winAnsi(r rune) byte {
+	switch {
+	case r >= 0x20 && r < 0x7f, r >= 0xa0 && r <= 0xff:
+		return byte(r)
+	case r == '\t':
+		return ' '
+	}
+	if b, ok := winAnsiExtras[r]; ok {
+		return b
+	}
+	return '?'
+}
+
+// encodeText returns text as the body of a PDF literal string.
+func encodeText(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch c := winAnsi(r); c {
+		case '(', ')', '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
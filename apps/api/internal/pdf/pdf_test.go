@@ -0,0 +1,141 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDocumentCrossReferenceMatchesObjects(t *testing.T) {
+	doc := New("Estimate E-000001")
+	logo := testImage(t)
+	img, err := doc.AddImage(logo)
+	if err != nil {
+		t.Fatalf("add image: %v", err)
+	}
+	first := doc.AddPage()
+	first.Image(img, 40, 40, 80, 40)
+	first.Text(40, 120, HelveticaBold, 18, Black, "Estimate (draft)")
+	second := doc.AddPage()
+	second.Line(40, 60, 572, 60, 1, Color{R: 31, G: 78, B: 121})
+	second.Rect(40, 80, 100, 20, White)
+
+	out, err := doc.Bytes()
+	if err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !bytes.HasPrefix(out, []byte("%PDF-1.4")) || !bytes.HasSuffix(out, []byte("%%EOF\n")) {
+		t.Fatalf("missing PDF header or trailer")
+	}
+
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(out)
+	if startxref == nil {
+		t.Fatalf("missing startxref")
+	}
+	xrefOffset, _ := strconv.Atoi(string(startxref[1]))
+	if !bytes.HasPrefix(out[xrefOffset:], []byte("xref\n")) {
+		t.Fatalf("startxref does not point at the xref table")
+	}
+	entries := regexp.MustCompile(`(\d{10}) 00000 n \n`).FindAllSubmatch(out[xrefOffset:], -1)
+	// Catalog, pages, two fonts, info, one image and two objects per page.
+	if len(entries) != 10 {
+		t.Fatalf("expected 10 objects, got %d", len(entries))
+	}
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		if want := fmt.Sprintf("%d 0 obj\n", i+1); !bytes.HasPrefix(out[offset:], []byte(want)) {
+			t.Fatalf("xref entry %d points at %q", i+1, out[offset:offset+10])
+		}
+	}
+	if !bytes.Contains(out, []byte("/Count 2")) || !bytes.Contains(out, []byte("/XObject << /Im1 6 0 R >>")) {
+		t.Fatalf("expected two pages and the image on the first one")
+	}
+
+	content := pageContent(t, out, 8)
+	if !strings.Contains(content, "(Estimate \\(draft\\)) Tj") || !strings.Contains(content, "/Im1 Do") {
+		t.Fatalf("unexpected first page content %q", content)
+	}
+}
+
+func TestEncodeTextUsesWinAnsi(t *testing.T) {
+	got := encodeText("Café – 5\\6 “quoted” 日")
+	want := "Caf\xe9 \x96 5\\\\6 \x93quoted\x94 ?"
+	if got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
+
+func TestTextWidthAndWrap(t *testing.T) {
+	if got := TextWidth(Helvetica, 10, "Hi"); got != 9.44 {
+		t.Fatalf("expected Hi to be 9.44pt wide, got %v", got)
+	}
+	if TextWidth(HelveticaBold, 10, "~") == 0 || TextWidth(Helvetica, 10, "~") == 0 {
+		t.Fatalf("expected widths for the whole ASCII range")
+	}
+
+	lines := Wrap(Helvetica, 10, 64, "Deposit is due at booking.\n\nThanks")
+	want := []string{"Deposit is due", "at booking.", "", "Thanks"}
+	if strings.Join(lines, "|") != strings.Join(want, "|") {
+		t.Fatalf("expected %q, got %q", want, lines)
+	}
+	for _, line := range Wrap(Helvetica, 10, 30, "Supercalifragilistic") {
+		if TextWidth(Helvetica, 10, line) > 30 {
+			t.Fatalf("line %q is wider than the limit", line)
+		}
+	}
+}
+
+func TestParseHexColor(t *testing.T) {
+	c, err := ParseHexColor("#1F4e79")
+	if err != nil || c != (Color{R: 0x1f, G: 0x4e, B: 0x79}) {
+		t.Fatalf("unexpected color %+v (%v)", c, err)
+	}
+	for _, bad := range []string{"1f4e79", "#1f4e7", "#zzzzzz"} {
+		if _, err := ParseHexColor(bad); err == nil {
+			t.Fatalf("expected %q to be rejected", bad)
+		}
+	}
+}
+
+func testImage(t *testing.T) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 2))
+	for x := 0; x < 4; x++ {
+		img.Set(x, 0, color.NRGBA{R: 200, A: 255})
+		img.Set(x, 1, color.NRGBA{B: 200, A: 0})
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// pageContent inflates the content stream in object number.
+func pageContent(t *testing.T, out []byte, number int) string {
+	t.Helper()
+	start := bytes.Index(out, []byte(fmt.Sprintf("\n%d 0 obj\n", number)))
+	if start < 0 {
+		t.Fatalf("object %d not found", number)
+	}
+	rest := out[start:]
+	streamStart := bytes.Index(rest, []byte("stream\n")) + len("stream\n")
+	streamEnd := bytes.Index(rest, []byte("\nendstream"))
+	zr, err := zlib.NewReader(bytes.NewReader(rest[streamStart:streamEnd]))
+	if err != nil {
+		t.Fatalf("inflate: %v", err)
+	}
+	content, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("inflate: %v", err)
+	}
+	return string(content)
+}
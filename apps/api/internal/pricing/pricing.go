@@ -0,0 +1,229 @@
+// Package pricing computes estimate prices from a tenant tariff. It is pure:
+// callers load the tariff and the estimate's volume and store the quote.
+package pricing
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Pricing modes. Moves shorter than the tariff's long-distance threshold are
+// billed by crew hours, longer ones by weight and distance.
+const (
+	ModeLocal        = "local"
+	ModeLongDistance = "long_distance"
+)
+
+// Line kinds in a Quote.
+const (
+	LineLabor            = "labor"
+	LineLinehaulWeight   = "linehaul_weight"
+	LineLinehaulDistance = "linehaul_distance"
+	LineMinimumCharge    = "minimum_charge"
+	LineFuelSurcharge    = "fuel_surcharge"
+	LineAccessorial      = "accessorial"
+	LineValuation        = "valuation"
+)
+
+// Tariff is a tenant's rate table. Amounts are in cents.
+type Tariff struct {
+	MinimumHours               int
+	MinimumChargeCents         int64
+	LongDistanceThresholdMiles int
+	PerMileCents               int64
+	PerCwtCents                int64
+	// FuelSurchargeBps is applied to the transportation charge (labor or
+	// linehaul, after the minimum) in basis points.
+	FuelSurchargeBps int
+	CrewRates        map[int]int64
+	Accessorials     map[string]Accessorial
+	Valuations       map[string]Valuation
+}
+
+// Accessorial is an extra service charged per unit, such as a flight of
+// stairs or a piano.
+type Accessorial struct {
+	Name      string
+	Unit      string
+	RateCents int64
+}
+
+// Valuation is a liability coverage option. CentsPerThousand is charged per
+// $1,000 of declared value; MinimumCentsPerLb sets the lowest value that may
+// be declared for the shipment's weight.
+type Valuation struct {
+	Name              string
+	CentsPerThousand  int64
+	MinimumCentsPerLb int64
+}
+
+// Request describes the move being priced.
+type Request struct {
+	WeightLbs          int
+	Miles              int
+	CrewSize           int
+	LaborMinutes       int
+	Accessorials       []AccessorialQuantity
+	ValuationCode      string
+	DeclaredValueCents int64
+}
+
+// AccessorialQuantity asks for Quantity units of the accessorial Code.
+type AccessorialQuantity struct {
+	Code     string
+	Quantity int
+}
+
+// Line is one charge in a Quote.
+type Line struct {
+	Kind            string `json:"kind"`
+	Code            string `json:"code,omitempty"`
+	Description     string `json:"description"`
+	Quantity        int64  `json:"quantity"`
+	UnitAmountCents int64  `json:"unitAmountCents"`
+	AmountCents     int64  `json:"amountCents"`
+}
+
+// Quote is a priced estimate. It is stored on the estimate as its price
+// breakdown, so the JSON names are part of the API.
+type Quote struct {
+	Mode               string `json:"mode"`
+	WeightLbs          int    `json:"weightLbs"`
+	Miles              int    `json:"miles"`
+	CrewSize           int    `json:"crewSize,omitempty"`
+	LaborMinutes       int    `json:"laborMinutes,omitempty"`
+	ValuationCode      string `json:"valuationCode,omitempty"`
+	DeclaredValueCents int64  `json:"declaredValueCents,omitempty"`
+	Lines              []Line `json:"lines"`
+	TotalCents         int64  `json:"totalCents"`
+}
+
+// Error is a request the tariff cannot price. Code is stable for API errors.
+type Error struct {
+	Code    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Price quotes req against t.
+func Price(t Tariff, req Request) (Quote, error) {
+	if req.Miles < 0 || req.WeightLbs < 0 || req.LaborMinutes < 0 || req.DeclaredValueCents < 0 {
+		return Quote{}, &Error{Code: "invalid_pricing_input", Message: "Pricing inputs cannot be negative"}
+	}
+
+	quote := Quote{WeightLbs: req.WeightLbs, Miles: req.Miles, Lines: []Line{}}
+	var transportation int64
+	if t.LongDistanceThresholdMiles > 0 && req.Miles >= t.LongDistanceThresholdMiles {
+		quote.Mode = ModeLongDistance
+		if req.WeightLbs == 0 {
+			return Quote{}, &Error{Code: "weight_required", Message: "Long-distance moves are priced by weight; add inventory or a weight"}
+		}
+		cwt := int64((req.WeightLbs + 99) / 100)
+		quote.Lines = append(quote.Lines,
+			Line{Kind: LineLinehaulWeight, Description: "Linehaul by weight (per 100 lbs)", Quantity: cwt, UnitAmountCents: t.PerCwtCents, AmountCents: cwt * t.PerCwtCents},
+			Line{Kind: LineLinehaulDistance, Description: "Linehaul by distance (per mile)", Quantity: int64(req.Miles), UnitAmountCents: t.PerMileCents, AmountCents: int64(req.Miles) * t.PerMileCents},
+		)
+		transportation = cwt*t.PerCwtCents + int64(req.Miles)*t.PerMileCents
+	} else {
+		quote.Mode = ModeLocal
+		rate, ok := t.CrewRates[req.CrewSize]
+		if !ok {
+			return Quote{}, &Error{Code: "crew_rate_missing", Message: fmt.Sprintf("The tariff has no hourly rate for a crew of %d", req.CrewSize)}
+		}
+		if req.LaborMinutes == 0 {
+			return Quote{}, &Error{Code: "labor_hours_required", Message: "Local moves are priced by the hour; laborHours is required"}
+		}
+		minutes := max(req.LaborMinutes, t.MinimumHours*60)
+		quote.CrewSize = req.CrewSize
+		quote.LaborMinutes = minutes
+		transportation = roundDiv(rate*int64(minutes), 60)
+		quote.Lines = append(quote.Lines, Line{
+			Kind:            LineLabor,
+			Description:     fmt.Sprintf("%d-person crew, %s", req.CrewSize, formatMinutes(minutes)),
+			Quantity:        int64(minutes),
+			UnitAmountCents: rate,
+			AmountCents:     transportation,
+		})
+	}
+
+	if transportation < t.MinimumChargeCents {
+		adjustment := t.MinimumChargeCents - transportation
+		quote.Lines = append(quote.Lines, Line{Kind: LineMinimumCharge, Description: "Minimum charge adjustment", Quantity: 1, UnitAmountCents: adjustment, AmountCents: adjustment})
+		transportation = t.MinimumChargeCents
+	}
+	if t.FuelSurchargeBps > 0 {
+		surcharge := roundDiv(transportation*int64(t.FuelSurchargeBps), 10000)
+		quote.Lines = append(quote.Lines, Line{Kind: LineFuelSurcharge, Description: fmt.Sprintf("Fuel surcharge (%s)", formatBps(t.FuelSurchargeBps)), Quantity: 1, UnitAmountCents: surcharge, AmountCents: surcharge})
+	}
+
+	accessorials, err := mergeAccessorials(req.Accessorials)
+	if err != nil {
+		return Quote{}, err
+	}
+	for _, requested := range accessorials {
+		accessorial, ok := t.Accessorials[requested.Code]
+		if !ok {
+			return Quote{}, &Error{Code: "unknown_accessorial", Message: fmt.Sprintf("The tariff has no accessorial %q", requested.Code)}
+		}
+		quantity := int64(requested.Quantity)
+		quote.Lines = append(quote.Lines, Line{Kind: LineAccessorial, Code: requested.Code, Description: accessorial.Name, Quantity: quantity, UnitAmountCents: accessorial.RateCents, AmountCents: quantity * accessorial.RateCents})
+	}
+
+	if req.ValuationCode != "" {
+		valuation, ok := t.Valuations[req.ValuationCode]
+		if !ok {
+			return Quote{}, &Error{Code: "unknown_valuation", Message: fmt.Sprintf("The tariff has no valuation option %q", req.ValuationCode)}
+		}
+		declared := max(req.DeclaredValueCents, int64(req.WeightLbs)*valuation.MinimumCentsPerLb)
+		// Charged per started $1,000 of declared value.
+		thousands := (declared + 99999) / 100000
+		quote.ValuationCode = req.ValuationCode
+		quote.DeclaredValueCents = declared
+		quote.Lines = append(quote.Lines, Line{Kind: LineValuation, Code: req.ValuationCode, Description: valuation.Name, Quantity: thousands, UnitAmountCents: valuation.CentsPerThousand, AmountCents: thousands * valuation.CentsPerThousand})
+	}
+
+	for _, line := range quote.Lines {
+		quote.TotalCents += line.AmountCents
+	}
+	return quote, nil
+}
+
+// mergeAccessorials adds up repeated codes and orders them by code so the
+// same request always gives the same breakdown.
+func mergeAccessorials(items []AccessorialQuantity) ([]AccessorialQuantity, error) {
+	quantities := map[string]int{}
+	for _, item := range items {
+		if item.Quantity < 1 {
+			return nil, &Error{Code: "invalid_pricing_input", Message: "Accessorial quantities must be at least 1"}
+		}
+		quantities[item.Code] += item.Quantity
+	}
+	merged := make([]AccessorialQuantity, 0, len(quantities))
+	for code, quantity := range quantities {
+		merged = append(merged, AccessorialQuantity{Code: code, Quantity: quantity})
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].Code < merged[j].Code })
+	return merged, nil
+}
+
+// roundDiv divides non-negative n by d, rounding halves up.
+func roundDiv(n, d int64) int64 {
+	return (n + d/2) / d
+}
+
+func formatMinutes(minutes int) string {
+	if minutes%60 == 0 {
+		return fmt.Sprintf("%d h", minutes/60)
+	}
+	return fmt.Sprintf("%d h %02d min", minutes/60, minutes%60)
+}
+
+func formatBps(bps int) string {
+	if bps%100 == 0 {
+		return fmt.Sprintf("%d%%", bps/100)
+	}
+	return fmt.Sprintf("%d.%02d%%", bps/100, bps%100)
+}
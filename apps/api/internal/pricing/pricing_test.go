@@ -0,0 +1,134 @@
+package pricing
+
+import (
+	"errors"
+	"testing"
+)
+
+func testTariff() Tariff {
+	return Tariff{
+		MinimumHours:               3,
+		MinimumChargeCents:         50000,
+		LongDistanceThresholdMiles: 100,
+		PerMileCents:               150,
+		PerCwtCents:                4500,
+		FuelSurchargeBps:           850,
+		CrewRates:                  map[int]int64{2: 14000, 3: 18500},
+		Accessorials: map[string]Accessorial{
+			"stairs": {Name: "Stairs", Unit: "flight", RateCents: 7500},
+			"piano":  {Name: "Piano", Unit: "each", RateCents: 25000},
+		},
+		Valuations: map[string]Valuation{
+			"released":   {Name: "Released value"},
+			"full_value": {Name: "Full value protection", CentsPerThousand: 1000, MinimumCentsPerLb: 600},
+		},
+	}
+}
+
+func TestPriceLocalAppliesMinimumHoursAndSurcharge(t *testing.T) {
+	quote, err := Price(testTariff(), Request{
+		CrewSize:     3,
+		LaborMinutes: 150,
+		Miles:        12,
+		Accessorials: []AccessorialQuantity{{Code: "stairs", Quantity: 1}, {Code: "stairs", Quantity: 1}},
+	})
+	if err != nil {
+		t.Fatalf("price: %v", err)
+	}
+	if quote.Mode != ModeLocal || quote.LaborMinutes != 180 {
+		t.Fatalf("expected local quote billed for the 3 hour minimum, got %s/%d", quote.Mode, quote.LaborMinutes)
+	}
+	// Labor 3 h x $185 = $555, fuel 8.5% = $47.18 (rounded), stairs 2 x $75.
+	wantLines := []struct {
+		kind   string
+		amount int64
+	}{
+		{LineLabor, 55500},
+		{LineFuelSurcharge, 4718},
+		{LineAccessorial, 15000},
+	}
+	if len(quote.Lines) != len(wantLines) {
+		t.Fatalf("expected %d lines, got %+v", len(wantLines), quote.Lines)
+	}
+	for i, want := range wantLines {
+		if quote.Lines[i].Kind != want.kind || quote.Lines[i].AmountCents != want.amount {
+			t.Fatalf("line %d: expected %s %d, got %+v", i, want.kind, want.amount, quote.Lines[i])
+		}
+	}
+	if quote.TotalCents != 55500+4718+15000 {
+		t.Fatalf("unexpected total %d", quote.TotalCents)
+	}
+}
+
+func TestPriceLocalRaisesToMinimumCharge(t *testing.T) {
+	tariff := testTariff()
+	tariff.MinimumHours = 0
+	tariff.FuelSurchargeBps = 0
+	quote, err := Price(tariff, Request{CrewSize: 2, LaborMinutes: 60})
+	if err != nil {
+		t.Fatalf("price: %v", err)
+	}
+	if len(quote.Lines) != 2 || quote.Lines[1].Kind != LineMinimumCharge || quote.Lines[1].AmountCents != 36000 {
+		t.Fatalf("expected a minimum charge adjustment of 36000, got %+v", quote.Lines)
+	}
+	if quote.TotalCents != 50000 {
+		t.Fatalf("expected total at the minimum charge, got %d", quote.TotalCents)
+	}
+}
+
+func TestPriceLongDistanceUsesWeightDistanceAndValuation(t *testing.T) {
+	quote, err := Price(testTariff(), Request{
+		WeightLbs:          4210,
+		Miles:              300,
+		ValuationCode:      "full_value",
+		DeclaredValueCents: 1000000,
+	})
+	if err != nil {
+		t.Fatalf("price: %v", err)
+	}
+	if quote.Mode != ModeLongDistance {
+		t.Fatalf("expected long-distance mode, got %s", quote.Mode)
+	}
+	// 43 cwt x $45 = $1,935, 300 mi x $1.50 = $450, fuel 8.5% of $2,385.
+	// Declared value is raised to 4,210 lbs x $6 = $25,260, so 26 x $10.
+	expected := map[string]int64{
+		LineLinehaulWeight:   193500,
+		LineLinehaulDistance: 45000,
+		LineFuelSurcharge:    20273,
+		LineValuation:        26000,
+	}
+	for _, line := range quote.Lines {
+		if want, ok := expected[line.Kind]; !ok || want != line.AmountCents {
+			t.Fatalf("unexpected line %+v", line)
+		}
+	}
+	if quote.DeclaredValueCents != 2526000 {
+		t.Fatalf("expected declared value raised to the weight minimum, got %d", quote.DeclaredValueCents)
+	}
+	if quote.TotalCents != 193500+45000+20273+26000 {
+		t.Fatalf("unexpected total %d", quote.TotalCents)
+	}
+}
+
+func TestPriceRejectsInputsTheTariffCannotPrice(t *testing.T) {
+	cases := []struct {
+		name string
+		req  Request
+		code string
+	}{
+		{"missing crew rate", Request{CrewSize: 5, LaborMinutes: 60}, "crew_rate_missing"},
+		{"missing hours", Request{CrewSize: 2}, "labor_hours_required"},
+		{"long distance without weight", Request{Miles: 500}, "weight_required"},
+		{"unknown accessorial", Request{CrewSize: 2, LaborMinutes: 60, Accessorials: []AccessorialQuantity{{Code: "hot_tub", Quantity: 1}}}, "unknown_accessorial"},
+		{"zero accessorial quantity", Request{CrewSize: 2, LaborMinutes: 60, Accessorials: []AccessorialQuantity{{Code: "piano", Quantity: 0}}}, "invalid_pricing_input"},
+		{"unknown valuation", Request{CrewSize: 2, LaborMinutes: 60, ValuationCode: "gold"}, "unknown_valuation"},
+		{"negative miles", Request{CrewSize: 2, LaborMinutes: 60, Miles: -1}, "invalid_pricing_input"},
+	}
+	for _, tc := range cases {
+		_, err := Price(testTariff(), tc.req)
+		var pricingErr *Error
+		if !errors.As(err, &pricingErr) || pricingErr.Code != tc.code {
+			t.Fatalf("%s: expected %s, got %v", tc.name, tc.code, err)
+		}
+	}
+}
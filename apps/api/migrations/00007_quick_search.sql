@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX estimates_number_trgm_idx ON estimates USING gin (estimate_number gin_trgm_ops);
+CREATE INDEX estimates_customer_name_trgm_idx ON estimates USING gin (customer_name gin_trgm_ops);
+CREATE INDEX estimates_email_trgm_idx ON estimates USING gin (email gin_trgm_ops);
+CREATE INDEX estimates_origin_city_trgm_idx ON estimates USING gin (origin_city gin_trgm_ops);
+CREATE INDEX estimates_destination_city_trgm_idx ON estimates USING gin (destination_city gin_trgm_ops);
+CREATE INDEX jobs_number_trgm_idx ON jobs USING gin (job_number gin_trgm_ops);
+CREATE INDEX customers_full_name_trgm_idx ON customers USING gin ((first_name || ' ' || last_name) gin_trgm_ops);
+CREATE INDEX customers_email_trgm_idx ON customers USING gin (email gin_trgm_ops);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS customers_email_trgm_idx;
+DROP INDEX IF EXISTS customers_full_name_trgm_idx;
+DROP INDEX IF EXISTS jobs_number_trgm_idx;
+DROP INDEX IF EXISTS estimates_destination_city_trgm_idx;
+DROP INDEX IF EXISTS estimates_origin_city_trgm_idx;
+DROP INDEX IF EXISTS estimates_email_trgm_idx;
+DROP INDEX IF EXISTS estimates_customer_name_trgm_idx;
+DROP INDEX IF EXISTS estimates_number_trgm_idx;
+-- +goose StatementEnd
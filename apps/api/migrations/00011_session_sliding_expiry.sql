@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE sessions
+    ADD COLUMN absolute_expires_at TIMESTAMPTZ,
+    ADD COLUMN token_rotated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ADD COLUMN previous_token_hash TEXT;
+
+UPDATE sessions
+SET absolute_expires_at = expires_at,
+    token_rotated_at = created_at;
+
+ALTER TABLE sessions
+    ALTER COLUMN absolute_expires_at SET NOT NULL;
+
+CREATE INDEX sessions_previous_token_idx ON sessions (previous_token_hash)
+    WHERE revoked_at IS NULL AND previous_token_hash IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS sessions_previous_token_idx;
+
+ALTER TABLE sessions
+    DROP COLUMN IF EXISTS previous_token_hash,
+    DROP COLUMN IF EXISTS token_rotated_at,
+    DROP COLUMN IF EXISTS absolute_expires_at;
+-- +goose StatementEnd
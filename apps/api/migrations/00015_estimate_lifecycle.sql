@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE estimates DROP CONSTRAINT IF EXISTS estimates_status_check;
+ALTER TABLE estimates
+    ADD CONSTRAINT estimates_status_check CHECK (status IN ('draft', 'sent', 'accepted', 'declined', 'expired', 'converted')),
+    ADD COLUMN lost_reason TEXT,
+    ADD COLUMN sent_at TIMESTAMPTZ,
+    ADD COLUMN expires_at TIMESTAMPTZ,
+    ADD COLUMN status_changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+UPDATE estimates SET status_changed_at = updated_at;
+CREATE INDEX estimates_tenant_sent_expiry_idx ON estimates (tenant_id, expires_at) WHERE status = 'sent';
+
+ALTER TABLE tenant_settings
+    ADD COLUMN estimate_validity_days INT NOT NULL DEFAULT 30 CHECK (estimate_validity_days BETWEEN 1 AND 365);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE tenant_settings DROP COLUMN IF EXISTS estimate_validity_days;
+
+DROP INDEX IF EXISTS estimates_tenant_sent_expiry_idx;
+UPDATE estimates SET status = 'draft' WHERE status NOT IN ('draft', 'converted');
+ALTER TABLE estimates DROP CONSTRAINT IF EXISTS estimates_status_check;
+ALTER TABLE estimates
+    ADD CONSTRAINT estimates_status_check CHECK (status IN ('draft', 'converted')),
+    DROP COLUMN IF EXISTS status_changed_at,
+    DROP COLUMN IF EXISTS expires_at,
+    DROP COLUMN IF EXISTS sent_at,
+    DROP COLUMN IF EXISTS lost_reason;
+-- +goose StatementEnd
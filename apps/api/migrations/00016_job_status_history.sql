@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE jobs DROP CONSTRAINT IF EXISTS jobs_status_check;
+ALTER TABLE jobs
+    ADD CONSTRAINT jobs_status_check CHECK (status IN ('booked', 'scheduled', 'in_progress', 'on_hold', 'completed', 'cancelled'));
+
+CREATE TABLE job_status_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    job_id UUID NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    from_status TEXT,
+    to_status TEXT NOT NULL,
+    reason TEXT,
+    changed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX job_status_history_job_idx ON job_status_history (tenant_id, job_id, changed_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS job_status_history;
+UPDATE jobs SET status = 'scheduled' WHERE status IN ('in_progress', 'on_hold');
+ALTER TABLE jobs DROP CONSTRAINT IF EXISTS jobs_status_check;
+ALTER TABLE jobs
+    ADD CONSTRAINT jobs_status_check CHECK (status IN ('booked', 'scheduled', 'completed', 'cancelled'));
+-- +goose StatementEnd
@@ -0,0 +1,47 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE inventory_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    default_room TEXT,
+    cubic_feet INT NOT NULL CHECK (cubic_feet >= 0),
+    weight_lbs INT NOT NULL CHECK (weight_lbs >= 0),
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by UUID REFERENCES users(id),
+    updated_by UUID REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE UNIQUE INDEX inventory_items_tenant_name_key ON inventory_items (tenant_id, lower(name));
+
+CREATE TABLE estimate_line_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    estimate_id UUID NOT NULL REFERENCES estimates(id) ON DELETE CASCADE,
+    inventory_item_id UUID REFERENCES inventory_items(id) ON DELETE SET NULL,
+    room TEXT NOT NULL,
+    item_name TEXT NOT NULL,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    cubic_feet INT NOT NULL CHECK (cubic_feet >= 0),
+    weight_lbs INT NOT NULL CHECK (weight_lbs >= 0),
+    created_by UUID REFERENCES users(id),
+    updated_by UUID REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX estimate_line_items_estimate_idx ON estimate_line_items (tenant_id, estimate_id, created_at);
+
+ALTER TABLE estimates
+    ADD COLUMN total_cubic_feet INT NOT NULL DEFAULT 0,
+    ADD COLUMN total_weight_lbs INT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE estimates
+    DROP COLUMN IF EXISTS total_weight_lbs,
+    DROP COLUMN IF EXISTS total_cubic_feet;
+DROP TABLE IF EXISTS estimate_line_items;
+DROP TABLE IF EXISTS inventory_items;
+-- +goose StatementEnd
@@ -0,0 +1,57 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE tariffs (
+    tenant_id UUID PRIMARY KEY REFERENCES tenants(id) ON DELETE CASCADE,
+    minimum_hours INT NOT NULL DEFAULT 0 CHECK (minimum_hours BETWEEN 0 AND 24),
+    minimum_charge_cents BIGINT NOT NULL DEFAULT 0 CHECK (minimum_charge_cents >= 0),
+    long_distance_threshold_miles INT NOT NULL DEFAULT 0 CHECK (long_distance_threshold_miles >= 0),
+    per_mile_cents BIGINT NOT NULL DEFAULT 0 CHECK (per_mile_cents >= 0),
+    per_cwt_cents BIGINT NOT NULL DEFAULT 0 CHECK (per_cwt_cents >= 0),
+    fuel_surcharge_bps INT NOT NULL DEFAULT 0 CHECK (fuel_surcharge_bps BETWEEN 0 AND 10000),
+    updated_by UUID REFERENCES users(id),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE tariff_crew_rates (
+    tenant_id UUID NOT NULL REFERENCES tariffs(tenant_id) ON DELETE CASCADE,
+    crew_size INT NOT NULL CHECK (crew_size BETWEEN 1 AND 20),
+    hourly_rate_cents BIGINT NOT NULL CHECK (hourly_rate_cents >= 0),
+    PRIMARY KEY (tenant_id, crew_size)
+);
+
+CREATE TABLE tariff_accessorials (
+    tenant_id UUID NOT NULL REFERENCES tariffs(tenant_id) ON DELETE CASCADE,
+    code TEXT NOT NULL,
+    name TEXT NOT NULL,
+    unit TEXT NOT NULL,
+    rate_cents BIGINT NOT NULL CHECK (rate_cents >= 0),
+    PRIMARY KEY (tenant_id, code)
+);
+
+CREATE TABLE tariff_valuation_options (
+    tenant_id UUID NOT NULL REFERENCES tariffs(tenant_id) ON DELETE CASCADE,
+    code TEXT NOT NULL,
+    name TEXT NOT NULL,
+    cents_per_thousand BIGINT NOT NULL CHECK (cents_per_thousand >= 0),
+    minimum_cents_per_lb BIGINT NOT NULL DEFAULT 0 CHECK (minimum_cents_per_lb >= 0),
+    PRIMARY KEY (tenant_id, code)
+);
+
+ALTER TABLE estimates
+    ADD COLUMN price_source TEXT CHECK (price_source IN ('manual', 'tariff')),
+    ADD COLUMN price_breakdown JSONB,
+    ADD COLUMN priced_at TIMESTAMPTZ;
+UPDATE estimates SET price_source = 'manual' WHERE estimated_total_cents IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE estimates
+    DROP COLUMN IF EXISTS priced_at,
+    DROP COLUMN IF EXISTS price_breakdown,
+    DROP COLUMN IF EXISTS price_source;
+DROP TABLE IF EXISTS tariff_valuation_options;
+DROP TABLE IF EXISTS tariff_accessorials;
+DROP TABLE IF EXISTS tariff_crew_rates;
+DROP TABLE IF EXISTS tariffs;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE estimate_revisions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    estimate_id UUID NOT NULL REFERENCES estimates(id) ON DELETE CASCADE,
+    revision_number INT NOT NULL CHECK (revision_number > 0),
+    change TEXT NOT NULL CHECK (change IN ('created', 'updated', 'status_changed', 'line_items', 'priced', 'converted', 'expired', 'imported', 'restored')),
+    restored_from INT,
+    snapshot JSONB NOT NULL,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT estimate_revisions_estimate_number_key UNIQUE (estimate_id, revision_number)
+);
+CREATE INDEX estimate_revisions_tenant_estimate_idx ON estimate_revisions (tenant_id, estimate_id, revision_number);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS estimate_revisions;
+-- +goose StatementEnd
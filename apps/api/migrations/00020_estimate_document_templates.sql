@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE estimate_document_templates (
+    tenant_id UUID PRIMARY KEY REFERENCES tenants(id) ON DELETE CASCADE,
+    company_name TEXT,
+    accent_color TEXT NOT NULL DEFAULT '#1f4e79' CHECK (accent_color ~ '^#[0-9a-f]{6}$'),
+    logo BYTEA,
+    logo_content_type TEXT CHECK (logo_content_type IN ('image/png', 'image/jpeg')),
+    intro_text TEXT,
+    terms_text TEXT,
+    footer_text TEXT,
+    show_line_items BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_by UUID REFERENCES users(id),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK ((logo IS NULL) = (logo_content_type IS NULL))
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS estimate_document_templates;
+-- +goose StatementEnd
@@ -0,0 +1,57 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE tenant_email_settings (
+    tenant_id UUID PRIMARY KEY REFERENCES tenants(id) ON DELETE CASCADE,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    from_name TEXT,
+    from_address TEXT,
+    reply_to TEXT,
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE email_templates (
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL CHECK (kind IN ('estimate_sent', 'booking_confirmation', 'storage_invoice')),
+    subject TEXT NOT NULL,
+    body TEXT NOT NULL,
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, kind)
+);
+
+CREATE TABLE email_outbox (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL CHECK (kind IN ('estimate_sent', 'booking_confirmation', 'storage_invoice')),
+    estimate_id UUID REFERENCES estimates(id) ON DELETE CASCADE,
+    job_id UUID REFERENCES jobs(id) ON DELETE CASCADE,
+    storage_record_id UUID REFERENCES storage_record(id) ON DELETE CASCADE,
+    to_address TEXT NOT NULL,
+    from_address TEXT NOT NULL,
+    from_name TEXT,
+    reply_to TEXT,
+    subject TEXT NOT NULL,
+    body TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'queued' CHECK (status IN ('queued', 'sending', 'sent', 'failed')),
+    attempts INT NOT NULL DEFAULT 0 CHECK (attempts >= 0),
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_error TEXT,
+    sent_at TIMESTAMPTZ,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (num_nonnulls(estimate_id, job_id, storage_record_id) = 1)
+);
+CREATE INDEX email_outbox_due_idx ON email_outbox (next_attempt_at) WHERE status IN ('queued', 'sending');
+CREATE INDEX email_outbox_estimate_idx ON email_outbox (estimate_id, created_at DESC) WHERE estimate_id IS NOT NULL;
+CREATE INDEX email_outbox_job_idx ON email_outbox (job_id, created_at DESC) WHERE job_id IS NOT NULL;
+CREATE INDEX email_outbox_storage_record_idx ON email_outbox (storage_record_id, created_at DESC) WHERE storage_record_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS email_outbox;
+DROP TABLE IF EXISTS email_templates;
+DROP TABLE IF EXISTS tenant_email_settings;
+-- +goose StatementEnd
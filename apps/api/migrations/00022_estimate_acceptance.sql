@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE estimate_acceptance_links (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    estimate_id UUID NOT NULL REFERENCES estimates(id) ON DELETE CASCADE,
+    auto_convert BOOLEAN NOT NULL DEFAULT FALSE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ,
+    accepted_at TIMESTAMPTZ,
+    first_viewed_at TIMESTAMPTZ,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX estimate_acceptance_links_estimate_idx ON estimate_acceptance_links (tenant_id, estimate_id, created_at DESC);
+
+CREATE TABLE estimate_signatures (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    estimate_id UUID NOT NULL REFERENCES estimates(id) ON DELETE CASCADE,
+    link_id UUID REFERENCES estimate_acceptance_links(id) ON DELETE SET NULL,
+    revision_number INT NOT NULL,
+    document_sha256 TEXT NOT NULL,
+    signer_name TEXT NOT NULL,
+    signer_ip TEXT NOT NULL,
+    user_agent TEXT NOT NULL,
+    signed_at TIMESTAMPTZ NOT NULL,
+    signature TEXT NOT NULL
+);
+CREATE INDEX estimate_signatures_estimate_idx ON estimate_signatures (tenant_id, estimate_id, signed_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS estimate_signatures;
+DROP TABLE IF EXISTS estimate_acceptance_links;
+-- +goose StatementEnd
@@ -0,0 +1,102 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE jobs
+    ADD COLUMN balance_cents BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN last_payment_at TIMESTAMPTZ;
+
+-- Balances are projections of the ledger now; an overpayment is a credit.
+ALTER TABLE storage_record DROP CONSTRAINT IF EXISTS storage_record_storage_balance_cents_check;
+ALTER TABLE storage_record DROP CONSTRAINT IF EXISTS storage_record_move_balance_cents_check;
+
+CREATE TABLE ledger_entries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    job_id UUID NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    storage_record_id UUID REFERENCES storage_record(id) ON DELETE CASCADE,
+    account TEXT NOT NULL CHECK (account IN ('move', 'storage')),
+    kind TEXT NOT NULL CHECK (kind IN ('charge', 'payment', 'refund', 'adjustment')),
+    amount_cents BIGINT NOT NULL,
+    balance_effect_cents BIGINT NOT NULL GENERATED ALWAYS AS (CASE WHEN kind = 'payment' THEN -amount_cents ELSE amount_cents END) STORED,
+    method TEXT CHECK (method IN ('cash', 'check', 'card', 'ach', 'other')),
+    reference TEXT,
+    memo TEXT,
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    idempotency_key TEXT,
+    idempotency_payload_hash TEXT,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT ledger_entries_account_check CHECK ((account = 'storage') = (storage_record_id IS NOT NULL)),
+    CONSTRAINT ledger_entries_amount_check CHECK (
+        (kind = 'adjustment' AND amount_cents <> 0) OR (kind <> 'adjustment' AND amount_cents > 0)
+    ),
+    CONSTRAINT ledger_entries_method_check CHECK ((kind IN ('payment', 'refund')) = (method IS NOT NULL))
+);
+CREATE INDEX ledger_entries_job_idx ON ledger_entries (tenant_id, job_id, occurred_at, created_at);
+CREATE INDEX ledger_entries_storage_idx ON ledger_entries (tenant_id, storage_record_id, occurred_at, created_at)
+    WHERE storage_record_id IS NOT NULL;
+CREATE UNIQUE INDEX ledger_entries_tenant_idempotency_uidx
+    ON ledger_entries (tenant_id, idempotency_key)
+    WHERE idempotency_key IS NOT NULL;
+
+-- Open every existing account with the balance that was typed in by hand, so
+-- the projections below reproduce today's numbers.
+INSERT INTO ledger_entries (tenant_id, job_id, storage_record_id, account, kind, amount_cents, memo)
+SELECT tenant_id, job_id, id, 'storage', 'adjustment', storage_balance_cents, 'Opening balance'
+FROM storage_record
+WHERE storage_balance_cents <> 0;
+
+INSERT INTO ledger_entries (tenant_id, job_id, account, kind, amount_cents, memo)
+SELECT tenant_id, job_id, 'move', 'adjustment', move_balance_cents, 'Opening balance'
+FROM storage_record
+WHERE move_balance_cents <> 0;
+
+-- Jobs without a storage record never had a typed balance. They are opened
+-- the way conversion opens new jobs: the estimate total as a charge and the
+-- deposit as a payment.
+INSERT INTO ledger_entries (tenant_id, job_id, account, kind, amount_cents, memo, occurred_at)
+SELECT j.tenant_id, j.id, 'move', 'charge', e.estimated_total_cents,
+       'Move charge from estimate ' || e.estimate_number, j.created_at
+FROM jobs j
+JOIN estimates e ON e.id = j.estimate_id AND e.tenant_id = j.tenant_id
+WHERE e.estimated_total_cents > 0
+  AND NOT EXISTS (
+      SELECT 1 FROM storage_record sr WHERE sr.tenant_id = j.tenant_id AND sr.job_id = j.id
+  );
+
+INSERT INTO ledger_entries (tenant_id, job_id, account, kind, amount_cents, method, memo, occurred_at)
+SELECT j.tenant_id, j.id, 'move', 'payment', e.deposit_cents, 'other',
+       'Deposit from estimate ' || e.estimate_number, j.created_at
+FROM jobs j
+JOIN estimates e ON e.id = j.estimate_id AND e.tenant_id = j.tenant_id
+WHERE e.deposit_cents > 0
+  AND NOT EXISTS (
+      SELECT 1 FROM storage_record sr WHERE sr.tenant_id = j.tenant_id AND sr.job_id = j.id
+  );
+
+UPDATE jobs j
+SET balance_cents = l.balance_cents,
+    last_payment_at = l.last_payment_at
+FROM (
+    SELECT tenant_id, job_id, SUM(balance_effect_cents) AS balance_cents,
+           MAX(occurred_at) FILTER (WHERE kind = 'payment') AS last_payment_at
+    FROM ledger_entries
+    WHERE account = 'move'
+    GROUP BY tenant_id, job_id
+) l
+WHERE l.tenant_id = j.tenant_id AND l.job_id = j.id;
+
+CREATE INDEX jobs_tenant_balance_idx ON jobs (tenant_id) WHERE balance_cents > 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS ledger_entries;
+UPDATE storage_record SET storage_balance_cents = GREATEST(storage_balance_cents, 0), move_balance_cents = GREATEST(move_balance_cents, 0);
+ALTER TABLE storage_record
+    ADD CONSTRAINT storage_record_storage_balance_cents_check CHECK (storage_balance_cents >= 0),
+    ADD CONSTRAINT storage_record_move_balance_cents_check CHECK (move_balance_cents >= 0);
+DROP INDEX IF EXISTS jobs_tenant_balance_idx;
+ALTER TABLE jobs
+    DROP COLUMN IF EXISTS last_payment_at,
+    DROP COLUMN IF EXISTS balance_cents;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Accounts with the session's email whose password was proven when the
+-- session was signed in; only these can be switched to without a password.
+ALTER TABLE sessions
+    ADD COLUMN linked_user_ids UUID[] NOT NULL DEFAULT '{}';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE sessions
+    DROP COLUMN IF EXISTS linked_user_ids;
+-- +goose StatementEnd
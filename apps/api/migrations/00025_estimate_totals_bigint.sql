@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- A full estimate at the per-item limits sums past INT, so the totals are
+-- kept as BIGINT like the line item products they add up.
+ALTER TABLE estimates
+    ALTER COLUMN total_cubic_feet TYPE BIGINT,
+    ALTER COLUMN total_weight_lbs TYPE BIGINT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE estimates
+    ALTER COLUMN total_cubic_feet TYPE INT USING LEAST(total_cubic_feet, 2147483647),
+    ALTER COLUMN total_weight_lbs TYPE INT USING LEAST(total_weight_lbs, 2147483647);
+-- +goose StatementEnd
@@ -10,7 +10,8 @@ SELECT
   t.name AS tenant_name
 FROM users u
 JOIN tenants t ON t.id = u.tenant_id
-WHERE lower(u.email) = lower(sqlc.arg(email));
+WHERE lower(u.email) = lower(sqlc.arg(email))
+ORDER BY t.name, t.id;
 
 -- name: CreateSession :one
 INSERT INTO sessions (
@@ -18,16 +19,33 @@ INSERT INTO sessions (
   user_id,
   token_hash,
   csrf_token,
-  expires_at
+  expires_at,
+  absolute_expires_at,
+  mfa_pending,
+  user_agent,
+  ip_address,
+  linked_user_ids
 ) VALUES (
   sqlc.arg(tenant_id),
   sqlc.arg(user_id),
   sqlc.arg(token_hash),
   sqlc.arg(csrf_token),
-  sqlc.arg(expires_at)
+  sqlc.arg(expires_at),
+  sqlc.arg(absolute_expires_at),
+  sqlc.arg(mfa_pending),
+  sqlc.arg(user_agent),
+  sqlc.arg(ip_address),
+  sqlc.arg(linked_user_ids)::uuid[]
 )
 RETURNING *;
 
+-- name: GetSessionLinkedUserIDs :one
+SELECT linked_user_ids
+FROM sessions
+WHERE id = sqlc.arg(id)
+  AND tenant_id = sqlc.arg(tenant_id)
+  AND revoked_at IS NULL;
+
 -- name: GetSessionPrincipalByTokenHash :one
 SELECT
   s.id AS session_id,
@@ -35,22 +53,48 @@ SELECT
   s.user_id,
   s.csrf_token,
   s.expires_at,
+  s.absolute_expires_at,
+  s.last_seen_at,
+  s.token_rotated_at,
+  (s.token_hash = sqlc.arg(token_hash))::boolean AS is_current_token,
   u.email,
   u.full_name,
   t.slug AS tenant_slug,
-  t.name AS tenant_name
+  t.name AS tenant_name,
+  s.mfa_pending,
+  (m.confirmed_at IS NOT NULL)::boolean AS mfa_enabled,
+  COALESCE(ts.require_mfa_for_sensitive_roles, FALSE)::boolean AS require_mfa_for_sensitive_roles
 FROM sessions s
 JOIN users u ON u.id = s.user_id
 JOIN tenants t ON t.id = s.tenant_id
-WHERE s.token_hash = sqlc.arg(token_hash)
+LEFT JOIN user_mfa m ON m.user_id = s.user_id
+LEFT JOIN tenant_settings ts ON ts.tenant_id = s.tenant_id
+WHERE (
+    s.token_hash = sqlc.arg(token_hash)
+    -- Requests already in flight when the token was rotated still carry the
+    -- previous cookie, so it stays valid for a short grace period.
+    OR (s.previous_token_hash = sqlc.arg(token_hash) AND s.token_rotated_at > NOW() - INTERVAL '1 minute')
+  )
   AND s.revoked_at IS NULL
   AND s.expires_at > NOW()
+  AND s.absolute_expires_at > NOW()
   AND u.is_active = TRUE;
 
 -- name: TouchSession :exec
 UPDATE sessions
-SET last_seen_at = NOW()
-WHERE id = sqlc.arg(id);
+SET last_seen_at = NOW(),
+    expires_at = sqlc.arg(expires_at)
+WHERE id = sqlc.arg(id)
+  AND revoked_at IS NULL;
+
+-- name: RotateSessionToken :execrows
+UPDATE sessions
+SET previous_token_hash = token_hash,
+    token_hash = sqlc.arg(new_token_hash),
+    token_rotated_at = NOW()
+WHERE id = sqlc.arg(id)
+  AND token_hash = sqlc.arg(current_token_hash)
+  AND revoked_at IS NULL;
 
 -- name: RevokeSessionByID :execrows
 UPDATE sessions
@@ -59,24 +103,650 @@ WHERE id = sqlc.arg(id)
   AND tenant_id = sqlc.arg(tenant_id)
   AND revoked_at IS NULL;
 
+-- name: ListUserSessions :many
+SELECT
+  id,
+  created_at,
+  last_seen_at,
+  expires_at,
+  user_agent,
+  ip_address
+FROM sessions
+WHERE user_id = sqlc.arg(user_id)
+  AND tenant_id = sqlc.arg(tenant_id)
+  AND revoked_at IS NULL
+  AND expires_at > NOW()
+  AND mfa_pending = FALSE
+ORDER BY COALESCE(last_seen_at, created_at) DESC, id;
+
+-- name: RevokeUserSession :execrows
+UPDATE sessions
+SET revoked_at = NOW()
+WHERE id = sqlc.arg(id)
+  AND user_id = sqlc.arg(user_id)
+  AND tenant_id = sqlc.arg(tenant_id)
+  AND revoked_at IS NULL
+  AND expires_at > NOW();
+
 -- name: RevokeSessionByTokenHash :execrows
 UPDATE sessions
 SET revoked_at = NOW()
 WHERE token_hash = sqlc.arg(token_hash)
   AND revoked_at IS NULL;
 
--- name: UserHasPermission :one
-SELECT EXISTS (
-  SELECT 1
-  FROM user_roles ur
-  JOIN roles r ON r.id = ur.role_id
-  JOIN role_permissions rp ON rp.role_id = r.id
-  JOIN permissions p ON p.id = rp.permission_id
-  WHERE ur.user_id = sqlc.arg(user_id)
-    AND ur.tenant_id = sqlc.arg(tenant_id)
-    AND r.tenant_id = sqlc.arg(tenant_id)
-    AND p.name = sqlc.arg(permission)
-) AS has_permission;
+-- name: GetAPITokenPrincipalByHash :one
+SELECT
+  at.id AS token_id,
+  at.tenant_id,
+  at.user_id,
+  at.scopes,
+  at.expires_at,
+  at.last_used_at,
+  u.email,
+  u.full_name,
+  t.slug AS tenant_slug,
+  t.name AS tenant_name,
+  (m.confirmed_at IS NOT NULL)::boolean AS mfa_enabled,
+  COALESCE(ts.require_mfa_for_sensitive_roles, FALSE)::boolean AS require_mfa_for_sensitive_roles
+FROM api_tokens at
+JOIN users u ON u.id = at.user_id
+JOIN tenants t ON t.id = at.tenant_id
+LEFT JOIN user_mfa m ON m.user_id = at.user_id
+LEFT JOIN tenant_settings ts ON ts.tenant_id = at.tenant_id
+WHERE at.token_hash = sqlc.arg(token_hash)
+  AND at.revoked_at IS NULL
+  AND (at.expires_at IS NULL OR at.expires_at > NOW())
+  AND u.is_active = TRUE;
+
+-- name: TouchAPIToken :exec
+UPDATE api_tokens
+SET last_used_at = NOW(),
+    last_used_ip = sqlc.arg(last_used_ip)
+WHERE id = sqlc.arg(id);
+
+-- name: CreateAPIToken :one
+INSERT INTO api_tokens (
+  tenant_id,
+  user_id,
+  name,
+  token_hash,
+  token_prefix,
+  scopes,
+  expires_at
+) VALUES (
+  sqlc.arg(tenant_id),
+  sqlc.arg(user_id),
+  sqlc.arg(name),
+  sqlc.arg(token_hash),
+  sqlc.arg(token_prefix),
+  sqlc.arg(scopes)::text[],
+  sqlc.narg(expires_at)
+)
+RETURNING id, name, token_prefix, scopes, expires_at, last_used_at, last_used_ip, created_at;
+
+-- name: ListAPITokens :many
+SELECT id, name, token_prefix, scopes, expires_at, last_used_at, last_used_ip, created_at
+FROM api_tokens
+WHERE tenant_id = sqlc.arg(tenant_id)
+  AND user_id = sqlc.arg(user_id)
+  AND revoked_at IS NULL
+ORDER BY created_at DESC, id;
+
+-- name: RevokeAPIToken :execrows
+UPDATE api_tokens
+SET revoked_at = NOW()
+WHERE id = sqlc.arg(id)
+  AND tenant_id = sqlc.arg(tenant_id)
+  AND user_id = sqlc.arg(user_id)
+  AND revoked_at IS NULL;
+
+-- name: ListUserPermissions :many
+SELECT DISTINCT p.name
+FROM user_roles ur
+JOIN roles r ON r.id = ur.role_id
+JOIN role_permissions rp ON rp.role_id = r.id
+JOIN permissions p ON p.id = rp.permission_id
+WHERE ur.user_id = sqlc.arg(user_id)
+  AND ur.tenant_id = sqlc.arg(tenant_id)
+  AND r.tenant_id = sqlc.arg(tenant_id)
+ORDER BY p.name;
+
+-- name: GetLoginMFAState :one
+SELECT
+  EXISTS (
+    SELECT 1
+    FROM user_mfa m
+    WHERE m.user_id = sqlc.arg(user_id)
+      AND m.confirmed_at IS NOT NULL
+  ) AS mfa_enabled,
+  COALESCE((
+    SELECT ts.require_mfa_for_sensitive_roles
+    FROM tenant_settings ts
+    WHERE ts.tenant_id = sqlc.arg(tenant_id)
+  ), FALSE)::boolean AS require_mfa_for_sensitive_roles;
+
+-- name: GetUserMFA :one
+SELECT user_id, tenant_id, totp_secret, confirmed_at, last_used_step, created_at, updated_at
+FROM user_mfa
+WHERE user_id = sqlc.arg(user_id)
+  AND tenant_id = sqlc.arg(tenant_id);
+
+-- name: UpsertPendingUserMFA :one
+INSERT INTO user_mfa (user_id, tenant_id, totp_secret)
+VALUES (sqlc.arg(user_id), sqlc.arg(tenant_id), sqlc.arg(totp_secret))
+ON CONFLICT (user_id) DO UPDATE
+SET totp_secret = EXCLUDED.totp_secret,
+    last_used_step = 0,
+    updated_at = NOW()
+WHERE user_mfa.confirmed_at IS NULL
+RETURNING user_id, tenant_id, totp_secret, confirmed_at, last_used_step, created_at, updated_at;
+
+-- name: ConfirmUserMFA :execrows
+UPDATE user_mfa
+SET confirmed_at = NOW(),
+    last_used_step = sqlc.arg(step),
+    updated_at = NOW()
+WHERE user_id = sqlc.arg(user_id)
+  AND confirmed_at IS NULL;
+
+-- name: AdvanceUserMFAStep :execrows
+UPDATE user_mfa
+SET last_used_step = sqlc.arg(step),
+    updated_at = NOW()
+WHERE user_id = sqlc.arg(user_id)
+  AND confirmed_at IS NOT NULL
+  AND last_used_step < sqlc.arg(step);
+
+-- name: DeleteRecoveryCodes :exec
+DELETE FROM user_mfa_recovery_codes
+WHERE user_id = sqlc.arg(user_id);
+
+-- name: InsertRecoveryCodes :exec
+INSERT INTO user_mfa_recovery_codes (user_id, code_hash)
+SELECT sqlc.arg(user_id), unnest(sqlc.arg(code_hashes)::text[]);
+
+-- name: UseRecoveryCode :execrows
+UPDATE user_mfa_recovery_codes
+SET used_at = NOW()
+WHERE user_id = sqlc.arg(user_id)
+  AND code_hash = sqlc.arg(code_hash)
+  AND used_at IS NULL;
+
+-- name: GetTenantSettings :one
+SELECT
+  t.id AS tenant_id,
+  COALESCE(ts.require_mfa_for_sensitive_roles, FALSE)::boolean AS require_mfa_for_sensitive_roles,
+  COALESCE(ts.password_min_length, 10)::int AS password_min_length,
+  COALESCE(ts.password_check_breached, TRUE)::boolean AS password_check_breached,
+  COALESCE(ts.estimate_validity_days, 30)::int AS estimate_validity_days,
+  ts.updated_at
+FROM tenants t
+LEFT JOIN tenant_settings ts ON ts.tenant_id = t.id
+WHERE t.id = sqlc.arg(tenant_id);
+
+-- name: UpsertTenantSettings :exec
+INSERT INTO tenant_settings (
+  tenant_id,
+  require_mfa_for_sensitive_roles,
+  password_min_length,
+  password_check_breached,
+  estimate_validity_days,
+  updated_by
+) VALUES (
+  sqlc.arg(tenant_id),
+  sqlc.arg(require_mfa_for_sensitive_roles),
+  sqlc.arg(password_min_length),
+  sqlc.arg(password_check_breached),
+  sqlc.arg(estimate_validity_days),
+  sqlc.arg(updated_by)
+)
+ON CONFLICT (tenant_id) DO UPDATE
+SET require_mfa_for_sensitive_roles = EXCLUDED.require_mfa_for_sensitive_roles,
+    password_min_length = EXCLUDED.password_min_length,
+    password_check_breached = EXCLUDED.password_check_breached,
+    estimate_validity_days = EXCLUDED.estimate_validity_days,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = NOW();
+
+-- name: GetSSOProvider :one
+SELECT
+  tenant_id,
+  enabled,
+  issuer,
+  client_id,
+  client_secret,
+  jit_provisioning,
+  default_role_id,
+  groups_claim,
+  updated_at
+FROM tenant_sso_providers
+WHERE tenant_id = sqlc.arg(tenant_id);
+
+-- name: GetEnabledSSOProviderBySlug :one
+SELECT
+  p.tenant_id,
+  p.issuer,
+  p.client_id
+FROM tenant_sso_providers p
+JOIN tenants t ON t.id = p.tenant_id
+WHERE lower(t.slug) = lower(sqlc.arg(slug))
+  AND p.enabled = TRUE;
+
+-- name: UpsertSSOProvider :exec
+INSERT INTO tenant_sso_providers (
+  tenant_id,
+  enabled,
+  issuer,
+  client_id,
+  client_secret,
+  jit_provisioning,
+  default_role_id,
+  groups_claim,
+  updated_by
+) VALUES (
+  sqlc.arg(tenant_id),
+  sqlc.arg(enabled),
+  sqlc.arg(issuer),
+  sqlc.arg(client_id),
+  sqlc.arg(client_secret),
+  sqlc.arg(jit_provisioning),
+  sqlc.narg(default_role_id),
+  sqlc.arg(groups_claim),
+  sqlc.arg(updated_by)
+)
+ON CONFLICT (tenant_id) DO UPDATE
+SET enabled = EXCLUDED.enabled,
+    issuer = EXCLUDED.issuer,
+    client_id = EXCLUDED.client_id,
+    client_secret = EXCLUDED.client_secret,
+    jit_provisioning = EXCLUDED.jit_provisioning,
+    default_role_id = EXCLUDED.default_role_id,
+    groups_claim = EXCLUDED.groups_claim,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = NOW();
+
+-- name: ListSSOGroupRoleMappings :many
+SELECT group_name, role_id
+FROM sso_group_role_mappings
+WHERE tenant_id = sqlc.arg(tenant_id)
+ORDER BY group_name, role_id;
+
+-- name: DeleteSSOGroupRoleMappings :exec
+DELETE FROM sso_group_role_mappings
+WHERE tenant_id = sqlc.arg(tenant_id);
+
+-- name: InsertSSOGroupRoleMappings :exec
+INSERT INTO sso_group_role_mappings (tenant_id, group_name, role_id)
+SELECT sqlc.arg(tenant_id), unnest(sqlc.arg(group_names)::text[]), unnest(sqlc.arg(role_ids)::uuid[])
+ON CONFLICT DO NOTHING;
+
+-- name: CreateOIDCLoginState :exec
+INSERT INTO oidc_login_states (state_hash, tenant_id, code_verifier, nonce, expires_at)
+VALUES (sqlc.arg(state_hash), sqlc.arg(tenant_id), sqlc.arg(code_verifier), sqlc.arg(nonce), sqlc.arg(expires_at));
+
+-- name: ConsumeOIDCLoginState :one
+UPDATE oidc_login_states
+SET consumed_at = NOW()
+WHERE state_hash = sqlc.arg(state_hash)
+  AND consumed_at IS NULL
+  AND expires_at > NOW()
+RETURNING tenant_id, code_verifier, nonce;
+
+-- name: DeleteExpiredOIDCLoginStates :exec
+DELETE FROM oidc_login_states
+WHERE expires_at < NOW() - INTERVAL '1 day';
+
+-- name: GetSSOLoginUser :one
+SELECT
+  u.id,
+  u.email,
+  u.full_name,
+  u.is_active,
+  t.slug AS tenant_slug,
+  t.name AS tenant_name,
+  (ui.id IS NOT NULL)::boolean AS identity_linked
+FROM users u
+JOIN tenants t ON t.id = u.tenant_id
+LEFT JOIN user_identities ui
+  ON ui.user_id = u.id
+  AND ui.issuer = sqlc.arg(issuer)
+  AND ui.subject = sqlc.arg(subject)
+WHERE u.tenant_id = sqlc.arg(tenant_id)
+  AND (
+    ui.id IS NOT NULL
+    OR (sqlc.arg(match_email)::boolean AND lower(u.email) = lower(sqlc.arg(email)::text))
+  )
+ORDER BY (ui.id IS NOT NULL) DESC
+LIMIT 1;
+
+-- name: UpsertUserIdentity :exec
+INSERT INTO user_identities (tenant_id, user_id, issuer, subject)
+VALUES (sqlc.arg(tenant_id), sqlc.arg(user_id), sqlc.arg(issuer), sqlc.arg(subject))
+ON CONFLICT (tenant_id, issuer, subject) DO UPDATE
+SET last_login_at = NOW();
+
+-- name: ListUserRoleIDs :many
+SELECT role_id
+FROM user_roles
+WHERE user_id = sqlc.arg(user_id)
+  AND tenant_id = sqlc.arg(tenant_id);
+
+-- name: RemoveUserRoles :execrows
+DELETE FROM user_roles
+WHERE user_id = sqlc.arg(user_id)
+  AND tenant_id = sqlc.arg(tenant_id)
+  AND role_id = ANY(sqlc.arg(role_ids)::uuid[]);
+
+-- name: GetLoginLockState :many
+SELECT user_id, locked_until
+FROM user_login_failures
+WHERE user_id = ANY(sqlc.arg(user_ids)::uuid[])
+  AND locked_until > NOW();
+
+-- name: RecordLoginFailure :one
+INSERT INTO user_login_failures (user_id, tenant_id, failed_count, last_failed_at)
+VALUES (sqlc.arg(user_id), sqlc.arg(tenant_id), 1, NOW())
+ON CONFLICT (user_id) DO UPDATE
+SET failed_count = user_login_failures.failed_count + 1,
+    last_failed_at = NOW(),
+    updated_at = NOW()
+RETURNING user_id, tenant_id, failed_count, lock_count, last_failed_at, locked_until, updated_at;
+
+-- name: LockUserLogin :exec
+UPDATE user_login_failures
+SET failed_count = 0,
+    lock_count = lock_count + 1,
+    locked_until = sqlc.arg(locked_until),
+    updated_at = NOW()
+WHERE user_id = sqlc.arg(user_id);
+
+-- name: ClearLoginFailures :execrows
+DELETE FROM user_login_failures
+WHERE user_id = sqlc.arg(user_id)
+  AND tenant_id = sqlc.arg(tenant_id);
+
+-- name: ListTenantUsers :many
+SELECT
+  u.id,
+  u.email,
+  u.full_name,
+  u.is_active,
+  u.created_at,
+  u.updated_at,
+  COALESCE(
+    ARRAY_AGG(r.name ORDER BY r.name) FILTER (WHERE r.id IS NOT NULL),
+    '{}'
+  )::text[] AS role_names,
+  f.locked_until
+FROM users u
+LEFT JOIN user_login_failures f
+  ON f.user_id = u.id
+LEFT JOIN user_roles ur
+  ON ur.user_id = u.id
+  AND ur.tenant_id = u.tenant_id
+LEFT JOIN roles r
+  ON r.id = ur.role_id
+  AND r.tenant_id = u.tenant_id
+WHERE u.tenant_id = sqlc.arg(tenant_id)
+GROUP BY u.id, f.user_id
+ORDER BY lower(u.email) ASC;
+
+-- name: GetTenantUserByID :one
+SELECT
+  u.id,
+  u.email,
+  u.full_name,
+  u.is_active,
+  u.created_at,
+  u.updated_at,
+  COALESCE(
+    ARRAY_AGG(r.name ORDER BY r.name) FILTER (WHERE r.id IS NOT NULL),
+    '{}'
+  )::text[] AS role_names,
+  f.locked_until
+FROM users u
+LEFT JOIN user_login_failures f
+  ON f.user_id = u.id
+LEFT JOIN user_roles ur
+  ON ur.user_id = u.id
+  AND ur.tenant_id = u.tenant_id
+LEFT JOIN roles r
+  ON r.id = ur.role_id
+  AND r.tenant_id = u.tenant_id
+WHERE u.id = sqlc.arg(id)
+  AND u.tenant_id = sqlc.arg(tenant_id)
+GROUP BY u.id, f.user_id;
+
+-- name: CreateUser :one
+INSERT INTO users (
+  tenant_id,
+  email,
+  full_name,
+  password_hash,
+  is_active
+) VALUES (
+  sqlc.arg(tenant_id),
+  sqlc.arg(email),
+  sqlc.arg(full_name),
+  sqlc.arg(password_hash),
+  TRUE
+)
+RETURNING *;
+
+-- name: UpdateTenantUser :one
+UPDATE users
+SET
+  full_name = COALESCE(sqlc.narg(full_name), full_name),
+  is_active = COALESCE(sqlc.narg(is_active), is_active),
+  updated_at = NOW()
+WHERE id = sqlc.arg(id)
+  AND tenant_id = sqlc.arg(tenant_id)
+RETURNING *;
+
+-- name: UpdateUserPasswordHash :execrows
+UPDATE users
+SET
+  password_hash = sqlc.arg(password_hash),
+  updated_at = NOW()
+WHERE id = sqlc.arg(id)
+  AND tenant_id = sqlc.arg(tenant_id);
+
+-- name: RevokeSessionsForUser :execrows
+UPDATE sessions
+SET revoked_at = NOW()
+WHERE user_id = sqlc.arg(user_id)
+  AND tenant_id = sqlc.arg(tenant_id)
+  AND revoked_at IS NULL;
+
+-- name: GetUserPasswordHash :one
+SELECT password_hash, email, full_name
+FROM users
+WHERE id = sqlc.arg(id)
+  AND tenant_id = sqlc.arg(tenant_id)
+  AND is_active = TRUE;
+
+-- name: RevokeOtherUserSessions :execrows
+UPDATE sessions
+SET revoked_at = NOW()
+WHERE user_id = sqlc.arg(user_id)
+  AND tenant_id = sqlc.arg(tenant_id)
+  AND id <> sqlc.arg(keep_session_id)
+  AND revoked_at IS NULL;
+
+-- name: DeleteUnusedPasswordResetTokens :exec
+DELETE FROM password_reset_tokens
+WHERE user_id = sqlc.arg(user_id)
+  AND tenant_id = sqlc.arg(tenant_id)
+  AND used_at IS NULL;
+
+-- name: CreatePasswordResetToken :one
+INSERT INTO password_reset_tokens (tenant_id, user_id, token_hash, created_by, expires_at)
+SELECT u.tenant_id, u.id, sqlc.arg(token_hash), sqlc.arg(created_by), sqlc.arg(expires_at)
+FROM users u
+WHERE u.id = sqlc.arg(user_id)
+  AND u.tenant_id = sqlc.arg(tenant_id)
+  AND u.is_active = TRUE
+RETURNING id, expires_at;
+
+-- name: GetPasswordResetToken :one
+SELECT
+  prt.id,
+  prt.tenant_id,
+  prt.user_id,
+  u.email,
+  u.full_name,
+  COALESCE(ts.password_min_length, 10)::int AS password_min_length,
+  COALESCE(ts.password_check_breached, TRUE)::boolean AS password_check_breached
+FROM password_reset_tokens prt
+JOIN users u ON u.id = prt.user_id
+LEFT JOIN tenant_settings ts ON ts.tenant_id = prt.tenant_id
+WHERE prt.token_hash = sqlc.arg(token_hash)
+  AND prt.used_at IS NULL
+  AND prt.expires_at > NOW()
+  AND u.is_active = TRUE;
+
+-- name: UsePasswordResetToken :execrows
+UPDATE password_reset_tokens
+SET used_at = NOW()
+WHERE id = sqlc.arg(id)
+  AND used_at IS NULL
+  AND expires_at > NOW();
+
+-- name: AssignUserRole :execrows
+INSERT INTO user_roles (user_id, role_id, tenant_id)
+SELECT sqlc.arg(user_id), r.id, r.tenant_id
+FROM roles r
+WHERE r.id = sqlc.arg(role_id)
+  AND r.tenant_id = sqlc.arg(tenant_id)
+ON CONFLICT DO NOTHING;
+
+-- name: ListPermissions :many
+SELECT
+  name,
+  description
+FROM permissions
+ORDER BY name ASC;
+
+-- name: CountPermissionsByNames :one
+SELECT COUNT(*)::bigint
+FROM permissions
+WHERE name = ANY(sqlc.arg(names)::text[]);
+
+-- name: ListTenantRoles :many
+SELECT
+  r.id,
+  r.name,
+  r.description,
+  r.created_at,
+  COALESCE(
+    (
+      SELECT ARRAY_AGG(p.name ORDER BY p.name)
+      FROM role_permissions rp
+      JOIN permissions p ON p.id = rp.permission_id
+      WHERE rp.role_id = r.id
+    ),
+    '{}'
+  )::text[] AS permission_names,
+  (
+    SELECT COUNT(*)
+    FROM user_roles ur
+    WHERE ur.role_id = r.id
+      AND ur.tenant_id = r.tenant_id
+  )::bigint AS user_count
+FROM roles r
+WHERE r.tenant_id = sqlc.arg(tenant_id)
+ORDER BY lower(r.name) ASC;
+
+-- name: GetTenantRoleByID :one
+SELECT
+  r.id,
+  r.name,
+  r.description,
+  r.created_at,
+  COALESCE(
+    (
+      SELECT ARRAY_AGG(p.name ORDER BY p.name)
+      FROM role_permissions rp
+      JOIN permissions p ON p.id = rp.permission_id
+      WHERE rp.role_id = r.id
+    ),
+    '{}'
+  )::text[] AS permission_names,
+  (
+    SELECT COUNT(*)
+    FROM user_roles ur
+    WHERE ur.role_id = r.id
+      AND ur.tenant_id = r.tenant_id
+  )::bigint AS user_count
+FROM roles r
+WHERE r.id = sqlc.arg(id)
+  AND r.tenant_id = sqlc.arg(tenant_id);
+
+-- name: LockTenantRoles :exec
+SELECT id
+FROM roles
+WHERE tenant_id = sqlc.arg(tenant_id)
+FOR UPDATE;
+
+-- name: CreateRole :one
+INSERT INTO roles (
+  tenant_id,
+  name,
+  description
+) VALUES (
+  sqlc.arg(tenant_id),
+  sqlc.arg(name),
+  sqlc.arg(description)
+)
+RETURNING *;
+
+-- name: UpdateRole :one
+UPDATE roles
+SET
+  name = COALESCE(sqlc.narg(name), name),
+  description = COALESCE(sqlc.narg(description), description)
+WHERE id = sqlc.arg(id)
+  AND tenant_id = sqlc.arg(tenant_id)
+RETURNING *;
+
+-- name: DeleteRole :execrows
+DELETE FROM roles
+WHERE id = sqlc.arg(id)
+  AND tenant_id = sqlc.arg(tenant_id);
+
+-- name: ClearRolePermissions :exec
+DELETE FROM role_permissions
+WHERE role_id = sqlc.arg(role_id);
+
+-- name: AddRolePermissionsByName :exec
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT sqlc.arg(role_id), p.id
+FROM permissions p
+WHERE p.name = ANY(sqlc.arg(names)::text[])
+ON CONFLICT DO NOTHING;
+
+-- name: ClearUserRoles :exec
+DELETE FROM user_roles
+WHERE user_id = sqlc.arg(user_id)
+  AND tenant_id = sqlc.arg(tenant_id);
+
+-- name: CountActiveUsersWithPermission :one
+SELECT COUNT(DISTINCT u.id)::bigint
+FROM users u
+JOIN user_roles ur
+  ON ur.user_id = u.id
+  AND ur.tenant_id = u.tenant_id
+JOIN role_permissions rp ON rp.role_id = ur.role_id
+JOIN permissions p ON p.id = rp.permission_id
+WHERE u.tenant_id = sqlc.arg(tenant_id)
+  AND u.is_active = TRUE
+  AND p.name = sqlc.arg(permission);
+
+-- name: CountTenantRolesByIDs :one
+SELECT COUNT(*)::bigint
+FROM roles
+WHERE tenant_id = sqlc.arg(tenant_id)
+  AND id = ANY(sqlc.arg(role_ids)::uuid[]);
 
 -- name: CreateCustomer :one
 INSERT INTO customers (
@@ -114,6 +784,105 @@ FROM customers
 WHERE id = sqlc.arg(id)
   AND tenant_id = sqlc.arg(tenant_id);
 
+-- name: ListCustomers :many
+SELECT
+  id,
+  tenant_id,
+  first_name,
+  last_name,
+  email,
+  phone,
+  created_by,
+  updated_by,
+  created_at,
+  updated_at
+FROM customers
+WHERE tenant_id = sqlc.arg(tenant_id)
+  AND (
+    sqlc.narg(search_q)::text IS NULL
+    OR TRIM(first_name || ' ' || last_name) ILIKE '%' || sqlc.narg(search_q)::text || '%'
+    OR COALESCE(email, '') ILIKE '%' || sqlc.narg(search_q)::text || '%'
+    OR COALESCE(phone, '') ILIKE '%' || sqlc.narg(search_q)::text || '%'
+    OR (
+      sqlc.narg(search_phone_digits)::text IS NOT NULL
+      AND regexp_replace(COALESCE(phone, ''), '[^0-9]', '', 'g') LIKE '%' || sqlc.narg(search_phone_digits)::text || '%'
+    )
+  )
+  AND (
+    sqlc.narg(cursor_created_at)::timestamptz IS NULL
+    OR (
+      created_at < sqlc.narg(cursor_created_at)::timestamptz
+      OR (
+        created_at = sqlc.narg(cursor_created_at)::timestamptz
+        AND id < sqlc.narg(cursor_id)::uuid
+      )
+    )
+  )
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(limit_rows);
+
+-- name: UpdateCustomer :one
+UPDATE customers
+SET
+  first_name = sqlc.arg(first_name),
+  last_name = sqlc.arg(last_name),
+  email = sqlc.narg(email),
+  phone = sqlc.narg(phone),
+  updated_by = sqlc.arg(updated_by),
+  updated_at = NOW()
+WHERE id = sqlc.arg(id)
+  AND tenant_id = sqlc.arg(tenant_id)
+RETURNING *;
+
+-- name: GetCustomerByIDForUpdate :one
+SELECT
+  id,
+  tenant_id,
+  first_name,
+  last_name,
+  email,
+  phone,
+  created_by,
+  updated_by,
+  created_at,
+  updated_at
+FROM customers
+WHERE id = sqlc.arg(id)
+  AND tenant_id = sqlc.arg(tenant_id)
+FOR UPDATE;
+
+-- name: ReassignEstimatesCustomer :execrows
+UPDATE estimates
+SET
+  customer_id = sqlc.arg(target_customer_id),
+  updated_by = sqlc.arg(updated_by),
+  updated_at = NOW()
+WHERE tenant_id = sqlc.arg(tenant_id)
+  AND customer_id = sqlc.arg(source_customer_id);
+
+-- name: ReassignJobsCustomer :execrows
+UPDATE jobs
+SET
+  customer_id = sqlc.arg(target_customer_id),
+  updated_by = sqlc.arg(updated_by),
+  updated_at = NOW()
+WHERE tenant_id = sqlc.arg(tenant_id)
+  AND customer_id = sqlc.arg(source_customer_id);
+
+-- name: ReassignCustomerImportIdempotency :exec
+UPDATE import_idempotency
+SET
+  target_entity_id = sqlc.arg(target_customer_id),
+  last_seen_at = NOW()
+WHERE tenant_id = sqlc.arg(tenant_id)
+  AND entity_type = 'customer'
+  AND target_entity_id = sqlc.arg(source_customer_id);
+
+-- name: DeleteCustomer :execrows
+DELETE FROM customers
+WHERE id = sqlc.arg(id)
+  AND tenant_id = sqlc.arg(tenant_id);
+
 -- name: CreateCustomerForEstimate :one
 INSERT INTO customers (
   tenant_id,
@@ -147,6 +916,25 @@ WHERE id = sqlc.arg(id)
   AND tenant_id = sqlc.arg(tenant_id)
 RETURNING *;
 
+-- name: SyncSoleEstimateCustomer :execrows
+UPDATE customers c
+SET
+  first_name = sqlc.arg(first_name),
+  last_name = sqlc.arg(last_name),
+  email = sqlc.arg(email),
+  phone = sqlc.arg(phone),
+  updated_by = sqlc.arg(updated_by),
+  updated_at = NOW()
+WHERE c.id = sqlc.arg(id)
+  AND c.tenant_id = sqlc.arg(tenant_id)
+  AND NOT EXISTS (
+    SELECT 1
+    FROM estimates e
+    WHERE e.tenant_id = c.tenant_id
+      AND e.customer_id = c.id
+      AND e.id <> sqlc.arg(estimate_id)
+  );
+
 -- name: IncrementTenantCounter :one
 INSERT INTO tenant_counters (tenant_id, counter_type, next_value)
 VALUES (sqlc.arg(tenant_id), sqlc.arg(counter_type), 2)
@@ -180,6 +968,7 @@ INSERT INTO estimates (
   move_size,
   location_type,
   estimated_total_cents,
+  price_source,
   deposit_cents,
   notes,
   idempotency_key,
@@ -208,7 +997,8 @@ INSERT INTO estimates (
   sqlc.arg(lead_source),
   sqlc.narg(move_size),
   sqlc.narg(location_type),
-  sqlc.narg(estimated_total_cents),
+  sqlc.narg(estimated_total_cents)::bigint,
+  CASE WHEN sqlc.narg(estimated_total_cents)::bigint IS NULL THEN NULL ELSE 'manual' END,
   sqlc.narg(deposit_cents),
   sqlc.narg(notes),
   sqlc.arg(idempotency_key),
@@ -244,7 +1034,16 @@ SELECT
   location_type,
   estimated_total_cents,
   deposit_cents,
+  total_cubic_feet,
+  total_weight_lbs,
+  price_source,
+  price_breakdown,
+  priced_at,
   notes,
+  lost_reason,
+  sent_at,
+  expires_at,
+  status_changed_at,
   idempotency_key,
   idempotency_payload_hash,
   created_by,
@@ -281,7 +1080,16 @@ SELECT
   e.location_type,
   e.estimated_total_cents,
   e.deposit_cents,
+  e.total_cubic_feet,
+  e.total_weight_lbs,
+  e.price_source,
+  e.price_breakdown,
+  e.priced_at,
   e.notes,
+  e.lost_reason,
+  e.sent_at,
+  e.expires_at,
+  e.status_changed_at,
   e.idempotency_key,
   e.idempotency_payload_hash,
   e.created_by,
@@ -296,6 +1104,64 @@ LEFT JOIN jobs j
 WHERE e.id = sqlc.arg(id)
   AND e.tenant_id = sqlc.arg(tenant_id);
 
+-- name: ListEstimates :many
+SELECT
+  e.id,
+  e.estimate_number,
+  e.customer_id,
+  e.status,
+  e.customer_name,
+  e.primary_phone,
+  e.email,
+  COALESCE(
+    NULLIF(CONCAT_WS(', ', NULLIF(TRIM(e.origin_city), ''), NULLIF(TRIM(e.origin_state), '')), ''),
+    'TBD'
+  )::text AS origin_short,
+  COALESCE(
+    NULLIF(CONCAT_WS(', ', NULLIF(TRIM(e.destination_city), ''), NULLIF(TRIM(e.destination_state), '')), ''),
+    'TBD'
+  )::text AS destination_short,
+  e.move_date,
+  e.lead_source,
+  e.estimated_total_cents,
+  e.deposit_cents,
+  e.expires_at,
+  e.created_by,
+  e.created_at,
+  e.updated_at,
+  j.id AS converted_job_id
+FROM estimates e
+LEFT JOIN jobs j
+  ON j.tenant_id = e.tenant_id
+  AND j.estimate_id = e.id
+WHERE e.tenant_id = sqlc.arg(tenant_id)
+  AND (sqlc.narg(status)::text IS NULL OR e.status = sqlc.narg(status)::text)
+  AND (sqlc.narg(lead_source)::text IS NULL OR lower(e.lead_source) = lower(sqlc.narg(lead_source)::text))
+  AND (sqlc.narg(move_date_from)::date IS NULL OR e.move_date >= sqlc.narg(move_date_from)::date)
+  AND (sqlc.narg(move_date_to)::date IS NULL OR e.move_date <= sqlc.narg(move_date_to)::date)
+  AND (sqlc.narg(created_from)::timestamptz IS NULL OR e.created_at >= sqlc.narg(created_from)::timestamptz)
+  AND (sqlc.narg(created_to)::timestamptz IS NULL OR e.created_at < sqlc.narg(created_to)::timestamptz)
+  AND (sqlc.narg(created_by)::uuid IS NULL OR e.created_by = sqlc.narg(created_by)::uuid)
+  AND (
+    sqlc.narg(search_q)::text IS NULL
+    OR e.customer_name ILIKE '%' || sqlc.narg(search_q)::text || '%'
+    OR e.email ILIKE '%' || sqlc.narg(search_q)::text || '%'
+    OR e.primary_phone ILIKE '%' || sqlc.narg(search_q)::text || '%'
+    OR COALESCE(e.secondary_phone, '') ILIKE '%' || sqlc.narg(search_q)::text || '%'
+  )
+  AND (
+    sqlc.narg(cursor_created_at)::timestamptz IS NULL
+    OR (
+      e.created_at < sqlc.narg(cursor_created_at)::timestamptz
+      OR (
+        e.created_at = sqlc.narg(cursor_created_at)::timestamptz
+        AND e.id < sqlc.narg(cursor_id)::uuid
+      )
+    )
+  )
+ORDER BY e.created_at DESC, e.id DESC
+LIMIT sqlc.arg(limit_rows);
+
 -- name: GetEstimateByIdempotencyKey :one
 SELECT
   id,
@@ -322,7 +1188,16 @@ SELECT
   location_type,
   estimated_total_cents,
   deposit_cents,
+  total_cubic_feet,
+  total_weight_lbs,
+  price_source,
+  price_breakdown,
+  priced_at,
   notes,
+  lost_reason,
+  sent_at,
+  expires_at,
+  status_changed_at,
   idempotency_key,
   idempotency_payload_hash,
   created_by,
@@ -354,6 +1229,11 @@ SET
   move_size = COALESCE(sqlc.narg(move_size), move_size),
   location_type = COALESCE(sqlc.narg(location_type), location_type),
   estimated_total_cents = COALESCE(sqlc.narg(estimated_total_cents)::bigint, estimated_total_cents),
+  price_source = CASE
+    WHEN sqlc.narg(estimated_total_cents)::bigint IS NULL
+      OR sqlc.narg(estimated_total_cents)::bigint = estimated_total_cents THEN price_source
+    ELSE 'manual'
+  END,
   deposit_cents = COALESCE(sqlc.narg(deposit_cents)::bigint, deposit_cents),
   notes = COALESCE(sqlc.narg(notes), notes),
   updated_by = sqlc.arg(updated_by),
@@ -362,16 +1242,463 @@ WHERE id = sqlc.arg(id)
   AND tenant_id = sqlc.arg(tenant_id)
 RETURNING *;
 
+-- name: TransitionEstimateStatus :one
+UPDATE estimates
+SET
+  status = sqlc.arg(status),
+  lost_reason = sqlc.narg(lost_reason),
+  sent_at = sqlc.narg(sent_at),
+  expires_at = sqlc.narg(expires_at),
+  status_changed_at = CASE WHEN status <> sqlc.arg(status) THEN NOW() ELSE status_changed_at END,
+  updated_by = sqlc.arg(updated_by),
+  updated_at = NOW()
+WHERE id = sqlc.arg(id)
+  AND tenant_id = sqlc.arg(tenant_id)
+  AND status = sqlc.arg(from_status)
+RETURNING *;
+
+-- name: ExpireSentEstimates :many
+UPDATE estimates
+SET
+  status = 'expired',
+  status_changed_at = NOW(),
+  updated_at = NOW()
+WHERE tenant_id = sqlc.arg(tenant_id)
+  AND status = 'sent'
+  AND expires_at <= NOW()
+RETURNING id;
+
 -- name: MarkEstimateConverted :execrows
 UPDATE estimates
 SET
   status = 'converted',
   updated_by = sqlc.arg(updated_by),
+  status_changed_at = NOW(),
   updated_at = NOW()
 WHERE id = sqlc.arg(id)
   AND tenant_id = sqlc.arg(tenant_id)
   AND status <> 'converted';
 
+-- name: LockEstimate :one
+SELECT id
+FROM estimates
+WHERE id = sqlc.arg(id)
+  AND tenant_id = sqlc.arg(tenant_id)
+FOR UPDATE;
+
+-- name: RecalculateEstimateTotals :one
+UPDATE estimates e
+SET
+  total_cubic_feet = totals.cubic_feet,
+  total_weight_lbs = totals.weight_lbs,
+  updated_by = sqlc.arg(updated_by),
+  updated_at = NOW()
+FROM (
+  SELECT
+    COALESCE(SUM(li.quantity * li.cubic_feet), 0)::bigint AS cubic_feet,
+    COALESCE(SUM(li.quantity * li.weight_lbs), 0)::bigint AS weight_lbs
+  FROM estimate_line_items li
+  WHERE li.tenant_id = sqlc.arg(tenant_id)
+    AND li.estimate_id = sqlc.arg(id)
+) totals
+WHERE e.id = sqlc.arg(id)
+  AND e.tenant_id = sqlc.arg(tenant_id)
+RETURNING e.total_cubic_feet, e.total_weight_lbs;
+
+-- name: SetEstimatePrice :one
+UPDATE estimates
+SET
+  estimated_total_cents = sqlc.arg(estimated_total_cents),
+  price_source = 'tariff',
+  price_breakdown = sqlc.arg(price_breakdown),
+  priced_at = NOW(),
+  updated_by = sqlc.arg(updated_by),
+  updated_at = NOW()
+WHERE id = sqlc.arg(id)
+  AND tenant_id = sqlc.arg(tenant_id)
+RETURNING *;
+
+-- name: GetEstimateDocumentTemplate :one
+SELECT
+  t.id AS tenant_id,
+  t.name AS tenant_name,
+  dt.company_name,
+  COALESCE(dt.accent_color, '#1f4e79')::text AS accent_color,
+  dt.logo,
+  dt.logo_content_type,
+  dt.intro_text,
+  dt.terms_text,
+  dt.footer_text,
+  COALESCE(dt.show_line_items, TRUE)::boolean AS show_line_items,
+  dt.updated_at
+FROM tenants t
+LEFT JOIN estimate_document_templates dt ON dt.tenant_id = t.id
+WHERE t.id = sqlc.arg(tenant_id);
+
+-- name: UpsertEstimateDocumentTemplate :exec
+INSERT INTO estimate_document_templates (
+  tenant_id,
+  company_name,
+  accent_color,
+  intro_text,
+  terms_text,
+  footer_text,
+  show_line_items,
+  updated_by,
+  updated_at
+) VALUES (
+  sqlc.arg(tenant_id),
+  sqlc.narg(company_name),
+  sqlc.arg(accent_color),
+  sqlc.narg(intro_text),
+  sqlc.narg(terms_text),
+  sqlc.narg(footer_text),
+  sqlc.arg(show_line_items),
+  sqlc.arg(updated_by),
+  NOW()
+)
+ON CONFLICT (tenant_id) DO UPDATE SET
+  company_name = EXCLUDED.company_name,
+  accent_color = EXCLUDED.accent_color,
+  intro_text = EXCLUDED.intro_text,
+  terms_text = EXCLUDED.terms_text,
+  footer_text = EXCLUDED.footer_text,
+  show_line_items = EXCLUDED.show_line_items,
+  updated_by = EXCLUDED.updated_by,
+  updated_at = NOW();
+
+-- name: SetEstimateDocumentLogo :exec
+UPDATE estimate_document_templates
+SET
+  logo = sqlc.narg(logo),
+  logo_content_type = sqlc.narg(logo_content_type)
+WHERE tenant_id = sqlc.arg(tenant_id);
+
+-- name: GetTariff :one
+SELECT *
+FROM tariffs
+WHERE tenant_id = sqlc.arg(tenant_id);
+
+-- name: UpsertTariff :one
+INSERT INTO tariffs (
+  tenant_id,
+  minimum_hours,
+  minimum_charge_cents,
+  long_distance_threshold_miles,
+  per_mile_cents,
+  per_cwt_cents,
+  fuel_surcharge_bps,
+  updated_by,
+  updated_at
+) VALUES (
+  sqlc.arg(tenant_id),
+  sqlc.arg(minimum_hours),
+  sqlc.arg(minimum_charge_cents),
+  sqlc.arg(long_distance_threshold_miles),
+  sqlc.arg(per_mile_cents),
+  sqlc.arg(per_cwt_cents),
+  sqlc.arg(fuel_surcharge_bps),
+  sqlc.arg(updated_by),
+  NOW()
+)
+ON CONFLICT (tenant_id) DO UPDATE SET
+  minimum_hours = EXCLUDED.minimum_hours,
+  minimum_charge_cents = EXCLUDED.minimum_charge_cents,
+  long_distance_threshold_miles = EXCLUDED.long_distance_threshold_miles,
+  per_mile_cents = EXCLUDED.per_mile_cents,
+  per_cwt_cents = EXCLUDED.per_cwt_cents,
+  fuel_surcharge_bps = EXCLUDED.fuel_surcharge_bps,
+  updated_by = EXCLUDED.updated_by,
+  updated_at = NOW()
+RETURNING *;
+
+-- name: ListTariffCrewRates :many
+SELECT crew_size, hourly_rate_cents
+FROM tariff_crew_rates
+WHERE tenant_id = sqlc.arg(tenant_id)
+ORDER BY crew_size;
+
+-- name: DeleteTariffCrewRates :exec
+DELETE FROM tariff_crew_rates
+WHERE tenant_id = sqlc.arg(tenant_id);
+
+-- name: InsertTariffCrewRates :exec
+INSERT INTO tariff_crew_rates (tenant_id, crew_size, hourly_rate_cents)
+SELECT sqlc.arg(tenant_id), unnest(sqlc.arg(crew_sizes)::int[]), unnest(sqlc.arg(hourly_rate_cents)::bigint[]);
+
+-- name: ListTariffAccessorials :many
+SELECT code, name, unit, rate_cents
+FROM tariff_accessorials
+WHERE tenant_id = sqlc.arg(tenant_id)
+ORDER BY code;
+
+-- name: DeleteTariffAccessorials :exec
+DELETE FROM tariff_accessorials
+WHERE tenant_id = sqlc.arg(tenant_id);
+
+-- name: InsertTariffAccessorials :exec
+INSERT INTO tariff_accessorials (tenant_id, code, name, unit, rate_cents)
+SELECT sqlc.arg(tenant_id), unnest(sqlc.arg(codes)::text[]), unnest(sqlc.arg(names)::text[]), unnest(sqlc.arg(units)::text[]), unnest(sqlc.arg(rate_cents)::bigint[]);
+
+-- name: ListTariffValuationOptions :many
+SELECT code, name, cents_per_thousand, minimum_cents_per_lb
+FROM tariff_valuation_options
+WHERE tenant_id = sqlc.arg(tenant_id)
+ORDER BY code;
+
+-- name: DeleteTariffValuationOptions :exec
+DELETE FROM tariff_valuation_options
+WHERE tenant_id = sqlc.arg(tenant_id);
+
+-- name: InsertTariffValuationOptions :exec
+INSERT INTO tariff_valuation_options (tenant_id, code, name, cents_per_thousand, minimum_cents_per_lb)
+SELECT sqlc.arg(tenant_id), unnest(sqlc.arg(codes)::text[]), unnest(sqlc.arg(names)::text[]), unnest(sqlc.arg(cents_per_thousand)::bigint[]), unnest(sqlc.arg(minimum_cents_per_lb)::bigint[]);
+
+-- name: InsertEstimateRevision :one
+INSERT INTO estimate_revisions (
+  tenant_id,
+  estimate_id,
+  revision_number,
+  change,
+  restored_from,
+  snapshot,
+  created_by
+) VALUES (
+  sqlc.arg(tenant_id),
+  sqlc.arg(estimate_id),
+  (
+    SELECT COALESCE(MAX(r.revision_number), 0) + 1
+    FROM estimate_revisions r
+    WHERE r.tenant_id = sqlc.arg(tenant_id)
+      AND r.estimate_id = sqlc.arg(estimate_id)
+  ),
+  sqlc.arg(change),
+  sqlc.narg(restored_from),
+  sqlc.arg(snapshot),
+  sqlc.narg(created_by)
+)
+RETURNING revision_number;
+
+-- name: ListEstimateRevisions :many
+SELECT
+  r.revision_number,
+  r.change,
+  r.restored_from,
+  r.created_by,
+  u.full_name AS created_by_name,
+  r.created_at
+FROM estimate_revisions r
+LEFT JOIN users u ON u.id = r.created_by
+WHERE r.tenant_id = sqlc.arg(tenant_id)
+  AND r.estimate_id = sqlc.arg(estimate_id)
+ORDER BY r.revision_number DESC;
+
+-- name: GetEstimateRevision :one
+SELECT
+  r.revision_number,
+  r.change,
+  r.restored_from,
+  r.created_by,
+  u.full_name AS created_by_name,
+  r.created_at,
+  r.snapshot
+FROM estimate_revisions r
+LEFT JOIN users u ON u.id = r.created_by
+WHERE r.tenant_id = sqlc.arg(tenant_id)
+  AND r.estimate_id = sqlc.arg(estimate_id)
+  AND r.revision_number = sqlc.arg(revision_number);
+
+-- name: GetPreviousEstimateRevisionNumber :one
+SELECT COALESCE(MAX(revision_number), 0)::int AS revision_number
+FROM estimate_revisions
+WHERE tenant_id = sqlc.arg(tenant_id)
+  AND estimate_id = sqlc.arg(estimate_id)
+  AND revision_number < sqlc.arg(revision_number);
+
+-- name: RestoreEstimateFields :one
+UPDATE estimates
+SET
+  customer_name = sqlc.arg(customer_name),
+  primary_phone = sqlc.arg(primary_phone),
+  secondary_phone = sqlc.narg(secondary_phone),
+  email = sqlc.arg(email),
+  origin_address_line1 = sqlc.arg(origin_address_line1),
+  origin_city = sqlc.arg(origin_city),
+  origin_state = sqlc.arg(origin_state),
+  origin_postal_code = sqlc.arg(origin_postal_code),
+  destination_address_line1 = sqlc.arg(destination_address_line1),
+  destination_city = sqlc.arg(destination_city),
+  destination_state = sqlc.arg(destination_state),
+  destination_postal_code = sqlc.arg(destination_postal_code),
+  move_date = sqlc.arg(move_date)::date,
+  pickup_time = sqlc.narg(pickup_time),
+  lead_source = sqlc.arg(lead_source),
+  move_size = sqlc.narg(move_size),
+  location_type = sqlc.narg(location_type),
+  estimated_total_cents = sqlc.narg(estimated_total_cents)::bigint,
+  price_source = sqlc.narg(price_source),
+  price_breakdown = sqlc.narg(price_breakdown),
+  priced_at = sqlc.narg(priced_at),
+  deposit_cents = sqlc.narg(deposit_cents)::bigint,
+  notes = sqlc.narg(notes),
+  updated_by = sqlc.arg(updated_by),
+  updated_at = NOW()
+WHERE id = sqlc.arg(id)
+  AND tenant_id = sqlc.arg(tenant_id)
+RETURNING *;
+
+-- name: DeleteEstimateLineItems :exec
+DELETE FROM estimate_line_items
+WHERE tenant_id = sqlc.arg(tenant_id)
+  AND estimate_id = sqlc.arg(estimate_id);
+
+-- name: RestoreEstimateLineItem :exec
+INSERT INTO estimate_line_items (
+  id,
+  tenant_id,
+  estimate_id,
+  inventory_item_id,
+  room,
+  item_name,
+  quantity,
+  cubic_feet,
+  weight_lbs,
+  created_by,
+  updated_by,
+  created_at
+) VALUES (
+  sqlc.arg(id),
+  sqlc.arg(tenant_id),
+  sqlc.arg(estimate_id),
+  (
+    SELECT i.id
+    FROM inventory_items i
+    WHERE i.id = sqlc.narg(inventory_item_id)
+      AND i.tenant_id = sqlc.arg(tenant_id)
+  ),
+  sqlc.arg(room),
+  sqlc.arg(item_name),
+  sqlc.arg(quantity),
+  sqlc.arg(cubic_feet),
+  sqlc.arg(weight_lbs),
+  sqlc.arg(updated_by),
+  sqlc.arg(updated_by),
+  sqlc.arg(created_at)
+);
+
+-- name: ListEstimateLineItems :many
+SELECT *
+FROM estimate_line_items
+WHERE tenant_id = sqlc.arg(tenant_id)
+  AND estimate_id = sqlc.arg(estimate_id)
+ORDER BY room, created_at, id;
+
+-- name: GetEstimateLineItem :one
+SELECT *
+FROM estimate_line_items
+WHERE id = sqlc.arg(id)
+  AND tenant_id = sqlc.arg(tenant_id)
+  AND estimate_id = sqlc.arg(estimate_id);
+
+-- name: CreateEstimateLineItem :one
+INSERT INTO estimate_line_items (
+  tenant_id,
+  estimate_id,
+  inventory_item_id,
+  room,
+  item_name,
+  quantity,
+  cubic_feet,
+  weight_lbs,
+  created_by,
+  updated_by
+) VALUES (
+  sqlc.arg(tenant_id),
+  sqlc.arg(estimate_id),
+  sqlc.narg(inventory_item_id),
+  sqlc.arg(room),
+  sqlc.arg(item_name),
+  sqlc.arg(quantity),
+  sqlc.arg(cubic_feet),
+  sqlc.arg(weight_lbs),
+  sqlc.arg(created_by),
+  sqlc.arg(created_by)
+)
+RETURNING *;
+
+-- name: UpdateEstimateLineItem :one
+UPDATE estimate_line_items
+SET
+  room = COALESCE(sqlc.narg(room), room),
+  item_name = COALESCE(sqlc.narg(item_name), item_name),
+  quantity = COALESCE(sqlc.narg(quantity)::int, quantity),
+  cubic_feet = COALESCE(sqlc.narg(cubic_feet)::int, cubic_feet),
+  weight_lbs = COALESCE(sqlc.narg(weight_lbs)::int, weight_lbs),
+  updated_by = sqlc.arg(updated_by),
+  updated_at = NOW()
+WHERE id = sqlc.arg(id)
+  AND tenant_id = sqlc.arg(tenant_id)
+  AND estimate_id = sqlc.arg(estimate_id)
+RETURNING *;
+
+-- name: DeleteEstimateLineItem :execrows
+DELETE FROM estimate_line_items
+WHERE id = sqlc.arg(id)
+  AND tenant_id = sqlc.arg(tenant_id)
+  AND estimate_id = sqlc.arg(estimate_id);
+
+-- name: ListInventoryItems :many
+SELECT *
+FROM inventory_items
+WHERE tenant_id = sqlc.arg(tenant_id)
+  AND (sqlc.arg(include_inactive)::boolean OR active)
+ORDER BY lower(name), id;
+
+-- name: GetInventoryItem :one
+SELECT *
+FROM inventory_items
+WHERE id = sqlc.arg(id)
+  AND tenant_id = sqlc.arg(tenant_id);
+
+-- name: CreateInventoryItem :one
+INSERT INTO inventory_items (
+  tenant_id,
+  name,
+  default_room,
+  cubic_feet,
+  weight_lbs,
+  created_by,
+  updated_by
+) VALUES (
+  sqlc.arg(tenant_id),
+  sqlc.arg(name),
+  sqlc.narg(default_room),
+  sqlc.arg(cubic_feet),
+  sqlc.arg(weight_lbs),
+  sqlc.arg(created_by),
+  sqlc.arg(created_by)
+)
+RETURNING *;
+
+-- name: UpdateInventoryItem :one
+UPDATE inventory_items
+SET
+  name = COALESCE(sqlc.narg(name), name),
+  default_room = CASE WHEN sqlc.narg(default_room)::text IS NULL THEN default_room ELSE NULLIF(sqlc.narg(default_room)::text, '') END,
+  cubic_feet = COALESCE(sqlc.narg(cubic_feet)::int, cubic_feet),
+  weight_lbs = COALESCE(sqlc.narg(weight_lbs)::int, weight_lbs),
+  active = COALESCE(sqlc.narg(active)::boolean, active),
+  updated_by = sqlc.arg(updated_by),
+  updated_at = NOW()
+WHERE id = sqlc.arg(id)
+  AND tenant_id = sqlc.arg(tenant_id)
+RETURNING *;
+
+-- name: DeleteInventoryItem :execrows
+DELETE FROM inventory_items
+WHERE id = sqlc.arg(id)
+  AND tenant_id = sqlc.arg(tenant_id);
+
 -- name: CreateJob :one
 INSERT INTO jobs (
   tenant_id,
@@ -398,7 +1725,28 @@ INSERT INTO jobs (
 )
 RETURNING *;
 
--- name: GetJobByID :one
+-- name: GetJobByID :one
+SELECT
+  id,
+  tenant_id,
+  job_number,
+  estimate_id,
+  customer_id,
+  status,
+  scheduled_date,
+  pickup_time,
+  convert_idempotency_key,
+  balance_cents,
+  last_payment_at,
+  created_by,
+  updated_by,
+  created_at,
+  updated_at
+FROM jobs
+WHERE id = sqlc.arg(id)
+  AND tenant_id = sqlc.arg(tenant_id);
+
+-- name: GetJobByIDForUpdate :one
 SELECT
   id,
   tenant_id,
@@ -409,13 +1757,16 @@ SELECT
   scheduled_date,
   pickup_time,
   convert_idempotency_key,
+  balance_cents,
+  last_payment_at,
   created_by,
   updated_by,
   created_at,
   updated_at
 FROM jobs
 WHERE id = sqlc.arg(id)
-  AND tenant_id = sqlc.arg(tenant_id);
+  AND tenant_id = sqlc.arg(tenant_id)
+FOR UPDATE;
 
 -- name: GetJobDetailByID :one
 SELECT
@@ -428,6 +1779,8 @@ SELECT
   j.scheduled_date,
   j.pickup_time,
   j.convert_idempotency_key,
+  j.balance_cents,
+  j.last_payment_at,
   j.created_by,
   j.updated_by,
   j.created_at,
@@ -463,7 +1816,7 @@ SELECT
   )::text AS destination_short,
   j.status,
   FALSE AS has_storage,
-  GREATEST(COALESCE(e.estimated_total_cents, 0) - COALESCE(e.deposit_cents, 0), 0)::bigint AS balance_due_cents
+  GREATEST(j.balance_cents, 0)::bigint AS balance_due_cents
 FROM jobs j
 JOIN customers c
   ON c.id = j.customer_id
@@ -490,6 +1843,72 @@ WHERE j.tenant_id = sqlc.arg(tenant_id)
   )
 ORDER BY j.scheduled_date ASC, COALESCE(j.pickup_time, ''), j.job_number ASC;
 
+-- name: ListJobs :many
+SELECT
+  j.id,
+  j.job_number,
+  j.customer_id,
+  j.estimate_id,
+  j.status,
+  j.scheduled_date,
+  j.pickup_time,
+  COALESCE(NULLIF(TRIM(c.first_name || ' ' || c.last_name), ''), j.job_number) AS customer_name,
+  (CASE WHEN j.convert_idempotency_key IS NOT NULL THEN 'estimate' ELSE 'import' END)::text AS source,
+  COALESCE(
+    NULLIF(CONCAT_WS(', ', NULLIF(TRIM(e.origin_city), ''), NULLIF(TRIM(e.origin_state), '')), ''),
+    'TBD'
+  )::text AS origin_short,
+  COALESCE(
+    NULLIF(CONCAT_WS(', ', NULLIF(TRIM(e.destination_city), ''), NULLIF(TRIM(e.destination_state), '')), ''),
+    'TBD'
+  )::text AS destination_short,
+  GREATEST(j.balance_cents, 0)::bigint AS balance_due_cents,
+  j.created_at,
+  j.updated_at
+FROM jobs j
+JOIN customers c
+  ON c.id = j.customer_id
+  AND c.tenant_id = j.tenant_id
+LEFT JOIN estimates e
+  ON e.id = j.estimate_id
+  AND e.tenant_id = j.tenant_id
+WHERE j.tenant_id = sqlc.arg(tenant_id)
+  AND (sqlc.narg(status)::text IS NULL OR j.status = sqlc.narg(status)::text)
+  AND (
+    sqlc.narg(scheduled)::boolean IS NULL
+    OR (sqlc.narg(scheduled)::boolean = TRUE AND j.scheduled_date IS NOT NULL)
+    OR (sqlc.narg(scheduled)::boolean = FALSE AND j.scheduled_date IS NULL)
+  )
+  AND (sqlc.narg(customer_id)::uuid IS NULL OR j.customer_id = sqlc.narg(customer_id)::uuid)
+  AND (
+    sqlc.narg(source)::text IS NULL
+    OR (sqlc.narg(source)::text = 'estimate' AND j.convert_idempotency_key IS NOT NULL)
+    OR (sqlc.narg(source)::text = 'import' AND j.convert_idempotency_key IS NULL)
+  )
+  AND (
+    sqlc.narg(balance_due)::boolean IS NULL
+    OR (
+      sqlc.narg(balance_due)::boolean = TRUE
+      AND j.balance_cents > 0
+    )
+    OR (
+      sqlc.narg(balance_due)::boolean = FALSE
+      AND j.balance_cents <= 0
+    )
+  )
+  AND (
+    sqlc.narg(cursor_created_at)::timestamptz IS NULL
+    OR (
+      j.created_at < sqlc.narg(cursor_created_at)::timestamptz
+      OR (
+        j.created_at = sqlc.narg(cursor_created_at)::timestamptz
+        AND j.id < sqlc.narg(cursor_id)::uuid
+      )
+    )
+  )
+ORDER BY j.created_at DESC, j.id DESC
+LIMIT sqlc.arg(limit_rows);
+
 -- name: GetJobByEstimateID :one
 SELECT
   id,
@@ -501,6 +1920,8 @@ SELECT
   scheduled_date,
   pickup_time,
   convert_idempotency_key,
+  balance_cents,
+  last_payment_at,
   created_by,
   updated_by,
   created_at,
@@ -520,6 +1941,8 @@ SELECT
   scheduled_date,
   pickup_time,
   convert_idempotency_key,
+  balance_cents,
+  last_payment_at,
   created_by,
   updated_by,
   created_at,
@@ -528,6 +1951,38 @@ FROM jobs
 WHERE tenant_id = sqlc.arg(tenant_id)
   AND convert_idempotency_key = sqlc.arg(convert_idempotency_key);
 
+-- name: InsertJobStatusHistory :exec
+INSERT INTO job_status_history (
+  tenant_id,
+  job_id,
+  from_status,
+  to_status,
+  reason,
+  changed_by
+) VALUES (
+  sqlc.arg(tenant_id),
+  sqlc.arg(job_id),
+  sqlc.narg(from_status),
+  sqlc.arg(to_status),
+  sqlc.narg(reason),
+  sqlc.narg(changed_by)
+);
+
+-- name: ListJobStatusHistory :many
+SELECT
+  h.id,
+  h.from_status,
+  h.to_status,
+  h.reason,
+  h.changed_by,
+  u.full_name AS changed_by_name,
+  h.changed_at
+FROM job_status_history h
+LEFT JOIN users u ON u.id = h.changed_by
+WHERE h.tenant_id = sqlc.arg(tenant_id)
+  AND h.job_id = sqlc.arg(job_id)
+ORDER BY h.changed_at ASC, h.id ASC;
+
 -- name: UpdateJobScheduleStatus :one
 UPDATE jobs
 SET
@@ -613,9 +2068,7 @@ INSERT INTO storage_record (
   oversize_items,
   volume,
   monthly_rate_cents,
-  storage_balance_cents,
   move_balance_cents,
-  last_payment_at,
   notes
 ) VALUES (
   sqlc.arg(tenant_id),
@@ -633,9 +2086,7 @@ INSERT INTO storage_record (
   COALESCE(sqlc.narg(oversize_items)::int, 0),
   COALESCE(sqlc.narg(volume)::int, 0),
   sqlc.narg(monthly_rate_cents)::bigint,
-  COALESCE(sqlc.narg(storage_balance_cents)::bigint, 0),
-  COALESCE(sqlc.narg(move_balance_cents)::bigint, 0),
-  sqlc.narg(last_payment_at)::timestamptz,
+  (SELECT j.balance_cents FROM jobs j WHERE j.id = sqlc.arg(job_id) AND j.tenant_id = sqlc.arg(tenant_id)),
   sqlc.narg(notes)
 )
 RETURNING *;
@@ -656,9 +2107,6 @@ SET
   oversize_items = sqlc.arg(oversize_items),
   volume = sqlc.arg(volume),
   monthly_rate_cents = sqlc.narg(monthly_rate_cents)::bigint,
-  storage_balance_cents = sqlc.arg(storage_balance_cents),
-  move_balance_cents = sqlc.arg(move_balance_cents),
-  last_payment_at = sqlc.narg(last_payment_at)::timestamptz,
   notes = sqlc.narg(notes),
   updated_at = NOW()
 WHERE id = sqlc.arg(id)
@@ -856,6 +2304,24 @@ FROM customers
 WHERE tenant_id = sqlc.arg(tenant_id)
   AND phone = sqlc.arg(phone);
 
+-- name: FindCustomersByPhoneDigits :many
+SELECT
+  id,
+  tenant_id,
+  first_name,
+  last_name,
+  email,
+  phone,
+  created_by,
+  updated_by,
+  created_at,
+  updated_at
+FROM customers
+WHERE tenant_id = sqlc.arg(tenant_id)
+  AND regexp_replace(COALESCE(phone, ''), '[^0-9]', '', 'g') = sqlc.arg(phone_digits)::text
+ORDER BY created_at ASC, id ASC
+LIMIT 5;
+
 -- name: GetJobByJobNumber :one
 SELECT
   id,
@@ -867,6 +2333,8 @@ SELECT
   scheduled_date,
   pickup_time,
   convert_idempotency_key,
+  balance_cents,
+  last_payment_at,
   created_by,
   updated_by,
   created_at,
@@ -915,7 +2383,16 @@ SELECT
   location_type,
   estimated_total_cents,
   deposit_cents,
+  total_cubic_feet,
+  total_weight_lbs,
+  price_source,
+  price_breakdown,
+  priced_at,
   notes,
+  lost_reason,
+  sent_at,
+  expires_at,
+  status_changed_at,
   idempotency_key,
   idempotency_payload_hash,
   created_by,
@@ -949,6 +2426,11 @@ SET
   move_size = sqlc.narg(move_size),
   location_type = sqlc.narg(location_type),
   estimated_total_cents = sqlc.narg(estimated_total_cents)::bigint,
+  price_source = CASE
+    WHEN sqlc.narg(estimated_total_cents)::bigint IS NOT DISTINCT FROM estimated_total_cents THEN price_source
+    WHEN sqlc.narg(estimated_total_cents)::bigint IS NULL THEN NULL
+    ELSE 'manual'
+  END,
   deposit_cents = sqlc.narg(deposit_cents)::bigint,
   notes = sqlc.narg(notes),
   updated_by = sqlc.narg(updated_by),
@@ -1218,6 +2700,426 @@ JOIN jobs j
 WHERE sr.tenant_id = sqlc.arg(tenant_id)
 ORDER BY sr.created_at ASC;
 
+-- name: GetTenantEmailSettings :one
+SELECT
+  t.id AS tenant_id,
+  t.name AS tenant_name,
+  COALESCE(es.enabled, FALSE)::boolean AS enabled,
+  es.from_name,
+  es.from_address,
+  es.reply_to,
+  es.updated_at
+FROM tenants t
+LEFT JOIN tenant_email_settings es ON es.tenant_id = t.id
+WHERE t.id = sqlc.arg(tenant_id);
+
+-- name: UpsertTenantEmailSettings :exec
+INSERT INTO tenant_email_settings (
+  tenant_id,
+  enabled,
+  from_name,
+  from_address,
+  reply_to,
+  updated_by,
+  updated_at
+) VALUES (
+  sqlc.arg(tenant_id),
+  sqlc.arg(enabled),
+  sqlc.narg(from_name),
+  sqlc.narg(from_address),
+  sqlc.narg(reply_to),
+  sqlc.arg(updated_by),
+  NOW()
+)
+ON CONFLICT (tenant_id) DO UPDATE SET
+  enabled = EXCLUDED.enabled,
+  from_name = EXCLUDED.from_name,
+  from_address = EXCLUDED.from_address,
+  reply_to = EXCLUDED.reply_to,
+  updated_by = EXCLUDED.updated_by,
+  updated_at = NOW();
+
+-- name: ListEmailTemplates :many
+SELECT kind, subject, body, updated_at
+FROM email_templates
+WHERE tenant_id = sqlc.arg(tenant_id)
+ORDER BY kind;
+
+-- name: GetEmailTemplate :one
+SELECT kind, subject, body, updated_at
+FROM email_templates
+WHERE tenant_id = sqlc.arg(tenant_id)
+  AND kind = sqlc.arg(kind);
+
+-- name: DeleteEmailTemplates :exec
+DELETE FROM email_templates
+WHERE tenant_id = sqlc.arg(tenant_id);
+
+-- name: InsertEmailTemplates :exec
+INSERT INTO email_templates (tenant_id, kind, subject, body, updated_by)
+SELECT sqlc.arg(tenant_id), unnest(sqlc.arg(kinds)::text[]), unnest(sqlc.arg(subjects)::text[]), unnest(sqlc.arg(bodies)::text[]), sqlc.arg(updated_by);
+
+-- name: GetJobEmailContext :one
+SELECT
+  j.id,
+  j.job_number,
+  j.scheduled_date,
+  j.pickup_time,
+  COALESCE(NULLIF(TRIM(c.first_name || ' ' || c.last_name), ''), e.customer_name, '')::text AS customer_name,
+  COALESCE(NULLIF(TRIM(c.email), ''), e.email, '')::text AS email,
+  e.estimate_number,
+  e.origin_city,
+  e.origin_state,
+  e.destination_city,
+  e.destination_state
+FROM jobs j
+JOIN customers c
+  ON c.id = j.customer_id
+  AND c.tenant_id = j.tenant_id
+LEFT JOIN estimates e
+  ON e.id = j.estimate_id
+  AND e.tenant_id = j.tenant_id
+WHERE j.id = sqlc.arg(id)
+  AND j.tenant_id = sqlc.arg(tenant_id);
+
+-- name: GetStorageRecordEmailContext :one
+SELECT
+  sr.id,
+  sr.job_id,
+  sr.facility,
+  sr.next_bill_date,
+  sr.monthly_rate_cents,
+  sr.storage_balance_cents,
+  j.job_number,
+  COALESCE(NULLIF(TRIM(c.first_name || ' ' || c.last_name), ''), e.customer_name, '')::text AS customer_name,
+  COALESCE(NULLIF(TRIM(c.email), ''), e.email, '')::text AS email
+FROM storage_record sr
+JOIN jobs j
+  ON j.id = sr.job_id
+  AND j.tenant_id = sr.tenant_id
+JOIN customers c
+  ON c.id = j.customer_id
+  AND c.tenant_id = j.tenant_id
+LEFT JOIN estimates e
+  ON e.id = j.estimate_id
+  AND e.tenant_id = j.tenant_id
+WHERE sr.id = sqlc.arg(id)
+  AND sr.tenant_id = sqlc.arg(tenant_id);
+
+-- name: EnqueueEmail :one
+INSERT INTO email_outbox (
+  tenant_id,
+  kind,
+  estimate_id,
+  job_id,
+  storage_record_id,
+  to_address,
+  from_address,
+  from_name,
+  reply_to,
+  subject,
+  body,
+  created_by
+) VALUES (
+  sqlc.arg(tenant_id),
+  sqlc.arg(kind),
+  sqlc.narg(estimate_id),
+  sqlc.narg(job_id),
+  sqlc.narg(storage_record_id),
+  sqlc.arg(to_address),
+  sqlc.arg(from_address),
+  sqlc.narg(from_name),
+  sqlc.narg(reply_to),
+  sqlc.arg(subject),
+  sqlc.arg(body),
+  sqlc.narg(created_by)
+)
+RETURNING *;
+
+-- name: ListEmailOutbox :many
+SELECT *
+FROM email_outbox
+WHERE tenant_id = sqlc.arg(tenant_id)
+  AND (sqlc.narg(estimate_id)::uuid IS NULL OR estimate_id = sqlc.narg(estimate_id))
+  AND (sqlc.narg(job_id)::uuid IS NULL OR job_id = sqlc.narg(job_id))
+  AND (sqlc.narg(storage_record_id)::uuid IS NULL OR storage_record_id = sqlc.narg(storage_record_id))
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: ClaimDueEmails :many
+UPDATE email_outbox
+SET
+  status = 'sending',
+  attempts = attempts + 1,
+  next_attempt_at = NOW() + make_interval(secs => sqlc.arg(lease_seconds)::int),
+  updated_at = NOW()
+WHERE id IN (
+  SELECT id
+  FROM email_outbox
+  WHERE status IN ('queued', 'sending')
+    AND next_attempt_at <= NOW()
+  ORDER BY next_attempt_at, id
+  LIMIT sqlc.arg(batch_size)
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: RenewEmailLease :execrows
+UPDATE email_outbox
+SET
+  next_attempt_at = NOW() + make_interval(secs => sqlc.arg(lease_seconds)::int),
+  updated_at = NOW()
+WHERE id = sqlc.arg(id)
+  AND status = 'sending'
+  AND attempts = sqlc.arg(attempts)
+  AND next_attempt_at > NOW();
+
+-- name: MarkEmailSent :execrows
+UPDATE email_outbox
+SET
+  status = 'sent',
+  sent_at = NOW(),
+  last_error = NULL,
+  updated_at = NOW()
+WHERE id = sqlc.arg(id)
+  AND status = 'sending'
+  AND attempts = sqlc.arg(attempts);
+
+-- name: MarkEmailRetry :execrows
+UPDATE email_outbox
+SET
+  status = 'queued',
+  last_error = sqlc.arg(last_error),
+  next_attempt_at = sqlc.arg(next_attempt_at),
+  updated_at = NOW()
+WHERE id = sqlc.arg(id)
+  AND status = 'sending'
+  AND attempts = sqlc.arg(attempts);
+
+-- name: MarkEmailFailed :execrows
+UPDATE email_outbox
+SET
+  status = 'failed',
+  last_error = sqlc.arg(last_error),
+  updated_at = NOW()
+WHERE id = sqlc.arg(id)
+  AND status = 'sending'
+  AND attempts = sqlc.arg(attempts);
+
+-- name: RevokeEstimateAcceptanceLinks :exec
+UPDATE estimate_acceptance_links
+SET revoked_at = NOW()
+WHERE tenant_id = sqlc.arg(tenant_id)
+  AND estimate_id = sqlc.arg(estimate_id)
+  AND revoked_at IS NULL
+  AND accepted_at IS NULL;
+
+-- name: CreateEstimateAcceptanceLink :one
+INSERT INTO estimate_acceptance_links (
+  tenant_id,
+  estimate_id,
+  auto_convert,
+  expires_at,
+  created_by
+) VALUES (
+  sqlc.arg(tenant_id),
+  sqlc.arg(estimate_id),
+  sqlc.arg(auto_convert),
+  sqlc.arg(expires_at),
+  sqlc.narg(created_by)
+)
+RETURNING *;
+
+-- name: GetEstimateAcceptanceLink :one
+SELECT *
+FROM estimate_acceptance_links
+WHERE id = sqlc.arg(id);
+
+-- name: LockEstimateAcceptanceLink :one
+SELECT *
+FROM estimate_acceptance_links
+WHERE id = sqlc.arg(id)
+FOR UPDATE;
+
+-- name: MarkEstimateAcceptanceLinkViewed :execrows
+UPDATE estimate_acceptance_links
+SET first_viewed_at = NOW()
+WHERE id = sqlc.arg(id)
+  AND first_viewed_at IS NULL;
+
+-- name: MarkEstimateAcceptanceLinkAccepted :exec
+UPDATE estimate_acceptance_links
+SET accepted_at = sqlc.arg(accepted_at)
+WHERE id = sqlc.arg(id);
+
+-- name: GetLatestEstimateRevisionDigest :one
+SELECT
+  revision_number,
+  encode(sha256(convert_to(snapshot::text, 'UTF8')), 'hex')::text AS document_sha256
+FROM estimate_revisions
+WHERE tenant_id = sqlc.arg(tenant_id)
+  AND estimate_id = sqlc.arg(estimate_id)
+ORDER BY revision_number DESC
+LIMIT 1;
+
+-- name: InsertEstimateSignature :exec
+INSERT INTO estimate_signatures (
+  id,
+  tenant_id,
+  estimate_id,
+  link_id,
+  revision_number,
+  document_sha256,
+  signer_name,
+  signer_ip,
+  user_agent,
+  signed_at,
+  signature
+) VALUES (
+  sqlc.arg(id),
+  sqlc.arg(tenant_id),
+  sqlc.arg(estimate_id),
+  sqlc.narg(link_id),
+  sqlc.arg(revision_number),
+  sqlc.arg(document_sha256),
+  sqlc.arg(signer_name),
+  sqlc.arg(signer_ip),
+  sqlc.arg(user_agent),
+  sqlc.arg(signed_at),
+  sqlc.arg(signature)
+);
+
+-- name: GetEstimateSignatureByLink :one
+SELECT *
+FROM estimate_signatures
+WHERE link_id = sqlc.arg(link_id)
+ORDER BY signed_at DESC
+LIMIT 1;
+
+-- name: ListEstimateSignatures :many
+SELECT
+  s.id,
+  s.tenant_id,
+  s.estimate_id,
+  s.link_id,
+  s.revision_number,
+  s.document_sha256,
+  s.signer_name,
+  s.signer_ip,
+  s.user_agent,
+  s.signed_at,
+  s.signature,
+  COALESCE(encode(sha256(convert_to(r.snapshot::text, 'UTF8')), 'hex'), '')::text AS revision_sha256
+FROM estimate_signatures s
+LEFT JOIN estimate_revisions r
+  ON r.estimate_id = s.estimate_id
+  AND r.revision_number = s.revision_number
+WHERE s.tenant_id = sqlc.arg(tenant_id)
+  AND s.estimate_id = sqlc.arg(estimate_id)
+ORDER BY s.signed_at DESC;
+
+-- name: CreateLedgerEntry :one
+INSERT INTO ledger_entries (
+  tenant_id,
+  job_id,
+  storage_record_id,
+  account,
+  kind,
+  amount_cents,
+  method,
+  reference,
+  memo,
+  occurred_at,
+  idempotency_key,
+  idempotency_payload_hash,
+  created_by
+) VALUES (
+  sqlc.arg(tenant_id),
+  sqlc.arg(job_id),
+  sqlc.narg(storage_record_id),
+  sqlc.arg(account),
+  sqlc.arg(kind),
+  sqlc.arg(amount_cents),
+  sqlc.narg(method),
+  sqlc.narg(reference),
+  sqlc.narg(memo),
+  COALESCE(sqlc.narg(occurred_at)::timestamptz, NOW()),
+  sqlc.narg(idempotency_key),
+  sqlc.narg(idempotency_payload_hash),
+  sqlc.narg(created_by)
+)
+RETURNING *;
+
+-- name: GetLedgerEntryByIdempotencyKey :one
+SELECT *
+FROM ledger_entries
+WHERE tenant_id = sqlc.arg(tenant_id)
+  AND idempotency_key = sqlc.arg(idempotency_key);
+
+-- name: ListLedgerEntries :many
+SELECT
+  id,
+  tenant_id,
+  job_id,
+  storage_record_id,
+  account,
+  kind,
+  amount_cents,
+  balance_effect_cents,
+  method,
+  reference,
+  memo,
+  occurred_at,
+  created_by,
+  created_at,
+  (SUM(balance_effect_cents) OVER (ORDER BY occurred_at, created_at, id))::bigint AS balance_after_cents
+FROM ledger_entries
+WHERE tenant_id = sqlc.arg(tenant_id)
+  AND job_id = sqlc.arg(job_id)
+  AND account = sqlc.arg(account)
+ORDER BY occurred_at, created_at, id;
+
+-- name: RefreshJobBalance :one
+UPDATE jobs j
+SET
+  balance_cents = l.balance_cents,
+  last_payment_at = l.last_payment_at
+FROM (
+  SELECT
+    COALESCE(SUM(balance_effect_cents), 0)::bigint AS balance_cents,
+    MAX(occurred_at) FILTER (WHERE kind = 'payment') AS last_payment_at
+  FROM ledger_entries
+  WHERE tenant_id = sqlc.arg(tenant_id)
+    AND job_id = sqlc.arg(job_id)
+    AND account = 'move'
+) l
+WHERE j.id = sqlc.arg(job_id)
+  AND j.tenant_id = sqlc.arg(tenant_id)
+RETURNING j.balance_cents, j.last_payment_at;
+
+-- name: SetStorageMoveBalance :exec
+UPDATE storage_record
+SET move_balance_cents = sqlc.arg(move_balance_cents)
+WHERE job_id = sqlc.arg(job_id)
+  AND tenant_id = sqlc.arg(tenant_id);
+
+-- name: RefreshStorageBalance :one
+UPDATE storage_record sr
+SET
+  storage_balance_cents = l.balance_cents,
+  last_payment_at = GREATEST(l.last_payment_at, sr.last_payment_at)
+FROM (
+  SELECT
+    COALESCE(SUM(balance_effect_cents), 0)::bigint AS balance_cents,
+    MAX(occurred_at) FILTER (WHERE kind = 'payment') AS last_payment_at
+  FROM ledger_entries
+  WHERE tenant_id = sqlc.arg(tenant_id)
+    AND storage_record_id = sqlc.arg(id)
+) l
+WHERE sr.id = sqlc.arg(id)
+  AND sr.tenant_id = sqlc.arg(tenant_id)
+RETURNING sr.storage_balance_cents, sr.last_payment_at;
+
 -- name: InsertAuditLog :exec
 INSERT INTO audit_log (
   tenant_id,
@@ -1236,3 +3138,131 @@ INSERT INTO audit_log (
   sqlc.narg(request_id),
   sqlc.arg(metadata)
 );
+
+-- name: SearchTenantRecords :many
+WITH hits AS (
+  SELECT
+    'estimate'::text AS hit_type,
+    e.id,
+    e.estimate_number AS title,
+    CONCAT_WS(', ', e.customer_name, NULLIF(TRIM(e.origin_city), ''), e.status)::text AS subtitle,
+    GREATEST(
+      CASE
+        WHEN lower(e.estimate_number) = lower(sqlc.arg(query)::text) THEN 1.0
+        WHEN e.estimate_number ILIKE sqlc.arg(query)::text || '%' THEN 0.9
+        ELSE 0
+      END,
+      CASE
+        WHEN sqlc.narg(phone_digits)::text IS NOT NULL
+          AND regexp_replace(e.primary_phone, '[^0-9]', '', 'g') LIKE '%' || sqlc.narg(phone_digits)::text || '%'
+          THEN 0.85
+        ELSE 0
+      END,
+      CASE WHEN lower(e.email) = lower(sqlc.arg(query)::text) THEN 0.85 ELSE 0 END,
+      word_similarity(sqlc.arg(query)::text, e.customer_name) * 0.8,
+      word_similarity(sqlc.arg(query)::text, COALESCE(e.origin_city, '')) * 0.5,
+      word_similarity(sqlc.arg(query)::text, COALESCE(e.destination_city, '')) * 0.5
+    )::float8 AS score,
+    e.updated_at
+  FROM estimates e
+  WHERE sqlc.arg(include_estimates)::boolean
+    AND e.tenant_id = sqlc.arg(tenant_id)
+    AND (
+      e.estimate_number ILIKE '%' || sqlc.arg(query)::text || '%'
+      OR e.customer_name ILIKE '%' || sqlc.arg(query)::text || '%'
+      OR sqlc.arg(query)::text <% e.customer_name
+      OR e.email ILIKE '%' || sqlc.arg(query)::text || '%'
+      OR COALESCE(e.origin_city, '') ILIKE '%' || sqlc.arg(query)::text || '%'
+      OR COALESCE(e.destination_city, '') ILIKE '%' || sqlc.arg(query)::text || '%'
+      OR (
+        sqlc.narg(phone_digits)::text IS NOT NULL
+        AND regexp_replace(e.primary_phone, '[^0-9]', '', 'g') LIKE '%' || sqlc.narg(phone_digits)::text || '%'
+      )
+    )
+
+  UNION ALL
+
+  SELECT
+    'job'::text AS hit_type,
+    j.id,
+    j.job_number AS title,
+    CONCAT_WS(', ', NULLIF(TRIM(c.first_name || ' ' || c.last_name), ''), NULLIF(TRIM(e.origin_city), ''), j.status)::text AS subtitle,
+    GREATEST(
+      CASE
+        WHEN lower(j.job_number) = lower(sqlc.arg(query)::text) THEN 1.0
+        WHEN j.job_number ILIKE sqlc.arg(query)::text || '%' THEN 0.9
+        ELSE 0
+      END,
+      CASE
+        WHEN sqlc.narg(phone_digits)::text IS NOT NULL
+          AND regexp_replace(COALESCE(c.phone, ''), '[^0-9]', '', 'g') LIKE '%' || sqlc.narg(phone_digits)::text || '%'
+          THEN 0.85
+        ELSE 0
+      END,
+      word_similarity(sqlc.arg(query)::text, c.first_name || ' ' || c.last_name) * 0.8,
+      word_similarity(sqlc.arg(query)::text, COALESCE(e.origin_city, '')) * 0.5,
+      word_similarity(sqlc.arg(query)::text, COALESCE(e.destination_city, '')) * 0.5
+    )::float8 AS score,
+    j.updated_at
+  FROM jobs j
+  JOIN customers c
+    ON c.id = j.customer_id
+    AND c.tenant_id = j.tenant_id
+  LEFT JOIN estimates e
+    ON e.id = j.estimate_id
+    AND e.tenant_id = j.tenant_id
+  WHERE sqlc.arg(include_jobs)::boolean
+    AND j.tenant_id = sqlc.arg(tenant_id)
+    AND (
+      j.job_number ILIKE '%' || sqlc.arg(query)::text || '%'
+      OR (c.first_name || ' ' || c.last_name) ILIKE '%' || sqlc.arg(query)::text || '%'
+      OR sqlc.arg(query)::text <% (c.first_name || ' ' || c.last_name)
+      OR COALESCE(e.origin_city, '') ILIKE '%' || sqlc.arg(query)::text || '%'
+      OR COALESCE(e.destination_city, '') ILIKE '%' || sqlc.arg(query)::text || '%'
+      OR (
+        sqlc.narg(phone_digits)::text IS NOT NULL
+        AND regexp_replace(COALESCE(c.phone, ''), '[^0-9]', '', 'g') LIKE '%' || sqlc.narg(phone_digits)::text || '%'
+      )
+    )
+
+  UNION ALL
+
+  SELECT
+    'customer'::text AS hit_type,
+    c.id,
+    TRIM(c.first_name || ' ' || c.last_name)::text AS title,
+    CONCAT_WS(', ', NULLIF(TRIM(COALESCE(c.email, '')), ''), NULLIF(TRIM(COALESCE(c.phone, '')), ''))::text AS subtitle,
+    GREATEST(
+      CASE WHEN lower(COALESCE(c.email, '')) = lower(sqlc.arg(query)::text) THEN 0.95 ELSE 0 END,
+      CASE
+        WHEN sqlc.narg(phone_digits)::text IS NOT NULL
+          AND regexp_replace(COALESCE(c.phone, ''), '[^0-9]', '', 'g') LIKE '%' || sqlc.narg(phone_digits)::text || '%'
+          THEN 0.85
+        ELSE 0
+      END,
+      CASE WHEN lower(TRIM(c.first_name || ' ' || c.last_name)) = lower(sqlc.arg(query)::text) THEN 0.95 ELSE 0 END,
+      word_similarity(sqlc.arg(query)::text, c.first_name || ' ' || c.last_name) * 0.85
+    )::float8 AS score,
+    c.updated_at
+  FROM customers c
+  WHERE sqlc.arg(include_customers)::boolean
+    AND c.tenant_id = sqlc.arg(tenant_id)
+    AND (
+      (c.first_name || ' ' || c.last_name) ILIKE '%' || sqlc.arg(query)::text || '%'
+      OR sqlc.arg(query)::text <% (c.first_name || ' ' || c.last_name)
+      OR COALESCE(c.email, '') ILIKE '%' || sqlc.arg(query)::text || '%'
+      OR (
+        sqlc.narg(phone_digits)::text IS NOT NULL
+        AND regexp_replace(COALESCE(c.phone, ''), '[^0-9]', '', 'g') LIKE '%' || sqlc.narg(phone_digits)::text || '%'
+      )
+    )
+)
+SELECT
+  hit_type,
+  id,
+  title,
+  subtitle,
+  score
+FROM hits
+ORDER BY score DESC, updated_at DESC, id DESC
+LIMIT sqlc.arg(limit_rows);
@@ -1,4 +1,5 @@
 CREATE EXTENSION IF NOT EXISTS pgcrypto;
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
 
 CREATE TABLE tenants (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
@@ -67,6 +68,8 @@ CREATE UNIQUE INDEX customers_tenant_id_uidx ON customers (tenant_id, id);
 CREATE UNIQUE INDEX customers_tenant_email_uidx
     ON customers (tenant_id, lower(email))
     WHERE email IS NOT NULL AND btrim(email) <> '';
+CREATE INDEX customers_full_name_trgm_idx ON customers USING gin ((first_name || ' ' || last_name) gin_trgm_ops);
+CREATE INDEX customers_email_trgm_idx ON customers USING gin (email gin_trgm_ops);
 
 CREATE TABLE tenant_counters (
     tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
@@ -82,7 +85,7 @@ CREATE TABLE estimates (
     tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
     estimate_number TEXT NOT NULL,
     customer_id UUID NOT NULL REFERENCES customers(id),
-    status TEXT NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'converted')),
+    status TEXT NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'sent', 'accepted', 'declined', 'expired', 'converted')),
     customer_name TEXT NOT NULL,
     primary_phone TEXT NOT NULL,
     secondary_phone TEXT,
@@ -102,7 +105,16 @@ CREATE TABLE estimates (
     location_type TEXT,
     estimated_total_cents BIGINT,
     deposit_cents BIGINT,
+    total_cubic_feet BIGINT NOT NULL DEFAULT 0,
+    total_weight_lbs BIGINT NOT NULL DEFAULT 0,
+    price_source TEXT CHECK (price_source IN ('manual', 'tariff')),
+    price_breakdown JSONB,
+    priced_at TIMESTAMPTZ,
     notes TEXT,
+    lost_reason TEXT,
+    sent_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ,
+    status_changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
     idempotency_key TEXT,
     idempotency_payload_hash TEXT,
     created_by UUID REFERENCES users(id),
@@ -120,6 +132,111 @@ CREATE UNIQUE INDEX estimates_tenant_id_uidx ON estimates (tenant_id, id);
 CREATE UNIQUE INDEX estimates_tenant_idempotency_uidx
     ON estimates (tenant_id, idempotency_key)
     WHERE idempotency_key IS NOT NULL;
+CREATE INDEX estimates_tenant_created_idx ON estimates (tenant_id, created_at DESC, id DESC);
+CREATE INDEX estimates_tenant_sent_expiry_idx ON estimates (tenant_id, expires_at) WHERE status = 'sent';
+CREATE INDEX estimates_number_trgm_idx ON estimates USING gin (estimate_number gin_trgm_ops);
+CREATE INDEX estimates_customer_name_trgm_idx ON estimates USING gin (customer_name gin_trgm_ops);
+CREATE INDEX estimates_email_trgm_idx ON estimates USING gin (email gin_trgm_ops);
+CREATE INDEX estimates_origin_city_trgm_idx ON estimates USING gin (origin_city gin_trgm_ops);
+CREATE INDEX estimates_destination_city_trgm_idx ON estimates USING gin (destination_city gin_trgm_ops);
+
+CREATE TABLE inventory_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    default_room TEXT,
+    cubic_feet INT NOT NULL CHECK (cubic_feet >= 0),
+    weight_lbs INT NOT NULL CHECK (weight_lbs >= 0),
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by UUID REFERENCES users(id),
+    updated_by UUID REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE UNIQUE INDEX inventory_items_tenant_name_key ON inventory_items (tenant_id, lower(name));
+
+CREATE TABLE estimate_line_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    estimate_id UUID NOT NULL REFERENCES estimates(id) ON DELETE CASCADE,
+    inventory_item_id UUID REFERENCES inventory_items(id) ON DELETE SET NULL,
+    room TEXT NOT NULL,
+    item_name TEXT NOT NULL,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    cubic_feet INT NOT NULL CHECK (cubic_feet >= 0),
+    weight_lbs INT NOT NULL CHECK (weight_lbs >= 0),
+    created_by UUID REFERENCES users(id),
+    updated_by UUID REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX estimate_line_items_estimate_idx ON estimate_line_items (tenant_id, estimate_id, created_at);
+
+CREATE TABLE estimate_revisions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    estimate_id UUID NOT NULL REFERENCES estimates(id) ON DELETE CASCADE,
+    revision_number INT NOT NULL CHECK (revision_number > 0),
+    change TEXT NOT NULL CHECK (change IN ('created', 'updated', 'status_changed', 'line_items', 'priced', 'converted', 'expired', 'imported', 'restored')),
+    restored_from INT,
+    snapshot JSONB NOT NULL,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT estimate_revisions_estimate_number_key UNIQUE (estimate_id, revision_number)
+);
+CREATE INDEX estimate_revisions_tenant_estimate_idx ON estimate_revisions (tenant_id, estimate_id, revision_number);
+
+CREATE TABLE tariffs (
+    tenant_id UUID PRIMARY KEY REFERENCES tenants(id) ON DELETE CASCADE,
+    minimum_hours INT NOT NULL DEFAULT 0 CHECK (minimum_hours BETWEEN 0 AND 24),
+    minimum_charge_cents BIGINT NOT NULL DEFAULT 0 CHECK (minimum_charge_cents >= 0),
+    long_distance_threshold_miles INT NOT NULL DEFAULT 0 CHECK (long_distance_threshold_miles >= 0),
+    per_mile_cents BIGINT NOT NULL DEFAULT 0 CHECK (per_mile_cents >= 0),
+    per_cwt_cents BIGINT NOT NULL DEFAULT 0 CHECK (per_cwt_cents >= 0),
+    fuel_surcharge_bps INT NOT NULL DEFAULT 0 CHECK (fuel_surcharge_bps BETWEEN 0 AND 10000),
+    updated_by UUID REFERENCES users(id),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE tariff_crew_rates (
+    tenant_id UUID NOT NULL REFERENCES tariffs(tenant_id) ON DELETE CASCADE,
+    crew_size INT NOT NULL CHECK (crew_size BETWEEN 1 AND 20),
+    hourly_rate_cents BIGINT NOT NULL CHECK (hourly_rate_cents >= 0),
+    PRIMARY KEY (tenant_id, crew_size)
+);
+
+CREATE TABLE tariff_accessorials (
+    tenant_id UUID NOT NULL REFERENCES tariffs(tenant_id) ON DELETE CASCADE,
+    code TEXT NOT NULL,
+    name TEXT NOT NULL,
+    unit TEXT NOT NULL,
+    rate_cents BIGINT NOT NULL CHECK (rate_cents >= 0),
+    PRIMARY KEY (tenant_id, code)
+);
+
+CREATE TABLE tariff_valuation_options (
+    tenant_id UUID NOT NULL REFERENCES tariffs(tenant_id) ON DELETE CASCADE,
+    code TEXT NOT NULL,
+    name TEXT NOT NULL,
+    cents_per_thousand BIGINT NOT NULL CHECK (cents_per_thousand >= 0),
+    minimum_cents_per_lb BIGINT NOT NULL DEFAULT 0 CHECK (minimum_cents_per_lb >= 0),
+    PRIMARY KEY (tenant_id, code)
+);
+
+CREATE TABLE estimate_document_templates (
+    tenant_id UUID PRIMARY KEY REFERENCES tenants(id) ON DELETE CASCADE,
+    company_name TEXT,
+    accent_color TEXT NOT NULL DEFAULT '#1f4e79' CHECK (accent_color ~ '^#[0-9a-f]{6}$'),
+    logo BYTEA,
+    logo_content_type TEXT CHECK (logo_content_type IN ('image/png', 'image/jpeg')),
+    intro_text TEXT,
+    terms_text TEXT,
+    footer_text TEXT,
+    show_line_items BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_by UUID REFERENCES users(id),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK ((logo IS NULL) = (logo_content_type IS NULL))
+);
 
 CREATE TABLE jobs (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
@@ -127,16 +244,21 @@ CREATE TABLE jobs (
     job_number TEXT NOT NULL,
     estimate_id UUID REFERENCES estimates(id),
     customer_id UUID NOT NULL REFERENCES customers(id),
-    status TEXT NOT NULL DEFAULT 'booked' CHECK (status IN ('booked', 'scheduled', 'completed', 'cancelled')),
+    status TEXT NOT NULL DEFAULT 'booked' CHECK (status IN ('booked', 'scheduled', 'in_progress', 'on_hold', 'completed', 'cancelled')),
     scheduled_date DATE,
     pickup_time TEXT,
     convert_idempotency_key TEXT,
+    balance_cents BIGINT NOT NULL DEFAULT 0,
+    last_payment_at TIMESTAMPTZ,
     created_by UUID REFERENCES users(id),
     updated_by UUID REFERENCES users(id),
     created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
     updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
 );
 CREATE INDEX jobs_tenant_idx ON jobs (tenant_id);
+CREATE INDEX jobs_tenant_balance_idx ON jobs (tenant_id) WHERE balance_cents > 0;
+CREATE INDEX jobs_tenant_created_idx ON jobs (tenant_id, created_at DESC, id DESC);
+CREATE INDEX jobs_number_trgm_idx ON jobs USING gin (job_number gin_trgm_ops);
 CREATE UNIQUE INDEX jobs_tenant_number_uidx ON jobs (tenant_id, job_number);
 CREATE UNIQUE INDEX jobs_tenant_estimate_uidx
     ON jobs (tenant_id, estimate_id)
@@ -145,6 +267,18 @@ CREATE UNIQUE INDEX jobs_tenant_convert_idempotency_uidx
     ON jobs (tenant_id, convert_idempotency_key)
     WHERE convert_idempotency_key IS NOT NULL;
 
+CREATE TABLE job_status_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    job_id UUID NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    from_status TEXT,
+    to_status TEXT NOT NULL,
+    reason TEXT,
+    changed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX job_status_history_job_idx ON job_status_history (tenant_id, job_id, changed_at);
+
 CREATE TABLE storage_record (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
     tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
@@ -162,8 +296,8 @@ CREATE TABLE storage_record (
     oversize_items INT NOT NULL DEFAULT 0 CHECK (oversize_items >= 0),
     volume INT NOT NULL DEFAULT 0 CHECK (volume >= 0),
     monthly_rate_cents BIGINT CHECK (monthly_rate_cents IS NULL OR monthly_rate_cents >= 0),
-    storage_balance_cents BIGINT NOT NULL DEFAULT 0 CHECK (storage_balance_cents >= 0),
-    move_balance_cents BIGINT NOT NULL DEFAULT 0 CHECK (move_balance_cents >= 0),
+    storage_balance_cents BIGINT NOT NULL DEFAULT 0,
+    move_balance_cents BIGINT NOT NULL DEFAULT 0,
     last_payment_at TIMESTAMPTZ,
     notes TEXT,
     created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
@@ -174,6 +308,113 @@ CREATE INDEX storage_record_tenant_facility_idx ON storage_record (tenant_id, fa
 CREATE INDEX storage_record_tenant_balance_idx ON storage_record (tenant_id, storage_balance_cents);
 CREATE INDEX storage_record_tenant_date_in_idx ON storage_record (tenant_id, date_in);
 
+CREATE TABLE tenant_email_settings (
+    tenant_id UUID PRIMARY KEY REFERENCES tenants(id) ON DELETE CASCADE,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    from_name TEXT,
+    from_address TEXT,
+    reply_to TEXT,
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE email_templates (
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL CHECK (kind IN ('estimate_sent', 'booking_confirmation', 'storage_invoice')),
+    subject TEXT NOT NULL,
+    body TEXT NOT NULL,
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, kind)
+);
+
+CREATE TABLE email_outbox (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL CHECK (kind IN ('estimate_sent', 'booking_confirmation', 'storage_invoice')),
+    estimate_id UUID REFERENCES estimates(id) ON DELETE CASCADE,
+    job_id UUID REFERENCES jobs(id) ON DELETE CASCADE,
+    storage_record_id UUID REFERENCES storage_record(id) ON DELETE CASCADE,
+    to_address TEXT NOT NULL,
+    from_address TEXT NOT NULL,
+    from_name TEXT,
+    reply_to TEXT,
+    subject TEXT NOT NULL,
+    body TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'queued' CHECK (status IN ('queued', 'sending', 'sent', 'failed')),
+    attempts INT NOT NULL DEFAULT 0 CHECK (attempts >= 0),
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_error TEXT,
+    sent_at TIMESTAMPTZ,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (num_nonnulls(estimate_id, job_id, storage_record_id) = 1)
+);
+CREATE INDEX email_outbox_due_idx ON email_outbox (next_attempt_at) WHERE status IN ('queued', 'sending');
+CREATE INDEX email_outbox_estimate_idx ON email_outbox (estimate_id, created_at DESC) WHERE estimate_id IS NOT NULL;
+CREATE INDEX email_outbox_job_idx ON email_outbox (job_id, created_at DESC) WHERE job_id IS NOT NULL;
+CREATE INDEX email_outbox_storage_record_idx ON email_outbox (storage_record_id, created_at DESC) WHERE storage_record_id IS NOT NULL;
+
+CREATE TABLE estimate_acceptance_links (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    estimate_id UUID NOT NULL REFERENCES estimates(id) ON DELETE CASCADE,
+    auto_convert BOOLEAN NOT NULL DEFAULT FALSE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ,
+    accepted_at TIMESTAMPTZ,
+    first_viewed_at TIMESTAMPTZ,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX estimate_acceptance_links_estimate_idx ON estimate_acceptance_links (tenant_id, estimate_id, created_at DESC);
+
+CREATE TABLE estimate_signatures (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    estimate_id UUID NOT NULL REFERENCES estimates(id) ON DELETE CASCADE,
+    link_id UUID REFERENCES estimate_acceptance_links(id) ON DELETE SET NULL,
+    revision_number INT NOT NULL,
+    document_sha256 TEXT NOT NULL,
+    signer_name TEXT NOT NULL,
+    signer_ip TEXT NOT NULL,
+    user_agent TEXT NOT NULL,
+    signed_at TIMESTAMPTZ NOT NULL,
+    signature TEXT NOT NULL
+);
+CREATE INDEX estimate_signatures_estimate_idx ON estimate_signatures (tenant_id, estimate_id, signed_at DESC);
+
+CREATE TABLE ledger_entries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    job_id UUID NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    storage_record_id UUID REFERENCES storage_record(id) ON DELETE CASCADE,
+    account TEXT NOT NULL CHECK (account IN ('move', 'storage')),
+    kind TEXT NOT NULL CHECK (kind IN ('charge', 'payment', 'refund', 'adjustment')),
+    amount_cents BIGINT NOT NULL,
+    balance_effect_cents BIGINT NOT NULL GENERATED ALWAYS AS (CASE WHEN kind = 'payment' THEN -amount_cents ELSE amount_cents END) STORED,
+    method TEXT CHECK (method IN ('cash', 'check', 'card', 'ach', 'other')),
+    reference TEXT,
+    memo TEXT,
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    idempotency_key TEXT,
+    idempotency_payload_hash TEXT,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT ledger_entries_account_check CHECK ((account = 'storage') = (storage_record_id IS NOT NULL)),
+    CONSTRAINT ledger_entries_amount_check CHECK (
+        (kind = 'adjustment' AND amount_cents <> 0) OR (kind <> 'adjustment' AND amount_cents > 0)
+    ),
+    CONSTRAINT ledger_entries_method_check CHECK ((kind IN ('payment', 'refund')) = (method IS NOT NULL))
+);
+CREATE INDEX ledger_entries_job_idx ON ledger_entries (tenant_id, job_id, occurred_at, created_at);
+CREATE INDEX ledger_entries_storage_idx ON ledger_entries (tenant_id, storage_record_id, occurred_at, created_at)
+    WHERE storage_record_id IS NOT NULL;
+CREATE UNIQUE INDEX ledger_entries_tenant_idempotency_uidx
+    ON ledger_entries (tenant_id, idempotency_key)
+    WHERE idempotency_key IS NOT NULL;
+
 CREATE TABLE import_run (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
     tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
@@ -229,9 +470,129 @@ CREATE TABLE sessions (
     created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
     expires_at TIMESTAMPTZ NOT NULL,
     last_seen_at TIMESTAMPTZ,
-    revoked_at TIMESTAMPTZ
+    revoked_at TIMESTAMPTZ,
+    mfa_pending BOOLEAN NOT NULL DEFAULT FALSE,
+    user_agent TEXT NOT NULL DEFAULT '',
+    ip_address TEXT NOT NULL DEFAULT '',
+    absolute_expires_at TIMESTAMPTZ NOT NULL,
+    token_rotated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    previous_token_hash TEXT,
+    linked_user_ids UUID[] NOT NULL DEFAULT '{}'
 );
 CREATE INDEX sessions_token_active_idx ON sessions (token_hash) WHERE revoked_at IS NULL;
+CREATE INDEX sessions_user_active_idx ON sessions (user_id, tenant_id) WHERE revoked_at IS NULL;
+CREATE INDEX sessions_previous_token_idx ON sessions (previous_token_hash)
+    WHERE revoked_at IS NULL AND previous_token_hash IS NOT NULL;
+
+CREATE TABLE api_tokens (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE,
+    token_prefix TEXT NOT NULL,
+    scopes TEXT[] NOT NULL,
+    expires_at TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+    last_used_ip TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    revoked_at TIMESTAMPTZ
+);
+CREATE INDEX api_tokens_user_idx ON api_tokens (tenant_id, user_id) WHERE revoked_at IS NULL;
+
+CREATE TABLE user_login_failures (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    failed_count INTEGER NOT NULL DEFAULT 0,
+    lock_count INTEGER NOT NULL DEFAULT 0,
+    last_failed_at TIMESTAMPTZ,
+    locked_until TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE tenant_settings (
+    tenant_id UUID PRIMARY KEY REFERENCES tenants(id) ON DELETE CASCADE,
+    require_mfa_for_sensitive_roles BOOLEAN NOT NULL DEFAULT FALSE,
+    password_min_length INT NOT NULL DEFAULT 10 CHECK (password_min_length BETWEEN 8 AND 128),
+    password_check_breached BOOLEAN NOT NULL DEFAULT TRUE,
+    estimate_validity_days INT NOT NULL DEFAULT 30 CHECK (estimate_validity_days BETWEEN 1 AND 365),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE TABLE password_reset_tokens (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash TEXT NOT NULL UNIQUE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX password_reset_tokens_user_idx ON password_reset_tokens (user_id) WHERE used_at IS NULL;
+
+CREATE TABLE user_mfa (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    totp_secret TEXT NOT NULL,
+    confirmed_at TIMESTAMPTZ,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE user_mfa_recovery_codes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash TEXT NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, code_hash)
+);
+
+CREATE TABLE tenant_sso_providers (
+    tenant_id UUID PRIMARY KEY REFERENCES tenants(id) ON DELETE CASCADE,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    issuer TEXT NOT NULL,
+    client_id TEXT NOT NULL,
+    client_secret TEXT NOT NULL DEFAULT '',
+    jit_provisioning BOOLEAN NOT NULL DEFAULT TRUE,
+    default_role_id UUID REFERENCES roles(id) ON DELETE SET NULL,
+    groups_claim TEXT NOT NULL DEFAULT 'groups',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE TABLE sso_group_role_mappings (
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    group_name TEXT NOT NULL,
+    role_id UUID NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    PRIMARY KEY (tenant_id, group_name, role_id)
+);
+
+CREATE TABLE oidc_login_states (
+    state_hash TEXT PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    code_verifier TEXT NOT NULL,
+    nonce TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    consumed_at TIMESTAMPTZ
+);
+
+CREATE TABLE user_identities (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    issuer TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_login_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (tenant_id, issuer, subject)
+);
+CREATE INDEX user_identities_user_idx ON user_identities (user_id);
 
 CREATE TABLE audit_log (
     id BIGSERIAL PRIMARY KEY,
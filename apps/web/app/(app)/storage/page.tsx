@@ -582,22 +582,15 @@ export default function StoragePage() {
                     disabled={drawerSaving}
                     onChange={(value) => updateForm(setForm, "monthlyRateCents", value)}
                   />
-                  <NumberField
-                    label="Storage balance (cents)"
-                    value={form.storageBalanceCents}
-                    disabled={drawerSaving}
-                    onChange={(value) => updateForm(setForm, "storageBalanceCents", value)}
-                  />
+                  <BalanceField label="Storage balance" cents={form.storageBalanceCents} />
                 </div>
 
                 <div className="grid gap-3 md:grid-cols-2">
-                  <NumberField
-                    label="Move balance (cents)"
-                    value={form.moveBalanceCents}
-                    disabled={drawerSaving}
-                    onChange={(value) => updateForm(setForm, "moveBalanceCents", value)}
-                  />
+                  <BalanceField label="Move balance" cents={form.moveBalanceCents} />
                 </div>
+                <p className="text-xs text-muted-foreground">
+                  Balances are derived from the payments ledger; post charges, payments and adjustments there.
+                </p>
 
                 <Field label="Notes">
                   <Textarea
@@ -688,6 +681,14 @@ function NumberField({
   );
 }
 
+function BalanceField({ label, cents }: { label: string; cents: string }) {
+  return (
+    <Field label={label}>
+      <Input value={formatCurrency(Number(cents) || 0)} readOnly disabled />
+    </Field>
+  );
+}
+
 function ToggleField({
   id,
   label,
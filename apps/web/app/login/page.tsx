@@ -1,6 +1,6 @@
 "use client";
 
-import { FormEvent, useState } from "react";
+import { FormEvent, useEffect, useState } from "react";
 import { useRouter } from "next/navigation";
 import { Building2, ShieldCheck } from "lucide-react";
 import { toast } from "sonner";
@@ -10,8 +10,26 @@ import { Card, CardContent, CardDescription, CardFooter, CardHeader, CardTitle }
 import { Checkbox } from "@/components/ui/checkbox";
 import { Input } from "@/components/ui/input";
 import { Label } from "@/components/ui/label";
-import { api, primeCsrfToken } from "@/lib/api";
-type LoginRequest = { email: string; password: string };
+import { ApiError, api, primeCsrfToken, ssoStartUrl } from "@/lib/api";
+
+type LoginRequest = { email: string; password: string; tenantSlug?: string };
+type TenantOption = { id: string; slug: string; name: string };
+type LoginResponse = { mfaRequired?: boolean };
+
+const ssoErrorMessages: Record<string, string> = {
+  account_not_found: "No account exists for you in this workspace. Ask an admin to invite you.",
+  account_disabled: "Your account is disabled.",
+  email_not_verified: "Your identity provider did not confirm your email address.",
+  invalid_state: "The sign-in link expired. Please try again.",
+};
+
+function tenantOptionsFromError(err: unknown): TenantOption[] | null {
+  if (!(err instanceof ApiError) || err.code !== "tenant_selection_required") {
+    return null;
+  }
+  const tenants = (err.details as { tenants?: TenantOption[] } | undefined)?.tenants;
+  return Array.isArray(tenants) && tenants.length > 0 ? tenants : null;
+}
 
 export default function LoginPage() {
   const router = useRouter();
@@ -19,22 +37,52 @@ export default function LoginPage() {
   const [password, setPassword] = useState("Admin12345!");
   const [error, setError] = useState("");
   const [loading, setLoading] = useState(false);
+  const [tenantOptions, setTenantOptions] = useState<TenantOption[] | null>(null);
+  const [mfaRequired, setMfaRequired] = useState(false);
+  const [mfaCode, setMfaCode] = useState("");
+  const [ssoTenant, setSsoTenant] = useState("");
 
-  async function onSubmit(event: FormEvent) {
-    event.preventDefault();
+  // Single sign-on comes back here with ?ssoError=<code> on failure, or
+  // ?mfa=required when the account still needs its authenticator code.
+  useEffect(() => {
+    const params = new URLSearchParams(window.location.search);
+    const ssoError = params.get("ssoError");
+    if (ssoError) {
+      setError(ssoErrorMessages[ssoError] ?? "Single sign-on failed. Please try again.");
+    }
+    if (params.get("mfa") === "required") {
+      setMfaRequired(true);
+    }
+  }, []);
+
+  async function finishSignIn() {
+    await primeCsrfToken();
+    toast.success("Welcome back");
+    router.push("/");
+    router.refresh();
+  }
+
+  async function signIn(tenantSlug?: string) {
     setLoading(true);
     setError("");
 
     try {
-      await api.request("/auth/login", {
+      const response = await api.request<LoginResponse>("/auth/login", {
         method: "POST",
-        body: JSON.stringify({ email, password } satisfies LoginRequest),
+        body: JSON.stringify({ email, password, tenantSlug } satisfies LoginRequest),
       });
-      await primeCsrfToken();
-      toast.success("Welcome back");
-      router.push("/");
-      router.refresh();
+      if (response?.mfaRequired) {
+        setTenantOptions(null);
+        setMfaRequired(true);
+        return;
+      }
+      await finishSignIn();
     } catch (err) {
+      const options = tenantOptionsFromError(err);
+      if (options) {
+        setTenantOptions(options);
+        return;
+      }
       const message = err instanceof Error ? err.message : "Login failed";
       setError(message);
       toast.error(message);
@@ -43,6 +91,43 @@ export default function LoginPage() {
     }
   }
 
+  async function verifyCode() {
+    setLoading(true);
+    setError("");
+
+    // Codes with a dash are recovery codes; authenticator codes are digits only.
+    const code = mfaCode.trim();
+    const body = code.includes("-") ? { recoveryCode: code } : { code };
+    try {
+      await api.request(
+        "/auth/mfa/verify",
+        { method: "POST", body: JSON.stringify(body) },
+        { withCsrf: false, suppressAuthRedirect: true },
+      );
+      await finishSignIn();
+    } catch (err) {
+      const message = err instanceof Error ? err.message : "Verification failed";
+      setError(message);
+      toast.error(message);
+      if (err instanceof ApiError && (err.code === "mfa_required" || err.code === "unauthorized" || err.code === "account_locked")) {
+        setMfaRequired(false);
+        setMfaCode("");
+      }
+    } finally {
+      setLoading(false);
+    }
+  }
+
+  async function onSubmit(event: FormEvent) {
+    event.preventDefault();
+    if (mfaRequired) {
+      await verifyCode();
+      return;
+    }
+    setTenantOptions(null);
+    await signIn();
+  }
+
   return (
     <main className="relative flex min-h-screen items-center justify-center overflow-hidden px-6 py-10">
       <div className="pointer-events-none absolute inset-0 bg-[radial-gradient(circle_at_top_right,hsl(var(--primary)/0.16),transparent_36%),radial-gradient(circle_at_bottom_left,hsl(var(--accent)/0.3),transparent_32%)]" />
@@ -95,10 +180,67 @@ export default function LoginPage() {
               </p>
             ) : null}
 
-            <Button type="submit" className="w-full" disabled={loading}>
-              {loading ? "Signing in..." : "Sign in"}
-            </Button>
+            {mfaRequired ? (
+              <div className="space-y-2">
+                <Label htmlFor="mfa-code">Authentication code</Label>
+                <Input
+                  id="mfa-code"
+                  inputMode="text"
+                  autoComplete="one-time-code"
+                  placeholder="123456 or recovery code"
+                  value={mfaCode}
+                  onChange={(e) => setMfaCode(e.target.value)}
+                  autoFocus
+                  required
+                />
+                <Button type="submit" className="w-full" disabled={loading}>
+                  {loading ? "Verifying..." : "Verify"}
+                </Button>
+              </div>
+            ) : tenantOptions ? (
+              <div className="space-y-2">
+                <p className="text-sm text-muted-foreground">This account belongs to several companies. Choose one:</p>
+                {tenantOptions.map((tenant) => (
+                  <Button
+                    key={tenant.id}
+                    type="button"
+                    variant="outline"
+                    className="w-full justify-start"
+                    disabled={loading}
+                    onClick={() => void signIn(tenant.slug)}
+                  >
+                    {tenant.name}
+                  </Button>
+                ))}
+              </div>
+            ) : (
+              <Button type="submit" className="w-full" disabled={loading}>
+                {loading ? "Signing in..." : "Sign in"}
+              </Button>
+            )}
           </form>
+
+          {!mfaRequired ? (
+            <div className="mt-6 space-y-2 border-t border-border/60 pt-4">
+              <Label htmlFor="sso-tenant">Company single sign-on</Label>
+              <div className="flex gap-2">
+                <Input
+                  id="sso-tenant"
+                  placeholder="Workspace ID"
+                  value={ssoTenant}
+                  onChange={(e) => setSsoTenant(e.target.value)}
+                />
+                <Button
+                  type="button"
+                  variant="outline"
+                  disabled={!ssoTenant.trim()}
+                  onClick={() => window.location.assign(ssoStartUrl(ssoTenant.trim()))}
+                >
+                  Continue
+                </Button>
+              </div>
+            </div>
+          ) : null}
         </CardContent>
 
         <CardFooter className="justify-between border-t border-border/60 pt-4 text-xs text-muted-foreground">
@@ -160,6 +160,12 @@ export async function requestBlob(path: string, init?: RequestInit, options?: Re
   return response;
 }
 
+// ssoStartUrl is a full-page navigation target, not a fetch: the API
+// redirects the browser to the tenant's identity provider.
+export function ssoStartUrl(tenantSlug: string) {
+  return `${apiBase}/auth/oidc/${encodeURIComponent(tenantSlug)}/start`;
+}
+
 export function isUnauthorizedError(error: unknown) {
   return error instanceof ApiError && error.status === 401;
 }
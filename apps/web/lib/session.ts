@@ -3,6 +3,9 @@ import { clearCsrfToken, requestJSON } from "@/lib/api";
 export type SessionPayload = {
   user: { id: string; email: string; fullName: string };
   tenant: { id: string; slug: string; name: string };
+  permissions: string[];
+  mfaEnabled: boolean;
+  mfaEnrollmentRequired: boolean;
 };
 
 export async function getMe() {